@@ -1,12 +1,30 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"math"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	controller "github.com/egnptr/dating-app/delivery/http"
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/billing"
+	"github.com/egnptr/dating-app/pkg/entitlement"
+	"github.com/egnptr/dating-app/pkg/event"
 	router "github.com/egnptr/dating-app/pkg/http"
+	"github.com/egnptr/dating-app/pkg/mail"
+	"github.com/egnptr/dating-app/pkg/oidc"
+	"github.com/egnptr/dating-app/pkg/payment"
+	"github.com/egnptr/dating-app/pkg/receipt"
+	"github.com/egnptr/dating-app/pkg/scheduler"
+	"github.com/egnptr/dating-app/pkg/storage"
+	"github.com/egnptr/dating-app/pkg/util"
 	"github.com/egnptr/dating-app/repository/cache"
 	"github.com/egnptr/dating-app/repository/db"
 	"github.com/egnptr/dating-app/usecase"
@@ -18,25 +36,424 @@ func main() {
 		redisURL = os.Getenv("REDIS_URL")
 	}
 
+	util.SetPasswordPolicy(passwordPolicy())
+
 	var (
-		dbRepo     = db.NewSQLiteRepository()
-		cacheRepo  = cache.NewRedisCache(redisURL, 1)
-		service    = usecase.NewUsecase(dbRepo, cacheRepo)
-		delivery   = controller.NewPostController(service)
-		httpRouter = router.NewMuxRouter()
+		ctx          = context.Background()
+		dbRepo       = db.NewSQLiteRepository()
+		cacheRepo    = cache.NewRedisCache(redisURL, 1)
+		mailer       = mail.NewLogMailer()
+		events       = event.NewHub()
+		blobs, media = photoStore()
+		config       = usecaseConfig()
+		service      = usecase.NewUsecase(dbRepo, cacheRepo, mailer, oidcProviders(), paymentProvider(), receiptVerifier(config.StoreProducts, config.StoreBoostPacks), blobs, events, config)
+		delivery     = controller.NewPostController(service, trustedProxies())
+		httpRouter   = router.NewMuxRouter()
 	)
 
+	// Events published by any instance reach the users connected here
+	go events.Run(ctx, cacheRepo.SubscribeEvents(ctx))
+
+	// Periodic jobs run on one instance at a time, elected through the cache
+	jobs := scheduler.New(cacheRepo, "scheduler_leader", 10*time.Second)
+	jobs.Every("quota_resets", time.Minute, service.PublishQuotaResets)
+	jobs.Every("profile_views", 30*time.Second, func(ctx context.Context, now time.Time) error {
+		return service.FlushProfileViews(ctx)
+	})
+	jobs.Every("expire_subscriptions", time.Minute, service.ExpireSubscriptions)
+	jobs.Every("renew_subscriptions", time.Minute, service.RenewSubscriptions)
+	jobs.Every("end_grace_periods", time.Minute, service.EndGracePeriods)
+	jobs.Every("dunning_notices", 10*time.Minute, service.SendDunningNotices)
+	go jobs.Run(ctx)
+
 	const port string = ":8080"
 	httpRouter.GET("/", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintln(w, "Hello World")
 	})
 	httpRouter.POST("/user/sign-up", delivery.SignUp)
 	httpRouter.POST("/user/login", delivery.LoginUser)
+	httpRouter.POST("/user/login/2fa", delivery.LoginTwoFactor)
+	httpRouter.GET("/user/verify-email", delivery.VerifyEmail)
+	httpRouter.GET("/oauth/login", delivery.OAuthLogin)
+	httpRouter.GET("/oauth/callback", delivery.OAuthCallback)
+	httpRouter.POST("/oauth/callback", delivery.OAuthCallback)
+	httpRouter.POST("/user/change-password", delivery.Authenticate(delivery.ChangePassword))
+	httpRouter.POST("/user/change-email", delivery.Authenticate(delivery.ChangeEmail))
+	httpRouter.POST("/user/2fa/enroll", delivery.Authenticate(delivery.EnrollTwoFactor))
+	httpRouter.POST("/user/2fa/verify", delivery.Authenticate(delivery.VerifyTwoFactor))
+
+	httpRouter.POST("/subscribe-premium", delivery.Authenticate(delivery.UpdateSubscription))
+	httpRouter.POST("/unsubscribe-premium", delivery.Authenticate(delivery.UpdateSubscription))
+	httpRouter.GET("/me/subscription", delivery.Authenticate(delivery.GetSubscription))
+	httpRouter.GET("/me/entitlements", delivery.Authenticate(delivery.GetEntitlements))
+	httpRouter.POST("/payments/webhook", delivery.PaymentWebhook)
+	httpRouter.POST("/iap/receipts", delivery.Authenticate(delivery.VerifyReceipt))
+	httpRouter.POST("/iap/notifications", delivery.StoreNotification)
+	httpRouter.GET("/me/boosts", delivery.Authenticate(delivery.GetBoosts))
+	httpRouter.POST("/boosts/activate", delivery.Authenticate(delivery.ActivateBoost))
+	httpRouter.POST("/boosts/purchase", delivery.Authenticate(delivery.PurchaseBoosts))
+	httpRouter.GET("/billing/history", delivery.Authenticate(delivery.GetBillingHistory))
+	httpRouter.GET("/billing/invoice", delivery.Authenticate(delivery.GetInvoice))
+	httpRouter.POST("/promo-codes/redeem", delivery.Authenticate(delivery.RedeemPromoCode))
+	httpRouter.GET("/me/referral", delivery.Authenticate(delivery.GetReferral))
+	httpRouter.POST("/referrals/redeem", delivery.Authenticate(delivery.RedeemReferral))
+	httpRouter.GET("/related-profiles", delivery.Authenticate(delivery.GetProfiles))
+	httpRouter.GET("/profile", delivery.Authenticate(delivery.ViewProfile))
+	httpRouter.GET("/users/{id}", delivery.Authenticate(delivery.ViewProfile))
+	httpRouter.GET("/me/viewers", delivery.Authenticate(delivery.GetProfileViewers))
+	httpRouter.GET("/me/likes", delivery.Authenticate(delivery.GetLikers))
+	httpRouter.POST("/me/incognito", delivery.Authenticate(delivery.SetIncognito))
+	httpRouter.GET("/me/photos", delivery.Authenticate(delivery.GetPhotos))
+	httpRouter.POST("/me/photos", delivery.Authenticate(delivery.UploadPhoto))
+	httpRouter.POST("/me/photos/order", delivery.Authenticate(delivery.ReorderPhotos))
+	httpRouter.POST("/me/photos/primary", delivery.Authenticate(delivery.SetPrimaryPhoto))
+	httpRouter.POST("/me/photos/delete", delivery.Authenticate(delivery.DeletePhoto))
+	if media != nil {
+		httpRouter.STATIC("/media/", media)
+	}
+	httpRouter.POST("/swipe", delivery.Authenticate(delivery.Swipe))
+	httpRouter.POST("/swipe/undo", delivery.Authenticate(delivery.UndoSwipe))
+	httpRouter.POST("/block", delivery.Authenticate(delivery.BlockUser))
+	httpRouter.POST("/unblock", delivery.Authenticate(delivery.UnblockUser))
 
-	httpRouter.POST("/subscribe-premium", delivery.UpdateSubscription)
-	httpRouter.POST("/unsubscribe-premium", delivery.UpdateSubscription)
-	httpRouter.GET("/related-profiles", delivery.GetProfiles)
-	httpRouter.POST("/swipe", delivery.Swipe)
+	httpRouter.POST("/report", delivery.Authenticate(delivery.ReportUser))
+
+	httpRouter.GET("/events", delivery.Authenticate(delivery.Events))
+
+	httpRouter.GET("/conversations", delivery.Authenticate(delivery.GetConversations))
+	httpRouter.POST("/conversations", delivery.Authenticate(delivery.StartConversation))
+	httpRouter.GET("/conversations/messages", delivery.Authenticate(delivery.GetMessages))
+	httpRouter.POST("/conversations/messages", delivery.Authenticate(delivery.SendMessage))
+	httpRouter.POST("/conversations/messages/delete", delivery.Authenticate(delivery.DeleteMessage))
+	httpRouter.POST("/conversations/read", delivery.Authenticate(delivery.MarkConversationRead))
+
+	// Staff routes, moderators and up get in, admin only routes check again
+	var (
+		admin     = httpRouter.Group("/admin", delivery.Authenticate, delivery.RequireRole(model.RoleModerator))
+		adminOnly = delivery.RequireRole(model.RoleAdmin)
+	)
+	admin.GET("/reports", delivery.GetReports)
+	admin.POST("/reports/claim", delivery.ClaimReport)
+	admin.POST("/reports/resolve", delivery.ResolveReport)
+	admin.POST("/reports/escalate", delivery.EscalateReport)
+	admin.GET("/users", delivery.SearchUsers)
+	admin.GET("/users/profile", delivery.GetUserProfile)
+	admin.POST("/users/ban", delivery.BanUser)
+	admin.POST("/users/suspend", delivery.SuspendUser)
+	admin.POST("/users/shadowban", delivery.ShadowbanUser)
+	admin.POST("/users/unban", delivery.UnbanUser)
+	admin.GET("/users/status-history", delivery.GetAccountStatusHistory)
+	admin.POST("/users/logout", delivery.ForceLogout)
+	admin.POST("/users/premium", adminOnly(delivery.SetPremium))
+	admin.GET("/users/billing", adminOnly(delivery.GetUserBillingHistory))
+	admin.GET("/users/invoice", adminOnly(delivery.GetUserInvoice))
+	admin.POST("/users/role", adminOnly(delivery.SetRole))
+	admin.POST("/promo-codes", adminOnly(delivery.CreatePromoCode))
 
 	httpRouter.SERVE(port)
 }
+
+// passwordPolicy builds the password policy from the environment, keeping the
+// defaults for anything that is not set
+func passwordPolicy() util.PasswordPolicy {
+	policy := util.DefaultPasswordPolicy()
+
+	if algorithm := os.Getenv("PASSWORD_HASH_ALGORITHM"); algorithm != "" {
+		policy.Algorithm = algorithm
+	}
+	if cost, err := strconv.Atoi(os.Getenv("PASSWORD_BCRYPT_COST")); err == nil {
+		policy.BcryptCost = cost
+	}
+	if argon2Time, err := strconv.ParseUint(os.Getenv("PASSWORD_ARGON2_TIME"), 10, 32); err == nil {
+		policy.Argon2Time = uint32(argon2Time)
+	}
+	if argon2Memory, err := strconv.ParseUint(os.Getenv("PASSWORD_ARGON2_MEMORY_KIB"), 10, 32); err == nil {
+		policy.Argon2Memory = uint32(argon2Memory)
+	}
+	if minLength, err := strconv.Atoi(os.Getenv("PASSWORD_MIN_LENGTH")); err == nil {
+		policy.MinLength = minLength
+	}
+	if blocklist := os.Getenv("PASSWORD_BLOCKLIST"); blocklist != "" {
+		policy.Blocklist = strings.Split(blocklist, ",")
+	}
+
+	return policy
+}
+
+// usecaseConfig reads the settings of the usecases from the environment
+func usecaseConfig() usecase.Config {
+	config := usecase.Config{
+		ReportHideThreshold: 3,
+		AdminUserIDs:        make(map[int64]bool),
+		UndoSwipeWindow:     5 * time.Minute,
+		TrialDays:           7,
+		ReferralPremiumDays: 7,
+		GracePeriod:         72 * time.Hour,
+		Seller:              billing.Seller{Name: "Dating App"},
+		TaxName:             "VAT",
+	}
+
+	if threshold, err := strconv.Atoi(os.Getenv("REPORT_HIDE_THRESHOLD")); err == nil {
+		config.ReportHideThreshold = threshold
+	}
+	if window, err := time.ParseDuration(os.Getenv("UNDO_SWIPE_WINDOW")); err == nil {
+		config.UndoSwipeWindow = window
+	}
+	if days, err := strconv.Atoi(os.Getenv("TRIAL_DAYS")); err == nil {
+		config.TrialDays = days
+	}
+	if days, err := strconv.Atoi(os.Getenv("REFERRAL_PREMIUM_DAYS")); err == nil {
+		config.ReferralPremiumDays = days
+	}
+	if period, err := time.ParseDuration(os.Getenv("SUBSCRIPTION_GRACE_PERIOD")); err == nil {
+		config.GracePeriod = period
+	}
+	if name := os.Getenv("BILLING_SELLER_NAME"); name != "" {
+		config.Seller.Name = name
+	}
+	config.Seller.Address = strings.ReplaceAll(os.Getenv("BILLING_SELLER_ADDRESS"), `\n`, "\n")
+	config.Seller.TaxID = os.Getenv("BILLING_SELLER_TAX_ID")
+	if name := os.Getenv("BILLING_TAX_NAME"); name != "" {
+		config.TaxName = name
+	}
+	// The rate is given in percent and kept in basis points
+	if rate, err := strconv.ParseFloat(os.Getenv("BILLING_TAX_RATE"), 64); err == nil && rate >= 0 {
+		config.TaxRate = int64(math.Round(rate * 100))
+	}
+	entitlements, err := entitlement.Load(os.Getenv("ENTITLEMENTS_FILE"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	config.Entitlements = entitlements
+	config.StoreProducts = storeProducts()
+	config.StoreBoostPacks = storeBoostPacks()
+
+	for _, id := range strings.Split(os.Getenv("ADMIN_USER_IDS"), ",") {
+		if userID, err := strconv.ParseInt(strings.TrimSpace(id), 10, 64); err == nil {
+			config.AdminUserIDs[userID] = true
+		}
+	}
+
+	return config
+}
+
+// trustedProxies parses TRUSTED_PROXIES, the addresses or CIDR ranges of the
+// proxies in front of the app whose X-Forwarded-For is believed
+func trustedProxies() []*net.IPNet {
+	var proxies []*net.IPNet
+	for _, entry := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if !strings.Contains(entry, "/") {
+			if ip := net.ParseIP(entry); ip != nil && ip.To4() != nil {
+				entry += "/32"
+			} else {
+				entry += "/128"
+			}
+		}
+		_, proxy, err := net.ParseCIDR(entry)
+		if err != nil {
+			log.Println("ignoring invalid trusted proxy", entry)
+			continue
+		}
+		proxies = append(proxies, proxy)
+	}
+	return proxies
+}
+
+// defaultIssuers are used when OIDC_<NAME>_ISSUER is not set
+var defaultIssuers = map[string]string{
+	"google": "https://accounts.google.com",
+	"apple":  "https://appleid.apple.com",
+}
+
+// oidcProviders sets up every provider listed in OIDC_PROVIDERS. A provider
+// whose discovery fails is skipped so the app still starts without it
+func oidcProviders() map[string]oidc.Provider {
+	providers := make(map[string]oidc.Provider)
+
+	redirectURL := "http://localhost:8080/oauth/callback"
+	if os.Getenv("OIDC_REDIRECT_URL") != "" {
+		redirectURL = os.Getenv("OIDC_REDIRECT_URL")
+	}
+
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		config := oidc.Config{
+			Issuer:       defaultIssuers[name],
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  redirectURL,
+		}
+		if issuer := os.Getenv(prefix + "ISSUER"); issuer != "" {
+			config.Issuer = issuer
+		}
+
+		provider, err := oidc.NewProvider(context.Background(), config)
+		if err != nil {
+			log.Println("error setting up oidc provider ", name, ": ", err.Error())
+			continue
+		}
+		providers[name] = provider
+	}
+
+	return providers
+}
+
+// What the fake provider and verifier charge, in cents, for each plan by tier
+// and for each boost in a pack
+var (
+	fakePrices = map[string]map[string]int64{
+		model.TierPlus: {model.PlanMonthly: 999, model.PlanYearly: 7999},
+		model.TierGold: {model.PlanMonthly: 1999, model.PlanYearly: 15999},
+	}
+	fakeBoostPrice int64 = 299
+)
+
+// paymentProvider charges through Stripe when STRIPE_SECRET_KEY is set and
+// falls back to the fake provider, which never charges, otherwise
+func paymentProvider() payment.Provider {
+	if os.Getenv("STRIPE_SECRET_KEY") == "" {
+		log.Println("STRIPE_SECRET_KEY is not set, payments go to the fake provider")
+
+		webhookSecret := os.Getenv("PAYMENT_WEBHOOK_SECRET")
+		if webhookSecret == "" {
+			webhookSecret, _ = util.GenerateToken(32)
+		}
+		provider := payment.NewFakeProvider(webhookSecret, model.PlanPeriods)
+		provider.Prices = fakePrices
+		return provider
+	}
+
+	// Prices are read from STRIPE_PRICE_<TIER>_<PLAN>
+	prices := make(map[string]map[string]string)
+	for tier := range model.PaidTiers {
+		prices[tier] = make(map[string]string)
+		for plan := range model.PlanPeriods {
+			prices[tier][plan] = os.Getenv("STRIPE_PRICE_" + strings.ToUpper(tier) + "_" + strings.ToUpper(plan))
+		}
+	}
+
+	return payment.NewStripeProvider(payment.StripeConfig{
+		SecretKey:     os.Getenv("STRIPE_SECRET_KEY"),
+		WebhookSecret: os.Getenv("STRIPE_WEBHOOK_SECRET"),
+		Prices:        prices,
+		Currency:      os.Getenv("STRIPE_CURRENCY"),
+		SuccessURL:    os.Getenv("PAYMENT_SUCCESS_URL"),
+		CancelURL:     os.Getenv("PAYMENT_CANCEL_URL"),
+	})
+}
+
+// storeProducts reads the products sold in the stores from IAP_PRODUCTS, a
+// list of product_id=tier:plan. Without it every tier and plan is sold as
+// <tier>_<plan>
+func storeProducts() map[string]model.StoreProduct {
+	products := make(map[string]model.StoreProduct)
+	if os.Getenv("IAP_PRODUCTS") == "" {
+		for tier := range model.PaidTiers {
+			for plan := range model.PlanPeriods {
+				products[tier+"_"+plan] = model.StoreProduct{Tier: tier, Plan: plan}
+			}
+		}
+		return products
+	}
+
+	for _, entry := range strings.Split(os.Getenv("IAP_PRODUCTS"), ",") {
+		productID, product, _ := strings.Cut(strings.TrimSpace(entry), "=")
+		tier, plan, _ := strings.Cut(product, ":")
+		if _, ok := model.PlanPeriods[plan]; !ok || !model.PaidTiers[tier] || productID == "" {
+			log.Println("error skipping store product ", entry, ": tier or plan is unknown")
+			continue
+		}
+		products[productID] = model.StoreProduct{Tier: tier, Plan: plan}
+	}
+
+	return products
+}
+
+// storeBoostPacks reads the boost packs sold in the stores from
+// IAP_BOOST_PACKS, a list of product_id=boosts. Without it packs of 1 and 5
+// boosts are sold as boosts_1 and boosts_5
+func storeBoostPacks() map[string]int {
+	if os.Getenv("IAP_BOOST_PACKS") == "" {
+		return map[string]int{"boosts_1": 1, "boosts_5": 5}
+	}
+
+	packs := make(map[string]int)
+	for _, entry := range strings.Split(os.Getenv("IAP_BOOST_PACKS"), ",") {
+		productID, count, _ := strings.Cut(strings.TrimSpace(entry), "=")
+		boosts, err := strconv.Atoi(count)
+		if err != nil || boosts <= 0 || productID == "" {
+			log.Println("error skipping boost pack ", entry, ": boosts is not a positive number")
+			continue
+		}
+		packs[productID] = boosts
+	}
+
+	return packs
+}
+
+// receiptVerifier checks receipts with the fake verifier, which keeps
+// purchases in memory, until the stores are set up
+func receiptVerifier(products map[string]model.StoreProduct, boostPacks map[string]int) receipt.Verifier {
+	log.Println("store receipts go to the fake verifier")
+
+	notificationSecret := os.Getenv("IAP_NOTIFICATION_SECRET")
+	if notificationSecret == "" {
+		notificationSecret, _ = util.GenerateToken(32)
+	}
+
+	periods := make(map[string]int)
+	prices := make(map[string]int64)
+	for productID, product := range products {
+		periods[productID] = model.PlanPeriods[product.Plan]
+		prices[productID] = fakePrices[product.Tier][product.Plan]
+	}
+
+	// Boost packs are bought once and never renew
+	for productID, boosts := range boostPacks {
+		periods[productID] = 0
+		prices[productID] = int64(boosts) * fakeBoostPrice
+	}
+
+	verifier := receipt.NewFakeVerifier(notificationSecret, periods)
+	verifier.Prices = prices
+	return verifier
+}
+
+// photoStore keeps photos in a directory served by the app under /media/,
+// unless PHOTO_STORAGE asks for an S3 bucket, which clients download from
+// directly. The handler is nil then
+func photoStore() (storage.BlobStore, http.Handler) {
+	if os.Getenv("PHOTO_STORAGE") == "s3" {
+		log.Println("photos go to bucket", os.Getenv("S3_BUCKET"))
+		return storage.NewS3Store(storage.S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PublicURL:       os.Getenv("S3_PUBLIC_URL"),
+		}), nil
+	}
+
+	dir := "./photos"
+	if os.Getenv("PHOTO_DIR") != "" {
+		dir = os.Getenv("PHOTO_DIR")
+	}
+	baseURL := "http://localhost:8080/media"
+	if os.Getenv("PHOTO_BASE_URL") != "" {
+		baseURL = os.Getenv("PHOTO_BASE_URL")
+	}
+
+	local := storage.NewLocalStore(dir, baseURL)
+	return local, local.Handler()
+}
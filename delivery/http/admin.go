@@ -0,0 +1,296 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+)
+
+func (c *controller) SearchUsers(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.SearchUsersRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	query := r.URL.Query()
+	req.ActorID = userIDFromContext(ctx)
+	req.Query = query.Get("query")
+	if limit := query.Get("limit"); limit != "" {
+		var err error
+		req.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			httpStatusCode = http.StatusBadRequest
+			response.Header.Reason = http.StatusText(httpStatusCode)
+			response.Header.Messages = []string{"Error invalid query parameters"}
+			return
+		}
+	}
+
+	data, err := c.Usecase.SearchUsers(ctx, req)
+	if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error searching users"}
+		return
+	}
+
+	response.Header.Messages = []string{"Users are fetched successfully"}
+	response.Data = data
+}
+
+func (c *controller) GetUserProfile(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.AdminUserRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	userID, err := strconv.ParseInt(r.URL.Query().Get("user_id"), 10, 64)
+	if err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error invalid query parameters"}
+		return
+	}
+	req.ActorID = userIDFromContext(ctx)
+	req.UserID = userID
+
+	data, err := c.Usecase.GetUserProfile(ctx, req)
+	if err == model.NotFoundErr {
+		httpStatusCode = http.StatusNotFound
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error user is not found"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error getting user profile"}
+		return
+	}
+
+	response.Header.Messages = []string{"User profile is fetched successfully"}
+	response.Data = data
+}
+
+func (c *controller) GetAccountStatusHistory(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.AdminUserRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	userID, err := strconv.ParseInt(r.URL.Query().Get("user_id"), 10, 64)
+	if err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error invalid query parameters"}
+		return
+	}
+	req.ActorID = userIDFromContext(ctx)
+	req.UserID = userID
+
+	data, err := c.Usecase.GetAccountStatusHistory(ctx, req)
+	if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error getting account status history"}
+		return
+	}
+
+	response.Header.Messages = []string{"Account status history is fetched successfully"}
+	response.Data = data
+}
+
+func (c *controller) SetPremium(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.AdminPremiumRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unmarshaling the request"}
+		return
+	}
+	req.ActorID = userIDFromContext(ctx)
+
+	err := c.Usecase.SetPremium(ctx, req)
+	if err == model.InvalidPlanErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error tier is unknown"}
+		return
+	} else if err == model.NotFoundErr {
+		httpStatusCode = http.StatusNotFound
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error user is not found"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error updating premium status"}
+		return
+	}
+
+	response.Header.Messages = []string{"Premium status is updated successfully"}
+}
+
+func (c *controller) SetRole(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.AdminRoleRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unmarshaling the request"}
+		return
+	}
+	req.ActorID = userIDFromContext(ctx)
+
+	err := c.Usecase.SetRole(ctx, req)
+	if err == model.InvalidRoleErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error role is unknown"}
+		return
+	} else if err == model.NotFoundErr {
+		httpStatusCode = http.StatusNotFound
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error user is not found"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error updating role"}
+		return
+	}
+
+	response.Header.Messages = []string{"Role is updated successfully"}
+}
+
+func (c *controller) BanUser(w http.ResponseWriter, r *http.Request) {
+	c.manageUser(w, r, c.Usecase.BanUser, "banning user", "User is banned successfully")
+}
+
+func (c *controller) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	c.manageUser(w, r, c.Usecase.SuspendUser, "suspending user", "User is suspended successfully")
+}
+
+func (c *controller) ShadowbanUser(w http.ResponseWriter, r *http.Request) {
+	c.manageUser(w, r, c.Usecase.ShadowbanUser, "shadowbanning user", "User is shadowbanned successfully")
+}
+
+func (c *controller) UnbanUser(w http.ResponseWriter, r *http.Request) {
+	c.manageUser(w, r, c.Usecase.UnbanUser, "unbanning user", "User is unbanned successfully")
+}
+
+func (c *controller) ForceLogout(w http.ResponseWriter, r *http.Request) {
+	c.manageUser(w, r, c.Usecase.ForceLogout, "logging out user", "User is logged out successfully")
+}
+
+// manageUser handles the staff actions on a single user, which share their
+// request and errors
+func (c *controller) manageUser(w http.ResponseWriter, r *http.Request, manage func(ctx context.Context, req model.AdminUserRequest) error, action, success string) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.AdminUserRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unmarshaling the request"}
+		return
+	}
+	req.ActorID = userIDFromContext(ctx)
+
+	err := manage(ctx, req)
+	if err == model.NotFoundErr {
+		httpStatusCode = http.StatusNotFound
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error user is not found"}
+		return
+	} else if err == model.ForbiddenErr {
+		httpStatusCode = http.StatusForbidden
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error staff have to be demoted first"}
+		return
+	} else if err == model.InvalidUntilErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error suspension has to end in the future"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error " + action}
+		return
+	}
+
+	response.Header.Messages = []string{success}
+}
@@ -0,0 +1,1017 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchUsers(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SearchUsersFunc: func(ctx context.Context, req model.SearchUsersRequest) ([]model.User, error) {
+						return []model.User{{UserID: 2}}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?query=jdoe&limit=10", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid query",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SearchUsersFunc: func(ctx context.Context, req model.SearchUsersRequest) ([]model.User, error) {
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?limit=abc", nil),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SearchUsersFunc: func(ctx context.Context, req model.SearchUsersRequest) ([]model.User, error) {
+						return nil, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?query=jdoe", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.SearchUsers(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestGetUserProfile(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetUserProfileFunc: func(ctx context.Context, req model.AdminUserRequest) (model.AdminUserProfile, error) {
+						return model.AdminUserProfile{User: model.User{UserID: 2}}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?user_id=2", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid query",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetUserProfileFunc: func(ctx context.Context, req model.AdminUserRequest) (model.AdminUserProfile, error) {
+						return model.AdminUserProfile{}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?user_id=abc", nil),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetUserProfileFunc: func(ctx context.Context, req model.AdminUserRequest) (model.AdminUserProfile, error) {
+						return model.AdminUserProfile{}, model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?user_id=2", nil),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetUserProfileFunc: func(ctx context.Context, req model.AdminUserRequest) (model.AdminUserProfile, error) {
+						return model.AdminUserProfile{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?user_id=2", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.GetUserProfile(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestSetPremium(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SetPremiumFunc: func(ctx context.Context, req model.AdminPremiumRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"is_premium": true
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid tier",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SetPremiumFunc: func(ctx context.Context, req model.AdminPremiumRequest) error {
+						return model.InvalidPlanErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"is_premium": true,
+						"tier": "platinum"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SetPremiumFunc: func(ctx context.Context, req model.AdminPremiumRequest) error {
+						return model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"is_premium": true
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SetPremiumFunc: func(ctx context.Context, req model.AdminPremiumRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"is_premium": true
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.SetPremium(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestSetRole(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SetRoleFunc: func(ctx context.Context, req model.AdminRoleRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"role": "moderator"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid role",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SetRoleFunc: func(ctx context.Context, req model.AdminRoleRequest) error {
+						return model.InvalidRoleErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"role": "moderator"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SetRoleFunc: func(ctx context.Context, req model.AdminRoleRequest) error {
+						return model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"role": "moderator"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SetRoleFunc: func(ctx context.Context, req model.AdminRoleRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"role": "moderator"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.SetRole(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestBanUser(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					BanUserFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"reason": "spam"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error unmarshal",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					BanUserFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					BanUserFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+						return model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"reason": "spam"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error staff",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					BanUserFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+						return model.ForbiddenErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"reason": "spam"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 403,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					BanUserFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"reason": "spam"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.BanUser(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestUnbanUser(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					UnbanUserFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"reason": "spam"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					UnbanUserFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+						return model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"reason": "spam"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					UnbanUserFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"reason": "spam"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.UnbanUser(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestForceLogout(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ForceLogoutFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"reason": "spam"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ForceLogoutFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+						return model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"reason": "spam"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ForceLogoutFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"reason": "spam"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.ForceLogout(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestGetAccountStatusHistory(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetAccountStatusHistoryFunc: func(ctx context.Context, req model.AdminUserRequest) ([]model.AccountStatusChange, error) {
+						return []model.AccountStatusChange{{UserID: 2, Status: model.AccountStatusBanned}}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?user_id=2", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid query",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetAccountStatusHistoryFunc: func(ctx context.Context, req model.AdminUserRequest) ([]model.AccountStatusChange, error) {
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?user_id=abc", nil),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetAccountStatusHistoryFunc: func(ctx context.Context, req model.AdminUserRequest) ([]model.AccountStatusChange, error) {
+						return nil, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?user_id=2", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.GetAccountStatusHistory(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestSuspendUser(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SuspendUserFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"reason": "rude",
+						"until": "2030-01-01T00:00:00Z"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid until",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SuspendUserFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+						return model.InvalidUntilErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"reason": "spam"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error staff",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SuspendUserFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+						return model.ForbiddenErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"reason": "rude",
+						"until": "2030-01-01T00:00:00Z"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 403,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SuspendUserFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"reason": "rude",
+						"until": "2030-01-01T00:00:00Z"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.SuspendUser(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestShadowbanUser(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ShadowbanUserFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"reason": "spam"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ShadowbanUserFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+						return model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"reason": "spam"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ShadowbanUserFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"user_id": 2,
+						"reason": "spam"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.ShadowbanUser(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
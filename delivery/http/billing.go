@@ -0,0 +1,130 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+)
+
+func (c *controller) GetBillingHistory(w http.ResponseWriter, r *http.Request) {
+	c.billingHistory(w, r, userIDFromContext(r.Context()), nil)
+}
+
+// GetUserBillingHistory lets support look at the billing history of the user
+// in the user_id query parameter
+func (c *controller) GetUserBillingHistory(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseInt(r.URL.Query().Get("user_id"), 10, 64)
+	c.billingHistory(w, r, userID, err)
+}
+
+func (c *controller) billingHistory(w http.ResponseWriter, r *http.Request, userID int64, errUser error) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.BillingHistoryRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	query := r.URL.Query()
+	beforeID, errBefore := strconv.ParseInt(query.Get("before_id"), 10, 64)
+	limit, errLimit := strconv.Atoi(query.Get("limit"))
+	if errUser != nil ||
+		(query.Has("before_id") && errBefore != nil) ||
+		(query.Has("limit") && errLimit != nil) {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error invalid query parameters"}
+		return
+	}
+
+	req.UserID = userID
+	req.BeforeID = beforeID
+	req.Limit = limit
+
+	data, err := c.Usecase.GetBillingHistory(ctx, req)
+	if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error getting billing history"}
+		return
+	}
+
+	response.Header.Messages = []string{"Billing history is fetched successfully"}
+	response.Data = data
+}
+
+func (c *controller) GetInvoice(w http.ResponseWriter, r *http.Request) {
+	c.invoice(w, r, userIDFromContext(r.Context()), nil)
+}
+
+// GetUserInvoice lets support download an invoice of the user in the user_id
+// query parameter
+func (c *controller) GetUserInvoice(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.ParseInt(r.URL.Query().Get("user_id"), 10, 64)
+	c.invoice(w, r, userID, err)
+}
+
+// invoice sends the rendered invoice as a download, errors are sent as JSON
+// like everywhere else
+func (c *controller) invoice(w http.ResponseWriter, r *http.Request, userID int64, errUser error) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.InvoiceRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+		document       *model.InvoiceDocument
+	)
+
+	defer func() {
+		if document != nil {
+			w.Header().Set("Content-type", document.ContentType)
+			w.Header().Set("Content-Disposition", `attachment; filename="`+document.Filename+`"`)
+			w.WriteHeader(httpStatusCode)
+			w.Write(document.Body)
+			return
+		}
+
+		w.Header().Set("Content-type", "application/json")
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	invoiceID, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if errUser != nil || err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error invalid query parameters"}
+		return
+	}
+
+	req.UserID = userID
+	req.InvoiceID = invoiceID
+
+	data, err := c.Usecase.GetInvoice(ctx, req)
+	if err == model.NotFoundErr {
+		httpStatusCode = http.StatusNotFound
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error invoice is not found"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error getting invoice"}
+		return
+	}
+
+	document = &data
+}
@@ -0,0 +1,337 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBillingHistory(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetBillingHistoryFunc: func(ctx context.Context, req model.BillingHistoryRequest) (model.BillingHistoryResponse, error) {
+						return model.BillingHistoryResponse{Entries: []model.LedgerEntry{{ID: 1, UserID: req.UserID}}}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?before_id=10&limit=5", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid query",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetBillingHistoryFunc: func(ctx context.Context, req model.BillingHistoryRequest) (model.BillingHistoryResponse, error) {
+						return model.BillingHistoryResponse{Entries: []model.LedgerEntry{{ID: 1, UserID: req.UserID}}}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?limit=abc", nil),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetBillingHistoryFunc: func(ctx context.Context, req model.BillingHistoryRequest) (model.BillingHistoryResponse, error) {
+						return model.BillingHistoryResponse{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.GetBillingHistory(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestGetUserBillingHistory(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetBillingHistoryFunc: func(ctx context.Context, req model.BillingHistoryRequest) (model.BillingHistoryResponse, error) {
+						return model.BillingHistoryResponse{Entries: []model.LedgerEntry{{ID: 1, UserID: req.UserID}}}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?user_id=2", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid query",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetBillingHistoryFunc: func(ctx context.Context, req model.BillingHistoryRequest) (model.BillingHistoryResponse, error) {
+						return model.BillingHistoryResponse{Entries: []model.LedgerEntry{{ID: 1, UserID: req.UserID}}}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?before_id=10", nil),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetBillingHistoryFunc: func(ctx context.Context, req model.BillingHistoryRequest) (model.BillingHistoryResponse, error) {
+						return model.BillingHistoryResponse{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?user_id=2", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.GetUserBillingHistory(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestGetInvoice(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetInvoiceFunc: func(ctx context.Context, req model.InvoiceRequest) (model.InvoiceDocument, error) {
+						return model.InvoiceDocument{Filename: "INV-000001.html", ContentType: "text/html; charset=utf-8", Body: []byte("<html></html>")}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?id=1", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid query",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetInvoiceFunc: func(ctx context.Context, req model.InvoiceRequest) (model.InvoiceDocument, error) {
+						return model.InvoiceDocument{Filename: "INV-000001.html", ContentType: "text/html; charset=utf-8", Body: []byte("<html></html>")}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?id=abc", nil),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetInvoiceFunc: func(ctx context.Context, req model.InvoiceRequest) (model.InvoiceDocument, error) {
+						return model.InvoiceDocument{}, model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?id=1", nil),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetInvoiceFunc: func(ctx context.Context, req model.InvoiceRequest) (model.InvoiceDocument, error) {
+						return model.InvoiceDocument{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?id=1", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.GetInvoice(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestGetUserInvoice(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetInvoiceFunc: func(ctx context.Context, req model.InvoiceRequest) (model.InvoiceDocument, error) {
+						return model.InvoiceDocument{Filename: "INV-000001.html", ContentType: "text/html; charset=utf-8", Body: []byte("<html></html>")}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?user_id=2&id=1", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid query",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetInvoiceFunc: func(ctx context.Context, req model.InvoiceRequest) (model.InvoiceDocument, error) {
+						return model.InvoiceDocument{Filename: "INV-000001.html", ContentType: "text/html; charset=utf-8", Body: []byte("<html></html>")}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?id=1", nil),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetInvoiceFunc: func(ctx context.Context, req model.InvoiceRequest) (model.InvoiceDocument, error) {
+						return model.InvoiceDocument{}, model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?user_id=2&id=1", nil),
+			},
+			wantCode: 404,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.GetUserInvoice(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestGetInvoiceDownload(t *testing.T) {
+	c := &controller{
+		Usecase: &usecase.UsecasesMock{
+			GetInvoiceFunc: func(ctx context.Context, req model.InvoiceRequest) (model.InvoiceDocument, error) {
+				return model.InvoiceDocument{Filename: "INV-000001.html", ContentType: "text/html; charset=utf-8", Body: []byte("<html></html>")}, nil
+			},
+		},
+	}
+	w := httptest.NewRecorder()
+	c.GetInvoice(w, httptest.NewRequest(http.MethodGet, "/?id=1", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-type"))
+	assert.Equal(t, `attachment; filename="INV-000001.html"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "<html></html>", w.Body.String())
+}
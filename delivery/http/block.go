@@ -0,0 +1,94 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+)
+
+func (c *controller) BlockUser(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.BlockRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unmarshaling the request"}
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+
+	err := c.Usecase.BlockUser(ctx, req)
+	if err == model.SelfBlockErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error cannot block yourself"}
+		return
+	} else if err == model.NotFoundErr {
+		httpStatusCode = http.StatusNotFound
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error user is not found"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error blocking user"}
+		return
+	}
+
+	response.Header.Messages = []string{"User is blocked successfully"}
+}
+
+func (c *controller) UnblockUser(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.BlockRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unmarshaling the request"}
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+
+	err := c.Usecase.UnblockUser(ctx, req)
+	if err == model.NotFoundErr {
+		httpStatusCode = http.StatusNotFound
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error user is not blocked"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unblocking user"}
+		return
+	}
+
+	response.Header.Messages = []string{"User is unblocked successfully"}
+}
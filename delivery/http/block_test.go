@@ -0,0 +1,236 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockUser(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					BlockUserFunc: func(ctx context.Context, req model.BlockRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"blocked_user_id": 2
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error unmarshal",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					BlockUserFunc: func(ctx context.Context, req model.BlockRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error self block",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					BlockUserFunc: func(ctx context.Context, req model.BlockRequest) error {
+						return model.SelfBlockErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"blocked_user_id": 2
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					BlockUserFunc: func(ctx context.Context, req model.BlockRequest) error {
+						return model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"blocked_user_id": 2
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					BlockUserFunc: func(ctx context.Context, req model.BlockRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"blocked_user_id": 2
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.BlockUser(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestUnblockUser(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					UnblockUserFunc: func(ctx context.Context, req model.BlockRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"blocked_user_id": 2
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error not blocked",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					UnblockUserFunc: func(ctx context.Context, req model.BlockRequest) error {
+						return model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"blocked_user_id": 2
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					UnblockUserFunc: func(ctx context.Context, req model.BlockRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"blocked_user_id": 2
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.UnblockUser(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
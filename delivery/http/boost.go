@@ -0,0 +1,124 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+)
+
+func (c *controller) GetBoosts(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	data, err := c.Usecase.GetBoosts(ctx, userIDFromContext(ctx))
+	if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error getting boosts"}
+		return
+	}
+
+	response.Header.Messages = []string{"Boosts are fetched successfully"}
+	response.Data = data
+}
+
+func (c *controller) ActivateBoost(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	data, err := c.Usecase.ActivateBoost(ctx, userIDFromContext(ctx))
+	if err == model.BoostLimitErr {
+		httpStatusCode = http.StatusTooManyRequests
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error no boosts left"}
+		return
+	} else if err == model.BoostActiveErr {
+		httpStatusCode = http.StatusConflict
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error a boost is running already"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error activating boost"}
+		return
+	}
+
+	response.Header.Messages = []string{"Boost is activated successfully"}
+	response.Data = data
+}
+
+func (c *controller) PurchaseBoosts(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.ReceiptRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unmarshaling the request"}
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+
+	data, err := c.Usecase.PurchaseBoosts(ctx, req)
+	if err == model.InvalidReceiptErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error receipt is invalid or the store is unknown"}
+		return
+	} else if err == model.InvalidPlanErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error product is not a boost pack"}
+		return
+	} else if err == model.ReceiptInUseErr {
+		httpStatusCode = http.StatusConflict
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error receipt belongs to another user"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error purchasing boosts"}
+		return
+	}
+
+	response.Header.Messages = []string{"Boosts are purchased successfully"}
+	response.Data = data
+}
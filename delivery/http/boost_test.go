@@ -0,0 +1,302 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBoosts(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetBoostsFunc: func(ctx context.Context, userID int64) (model.BoostInventory, error) {
+						return model.BoostInventory{Monthly: 1, Purchased: 2}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetBoostsFunc: func(ctx context.Context, userID int64) (model.BoostInventory, error) {
+						return model.BoostInventory{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.GetBoosts(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestActivateBoost(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ActivateBoostFunc: func(ctx context.Context, userID int64) (model.Boost, error) {
+						return model.Boost{ID: 1, UserID: userID}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error no boosts left",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ActivateBoostFunc: func(ctx context.Context, userID int64) (model.Boost, error) {
+						return model.Boost{}, model.BoostLimitErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/", nil),
+			},
+			wantCode: 429,
+		},
+		{
+			name: "case error boost running",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ActivateBoostFunc: func(ctx context.Context, userID int64) (model.Boost, error) {
+						return model.Boost{}, model.BoostActiveErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/", nil),
+			},
+			wantCode: 409,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ActivateBoostFunc: func(ctx context.Context, userID int64) (model.Boost, error) {
+						return model.Boost{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.ActivateBoost(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestPurchaseBoosts(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					PurchaseBoostsFunc: func(ctx context.Context, req model.ReceiptRequest) (model.BoostInventory, error) {
+						return model.BoostInventory{Purchased: 5}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"store": "play", "receipt": "receipt_1"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid receipt",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					PurchaseBoostsFunc: func(ctx context.Context, req model.ReceiptRequest) (model.BoostInventory, error) {
+						return model.BoostInventory{}, model.InvalidReceiptErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"store": "play", "receipt": "receipt_1"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error not a boost pack",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					PurchaseBoostsFunc: func(ctx context.Context, req model.ReceiptRequest) (model.BoostInventory, error) {
+						return model.BoostInventory{}, model.InvalidPlanErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"store": "play", "receipt": "receipt_1"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error receipt in use",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					PurchaseBoostsFunc: func(ctx context.Context, req model.ReceiptRequest) (model.BoostInventory, error) {
+						return model.BoostInventory{}, model.ReceiptInUseErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"store": "play", "receipt": "receipt_1"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 409,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					PurchaseBoostsFunc: func(ctx context.Context, req model.ReceiptRequest) (model.BoostInventory, error) {
+						return model.BoostInventory{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"store": "play", "receipt": "receipt_1"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+		{
+			name: "case error unmarshal",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					PurchaseBoostsFunc: func(ctx context.Context, req model.ReceiptRequest) (model.BoostInventory, error) {
+						return model.BoostInventory{}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.PurchaseBoosts(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+)
+
+func (c *controller) GetConversations(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	data, err := c.Usecase.GetConversations(ctx, userIDFromContext(ctx))
+	if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error getting conversations"}
+		return
+	}
+
+	response.Header.Messages = []string{"Conversations are fetched successfully"}
+	response.Data = data
+}
+
+func (c *controller) StartConversation(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.StartConversationRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unmarshaling the request"}
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+
+	data, err := c.Usecase.StartConversation(ctx, req)
+	if err == model.NotMatchedErr {
+		httpStatusCode = http.StatusForbidden
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error users have not matched each other"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error starting conversation"}
+		return
+	}
+
+	response.Header.Messages = []string{"Conversation is started successfully"}
+	response.Data = data
+}
+
+func (c *controller) GetMessages(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.GetMessagesRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	query := r.URL.Query()
+	conversationID, errConversation := strconv.ParseInt(query.Get("conversation_id"), 10, 64)
+	beforeID, errBefore := strconv.ParseInt(query.Get("before_id"), 10, 64)
+	limit, errLimit := strconv.Atoi(query.Get("limit"))
+	if errConversation != nil ||
+		(query.Has("before_id") && errBefore != nil) ||
+		(query.Has("limit") && errLimit != nil) {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error invalid query parameters"}
+		return
+	}
+
+	req.UserID = userIDFromContext(ctx)
+	req.ConversationID = conversationID
+	req.BeforeID = beforeID
+	req.Limit = limit
+
+	data, err := c.Usecase.GetMessages(ctx, req)
+	if err == model.NotFoundErr {
+		httpStatusCode = http.StatusNotFound
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error conversation is not found"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error getting messages"}
+		return
+	}
+
+	response.Header.Messages = []string{"Messages are fetched successfully"}
+	response.Data = data
+}
+
+func (c *controller) SendMessage(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.SendMessageRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unmarshaling the request"}
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+
+	data, err := c.Usecase.SendMessage(ctx, req)
+	if err == model.InvalidMessageErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error message is empty or too long"}
+		return
+	} else if err == model.NotFoundErr {
+		httpStatusCode = http.StatusNotFound
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error conversation is not found"}
+		return
+	} else if err == model.NotMatchedErr {
+		httpStatusCode = http.StatusForbidden
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error users have not matched each other"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error sending message"}
+		return
+	}
+
+	response.Header.Messages = []string{"Message is sent successfully"}
+	response.Data = data
+}
+
+func (c *controller) DeleteMessage(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.DeleteMessageRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unmarshaling the request"}
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+
+	err := c.Usecase.DeleteMessage(ctx, req)
+	if err == model.NotFoundErr {
+		httpStatusCode = http.StatusNotFound
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error message is not found"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error deleting message"}
+		return
+	}
+
+	response.Header.Messages = []string{"Message is deleted successfully"}
+}
+
+func (c *controller) MarkConversationRead(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.MarkReadRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unmarshaling the request"}
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+
+	err := c.Usecase.MarkConversationRead(ctx, req)
+	if err == model.NotFoundErr {
+		httpStatusCode = http.StatusNotFound
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error conversation is not found"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error marking conversation as read"}
+		return
+	}
+
+	response.Header.Messages = []string{"Conversation is marked as read"}
+}
@@ -0,0 +1,574 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetConversations(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetConversationsFunc: func(ctx context.Context, userID int64) ([]model.Conversation, error) {
+						return []model.Conversation{{ID: 1, PeerID: 2, UnreadCount: 1}}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetConversationsFunc: func(ctx context.Context, userID int64) ([]model.Conversation, error) {
+						return nil, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.GetConversations(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestStartConversation(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					StartConversationFunc: func(ctx context.Context, req model.StartConversationRequest) (model.Conversation, error) {
+						return model.Conversation{ID: 1, PeerID: 2}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"recipient_id": 2
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error not matched",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					StartConversationFunc: func(ctx context.Context, req model.StartConversationRequest) (model.Conversation, error) {
+						return model.Conversation{}, model.NotMatchedErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"recipient_id": 2
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 403,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					StartConversationFunc: func(ctx context.Context, req model.StartConversationRequest) (model.Conversation, error) {
+						return model.Conversation{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"recipient_id": 2
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.StartConversation(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestGetMessages(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetMessagesFunc: func(ctx context.Context, req model.GetMessagesRequest) (model.GetMessagesResponse, error) {
+						return model.GetMessagesResponse{NextBeforeID: 5}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?conversation_id=1&before_id=10&limit=5", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid query",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetMessagesFunc: func(ctx context.Context, req model.GetMessagesRequest) (model.GetMessagesResponse, error) {
+						return model.GetMessagesResponse{}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?conversation_id=abc", nil),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetMessagesFunc: func(ctx context.Context, req model.GetMessagesRequest) (model.GetMessagesResponse, error) {
+						return model.GetMessagesResponse{}, model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?conversation_id=1", nil),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetMessagesFunc: func(ctx context.Context, req model.GetMessagesRequest) (model.GetMessagesResponse, error) {
+						return model.GetMessagesResponse{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?conversation_id=1", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.GetMessages(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestSendMessage(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SendMessageFunc: func(ctx context.Context, req model.SendMessageRequest) (model.Message, error) {
+						return model.Message{ID: 1}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"conversation_id": 1,
+						"body": "hello"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid message",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SendMessageFunc: func(ctx context.Context, req model.SendMessageRequest) (model.Message, error) {
+						return model.Message{}, model.InvalidMessageErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"conversation_id": 1,
+						"body": "hello"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SendMessageFunc: func(ctx context.Context, req model.SendMessageRequest) (model.Message, error) {
+						return model.Message{}, model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"conversation_id": 1,
+						"body": "hello"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error not matched",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SendMessageFunc: func(ctx context.Context, req model.SendMessageRequest) (model.Message, error) {
+						return model.Message{}, model.NotMatchedErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"conversation_id": 1,
+						"body": "hello"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 403,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SendMessageFunc: func(ctx context.Context, req model.SendMessageRequest) (model.Message, error) {
+						return model.Message{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"conversation_id": 1,
+						"body": "hello"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.SendMessage(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestDeleteMessage(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					DeleteMessageFunc: func(ctx context.Context, req model.DeleteMessageRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"message_id": 1
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					DeleteMessageFunc: func(ctx context.Context, req model.DeleteMessageRequest) error {
+						return model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"message_id": 1
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					DeleteMessageFunc: func(ctx context.Context, req model.DeleteMessageRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"message_id": 1
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.DeleteMessage(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestMarkConversationRead(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					MarkConversationReadFunc: func(ctx context.Context, req model.MarkReadRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"conversation_id": 1,
+						"message_id": 5
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					MarkConversationReadFunc: func(ctx context.Context, req model.MarkReadRequest) error {
+						return model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"conversation_id": 1,
+						"message_id": 5
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					MarkConversationReadFunc: func(ctx context.Context, req model.MarkReadRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"conversation_id": 1,
+						"message_id": 5
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.MarkConversationRead(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
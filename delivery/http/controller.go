@@ -2,7 +2,12 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,11 +17,16 @@ import (
 
 type controller struct {
 	Usecase usecase.Usecases
+
+	// Proxies whose X-Forwarded-For is believed. Without any, the client is
+	// whoever connected
+	TrustedProxies []*net.IPNet
 }
 
-func NewPostController(service usecase.Usecases) *controller {
+func NewPostController(service usecase.Usecases, trustedProxies []*net.IPNet) *controller {
 	return &controller{
-		Usecase: service,
+		Usecase:        service,
+		TrustedProxies: trustedProxies,
 	}
 }
 
@@ -42,9 +52,23 @@ func (c *controller) SignUp(w http.ResponseWriter, r *http.Request) {
 		response.Header.Messages = []string{"Error unmarshaling the request"}
 		return
 	}
+	// Referral links carry the code in the query
+	if user.ReferralCode == "" {
+		user.ReferralCode = r.URL.Query().Get("referral_code")
+	}
 
 	err := c.Usecase.CreateUser(ctx, user)
-	if err != nil {
+	if errors.Is(err, model.WeakPasswordErr) {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{err.Error()}
+		return
+	} else if err == model.NotFoundErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error referral code is unknown"}
+		return
+	} else if err != nil {
 		httpStatusCode = http.StatusInternalServerError
 		response.Header.Reason = http.StatusText(httpStatusCode)
 		response.Header.Messages = []string{"Error creating user"}
@@ -77,12 +101,34 @@ func (c *controller) LoginUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := c.Usecase.Login(ctx, req)
-	if err == model.UnauthorizedErr {
+	req.IPAddress = c.clientIP(r)
+
+	var (
+		lockedErr    *model.LockedError
+		suspendedErr *model.SuspendedError
+	)
+	data, err := c.Usecase.Login(ctx, req)
+	if errors.As(err, &lockedErr) {
+		httpStatusCode = http.StatusTooManyRequests
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(lockedErr.RetryAfter.Seconds()))))
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error too many failed log in attempts"}
+		return
+	} else if err == model.UnauthorizedErr {
 		httpStatusCode = http.StatusUnauthorized
 		response.Header.Reason = http.StatusText(httpStatusCode)
 		response.Header.Messages = []string{"Error unauthorized log in"}
 		return
+	} else if err == model.BannedErr {
+		httpStatusCode = http.StatusForbidden
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error account is banned"}
+		return
+	} else if errors.As(err, &suspendedErr) {
+		httpStatusCode = http.StatusForbidden
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{fmt.Sprintf("Error account is suspended until %s: %s", suspendedErr.Until.Format(time.RFC3339), suspendedErr.Reason)}
+		return
 	} else if err != nil && err != model.UnauthorizedErr {
 		httpStatusCode = http.StatusInternalServerError
 		response.Header.Reason = http.StatusText(httpStatusCode)
@@ -91,13 +137,17 @@ func (c *controller) LoginUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response.Header.Messages = []string{"Logged in successfully"}
+	if data.TwoFactorRequired {
+		response.Header.Messages = []string{"Two-factor authentication is required"}
+	}
+	response.Data = data
 }
 
-func (c *controller) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+func (c *controller) LoginTwoFactor(w http.ResponseWriter, r *http.Request) {
 	var (
 		startTime      = time.Now()
 		ctx            = r.Context()
-		req            model.SubscribeRequest
+		req            model.LoginTwoFactorRequest
 		response       responseDefault
 		httpStatusCode = http.StatusOK
 	)
@@ -116,27 +166,192 @@ func (c *controller) UpdateSubscription(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	req.Subscribe = true
-	if strings.Contains(r.URL.Path, "unsubscribe") {
-		req.Subscribe = false
+	var lockedErr *model.LockedError
+	data, err := c.Usecase.LoginTwoFactor(ctx, req)
+	if errors.As(err, &lockedErr) {
+		httpStatusCode = http.StatusTooManyRequests
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(lockedErr.RetryAfter.Seconds()))))
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error too many failed log in attempts"}
+		return
+	} else if err == model.UnauthorizedErr || err == model.InvalidTokenErr {
+		httpStatusCode = http.StatusUnauthorized
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unauthorized log in"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error logging in"}
+		return
 	}
 
-	err := c.Usecase.UpdateSubscription(ctx, req)
+	response.Header.Messages = []string{"Logged in successfully"}
+	response.Data = data
+}
+
+func (c *controller) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	data, err := c.Usecase.EnrollTwoFactor(ctx, userIDFromContext(ctx))
+	if err == model.TwoFactorEnabledErr {
+		httpStatusCode = http.StatusConflict
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error two-factor authentication is already enabled"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error enrolling two-factor authentication"}
+		return
+	}
+
+	response.Header.Messages = []string{"Scan the URI with an authenticator app and verify the first code"}
+	response.Data = data
+}
+
+func (c *controller) VerifyTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.TwoFactorVerifyRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unmarshaling the request"}
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+
+	data, err := c.Usecase.VerifyTwoFactor(ctx, req)
+	if err == model.UnauthorizedErr {
+		httpStatusCode = http.StatusUnauthorized
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error invalid two-factor code"}
+		return
+	} else if err == model.TwoFactorEnabledErr || err == model.TwoFactorNotEnrolledErr {
+		httpStatusCode = http.StatusConflict
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{err.Error()}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error verifying two-factor authentication"}
+		return
+	}
+
+	response.Header.Messages = []string{"Two-factor authentication is enabled, store the recovery codes safely"}
+	response.Data = data
+}
+
+// clientIP returns the address the request originates from. X-Forwarded-For
+// is only believed as far back as it was written by trusted proxies, anything
+// before the first untrusted hop could have been made up by the client
+func (c *controller) clientIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0 && c.trustedProxy(ip); i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			break
+		}
+		ip = hop
+	}
+	return ip
+}
+
+func (c *controller) trustedProxy(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+
+	for _, proxy := range c.TrustedProxies {
+		if proxy.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *controller) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.ChangePasswordRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unmarshaling the request"}
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+
+	err := c.Usecase.ChangePassword(ctx, req)
+	if err == model.UnauthorizedErr {
+		httpStatusCode = http.StatusUnauthorized
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error current password is incorrect"}
+		return
+	} else if errors.Is(err, model.WeakPasswordErr) {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{err.Error()}
+		return
+	} else if err != nil {
 		httpStatusCode = http.StatusInternalServerError
 		response.Header.Reason = http.StatusText(httpStatusCode)
-		response.Header.Messages = []string{"Error update subscription"}
+		response.Header.Messages = []string{"Error changing password"}
 		return
 	}
 
-	response.Header.Messages = []string{"Subscription successfully"}
+	response.Header.Messages = []string{"Password is changed successfully"}
 }
 
-func (c *controller) GetProfiles(w http.ResponseWriter, r *http.Request) {
+func (c *controller) ChangeEmail(w http.ResponseWriter, r *http.Request) {
 	var (
 		startTime      = time.Now()
 		ctx            = r.Context()
-		req            model.GetRelatedUserRequest
+		req            model.ChangeEmailRequest
 		response       responseDefault
 		httpStatusCode = http.StatusOK
 	)
@@ -154,9 +369,247 @@ func (c *controller) GetProfiles(w http.ResponseWriter, r *http.Request) {
 		response.Header.Messages = []string{"Error unmarshaling the request"}
 		return
 	}
+	req.UserID = userIDFromContext(ctx)
 
+	err := c.Usecase.ChangeEmail(ctx, req)
+	if err == model.UnauthorizedErr {
+		httpStatusCode = http.StatusUnauthorized
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error current password is incorrect"}
+		return
+	} else if err == model.InvalidEmailErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error email is not a valid address"}
+		return
+	} else if err == model.EmailTakenErr {
+		httpStatusCode = http.StatusConflict
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error email is already used by another account"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error changing email"}
+		return
+	}
+
+	response.Header.Messages = []string{"Email is changed successfully, please verify the new email"}
+}
+
+func (c *controller) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            = model.VerifyEmailRequest{Token: r.URL.Query().Get("token")}
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	err := c.Usecase.VerifyEmail(ctx, req)
+	if err == model.InvalidTokenErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error invalid or expired verification token"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error verifying email"}
+		return
+	}
+
+	response.Header.Messages = []string{"Email is verified successfully"}
+}
+
+func (c *controller) OAuthLogin(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            = model.OAuthLoginRequest{Provider: r.URL.Query().Get("provider")}
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	data, err := c.Usecase.OAuthLogin(ctx, req)
+	if err == model.NotFoundErr {
+		httpStatusCode = http.StatusNotFound
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unknown login provider"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error starting social log in"}
+		return
+	}
+
+	response.Header.Messages = []string{"Redirect to the authorization url to continue"}
+	response.Data = data
+}
+
+// OAuthCallback accepts both GET and POST, since some providers such as Apple
+// post the authorization response back as a form
+func (c *controller) OAuthCallback(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            = model.OAuthCallbackRequest{State: r.FormValue("state"), Code: r.FormValue("code")}
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	var suspendedErr *model.SuspendedError
+	data, err := c.Usecase.OAuthCallback(ctx, req)
+	if err == model.InvalidTokenErr || err == model.UnauthorizedErr {
+		httpStatusCode = http.StatusUnauthorized
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unauthorized log in"}
+		return
+	} else if err == model.EmailTakenErr {
+		httpStatusCode = http.StatusConflict
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error email is already used by another account, log in with password instead"}
+		return
+	} else if err == model.BannedErr {
+		httpStatusCode = http.StatusForbidden
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error account is banned"}
+		return
+	} else if errors.As(err, &suspendedErr) {
+		httpStatusCode = http.StatusForbidden
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{fmt.Sprintf("Error account is suspended until %s: %s", suspendedErr.Until.Format(time.RFC3339), suspendedErr.Reason)}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error logging in"}
+		return
+	}
+
+	response.Header.Messages = []string{"Logged in successfully"}
+	if data.TwoFactorRequired {
+		response.Header.Messages = []string{"Two-factor authentication is required"}
+	}
+	response.Data = data
+}
+
+func (c *controller) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.SubscribeRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	// Unsubscribing takes no body, there is only one subscription to cancel
+	req.Subscribe = !strings.Contains(r.URL.Path, "unsubscribe")
+	if req.Subscribe {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			httpStatusCode = http.StatusBadRequest
+			response.Header.Reason = http.StatusText(httpStatusCode)
+			response.Header.Messages = []string{"Error unmarshaling the request"}
+			return
+		}
+	}
+	req.UserID = userIDFromContext(ctx)
+
+	data, err := c.Usecase.UpdateSubscription(ctx, req)
+	if err == model.InvalidPlanErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error tier or plan is unknown"}
+		return
+	} else if err == model.AlreadySubscribedErr {
+		httpStatusCode = http.StatusConflict
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error already subscribed"}
+		return
+	} else if err == model.StoreManagedErr {
+		httpStatusCode = http.StatusConflict
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error subscription is managed in the store it was bought in"}
+		return
+	} else if err == model.NotFoundErr {
+		httpStatusCode = http.StatusNotFound
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error no active subscription"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error update subscription"}
+		return
+	}
+
+	response.Header.Messages = []string{"Subscription successfully"}
+	if data.CheckoutURL != "" {
+		response.Header.Messages = []string{"Checkout is created successfully"}
+		response.Data = data
+	}
+}
+
+func (c *controller) GetProfiles(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.GetRelatedUserRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	req.UserID = userIDFromContext(ctx)
+
+	var suspendedErr *model.SuspendedError
 	data, err := c.Usecase.GetProfiles(ctx, req)
-	if err != nil {
+	if err == model.BannedErr {
+		httpStatusCode = http.StatusForbidden
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error account is banned"}
+		return
+	} else if errors.As(err, &suspendedErr) {
+		httpStatusCode = http.StatusForbidden
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{fmt.Sprintf("Error account is suspended until %s: %s", suspendedErr.Until.Format(time.RFC3339), suspendedErr.Reason)}
+		return
+	} else if err != nil {
 		httpStatusCode = http.StatusInternalServerError
 		response.Header.Reason = http.StatusText(httpStatusCode)
 		response.Header.Messages = []string{"Error fetching related profiles"}
@@ -188,9 +641,31 @@ func (c *controller) Swipe(w http.ResponseWriter, r *http.Request) {
 		response.Header.Messages = []string{"Error unmarshaling the request"}
 		return
 	}
+	req.UserID = userIDFromContext(ctx)
 
+	var suspendedErr *model.SuspendedError
 	err := c.Usecase.Swipe(ctx, req)
-	if err != nil {
+	if err == model.BlockedErr {
+		httpStatusCode = http.StatusForbidden
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error profile is not available"}
+		return
+	} else if err == model.SuperLikeLimitErr {
+		httpStatusCode = http.StatusTooManyRequests
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error no super likes left for today"}
+		return
+	} else if err == model.BannedErr {
+		httpStatusCode = http.StatusForbidden
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error account is banned"}
+		return
+	} else if errors.As(err, &suspendedErr) {
+		httpStatusCode = http.StatusForbidden
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{fmt.Sprintf("Error account is suspended until %s: %s", suspendedErr.Until.Format(time.RFC3339), suspendedErr.Reason)}
+		return
+	} else if err != nil {
 		httpStatusCode = http.StatusInternalServerError
 		response.Header.Reason = http.StatusText(httpStatusCode)
 		response.Header.Messages = []string{"Error swiping profile"}
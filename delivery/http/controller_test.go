@@ -3,10 +3,13 @@ package http
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/egnptr/dating-app/model"
 	"github.com/egnptr/dating-app/usecase"
@@ -51,6 +54,80 @@ func TestSignUp(t *testing.T) {
 			},
 			wantCode: 200,
 		},
+		{
+			name: "case success referral link",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					CreateUserFunc: func(ctx context.Context, req model.User) error {
+						assert.Equal(t, "ABC123", req.ReferralCode)
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/?referral_code=ABC123", strings.NewReader(`{
+						"username": "abc",
+						"password": "test123",
+						"full_name": "test",
+						"email": "test1"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error unknown referral code",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					CreateUserFunc: func(ctx context.Context, req model.User) error {
+						return model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"username": "abc",
+						"password": "test123",
+						"full_name": "test",
+						"email": "test1",
+						"referral_code": "NOPE"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error weak password",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					CreateUserFunc: func(ctx context.Context, req model.User) error {
+						return fmt.Errorf("%w: password is too common", model.WeakPasswordErr)
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"username": "abc",
+						"password": "password",
+						"full_name": "test",
+						"email": "test1"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
 		{
 			name: "case error",
 			fields: fields{
@@ -107,8 +184,8 @@ func TestLoginUser(t *testing.T) {
 			name: "case success",
 			fields: fields{
 				service: &usecase.UsecasesMock{
-					LoginFunc: func(ctx context.Context, req model.LoginRequest) error {
-						return nil
+					LoginFunc: func(ctx context.Context, req model.LoginRequest) (model.LoginResponse, error) {
+						return model.LoginResponse{Token: "token"}, nil
 					},
 				},
 			},
@@ -125,12 +202,78 @@ func TestLoginUser(t *testing.T) {
 			},
 			wantCode: 200,
 		},
+		{
+			name: "case error locked",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					LoginFunc: func(ctx context.Context, req model.LoginRequest) (model.LoginResponse, error) {
+						return model.LoginResponse{}, &model.LockedError{RetryAfter: time.Minute}
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"username": "abc",
+						"password": "test123"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 429,
+		},
+		{
+			name: "case error banned",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					LoginFunc: func(ctx context.Context, req model.LoginRequest) (model.LoginResponse, error) {
+						return model.LoginResponse{}, model.BannedErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"username": "abc",
+						"password": "test123"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 403,
+		},
+		{
+			name: "case error suspended",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					LoginFunc: func(ctx context.Context, req model.LoginRequest) (model.LoginResponse, error) {
+						return model.LoginResponse{}, &model.SuspendedError{Reason: "rude", Until: time.Now().Add(time.Hour)}
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"username": "abc",
+						"password": "test123"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 403,
+		},
 		{
 			name: "case error",
 			fields: fields{
 				service: &usecase.UsecasesMock{
-					LoginFunc: func(ctx context.Context, req model.LoginRequest) error {
-						return errors.New("err")
+					LoginFunc: func(ctx context.Context, req model.LoginRequest) (model.LoginResponse, error) {
+						return model.LoginResponse{}, errors.New("err")
 					},
 				},
 			},
@@ -179,16 +322,19 @@ func TestUpdateSubscription(t *testing.T) {
 			name: "case success",
 			fields: fields{
 				service: &usecase.UsecasesMock{
-					UpdateSubscriptionFunc: func(ctx context.Context, req model.SubscribeRequest) error {
-						return nil
+					UpdateSubscriptionFunc: func(ctx context.Context, req model.SubscribeRequest) (model.SubscribeResponse, error) {
+						assert.Equal(t, model.SubscribeRequest{UserID: 1, Tier: model.TierGold, Plan: model.PlanYearly, Subscribe: true}, req)
+						return model.SubscribeResponse{CheckoutID: "cs_1", CheckoutURL: "https://pay.example.com/cs_1"}, nil
 					},
 				},
 			},
 			args: args{
 				w: httptest.NewRecorder(),
 				r: func() *http.Request {
-					request := httptest.NewRequest(http.MethodPost, "/unsubscribe", strings.NewReader(`{
-						"user_id": 1
+					request := httptest.NewRequest(http.MethodPost, "/subscribe", strings.NewReader(`{
+						"user_id": 3,
+						"tier": "gold",
+						"plan": "yearly"
 					}`))
 					request.Header.Set("Content-Type", "application/json")
 					return request
@@ -196,12 +342,100 @@ func TestUpdateSubscription(t *testing.T) {
 			},
 			wantCode: 200,
 		},
+		{
+			name: "case success unsubscribe",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					UpdateSubscriptionFunc: func(ctx context.Context, req model.SubscribeRequest) (model.SubscribeResponse, error) {
+						assert.Equal(t, model.SubscribeRequest{UserID: 1}, req)
+						return model.SubscribeResponse{}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/unsubscribe", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid plan",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					UpdateSubscriptionFunc: func(ctx context.Context, req model.SubscribeRequest) (model.SubscribeResponse, error) {
+						return model.SubscribeResponse{}, model.InvalidPlanErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/subscribe", strings.NewReader(`{
+						"tier": "gold"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error already subscribed",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					UpdateSubscriptionFunc: func(ctx context.Context, req model.SubscribeRequest) (model.SubscribeResponse, error) {
+						return model.SubscribeResponse{}, model.AlreadySubscribedErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/subscribe", strings.NewReader(`{
+						"tier": "gold"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 409,
+		},
+		{
+			name: "case error store managed",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					UpdateSubscriptionFunc: func(ctx context.Context, req model.SubscribeRequest) (model.SubscribeResponse, error) {
+						return model.SubscribeResponse{}, model.StoreManagedErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/unsubscribe", nil),
+			},
+			wantCode: 409,
+		},
+		{
+			name: "case error no subscription",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					UpdateSubscriptionFunc: func(ctx context.Context, req model.SubscribeRequest) (model.SubscribeResponse, error) {
+						return model.SubscribeResponse{}, model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/unsubscribe", nil),
+			},
+			wantCode: 404,
+		},
 		{
 			name: "case error",
 			fields: fields{
 				service: &usecase.UsecasesMock{
-					UpdateSubscriptionFunc: func(ctx context.Context, req model.SubscribeRequest) error {
-						return errors.New("err")
+					UpdateSubscriptionFunc: func(ctx context.Context, req model.SubscribeRequest) (model.SubscribeResponse, error) {
+						return model.SubscribeResponse{}, errors.New("err")
 					},
 				},
 			},
@@ -209,7 +443,7 @@ func TestUpdateSubscription(t *testing.T) {
 				w: httptest.NewRecorder(),
 				r: func() *http.Request {
 					request := httptest.NewRequest(http.MethodPost, "/subscribe", strings.NewReader(`{
-						"user_id": 1
+						"tier": "gold"
 					}`))
 					request.Header.Set("Content-Type", "application/json")
 					return request
@@ -223,7 +457,7 @@ func TestUpdateSubscription(t *testing.T) {
 			c := &controller{
 				Usecase: tt.fields.service,
 			}
-			c.UpdateSubscription(tt.args.w, tt.args.r)
+			c.UpdateSubscription(tt.args.w, tt.args.r.WithContext(context.WithValue(tt.args.r.Context(), userIDKey, int64(1))))
 			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
 				assert.Equal(t, tt.wantCode, recorder.Code)
 			}
@@ -249,41 +483,60 @@ func TestGetProfiles(t *testing.T) {
 			name: "case success",
 			fields: fields{
 				service: &usecase.UsecasesMock{
-					GetProfilesFunc: func(ctx context.Context, req model.GetRelatedUserRequest) ([]model.User, error) {
-						return []model.User{}, nil
+					GetProfilesFunc: func(ctx context.Context, req model.GetRelatedUserRequest) ([]model.Profile, error) {
+						assert.Equal(t, int64(1), req.UserID)
+						return []model.Profile{}, nil
 					},
 				},
 			},
 			args: args{
 				w: httptest.NewRecorder(),
-				r: func() *http.Request {
-					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
-						"user_id": 1
-					}`))
-					request.Header.Set("Content-Type", "application/json")
-					return request
-				}(),
+				r: httptest.NewRequest(http.MethodGet, "/", nil),
 			},
 			wantCode: 200,
 		},
+		{
+			name: "case error banned",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetProfilesFunc: func(ctx context.Context, req model.GetRelatedUserRequest) ([]model.Profile, error) {
+						return nil, model.BannedErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/", nil),
+			},
+			wantCode: 403,
+		},
+		{
+			name: "case error suspended",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetProfilesFunc: func(ctx context.Context, req model.GetRelatedUserRequest) ([]model.Profile, error) {
+						return nil, &model.SuspendedError{Reason: "spam", Until: time.Now().Add(time.Hour)}
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/", nil),
+			},
+			wantCode: 403,
+		},
 		{
 			name: "case error",
 			fields: fields{
 				service: &usecase.UsecasesMock{
-					GetProfilesFunc: func(ctx context.Context, req model.GetRelatedUserRequest) ([]model.User, error) {
-						return []model.User{}, errors.New("err")
+					GetProfilesFunc: func(ctx context.Context, req model.GetRelatedUserRequest) ([]model.Profile, error) {
+						return []model.Profile{}, errors.New("err")
 					},
 				},
 			},
 			args: args{
 				w: httptest.NewRecorder(),
-				r: func() *http.Request {
-					request := httptest.NewRequest(http.MethodGet, "/", strings.NewReader(`{
-						"user_id": 1
-					}`))
-					request.Header.Set("Content-Type", "application/json")
-					return request
-				}(),
+				r: httptest.NewRequest(http.MethodGet, "/", nil),
 			},
 			wantCode: 500,
 		},
@@ -293,7 +546,7 @@ func TestGetProfiles(t *testing.T) {
 			c := &controller{
 				Usecase: tt.fields.service,
 			}
-			c.GetProfiles(tt.args.w, tt.args.r)
+			c.GetProfiles(tt.args.w, tt.args.r.WithContext(context.WithValue(tt.args.r.Context(), userIDKey, int64(1))))
 			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
 				assert.Equal(t, tt.wantCode, recorder.Code)
 			}
@@ -320,6 +573,9 @@ func TestSwipe(t *testing.T) {
 			fields: fields{
 				service: &usecase.UsecasesMock{
 					SwipeFunc: func(ctx context.Context, req model.SwipeRequest) error {
+						if req.UserID != 1 {
+							return errors.New("swiped as the wrong user")
+						}
 						return nil
 					},
 				},
@@ -328,7 +584,6 @@ func TestSwipe(t *testing.T) {
 				w: httptest.NewRecorder(),
 				r: func() *http.Request {
 					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
-						"user_id": 1,
 						"swiped_user_id": 2,
 						"swipe_status": 1
 					}`))
@@ -339,11 +594,14 @@ func TestSwipe(t *testing.T) {
 			wantCode: 200,
 		},
 		{
-			name: "case error",
+			name: "case user id in body ignored",
 			fields: fields{
 				service: &usecase.UsecasesMock{
 					SwipeFunc: func(ctx context.Context, req model.SwipeRequest) error {
-						return errors.New("err")
+						if req.UserID != 1 {
+							return errors.New("swiped as the wrong user")
+						}
+						return nil
 					},
 				},
 			},
@@ -351,7 +609,7 @@ func TestSwipe(t *testing.T) {
 				w: httptest.NewRecorder(),
 				r: func() *http.Request {
 					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
-						"user_id": 1,
+						"user_id": 3,
 						"swiped_user_id": 2,
 						"swipe_status": 1
 					}`))
@@ -359,18 +617,1004 @@ func TestSwipe(t *testing.T) {
 					return request
 				}(),
 			},
-			wantCode: 500,
+			wantCode: 200,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			c := &controller{
-				Usecase: tt.fields.service,
-			}
-			c.Swipe(tt.args.w, tt.args.r)
-			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
-				assert.Equal(t, tt.wantCode, recorder.Code)
+		{
+			name: "case error blocked",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SwipeFunc: func(ctx context.Context, req model.SwipeRequest) error {
+						return model.BlockedErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"swiped_user_id": 2,
+						"swipe_status": 1
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 403,
+		},
+		{
+			name: "case error super like limit",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SwipeFunc: func(ctx context.Context, req model.SwipeRequest) error {
+						return model.SuperLikeLimitErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"swiped_user_id": 2,
+						"swipe_status": 2
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 429,
+		},
+		{
+			name: "case error suspended",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SwipeFunc: func(ctx context.Context, req model.SwipeRequest) error {
+						return &model.SuspendedError{Reason: "rude", Until: time.Now().Add(time.Hour)}
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"swiped_user_id": 2,
+						"swipe_status": 1
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 403,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SwipeFunc: func(ctx context.Context, req model.SwipeRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"swiped_user_id": 2,
+						"swipe_status": 1
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.Swipe(tt.args.w, tt.args.r.WithContext(context.WithValue(tt.args.r.Context(), userIDKey, int64(1))))
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					AuthenticateFunc: func(ctx context.Context, token string) (int64, error) {
+						return 1, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodGet, "/", nil)
+					request.Header.Set("Authorization", "Bearer token")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					AuthenticateFunc: func(ctx context.Context, token string) (int64, error) {
+						return 0, model.UnauthorizedErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/", nil),
+			},
+			wantCode: 401,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.Authenticate(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, int64(1), userIDFromContext(r.Context()))
+			})(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name     string
+		hasRole  bool
+		err      error
+		wantCode int
+	}{
+		{
+			name:     "case success",
+			hasRole:  true,
+			wantCode: 200,
+		},
+		{
+			name:     "case error missing role",
+			wantCode: 403,
+		},
+		{
+			name:     "case error user not found",
+			err:      model.NotFoundErr,
+			wantCode: 403,
+		},
+		{
+			name:     "case error",
+			err:      errors.New("err"),
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: &usecase.UsecasesMock{
+					HasRoleFunc: func(ctx context.Context, userID int64, role string) (bool, error) {
+						assert.Equal(t, model.RoleModerator, role)
+						return tt.hasRole, tt.err
+					},
+				},
+			}
+			recorder := httptest.NewRecorder()
+			c.RequireRole(model.RoleModerator)(func(w http.ResponseWriter, r *http.Request) {})(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+			assert.Equal(t, tt.wantCode, recorder.Code)
+		})
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ChangePasswordFunc: func(ctx context.Context, req model.ChangePasswordRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"current_password": "test123",
+						"new_password": "test456"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error unauthorized",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ChangePasswordFunc: func(ctx context.Context, req model.ChangePasswordRequest) error {
+						return model.UnauthorizedErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"current_password": "test123",
+						"new_password": "test456"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 401,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ChangePasswordFunc: func(ctx context.Context, req model.ChangePasswordRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"current_password": "test123",
+						"new_password": "test456"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.ChangePassword(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestChangeEmail(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ChangeEmailFunc: func(ctx context.Context, req model.ChangeEmailRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"current_password": "test123",
+						"new_email": "new@mail.com"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid email",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ChangeEmailFunc: func(ctx context.Context, req model.ChangeEmailRequest) error {
+						return model.InvalidEmailErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"current_password": "test123",
+						"new_email": "new@mail.com"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error email taken",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ChangeEmailFunc: func(ctx context.Context, req model.ChangeEmailRequest) error {
+						return model.EmailTakenErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"current_password": "test123",
+						"new_email": "new@mail.com"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 409,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ChangeEmailFunc: func(ctx context.Context, req model.ChangeEmailRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"current_password": "test123",
+						"new_email": "new@mail.com"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.ChangeEmail(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					VerifyEmailFunc: func(ctx context.Context, req model.VerifyEmailRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?token=abc", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid token",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					VerifyEmailFunc: func(ctx context.Context, req model.VerifyEmailRequest) error {
+						return model.InvalidTokenErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?token=abc", nil),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					VerifyEmailFunc: func(ctx context.Context, req model.VerifyEmailRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?token=abc", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.VerifyEmail(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestLoginTwoFactor(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					LoginTwoFactorFunc: func(ctx context.Context, req model.LoginTwoFactorRequest) (model.LoginResponse, error) {
+						return model.LoginResponse{Token: "token"}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"challenge_token": "challenge",
+						"code": "123456"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error locked",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					LoginTwoFactorFunc: func(ctx context.Context, req model.LoginTwoFactorRequest) (model.LoginResponse, error) {
+						return model.LoginResponse{}, &model.LockedError{RetryAfter: time.Minute}
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"challenge_token": "challenge",
+						"code": "123456"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 429,
+		},
+		{
+			name: "case error unauthorized",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					LoginTwoFactorFunc: func(ctx context.Context, req model.LoginTwoFactorRequest) (model.LoginResponse, error) {
+						return model.LoginResponse{}, model.UnauthorizedErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"challenge_token": "challenge",
+						"code": "123456"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 401,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					LoginTwoFactorFunc: func(ctx context.Context, req model.LoginTwoFactorRequest) (model.LoginResponse, error) {
+						return model.LoginResponse{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"challenge_token": "challenge",
+						"code": "123456"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.LoginTwoFactor(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestEnrollTwoFactor(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					EnrollTwoFactorFunc: func(ctx context.Context, userID int64) (model.TwoFactorEnrollResponse, error) {
+						return model.TwoFactorEnrollResponse{Secret: "secret"}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error already enabled",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					EnrollTwoFactorFunc: func(ctx context.Context, userID int64) (model.TwoFactorEnrollResponse, error) {
+						return model.TwoFactorEnrollResponse{}, model.TwoFactorEnabledErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/", nil),
+			},
+			wantCode: 409,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					EnrollTwoFactorFunc: func(ctx context.Context, userID int64) (model.TwoFactorEnrollResponse, error) {
+						return model.TwoFactorEnrollResponse{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.EnrollTwoFactor(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestVerifyTwoFactor(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					VerifyTwoFactorFunc: func(ctx context.Context, req model.TwoFactorVerifyRequest) (model.TwoFactorVerifyResponse, error) {
+						return model.TwoFactorVerifyResponse{RecoveryCodes: []string{"code"}}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"code": "123456"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid code",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					VerifyTwoFactorFunc: func(ctx context.Context, req model.TwoFactorVerifyRequest) (model.TwoFactorVerifyResponse, error) {
+						return model.TwoFactorVerifyResponse{}, model.UnauthorizedErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"code": "123456"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 401,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					VerifyTwoFactorFunc: func(ctx context.Context, req model.TwoFactorVerifyRequest) (model.TwoFactorVerifyResponse, error) {
+						return model.TwoFactorVerifyResponse{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"code": "123456"
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.VerifyTwoFactor(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestOAuthLogin(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					OAuthLoginFunc: func(ctx context.Context, req model.OAuthLoginRequest) (model.OAuthLoginResponse, error) {
+						return model.OAuthLoginResponse{AuthorizationURL: "https://accounts.example.com/authorize"}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?provider=google", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error unknown provider",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					OAuthLoginFunc: func(ctx context.Context, req model.OAuthLoginRequest) (model.OAuthLoginResponse, error) {
+						return model.OAuthLoginResponse{}, model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?provider=unknown", nil),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					OAuthLoginFunc: func(ctx context.Context, req model.OAuthLoginRequest) (model.OAuthLoginResponse, error) {
+						return model.OAuthLoginResponse{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?provider=google", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.OAuthLogin(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestOAuthCallback(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					OAuthCallbackFunc: func(ctx context.Context, req model.OAuthCallbackRequest) (model.LoginResponse, error) {
+						return model.LoginResponse{Token: "token"}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?state=abc&code=xyz", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid state",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					OAuthCallbackFunc: func(ctx context.Context, req model.OAuthCallbackRequest) (model.LoginResponse, error) {
+						return model.LoginResponse{}, model.InvalidTokenErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?state=abc&code=xyz", nil),
+			},
+			wantCode: 401,
+		},
+		{
+			name: "case error email taken",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					OAuthCallbackFunc: func(ctx context.Context, req model.OAuthCallbackRequest) (model.LoginResponse, error) {
+						return model.LoginResponse{}, model.EmailTakenErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?state=abc&code=xyz", nil),
+			},
+			wantCode: 409,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					OAuthCallbackFunc: func(ctx context.Context, req model.OAuthCallbackRequest) (model.LoginResponse, error) {
+						return model.LoginResponse{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?state=abc&code=xyz", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.OAuthCallback(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	_, proxies, _ := net.ParseCIDR("10.0.0.0/8")
+	c := &controller{
+		TrustedProxies: []*net.IPNet{proxies},
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		want       string
+	}{
+		{
+			name:       "case direct",
+			remoteAddr: "203.0.113.7:4321",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "case forwarded by untrusted client",
+			remoteAddr: "203.0.113.7:4321",
+			forwarded:  []string{"198.51.100.1"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "case forwarded by trusted proxy",
+			remoteAddr: "10.0.0.2:4321",
+			forwarded:  []string{"203.0.113.7"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "case forged hop before the client",
+			remoteAddr: "10.0.0.2:4321",
+			forwarded:  []string{"198.51.100.1, 203.0.113.7, 10.0.0.3"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "case hops in several headers",
+			remoteAddr: "10.0.0.2:4321",
+			forwarded:  []string{"198.51.100.1", "203.0.113.7"},
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "case only trusted proxies",
+			remoteAddr: "10.0.0.2:4321",
+			forwarded:  []string{"10.0.0.4, 10.0.0.3"},
+			want:       "10.0.0.4",
+		},
+		{
+			name:       "case garbage from trusted proxy",
+			remoteAddr: "10.0.0.2:4321",
+			forwarded:  []string{"unknown"},
+			want:       "10.0.0.2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			for _, forwarded := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", forwarded)
 			}
+			assert.Equal(t, tt.want, c.clientIP(r))
 		})
 	}
 }
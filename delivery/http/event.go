@@ -0,0 +1,121 @@
+package http
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// Time allowed to write a message to the client
+	eventWriteWait = 10 * time.Second
+
+	// Clients not answering a ping within eventPongWait are disconnected
+	eventPongWait   = 60 * time.Second
+	eventPingPeriod = eventPongWait * 9 / 10
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// Events streams the events of the logged in user over a WebSocket. Clients
+// reconnecting pass the seq of the last event they received as last_seq to
+// get what they missed replayed first
+func (c *controller) Events(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var lastSeq int64
+	if r.URL.Query().Has("last_seq") {
+		var err error
+		lastSeq, err = strconv.ParseInt(r.URL.Query().Get("last_seq"), 10, 64)
+		if err != nil {
+			http.Error(w, "Error invalid last_seq", http.StatusBadRequest)
+			return
+		}
+	}
+
+	replay, events, unsubscribe, err := c.Usecase.SubscribeEvents(ctx, model.SubscribeEventsRequest{
+		UserID:  userIDFromContext(ctx),
+		LastSeq: lastSeq,
+	})
+	if err != nil {
+		http.Error(w, "Error subscribing to events", http.StatusInternalServerError)
+		return
+	}
+	defer unsubscribe()
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader already replied to the client
+		log.Println("error upgrading websocket: ", err.Error())
+		return
+	}
+	defer conn.Close()
+
+	// Only control frames are expected from the client, reading is needed
+	// to process pongs and to notice the connection closing
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		conn.SetReadDeadline(time.Now().Add(eventPongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(eventPongWait))
+		})
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	var replayedSeq int64
+	for _, event := range replay {
+		if err := writeEvent(conn, event); err != nil {
+			return
+		}
+		replayedSeq = event.Seq
+	}
+
+	ticker := time.NewTicker(eventPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case event, ok := <-events:
+			if !ok {
+				// Too far behind, the client reconnects and replays
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too slow"),
+					time.Now().Add(eventWriteWait))
+				return
+			}
+
+			// Already sent as part of the replay
+			if event.Seq <= replayedSeq {
+				continue
+			}
+
+			if err := writeEvent(conn, event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(eventWriteWait))
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeEvent(conn *websocket.Conn, event model.Event) error {
+	conn.SetWriteDeadline(time.Now().Add(eventWriteWait))
+	return conn.WriteJSON(event)
+}
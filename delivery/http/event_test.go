@@ -0,0 +1,108 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/usecase"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvents(t *testing.T) {
+	live := make(chan model.Event, 2)
+	live <- model.Event{Seq: 2, UserID: 1, Type: model.EventTypeLiked}
+	live <- model.Event{Seq: 3, UserID: 1, Type: model.EventTypeMatched}
+
+	c := &controller{
+		Usecase: &usecase.UsecasesMock{
+			SubscribeEventsFunc: func(ctx context.Context, req model.SubscribeEventsRequest) ([]model.Event, <-chan model.Event, func(), error) {
+				assert.Equal(t, int64(1), req.UserID)
+				assert.Equal(t, int64(1), req.LastSeq)
+				return []model.Event{{Seq: 2, UserID: 1, Type: model.EventTypeLiked}}, live, func() {}, nil
+			},
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Events(w, r.WithContext(context.WithValue(r.Context(), userIDKey, int64(1))))
+	}))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/?last_seq=1", nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	// The live copy of the replayed event is skipped
+	var got []int64
+	for i := 0; i < 2; i++ {
+		var event model.Event
+		if !assert.NoError(t, conn.ReadJSON(&event)) {
+			return
+		}
+		got = append(got, event.Seq)
+	}
+	assert.Equal(t, []int64{2, 3}, got)
+}
+
+func TestEventsError(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case error invalid last seq",
+			fields: fields{
+				service: &usecase.UsecasesMock{},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?last_seq=abc", nil),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SubscribeEventsFunc: func(ctx context.Context, req model.SubscribeEventsRequest) ([]model.Event, <-chan model.Event, func(), error) {
+						return nil, nil, nil, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.Events(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+func (c *controller) GetLikers(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	data, err := c.Usecase.GetLikers(ctx, userIDFromContext(ctx))
+	if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error getting likers"}
+		return
+	}
+
+	response.Header.Messages = []string{"Likers are fetched successfully"}
+	response.Data = data
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/gorilla/websocket"
+)
+
+type contextKey int
+
+const userIDKey contextKey = iota
+
+// Authenticate resolves the bearer token of the request into a user and
+// rejects the request when there is no valid session
+func (c *controller) Authenticate(next func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+		// Browsers cannot set headers when opening a WebSocket
+		if token == "" && websocket.IsWebSocketUpgrade(r) {
+			token = r.URL.Query().Get("access_token")
+		}
+
+		userID, err := c.Usecase.Authenticate(r.Context(), token)
+		if err != nil {
+			var response responseDefault
+			httpStatusCode := http.StatusUnauthorized
+			response.Header.ProcessTime = float64(time.Since(startTime))
+			response.Header.Reason = http.StatusText(httpStatusCode)
+			response.Header.Messages = []string{"Error unauthorized request"}
+
+			w.Header().Set("Content-type", "application/json")
+			w.WriteHeader(httpStatusCode)
+			json.NewEncoder(w).Encode(response)
+			return
+		}
+
+		next(w, r.WithContext(context.WithValue(r.Context(), userIDKey, userID)))
+	}
+}
+
+func userIDFromContext(ctx context.Context) int64 {
+	userID, _ := ctx.Value(userIDKey).(int64)
+	return userID
+}
+
+// RequireRole rejects requests of authenticated users who are not granted
+// the role
+func (c *controller) RequireRole(role string) func(next func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
+	return func(next func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
+		return func(w http.ResponseWriter, r *http.Request) {
+			startTime := time.Now()
+			ok, err := c.Usecase.HasRole(r.Context(), userIDFromContext(r.Context()), role)
+			if err != nil || !ok {
+				var response responseDefault
+				httpStatusCode := http.StatusForbidden
+				response.Header.Messages = []string{"Error forbidden request"}
+				if err != nil && err != model.NotFoundErr {
+					httpStatusCode = http.StatusInternalServerError
+					response.Header.Messages = []string{"Error checking role"}
+				}
+				response.Header.ProcessTime = float64(time.Since(startTime))
+				response.Header.Reason = http.StatusText(httpStatusCode)
+
+				w.Header().Set("Content-type", "application/json")
+				w.WriteHeader(httpStatusCode)
+				json.NewEncoder(w).Encode(response)
+				return
+			}
+
+			next(w, r)
+		}
+	}
+}
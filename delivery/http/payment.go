@@ -0,0 +1,58 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+)
+
+// Webhooks and store notifications are small, anything bigger is not from
+// the payment provider or a store
+const paymentWebhookMaxBytes = 64 << 10
+
+// PaymentWebhook receives the events of the payment provider. Anything but a
+// 2xx makes the provider deliver the event again later
+func (c *controller) PaymentWebhook(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, paymentWebhookMaxBytes))
+	if err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error reading the request"}
+		return
+	}
+
+	err = c.Usecase.HandlePaymentWebhook(ctx, model.PaymentWebhookRequest{
+		Payload: payload,
+		Header:  r.Header,
+	})
+	if err == model.InvalidSignatureErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error webhook signature is invalid"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error handling webhook"}
+		return
+	}
+
+	response.Header.Messages = []string{"Webhook is handled successfully"}
+}
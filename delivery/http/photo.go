@@ -0,0 +1,240 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+)
+
+// Room the multipart encoding takes on top of the photo itself
+const photoFormOverhead = 1 << 20
+
+func (c *controller) UploadPhoto(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	r.Body = http.MaxBytesReader(w, r.Body, model.PhotoMaxBytes+photoFormOverhead)
+	body, err := readPhoto(r)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		httpStatusCode = http.StatusRequestEntityTooLarge
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error photo is too large"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error reading the photo field of the form"}
+		return
+	}
+
+	data, err := c.Usecase.UploadPhoto(ctx, model.UploadPhotoRequest{UserID: userIDFromContext(ctx), Body: body})
+	if err == model.InvalidPhotoErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error photo is not a JPEG, PNG or GIF image"}
+		return
+	} else if err == model.PhotoTooLargeErr {
+		httpStatusCode = http.StatusRequestEntityTooLarge
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error photo is too large"}
+		return
+	} else if err == model.PhotoLimitErr {
+		httpStatusCode = http.StatusConflict
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error no more photos can be added"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error uploading photo"}
+		return
+	}
+
+	response.Header.Messages = []string{"Photo is uploaded successfully"}
+	response.Data = data
+}
+
+func (c *controller) GetPhotos(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	data, err := c.Usecase.GetPhotos(ctx, userIDFromContext(ctx))
+	if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error getting photos"}
+		return
+	}
+
+	response.Header.Messages = []string{"Photos are fetched successfully"}
+	response.Data = data
+}
+
+func (c *controller) ReorderPhotos(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.ReorderPhotosRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unmarshaling the request"}
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+
+	data, err := c.Usecase.ReorderPhotos(ctx, req)
+	if err == model.InvalidPhotoOrderErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error order has to list every photo once"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error reordering photos"}
+		return
+	}
+
+	response.Header.Messages = []string{"Photos are reordered successfully"}
+	response.Data = data
+}
+
+func (c *controller) SetPrimaryPhoto(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.PhotoRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unmarshaling the request"}
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+
+	err := c.Usecase.SetPrimaryPhoto(ctx, req)
+	if err == model.NotFoundErr {
+		httpStatusCode = http.StatusNotFound
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error photo not found"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error setting primary photo"}
+		return
+	}
+
+	response.Header.Messages = []string{"Primary photo is set successfully"}
+}
+
+func (c *controller) DeletePhoto(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.PhotoRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unmarshaling the request"}
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+
+	err := c.Usecase.DeletePhoto(ctx, req)
+	if err == model.NotFoundErr {
+		httpStatusCode = http.StatusNotFound
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error photo not found"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error deleting photo"}
+		return
+	}
+
+	response.Header.Messages = []string{"Photo is deleted successfully"}
+}
+
+// readPhoto reads the photo field of a multipart form, keeping no part of
+// the form on disk
+func readPhoto(r *http.Request) (body []byte, err error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == "photo" {
+			defer part.Close()
+			return io.ReadAll(part)
+		}
+		part.Close()
+	}
+}
@@ -0,0 +1,495 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+// photoForm is a multipart request with the body as its field
+func photoForm(t *testing.T, field string, body []byte) *http.Request {
+	var buf bytes.Buffer
+	form := multipart.NewWriter(&buf)
+	part, err := form.CreateFormFile(field, "photo.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(body)
+	form.Close()
+
+	r := httptest.NewRequest(http.MethodPost, "/", &buf)
+	r.Header.Set("Content-Type", form.FormDataContentType())
+	return r
+}
+
+func TestUploadPhoto(t *testing.T) {
+	upload := func(ctx context.Context, req model.UploadPhotoRequest) (model.Photo, error) {
+		return model.Photo{ID: 1, UserID: req.UserID}, nil
+	}
+
+	tests := []struct {
+		name     string
+		service  usecase.Usecases
+		r        *http.Request
+		wantCode int
+	}{
+		{
+			name: "case success",
+			service: &usecase.UsecasesMock{
+				UploadPhotoFunc: func(ctx context.Context, req model.UploadPhotoRequest) (model.Photo, error) {
+					assert.Equal(t, []byte("image"), req.Body)
+					return model.Photo{ID: 1, UserID: req.UserID}, nil
+				},
+			},
+			r:        photoForm(t, "photo", []byte("image")),
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid photo",
+			service: &usecase.UsecasesMock{
+				UploadPhotoFunc: func(ctx context.Context, req model.UploadPhotoRequest) (model.Photo, error) {
+					return model.Photo{}, model.InvalidPhotoErr
+				},
+			},
+			r:        photoForm(t, "photo", []byte("image")),
+			wantCode: 400,
+		},
+		{
+			name: "case error photo too large",
+			service: &usecase.UsecasesMock{
+				UploadPhotoFunc: func(ctx context.Context, req model.UploadPhotoRequest) (model.Photo, error) {
+					return model.Photo{}, model.PhotoTooLargeErr
+				},
+			},
+			r:        photoForm(t, "photo", []byte("image")),
+			wantCode: 413,
+		},
+		{
+			name: "case error photo limit",
+			service: &usecase.UsecasesMock{
+				UploadPhotoFunc: func(ctx context.Context, req model.UploadPhotoRequest) (model.Photo, error) {
+					return model.Photo{}, model.PhotoLimitErr
+				},
+			},
+			r:        photoForm(t, "photo", []byte("image")),
+			wantCode: 409,
+		},
+		{
+			name: "case error",
+			service: &usecase.UsecasesMock{
+				UploadPhotoFunc: func(ctx context.Context, req model.UploadPhotoRequest) (model.Photo, error) {
+					return model.Photo{}, errors.New("err")
+				},
+			},
+			r:        photoForm(t, "photo", []byte("image")),
+			wantCode: 500,
+		},
+		{
+			name:     "case error request too large",
+			service:  &usecase.UsecasesMock{UploadPhotoFunc: upload},
+			r:        photoForm(t, "photo", make([]byte, model.PhotoMaxBytes+photoFormOverhead)),
+			wantCode: 413,
+		},
+		{
+			name:     "case error missing field",
+			service:  &usecase.UsecasesMock{UploadPhotoFunc: upload},
+			r:        photoForm(t, "image", []byte("image")),
+			wantCode: 400,
+		},
+		{
+			name:     "case error not a form",
+			service:  &usecase.UsecasesMock{UploadPhotoFunc: upload},
+			r:        httptest.NewRequest(http.MethodPost, "/", strings.NewReader("image")),
+			wantCode: 400,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.service,
+			}
+			recorder := httptest.NewRecorder()
+			c.UploadPhoto(recorder, tt.r)
+			assert.Equal(t, tt.wantCode, recorder.Code)
+		})
+	}
+}
+
+func TestGetPhotos(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetPhotosFunc: func(ctx context.Context, userID int64) ([]model.Photo, error) {
+						return []model.Photo{{ID: 1, UserID: userID, IsPrimary: true}}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetPhotosFunc: func(ctx context.Context, userID int64) ([]model.Photo, error) {
+						return nil, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.GetPhotos(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestReorderPhotos(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ReorderPhotosFunc: func(ctx context.Context, req model.ReorderPhotosRequest) ([]model.Photo, error) {
+						return []model.Photo{{ID: 2}, {ID: 1}}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"photo_ids": [2, 1]}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid order",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ReorderPhotosFunc: func(ctx context.Context, req model.ReorderPhotosRequest) ([]model.Photo, error) {
+						return nil, model.InvalidPhotoOrderErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"photo_ids": [2, 1]}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ReorderPhotosFunc: func(ctx context.Context, req model.ReorderPhotosRequest) ([]model.Photo, error) {
+						return nil, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"photo_ids": [2, 1]}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+		{
+			name: "case error unmarshal",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ReorderPhotosFunc: func(ctx context.Context, req model.ReorderPhotosRequest) ([]model.Photo, error) {
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.ReorderPhotos(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestSetPrimaryPhoto(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SetPrimaryPhotoFunc: func(ctx context.Context, req model.PhotoRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"photo_id": 1}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SetPrimaryPhotoFunc: func(ctx context.Context, req model.PhotoRequest) error {
+						return model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"photo_id": 1}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SetPrimaryPhotoFunc: func(ctx context.Context, req model.PhotoRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"photo_id": 1}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+		{
+			name: "case error unmarshal",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SetPrimaryPhotoFunc: func(ctx context.Context, req model.PhotoRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.SetPrimaryPhoto(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestDeletePhoto(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					DeletePhotoFunc: func(ctx context.Context, req model.PhotoRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"photo_id": 1}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					DeletePhotoFunc: func(ctx context.Context, req model.PhotoRequest) error {
+						return model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"photo_id": 1}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					DeletePhotoFunc: func(ctx context.Context, req model.PhotoRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"photo_id": 1}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+		{
+			name: "case error unmarshal",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					DeletePhotoFunc: func(ctx context.Context, req model.PhotoRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.DeletePhoto(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	router "github.com/egnptr/dating-app/pkg/http"
+)
+
+// ViewProfile serves both GET /users/{id} and GET /profile?user_id=
+func (c *controller) ViewProfile(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.ViewProfileRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	profileID, err := profileIDFromRequest(r)
+	if err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error invalid user id"}
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+	req.ProfileID = profileID
+
+	data, err := c.Usecase.ViewProfile(ctx, req)
+	if err == model.NotFoundErr {
+		httpStatusCode = http.StatusNotFound
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error profile is not found"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error getting profile"}
+		return
+	}
+
+	response.Header.Messages = []string{"Profile is fetched successfully"}
+	response.Data = data
+}
+
+func (c *controller) GetProfileViewers(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	data, err := c.Usecase.GetProfileViewers(ctx, userIDFromContext(ctx))
+	if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error getting profile viewers"}
+		return
+	}
+
+	response.Header.Messages = []string{"Profile viewers are fetched successfully"}
+	response.Data = data
+}
+
+func (c *controller) SetIncognito(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.IncognitoRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unmarshaling the request"}
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+
+	err := c.Usecase.SetIncognito(ctx, req)
+	if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error updating incognito mode"}
+		return
+	}
+
+	response.Header.Messages = []string{"Incognito mode is updated successfully"}
+}
+
+// profileIDFromRequest reads the viewed user from the path, falling back to
+// the user_id query parameter of the older route
+func profileIDFromRequest(r *http.Request) (int64, error) {
+	id := router.PathVar(r, "id")
+	if id == "" {
+		id = r.URL.Query().Get("user_id")
+	}
+	return strconv.ParseInt(id, 10, 64)
+}
@@ -0,0 +1,292 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/usecase"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewProfile(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ViewProfileFunc: func(ctx context.Context, req model.ViewProfileRequest) (model.Profile, error) {
+						return model.Profile{UserID: 2, FullName: "Jane Doe"}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?user_id=2", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid query",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ViewProfileFunc: func(ctx context.Context, req model.ViewProfileRequest) (model.Profile, error) {
+						return model.Profile{}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?user_id=abc", nil),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ViewProfileFunc: func(ctx context.Context, req model.ViewProfileRequest) (model.Profile, error) {
+						return model.Profile{}, model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?user_id=2", nil),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					ViewProfileFunc: func(ctx context.Context, req model.ViewProfileRequest) (model.Profile, error) {
+						return model.Profile{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/?user_id=2", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.ViewProfile(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestViewProfilePath(t *testing.T) {
+	request := func(id string) *http.Request {
+		return mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/users/"+id, nil), map[string]string{"id": id})
+	}
+
+	tests := []struct {
+		name     string
+		service  usecase.Usecases
+		r        *http.Request
+		wantCode int
+	}{
+		{
+			name: "case success",
+			service: &usecase.UsecasesMock{
+				ViewProfileFunc: func(ctx context.Context, req model.ViewProfileRequest) (model.Profile, error) {
+					assert.Equal(t, int64(2), req.ProfileID)
+					return model.Profile{UserID: 2, Relation: model.RelationStranger, FullName: "Jane Doe"}, nil
+				},
+			},
+			r:        request("2"),
+			wantCode: 200,
+		},
+		{
+			name: "case error not found",
+			service: &usecase.UsecasesMock{
+				ViewProfileFunc: func(ctx context.Context, req model.ViewProfileRequest) (model.Profile, error) {
+					return model.Profile{}, model.NotFoundErr
+				},
+			},
+			r:        request("2"),
+			wantCode: 404,
+		},
+		{
+			name: "case error",
+			service: &usecase.UsecasesMock{
+				ViewProfileFunc: func(ctx context.Context, req model.ViewProfileRequest) (model.Profile, error) {
+					return model.Profile{}, errors.New("err")
+				},
+			},
+			r:        request("2"),
+			wantCode: 500,
+		},
+		{
+			name:     "case error invalid id",
+			service:  &usecase.UsecasesMock{},
+			r:        request("me"),
+			wantCode: 400,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.service,
+			}
+			recorder := httptest.NewRecorder()
+			c.ViewProfile(recorder, tt.r)
+			assert.Equal(t, tt.wantCode, recorder.Code)
+		})
+	}
+}
+
+func TestGetProfileViewers(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetProfileViewersFunc: func(ctx context.Context, userID int64) (model.ProfileViewersResponse, error) {
+						return model.ProfileViewersResponse{Count: 3}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetProfileViewersFunc: func(ctx context.Context, userID int64) (model.ProfileViewersResponse, error) {
+						return model.ProfileViewersResponse{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.GetProfileViewers(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestSetIncognito(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SetIncognitoFunc: func(ctx context.Context, req model.IncognitoRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"incognito": true
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					SetIncognitoFunc: func(ctx context.Context, req model.IncognitoRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{
+						"incognito": true
+					}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.SetIncognito(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
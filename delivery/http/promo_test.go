@@ -0,0 +1,479 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePromoCode(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					CreatePromoCodeFunc: func(ctx context.Context, req model.CreatePromoCodeRequest) (model.PromoCode, error) {
+						return model.PromoCode{ID: 1, Code: req.Code}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"code": "SUMMER", "kind": "days", "value": 14}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					CreatePromoCodeFunc: func(ctx context.Context, req model.CreatePromoCodeRequest) (model.PromoCode, error) {
+						return model.PromoCode{}, model.InvalidPromoCodeErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"code": "SUMMER", "kind": "days", "value": 14}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error taken",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					CreatePromoCodeFunc: func(ctx context.Context, req model.CreatePromoCodeRequest) (model.PromoCode, error) {
+						return model.PromoCode{}, model.PromoCodeTakenErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"code": "SUMMER", "kind": "days", "value": 14}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 409,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					CreatePromoCodeFunc: func(ctx context.Context, req model.CreatePromoCodeRequest) (model.PromoCode, error) {
+						return model.PromoCode{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"code": "SUMMER", "kind": "days", "value": 14}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+		{
+			name: "case error unmarshal",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					CreatePromoCodeFunc: func(ctx context.Context, req model.CreatePromoCodeRequest) (model.PromoCode, error) {
+						return model.PromoCode{}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.CreatePromoCode(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestRedeemPromoCode(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					RedeemPromoCodeFunc: func(ctx context.Context, req model.RedeemRequest) (model.RedeemPromoCodeResponse, error) {
+						return model.RedeemPromoCodeResponse{Kind: model.PromoKindDays, Value: 14}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"code": "SUMMER"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error unknown code",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					RedeemPromoCodeFunc: func(ctx context.Context, req model.RedeemRequest) (model.RedeemPromoCodeResponse, error) {
+						return model.RedeemPromoCodeResponse{}, model.UnknownPromoCodeErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"code": "SUMMER"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error expired",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					RedeemPromoCodeFunc: func(ctx context.Context, req model.RedeemRequest) (model.RedeemPromoCodeResponse, error) {
+						return model.RedeemPromoCodeResponse{}, model.PromoCodeExpiredErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"code": "SUMMER"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 410,
+		},
+		{
+			name: "case error used up",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					RedeemPromoCodeFunc: func(ctx context.Context, req model.RedeemRequest) (model.RedeemPromoCodeResponse, error) {
+						return model.RedeemPromoCodeResponse{}, model.PromoCodeUsedUpErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"code": "SUMMER"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 410,
+		},
+		{
+			name: "case error redeemed",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					RedeemPromoCodeFunc: func(ctx context.Context, req model.RedeemRequest) (model.RedeemPromoCodeResponse, error) {
+						return model.RedeemPromoCodeResponse{}, model.AlreadyRedeemedErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"code": "SUMMER"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 409,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					RedeemPromoCodeFunc: func(ctx context.Context, req model.RedeemRequest) (model.RedeemPromoCodeResponse, error) {
+						return model.RedeemPromoCodeResponse{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"code": "SUMMER"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.RedeemPromoCode(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestGetReferral(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetReferralFunc: func(ctx context.Context, userID int64) (model.ReferralResponse, error) {
+						return model.ReferralResponse{Code: "ABC123"}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					GetReferralFunc: func(ctx context.Context, userID int64) (model.ReferralResponse, error) {
+						return model.ReferralResponse{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodGet, "/", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.GetReferral(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestRedeemReferral(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					RedeemReferralFunc: func(ctx context.Context, req model.RedeemRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"code": "SUMMER"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					RedeemReferralFunc: func(ctx context.Context, req model.RedeemRequest) error {
+						return model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"code": "SUMMER"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error own code",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					RedeemReferralFunc: func(ctx context.Context, req model.RedeemRequest) error {
+						return model.SelfReferralErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"code": "SUMMER"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error verified already",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					RedeemReferralFunc: func(ctx context.Context, req model.RedeemRequest) error {
+						return model.ReferralClosedErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"code": "SUMMER"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 403,
+		},
+		{
+			name: "case error referred before",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					RedeemReferralFunc: func(ctx context.Context, req model.RedeemRequest) error {
+						return model.AlreadyRedeemedErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"code": "SUMMER"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 409,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					RedeemReferralFunc: func(ctx context.Context, req model.RedeemRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"code": "SUMMER"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.RedeemReferral(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+)
+
+func (c *controller) VerifyReceipt(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.ReceiptRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unmarshaling the request"}
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+
+	data, err := c.Usecase.VerifyReceipt(ctx, req)
+	if err == model.InvalidReceiptErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error receipt is invalid or the store is unknown"}
+		return
+	} else if err == model.InvalidPlanErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error product is unknown"}
+		return
+	} else if err == model.ReceiptInUseErr {
+		httpStatusCode = http.StatusConflict
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error receipt belongs to another user"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error verifying receipt"}
+		return
+	}
+
+	response.Header.Messages = []string{"Receipt is verified successfully"}
+	response.Data = data
+}
+
+// StoreNotification receives the server notifications of the store named by
+// the store query parameter. Anything but a 2xx makes the store send the
+// notification again later
+func (c *controller) StoreNotification(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, paymentWebhookMaxBytes))
+	if err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error reading the request"}
+		return
+	}
+
+	err = c.Usecase.HandleStoreNotification(ctx, model.StoreNotificationRequest{
+		Store:   r.URL.Query().Get("store"),
+		Payload: payload,
+		Header:  r.Header,
+	})
+	if err == model.InvalidSignatureErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error notification signature is invalid"}
+		return
+	} else if err == model.InvalidReceiptErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error store is unknown"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error handling notification"}
+		return
+	}
+
+	response.Header.Messages = []string{"Notification is handled successfully"}
+}
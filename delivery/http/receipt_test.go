@@ -0,0 +1,260 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyReceipt(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					VerifyReceiptFunc: func(ctx context.Context, req model.ReceiptRequest) (model.Subscription, error) {
+						return model.Subscription{ID: 1, Store: req.Store}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"store": "app_store", "receipt": "receipt_1"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid receipt",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					VerifyReceiptFunc: func(ctx context.Context, req model.ReceiptRequest) (model.Subscription, error) {
+						return model.Subscription{}, model.InvalidReceiptErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"store": "app_store", "receipt": "receipt_1"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error unknown product",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					VerifyReceiptFunc: func(ctx context.Context, req model.ReceiptRequest) (model.Subscription, error) {
+						return model.Subscription{}, model.InvalidPlanErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"store": "app_store", "receipt": "receipt_1"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error receipt in use",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					VerifyReceiptFunc: func(ctx context.Context, req model.ReceiptRequest) (model.Subscription, error) {
+						return model.Subscription{}, model.ReceiptInUseErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"store": "app_store", "receipt": "receipt_1"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 409,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					VerifyReceiptFunc: func(ctx context.Context, req model.ReceiptRequest) (model.Subscription, error) {
+						return model.Subscription{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"store": "app_store", "receipt": "receipt_1"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+		{
+			name: "case error unmarshal",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					VerifyReceiptFunc: func(ctx context.Context, req model.ReceiptRequest) (model.Subscription, error) {
+						return model.Subscription{}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.VerifyReceipt(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestStoreNotification(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					HandleStoreNotificationFunc: func(ctx context.Context, req model.StoreNotificationRequest) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/?store=app_store", strings.NewReader(`{"id": "notification_1", "type": "renewed", "purchase_id": "purchase_1"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error invalid signature",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					HandleStoreNotificationFunc: func(ctx context.Context, req model.StoreNotificationRequest) error {
+						return model.InvalidSignatureErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/?store=app_store", strings.NewReader(`{"id": "notification_1", "type": "renewed", "purchase_id": "purchase_1"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error unknown store",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					HandleStoreNotificationFunc: func(ctx context.Context, req model.StoreNotificationRequest) error {
+						return model.InvalidReceiptErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/?store=web", strings.NewReader(`{"id": "notification_1", "type": "renewed", "purchase_id": "purchase_1"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 400,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					HandleStoreNotificationFunc: func(ctx context.Context, req model.StoreNotificationRequest) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: func() *http.Request {
+					request := httptest.NewRequest(http.MethodPost, "/?store=app_store", strings.NewReader(`{"id": "notification_1", "type": "renewed", "purchase_id": "purchase_1"}`))
+					request.Header.Set("Content-Type", "application/json")
+					return request
+				}(),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.StoreNotification(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+)
+
+func (c *controller) ReportUser(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.ReportRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unmarshaling the request"}
+		return
+	}
+	req.UserID = userIDFromContext(ctx)
+
+	data, err := c.Usecase.ReportUser(ctx, req)
+	if err == model.SelfReportErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error cannot report yourself"}
+		return
+	} else if err == model.InvalidReportErr {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error invalid report reason or detail"}
+		return
+	} else if err == model.NotFoundErr {
+		httpStatusCode = http.StatusNotFound
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error user is not found"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error reporting user"}
+		return
+	}
+
+	response.Header.Messages = []string{"User is reported successfully"}
+	response.Data = data
+}
+
+func (c *controller) GetReports(w http.ResponseWriter, r *http.Request) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.GetReportsRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	query := r.URL.Query()
+	req.Status = query.Get("status")
+	if limit := query.Get("limit"); limit != "" {
+		var err error
+		req.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			httpStatusCode = http.StatusBadRequest
+			response.Header.Reason = http.StatusText(httpStatusCode)
+			response.Header.Messages = []string{"Error invalid query parameters"}
+			return
+		}
+	}
+
+	data, err := c.Usecase.GetReports(ctx, req)
+	if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error getting reports"}
+		return
+	}
+
+	response.Header.Messages = []string{"Reports are fetched successfully"}
+	response.Data = data
+}
+
+func (c *controller) ClaimReport(w http.ResponseWriter, r *http.Request) {
+	c.reviewReport(w, r, c.Usecase.ClaimReport, "claiming")
+}
+
+func (c *controller) ResolveReport(w http.ResponseWriter, r *http.Request) {
+	c.reviewReport(w, r, c.Usecase.ResolveReport, "resolving")
+}
+
+func (c *controller) EscalateReport(w http.ResponseWriter, r *http.Request) {
+	c.reviewReport(w, r, c.Usecase.EscalateReport, "escalating")
+}
+
+// reviewReport handles the moderator actions on a report, which share their
+// request and errors
+func (c *controller) reviewReport(w http.ResponseWriter, r *http.Request, review func(ctx context.Context, req model.ReviewReportRequest) error, action string) {
+	var (
+		startTime      = time.Now()
+		ctx            = r.Context()
+		req            model.ReviewReportRequest
+		response       responseDefault
+		httpStatusCode = http.StatusOK
+	)
+
+	defer func() {
+		response.Header.ProcessTime = float64(time.Since(startTime))
+		w.WriteHeader(httpStatusCode)
+		json.NewEncoder(w).Encode(response)
+	}()
+
+	w.Header().Set("Content-type", "application/json")
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpStatusCode = http.StatusBadRequest
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error unmarshaling the request"}
+		return
+	}
+	req.ModeratorID = userIDFromContext(ctx)
+
+	err := review(ctx, req)
+	if err == model.NotFoundErr {
+		httpStatusCode = http.StatusNotFound
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error report is not found"}
+		return
+	} else if err == model.ReportConflictErr {
+		httpStatusCode = http.StatusConflict
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error report is claimed by someone else or already closed"}
+		return
+	} else if err == model.ForbiddenErr {
+		httpStatusCode = http.StatusForbidden
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error escalated reports can only be claimed by admins"}
+		return
+	} else if err != nil {
+		httpStatusCode = http.StatusInternalServerError
+		response.Header.Reason = http.StatusText(httpStatusCode)
+		response.Header.Messages = []string{"Error " + action + " report"}
+		return
+	}
+
+	response.Header.Messages = []string{"Report is updated successfully"}
+}
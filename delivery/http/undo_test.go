@@ -0,0 +1,131 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUndoSwipe(t *testing.T) {
+	type fields struct {
+		service usecase.Usecases
+	}
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		args     args
+		wantCode int
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					UndoSwipeFunc: func(ctx context.Context, req model.UndoSwipeRequest) (model.Swipe, error) {
+						return model.Swipe{SwipedID: 2, SwipeStatus: model.SwipeStatusPass}, nil
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/", nil),
+			},
+			wantCode: 200,
+		},
+		{
+			name: "case error not premium",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					UndoSwipeFunc: func(ctx context.Context, req model.UndoSwipeRequest) (model.Swipe, error) {
+						return model.Swipe{}, model.PremiumRequiredErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/", nil),
+			},
+			wantCode: 403,
+		},
+		{
+			name: "case error rewind limit",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					UndoSwipeFunc: func(ctx context.Context, req model.UndoSwipeRequest) (model.Swipe, error) {
+						return model.Swipe{}, model.RewindLimitErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/", nil),
+			},
+			wantCode: 429,
+		},
+		{
+			name: "case error no swipe",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					UndoSwipeFunc: func(ctx context.Context, req model.UndoSwipeRequest) (model.Swipe, error) {
+						return model.Swipe{}, model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/", nil),
+			},
+			wantCode: 404,
+		},
+		{
+			name: "case error window passed",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					UndoSwipeFunc: func(ctx context.Context, req model.UndoSwipeRequest) (model.Swipe, error) {
+						return model.Swipe{}, model.UndoExpiredErr
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/", nil),
+			},
+			wantCode: 409,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				service: &usecase.UsecasesMock{
+					UndoSwipeFunc: func(ctx context.Context, req model.UndoSwipeRequest) (model.Swipe, error) {
+						return model.Swipe{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				w: httptest.NewRecorder(),
+				r: httptest.NewRequest(http.MethodPost, "/", nil),
+			},
+			wantCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &controller{
+				Usecase: tt.fields.service,
+			}
+			c.UndoSwipe(tt.args.w, tt.args.r)
+			if recorder, ok := tt.args.w.(*httptest.ResponseRecorder); ok && recorder != nil {
+				assert.Equal(t, tt.wantCode, recorder.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package model
+
+import "time"
+
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// roleRanks orders the roles, every role is granted what the roles ranked
+// below it are
+var roleRanks = map[string]int{
+	RoleUser:      0,
+	RoleModerator: 1,
+	RoleAdmin:     2,
+}
+
+func IsRole(role string) bool {
+	_, ok := roleRanks[role]
+	return ok
+}
+
+const (
+	AccountStatusActive = "active"
+	// Cannot log in until the suspension ends
+	AccountStatusSuspended = "suspended"
+	// Cannot log in anymore
+	AccountStatusBanned = "banned"
+	// Can still use the app, but is never shown to anyone and never matches
+	AccountStatusShadowbanned = "shadowbanned"
+)
+
+// AccountStatusChange is an entry of the account status history of a user
+type AccountStatusChange struct {
+	ID        int64      `json:"id,omitempty"`
+	UserID    int64      `json:"user_id"`
+	Status    string     `json:"status"`
+	Reason    string     `json:"reason,omitempty"`
+	Until     *time.Time `json:"until,omitempty"`
+	ActorID   int64      `json:"actor_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at,omitempty"`
+}
+
+type SearchUsersRequest struct {
+	ActorID int64  `json:"-"`
+	Query   string `json:"query"`
+	Limit   int    `json:"limit"`
+}
+
+// AdminUserRequest is used for the staff actions targeting a single user
+type AdminUserRequest struct {
+	ActorID int64  `json:"-"`
+	UserID  int64  `json:"user_id"`
+	Reason  string `json:"reason"`
+
+	// End of a suspension
+	Until *time.Time `json:"until,omitempty"`
+}
+
+type AdminPremiumRequest struct {
+	ActorID   int64  `json:"-"`
+	UserID    int64  `json:"user_id"`
+	IsPremium bool   `json:"is_premium"`
+	Tier      string `json:"tier"`
+}
+
+type AdminRoleRequest struct {
+	ActorID int64  `json:"-"`
+	UserID  int64  `json:"user_id"`
+	Role    string `json:"role"`
+}
+
+type SwipeStats struct {
+	LikesGiven    int64 `json:"likes_given"`
+	PassesGiven   int64 `json:"passes_given"`
+	LikesReceived int64 `json:"likes_received"`
+	Matches       int64 `json:"matches"`
+}
+
+type AdminUserProfile struct {
+	User       User       `json:"user"`
+	SwipeStats SwipeStats `json:"swipe_stats"`
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+const (
+	AuditActionPasswordChanged = "password_changed"
+	AuditActionEmailChanged    = "email_changed"
+	AuditActionTwoFactorOn     = "two_factor_enabled"
+	AuditActionIdentityLinked  = "identity_linked"
+
+	// Actions taken by staff through the admin api
+	AuditActionUsersSearched   = "admin_users_searched"
+	AuditActionProfileViewed   = "admin_profile_viewed"
+	AuditActionHistoryViewed   = "admin_status_history_viewed"
+	AuditActionPremiumChanged  = "admin_premium_changed"
+	AuditActionBanned          = "admin_banned"
+	AuditActionSuspended       = "admin_suspended"
+	AuditActionShadowbanned    = "admin_shadowbanned"
+	AuditActionUnbanned        = "admin_unbanned"
+	AuditActionLoggedOut       = "admin_logged_out"
+	AuditActionRoleChanged     = "admin_role_changed"
+	AuditActionReportClaimed   = "admin_report_claimed"
+	AuditActionReportResolved  = "admin_report_resolved"
+	AuditActionReportEscalated = "admin_report_escalated"
+)
+
+type AuditLog struct {
+	ID        int64     `json:"id,omitempty"`
+	UserID    int64     `json:"user_id"`
+	ActorID   int64     `json:"actor_id"`
+	Action    string    `json:"action"`
+	Detail    string    `json:"detail,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
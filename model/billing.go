@@ -0,0 +1,98 @@
+package model
+
+import "time"
+
+// Kinds of ledger entries. Amounts are positive for charges, negative for
+// refunds and zero for plan changes
+const (
+	LedgerKindCharge     = "charge"
+	LedgerKindRefund     = "refund"
+	LedgerKindPlanChange = "plan_change"
+)
+
+// LedgerEntry is a change to what a user paid. Entries are only ever added
+type LedgerEntry struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+
+	// Zero for purchases that are not subscriptions, like boost packs
+	SubscriptionID int64 `json:"subscription_id,omitempty"`
+
+	Kind        string `json:"kind"`
+	Tier        string `json:"tier,omitempty"`
+	Plan        string `json:"plan,omitempty"`
+	Description string `json:"description"`
+
+	// In the smallest currency unit, tax included
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+
+	// Period of the subscription the entry is for
+	PeriodStart *time.Time `json:"period_start,omitempty"`
+	PeriodEnd   *time.Time `json:"period_end,omitempty"`
+
+	// Set for charges and refunds of money, which get an invoice
+	InvoiceID     int64  `json:"invoice_id,omitempty"`
+	InvoiceNumber string `json:"invoice_number,omitempty"`
+
+	// What the entry records, each charge or refund is entered once however
+	// many times it is seen
+	Reference string `json:"-"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Invoice is issued for every ledger entry moving money, a refund's being a
+// credit note with negative amounts. Invoices are numbered without gaps in
+// the order they are issued
+type Invoice struct {
+	ID            int64  `json:"id"`
+	Number        string `json:"number"`
+	UserID        int64  `json:"user_id"`
+	LedgerEntryID int64  `json:"ledger_entry_id"`
+
+	// Taken from the ledger entry
+	Kind        string     `json:"kind"`
+	Description string     `json:"description"`
+	PeriodStart *time.Time `json:"period_start,omitempty"`
+	PeriodEnd   *time.Time `json:"period_end,omitempty"`
+
+	// Amounts are in the smallest currency unit. The tax rate in force when
+	// the invoice was issued is kept, in basis points
+	Subtotal int64  `json:"subtotal"`
+	TaxName  string `json:"tax_name"`
+	TaxRate  int64  `json:"tax_rate"`
+	Tax      int64  `json:"tax"`
+	Total    int64  `json:"total"`
+	Currency string `json:"currency"`
+
+	IssuedAt time.Time `json:"issued_at"`
+}
+
+type BillingHistoryRequest struct {
+	UserID int64 `json:"-"`
+
+	// Only entries older than BeforeID are returned, zero starts from the
+	// latest entry
+	BeforeID int64 `json:"before_id"`
+	Limit    int   `json:"limit"`
+}
+
+type BillingHistoryResponse struct {
+	Entries []LedgerEntry `json:"entries"`
+
+	// Cursor for the next page, empty when there are no older entries
+	NextBeforeID int64 `json:"next_before_id,omitempty"`
+}
+
+type InvoiceRequest struct {
+	UserID    int64 `json:"-"`
+	InvoiceID int64 `json:"invoice_id"`
+}
+
+// InvoiceDocument is an invoice rendered for downloading
+type InvoiceDocument struct {
+	Filename    string
+	ContentType string
+	Body        []byte
+}
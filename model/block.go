@@ -0,0 +1,6 @@
+package model
+
+type BlockRequest struct {
+	UserID        int64 `json:"-"`
+	BlockedUserID int64 `json:"blocked_user_id"`
+}
@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// Boosts are spent from the monthly allowance of the tier first, then from
+// the ones the user bought
+const (
+	BoostSourceMonthly   = "monthly"
+	BoostSourcePurchased = "purchased"
+)
+
+// Boost moves the user toward the top of discovery until it expires
+type Boost struct {
+	ID          int64     `json:"id"`
+	UserID      int64     `json:"user_id"`
+	Source      string    `json:"source"`
+	ActivatedAt time.Time `json:"activated_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// BoostPurchase is a boost pack bought in a store, credited once whoever
+// sends the receipt again
+type BoostPurchase struct {
+	ID        string    `json:"id"`
+	UserID    int64     `json:"user_id"`
+	Boosts    int       `json:"boosts"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BoostInventory is what the user has left to boost with
+type BoostInventory struct {
+	// Boosts left of the monthly allowance, -1 when there is no cap
+	Monthly   int   `json:"monthly"`
+	Purchased int64 `json:"purchased"`
+
+	// The boost running now
+	Active *Boost `json:"active,omitempty"`
+}
@@ -0,0 +1,76 @@
+package model
+
+import "time"
+
+type Conversation struct {
+	ID            int64      `json:"id"`
+	UserAID       int64      `json:"-"`
+	UserBID       int64      `json:"-"`
+	PeerID        int64      `json:"peer_id"`
+	PeerName      string     `json:"peer_name,omitempty"`
+	LastMessageAt *time.Time `json:"last_message_at,omitempty"`
+	UnreadCount   int64      `json:"unread_count"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// HasParticipant reports whether the user is one of the two members of the
+// conversation
+func (c *Conversation) HasParticipant(userID int64) bool {
+	return c.UserAID == userID || c.UserBID == userID
+}
+
+// Peer returns the other member of the conversation
+func (c *Conversation) Peer(userID int64) int64 {
+	if c.UserAID == userID {
+		return c.UserBID
+	}
+	return c.UserAID
+}
+
+type Message struct {
+	ID             int64      `json:"id"`
+	ConversationID int64      `json:"conversation_id"`
+	SenderID       int64      `json:"sender_id"`
+	Body           string     `json:"body"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+}
+
+type StartConversationRequest struct {
+	UserID      int64 `json:"-"`
+	RecipientID int64 `json:"recipient_id"`
+}
+
+type SendMessageRequest struct {
+	UserID         int64  `json:"-"`
+	ConversationID int64  `json:"conversation_id"`
+	Body           string `json:"body"`
+}
+
+type GetMessagesRequest struct {
+	UserID         int64 `json:"-"`
+	ConversationID int64 `json:"conversation_id"`
+
+	// Only messages older than BeforeID are returned, zero starts from the
+	// latest message
+	BeforeID int64 `json:"before_id"`
+	Limit    int   `json:"limit"`
+}
+
+type GetMessagesResponse struct {
+	Messages []Message `json:"messages"`
+
+	// Cursor for the next page, empty when there are no older messages
+	NextBeforeID int64 `json:"next_before_id,omitempty"`
+}
+
+type DeleteMessageRequest struct {
+	UserID    int64 `json:"-"`
+	MessageID int64 `json:"message_id"`
+}
+
+type MarkReadRequest struct {
+	UserID         int64 `json:"-"`
+	ConversationID int64 `json:"conversation_id"`
+	MessageID      int64 `json:"message_id"`
+}
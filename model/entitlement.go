@@ -0,0 +1,9 @@
+package model
+
+// Entitlements is what the tier of a user lets them do. Limits of -1 are
+// unlimited
+type Entitlements struct {
+	Tier     string         `json:"tier"`
+	Features []string       `json:"features"`
+	Limits   map[string]int `json:"limits"`
+}
@@ -1,5 +1,79 @@
 package model
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
-var UnauthorizedErr = errors.New("unauthorized")
+var (
+	UnauthorizedErr   = errors.New("unauthorized")
+	NotFoundErr       = errors.New("not found")
+	InvalidTokenErr   = errors.New("invalid or expired token")
+	WeakPasswordErr   = errors.New("password is too weak")
+	EmailTakenErr     = errors.New("email is already used by another account")
+	InvalidEmailErr   = errors.New("email is not a valid address")
+	NotMatchedErr     = errors.New("users have not matched each other")
+	InvalidMessageErr = errors.New("message is empty or too long")
+	BlockedErr        = errors.New("one of the users blocked the other")
+	SelfBlockErr      = errors.New("users cannot block themselves")
+	ForbiddenErr      = errors.New("forbidden")
+	BannedErr         = errors.New("account is banned")
+	InvalidRoleErr    = errors.New("role is unknown")
+	InvalidUntilErr   = errors.New("suspension has to end in the future")
+
+	SuperLikeLimitErr  = errors.New("no super likes left for today")
+	PremiumRequiredErr = errors.New("premium is required")
+	UndoExpiredErr     = errors.New("swipe is too old to undo")
+	RewindLimitErr     = errors.New("no rewinds left for today")
+	BoostLimitErr      = errors.New("no boosts left")
+	BoostActiveErr     = errors.New("a boost is running already")
+
+	InvalidPlanErr       = errors.New("plan is unknown")
+	AlreadySubscribedErr = errors.New("user already has an active subscription")
+	InvalidSignatureErr  = errors.New("webhook signature is invalid")
+	InvalidReceiptErr    = errors.New("receipt is invalid or the store is unknown")
+	ReceiptInUseErr      = errors.New("receipt belongs to another user")
+	StoreManagedErr      = errors.New("subscription is managed in the store it was bought in")
+
+	InvalidPromoCodeErr = errors.New("promo code kind, value or tier is invalid")
+	UnknownPromoCodeErr = errors.New("promo code does not exist")
+	PromoCodeTakenErr   = errors.New("promo code already exists")
+	PromoCodeExpiredErr = errors.New("promo code has expired")
+	PromoCodeUsedUpErr  = errors.New("promo code has no uses left")
+	AlreadyRedeemedErr  = errors.New("user already redeemed the code")
+	SelfReferralErr     = errors.New("users cannot refer themselves")
+	ReferralClosedErr   = errors.New("only users who have not verified their email can be referred")
+
+	SelfReportErr     = errors.New("users cannot report themselves")
+	InvalidReportErr  = errors.New("report reason is unknown or detail is too long")
+	ReportConflictErr = errors.New("report is not in a state allowing this action")
+
+	TwoFactorEnabledErr     = errors.New("two-factor authentication is already enabled")
+	TwoFactorNotEnrolledErr = errors.New("two-factor authentication is not enrolled")
+
+	InvalidPhotoErr      = errors.New("photo is not a JPEG, PNG or GIF image or cannot be read")
+	PhotoTooLargeErr     = errors.New("photo is too large")
+	PhotoLimitErr        = errors.New("no more photos can be added")
+	InvalidPhotoOrderErr = errors.New("photo order has to list every photo of the user once")
+)
+
+// LockedError is returned when logging in is temporarily blocked because of
+// too many failed attempts
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("too many failed login attempts, retry after %s", e.RetryAfter)
+}
+
+// SuspendedError is returned when logging in to a suspended account
+type SuspendedError struct {
+	Reason string
+	Until  time.Time
+}
+
+func (e *SuspendedError) Error() string {
+	return fmt.Sprintf("account is suspended until %s: %s", e.Until.Format(time.RFC3339), e.Reason)
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	EventTypeLiked          = "liked"
+	EventTypeSuperLiked     = "super_liked"
+	EventTypeMatched        = "matched"
+	EventTypeUnmatched      = "unmatched"
+	EventTypePremiumChanged = "premium_changed"
+	EventTypeQuotaReset     = "quota_reset"
+	EventTypeMessagesRead   = "messages_read"
+	EventTypePaymentFailed  = "payment_failed"
+)
+
+// Event is a notification pushed to a connected user. Seq increases per user
+// so a client can ask for what it missed after reconnecting
+type Event struct {
+	Seq       int64           `json:"seq"`
+	UserID    int64           `json:"user_id"`
+	Type      string          `json:"type"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type LikedEvent struct {
+	UserID int64 `json:"user_id"`
+}
+
+type MatchedEvent struct {
+	UserID int64 `json:"user_id"`
+}
+
+// MessagesReadEvent is a read receipt, the peer read the conversation up to
+// the message
+type MessagesReadEvent struct {
+	ConversationID int64 `json:"conversation_id"`
+	MessageID      int64 `json:"message_id"`
+}
+
+type PremiumChangedEvent struct {
+	IsPremium bool `json:"is_premium"`
+}
+
+// PaymentFailedEvent asks the user to update their payment method before
+// premium is taken away when the grace period ends
+type PaymentFailedEvent struct {
+	GraceUntil time.Time `json:"grace_until"`
+}
+
+type SubscribeEventsRequest struct {
+	UserID int64 `json:"-"`
+
+	// Seq of the last event the client received, events after it are
+	// replayed before live events
+	LastSeq int64 `json:"last_seq"`
+}
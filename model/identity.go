@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+type Identity struct {
+	ID        int64     `json:"id,omitempty"`
+	UserID    int64     `json:"user_id"`
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+type OAuthState struct {
+	Provider     string `json:"provider"`
+	CodeVerifier string `json:"code_verifier"`
+	Nonce        string `json:"nonce"`
+}
+
+type OAuthLoginRequest struct {
+	Provider string `json:"provider"`
+}
+
+type OAuthLoginResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+type OAuthCallbackRequest struct {
+	State string `json:"state"`
+	Code  string `json:"code"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type Liker struct {
+	UserID     int64     `json:"id"`
+	FullName   string    `json:"full_name"`
+	SuperLiked bool      `json:"super_liked,omitempty"`
+	LikedAt    time.Time `json:"liked_at"`
+}
+
+type LikersResponse struct {
+	Count int64 `json:"count"`
+
+	// Only listed for premium users, free users only see the count
+	Likers []Liker `json:"likers,omitempty"`
+}
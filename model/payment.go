@@ -0,0 +1,29 @@
+package model
+
+import (
+	"net/http"
+	"time"
+)
+
+// SubscribeResponse points the user at the page to pay on. It is empty when
+// subscribing again only took back a cancellation
+type SubscribeResponse struct {
+	CheckoutID  string `json:"checkout_id,omitempty"`
+	CheckoutURL string `json:"checkout_url,omitempty"`
+}
+
+// PaymentWebhookRequest is a webhook as it came in, the signature covers the
+// raw payload so it is kept as is
+type PaymentWebhookRequest struct {
+	Payload []byte
+	Header  http.Header
+}
+
+// PaymentEvent is a webhook event that was handled, kept so a redelivered
+// event is not handled twice
+type PaymentEvent struct {
+	ID             string    `json:"id"`
+	Type           string    `json:"type"`
+	SubscriptionID string    `json:"subscription_id"`
+	CreatedAt      time.Time `json:"created_at"`
+}
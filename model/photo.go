@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+// Uploads larger than this are turned down before they are decoded
+const PhotoMaxBytes = 10 << 20
+
+// Photo is a picture on the profile of a user, stored as a photo and a
+// square thumbnail
+type Photo struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+
+	// Where the files are kept in the blob store
+	Key          string `json:"-"`
+	ThumbnailKey string `json:"-"`
+
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+
+	// Order the user put the photos in. The primary photo stands for the
+	// user in lists and is shown first wherever it is
+	Position  int       `json:"position"`
+	IsPrimary bool      `json:"is_primary"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type UploadPhotoRequest struct {
+	UserID int64
+	Body   []byte
+}
+
+type ReorderPhotosRequest struct {
+	UserID   int64   `json:"-"`
+	PhotoIDs []int64 `json:"photo_ids"`
+}
+
+type PhotoRequest struct {
+	UserID  int64 `json:"-"`
+	PhotoID int64 `json:"photo_id"`
+}
@@ -0,0 +1,75 @@
+package model
+
+import "time"
+
+// What a viewer is to a profile, which decides what of it they see. Each
+// relation sees what the ones before it do
+const (
+	RelationStranger = "stranger"
+	// The viewer liked or super liked the profile, without a like back
+	RelationLiked = "liked"
+	// The viewer and the profile liked each other
+	RelationMatch = "match"
+	RelationSelf  = "self"
+	RelationAdmin = "admin"
+)
+
+// Profile is what a viewer is shown of a user, never the email or any other
+// account detail of someone else
+type Profile struct {
+	UserID   int64  `json:"id"`
+	Relation string `json:"relation"`
+
+	FullName  string  `json:"full_name,omitempty"`
+	IsPremium bool    `json:"is_premium,omitempty"`
+	Photos    []Photo `json:"photos,omitempty"`
+
+	// Set on discovered profiles of users who super liked the viewer
+	SuperLiked bool `json:"super_liked,omitempty"`
+
+	// When the viewer liked the profile, for likes and matches
+	LikedAt *time.Time `json:"liked_at,omitempty"`
+
+	// Seen by matches
+	Username string `json:"username,omitempty"`
+
+	// Seen by the user themselves and admins
+	Email            string     `json:"email,omitempty"`
+	EmailVerified    bool       `json:"email_verified,omitempty"`
+	Tier             string     `json:"tier,omitempty"`
+	IsIncognito      bool       `json:"is_incognito,omitempty"`
+	TwoFactorEnabled bool       `json:"two_factor_enabled,omitempty"`
+	Role             string     `json:"role,omitempty"`
+	AccountStatus    string     `json:"account_status,omitempty"`
+	StatusReason     string     `json:"status_reason,omitempty"`
+	StatusUntil      *time.Time `json:"status_until,omitempty"`
+}
+
+// Profile projects the user to the fields the relation sees
+func (u *User) Profile(relation string) Profile {
+	profile := Profile{
+		UserID:     u.UserID,
+		Relation:   relation,
+		FullName:   u.FullName,
+		IsPremium:  u.IsPremium,
+		Photos:     u.Photos,
+		SuperLiked: u.SuperLiked,
+	}
+
+	switch relation {
+	case RelationMatch:
+		profile.Username = u.Username
+	case RelationSelf, RelationAdmin:
+		profile.Username = u.Username
+		profile.Email = u.Email
+		profile.EmailVerified = u.EmailVerified
+		profile.Tier = u.Tier
+		profile.IsIncognito = u.IsIncognito
+		profile.TwoFactorEnabled = u.TwoFactorEnabled
+		profile.Role = u.Role
+		profile.AccountStatus = u.AccountStatus
+		profile.StatusReason = u.StatusReason
+		profile.StatusUntil = u.StatusUntil
+	}
+	return profile
+}
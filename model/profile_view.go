@@ -0,0 +1,32 @@
+package model
+
+import "time"
+
+type ProfileView struct {
+	ViewerID int64     `json:"viewer_id"`
+	ViewedID int64     `json:"viewed_id"`
+	ViewedAt time.Time `json:"viewed_at"`
+}
+
+type ProfileViewer struct {
+	UserID   int64     `json:"id"`
+	FullName string    `json:"full_name"`
+	ViewedAt time.Time `json:"viewed_at"`
+}
+
+type ViewProfileRequest struct {
+	UserID    int64 `json:"-"`
+	ProfileID int64 `json:"profile_id"`
+}
+
+type ProfileViewersResponse struct {
+	Count int64 `json:"count"`
+
+	// Only listed for premium users, free users only see the count
+	Viewers []ProfileViewer `json:"viewers,omitempty"`
+}
+
+type IncognitoRequest struct {
+	UserID    int64 `json:"-"`
+	Incognito bool  `json:"incognito"`
+}
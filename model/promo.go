@@ -0,0 +1,93 @@
+package model
+
+import "time"
+
+// Promo codes either take money off the next checkout or grant premium days
+// right away
+const (
+	PromoKindPercent = "percent"
+	PromoKindFixed   = "fixed"
+	PromoKindDays    = "days"
+)
+
+// PlanPromo is the plan of the subscriptions granting promo and referral
+// premium days. It cannot be subscribed to
+const PlanPromo = "promo"
+
+type PromoCode struct {
+	ID   int64  `json:"id"`
+	Code string `json:"code"`
+	Kind string `json:"kind"`
+
+	// Percent off, amount off in the smallest currency unit or premium days
+	// depending on the kind
+	Value int64 `json:"value"`
+
+	// Tier the premium days are granted on
+	Tier string `json:"tier,omitempty"`
+
+	// How many users can redeem the code, zero has no cap
+	MaxUses   int64      `json:"max_uses"`
+	Uses      int64      `json:"uses"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	CreatedBy int64     `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IsDiscount reports whether the code takes money off rather than granting
+// days
+func (p *PromoCode) IsDiscount() bool {
+	return p.Kind == PromoKindPercent || p.Kind == PromoKindFixed
+}
+
+type CreatePromoCodeRequest struct {
+	ActorID   int64      `json:"-"`
+	Code      string     `json:"code"`
+	Kind      string     `json:"kind"`
+	Value     int64      `json:"value"`
+	Tier      string     `json:"tier"`
+	MaxUses   int64      `json:"max_uses"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// PromoRedemption is a user redeeming a code. Discounts are pending until
+// the next subscription the user pays for starts
+type PromoRedemption struct {
+	PromoCodeID int64      `json:"promo_code_id"`
+	UserID      int64      `json:"user_id"`
+	RedeemedAt  time.Time  `json:"redeemed_at"`
+	AppliedAt   *time.Time `json:"applied_at,omitempty"`
+}
+
+type RedeemRequest struct {
+	UserID int64  `json:"-"`
+	Code   string `json:"code"`
+}
+
+// RedeemPromoCodeResponse tells what the code did. Days codes say until when
+// the user is premium, discounts apply to the next checkout
+type RedeemPromoCodeResponse struct {
+	Kind         string     `json:"kind"`
+	Value        int64      `json:"value"`
+	PremiumUntil *time.Time `json:"premium_until,omitempty"`
+}
+
+// Referral is a user signing up through the link of another. Both get
+// premium days once the referred user verifies their email
+type Referral struct {
+	ReferrerID int64      `json:"referrer_id"`
+	RefereeID  int64      `json:"referee_id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RewardedAt *time.Time `json:"rewarded_at,omitempty"`
+}
+
+type ReferralResponse struct {
+	Code string `json:"code"`
+	Link string `json:"link"`
+
+	// Users who signed up through the link, and the ones of them who
+	// verified their email so both got the premium days
+	Referred int64 `json:"referred"`
+	Rewarded int64 `json:"rewarded"`
+}
@@ -0,0 +1,24 @@
+package model
+
+import "net/http"
+
+// ReceiptRequest is a receipt the app got from a store after a purchase
+type ReceiptRequest struct {
+	UserID  int64  `json:"-"`
+	Store   string `json:"store"`
+	Receipt string `json:"receipt"`
+}
+
+// StoreNotificationRequest is a server notification as it came in from the
+// store, the signature covers the raw payload so it is kept as is
+type StoreNotificationRequest struct {
+	Store   string
+	Payload []byte
+	Header  http.Header
+}
+
+// StoreProduct is what a product sold in the stores subscribes to
+type StoreProduct struct {
+	Tier string `json:"tier"`
+	Plan string `json:"plan"`
+}
@@ -0,0 +1,64 @@
+package model
+
+import "time"
+
+const (
+	ReportReasonSpam          = "spam"
+	ReportReasonHarassment    = "harassment"
+	ReportReasonFakeProfile   = "fake_profile"
+	ReportReasonInappropriate = "inappropriate_content"
+	ReportReasonUnderage      = "underage"
+	ReportReasonOther         = "other"
+)
+
+// ReportReasons are the categories a report can be filed under
+var ReportReasons = []string{
+	ReportReasonSpam,
+	ReportReasonHarassment,
+	ReportReasonFakeProfile,
+	ReportReasonInappropriate,
+	ReportReasonUnderage,
+	ReportReasonOther,
+}
+
+const (
+	// Waiting in the queue for a moderator
+	ReportStatusOpen = "open"
+	// Being reviewed by the assigned moderator
+	ReportStatusClaimed = "claimed"
+	// Handed over to be reviewed by someone more senior
+	ReportStatusEscalated = "escalated"
+	ReportStatusResolved  = "resolved"
+)
+
+type Report struct {
+	ID         int64      `json:"id"`
+	ReporterID int64      `json:"reporter_id"`
+	ReportedID int64      `json:"reported_id"`
+	Reason     string     `json:"reason"`
+	Detail     string     `json:"detail,omitempty"`
+	Status     string     `json:"status"`
+	AssigneeID int64      `json:"assignee_id,omitempty"`
+	Resolution string     `json:"resolution,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
+
+type ReportRequest struct {
+	UserID         int64  `json:"-"`
+	ReportedUserID int64  `json:"reported_user_id"`
+	Reason         string `json:"reason"`
+	Detail         string `json:"detail"`
+}
+
+type GetReportsRequest struct {
+	Status string `json:"status"`
+	Limit  int    `json:"limit"`
+}
+
+// ReviewReportRequest is used to claim, resolve and escalate a report
+type ReviewReportRequest struct {
+	ModeratorID int64  `json:"-"`
+	ReportID    int64  `json:"report_id"`
+	Note        string `json:"note"`
+}
@@ -0,0 +1,74 @@
+package model
+
+import "time"
+
+// Tiers decide what a user is entitled to, users without a subscription are
+// on the free tier
+const (
+	TierFree = "free"
+	TierPlus = "plus"
+	TierGold = "gold"
+)
+
+// PaidTiers are the tiers that can be subscribed to
+var PaidTiers = map[string]bool{
+	TierPlus: true,
+	TierGold: true,
+}
+
+// Plans are how often a subscription is billed
+const (
+	PlanMonthly = "monthly"
+	PlanYearly  = "yearly"
+)
+
+// PlanPeriods is how many months a period of each plan lasts
+var PlanPeriods = map[string]int{
+	PlanMonthly: 1,
+	PlanYearly:  12,
+}
+
+const (
+	SubscriptionStatusActive   = "active"
+	SubscriptionStatusPastDue  = "past_due"
+	SubscriptionStatusCanceled = "canceled"
+)
+
+type Subscription struct {
+	ID                 int64     `json:"id"`
+	UserID             int64     `json:"user_id"`
+	Tier               string    `json:"tier"`
+	Plan               string    `json:"plan"`
+	Status             string    `json:"status"`
+	CurrentPeriodStart time.Time `json:"current_period_start"`
+	CurrentPeriodEnd   time.Time `json:"current_period_end"`
+	AutoRenew          bool      `json:"auto_renew"`
+
+	// Canceled subscriptions stay active until the period they were paid for
+	// ends
+	CancelAtPeriodEnd bool `json:"cancel_at_period_end"`
+
+	// Past due subscriptions keep premium until the grace period ends while
+	// the payment is retried
+	GraceUntil *time.Time `json:"grace_until,omitempty"`
+
+	// Subscriptions paid through the payment provider are known there by this
+	// ID, ones bought in a store by the store and the purchase ID. Ones
+	// granted by staff have none
+	ProviderSubscriptionID string `json:"-"`
+
+	// Store the subscription was bought in from the mobile apps, empty for the
+	// rest. Only the store can cancel or renew it
+	Store string `json:"store,omitempty"`
+
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// IsActive reports whether the subscription grants premium at the time
+func (s *Subscription) IsActive(at time.Time) bool {
+	if s.Status == SubscriptionStatusPastDue {
+		return s.GraceUntil != nil && at.Before(*s.GraceUntil)
+	}
+	return s.Status == SubscriptionStatusActive && at.Before(s.CurrentPeriodEnd)
+}
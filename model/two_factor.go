@@ -0,0 +1,27 @@
+package model
+
+type TOTP struct {
+	UserID  int64  `json:"user_id"`
+	Secret  string `json:"secret"`
+	Enabled bool   `json:"enabled"`
+}
+
+type TwoFactorEnrollResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type TwoFactorVerifyRequest struct {
+	UserID int64  `json:"-"`
+	Code   string `json:"code"`
+}
+
+type TwoFactorVerifyResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type LoginTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recovery_code"`
+}
@@ -1,35 +1,116 @@
 package model
 
+import "time"
+
 type User struct {
-	UserID    int64  `json:"id,omitempty"`
-	Username  string `json:"username,omitempty"`
-	Password  string `json:"password,omitempty"`
-	FullName  string `json:"full_name,omitempty"`
-	Email     string `json:"email,omitempty"`
-	IsPremium bool   `json:"is_premium,omitempty"`
+	UserID        int64  `json:"id,omitempty"`
+	Username      string `json:"username,omitempty"`
+	Password      string `json:"password,omitempty"`
+	FullName      string `json:"full_name,omitempty"`
+	Email         string `json:"email,omitempty"`
+	IsPremium     bool   `json:"is_premium,omitempty"`
+	Tier          string `json:"tier,omitempty"`
+	EmailVerified bool   `json:"email_verified,omitempty"`
+	IsIncognito   bool   `json:"is_incognito,omitempty"`
+	Role          string `json:"role,omitempty"`
+	AccountStatus string `json:"account_status,omitempty"`
+
+	// When the user first verified an email, kept when the email changes
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+
+	// Set on discovered profiles of users who super liked the viewer
+	SuperLiked bool `json:"super_liked,omitempty"`
+
+	// Why the account is not active and until when, if it ends
+	StatusReason string     `json:"status_reason,omitempty"`
+	StatusUntil  *time.Time `json:"status_until,omitempty"`
+
+	TwoFactorEnabled bool `json:"two_factor_enabled,omitempty"`
+
+	// Code of the referral link the user signed up through
+	ReferralCode string `json:"referral_code,omitempty"`
+
+	// Set on discovered profiles, the primary photo first
+	Photos []Photo `json:"photos,omitempty"`
 }
 
+// HasRole reports whether the user is granted the role, either directly or
+// through a higher one
+func (u *User) HasRole(role string) bool {
+	rank, ok := roleRanks[role]
+	return ok && roleRanks[u.Role] >= rank
+}
+
+const (
+	SwipeStatusPass      = -1
+	SwipeStatusLike      = 1
+	SwipeStatusSuperLike = 2
+)
+
 type UserRelation struct {
 	UserID      int64 `json:"id"`
 	SwipeStatus int   `json:"swipe_status"`
 }
 
 type LoginRequest struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+	IPAddress string `json:"-"`
+}
+
+type LoginResponse struct {
+	Token string `json:"token,omitempty"`
+
+	// Set instead of Token when the account requires a second factor
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
+}
+
+type ChangePasswordRequest struct {
+	UserID          int64  `json:"-"`
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+type ChangeEmailRequest struct {
+	UserID          int64  `json:"-"`
+	CurrentPassword string `json:"current_password"`
+	NewEmail        string `json:"new_email"`
+}
+
+type EmailVerification struct {
+	UserID int64  `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
 }
 
 type SubscribeRequest struct {
-	UserID    int64 `json:"user_id"`
+	UserID    int64  `json:"-"`
+	Tier      string `json:"tier"`
+	Plan      string `json:"plan"`
 	Subscribe bool
 }
 
 type SwipeRequest struct {
-	UserID       int64 `json:"user_id"`
+	UserID       int64 `json:"-"`
 	SwipedUserID int64 `json:"swiped_user_id"`
 	SwipeStatus  int   `json:"swipe_status"`
 }
 
+type Swipe struct {
+	SwiperID    int64     `json:"-"`
+	SwipedID    int64     `json:"swiped_user_id"`
+	SwipeStatus int       `json:"swipe_status"`
+	SwipedAt    time.Time `json:"swiped_at"`
+}
+
+type UndoSwipeRequest struct {
+	UserID int64 `json:"-"`
+}
+
 type GetRelatedUserRequest struct {
-	UserID int64 `json:"user_id"`
+	UserID int64 `json:"-"`
 }
@@ -0,0 +1,141 @@
+package billing
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+)
+
+// Seller issues the invoices
+type Seller struct {
+	Name    string
+	Address string
+	TaxID   string
+}
+
+// Document is everything printed on an invoice
+type Document struct {
+	Seller  Seller
+	Invoice model.Invoice
+
+	// Who the invoice is billed to
+	Username string
+	Email    string
+}
+
+// Currencies without minor units, amounts in them are whole
+var zeroDecimalCurrencies = map[string]bool{
+	"jpy": true,
+	"krw": true,
+	"vnd": true,
+}
+
+// FormatAmount writes an amount in the smallest currency unit the way people
+// read it, e.g. 1999 usd as 19.99 USD
+func FormatAmount(amount int64, currency string) string {
+	code := strings.ToUpper(currency)
+	if zeroDecimalCurrencies[strings.ToLower(currency)] {
+		return fmt.Sprintf("%d %s", amount, code)
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d %s", sign, amount/100, amount%100, code)
+}
+
+// FormatTaxRate writes a rate in basis points as a percentage, e.g. 1150 as
+// 11.5%
+func FormatTaxRate(rate int64) string {
+	if rate%100 == 0 {
+		return fmt.Sprintf("%d%%", rate/100)
+	}
+	return strings.TrimRight(fmt.Sprintf("%.2f", float64(rate)/100), "0") + "%"
+}
+
+var invoiceTemplate = template.Must(template.New("invoice").Funcs(template.FuncMap{
+	"amount": func(amount int64, currency string) string {
+		return FormatAmount(amount, currency)
+	},
+	"rate": FormatTaxRate,
+	"date": func(t interface{}) string {
+		switch t := t.(type) {
+		case time.Time:
+			return t.UTC().Format("January 2, 2006")
+		case *time.Time:
+			return t.UTC().Format("January 2, 2006")
+		}
+		return ""
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{if .IsCreditNote}}Credit note{{else}}Invoice{{end}} {{.Invoice.Number}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em auto; max-width: 40em; color: #222; }
+table { border-collapse: collapse; width: 100%; margin-top: 2em; }
+th, td { padding: 0.4em 0; text-align: left; }
+td.amount, th.amount { text-align: right; }
+tr.total td { border-top: 1px solid #222; font-weight: bold; }
+.parties { display: flex; justify-content: space-between; margin-top: 2em; }
+</style>
+</head>
+<body>
+<h1>{{if .IsCreditNote}}Credit note{{else}}Invoice{{end}} {{.Invoice.Number}}</h1>
+<p>Issued {{date .Invoice.IssuedAt}}</p>
+<div class="parties">
+<div>
+<strong>{{.Seller.Name}}</strong><br>
+{{range .SellerAddress}}{{.}}<br>{{end}}
+{{if .Seller.TaxID}}Tax ID {{.Seller.TaxID}}{{end}}
+</div>
+<div>
+<strong>Billed to</strong><br>
+{{.Username}}<br>
+{{if .Email}}{{.Email}}{{end}}
+</div>
+</div>
+<table>
+<tr><th>Description</th><th class="amount">Amount</th></tr>
+<tr>
+<td>{{.Invoice.Description}}{{if and .Invoice.PeriodStart .Invoice.PeriodEnd}}<br><small>{{date .Invoice.PeriodStart}} to {{date .Invoice.PeriodEnd}}</small>{{end}}</td>
+<td class="amount">{{amount .Invoice.Subtotal .Invoice.Currency}}</td>
+</tr>
+<tr><td>Subtotal</td><td class="amount">{{amount .Invoice.Subtotal .Invoice.Currency}}</td></tr>
+<tr><td>{{.Invoice.TaxName}} {{rate .Invoice.TaxRate}}</td><td class="amount">{{amount .Invoice.Tax .Invoice.Currency}}</td></tr>
+<tr class="total"><td>Total</td><td class="amount">{{amount .Invoice.Total .Invoice.Currency}}</td></tr>
+</table>
+</body>
+</html>
+`))
+
+// RenderInvoice writes the document as an HTML page, which browsers can also
+// print to PDF
+func RenderInvoice(w io.Writer, doc Document) error {
+	return invoiceTemplate.Execute(w, struct {
+		Document
+		IsCreditNote  bool
+		SellerAddress []string
+	}{
+		Document:      doc,
+		IsCreditNote:  doc.Invoice.Total < 0,
+		SellerAddress: strings.Split(doc.Seller.Address, "\n"),
+	})
+}
+
+// TaxIncluded returns the part of an amount including tax at the rate in
+// basis points that is the tax, rounded to the nearest unit
+func TaxIncluded(amount, rate int64) int64 {
+	if amount < 0 {
+		return -TaxIncluded(-amount, rate)
+	}
+	divisor := 10000 + rate
+	return (amount*rate + divisor/2) / divisor
+}
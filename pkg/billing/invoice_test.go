@@ -0,0 +1,72 @@
+package billing
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatAmount(t *testing.T) {
+	assert.Equal(t, "19.99 USD", FormatAmount(1999, "usd"))
+	assert.Equal(t, "0.05 EUR", FormatAmount(5, "eur"))
+	assert.Equal(t, "-19.99 USD", FormatAmount(-1999, "usd"))
+	assert.Equal(t, "1200 JPY", FormatAmount(1200, "jpy"))
+}
+
+func TestFormatTaxRate(t *testing.T) {
+	assert.Equal(t, "11%", FormatTaxRate(1100))
+	assert.Equal(t, "7.5%", FormatTaxRate(750))
+	assert.Equal(t, "0%", FormatTaxRate(0))
+}
+
+func TestTaxIncluded(t *testing.T) {
+	assert.Equal(t, int64(198), TaxIncluded(1999, 1100))
+	assert.Equal(t, int64(-198), TaxIncluded(-1999, 1100))
+	assert.Equal(t, int64(0), TaxIncluded(1999, 0))
+}
+
+func TestRenderInvoice(t *testing.T) {
+	periodStart := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	periodEnd := periodStart.AddDate(0, 1, 0)
+	doc := Document{
+		Seller:   Seller{Name: "Dating App", Address: "1 Main St\nSpringfield", TaxID: "TX-1"},
+		Username: "<alice>",
+		Email:    "alice@example.com",
+		Invoice: model.Invoice{
+			Number:      "INV-000001",
+			Description: "gold monthly subscription",
+			PeriodStart: &periodStart,
+			PeriodEnd:   &periodEnd,
+			Subtotal:    1801,
+			TaxName:     "VAT",
+			TaxRate:     1100,
+			Tax:         198,
+			Total:       1999,
+			Currency:    "usd",
+			IssuedAt:    periodStart,
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, RenderInvoice(&buf, doc))
+	page := buf.String()
+	assert.Contains(t, page, "Invoice INV-000001")
+	assert.Contains(t, page, "January 1, 2024 to February 1, 2024")
+	assert.Contains(t, page, "VAT 11%")
+	assert.Contains(t, page, "19.99 USD")
+	assert.Contains(t, page, "Tax ID TX-1")
+	assert.Contains(t, page, "1 Main St<br>Springfield")
+
+	// Whatever users put in their name is escaped
+	assert.Contains(t, page, "&lt;alice&gt;")
+
+	// Refunds are credited
+	doc.Invoice.Subtotal, doc.Invoice.Tax, doc.Invoice.Total = -1801, -198, -1999
+	buf.Reset()
+	assert.NoError(t, RenderInvoice(&buf, doc))
+	assert.Contains(t, buf.String(), "Credit note INV-000001")
+	assert.Contains(t, buf.String(), "-19.99 USD")
+}
@@ -0,0 +1,163 @@
+package entitlement
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/egnptr/dating-app/model"
+)
+
+// Features are either available on a tier or not
+const (
+	FeatureSeeWhoLiked       = "see_who_liked"
+	FeatureSeeProfileViewers = "see_profile_viewers"
+	FeatureReadReceipts      = "read_receipts"
+)
+
+// Limits cap how often something can be done in a period
+const (
+	LimitDailySwipes     = "daily_swipes"
+	LimitDailySuperLikes = "daily_super_likes"
+	LimitDailyRewinds    = "daily_rewinds"
+	LimitMonthlyBoosts   = "monthly_boosts"
+)
+
+// Unlimited is the limit of things that can be done as often as wanted
+const Unlimited = -1
+
+// Plan is what a tier is entitled to. Features and limits it does not list
+// are not available
+type Plan struct {
+	Features map[string]bool `json:"features"`
+	Limits   map[string]int  `json:"limits"`
+}
+
+// Can reports whether the feature is available on the plan
+func (p Plan) Can(feature string) bool {
+	return p.Features[feature]
+}
+
+// Limit returns the limit on the plan, zero when the plan does not list it
+func (p Plan) Limit(limit string) int {
+	return p.Limits[limit]
+}
+
+// Allows reports whether something used the given number of times can be
+// used once more
+func (p Plan) Allows(limit string, used int64) bool {
+	n := p.Limit(limit)
+	return n == Unlimited || used < int64(n)
+}
+
+// Config maps the tiers to their plans, a nil Config is the Default one
+type Config map[string]Plan
+
+// Plan returns the plan of the tier, falling back to the free tier for tiers
+// it does not know
+func (c Config) Plan(tier string) Plan {
+	if c == nil {
+		c = Default()
+	}
+	if plan, ok := c[tier]; ok {
+		return plan
+	}
+	return c[model.TierFree]
+}
+
+// Default is what each tier is entitled to unless configured otherwise
+func Default() Config {
+	return Config{
+		model.TierFree: {
+			Features: map[string]bool{},
+			Limits: map[string]int{
+				LimitDailySwipes:     10,
+				LimitDailySuperLikes: 1,
+				LimitDailyRewinds:    0,
+				LimitMonthlyBoosts:   0,
+			},
+		},
+		model.TierPlus: {
+			Features: map[string]bool{
+				FeatureSeeWhoLiked:       true,
+				FeatureSeeProfileViewers: true,
+			},
+			Limits: map[string]int{
+				LimitDailySwipes:     Unlimited,
+				LimitDailySuperLikes: 5,
+				LimitDailyRewinds:    Unlimited,
+				LimitMonthlyBoosts:   1,
+			},
+		},
+		model.TierGold: {
+			Features: map[string]bool{
+				FeatureSeeWhoLiked:       true,
+				FeatureSeeProfileViewers: true,
+				FeatureReadReceipts:      true,
+			},
+			Limits: map[string]int{
+				LimitDailySwipes:     Unlimited,
+				LimitDailySuperLikes: 10,
+				LimitDailyRewinds:    Unlimited,
+				LimitMonthlyBoosts:   5,
+			},
+		},
+	}
+}
+
+// Load reads a JSON file of tiers to plans. Tiers in the file replace their
+// default plan, the others keep it. An empty path keeps every default
+func Load(path string) (Config, error) {
+	config := Default()
+	if path == "" {
+		return config, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read entitlements: %w", err)
+	}
+
+	var plans Config
+	if err := json.Unmarshal(data, &plans); err != nil {
+		return nil, fmt.Errorf("failed to decode entitlements: %w", err)
+	}
+
+	for tier, plan := range plans {
+		if _, ok := config[tier]; !ok {
+			return nil, fmt.Errorf("tier %q is unknown", tier)
+		}
+		config[tier] = plan
+	}
+
+	return config, nil
+}
+
+// Entitlements lists what the plan of the tier is entitled to
+func (c Config) Entitlements(tier string) model.Entitlements {
+	if c == nil {
+		c = Default()
+	}
+	if _, ok := c[tier]; !ok {
+		tier = model.TierFree
+	}
+
+	plan := c[tier]
+	res := model.Entitlements{
+		Tier:     tier,
+		Features: []string{},
+		Limits:   make(map[string]int, len(plan.Limits)),
+	}
+	for feature, ok := range plan.Features {
+		if ok {
+			res.Features = append(res.Features, feature)
+		}
+	}
+	sort.Strings(res.Features)
+	for limit, n := range plan.Limits {
+		res.Limits[limit] = n
+	}
+
+	return res
+}
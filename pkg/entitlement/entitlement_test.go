@@ -0,0 +1,59 @@
+package entitlement
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanAllows(t *testing.T) {
+	plan := Plan{Limits: map[string]int{
+		LimitDailySwipes:     Unlimited,
+		LimitDailySuperLikes: 2,
+	}}
+
+	assert.True(t, plan.Allows(LimitDailySwipes, 1000))
+	assert.True(t, plan.Allows(LimitDailySuperLikes, 1))
+	assert.False(t, plan.Allows(LimitDailySuperLikes, 2))
+	// Limits the plan does not list are not available
+	assert.False(t, plan.Allows(LimitDailyRewinds, 0))
+}
+
+func TestConfigPlan(t *testing.T) {
+	var config Config
+
+	assert.True(t, config.Plan(model.TierGold).Can(FeatureReadReceipts))
+	assert.False(t, config.Plan(model.TierPlus).Can(FeatureReadReceipts))
+	// Unknown tiers get the free plan
+	assert.Equal(t, 10, config.Plan("platinum").Limit(LimitDailySwipes))
+	assert.Equal(t, model.TierFree, config.Entitlements("platinum").Tier)
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+		return path
+	}
+
+	config, err := Load("")
+	assert.NoError(t, err)
+	assert.Equal(t, Default(), config)
+
+	config, err = Load(write("free.json", `{"free": {"features": {"see_who_liked": true}, "limits": {"daily_swipes": 20}}}`))
+	assert.NoError(t, err)
+	assert.True(t, config.Plan(model.TierFree).Can(FeatureSeeWhoLiked))
+	assert.Equal(t, 20, config.Plan(model.TierFree).Limit(LimitDailySwipes))
+	assert.Equal(t, 0, config.Plan(model.TierFree).Limit(LimitDailySuperLikes))
+	assert.Equal(t, Default()[model.TierGold], config.Plan(model.TierGold))
+
+	_, err = Load(write("unknown.json", `{"platinum": {}}`))
+	assert.Error(t, err)
+
+	_, err = Load(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+package event
+
+import (
+	"context"
+	"sync"
+
+	"github.com/egnptr/dating-app/model"
+)
+
+// Events buffered per subscriber before it is considered too slow
+const subscriberBuffer = 32
+
+// Hub fans events out to the subscribers connected to this instance
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[int64]map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	events chan model.Event
+	once   sync.Once
+}
+
+func (s *subscriber) close() {
+	s.once.Do(func() { close(s.events) })
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[int64]map[*subscriber]struct{}),
+	}
+}
+
+// Run dispatches every event of source until it is closed or ctx is done
+func (h *Hub) Run(ctx context.Context, source <-chan model.Event) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-source:
+			if !ok {
+				return
+			}
+			h.Dispatch(event)
+		}
+	}
+}
+
+// Subscribe returns the events of the user and a function to stop receiving
+// them. The channel is closed when the subscriber falls behind, it is then up
+// to the client to reconnect and replay what it missed
+func (h *Hub) Subscribe(userID int64) (<-chan model.Event, func()) {
+	sub := &subscriber{events: make(chan model.Event, subscriberBuffer)}
+
+	h.mu.Lock()
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[*subscriber]struct{})
+	}
+	h.subscribers[userID][sub] = struct{}{}
+	h.mu.Unlock()
+
+	return sub.events, func() { h.remove(userID, sub) }
+}
+
+// Dispatch delivers the event to the subscribers of its user
+func (h *Hub) Dispatch(event model.Event) {
+	var slow []*subscriber
+
+	h.mu.RLock()
+	for sub := range h.subscribers[event.UserID] {
+		select {
+		case sub.events <- event:
+		default:
+			slow = append(slow, sub)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, sub := range slow {
+		h.remove(event.UserID, sub)
+	}
+}
+
+func (h *Hub) remove(userID int64, sub *subscriber) {
+	h.mu.Lock()
+	delete(h.subscribers[userID], sub)
+	if len(h.subscribers[userID]) == 0 {
+		delete(h.subscribers, userID)
+	}
+	h.mu.Unlock()
+
+	sub.close()
+}
@@ -0,0 +1,59 @@
+package event
+
+import (
+	"context"
+	"testing"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDispatch(t *testing.T) {
+	hub := NewHub()
+
+	events, unsubscribe := hub.Subscribe(1)
+	defer unsubscribe()
+	other, unsubscribeOther := hub.Subscribe(2)
+	defer unsubscribeOther()
+
+	hub.Dispatch(model.Event{Seq: 1, UserID: 1, Type: model.EventTypeLiked})
+
+	assert.Equal(t, int64(1), (<-events).Seq)
+	assert.Len(t, other, 0)
+}
+
+func TestDispatchSlowSubscriber(t *testing.T) {
+	hub := NewHub()
+
+	events, unsubscribe := hub.Subscribe(1)
+	defer unsubscribe()
+
+	for seq := int64(1); seq <= subscriberBuffer+1; seq++ {
+		hub.Dispatch(model.Event{Seq: seq, UserID: 1})
+	}
+
+	var received int
+	for range events {
+		received++
+	}
+	assert.Equal(t, subscriberBuffer, received)
+}
+
+func TestRun(t *testing.T) {
+	hub := NewHub()
+	events, unsubscribe := hub.Subscribe(1)
+
+	source := make(chan model.Event, 1)
+	source <- model.Event{Seq: 7, UserID: 1}
+	close(source)
+	hub.Run(context.Background(), source)
+
+	assert.Equal(t, int64(7), (<-events).Seq)
+
+	unsubscribe()
+	_, ok := <-events
+	assert.False(t, ok)
+
+	// Unsubscribing twice must not panic
+	unsubscribe()
+}
@@ -8,7 +8,8 @@ import (
 )
 
 type muxRouter struct {
-	Router *mux.Router
+	Router     *mux.Router
+	Middleware []Middleware
 }
 
 func NewMuxRouter() Router {
@@ -20,15 +21,40 @@ func NewMuxRouter() Router {
 }
 
 func (m *muxRouter) GET(uri string, f func(w http.ResponseWriter, r *http.Request)) {
-	m.Router.HandleFunc(uri, f).Methods("GET")
+	m.Router.HandleFunc(uri, m.wrap(f)).Methods("GET")
 }
 
 func (m *muxRouter) POST(uri string, f func(w http.ResponseWriter, r *http.Request)) {
-	m.Router.HandleFunc(uri, f).Methods("POST")
+	m.Router.HandleFunc(uri, m.wrap(f)).Methods("POST")
 }
 
 func (m *muxRouter) DELETE(uri string, f func(w http.ResponseWriter, r *http.Request)) {
-	m.Router.HandleFunc(uri, f).Methods("DELETE")
+	m.Router.HandleFunc(uri, m.wrap(f)).Methods("DELETE")
+}
+
+func (m *muxRouter) STATIC(prefix string, handler http.Handler) {
+	m.Router.PathPrefix(prefix).Handler(http.StripPrefix(prefix, http.HandlerFunc(m.wrap(handler.ServeHTTP)))).Methods("GET")
+}
+
+func (m *muxRouter) Group(prefix string, middleware ...Middleware) Router {
+	return &muxRouter{
+		Router:     m.Router.PathPrefix(prefix).Subrouter(),
+		Middleware: append(append([]Middleware{}, m.Middleware...), middleware...),
+	}
+}
+
+// wrap applies the middleware of the router, the first one running first
+func (m *muxRouter) wrap(f func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
+	for i := len(m.Middleware) - 1; i >= 0; i-- {
+		f = m.Middleware[i](f)
+	}
+	return f
+}
+
+// PathVar returns the variable of the route path the request matched, empty
+// when the route has none by that name
+func PathVar(r *http.Request, name string) string {
+	return mux.Vars(r)[name]
 }
 
 func (m *muxRouter) SERVE(port string) {
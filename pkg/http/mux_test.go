@@ -0,0 +1,110 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroup(t *testing.T) {
+	var calls []string
+	middleware := func(name string) Middleware {
+		return func(f func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
+			return func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, name)
+				f(w, r)
+			}
+		}
+	}
+
+	router := NewMuxRouter().(*muxRouter)
+	router.GET("/ping", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "ping")
+	})
+	admin := router.Group("/admin", middleware("first"), middleware("second"))
+	admin.GET("/users", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "users")
+	})
+	admin.Group("/reports", middleware("third")).POST("/claim", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "claim")
+	})
+
+	tests := []struct {
+		name      string
+		method    string
+		url       string
+		wantCode  int
+		wantCalls []string
+	}{
+		{
+			name:      "case outside group",
+			method:    http.MethodGet,
+			url:       "/ping",
+			wantCode:  200,
+			wantCalls: []string{"ping"},
+		},
+		{
+			name:      "case group",
+			method:    http.MethodGet,
+			url:       "/admin/users",
+			wantCode:  200,
+			wantCalls: []string{"first", "second", "users"},
+		},
+		{
+			name:      "case nested group",
+			method:    http.MethodPost,
+			url:       "/admin/reports/claim",
+			wantCode:  200,
+			wantCalls: []string{"first", "second", "third", "claim"},
+		},
+		{
+			name:     "case not found",
+			method:   http.MethodGet,
+			url:      "/admin/unknown",
+			wantCode: 404,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			recorder := httptest.NewRecorder()
+			router.Router.ServeHTTP(recorder, httptest.NewRequest(tt.method, tt.url, nil))
+			assert.Equal(t, tt.wantCode, recorder.Code)
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestStatic(t *testing.T) {
+	router := NewMuxRouter().(*muxRouter)
+	router.STATIC("/media/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path))
+	}))
+
+	recorder := httptest.NewRecorder()
+	router.Router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/media/photos/1/a.jpg", nil))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "photos/1/a.jpg", recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	router.Router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/media/photos/1/a.jpg", nil))
+	assert.Equal(t, 405, recorder.Code)
+}
+
+func TestPathVar(t *testing.T) {
+	router := NewMuxRouter().(*muxRouter)
+	router.GET("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(PathVar(r, "id") + "," + PathVar(r, "name")))
+	})
+
+	recorder := httptest.NewRecorder()
+	router.Router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "42,", recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	router.Router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/", nil))
+	assert.Equal(t, 404, recorder.Code)
+}
@@ -2,9 +2,22 @@ package http
 
 import "net/http"
 
+// Middleware wraps a handler, e.g. to authenticate the request before it
+type Middleware func(f func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request)
+
+// Routes may hold variables in their path, e.g. /users/{id}, which handlers
+// read with PathVar
 type Router interface {
 	GET(uri string, f func(w http.ResponseWriter, r *http.Request))
 	POST(uri string, f func(w http.ResponseWriter, r *http.Request))
 	DELETE(uri string, f func(w http.ResponseWriter, r *http.Request))
+
+	// STATIC serves every GET request under prefix with the handler, which
+	// sees the path with prefix taken off
+	STATIC(prefix string, handler http.Handler)
+
+	// Group returns a router for the routes under prefix, whose handlers are
+	// wrapped with the middleware in the given order
+	Group(prefix string, middleware ...Middleware) Router
 	SERVE(port string)
 }
@@ -0,0 +1,24 @@
+package mail
+
+import (
+	"context"
+	"log"
+)
+
+// go:generate moq -rm -out mail_mock.go . Mailer
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) (err error)
+}
+
+type logMailer struct{}
+
+// NewLogMailer returns a Mailer that writes outgoing emails to the log
+// instead of delivering them
+func NewLogMailer() Mailer {
+	return &logMailer{}
+}
+
+func (*logMailer) Send(ctx context.Context, to, subject, body string) (err error) {
+	log.Printf("sending email to %s: %s\n%s\n", to, subject, body)
+	return
+}
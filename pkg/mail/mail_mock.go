@@ -0,0 +1,93 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package mail
+
+import (
+	"context"
+	"sync"
+)
+
+// Ensure, that MailerMock does implement Mailer.
+// If this is not the case, regenerate this file with moq.
+var _ Mailer = &MailerMock{}
+
+// MailerMock is a mock implementation of Mailer.
+//
+//	func TestSomethingThatUsesMailer(t *testing.T) {
+//
+//		// make and configure a mocked Mailer
+//		mockedMailer := &MailerMock{
+//			SendFunc: func(ctx context.Context, to string, subject string, body string) error {
+//				panic("mock out the Send method")
+//			},
+//		}
+//
+//		// use mockedMailer in code that requires Mailer
+//		// and then make assertions.
+//
+//	}
+type MailerMock struct {
+	// SendFunc mocks the Send method.
+	SendFunc func(ctx context.Context, to string, subject string, body string) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Send holds details about calls to the Send method.
+		Send []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// To is the to argument value.
+			To string
+			// Subject is the subject argument value.
+			Subject string
+			// Body is the body argument value.
+			Body string
+		}
+	}
+	lockSend sync.RWMutex
+}
+
+// Send calls SendFunc.
+func (mock *MailerMock) Send(ctx context.Context, to string, subject string, body string) error {
+	if mock.SendFunc == nil {
+		panic("MailerMock.SendFunc: method is nil but Mailer.Send was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		To      string
+		Subject string
+		Body    string
+	}{
+		Ctx:     ctx,
+		To:      to,
+		Subject: subject,
+		Body:    body,
+	}
+	mock.lockSend.Lock()
+	mock.calls.Send = append(mock.calls.Send, callInfo)
+	mock.lockSend.Unlock()
+	return mock.SendFunc(ctx, to, subject, body)
+}
+
+// SendCalls gets all the calls that were made to Send.
+// Check the length with:
+//
+//	len(mockedMailer.SendCalls())
+func (mock *MailerMock) SendCalls() []struct {
+	Ctx     context.Context
+	To      string
+	Subject string
+	Body    string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		To      string
+		Subject string
+		Body    string
+	}
+	mock.lockSend.RLock()
+	calls = mock.calls.Send
+	mock.lockSend.RUnlock()
+	return calls
+}
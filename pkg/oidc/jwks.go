@@ -0,0 +1,160 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// keySet caches the signing keys of a provider and refreshes them when a
+// token is signed with a key it has not seen yet
+type keySet struct {
+	client *http.Client
+	uri    string
+
+	mu   sync.RWMutex
+	keys map[string]*rsa.PublicKey
+}
+
+func newKeySet(client *http.Client, uri string) *keySet {
+	return &keySet{
+		client: client,
+		uri:    uri,
+		keys:   make(map[string]*rsa.PublicKey),
+	}
+}
+
+// verifySignature checks the RS256 signature of a compact JWS and returns its
+// decoded payload
+func (k *keySet) verifySignature(ctx context.Context, token string) ([]byte, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed id token")
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("malformed id token header: %w", err)
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, fmt.Errorf("malformed id token header: %w", err)
+	}
+
+	if header.Alg != "RS256" {
+		return nil, fmt.Errorf("unsupported id token algorithm %q", header.Alg)
+	}
+
+	key, err := k.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed id token signature: %w", err)
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return nil, errors.New("invalid id token signature")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed id token payload: %w", err)
+	}
+	return payload, nil
+}
+
+func (k *keySet) key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	k.mu.RLock()
+	key, ok := k.keys[kid]
+	k.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	if err := k.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if key, ok := k.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown id token signing key %q", kid)
+}
+
+func (k *keySet) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.uri, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build jwks request: %w", err)
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch jwks: %s", resp.Status)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("failed to decode jwks: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			continue
+		}
+
+		keys[jwk.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	k.mu.Lock()
+	k.keys = keys
+	k.mu.Unlock()
+	return nil
+}
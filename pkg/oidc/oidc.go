@@ -0,0 +1,263 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/egnptr/dating-app/pkg/util"
+)
+
+// Config describes a relying party registration at an OpenID provider
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// Claims are the verified claims of an ID token the app cares about
+type Claims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// go:generate moq -rm -out oidc_mock.go . Provider
+type Provider interface {
+	AuthCodeURL(state, nonce, codeVerifier string) string
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (claims Claims, err error)
+}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type provider struct {
+	config    Config
+	discovery discovery
+	keys      *keySet
+	client    *http.Client
+}
+
+// NewProvider fetches the discovery document of the issuer and returns a
+// provider running the authorization code flow with PKCE against it
+func NewProvider(ctx context.Context, config Config) (Provider, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	wellKnown := strings.TrimSuffix(config.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build discovery request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch discovery document: %s", resp.Status)
+	}
+
+	var doc discovery
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode discovery document: %w", err)
+	}
+
+	if doc.Issuer != config.Issuer {
+		return nil, fmt.Errorf("issuer %q does not match discovery document issuer %q", config.Issuer, doc.Issuer)
+	}
+
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "email", "profile"}
+	}
+
+	return &provider{
+		config:    config,
+		discovery: doc,
+		keys:      newKeySet(client, doc.JWKSURI),
+		client:    client,
+	}, nil
+}
+
+// GenerateCodeVerifier returns a random PKCE code verifier
+func GenerateCodeVerifier() (string, error) {
+	return util.GenerateToken(32)
+}
+
+// CodeChallenge returns the S256 PKCE code challenge of the verifier
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (p *provider) AuthCodeURL(state, nonce, codeVerifier string) string {
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", CodeChallenge(codeVerifier))
+	query.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(p.discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return p.discovery.AuthorizationEndpoint + separator + query.Encode()
+}
+
+func (p *provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (claims Claims, err error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("client_id", p.config.ClientID)
+	form.Set("client_secret", p.config.ClientSecret)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		err = fmt.Errorf("failed to build token request: %w", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		err = fmt.Errorf("failed to exchange code: %w", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("failed to exchange code: %s", resp.Status)
+		return
+	}
+
+	var token struct {
+		IDToken string `json:"id_token"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		err = fmt.Errorf("failed to decode token response: %w", err)
+		return
+	}
+
+	if token.IDToken == "" {
+		err = errors.New("token response has no id_token")
+		return
+	}
+
+	return p.verify(ctx, token.IDToken, nonce)
+}
+
+type idTokenClaims struct {
+	Issuer        string   `json:"iss"`
+	Subject       string   `json:"sub"`
+	Audience      audience `json:"aud"`
+	Expiry        int64    `json:"exp"`
+	IssuedAt      int64    `json:"iat"`
+	Nonce         string   `json:"nonce"`
+	Email         string   `json:"email"`
+	EmailVerified boolean  `json:"email_verified"`
+	Name          string   `json:"name"`
+}
+
+// verify checks the signature and the standard claims of an ID token as
+// described in OpenID Connect Core section 3.1.3.7
+func (p *provider) verify(ctx context.Context, idToken, nonce string) (claims Claims, err error) {
+	payload, err := p.keys.verifySignature(ctx, idToken)
+	if err != nil {
+		return
+	}
+
+	var token idTokenClaims
+	if err = json.Unmarshal(payload, &token); err != nil {
+		err = fmt.Errorf("failed to decode id token claims: %w", err)
+		return
+	}
+
+	switch {
+	case token.Issuer != p.discovery.Issuer:
+		err = fmt.Errorf("unexpected id token issuer %q", token.Issuer)
+	case !token.Audience.contains(p.config.ClientID):
+		err = errors.New("id token is not issued for this client")
+	case time.Now().After(time.Unix(token.Expiry, 0)):
+		err = errors.New("id token is expired")
+	case token.Nonce != nonce:
+		err = errors.New("id token nonce does not match")
+	case token.Subject == "":
+		err = errors.New("id token has no subject")
+	}
+	if err != nil {
+		return
+	}
+
+	claims = Claims{
+		Subject:       token.Subject,
+		Email:         token.Email,
+		EmailVerified: bool(token.EmailVerified),
+		Name:          token.Name,
+	}
+	return
+}
+
+// audience accepts both the single string and the array form of "aud"
+type audience []string
+
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(b, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+func (a audience) contains(clientID string) bool {
+	for _, aud := range a {
+		if aud == clientID {
+			return true
+		}
+	}
+	return false
+}
+
+// boolean accepts both booleans and the "true"/"false" strings some
+// providers, such as Apple, send for email_verified
+type boolean bool
+
+func (b *boolean) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "true":
+		*b = true
+	case "false", "null":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package oidc
+
+import (
+	"context"
+	"sync"
+)
+
+// Ensure, that ProviderMock does implement Provider.
+// If this is not the case, regenerate this file with moq.
+var _ Provider = &ProviderMock{}
+
+// ProviderMock is a mock implementation of Provider.
+//
+//	func TestSomethingThatUsesProvider(t *testing.T) {
+//
+//		// make and configure a mocked Provider
+//		mockedProvider := &ProviderMock{
+//			AuthCodeURLFunc: func(state string, nonce string, codeVerifier string) string {
+//				panic("mock out the AuthCodeURL method")
+//			},
+//			ExchangeFunc: func(ctx context.Context, code string, codeVerifier string, nonce string) (Claims, error) {
+//				panic("mock out the Exchange method")
+//			},
+//		}
+//
+//		// use mockedProvider in code that requires Provider
+//		// and then make assertions.
+//
+//	}
+type ProviderMock struct {
+	// AuthCodeURLFunc mocks the AuthCodeURL method.
+	AuthCodeURLFunc func(state string, nonce string, codeVerifier string) string
+
+	// ExchangeFunc mocks the Exchange method.
+	ExchangeFunc func(ctx context.Context, code string, codeVerifier string, nonce string) (Claims, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AuthCodeURL holds details about calls to the AuthCodeURL method.
+		AuthCodeURL []struct {
+			// State is the state argument value.
+			State string
+			// Nonce is the nonce argument value.
+			Nonce string
+			// CodeVerifier is the codeVerifier argument value.
+			CodeVerifier string
+		}
+		// Exchange holds details about calls to the Exchange method.
+		Exchange []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Code is the code argument value.
+			Code string
+			// CodeVerifier is the codeVerifier argument value.
+			CodeVerifier string
+			// Nonce is the nonce argument value.
+			Nonce string
+		}
+	}
+	lockAuthCodeURL sync.RWMutex
+	lockExchange    sync.RWMutex
+}
+
+// AuthCodeURL calls AuthCodeURLFunc.
+func (mock *ProviderMock) AuthCodeURL(state string, nonce string, codeVerifier string) string {
+	if mock.AuthCodeURLFunc == nil {
+		panic("ProviderMock.AuthCodeURLFunc: method is nil but Provider.AuthCodeURL was just called")
+	}
+	callInfo := struct {
+		State        string
+		Nonce        string
+		CodeVerifier string
+	}{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+	}
+	mock.lockAuthCodeURL.Lock()
+	mock.calls.AuthCodeURL = append(mock.calls.AuthCodeURL, callInfo)
+	mock.lockAuthCodeURL.Unlock()
+	return mock.AuthCodeURLFunc(state, nonce, codeVerifier)
+}
+
+// AuthCodeURLCalls gets all the calls that were made to AuthCodeURL.
+// Check the length with:
+//
+//	len(mockedProvider.AuthCodeURLCalls())
+func (mock *ProviderMock) AuthCodeURLCalls() []struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+} {
+	var calls []struct {
+		State        string
+		Nonce        string
+		CodeVerifier string
+	}
+	mock.lockAuthCodeURL.RLock()
+	calls = mock.calls.AuthCodeURL
+	mock.lockAuthCodeURL.RUnlock()
+	return calls
+}
+
+// Exchange calls ExchangeFunc.
+func (mock *ProviderMock) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (Claims, error) {
+	if mock.ExchangeFunc == nil {
+		panic("ProviderMock.ExchangeFunc: method is nil but Provider.Exchange was just called")
+	}
+	callInfo := struct {
+		Ctx          context.Context
+		Code         string
+		CodeVerifier string
+		Nonce        string
+	}{
+		Ctx:          ctx,
+		Code:         code,
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+	}
+	mock.lockExchange.Lock()
+	mock.calls.Exchange = append(mock.calls.Exchange, callInfo)
+	mock.lockExchange.Unlock()
+	return mock.ExchangeFunc(ctx, code, codeVerifier, nonce)
+}
+
+// ExchangeCalls gets all the calls that were made to Exchange.
+// Check the length with:
+//
+//	len(mockedProvider.ExchangeCalls())
+func (mock *ProviderMock) ExchangeCalls() []struct {
+	Ctx          context.Context
+	Code         string
+	CodeVerifier string
+	Nonce        string
+} {
+	var calls []struct {
+		Ctx          context.Context
+		Code         string
+		CodeVerifier string
+		Nonce        string
+	}
+	mock.lockExchange.RLock()
+	calls = mock.calls.Exchange
+	mock.lockExchange.RUnlock()
+	return calls
+}
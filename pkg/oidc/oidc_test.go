@@ -0,0 +1,102 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/egnptr/dating-app/pkg/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExchange(t *testing.T) {
+	server := oidctest.NewServer("client", "secret")
+	defer server.Close()
+
+	identity := oidctest.Identity{
+		Subject:       "subject",
+		Email:         "jdoe@mail.com",
+		EmailVerified: true,
+		Name:          "John Doe",
+	}
+
+	tests := []struct {
+		name         string
+		clientSecret string
+		nonce        string
+		verifier     string
+		wantClaims   Claims
+		wantErr      bool
+	}{
+		{
+			name:         "case success",
+			clientSecret: "secret",
+			nonce:        "nonce",
+			verifier:     "verifier",
+			wantClaims: Claims{
+				Subject:       "subject",
+				Email:         "jdoe@mail.com",
+				EmailVerified: true,
+				Name:          "John Doe",
+			},
+		},
+		{
+			name:         "case error wrong code verifier",
+			clientSecret: "secret",
+			nonce:        "nonce",
+			verifier:     "other",
+			wantErr:      true,
+		},
+		{
+			name:         "case error wrong nonce",
+			clientSecret: "secret",
+			nonce:        "other",
+			verifier:     "verifier",
+			wantErr:      true,
+		},
+		{
+			name:         "case error wrong client secret",
+			clientSecret: "other",
+			nonce:        "nonce",
+			verifier:     "verifier",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewProvider(context.Background(), Config{
+				Issuer:       server.Issuer(),
+				ClientID:     "client",
+				ClientSecret: tt.clientSecret,
+				RedirectURL:  "http://localhost:8080/oauth/callback",
+			})
+			assert.NoError(t, err)
+
+			authURL := p.AuthCodeURL("state", "nonce", "verifier")
+			parsed, _ := url.Parse(authURL)
+			assert.Equal(t, CodeChallenge("verifier"), parsed.Query().Get("code_challenge"))
+
+			code, state, err := server.Authorize(authURL, identity)
+			assert.NoError(t, err)
+			assert.Equal(t, "state", state)
+
+			gotClaims, gotErr := p.Exchange(context.Background(), code, tt.verifier, tt.nonce)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("Exchange() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantClaims, gotClaims)
+		})
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	server := oidctest.NewServer("client", "secret")
+	defer server.Close()
+
+	_, err := NewProvider(context.Background(), Config{Issuer: server.Issuer() + "/other"})
+	assert.Error(t, err)
+
+	_, err = NewProvider(context.Background(), Config{Issuer: server.Issuer()})
+	assert.NoError(t, err)
+}
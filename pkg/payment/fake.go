@@ -0,0 +1,272 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const fakeSignatureHeader = "Payment-Signature"
+
+// Webhook is a signed webhook request as the provider would send it
+type Webhook struct {
+	Payload []byte
+	Header  http.Header
+}
+
+type fakeEvent struct {
+	ID             string `json:"id"`
+	Type           string `json:"type"`
+	SubscriptionID string `json:"subscription_id"`
+}
+
+// FakeProvider keeps checkouts and subscriptions in memory. Nothing is ever
+// charged, the methods past the Provider ones play the part of the user and
+// the provider and return the webhook that would follow
+type FakeProvider struct {
+	WebhookSecret string
+
+	// How many months a period of each plan lasts
+	Periods map[string]int
+
+	// Amount charged for each plan by tier in the smallest unit of Currency,
+	// nothing is charged for the ones missing
+	Prices   map[string]map[string]int64
+	Currency string
+
+	mu            sync.Mutex
+	seq           int
+	checkouts     map[string]CheckoutRequest
+	subscriptions map[string]Subscription
+	declined      map[string]bool
+}
+
+// NewFakeProvider returns a local stand-in provider for tests and for running
+// the app without a payment account
+func NewFakeProvider(webhookSecret string, periods map[string]int) *FakeProvider {
+	return &FakeProvider{
+		WebhookSecret: webhookSecret,
+		Periods:       periods,
+		Currency:      "usd",
+		checkouts:     make(map[string]CheckoutRequest),
+		subscriptions: make(map[string]Subscription),
+		declined:      make(map[string]bool),
+	}
+}
+
+func (p *FakeProvider) CreateCheckout(ctx context.Context, req CheckoutRequest) (checkout Checkout, err error) {
+	if _, ok := p.Periods[req.Plan]; !ok {
+		err = ErrUnknownPlan
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	checkout.ID = p.nextID("cs")
+	checkout.URL = "https://payments.invalid/checkout/" + checkout.ID
+	p.checkouts[checkout.ID] = req
+
+	log.Printf("fake checkout %s created for user %d on %s %s, %d trial days, %d%% or %d off\n",
+		checkout.ID, req.UserID, req.Tier, req.Plan, req.TrialDays, req.PercentOff, req.AmountOff)
+	return
+}
+
+func (p *FakeProvider) VerifyWebhook(ctx context.Context, payload []byte, header http.Header) (event Event, err error) {
+	if err = verifySignature(p.WebhookSecret, payload, header.Get(fakeSignatureHeader), time.Now()); err != nil {
+		return
+	}
+
+	var raw fakeEvent
+	if err = json.Unmarshal(payload, &raw); err != nil {
+		err = fmt.Errorf("failed to decode webhook: %w", err)
+		return
+	}
+
+	return Event(raw), nil
+}
+
+func (p *FakeProvider) FetchSubscription(ctx context.Context, subscriptionID string) (subscription Subscription, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	subscription, ok := p.subscriptions[subscriptionID]
+	if !ok {
+		err = ErrNotFound
+	}
+	return
+}
+
+func (p *FakeProvider) SetCancelAtPeriodEnd(ctx context.Context, subscriptionID string, cancel bool) (err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	subscription, ok := p.subscriptions[subscriptionID]
+	if !ok {
+		return ErrNotFound
+	}
+
+	subscription.CancelAtPeriodEnd = cancel
+	p.subscriptions[subscriptionID] = subscription
+	return
+}
+
+// RenewSubscription starts the next period of an active subscription whose
+// period ended. It ends instead when canceled at the period end, and is past
+// due when its renewals are declined
+func (p *FakeProvider) RenewSubscription(ctx context.Context, subscriptionID string) (subscription Subscription, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	subscription, ok := p.subscriptions[subscriptionID]
+	if !ok {
+		err = ErrNotFound
+		return
+	}
+
+	if subscription.Status != StatusActive || subscription.CurrentPeriodEnd.After(time.Now()) {
+		return
+	}
+
+	switch {
+	case subscription.CancelAtPeriodEnd:
+		subscription.Status = StatusCanceled
+	case p.declined[subscriptionID]:
+		subscription.Status = StatusPastDue
+	default:
+		p.charge(&subscription)
+	}
+	p.subscriptions[subscriptionID] = subscription
+	return
+}
+
+// CompleteCheckout pays for the checkout, starting its subscription
+func (p *FakeProvider) CompleteCheckout(checkoutID string) (subscriptionID string, webhook Webhook, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	req, ok := p.checkouts[checkoutID]
+	if !ok {
+		err = ErrNotFound
+		return
+	}
+	delete(p.checkouts, checkoutID)
+
+	// A trial takes the place of the first period, discounts come off the
+	// first charge
+	now := time.Now()
+	periodEnd := now.AddDate(0, p.Periods[req.Plan], 0)
+	amount := p.Prices[req.Tier][req.Plan]
+	amount -= amount*req.PercentOff/100 + req.AmountOff
+	if amount < 0 {
+		amount = 0
+	}
+	if req.TrialDays > 0 {
+		periodEnd = now.AddDate(0, 0, req.TrialDays)
+		amount = 0
+	}
+
+	subscriptionID = p.nextID("sub")
+	p.subscriptions[subscriptionID] = Subscription{
+		ID:                 subscriptionID,
+		UserID:             req.UserID,
+		Tier:               req.Tier,
+		Plan:               req.Plan,
+		Status:             StatusActive,
+		CurrentPeriodStart: now,
+		CurrentPeriodEnd:   periodEnd,
+		AmountPaid:         amount,
+		Currency:           p.Currency,
+	}
+
+	webhook, err = p.webhook(EventPaymentSucceeded, subscriptionID)
+	return
+}
+
+// Renew charges the subscription for the period after the current one
+func (p *FakeProvider) Renew(subscriptionID string) (Webhook, error) {
+	return p.update(subscriptionID, EventPaymentSucceeded, func(subscription *Subscription) {
+		subscription.Status = StatusActive
+		p.charge(subscription)
+	})
+}
+
+// charge pays for the period after the current one at the full price
+func (p *FakeProvider) charge(subscription *Subscription) {
+	subscription.CurrentPeriodStart = subscription.CurrentPeriodEnd
+	subscription.CurrentPeriodEnd = subscription.CurrentPeriodEnd.AddDate(0, p.Periods[subscription.Plan], 0)
+	subscription.AmountPaid = p.Prices[subscription.Tier][subscription.Plan]
+	subscription.Currency = p.Currency
+}
+
+// FailPayment fails to charge the subscription for its next period
+func (p *FakeProvider) FailPayment(subscriptionID string) (Webhook, error) {
+	return p.update(subscriptionID, EventPaymentFailed, func(subscription *Subscription) {
+		subscription.Status = StatusPastDue
+	})
+}
+
+// DeclineRenewals makes charging the subscription for its next periods fail,
+// as with an expired card, or succeed again
+func (p *FakeProvider) DeclineRenewals(subscriptionID string, decline bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.declined[subscriptionID] = decline
+}
+
+// Refund gives the last payment back, which ends the subscription
+func (p *FakeProvider) Refund(subscriptionID string) (Webhook, error) {
+	return p.update(subscriptionID, EventPaymentRefunded, func(subscription *Subscription) {
+		subscription.Status = StatusCanceled
+	})
+}
+
+// Cancel ends the subscription at the provider
+func (p *FakeProvider) Cancel(subscriptionID string) (Webhook, error) {
+	return p.update(subscriptionID, EventSubscriptionCanceled, func(subscription *Subscription) {
+		subscription.Status = StatusCanceled
+	})
+}
+
+func (p *FakeProvider) update(subscriptionID, eventType string, f func(subscription *Subscription)) (webhook Webhook, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	subscription, ok := p.subscriptions[subscriptionID]
+	if !ok {
+		err = ErrNotFound
+		return
+	}
+
+	f(&subscription)
+	p.subscriptions[subscriptionID] = subscription
+
+	return p.webhook(eventType, subscriptionID)
+}
+
+// webhook signs a new event, the caller holds the lock
+func (p *FakeProvider) webhook(eventType, subscriptionID string) (webhook Webhook, err error) {
+	payload, err := json.Marshal(fakeEvent{
+		ID:             p.nextID("evt"),
+		Type:           eventType,
+		SubscriptionID: subscriptionID,
+	})
+	if err != nil {
+		return
+	}
+
+	header := http.Header{}
+	header.Set(fakeSignatureHeader, Sign(p.WebhookSecret, payload, time.Now()))
+
+	return Webhook{Payload: payload, Header: header}, nil
+}
+
+func (p *FakeProvider) nextID(prefix string) string {
+	p.seq++
+	return fmt.Sprintf("%s_fake_%d", prefix, p.seq)
+}
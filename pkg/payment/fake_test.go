@@ -0,0 +1,128 @@
+package payment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeProvider(t *testing.T) {
+	provider := NewFakeProvider("secret", map[string]int{"monthly": 1})
+	provider.Prices = map[string]map[string]int64{"gold": {"monthly": 1999}, "plus": {"monthly": 999}}
+	ctx := context.Background()
+
+	_, err := provider.CreateCheckout(ctx, CheckoutRequest{UserID: 1, Plan: "weekly"})
+	assert.Equal(t, ErrUnknownPlan, err)
+
+	checkout, err := provider.CreateCheckout(ctx, CheckoutRequest{UserID: 1, Tier: "gold", Plan: "monthly"})
+	assert.NoError(t, err)
+
+	subscriptionID, webhook, err := provider.CompleteCheckout(checkout.ID)
+	assert.NoError(t, err)
+
+	event, err := provider.VerifyWebhook(ctx, webhook.Payload, webhook.Header)
+	assert.NoError(t, err)
+	assert.Equal(t, EventPaymentSucceeded, event.Type)
+	assert.Equal(t, subscriptionID, event.SubscriptionID)
+
+	subscription, err := provider.FetchSubscription(ctx, subscriptionID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), subscription.UserID)
+	assert.Equal(t, "gold", subscription.Tier)
+	assert.Equal(t, StatusActive, subscription.Status)
+	assert.Equal(t, subscription.CurrentPeriodStart.AddDate(0, 1, 0), subscription.CurrentPeriodEnd)
+	assert.Equal(t, int64(1999), subscription.AmountPaid)
+	assert.Equal(t, "usd", subscription.Currency)
+
+	periodEnd := subscription.CurrentPeriodEnd
+	_, err = provider.Renew(subscriptionID)
+	assert.NoError(t, err)
+	subscription, _ = provider.FetchSubscription(ctx, subscriptionID)
+	assert.Equal(t, periodEnd.AddDate(0, 1, 0), subscription.CurrentPeriodEnd)
+
+	webhook, err = provider.FailPayment(subscriptionID)
+	assert.NoError(t, err)
+	event, _ = provider.VerifyWebhook(ctx, webhook.Payload, webhook.Header)
+	assert.Equal(t, EventPaymentFailed, event.Type)
+	subscription, _ = provider.FetchSubscription(ctx, subscriptionID)
+	assert.Equal(t, StatusPastDue, subscription.Status)
+
+	assert.NoError(t, provider.SetCancelAtPeriodEnd(ctx, subscriptionID, true))
+	subscription, _ = provider.FetchSubscription(ctx, subscriptionID)
+	assert.True(t, subscription.CancelAtPeriodEnd)
+
+	webhook, err = provider.Refund(subscriptionID)
+	assert.NoError(t, err)
+	subscription, _ = provider.FetchSubscription(ctx, subscriptionID)
+	assert.Equal(t, StatusCanceled, subscription.Status)
+
+	// A tampered webhook is turned down
+	_, err = provider.VerifyWebhook(ctx, append(webhook.Payload, ' '), webhook.Header)
+	assert.Equal(t, ErrInvalidSignature, err)
+
+	// A trial takes the place of the first period
+	checkout, _ = provider.CreateCheckout(ctx, CheckoutRequest{UserID: 2, Tier: "plus", Plan: "monthly", TrialDays: 7})
+	subscriptionID, _, _ = provider.CompleteCheckout(checkout.ID)
+	subscription, _ = provider.FetchSubscription(ctx, subscriptionID)
+	assert.Equal(t, subscription.CurrentPeriodStart.AddDate(0, 0, 7), subscription.CurrentPeriodEnd)
+	assert.Equal(t, int64(0), subscription.AmountPaid)
+
+	// Discounts come off the first charge
+	checkout, _ = provider.CreateCheckout(ctx, CheckoutRequest{UserID: 3, Tier: "plus", Plan: "monthly", PercentOff: 20})
+	discountedID, _, _ := provider.CompleteCheckout(checkout.ID)
+	subscription, _ = provider.FetchSubscription(ctx, discountedID)
+	assert.Equal(t, int64(800), subscription.AmountPaid)
+
+	// A checkout is only paid once
+	_, _, err = provider.CompleteCheckout(checkout.ID)
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestFakeProviderRenewSubscription(t *testing.T) {
+	provider := NewFakeProvider("secret", map[string]int{"monthly": 1})
+	ctx := context.Background()
+
+	// A day long trial has not ended yet, renewing is a no-op
+	checkout, _ := provider.CreateCheckout(ctx, CheckoutRequest{UserID: 1, Tier: "plus", Plan: "monthly", TrialDays: 1})
+	subscriptionID, _, _ := provider.CompleteCheckout(checkout.ID)
+	before, _ := provider.FetchSubscription(ctx, subscriptionID)
+	subscription, err := provider.RenewSubscription(ctx, subscriptionID)
+	assert.NoError(t, err)
+	assert.Equal(t, before, subscription)
+
+	due := func() {
+		provider.mu.Lock()
+		subscription := provider.subscriptions[subscriptionID]
+		subscription.CurrentPeriodEnd = time.Now().Add(-time.Minute)
+		provider.subscriptions[subscriptionID] = subscription
+		provider.mu.Unlock()
+	}
+
+	due()
+	periodEnd := provider.subscriptions[subscriptionID].CurrentPeriodEnd
+	subscription, _ = provider.RenewSubscription(ctx, subscriptionID)
+	assert.Equal(t, StatusActive, subscription.Status)
+	assert.Equal(t, periodEnd, subscription.CurrentPeriodStart)
+	assert.Equal(t, periodEnd.AddDate(0, 1, 0), subscription.CurrentPeriodEnd)
+
+	due()
+	provider.DeclineRenewals(subscriptionID, true)
+	subscription, _ = provider.RenewSubscription(ctx, subscriptionID)
+	assert.Equal(t, StatusPastDue, subscription.Status)
+
+	// Only active subscriptions renew
+	provider.DeclineRenewals(subscriptionID, false)
+	subscription, _ = provider.RenewSubscription(ctx, subscriptionID)
+	assert.Equal(t, StatusPastDue, subscription.Status)
+
+	_, _ = provider.Renew(subscriptionID)
+	due()
+	assert.NoError(t, provider.SetCancelAtPeriodEnd(ctx, subscriptionID, true))
+	subscription, _ = provider.RenewSubscription(ctx, subscriptionID)
+	assert.Equal(t, StatusCanceled, subscription.Status)
+
+	_, err = provider.RenewSubscription(ctx, "sub_unknown")
+	assert.Equal(t, ErrNotFound, err)
+}
@@ -0,0 +1,152 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Webhook events the app acts on, whatever the provider calls them
+const (
+	EventPaymentSucceeded     = "payment.succeeded"
+	EventPaymentFailed        = "payment.failed"
+	EventPaymentRefunded      = "payment.refunded"
+	EventSubscriptionCanceled = "subscription.canceled"
+)
+
+// Subscription statuses as seen by the provider
+const (
+	StatusActive   = "active"
+	StatusPastDue  = "past_due"
+	StatusCanceled = "canceled"
+)
+
+// Webhooks signed longer ago than this are turned down so a captured request
+// cannot be replayed later
+const signatureTolerance = 5 * time.Minute
+
+var (
+	ErrInvalidSignature = errors.New("webhook signature is invalid")
+	ErrUnknownPlan      = errors.New("plan has no price at the provider")
+	ErrNotFound         = errors.New("not found at the provider")
+)
+
+// CheckoutRequest asks for a page where the user pays for the first period of
+// the plan of the tier
+type CheckoutRequest struct {
+	UserID int64
+	Tier   string
+	Plan   string
+
+	// Days the subscription is free for before the first charge
+	TrialDays int
+
+	// Taken off the first charge, either a percentage or an amount in the
+	// smallest currency unit
+	PercentOff int64
+	AmountOff  int64
+}
+
+type Checkout struct {
+	ID  string
+	URL string
+}
+
+// Event is a verified webhook. Only the subscription it is about is taken
+// from it, its state is fetched from the provider when the event is handled
+type Event struct {
+	ID             string
+	Type           string
+	SubscriptionID string
+}
+
+// Subscription is the state of a subscription at the provider
+type Subscription struct {
+	ID                 string
+	UserID             int64
+	Tier               string
+	Plan               string
+	Status             string
+	CurrentPeriodStart time.Time
+	CurrentPeriodEnd   time.Time
+	CancelAtPeriodEnd  bool
+
+	// Last payment for the subscription in the smallest currency unit, zero
+	// during a trial
+	AmountPaid int64
+	Currency   string
+}
+
+// go:generate moq -rm -out payment_mock.go . Provider
+type Provider interface {
+	CreateCheckout(ctx context.Context, req CheckoutRequest) (checkout Checkout, err error)
+	VerifyWebhook(ctx context.Context, payload []byte, header http.Header) (event Event, err error)
+	FetchSubscription(ctx context.Context, subscriptionID string) (subscription Subscription, err error)
+	SetCancelAtPeriodEnd(ctx context.Context, subscriptionID string, cancel bool) (err error)
+
+	// RenewSubscription charges a subscription whose period ended for the
+	// next one and returns its state after, past due when the charge failed
+	RenewSubscription(ctx context.Context, subscriptionID string) (subscription Subscription, err error)
+}
+
+// Sign returns a signature header value for the payload in the form
+// t=<unix time>,v1=<hex hmac-sha256 of "<unix time>.<payload>">
+func Sign(secret string, payload []byte, at time.Time) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", timestamp, computeSignature(secret, timestamp, payload))
+}
+
+// verifySignature checks a header made by Sign. Any of the v1 signatures may
+// match so the secret can be rolled without dropping webhooks
+func verifySignature(secret string, payload []byte, header string, now time.Time) error {
+	var (
+		timestamp  string
+		signatures []string
+	)
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+
+	age := now.Sub(time.Unix(unix, 0))
+	if age > signatureTolerance || age < -signatureTolerance {
+		return ErrInvalidSignature
+	}
+
+	expected := computeSignature(secret, timestamp, payload)
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
+
+func computeSignature(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,294 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package payment
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// Ensure, that ProviderMock does implement Provider.
+// If this is not the case, regenerate this file with moq.
+var _ Provider = &ProviderMock{}
+
+// ProviderMock is a mock implementation of Provider.
+//
+//	func TestSomethingThatUsesProvider(t *testing.T) {
+//
+//		// make and configure a mocked Provider
+//		mockedProvider := &ProviderMock{
+//			CreateCheckoutFunc: func(ctx context.Context, req CheckoutRequest) (Checkout, error) {
+//				panic("mock out the CreateCheckout method")
+//			},
+//			FetchSubscriptionFunc: func(ctx context.Context, subscriptionID string) (Subscription, error) {
+//				panic("mock out the FetchSubscription method")
+//			},
+//			RenewSubscriptionFunc: func(ctx context.Context, subscriptionID string) (Subscription, error) {
+//				panic("mock out the RenewSubscription method")
+//			},
+//			SetCancelAtPeriodEndFunc: func(ctx context.Context, subscriptionID string, cancel bool) error {
+//				panic("mock out the SetCancelAtPeriodEnd method")
+//			},
+//			VerifyWebhookFunc: func(ctx context.Context, payload []byte, header http.Header) (Event, error) {
+//				panic("mock out the VerifyWebhook method")
+//			},
+//		}
+//
+//		// use mockedProvider in code that requires Provider
+//		// and then make assertions.
+//
+//	}
+type ProviderMock struct {
+	// CreateCheckoutFunc mocks the CreateCheckout method.
+	CreateCheckoutFunc func(ctx context.Context, req CheckoutRequest) (Checkout, error)
+
+	// FetchSubscriptionFunc mocks the FetchSubscription method.
+	FetchSubscriptionFunc func(ctx context.Context, subscriptionID string) (Subscription, error)
+
+	// RenewSubscriptionFunc mocks the RenewSubscription method.
+	RenewSubscriptionFunc func(ctx context.Context, subscriptionID string) (Subscription, error)
+
+	// SetCancelAtPeriodEndFunc mocks the SetCancelAtPeriodEnd method.
+	SetCancelAtPeriodEndFunc func(ctx context.Context, subscriptionID string, cancel bool) error
+
+	// VerifyWebhookFunc mocks the VerifyWebhook method.
+	VerifyWebhookFunc func(ctx context.Context, payload []byte, header http.Header) (Event, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// CreateCheckout holds details about calls to the CreateCheckout method.
+		CreateCheckout []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req CheckoutRequest
+		}
+		// FetchSubscription holds details about calls to the FetchSubscription method.
+		FetchSubscription []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// SubscriptionID is the subscriptionID argument value.
+			SubscriptionID string
+		}
+		// RenewSubscription holds details about calls to the RenewSubscription method.
+		RenewSubscription []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// SubscriptionID is the subscriptionID argument value.
+			SubscriptionID string
+		}
+		// SetCancelAtPeriodEnd holds details about calls to the SetCancelAtPeriodEnd method.
+		SetCancelAtPeriodEnd []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// SubscriptionID is the subscriptionID argument value.
+			SubscriptionID string
+			// Cancel is the cancel argument value.
+			Cancel bool
+		}
+		// VerifyWebhook holds details about calls to the VerifyWebhook method.
+		VerifyWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Payload is the payload argument value.
+			Payload []byte
+			// Header is the header argument value.
+			Header http.Header
+		}
+	}
+	lockCreateCheckout       sync.RWMutex
+	lockFetchSubscription    sync.RWMutex
+	lockRenewSubscription    sync.RWMutex
+	lockSetCancelAtPeriodEnd sync.RWMutex
+	lockVerifyWebhook        sync.RWMutex
+}
+
+// CreateCheckout calls CreateCheckoutFunc.
+func (mock *ProviderMock) CreateCheckout(ctx context.Context, req CheckoutRequest) (Checkout, error) {
+	if mock.CreateCheckoutFunc == nil {
+		panic("ProviderMock.CreateCheckoutFunc: method is nil but Provider.CreateCheckout was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req CheckoutRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockCreateCheckout.Lock()
+	mock.calls.CreateCheckout = append(mock.calls.CreateCheckout, callInfo)
+	mock.lockCreateCheckout.Unlock()
+	return mock.CreateCheckoutFunc(ctx, req)
+}
+
+// CreateCheckoutCalls gets all the calls that were made to CreateCheckout.
+// Check the length with:
+//
+//	len(mockedProvider.CreateCheckoutCalls())
+func (mock *ProviderMock) CreateCheckoutCalls() []struct {
+	Ctx context.Context
+	Req CheckoutRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req CheckoutRequest
+	}
+	mock.lockCreateCheckout.RLock()
+	calls = mock.calls.CreateCheckout
+	mock.lockCreateCheckout.RUnlock()
+	return calls
+}
+
+// FetchSubscription calls FetchSubscriptionFunc.
+func (mock *ProviderMock) FetchSubscription(ctx context.Context, subscriptionID string) (Subscription, error) {
+	if mock.FetchSubscriptionFunc == nil {
+		panic("ProviderMock.FetchSubscriptionFunc: method is nil but Provider.FetchSubscription was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		SubscriptionID string
+	}{
+		Ctx:            ctx,
+		SubscriptionID: subscriptionID,
+	}
+	mock.lockFetchSubscription.Lock()
+	mock.calls.FetchSubscription = append(mock.calls.FetchSubscription, callInfo)
+	mock.lockFetchSubscription.Unlock()
+	return mock.FetchSubscriptionFunc(ctx, subscriptionID)
+}
+
+// FetchSubscriptionCalls gets all the calls that were made to FetchSubscription.
+// Check the length with:
+//
+//	len(mockedProvider.FetchSubscriptionCalls())
+func (mock *ProviderMock) FetchSubscriptionCalls() []struct {
+	Ctx            context.Context
+	SubscriptionID string
+} {
+	var calls []struct {
+		Ctx            context.Context
+		SubscriptionID string
+	}
+	mock.lockFetchSubscription.RLock()
+	calls = mock.calls.FetchSubscription
+	mock.lockFetchSubscription.RUnlock()
+	return calls
+}
+
+// RenewSubscription calls RenewSubscriptionFunc.
+func (mock *ProviderMock) RenewSubscription(ctx context.Context, subscriptionID string) (Subscription, error) {
+	if mock.RenewSubscriptionFunc == nil {
+		panic("ProviderMock.RenewSubscriptionFunc: method is nil but Provider.RenewSubscription was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		SubscriptionID string
+	}{
+		Ctx:            ctx,
+		SubscriptionID: subscriptionID,
+	}
+	mock.lockRenewSubscription.Lock()
+	mock.calls.RenewSubscription = append(mock.calls.RenewSubscription, callInfo)
+	mock.lockRenewSubscription.Unlock()
+	return mock.RenewSubscriptionFunc(ctx, subscriptionID)
+}
+
+// RenewSubscriptionCalls gets all the calls that were made to RenewSubscription.
+// Check the length with:
+//
+//	len(mockedProvider.RenewSubscriptionCalls())
+func (mock *ProviderMock) RenewSubscriptionCalls() []struct {
+	Ctx            context.Context
+	SubscriptionID string
+} {
+	var calls []struct {
+		Ctx            context.Context
+		SubscriptionID string
+	}
+	mock.lockRenewSubscription.RLock()
+	calls = mock.calls.RenewSubscription
+	mock.lockRenewSubscription.RUnlock()
+	return calls
+}
+
+// SetCancelAtPeriodEnd calls SetCancelAtPeriodEndFunc.
+func (mock *ProviderMock) SetCancelAtPeriodEnd(ctx context.Context, subscriptionID string, cancel bool) error {
+	if mock.SetCancelAtPeriodEndFunc == nil {
+		panic("ProviderMock.SetCancelAtPeriodEndFunc: method is nil but Provider.SetCancelAtPeriodEnd was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		SubscriptionID string
+		Cancel         bool
+	}{
+		Ctx:            ctx,
+		SubscriptionID: subscriptionID,
+		Cancel:         cancel,
+	}
+	mock.lockSetCancelAtPeriodEnd.Lock()
+	mock.calls.SetCancelAtPeriodEnd = append(mock.calls.SetCancelAtPeriodEnd, callInfo)
+	mock.lockSetCancelAtPeriodEnd.Unlock()
+	return mock.SetCancelAtPeriodEndFunc(ctx, subscriptionID, cancel)
+}
+
+// SetCancelAtPeriodEndCalls gets all the calls that were made to SetCancelAtPeriodEnd.
+// Check the length with:
+//
+//	len(mockedProvider.SetCancelAtPeriodEndCalls())
+func (mock *ProviderMock) SetCancelAtPeriodEndCalls() []struct {
+	Ctx            context.Context
+	SubscriptionID string
+	Cancel         bool
+} {
+	var calls []struct {
+		Ctx            context.Context
+		SubscriptionID string
+		Cancel         bool
+	}
+	mock.lockSetCancelAtPeriodEnd.RLock()
+	calls = mock.calls.SetCancelAtPeriodEnd
+	mock.lockSetCancelAtPeriodEnd.RUnlock()
+	return calls
+}
+
+// VerifyWebhook calls VerifyWebhookFunc.
+func (mock *ProviderMock) VerifyWebhook(ctx context.Context, payload []byte, header http.Header) (Event, error) {
+	if mock.VerifyWebhookFunc == nil {
+		panic("ProviderMock.VerifyWebhookFunc: method is nil but Provider.VerifyWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Payload []byte
+		Header  http.Header
+	}{
+		Ctx:     ctx,
+		Payload: payload,
+		Header:  header,
+	}
+	mock.lockVerifyWebhook.Lock()
+	mock.calls.VerifyWebhook = append(mock.calls.VerifyWebhook, callInfo)
+	mock.lockVerifyWebhook.Unlock()
+	return mock.VerifyWebhookFunc(ctx, payload, header)
+}
+
+// VerifyWebhookCalls gets all the calls that were made to VerifyWebhook.
+// Check the length with:
+//
+//	len(mockedProvider.VerifyWebhookCalls())
+func (mock *ProviderMock) VerifyWebhookCalls() []struct {
+	Ctx     context.Context
+	Payload []byte
+	Header  http.Header
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Payload []byte
+		Header  http.Header
+	}
+	mock.lockVerifyWebhook.RLock()
+	calls = mock.calls.VerifyWebhook
+	mock.lockVerifyWebhook.RUnlock()
+	return calls
+}
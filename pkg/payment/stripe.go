@@ -0,0 +1,285 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const stripeSignatureHeader = "Stripe-Signature"
+
+// StripeConfig describes an account at Stripe
+type StripeConfig struct {
+	SecretKey     string
+	WebhookSecret string
+
+	// Price ID charged for each plan, by tier
+	Prices map[string]map[string]string
+
+	// Currency of the amounts taken off by discounts, lowercase ISO code
+	Currency string
+
+	// Where the checkout page sends the user back to
+	SuccessURL string
+	CancelURL  string
+
+	// Defaults to the Stripe API, overridden to test against a local server
+	BaseURL string
+}
+
+type stripeProvider struct {
+	config StripeConfig
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewStripeProvider returns a provider charging subscriptions through Stripe
+// Checkout and the Stripe subscriptions API
+func NewStripeProvider(config StripeConfig) Provider {
+	if config.BaseURL == "" {
+		config.BaseURL = "https://api.stripe.com"
+	}
+	if config.Currency == "" {
+		config.Currency = "usd"
+	}
+
+	return &stripeProvider{
+		config: config,
+		client: &http.Client{Timeout: 10 * time.Second},
+		now:    time.Now,
+	}
+}
+
+// stripeEventTypes maps the Stripe events handled by the app to its own
+var stripeEventTypes = map[string]string{
+	"invoice.payment_succeeded":     EventPaymentSucceeded,
+	"invoice.payment_failed":        EventPaymentFailed,
+	"charge.refunded":               EventPaymentRefunded,
+	"customer.subscription.deleted": EventSubscriptionCanceled,
+}
+
+type stripeSubscription struct {
+	ID                 string            `json:"id"`
+	Status             string            `json:"status"`
+	CurrentPeriodStart int64             `json:"current_period_start"`
+	CurrentPeriodEnd   int64             `json:"current_period_end"`
+	CancelAtPeriodEnd  bool              `json:"cancel_at_period_end"`
+	Metadata           map[string]string `json:"metadata"`
+
+	// Expanded when fetching the subscription
+	LatestInvoice struct {
+		AmountPaid int64  `json:"amount_paid"`
+		Currency   string `json:"currency"`
+	} `json:"latest_invoice"`
+}
+
+func (p *stripeProvider) CreateCheckout(ctx context.Context, req CheckoutRequest) (checkout Checkout, err error) {
+	price, ok := p.config.Prices[req.Tier][req.Plan]
+	if !ok || price == "" {
+		err = ErrUnknownPlan
+		return
+	}
+
+	userID := strconv.FormatInt(req.UserID, 10)
+	form := url.Values{}
+	form.Set("mode", "subscription")
+	form.Set("line_items[0][price]", price)
+	form.Set("line_items[0][quantity]", "1")
+	form.Set("success_url", p.config.SuccessURL)
+	form.Set("cancel_url", p.config.CancelURL)
+	form.Set("client_reference_id", userID)
+	form.Set("subscription_data[metadata][user_id]", userID)
+	form.Set("subscription_data[metadata][tier]", req.Tier)
+	form.Set("subscription_data[metadata][plan]", req.Plan)
+	if req.TrialDays > 0 {
+		form.Set("subscription_data[trial_period_days]", strconv.Itoa(req.TrialDays))
+	}
+	if req.PercentOff > 0 || req.AmountOff > 0 {
+		var coupon string
+		if coupon, err = p.createCoupon(ctx, req); err != nil {
+			return
+		}
+		form.Set("discounts[0][coupon]", coupon)
+	}
+
+	var session struct {
+		ID  string `json:"id"`
+		URL string `json:"url"`
+	}
+	if err = p.call(ctx, http.MethodPost, "/v1/checkout/sessions", form, &session); err != nil {
+		err = fmt.Errorf("failed to create checkout session: %w", err)
+		return
+	}
+
+	return Checkout{ID: session.ID, URL: session.URL}, nil
+}
+
+// createCoupon makes a coupon for the discount of the checkout, taken off the
+// first charge only
+func (p *stripeProvider) createCoupon(ctx context.Context, req CheckoutRequest) (couponID string, err error) {
+	form := url.Values{}
+	form.Set("duration", "once")
+	if req.PercentOff > 0 {
+		form.Set("percent_off", strconv.FormatInt(req.PercentOff, 10))
+	} else {
+		form.Set("amount_off", strconv.FormatInt(req.AmountOff, 10))
+		form.Set("currency", p.config.Currency)
+	}
+
+	var coupon struct {
+		ID string `json:"id"`
+	}
+	if err = p.call(ctx, http.MethodPost, "/v1/coupons", form, &coupon); err != nil {
+		err = fmt.Errorf("failed to create coupon: %w", err)
+		return
+	}
+
+	return coupon.ID, nil
+}
+
+func (p *stripeProvider) VerifyWebhook(ctx context.Context, payload []byte, header http.Header) (event Event, err error) {
+	if err = verifySignature(p.config.WebhookSecret, payload, header.Get(stripeSignatureHeader), p.now()); err != nil {
+		return
+	}
+
+	var raw struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+		Data struct {
+			Object struct {
+				ID           string `json:"id"`
+				Subscription string `json:"subscription"`
+				Invoice      string `json:"invoice"`
+			} `json:"object"`
+		} `json:"data"`
+	}
+	if err = json.Unmarshal(payload, &raw); err != nil {
+		err = fmt.Errorf("failed to decode webhook: %w", err)
+		return
+	}
+
+	event = Event{ID: raw.ID, Type: raw.Type}
+	eventType, ok := stripeEventTypes[raw.Type]
+	if !ok {
+		return
+	}
+	event.Type = eventType
+
+	object := raw.Data.Object
+	switch raw.Type {
+	case "customer.subscription.deleted":
+		event.SubscriptionID = object.ID
+	case "charge.refunded":
+		// Charges only point at their invoice, which knows the subscription
+		if object.Invoice == "" {
+			return
+		}
+		var invoice struct {
+			Subscription string `json:"subscription"`
+		}
+		if err = p.call(ctx, http.MethodGet, "/v1/invoices/"+url.PathEscape(object.Invoice), nil, &invoice); err != nil {
+			err = fmt.Errorf("failed to fetch refunded invoice: %w", err)
+			return
+		}
+		event.SubscriptionID = invoice.Subscription
+	default:
+		event.SubscriptionID = object.Subscription
+	}
+
+	return
+}
+
+func (p *stripeProvider) FetchSubscription(ctx context.Context, subscriptionID string) (subscription Subscription, err error) {
+	var raw stripeSubscription
+	path := "/v1/subscriptions/" + url.PathEscape(subscriptionID) + "?expand[]=latest_invoice"
+	if err = p.call(ctx, http.MethodGet, path, nil, &raw); err != nil {
+		err = fmt.Errorf("failed to fetch subscription: %w", err)
+		return
+	}
+
+	return raw.subscription(), nil
+}
+
+func (p *stripeProvider) SetCancelAtPeriodEnd(ctx context.Context, subscriptionID string, cancel bool) (err error) {
+	form := url.Values{}
+	form.Set("cancel_at_period_end", strconv.FormatBool(cancel))
+
+	if err = p.call(ctx, http.MethodPost, "/v1/subscriptions/"+url.PathEscape(subscriptionID), form, nil); err != nil {
+		err = fmt.Errorf("failed to update subscription: %w", err)
+		return
+	}
+
+	return
+}
+
+// RenewSubscription only fetches the subscription, Stripe charges renewals on
+// its own and the state it reports is what happened
+func (p *stripeProvider) RenewSubscription(ctx context.Context, subscriptionID string) (subscription Subscription, err error) {
+	return p.FetchSubscription(ctx, subscriptionID)
+}
+
+// call sends a form encoded request to the API and decodes the response into
+// out when it is not nil
+func (p *stripeProvider) call(ctx context.Context, method, path string, form url.Values, out interface{}) error {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(p.config.BaseURL, "/")+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.config.SecretKey)
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	} else if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (s stripeSubscription) subscription() Subscription {
+	userID, _ := strconv.ParseInt(s.Metadata["user_id"], 10, 64)
+
+	status := StatusCanceled
+	switch s.Status {
+	case "active", "trialing":
+		status = StatusActive
+	case "past_due", "unpaid", "incomplete":
+		status = StatusPastDue
+	}
+
+	return Subscription{
+		ID:                 s.ID,
+		UserID:             userID,
+		Tier:               s.Metadata["tier"],
+		Plan:               s.Metadata["plan"],
+		Status:             status,
+		CurrentPeriodStart: time.Unix(s.CurrentPeriodStart, 0),
+		CurrentPeriodEnd:   time.Unix(s.CurrentPeriodEnd, 0),
+		CancelAtPeriodEnd:  s.CancelAtPeriodEnd,
+		AmountPaid:         s.LatestInvoice.AmountPaid,
+		Currency:           s.LatestInvoice.Currency,
+	}
+}
@@ -0,0 +1,173 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newStripeServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/checkout/sessions", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer sk_test", r.Header.Get("Authorization"))
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "subscription", r.PostForm.Get("mode"))
+		assert.Equal(t, "price_monthly", r.PostForm.Get("line_items[0][price]"))
+		assert.Equal(t, "1", r.PostForm.Get("subscription_data[metadata][user_id]"))
+		assert.Equal(t, "plus", r.PostForm.Get("subscription_data[metadata][tier]"))
+		if coupon := r.PostForm.Get("discounts[0][coupon]"); coupon != "" {
+			assert.Equal(t, "co_1", coupon)
+			assert.Equal(t, "7", r.PostForm.Get("subscription_data[trial_period_days]"))
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id": "cs_1", "url": "https://checkout.stripe.com/cs_1"})
+	})
+	mux.HandleFunc("/v1/coupons", func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "once", r.PostForm.Get("duration"))
+		assert.Equal(t, "20", r.PostForm.Get("percent_off"))
+		json.NewEncoder(w).Encode(map[string]string{"id": "co_1"})
+	})
+	mux.HandleFunc("/v1/subscriptions/sub_1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			assert.NoError(t, r.ParseForm())
+			assert.Equal(t, "true", r.PostForm.Get("cancel_at_period_end"))
+		} else {
+			assert.Equal(t, "latest_invoice", r.URL.Query().Get("expand[]"))
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":                   "sub_1",
+			"status":               "past_due",
+			"current_period_start": 1700000000,
+			"current_period_end":   1702592000,
+			"cancel_at_period_end": false,
+			"metadata":             map[string]string{"user_id": "1", "tier": "plus", "plan": "monthly"},
+			"latest_invoice":       map[string]interface{}{"amount_paid": 999, "currency": "usd"},
+		})
+	})
+	mux.HandleFunc("/v1/invoices/in_1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"id": "in_1", "subscription": "sub_1"})
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestStripeProvider(t *testing.T) {
+	server := newStripeServer(t)
+	defer server.Close()
+
+	provider := NewStripeProvider(StripeConfig{
+		SecretKey:     "sk_test",
+		WebhookSecret: "whsec_test",
+		Prices:        map[string]map[string]string{"plus": {"monthly": "price_monthly"}},
+		BaseURL:       server.URL,
+	})
+	ctx := context.Background()
+
+	checkout, err := provider.CreateCheckout(ctx, CheckoutRequest{UserID: 1, Tier: "plus", Plan: "monthly"})
+	assert.NoError(t, err)
+	assert.Equal(t, Checkout{ID: "cs_1", URL: "https://checkout.stripe.com/cs_1"}, checkout)
+
+	// Trials and discounts go along with the checkout
+	_, err = provider.CreateCheckout(ctx, CheckoutRequest{UserID: 1, Tier: "plus", Plan: "monthly", TrialDays: 7, PercentOff: 20})
+	assert.NoError(t, err)
+
+	_, err = provider.CreateCheckout(ctx, CheckoutRequest{UserID: 1, Tier: "plus", Plan: "weekly"})
+	assert.Equal(t, ErrUnknownPlan, err)
+
+	subscription, err := provider.FetchSubscription(ctx, "sub_1")
+	assert.NoError(t, err)
+	assert.Equal(t, Subscription{
+		ID:                 "sub_1",
+		UserID:             1,
+		Tier:               "plus",
+		Plan:               "monthly",
+		Status:             StatusPastDue,
+		CurrentPeriodStart: time.Unix(1700000000, 0),
+		CurrentPeriodEnd:   time.Unix(1702592000, 0),
+		AmountPaid:         999,
+		Currency:           "usd",
+	}, subscription)
+
+	_, err = provider.FetchSubscription(ctx, "sub_2")
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	assert.NoError(t, provider.SetCancelAtPeriodEnd(ctx, "sub_1", true))
+}
+
+func TestStripeVerifyWebhook(t *testing.T) {
+	server := newStripeServer(t)
+	defer server.Close()
+
+	provider := NewStripeProvider(StripeConfig{
+		WebhookSecret: "whsec_test",
+		BaseURL:       server.URL,
+	})
+
+	tests := []struct {
+		name      string
+		payload   string
+		secret    string
+		signedAt  time.Time
+		wantEvent Event
+		wantErr   error
+	}{
+		{
+			name:      "case success invoice",
+			payload:   `{"id": "evt_1", "type": "invoice.payment_succeeded", "data": {"object": {"id": "in_1", "subscription": "sub_1"}}}`,
+			secret:    "whsec_test",
+			signedAt:  time.Now(),
+			wantEvent: Event{ID: "evt_1", Type: EventPaymentSucceeded, SubscriptionID: "sub_1"},
+		},
+		{
+			name:      "case success refunded charge",
+			payload:   `{"id": "evt_2", "type": "charge.refunded", "data": {"object": {"id": "ch_1", "invoice": "in_1"}}}`,
+			secret:    "whsec_test",
+			signedAt:  time.Now(),
+			wantEvent: Event{ID: "evt_2", Type: EventPaymentRefunded, SubscriptionID: "sub_1"},
+		},
+		{
+			name:      "case success deleted subscription",
+			payload:   `{"id": "evt_3", "type": "customer.subscription.deleted", "data": {"object": {"id": "sub_1"}}}`,
+			secret:    "whsec_test",
+			signedAt:  time.Now(),
+			wantEvent: Event{ID: "evt_3", Type: EventSubscriptionCanceled, SubscriptionID: "sub_1"},
+		},
+		{
+			name:      "case success unhandled type",
+			payload:   `{"id": "evt_4", "type": "customer.created", "data": {"object": {"id": "cus_1"}}}`,
+			secret:    "whsec_test",
+			signedAt:  time.Now(),
+			wantEvent: Event{ID: "evt_4", Type: "customer.created"},
+		},
+		{
+			name:     "case error wrong secret",
+			payload:  `{"id": "evt_1", "type": "invoice.payment_succeeded", "data": {"object": {"subscription": "sub_1"}}}`,
+			secret:   "other",
+			signedAt: time.Now(),
+			wantErr:  ErrInvalidSignature,
+		},
+		{
+			name:     "case error signed too long ago",
+			payload:  `{"id": "evt_1", "type": "invoice.payment_succeeded", "data": {"object": {"subscription": "sub_1"}}}`,
+			secret:   "whsec_test",
+			signedAt: time.Now().Add(-time.Hour),
+			wantErr:  ErrInvalidSignature,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set("Stripe-Signature", Sign(tt.secret, []byte(tt.payload), tt.signedAt))
+
+			gotEvent, gotErr := provider.VerifyWebhook(context.Background(), []byte(tt.payload), header)
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Equal(t, tt.wantEvent, gotEvent)
+		})
+	}
+}
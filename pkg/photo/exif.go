@@ -0,0 +1,119 @@
+package photo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+// EXIF tag telling how the camera was held, which viewers apply when showing
+// the photo
+const orientationTag = 0x0112
+
+// exifOrientation reads the orientation from the EXIF data of a JPEG file,
+// 1 (as stored) when there is none or it cannot be read
+func exifOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	// Segments up to the image data start with a marker and their length
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if marker == 0xDA || length < 2 || i+2+length > len(data) {
+			return 1
+		}
+
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+// tiffOrientation looks the orientation up in the first directory of the TIFF
+// structure EXIF data is kept in
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < entries; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) != orientationTag {
+			continue
+		}
+
+		orientation := int(order.Uint16(tiff[entry+8:]))
+		if orientation < 1 || orientation > 8 {
+			return 1
+		}
+		return orientation
+	}
+	return 1
+}
+
+// orient turns and flips the image the way the EXIF orientation says it is
+// meant to be shown
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	w, h := sw, sh
+	if orientation >= 5 {
+		w, h = sh, sw
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // flipped horizontally
+				sx, sy = w-1-x, y
+			case 3: // turned 180 degrees
+				sx, sy = w-1-x, h-1-y
+			case 4: // flipped vertically
+				sx, sy = x, h-1-y
+			case 5: // transposed
+				sx, sy = y, x
+			case 6: // turned 90 degrees clockwise
+				sx, sy = y, w-1-x
+			case 7: // transversed
+				sx, sy = h-1-y, w-1-x
+			case 8: // turned 90 degrees counterclockwise
+				sx, sy = h-1-y, x
+			}
+
+			s, d := src.PixOffset(sx, sy), dst.PixOffset(x, y)
+			copy(dst.Pix[d:d+4], src.Pix[s:s+4])
+		}
+	}
+	return dst
+}
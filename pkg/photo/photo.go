@@ -0,0 +1,112 @@
+package photo
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+)
+
+const (
+	// Decoding an image takes memory for every pixel however small the file
+	// is, so larger ones are turned down before they are decoded
+	MaxPixels = 25000000
+
+	// Longest side of the photo as shown on a profile, and side of the
+	// square thumbnail shown in lists. Smaller photos are not enlarged
+	FullSize      = 1600
+	ThumbnailSize = 320
+
+	jpegQuality = 85
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("photo is not a JPEG, PNG or GIF image")
+	ErrInvalid           = errors.New("photo cannot be decoded")
+	ErrTooLarge          = errors.New("photo has too many pixels")
+)
+
+// Formats accepted, told apart by the content of the file rather than the
+// name or type the client sent along
+var formats = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// Result is an uploaded photo ready to be stored, both sizes encoded as JPEG
+type Result struct {
+	Full          []byte
+	Width, Height int
+
+	Thumbnail []byte
+}
+
+// Process turns an uploaded file into the photo and thumbnail served to other
+// users. Both are encoded again from the pixels, which leaves the EXIF data
+// of the upload, like where it was taken, behind. The orientation in it is
+// applied to the pixels first so the photo is still the right way up
+func Process(data []byte) (res Result, err error) {
+	if !formats[http.DetectContentType(data)] {
+		err = ErrUnsupportedFormat
+		return
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		err = ErrInvalid
+		return
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		err = ErrInvalid
+		return
+	} else if config.Width*config.Height > MaxPixels {
+		err = ErrTooLarge
+		return
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		err = ErrInvalid
+		return
+	}
+	orientation := 1
+	if format == "jpeg" {
+		orientation = exifOrientation(data)
+	}
+
+	src := flatten(img)
+	full := orient(fit(src, FullSize), orientation)
+	thumbnail := orient(fill(src, ThumbnailSize), orientation)
+
+	if res.Full, err = encode(full); err != nil {
+		return
+	}
+	if res.Thumbnail, err = encode(thumbnail); err != nil {
+		return
+	}
+	res.Width, res.Height = full.Bounds().Dx(), full.Bounds().Dy()
+	return
+}
+
+// flatten draws the image on white, as JPEG has no transparency
+func flatten(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Over)
+	return dst
+}
+
+func encode(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,202 @@
+package photo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testJPEG encodes a w by h image, red on its left half and blue on the
+// right, with an EXIF segment holding the orientation unless it is zero
+func testJPEG(t *testing.T, w, h, orientation int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= w/2 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	if orientation == 0 {
+		return data
+	}
+
+	// Big endian TIFF with one directory entry, the orientation
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01")
+	tiff = binary.BigEndian.AppendUint16(tiff, orientationTag)
+	tiff = append(tiff, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01)
+	tiff = binary.BigEndian.AppendUint16(tiff, uint16(orientation))
+	tiff = append(tiff, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	return append(append([]byte{0xFF, 0xD8}, app1...), data[2:]...)
+}
+
+// testPNGHeader is the start of a PNG file claiming to be w by h, enough to
+// read its size but not its pixels
+func testPNGHeader(w, h int) []byte {
+	ihdr := []byte("IHDR")
+	ihdr = binary.BigEndian.AppendUint32(ihdr, uint32(w))
+	ihdr = binary.BigEndian.AppendUint32(ihdr, uint32(h))
+	ihdr = append(ihdr, 8, 6, 0, 0, 0)
+
+	data := []byte("\x89PNG\r\n\x1a\n")
+	data = binary.BigEndian.AppendUint32(data, uint32(len(ihdr)-4))
+	data = append(data, ihdr...)
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(ihdr))
+}
+
+func TestExifOrientation(t *testing.T) {
+	assert.Equal(t, 6, exifOrientation(testJPEG(t, 4, 2, 6)))
+	assert.Equal(t, 3, exifOrientation(testJPEG(t, 4, 2, 3)))
+	assert.Equal(t, 1, exifOrientation(testJPEG(t, 4, 2, 0)))
+	assert.Equal(t, 1, exifOrientation([]byte("not a jpeg")))
+	assert.Equal(t, 1, exifOrientation([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0xFF}))
+}
+
+func TestOrient(t *testing.T) {
+	// 2 by 1, red then blue
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	src.Set(0, 0, color.RGBA{R: 255, A: 255})
+	src.Set(1, 0, color.RGBA{B: 255, A: 255})
+
+	red, blue := color.RGBA{R: 255, A: 255}, color.RGBA{B: 255, A: 255}
+	tests := []struct {
+		orientation int
+		want        [][]color.RGBA
+	}{
+		{orientation: 1, want: [][]color.RGBA{{red, blue}}},
+		{orientation: 2, want: [][]color.RGBA{{blue, red}}},
+		{orientation: 3, want: [][]color.RGBA{{blue, red}}},
+		{orientation: 4, want: [][]color.RGBA{{red, blue}}},
+		{orientation: 5, want: [][]color.RGBA{{red}, {blue}}},
+		{orientation: 6, want: [][]color.RGBA{{red}, {blue}}},
+		{orientation: 7, want: [][]color.RGBA{{blue}, {red}}},
+		{orientation: 8, want: [][]color.RGBA{{blue}, {red}}},
+	}
+	for _, tt := range tests {
+		got := orient(src, tt.orientation)
+		if assert.Equal(t, image.Pt(len(tt.want[0]), len(tt.want)), got.Bounds().Size(), "orientation %d", tt.orientation) {
+			for y, row := range tt.want {
+				for x, want := range row {
+					assert.Equal(t, want, got.RGBAAt(x, y), "orientation %d at %d,%d", tt.orientation, x, y)
+				}
+			}
+		}
+	}
+}
+
+func TestProcess(t *testing.T) {
+	transparent := image.NewNRGBA(image.Rect(0, 0, 3000, 1000))
+	var transparentPNG bytes.Buffer
+	if err := png.Encode(&transparentPNG, transparent); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		data          []byte
+		wantWidth     int
+		wantHeight    int
+		wantThumbnail int
+		wantErr       error
+	}{
+		{
+			name:          "case success small",
+			data:          testJPEG(t, 200, 100, 0),
+			wantWidth:     200,
+			wantHeight:    100,
+			wantThumbnail: 100,
+		},
+		{
+			name:          "case success turned",
+			data:          testJPEG(t, 400, 200, 6),
+			wantWidth:     200,
+			wantHeight:    400,
+			wantThumbnail: 200,
+		},
+		{
+			name:          "case success scaled down",
+			data:          transparentPNG.Bytes(),
+			wantWidth:     FullSize,
+			wantHeight:    533,
+			wantThumbnail: ThumbnailSize,
+		},
+		{
+			name:    "case error unsupported format",
+			data:    []byte("<svg></svg>"),
+			wantErr: ErrUnsupportedFormat,
+		},
+		{
+			name:    "case error too many pixels",
+			data:    testPNGHeader(10000, 10000),
+			wantErr: ErrTooLarge,
+		},
+		{
+			name:    "case error truncated",
+			data:    testPNGHeader(100, 100),
+			wantErr: ErrInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotErr := Process(tt.data)
+			assert.Equal(t, tt.wantErr, gotErr)
+			if tt.wantErr != nil {
+				return
+			}
+
+			assert.Equal(t, tt.wantWidth, got.Width)
+			assert.Equal(t, tt.wantHeight, got.Height)
+
+			full, err := jpeg.Decode(bytes.NewReader(got.Full))
+			if assert.NoError(t, err) {
+				assert.Equal(t, image.Pt(tt.wantWidth, tt.wantHeight), full.Bounds().Size())
+			}
+			thumbnail, err := jpeg.Decode(bytes.NewReader(got.Thumbnail))
+			if assert.NoError(t, err) {
+				assert.Equal(t, image.Pt(tt.wantThumbnail, tt.wantThumbnail), thumbnail.Bounds().Size())
+			}
+
+			// Nothing of the EXIF data is kept
+			assert.False(t, bytes.Contains(got.Full, []byte("Exif")))
+			assert.Equal(t, 1, exifOrientation(got.Full))
+		})
+	}
+}
+
+func TestProcessKeepsColors(t *testing.T) {
+	// Red on the left turns to the top once the photo is turned clockwise
+	got, err := Process(testJPEG(t, 400, 200, 6))
+	if !assert.NoError(t, err) {
+		return
+	}
+	img, err := jpeg.Decode(bytes.NewReader(got.Full))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	r, _, b, _ := img.At(100, 50).RGBA()
+	assert.True(t, r > b, "top should be red")
+	r, _, b, _ = img.At(100, 350).RGBA()
+	assert.True(t, b > r, "bottom should be blue")
+}
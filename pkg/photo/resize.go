@@ -0,0 +1,77 @@
+package photo
+
+import "image"
+
+// fit scales the image down until its longest side is at most size
+func fit(src *image.RGBA, size int) *image.RGBA {
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	if w <= size && h <= size {
+		return src
+	}
+
+	if w >= h {
+		w, h = size, max(1, (h*size+w/2)/w)
+	} else {
+		w, h = max(1, (w*size+h/2)/h), size
+	}
+	return scale(src, src.Bounds(), w, h)
+}
+
+// fill crops the middle square of the image and scales it down to size
+func fill(src *image.RGBA, size int) *image.RGBA {
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	side := min(w, h)
+	crop := image.Rect(0, 0, side, side).Add(image.Pt((w-side)/2, (h-side)/2))
+	return scale(src, crop, min(side, size), min(side, size))
+}
+
+// scale resizes the part of src within r to w by h, every pixel taking the
+// average of the pixels it covers. Only meant for scaling down
+func scale(src *image.RGBA, r image.Rectangle, w, h int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	sw, sh := r.Dx(), r.Dy()
+
+	for y := 0; y < h; y++ {
+		y0 := r.Min.Y + y*sh/h
+		y1 := max(y0+1, r.Min.Y+(y+1)*sh/h)
+
+		for x := 0; x < w; x++ {
+			x0 := r.Min.X + x*sw/w
+			x1 := max(x0+1, r.Min.X+(x+1)*sw/w)
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				i := src.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					sum[0] += int(src.Pix[i])
+					sum[1] += int(src.Pix[i+1])
+					sum[2] += int(src.Pix[i+2])
+					sum[3] += int(src.Pix[i+3])
+					i += 4
+				}
+			}
+
+			n := (x1 - x0) * (y1 - y0)
+			d := dst.PixOffset(x, y)
+			for c := range sum {
+				dst.Pix[d+c] = uint8((sum[c] + n/2) / n)
+			}
+		}
+	}
+
+	return dst
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
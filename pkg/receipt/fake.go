@@ -0,0 +1,233 @@
+package receipt
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const fakeSignatureHeader = "Notification-Signature"
+
+// Notifications signed longer ago than this are turned down so a captured
+// request cannot be replayed later
+const signatureTolerance = 5 * time.Minute
+
+// Notice is a signed server notification as the store would send it
+type Notice struct {
+	Payload []byte
+	Header  http.Header
+}
+
+type fakeNotification struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	PurchaseID string `json:"purchase_id"`
+	SignedAt   int64  `json:"signed_at"`
+}
+
+// FakeVerifier keeps purchases in memory for both stores. Nothing is ever
+// charged, the methods past the Verifier ones play the part of the user and
+// the store and return the notification that would follow
+type FakeVerifier struct {
+	NotificationSecret string
+
+	// How many months a period of each product lasts
+	Periods map[string]int
+
+	// Amount charged for each product in the smallest unit of Currency,
+	// nothing is charged for the ones missing
+	Prices   map[string]int64
+	Currency string
+
+	mu        sync.Mutex
+	seq       int
+	receipts  map[string]string
+	purchases map[string]Purchase
+}
+
+// NewFakeVerifier returns a local stand-in verifier for tests and for running
+// the app without store accounts
+func NewFakeVerifier(notificationSecret string, periods map[string]int) *FakeVerifier {
+	return &FakeVerifier{
+		NotificationSecret: notificationSecret,
+		Periods:            periods,
+		Currency:           "usd",
+		receipts:           make(map[string]string),
+		purchases:          make(map[string]Purchase),
+	}
+}
+
+func (v *FakeVerifier) VerifyReceipt(ctx context.Context, store, receipt string) (purchase Purchase, err error) {
+	if store != StoreAppStore && store != StorePlay {
+		err = ErrUnknownStore
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	purchaseID, ok := v.receipts[receipt]
+	if !ok || v.purchases[purchaseID].Store != store {
+		err = ErrInvalidReceipt
+		return
+	}
+
+	return v.purchases[purchaseID], nil
+}
+
+func (v *FakeVerifier) FetchPurchase(ctx context.Context, store, purchaseID string) (purchase Purchase, err error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	purchase, ok := v.purchases[purchaseID]
+	if !ok || purchase.Store != store {
+		err = ErrNotFound
+	}
+	return
+}
+
+func (v *FakeVerifier) ParseNotification(ctx context.Context, store string, payload []byte, header http.Header) (notification Notification, err error) {
+	if store != StoreAppStore && store != StorePlay {
+		err = ErrUnknownStore
+		return
+	}
+
+	expected := v.sign(payload)
+	if !hmac.Equal([]byte(header.Get(fakeSignatureHeader)), []byte(expected)) {
+		err = ErrInvalidSignature
+		return
+	}
+
+	var raw fakeNotification
+	if err = json.Unmarshal(payload, &raw); err != nil {
+		err = fmt.Errorf("failed to decode notification: %w", err)
+		return
+	}
+
+	age := time.Since(time.Unix(raw.SignedAt, 0))
+	if age > signatureTolerance || age < -signatureTolerance {
+		err = ErrInvalidSignature
+		return
+	}
+
+	return Notification{ID: raw.ID, Type: raw.Type, PurchaseID: raw.PurchaseID}, nil
+}
+
+// Buy subscribes to the product in the store, returning the receipt the app
+// would send
+func (v *FakeVerifier) Buy(store, productID string) (receipt string, err error) {
+	if _, ok := v.Periods[productID]; !ok {
+		err = ErrInvalidReceipt
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := time.Now()
+	purchaseID := v.nextID("purchase")
+	v.purchases[purchaseID] = Purchase{
+		ID:          purchaseID,
+		Store:       store,
+		ProductID:   productID,
+		Status:      StatusActive,
+		PurchasedAt: now,
+		ExpiresAt:   now.AddDate(0, v.Periods[productID], 0),
+		AutoRenew:   true,
+		Price:       v.Prices[productID],
+		Currency:    v.Currency,
+	}
+
+	receipt = v.nextID("receipt")
+	v.receipts[receipt] = purchaseID
+	return
+}
+
+// Renew charges the purchase for the period after the current one
+func (v *FakeVerifier) Renew(purchaseID string) (Notice, error) {
+	return v.update(purchaseID, NotificationRenewed, func(purchase *Purchase) {
+		purchase.Status = StatusActive
+		purchase.ExpiresAt = purchase.ExpiresAt.AddDate(0, v.Periods[purchase.ProductID], 0)
+		purchase.Price = v.Prices[purchase.ProductID]
+	})
+}
+
+// EnterGracePeriod fails to renew the purchase, keeping the user entitled for
+// the days of the grace period
+func (v *FakeVerifier) EnterGracePeriod(purchaseID string, days int) (Notice, error) {
+	return v.update(purchaseID, NotificationGracePeriod, func(purchase *Purchase) {
+		purchase.Status = StatusGracePeriod
+		purchase.ExpiresAt = time.Now().AddDate(0, 0, days)
+	})
+}
+
+// SetAutoRenew turns renewing on or off, as the user would in the store
+func (v *FakeVerifier) SetAutoRenew(purchaseID string, autoRenew bool) (Notice, error) {
+	return v.update(purchaseID, NotificationRenewalChanged, func(purchase *Purchase) {
+		purchase.AutoRenew = autoRenew
+	})
+}
+
+// Expire ends the purchase
+func (v *FakeVerifier) Expire(purchaseID string) (Notice, error) {
+	return v.update(purchaseID, NotificationExpired, func(purchase *Purchase) {
+		purchase.Status = StatusExpired
+		purchase.ExpiresAt = time.Now()
+		purchase.AutoRenew = false
+	})
+}
+
+// Refund gives the last payment back, which ends the purchase
+func (v *FakeVerifier) Refund(purchaseID string) (Notice, error) {
+	return v.update(purchaseID, NotificationRefunded, func(purchase *Purchase) {
+		purchase.Status = StatusRefunded
+		purchase.AutoRenew = false
+	})
+}
+
+func (v *FakeVerifier) update(purchaseID, notificationType string, f func(purchase *Purchase)) (notice Notice, err error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	purchase, ok := v.purchases[purchaseID]
+	if !ok {
+		err = ErrNotFound
+		return
+	}
+
+	f(&purchase)
+	v.purchases[purchaseID] = purchase
+
+	payload, err := json.Marshal(fakeNotification{
+		ID:         v.nextID("notification"),
+		Type:       notificationType,
+		PurchaseID: purchaseID,
+		SignedAt:   time.Now().Unix(),
+	})
+	if err != nil {
+		return
+	}
+
+	header := http.Header{}
+	header.Set(fakeSignatureHeader, v.sign(payload))
+
+	return Notice{Payload: payload, Header: header}, nil
+}
+
+func (v *FakeVerifier) sign(payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(v.NotificationSecret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// nextID returns a new ID, the caller holds the lock
+func (v *FakeVerifier) nextID(prefix string) string {
+	v.seq++
+	return fmt.Sprintf("%s_fake_%d", prefix, v.seq)
+}
@@ -0,0 +1,67 @@
+package receipt
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeVerifier(t *testing.T) {
+	verifier := NewFakeVerifier("secret", map[string]int{"plus_monthly": 1})
+	verifier.Prices = map[string]int64{"plus_monthly": 999}
+	ctx := context.Background()
+
+	_, err := verifier.Buy(StoreAppStore, "plus_weekly")
+	assert.Equal(t, ErrInvalidReceipt, err)
+
+	receipt, err := verifier.Buy(StoreAppStore, "plus_monthly")
+	assert.NoError(t, err)
+
+	purchase, err := verifier.VerifyReceipt(ctx, StoreAppStore, receipt)
+	assert.NoError(t, err)
+	assert.Equal(t, "plus_monthly", purchase.ProductID)
+	assert.Equal(t, StatusActive, purchase.Status)
+	assert.Equal(t, purchase.PurchasedAt.AddDate(0, 1, 0), purchase.ExpiresAt)
+	assert.Equal(t, int64(999), purchase.Price)
+	assert.Equal(t, "usd", purchase.Currency)
+
+	// Receipts are only valid for the store they came from
+	_, err = verifier.VerifyReceipt(ctx, StorePlay, receipt)
+	assert.Equal(t, ErrInvalidReceipt, err)
+	_, err = verifier.VerifyReceipt(ctx, "web", receipt)
+	assert.Equal(t, ErrUnknownStore, err)
+
+	notice, err := verifier.Renew(purchase.ID)
+	assert.NoError(t, err)
+	notification, err := verifier.ParseNotification(ctx, StoreAppStore, notice.Payload, notice.Header)
+	assert.NoError(t, err)
+	assert.Equal(t, NotificationRenewed, notification.Type)
+	assert.Equal(t, purchase.ID, notification.PurchaseID)
+	renewed, _ := verifier.FetchPurchase(ctx, StoreAppStore, purchase.ID)
+	assert.Equal(t, purchase.ExpiresAt.AddDate(0, 1, 0), renewed.ExpiresAt)
+
+	_, err = verifier.EnterGracePeriod(purchase.ID, 3)
+	assert.NoError(t, err)
+	purchase, _ = verifier.FetchPurchase(ctx, StoreAppStore, purchase.ID)
+	assert.Equal(t, StatusGracePeriod, purchase.Status)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, 3), purchase.ExpiresAt, time.Minute)
+
+	_, err = verifier.SetAutoRenew(purchase.ID, false)
+	assert.NoError(t, err)
+	purchase, _ = verifier.FetchPurchase(ctx, StoreAppStore, purchase.ID)
+	assert.False(t, purchase.AutoRenew)
+
+	notice, err = verifier.Refund(purchase.ID)
+	assert.NoError(t, err)
+	purchase, _ = verifier.FetchPurchase(ctx, StoreAppStore, purchase.ID)
+	assert.Equal(t, StatusRefunded, purchase.Status)
+
+	// A tampered notification is turned down
+	_, err = verifier.ParseNotification(ctx, StoreAppStore, append(notice.Payload, ' '), notice.Header)
+	assert.Equal(t, ErrInvalidSignature, err)
+
+	_, err = verifier.FetchPurchase(ctx, StorePlay, purchase.ID)
+	assert.Equal(t, ErrNotFound, err)
+}
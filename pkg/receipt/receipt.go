@@ -0,0 +1,89 @@
+package receipt
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Stores selling subscriptions in the mobile apps
+const (
+	StoreAppStore = "app_store"
+	StorePlay     = "play"
+)
+
+// Purchase statuses as seen by the store
+const (
+	StatusActive = "active"
+
+	// Renewing failed but the store keeps the user entitled while it retries
+	StatusGracePeriod = "grace_period"
+
+	// Renewing failed and the user is no longer entitled while the store
+	// retries
+	StatusBillingRetry = "billing_retry"
+
+	StatusExpired  = "expired"
+	StatusRefunded = "refunded"
+)
+
+// Server notifications the app acts on, whatever the store calls them
+const (
+	NotificationRenewed        = "renewed"
+	NotificationGracePeriod    = "grace_period"
+	NotificationBillingRetry   = "billing_retry"
+	NotificationRenewalChanged = "renewal_changed"
+	NotificationExpired        = "expired"
+	NotificationRefunded       = "refunded"
+)
+
+var (
+	ErrInvalidReceipt   = errors.New("receipt is invalid")
+	ErrInvalidSignature = errors.New("notification signature is invalid")
+	ErrUnknownStore     = errors.New("store is unknown")
+	ErrNotFound         = errors.New("not found at the store")
+)
+
+// Purchase is a subscription bought in a store. Renewals keep the ID of the
+// first purchase, the original transaction ID of the App Store and the
+// purchase token of Play
+type Purchase struct {
+	ID        string
+	Store     string
+	ProductID string
+	Status    string
+
+	PurchasedAt time.Time
+
+	// Until when the user is entitled, the end of the grace period while in
+	// one
+	ExpiresAt time.Time
+
+	AutoRenew bool
+
+	// Last payment for the purchase in the smallest currency unit
+	Price    int64
+	Currency string
+}
+
+type Notification struct {
+	ID         string
+	Type       string
+	PurchaseID string
+}
+
+// go:generate moq -rm -out receipt_mock.go . Verifier
+type Verifier interface {
+	// VerifyReceipt checks a receipt the app got from the store and returns
+	// the purchase it is for
+	VerifyReceipt(ctx context.Context, store, receipt string) (Purchase, error)
+
+	// FetchPurchase returns the current state of the purchase at the store
+	FetchPurchase(ctx context.Context, store, purchaseID string) (Purchase, error)
+
+	// ParseNotification checks that a server notification came from the
+	// store. Types the app does not act on are returned as the store named
+	// them
+	ParseNotification(ctx context.Context, store string, payload []byte, header http.Header) (Notification, error)
+}
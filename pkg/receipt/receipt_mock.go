@@ -0,0 +1,206 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package receipt
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// Ensure, that VerifierMock does implement Verifier.
+// If this is not the case, regenerate this file with moq.
+var _ Verifier = &VerifierMock{}
+
+// VerifierMock is a mock implementation of Verifier.
+//
+//	func TestSomethingThatUsesVerifier(t *testing.T) {
+//
+//		// make and configure a mocked Verifier
+//		mockedVerifier := &VerifierMock{
+//			FetchPurchaseFunc: func(ctx context.Context, store string, purchaseID string) (Purchase, error) {
+//				panic("mock out the FetchPurchase method")
+//			},
+//			ParseNotificationFunc: func(ctx context.Context, store string, payload []byte, header http.Header) (Notification, error) {
+//				panic("mock out the ParseNotification method")
+//			},
+//			VerifyReceiptFunc: func(ctx context.Context, store string, receipt string) (Purchase, error) {
+//				panic("mock out the VerifyReceipt method")
+//			},
+//		}
+//
+//		// use mockedVerifier in code that requires Verifier
+//		// and then make assertions.
+//
+//	}
+type VerifierMock struct {
+	// FetchPurchaseFunc mocks the FetchPurchase method.
+	FetchPurchaseFunc func(ctx context.Context, store string, purchaseID string) (Purchase, error)
+
+	// ParseNotificationFunc mocks the ParseNotification method.
+	ParseNotificationFunc func(ctx context.Context, store string, payload []byte, header http.Header) (Notification, error)
+
+	// VerifyReceiptFunc mocks the VerifyReceipt method.
+	VerifyReceiptFunc func(ctx context.Context, store string, receipt string) (Purchase, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// FetchPurchase holds details about calls to the FetchPurchase method.
+		FetchPurchase []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Store is the store argument value.
+			Store string
+			// PurchaseID is the purchaseID argument value.
+			PurchaseID string
+		}
+		// ParseNotification holds details about calls to the ParseNotification method.
+		ParseNotification []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Store is the store argument value.
+			Store string
+			// Payload is the payload argument value.
+			Payload []byte
+			// Header is the header argument value.
+			Header http.Header
+		}
+		// VerifyReceipt holds details about calls to the VerifyReceipt method.
+		VerifyReceipt []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Store is the store argument value.
+			Store string
+			// Receipt is the receipt argument value.
+			Receipt string
+		}
+	}
+	lockFetchPurchase     sync.RWMutex
+	lockParseNotification sync.RWMutex
+	lockVerifyReceipt     sync.RWMutex
+}
+
+// FetchPurchase calls FetchPurchaseFunc.
+func (mock *VerifierMock) FetchPurchase(ctx context.Context, store string, purchaseID string) (Purchase, error) {
+	if mock.FetchPurchaseFunc == nil {
+		panic("VerifierMock.FetchPurchaseFunc: method is nil but Verifier.FetchPurchase was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Store      string
+		PurchaseID string
+	}{
+		Ctx:        ctx,
+		Store:      store,
+		PurchaseID: purchaseID,
+	}
+	mock.lockFetchPurchase.Lock()
+	mock.calls.FetchPurchase = append(mock.calls.FetchPurchase, callInfo)
+	mock.lockFetchPurchase.Unlock()
+	return mock.FetchPurchaseFunc(ctx, store, purchaseID)
+}
+
+// FetchPurchaseCalls gets all the calls that were made to FetchPurchase.
+// Check the length with:
+//
+//	len(mockedVerifier.FetchPurchaseCalls())
+func (mock *VerifierMock) FetchPurchaseCalls() []struct {
+	Ctx        context.Context
+	Store      string
+	PurchaseID string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Store      string
+		PurchaseID string
+	}
+	mock.lockFetchPurchase.RLock()
+	calls = mock.calls.FetchPurchase
+	mock.lockFetchPurchase.RUnlock()
+	return calls
+}
+
+// ParseNotification calls ParseNotificationFunc.
+func (mock *VerifierMock) ParseNotification(ctx context.Context, store string, payload []byte, header http.Header) (Notification, error) {
+	if mock.ParseNotificationFunc == nil {
+		panic("VerifierMock.ParseNotificationFunc: method is nil but Verifier.ParseNotification was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Store   string
+		Payload []byte
+		Header  http.Header
+	}{
+		Ctx:     ctx,
+		Store:   store,
+		Payload: payload,
+		Header:  header,
+	}
+	mock.lockParseNotification.Lock()
+	mock.calls.ParseNotification = append(mock.calls.ParseNotification, callInfo)
+	mock.lockParseNotification.Unlock()
+	return mock.ParseNotificationFunc(ctx, store, payload, header)
+}
+
+// ParseNotificationCalls gets all the calls that were made to ParseNotification.
+// Check the length with:
+//
+//	len(mockedVerifier.ParseNotificationCalls())
+func (mock *VerifierMock) ParseNotificationCalls() []struct {
+	Ctx     context.Context
+	Store   string
+	Payload []byte
+	Header  http.Header
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Store   string
+		Payload []byte
+		Header  http.Header
+	}
+	mock.lockParseNotification.RLock()
+	calls = mock.calls.ParseNotification
+	mock.lockParseNotification.RUnlock()
+	return calls
+}
+
+// VerifyReceipt calls VerifyReceiptFunc.
+func (mock *VerifierMock) VerifyReceipt(ctx context.Context, store string, receipt string) (Purchase, error) {
+	if mock.VerifyReceiptFunc == nil {
+		panic("VerifierMock.VerifyReceiptFunc: method is nil but Verifier.VerifyReceipt was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Store   string
+		Receipt string
+	}{
+		Ctx:     ctx,
+		Store:   store,
+		Receipt: receipt,
+	}
+	mock.lockVerifyReceipt.Lock()
+	mock.calls.VerifyReceipt = append(mock.calls.VerifyReceipt, callInfo)
+	mock.lockVerifyReceipt.Unlock()
+	return mock.VerifyReceiptFunc(ctx, store, receipt)
+}
+
+// VerifyReceiptCalls gets all the calls that were made to VerifyReceipt.
+// Check the length with:
+//
+//	len(mockedVerifier.VerifyReceiptCalls())
+func (mock *VerifierMock) VerifyReceiptCalls() []struct {
+	Ctx     context.Context
+	Store   string
+	Receipt string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Store   string
+		Receipt string
+	}
+	mock.lockVerifyReceipt.RLock()
+	calls = mock.calls.VerifyReceipt
+	mock.lockVerifyReceipt.RUnlock()
+	return calls
+}
@@ -0,0 +1,130 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/egnptr/dating-app/pkg/util"
+)
+
+// Locker holds a lock shared by every instance of the app, Redis in
+// production
+type Locker interface {
+	// AcquireLock takes the lock for the owner until the ttl passes, or
+	// extends it when the owner holds it already
+	AcquireLock(ctx context.Context, name, owner string, ttl time.Duration) (acquired bool, err error)
+	ReleaseLock(ctx context.Context, name, owner string) (err error)
+}
+
+// Ticks the leader can miss before its lock expires and another instance
+// takes over
+const leaseTicks = 3
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context, now time.Time) error
+	next     time.Time
+}
+
+// Scheduler runs jobs periodically on one instance of the app at a time. On
+// every tick each instance tries to take or keep the leader lock, and only the
+// leader runs the jobs that are due. Jobs may still run twice around a change
+// of leader, so they have to be safe to repeat
+type Scheduler struct {
+	locker Locker
+	name   string
+	owner  string
+	tick   time.Duration
+
+	mu     sync.Mutex
+	jobs   []*job
+	leader bool
+}
+
+// New returns a scheduler electing its leader through the lock of the name,
+// checking for due jobs on every tick
+func New(locker Locker, name string, tick time.Duration) *Scheduler {
+	owner, err := util.GenerateToken(16)
+	if err != nil {
+		owner = time.Now().String()
+	}
+
+	return &Scheduler{
+		locker: locker,
+		name:   name,
+		owner:  owner,
+		tick:   tick,
+	}
+}
+
+// Every registers a job run once per interval. A new leader runs every job
+// on its first tick as it cannot tell when the last leader ran them
+func (s *Scheduler) Every(name string, interval time.Duration, run func(ctx context.Context, now time.Time) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs = append(s.jobs, &job{name: name, interval: interval, run: run})
+}
+
+// Run checks for due jobs until ctx is done, then lets go of the leader lock
+// so another instance takes over right away
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.tick)
+	defer ticker.Stop()
+
+	s.step(ctx, time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			s.mu.Lock()
+			leader := s.leader
+			s.mu.Unlock()
+			if leader {
+				if err := s.locker.ReleaseLock(context.Background(), s.name, s.owner); err != nil {
+					log.Println("error when releasing scheduler lock")
+				}
+			}
+			return
+		case now := <-ticker.C:
+			s.step(ctx, now)
+		}
+	}
+}
+
+// step takes or keeps the leader lock and runs the jobs due at the time
+func (s *Scheduler) step(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	leader, err := s.locker.AcquireLock(ctx, s.name, s.owner, leaseTicks*s.tick)
+	if err != nil {
+		log.Println("error when acquiring scheduler lock")
+		leader = false
+	}
+
+	// Jobs start over on the next leadership, whoever ran them meanwhile
+	if !leader {
+		if s.leader {
+			for _, job := range s.jobs {
+				job.next = time.Time{}
+			}
+		}
+		s.leader = false
+		return
+	}
+	s.leader = true
+
+	for _, job := range s.jobs {
+		if now.Before(job.next) {
+			continue
+		}
+		job.next = now.Add(job.interval)
+
+		if err := job.run(ctx, now); err != nil {
+			log.Println("error when running scheduled job: ", job.name)
+		}
+	}
+}
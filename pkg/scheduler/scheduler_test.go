@@ -0,0 +1,113 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeLocker holds locks in memory, ignoring their ttl
+type fakeLocker struct {
+	mu     sync.Mutex
+	owners map[string]string
+	err    error
+}
+
+func (l *fakeLocker) AcquireLock(ctx context.Context, name, owner string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.err != nil {
+		return false, l.err
+	}
+	if current, ok := l.owners[name]; ok && current != owner {
+		return false, nil
+	}
+	l.owners[name] = owner
+	return true, nil
+}
+
+func (l *fakeLocker) ReleaseLock(ctx context.Context, name, owner string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.owners[name] == owner {
+		delete(l.owners, name)
+	}
+	return nil
+}
+
+func TestSchedulerStep(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		now    = time.Now()
+		locker = &fakeLocker{owners: make(map[string]string)}
+		first  = New(locker, "leader", time.Second)
+		second = New(locker, "leader", time.Second)
+		runs   = make(map[string]int)
+	)
+	for _, s := range []*Scheduler{first, second} {
+		s.Every("minutely", time.Minute, func(ctx context.Context, now time.Time) error {
+			runs["minutely"]++
+			return nil
+		})
+		s.Every("failing", time.Hour, func(ctx context.Context, now time.Time) error {
+			runs["failing"]++
+			return errors.New("err")
+		})
+	}
+
+	// Only the leader runs the jobs, each once per interval
+	first.step(ctx, now)
+	second.step(ctx, now)
+	assert.Equal(t, map[string]int{"minutely": 1, "failing": 1}, runs)
+
+	first.step(ctx, now.Add(30*time.Second))
+	assert.Equal(t, map[string]int{"minutely": 1, "failing": 1}, runs)
+
+	first.step(ctx, now.Add(time.Minute))
+	assert.Equal(t, map[string]int{"minutely": 2, "failing": 1}, runs)
+
+	// Another instance takes over once the leader lets go, running every job
+	assert.NoError(t, locker.ReleaseLock(ctx, "leader", first.owner))
+	second.step(ctx, now.Add(90*time.Second))
+	first.step(ctx, now.Add(2*time.Minute))
+	assert.Equal(t, map[string]int{"minutely": 3, "failing": 2}, runs)
+	assert.True(t, second.leader)
+	assert.False(t, first.leader)
+
+	// Nobody runs the jobs while the lock cannot be checked
+	locker.err = errors.New("err")
+	second.step(ctx, now.Add(time.Hour))
+	assert.Equal(t, map[string]int{"minutely": 3, "failing": 2}, runs)
+	assert.False(t, second.leader)
+}
+
+func TestSchedulerRun(t *testing.T) {
+	locker := &fakeLocker{owners: make(map[string]string)}
+	s := New(locker, "leader", time.Millisecond)
+
+	ran := make(chan struct{}, 1)
+	s.Every("job", time.Hour, func(ctx context.Context, now time.Time) error {
+		ran <- struct{}{}
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	<-ran
+	cancel()
+	<-done
+
+	// The lock is let go on the way out
+	assert.Empty(t, locker.owners)
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStore keeps blobs as files in a directory, for running the app on a
+// single machine. The app serves them itself through Handler
+type LocalStore struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalStore returns a store writing to dir, whose blobs are downloaded
+// from under baseURL
+func NewLocalStore(dir, baseURL string) *LocalStore {
+	return &LocalStore{
+		dir:     dir,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+func (s *LocalStore) Put(ctx context.Context, key, contentType string, body []byte) (err error) {
+	if !validKey(key) {
+		return ErrInvalidKey
+	}
+
+	name := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err = os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return
+	}
+
+	// Written aside and moved in place so a half written file is never served
+	file, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(file.Name())
+
+	if _, err = file.Write(body); err != nil {
+		file.Close()
+		return
+	}
+	if err = file.Close(); err != nil {
+		return
+	}
+	if err = os.Chmod(file.Name(), 0o644); err != nil {
+		return
+	}
+	return os.Rename(file.Name(), name)
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) (err error) {
+	if !validKey(key) {
+		return ErrInvalidKey
+	}
+
+	err = os.Remove(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	return
+}
+
+func (s *LocalStore) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// Handler serves the blobs by their key, without listing directories
+func (s *LocalStore) Handler() http.Handler {
+	return http.FileServer(filesOnly{http.Dir(s.dir)})
+}
+
+// filesOnly hides directories so their content cannot be listed
+type filesOnly struct {
+	fs http.FileSystem
+}
+
+func (f filesOnly) Open(name string) (http.File, error) {
+	file, err := f.fs.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if info.IsDir() || strings.HasPrefix(info.Name(), ".") {
+		file.Close()
+		return nil, os.ErrNotExist
+	}
+	return file, nil
+}
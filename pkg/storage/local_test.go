@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStore(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		dir   = t.TempDir()
+		store = NewLocalStore(dir, "http://localhost:8080/media/")
+	)
+
+	assert.NoError(t, store.Put(ctx, "photos/1/abc.jpg", "image/jpeg", []byte("jpeg")))
+	got, err := os.ReadFile(filepath.Join(dir, "photos", "1", "abc.jpg"))
+	assert.NoError(t, err)
+	assert.Equal(t, "jpeg", string(got))
+	assert.Equal(t, "http://localhost:8080/media/photos/1/abc.jpg", store.URL("photos/1/abc.jpg"))
+
+	// Blobs are served by key, directories are not listed
+	tests := []struct {
+		url      string
+		wantCode int
+		wantBody string
+	}{
+		{url: "/photos/1/abc.jpg", wantCode: 200, wantBody: "jpeg"},
+		{url: "/photos/1/", wantCode: 404},
+		{url: "/photos/1/missing.jpg", wantCode: 404},
+	}
+	for _, tt := range tests {
+		recorder := httptest.NewRecorder()
+		store.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.url, nil))
+		assert.Equal(t, tt.wantCode, recorder.Code, tt.url)
+		if tt.wantBody != "" {
+			assert.Equal(t, tt.wantBody, recorder.Body.String(), tt.url)
+		}
+	}
+
+	assert.NoError(t, store.Delete(ctx, "photos/1/abc.jpg"))
+	_, err = os.Stat(filepath.Join(dir, "photos", "1", "abc.jpg"))
+	assert.True(t, os.IsNotExist(err))
+	assert.NoError(t, store.Delete(ctx, "photos/1/abc.jpg"))
+
+	// Keys cannot leave the directory
+	for _, key := range []string{"", "../secret", "/etc/passwd", "photos/../../secret", "photos//1"} {
+		assert.Equal(t, ErrInvalidKey, store.Put(ctx, key, "image/jpeg", []byte("jpeg")), key)
+		assert.Equal(t, ErrInvalidKey, store.Delete(ctx, key), key)
+	}
+}
@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+type S3Config struct {
+	// Where the S3 API is reached, e.g. https://s3.us-east-1.amazonaws.com or
+	// http://localhost:9000 for MinIO. Buckets are addressed by path
+	Endpoint string
+	Region   string
+	Bucket   string
+
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// Where clients download blobs from, e.g. a CDN in front of the bucket.
+	// Without it they go to the bucket itself, which then has to allow
+	// reading by anyone
+	PublicURL string
+}
+
+// S3Store keeps blobs in a bucket of S3 or a service speaking its API, like
+// MinIO, signing requests with AWS Signature Version 4
+type S3Store struct {
+	config S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewS3Store(config S3Config) *S3Store {
+	config.Endpoint = strings.TrimRight(config.Endpoint, "/")
+	config.PublicURL = strings.TrimRight(config.PublicURL, "/")
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+
+	return &S3Store{
+		config: config,
+		client: &http.Client{Timeout: 30 * time.Second},
+		now:    time.Now,
+	}
+}
+
+func (s *S3Store) Put(ctx context.Context, key, contentType string, body []byte) (err error) {
+	if !validKey(key) {
+		return ErrInvalidKey
+	}
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", contentType)
+	return s.do(req, body, http.StatusOK)
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) (err error) {
+	if !validKey(key) {
+		return ErrInvalidKey
+	}
+
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return
+	}
+	return s.do(req, nil, http.StatusNoContent, http.StatusOK, http.StatusNotFound)
+}
+
+func (s *S3Store) URL(key string) string {
+	if s.config.PublicURL != "" {
+		return s.config.PublicURL + "/" + uriEncode(key)
+	}
+	return s.config.Endpoint + "/" + uriEncode(s.config.Bucket+"/"+key)
+}
+
+func (s *S3Store) newRequest(ctx context.Context, method, key string, body []byte) (*http.Request, error) {
+	u, err := url.Parse(s.config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = "/" + s.config.Bucket + "/" + key
+	u.RawPath = uriEncode(u.Path)
+
+	return http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+}
+
+// do signs and sends the request, which succeeds with one of the statuses
+func (s *S3Store) do(req *http.Request, body []byte, statuses ...int) error {
+	s.sign(req, sha256Hex(body), s.now())
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	for _, status := range statuses {
+		if res.StatusCode == status {
+			io.Copy(io.Discard, res.Body)
+			return nil
+		}
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	return fmt.Errorf("s3 %s %s responded with %s: %s", req.Method, req.URL.Path, res.Status, message)
+}
+
+// sign adds the Authorization header of Signature Version 4, covering the
+// host and every header set on the request
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func (s *S3Store) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.config.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), date)
+	key = hmacSHA256(key, s.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// uriEncode escapes everything in the path but unreserved characters and
+// slashes, as the signature expects
+func uriEncode(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' ||
+			c == '-' || c == '_' || c == '.' || c == '~' || c == '/' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
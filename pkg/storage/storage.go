@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+)
+
+var ErrInvalidKey = errors.New("blob key is invalid")
+
+// go:generate moq -rm -out storage_mock.go . BlobStore
+type BlobStore interface {
+	// Put stores the body under the key, replacing what was there. Keys are
+	// relative paths like photos/1/abc.jpg
+	Put(ctx context.Context, key, contentType string, body []byte) (err error)
+
+	// Delete removes what is stored under the key, which is not an error
+	// when nothing is
+	Delete(ctx context.Context, key string) (err error)
+
+	// URL is where clients download what is stored under the key from
+	URL(key string) string
+}
+
+// validKey reports whether the key is a clean relative path staying inside
+// the store
+func validKey(key string) bool {
+	return key != "" && key != "." && path.Clean(key) == key && !path.IsAbs(key) &&
+		key != ".." && !strings.HasPrefix(key, "../")
+}
@@ -0,0 +1,187 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package storage
+
+import (
+	"context"
+	"sync"
+)
+
+// Ensure, that BlobStoreMock does implement BlobStore.
+// If this is not the case, regenerate this file with moq.
+var _ BlobStore = &BlobStoreMock{}
+
+// BlobStoreMock is a mock implementation of BlobStore.
+//
+//	func TestSomethingThatUsesBlobStore(t *testing.T) {
+//
+//		// make and configure a mocked BlobStore
+//		mockedBlobStore := &BlobStoreMock{
+//			DeleteFunc: func(ctx context.Context, key string) error {
+//				panic("mock out the Delete method")
+//			},
+//			PutFunc: func(ctx context.Context, key string, contentType string, body []byte) error {
+//				panic("mock out the Put method")
+//			},
+//			URLFunc: func(key string) string {
+//				panic("mock out the URL method")
+//			},
+//		}
+//
+//		// use mockedBlobStore in code that requires BlobStore
+//		// and then make assertions.
+//
+//	}
+type BlobStoreMock struct {
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, key string) error
+
+	// PutFunc mocks the Put method.
+	PutFunc func(ctx context.Context, key string, contentType string, body []byte) error
+
+	// URLFunc mocks the URL method.
+	URLFunc func(key string) string
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// Put holds details about calls to the Put method.
+		Put []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// ContentType is the contentType argument value.
+			ContentType string
+			// Body is the body argument value.
+			Body []byte
+		}
+		// URL holds details about calls to the URL method.
+		URL []struct {
+			// Key is the key argument value.
+			Key string
+		}
+	}
+	lockDelete sync.RWMutex
+	lockPut    sync.RWMutex
+	lockURL    sync.RWMutex
+}
+
+// Delete calls DeleteFunc.
+func (mock *BlobStoreMock) Delete(ctx context.Context, key string) error {
+	if mock.DeleteFunc == nil {
+		panic("BlobStoreMock.DeleteFunc: method is nil but BlobStore.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, key)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedBlobStore.DeleteCalls())
+func (mock *BlobStoreMock) DeleteCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
+// Put calls PutFunc.
+func (mock *BlobStoreMock) Put(ctx context.Context, key string, contentType string, body []byte) error {
+	if mock.PutFunc == nil {
+		panic("BlobStoreMock.PutFunc: method is nil but BlobStore.Put was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		Key         string
+		ContentType string
+		Body        []byte
+	}{
+		Ctx:         ctx,
+		Key:         key,
+		ContentType: contentType,
+		Body:        body,
+	}
+	mock.lockPut.Lock()
+	mock.calls.Put = append(mock.calls.Put, callInfo)
+	mock.lockPut.Unlock()
+	return mock.PutFunc(ctx, key, contentType, body)
+}
+
+// PutCalls gets all the calls that were made to Put.
+// Check the length with:
+//
+//	len(mockedBlobStore.PutCalls())
+func (mock *BlobStoreMock) PutCalls() []struct {
+	Ctx         context.Context
+	Key         string
+	ContentType string
+	Body        []byte
+} {
+	var calls []struct {
+		Ctx         context.Context
+		Key         string
+		ContentType string
+		Body        []byte
+	}
+	mock.lockPut.RLock()
+	calls = mock.calls.Put
+	mock.lockPut.RUnlock()
+	return calls
+}
+
+// URL calls URLFunc.
+func (mock *BlobStoreMock) URL(key string) string {
+	if mock.URLFunc == nil {
+		panic("BlobStoreMock.URLFunc: method is nil but BlobStore.URL was just called")
+	}
+	callInfo := struct {
+		Key string
+	}{
+		Key: key,
+	}
+	mock.lockURL.Lock()
+	mock.calls.URL = append(mock.calls.URL, callInfo)
+	mock.lockURL.Unlock()
+	return mock.URLFunc(key)
+}
+
+// URLCalls gets all the calls that were made to URL.
+// Check the length with:
+//
+//	len(mockedBlobStore.URLCalls())
+func (mock *BlobStoreMock) URLCalls() []struct {
+	Key string
+} {
+	var calls []struct {
+		Key string
+	}
+	mock.lockURL.RLock()
+	calls = mock.calls.URL
+	mock.lockURL.RUnlock()
+	return calls
+}
@@ -0,0 +1,104 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Period is the number of seconds a code stays valid
+	Period = 30
+	// Digits is the length of a generated code
+	Digits = 6
+	// Skew is the number of periods before and after the current one that
+	// are still accepted to allow for clock drift
+	Skew = 1
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a random base32 encoded secret
+func GenerateSecret() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate secret: %w", err)
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// GenerateCode returns the code of the secret for the period containing t
+func GenerateCode(secret string, t time.Time) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode secret: %w", err)
+	}
+	return hotp(key, uint64(t.Unix()/Period)), nil
+}
+
+// Validate checks the code against the secret for the period containing t
+// and its neighbouring periods
+func Validate(secret, code string, t time.Time) bool {
+	_, ok := Match(secret, code, t)
+	return ok
+}
+
+// Match checks the code as Validate does and returns the time step it was
+// generated for. Remembering the last accepted step and turning down any step
+// not after it keeps a code from being used twice, RFC 6238 section 5.2
+func Match(secret, code string, t time.Time) (step int64, ok bool) {
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	for i := -Skew; i <= Skew; i++ {
+		at := t.Add(time.Duration(i*Period) * time.Second)
+		expected, err := GenerateCode(secret, at)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return at.Unix() / Period, true
+		}
+	}
+	return 0, false
+}
+
+// URI returns the otpauth URI authenticator apps use to enroll the secret
+func URI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(Digits))
+	query.Set("period", fmt.Sprint(Period))
+
+	label := url.PathEscape(issuer + ":" + account)
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, query.Encode())
+}
+
+// hotp implements the HMAC-based one-time password of RFC 4226
+func hotp(key []byte, counter uint64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", Digits, value%mod)
+}
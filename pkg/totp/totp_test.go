@@ -0,0 +1,113 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test vectors from RFC 6238 appendix B, truncated to six digits
+func TestGenerateCode(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		name     string
+		unix     int64
+		wantCode string
+	}{
+		{
+			name:     "case 59",
+			unix:     59,
+			wantCode: "287082",
+		},
+		{
+			name:     "case 1111111109",
+			unix:     1111111109,
+			wantCode: "081804",
+		},
+		{
+			name:     "case 1234567890",
+			unix:     1234567890,
+			wantCode: "005924",
+		},
+		{
+			name:     "case 20000000000",
+			unix:     20000000000,
+			wantCode: "353130",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotCode, gotErr := GenerateCode(secret, time.Unix(tt.unix, 0))
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tt.wantCode, gotCode)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		name string
+		unix int64
+		code string
+		want bool
+	}{
+		{
+			name: "case current period",
+			unix: 59,
+			code: "287082",
+			want: true,
+		},
+		{
+			name: "case previous period",
+			unix: 89,
+			code: "287082",
+			want: true,
+		},
+		{
+			name: "case stale period",
+			unix: 179,
+			code: "287082",
+		},
+		{
+			name: "case malformed code",
+			unix: 59,
+			code: "28708",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Validate(secret, tt.code, time.Unix(tt.unix, 0)))
+		})
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("Dating App", "jdoe", "SECRET")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Dating%20App:jdoe?"))
+	assert.Contains(t, uri, "secret=SECRET")
+	assert.Contains(t, uri, "issuer=Dating+App")
+}
+
+func TestMatch(t *testing.T) {
+	secret := encoding.EncodeToString([]byte("12345678901234567890"))
+	now := time.Unix(1700000000, 0)
+
+	for i := -Skew; i <= Skew; i++ {
+		at := now.Add(time.Duration(i*Period) * time.Second)
+		code, _ := GenerateCode(secret, at)
+
+		step, ok := Match(secret, code, now)
+		assert.True(t, ok)
+		assert.Equal(t, at.Unix()/Period, step)
+	}
+
+	code, _ := GenerateCode(secret, now.Add(2*Period*time.Second))
+	_, ok := Match(secret, code, now)
+	assert.False(t, ok)
+}
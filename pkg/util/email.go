@@ -0,0 +1,17 @@
+package util
+
+import "net/mail"
+
+// maxEmailLength is the longest address a mail server has to take
+const maxEmailLength = 254
+
+// ValidEmail reports whether the email is a bare address, without a display
+// name or anything around it
+func ValidEmail(email string) bool {
+	if len(email) > maxEmailLength {
+		return false
+	}
+
+	address, err := mail.ParseAddress(email)
+	return err == nil && address.Address == email
+}
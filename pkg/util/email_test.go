@@ -0,0 +1,28 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		want  bool
+	}{
+		{email: "jane@doe.com", want: true},
+		{email: "jane.doe+dating@mail.doe.com", want: true},
+		{email: "", want: false},
+		{email: "jane", want: false},
+		{email: "jane@", want: false},
+		{email: "Jane <jane@doe.com>", want: false},
+		{email: " jane@doe.com", want: false},
+		{email: "jane@doe.com, john@doe.com", want: false},
+		{email: strings.Repeat("a", 250) + "@doe.com", want: false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ValidEmail(tt.email), tt.email)
+	}
+}
@@ -1,14 +1,82 @@
 package util
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"strings"
+	"unicode"
 
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 )
 
-// HashPassword returns the bcrypt hash of the password
+const (
+	HashAlgorithmBcrypt   = "bcrypt"
+	HashAlgorithmArgon2id = "argon2id"
+)
+
+// MaxPasswordBytes is the most bcrypt hashes, longer passwords are turned
+// down whatever the algorithm so switching to bcrypt never locks anyone out
+const MaxPasswordBytes = 72
+
+var errPasswordMismatch = errors.New("password does not match the hash")
+
+// PasswordPolicy controls how new passwords are hashed and how strong they
+// have to be
+type PasswordPolicy struct {
+	Algorithm  string
+	BcryptCost int
+
+	Argon2Time    uint32
+	Argon2Memory  uint32
+	Argon2Threads uint8
+	Argon2KeyLen  uint32
+
+	MinLength int
+	Blocklist []string
+}
+
+// commonPasswords are rejected regardless of the configured blocklist
+var commonPasswords = []string{
+	"123456", "12345678", "123456789", "1234567890", "password", "password1",
+	"password123", "qwerty", "qwerty123", "qwertyuiop", "abc123", "111111",
+	"iloveyou", "admin", "welcome", "letmein", "monkey", "dragon", "sunshine",
+	"princess", "football", "baseball", "trustno1", "1q2w3e4r", "zaq12wsx",
+	"passw0rd", "lovely", "loveme", "babygirl", "sweetheart",
+}
+
+var passwordPolicy = DefaultPasswordPolicy()
+
+// DefaultPasswordPolicy hashes with bcrypt at its default cost and the
+// argon2id parameters recommended by RFC 9106 when switched over
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		Algorithm:     HashAlgorithmBcrypt,
+		BcryptCost:    bcrypt.DefaultCost,
+		Argon2Time:    1,
+		Argon2Memory:  64 * 1024,
+		Argon2Threads: 4,
+		Argon2KeyLen:  32,
+		MinLength:     8,
+	}
+}
+
+// SetPasswordPolicy replaces the policy used by HashPassword, NeedsRehash and
+// ValidatePassword. It is meant to be called once on start up
+func SetPasswordPolicy(policy PasswordPolicy) {
+	passwordPolicy = policy
+}
+
+// HashPassword returns the hash of the password using the current policy
 func HashPassword(password string) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if passwordPolicy.Algorithm == HashAlgorithmArgon2id {
+		return hashArgon2id(password, passwordPolicy)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), passwordPolicy.BcryptCost)
 	if err != nil {
 		return "", fmt.Errorf("failed to hash password: %w", err)
 	}
@@ -17,5 +85,141 @@ func HashPassword(password string) (string, error) {
 
 // CheckPassword checks if the provided password is correct or not
 func CheckPassword(password string, hashedPassword string) error {
+	if strings.HasPrefix(hashedPassword, "$argon2id$") {
+		return checkArgon2id(password, hashedPassword)
+	}
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 }
+
+// NeedsRehash reports whether the hash was created with another algorithm or
+// other parameters than the current policy asks for
+func NeedsRehash(hashedPassword string) bool {
+	if passwordPolicy.Algorithm == HashAlgorithmArgon2id {
+		params, _, _, err := decodeArgon2id(hashedPassword)
+		return err != nil || params != argon2Params(passwordPolicy)
+	}
+
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	return err != nil || cost != passwordPolicy.BcryptCost
+}
+
+// ValidatePassword checks the password against the strength rules of the
+// current policy. userInputs, such as the username or email, must not be
+// part of the password
+func ValidatePassword(password string, userInputs ...string) error {
+	if len([]rune(password)) < passwordPolicy.MinLength {
+		return fmt.Errorf("password must be at least %d characters", passwordPolicy.MinLength)
+	}
+	if len(password) > MaxPasswordBytes {
+		return fmt.Errorf("password must be at most %d bytes", MaxPasswordBytes)
+	}
+
+	lower := strings.ToLower(password)
+	for _, blocked := range append(commonPasswords, passwordPolicy.Blocklist...) {
+		if lower == strings.ToLower(blocked) {
+			return errors.New("password is too common")
+		}
+	}
+
+	for _, input := range userInputs {
+		if len(input) >= 3 && strings.Contains(lower, strings.ToLower(input)) {
+			return errors.New("password must not contain personal information")
+		}
+	}
+
+	var hasLetter, hasDigit, hasOther bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		default:
+			hasOther = true
+		}
+	}
+	if !hasLetter || !(hasDigit || hasOther) {
+		return errors.New("password must contain letters and at least a digit or symbol")
+	}
+
+	return nil
+}
+
+type argon2idParams struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+	keyLen  uint32
+}
+
+func argon2Params(policy PasswordPolicy) argon2idParams {
+	return argon2idParams{
+		time:    policy.Argon2Time,
+		memory:  policy.Argon2Memory,
+		threads: policy.Argon2Threads,
+		keyLen:  policy.Argon2KeyLen,
+	}
+}
+
+// hashArgon2id encodes the hash in the PHC string format used by the
+// reference implementation
+func hashArgon2id(password string, policy PasswordPolicy) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	params := argon2Params(policy)
+	key := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, params.keyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.memory, params.time, params.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func checkArgon2id(password string, hashedPassword string) error {
+	params, salt, key, err := decodeArgon2id(hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, params.keyLen)
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return errPasswordMismatch
+	}
+	return nil
+}
+
+func decodeArgon2id(hashedPassword string) (params argon2idParams, salt, key []byte, err error) {
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 || parts[1] != HashAlgorithmArgon2id {
+		err = errors.New("invalid argon2id hash")
+		return
+	}
+
+	var version int
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		err = errors.New("unsupported argon2id version")
+		return
+	}
+
+	if _, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil {
+		err = fmt.Errorf("invalid argon2id parameters: %w", err)
+		return
+	}
+
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		err = fmt.Errorf("invalid argon2id salt: %w", err)
+		return
+	}
+
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		err = fmt.Errorf("invalid argon2id key: %w", err)
+		return
+	}
+	params.keyLen = uint32(len(key))
+
+	return
+}
@@ -0,0 +1,116 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHashPassword(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy PasswordPolicy
+	}{
+		{
+			name: "case bcrypt",
+			policy: PasswordPolicy{
+				Algorithm:  HashAlgorithmBcrypt,
+				BcryptCost: bcrypt.MinCost,
+			},
+		},
+		{
+			name: "case argon2id",
+			policy: PasswordPolicy{
+				Algorithm:     HashAlgorithmArgon2id,
+				Argon2Time:    1,
+				Argon2Memory:  1024,
+				Argon2Threads: 1,
+				Argon2KeyLen:  32,
+			},
+		},
+	}
+	defer SetPasswordPolicy(DefaultPasswordPolicy())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetPasswordPolicy(tt.policy)
+
+			hashedPassword, err := HashPassword("correct-horse-42")
+			assert.NoError(t, err)
+			assert.NoError(t, CheckPassword("correct-horse-42", hashedPassword))
+			assert.Error(t, CheckPassword("wrong-horse-42", hashedPassword))
+			assert.False(t, NeedsRehash(hashedPassword))
+		})
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	defer SetPasswordPolicy(DefaultPasswordPolicy())
+
+	SetPasswordPolicy(PasswordPolicy{Algorithm: HashAlgorithmBcrypt, BcryptCost: bcrypt.MinCost})
+	bcryptHash, _ := HashPassword("correct-horse-42")
+
+	SetPasswordPolicy(PasswordPolicy{Algorithm: HashAlgorithmBcrypt, BcryptCost: bcrypt.MinCost + 1})
+	assert.True(t, NeedsRehash(bcryptHash), "bcrypt cost raised")
+
+	SetPasswordPolicy(PasswordPolicy{Algorithm: HashAlgorithmArgon2id, Argon2Time: 1, Argon2Memory: 1024, Argon2Threads: 1, Argon2KeyLen: 32})
+	assert.True(t, NeedsRehash(bcryptHash), "switched to argon2id")
+
+	argon2Hash, _ := HashPassword("correct-horse-42")
+	SetPasswordPolicy(PasswordPolicy{Algorithm: HashAlgorithmArgon2id, Argon2Time: 2, Argon2Memory: 1024, Argon2Threads: 1, Argon2KeyLen: 32})
+	assert.True(t, NeedsRehash(argon2Hash), "argon2id time raised")
+	assert.NoError(t, CheckPassword("correct-horse-42", argon2Hash))
+}
+
+func TestValidatePassword(t *testing.T) {
+	tests := []struct {
+		name       string
+		password   string
+		userInputs []string
+		wantErr    bool
+	}{
+		{
+			name:     "case strong password",
+			password: "correct-horse-42",
+		},
+		{
+			name:     "case too short",
+			password: "a1b2c3",
+			wantErr:  true,
+		},
+		{
+			name:     "case common password",
+			password: "Password123",
+			wantErr:  true,
+		},
+		{
+			name:       "case contains username",
+			password:   "jdoe-is-great-1",
+			userInputs: []string{"jdoe"},
+			wantErr:    true,
+		},
+		{
+			name:     "case too long",
+			password: strings.Repeat("correct-horse-42", 5),
+			wantErr:  true,
+		},
+		{
+			name:     "case longest",
+			password: strings.Repeat("correct-horse-42", 4) + "battery1",
+		},
+		{
+			name:     "case letters only",
+			password: "correcthorse",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotErr := ValidatePassword(tt.password, tt.userInputs...)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("ValidatePassword() error = %v, wantErr = %v", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// Channel every instance listens on, events are routed to the connected
+	// user by the receiving instance
+	eventChannel = "events"
+
+	// Events kept per user for replaying to reconnecting clients
+	eventReplayLen = 100
+)
+
+// PublishEvent assigns the next sequence number of the user to the event,
+// keeps it for replay and broadcasts it to every instance
+func (cache *RedisCache) PublishEvent(ctx context.Context, event model.Event) (err error) {
+	seqKey := fmt.Sprintf("event_seq:%d", event.UserID)
+	key := fmt.Sprintf("events:%d", event.UserID)
+
+	event.Seq, err = cache.Client.Incr(ctx, seqKey).Result()
+	if err != nil {
+		log.Println("error set cache: ", seqKey)
+		return
+	}
+
+	valueJson, err := json.Marshal(&event)
+	if err != nil {
+		log.Println("error marshal json")
+		return
+	}
+
+	err = cache.Client.LPush(ctx, key, valueJson).Err()
+	if err != nil {
+		log.Println("error set cache: ", key)
+		return
+	}
+
+	err = cache.Client.LTrim(ctx, key, 0, eventReplayLen-1).Err()
+	if err != nil {
+		log.Println("error trim cache: ", key)
+		return
+	}
+
+	err = cache.Client.Expire(ctx, key, 24*time.Hour).Err()
+	if err != nil {
+		log.Println("error set cache expire: ", key)
+		return
+	}
+
+	err = cache.Client.Publish(ctx, eventChannel, valueJson).Err()
+	if err != nil {
+		log.Println("error publish event: ", key)
+		return
+	}
+
+	return
+}
+
+// GetEventsSince returns the kept events of the user after afterSeq, oldest
+// first
+func (cache *RedisCache) GetEventsSince(ctx context.Context, userID, afterSeq int64) (events []model.Event, err error) {
+	key := fmt.Sprintf("events:%d", userID)
+
+	cacheData, err := cache.Client.LRange(ctx, key, 0, -1).Result()
+	if err != nil {
+		err = errors.New("error fetching cached data")
+		log.Println(err.Error())
+		return
+	}
+
+	for i := len(cacheData) - 1; i >= 0; i-- {
+		var event model.Event
+		err = json.Unmarshal([]byte(cacheData[i]), &event)
+		if err != nil {
+			log.Println("error unmarshal json")
+			return
+		}
+
+		if event.Seq > afterSeq {
+			events = append(events, event)
+		}
+	}
+
+	return
+}
+
+// SubscribeEvents streams the events published by every instance until ctx
+// is done
+func (cache *RedisCache) SubscribeEvents(ctx context.Context) (events <-chan model.Event) {
+	pubsub := cache.Client.Subscribe(ctx, eventChannel)
+	stream := make(chan model.Event)
+
+	go func() {
+		defer close(stream)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var event model.Event
+				if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+					log.Println("error unmarshal json")
+					continue
+				}
+
+				select {
+				case stream <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return stream
+}
+
+func (cache *RedisCache) ScheduleQuotaReset(ctx context.Context, userID int64, at time.Time) (err error) {
+	key := "quota_reset"
+
+	err = cache.Client.ZAdd(ctx, key, redis.Z{
+		Score:  float64(at.Unix()),
+		Member: userID,
+	}).Err()
+	if err != nil {
+		log.Println("error set cache: ", key)
+		return
+	}
+
+	return
+}
+
+// PopDueQuotaResets claims the quota resets that are due. Reading and
+// removing them runs in one transaction so each reset is only returned to a
+// single instance
+func (cache *RedisCache) PopDueQuotaResets(ctx context.Context, now time.Time) (userIDs []int64, err error) {
+	key := "quota_reset"
+	max := strconv.FormatInt(now.Unix(), 10)
+
+	var members *redis.StringSliceCmd
+	_, err = cache.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		members = pipe.ZRangeByScore(ctx, key, &redis.ZRangeBy{
+			Min: "-inf",
+			Max: max,
+		})
+		pipe.ZRemRangeByScore(ctx, key, "-inf", max)
+		return nil
+	})
+	if err != nil {
+		err = errors.New("error fetching cached data")
+		log.Println(err.Error())
+		return
+	}
+
+	for _, member := range members.Val() {
+		userID, errParse := strconv.ParseInt(member, 10, 64)
+		if errParse != nil {
+			log.Println("error parse quota reset member: ", member)
+			continue
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	return
+}
@@ -0,0 +1,207 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/go-redis/redismock/v9"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishEvent(t *testing.T) {
+	event := model.Event{
+		UserID:    1,
+		Type:      model.EventTypeLiked,
+		Data:      json.RawMessage(`{"user_id":2}`),
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	published := event
+	published.Seq = 5
+	publishedJson, _ := json.Marshal(&published)
+
+	type fields struct {
+		redisClient *redis.Client
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectIncr("event_seq:1").SetVal(5)
+					mock.ExpectLPush("events:1", publishedJson).SetVal(1)
+					mock.ExpectLTrim("events:1", 0, eventReplayLen-1).SetVal("OK")
+					mock.ExpectExpire("events:1", 24*time.Hour).SetVal(true)
+					mock.ExpectPublish(eventChannel, publishedJson).SetVal(1)
+					return client
+				}(),
+			},
+		},
+		{
+			name: "case error seq",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectIncr("event_seq:1").SetErr(errors.New("err"))
+					return client
+				}(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error publish",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectIncr("event_seq:1").SetVal(5)
+					mock.ExpectLPush("events:1", publishedJson).SetVal(1)
+					mock.ExpectLTrim("events:1", 0, eventReplayLen-1).SetVal("OK")
+					mock.ExpectExpire("events:1", 24*time.Hour).SetVal(true)
+					mock.ExpectPublish(eventChannel, publishedJson).SetErr(errors.New("err"))
+					return client
+				}(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RedisCache{
+				Client: tt.fields.redisClient,
+			}
+			gotErr := r.PublishEvent(context.Background(), event)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("PublishEvent() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestGetEventsSince(t *testing.T) {
+	eventJson := func(seq int64) string {
+		data, _ := json.Marshal(&model.Event{Seq: seq, UserID: 1, Type: model.EventTypeLiked})
+		return string(data)
+	}
+
+	type fields struct {
+		redisClient *redis.Client
+	}
+	type args struct {
+		afterSeq int64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantSeq []int64
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectLRange("events:1", 0, -1).SetVal([]string{eventJson(4), eventJson(3), eventJson(2)})
+					return client
+				}(),
+			},
+			args: args{
+				afterSeq: 2,
+			},
+			wantSeq: []int64{3, 4},
+		},
+		{
+			name: "case error",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectLRange("events:1", 0, -1).SetErr(errors.New("err"))
+					return client
+				}(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RedisCache{
+				Client: tt.fields.redisClient,
+			}
+			gotRes, gotErr := r.GetEventsSince(context.Background(), 1, tt.args.afterSeq)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("GetEventsSince() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+
+			var gotSeq []int64
+			for _, event := range gotRes {
+				gotSeq = append(gotSeq, event.Seq)
+			}
+			assert.Equal(t, tt.wantSeq, gotSeq)
+		})
+	}
+}
+
+func TestPopDueQuotaResets(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	type fields struct {
+		redisClient *redis.Client
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantRes []int64
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectTxPipeline()
+					mock.ExpectZRangeByScore("quota_reset", &redis.ZRangeBy{Min: "-inf", Max: "1700000000"}).SetVal([]string{"1", "2"})
+					mock.ExpectZRemRangeByScore("quota_reset", "-inf", "1700000000").SetVal(2)
+					mock.ExpectTxPipelineExec()
+					return client
+				}(),
+			},
+			wantRes: []int64{1, 2},
+		},
+		{
+			name: "case error",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectTxPipeline()
+					mock.ExpectZRangeByScore("quota_reset", &redis.ZRangeBy{Min: "-inf", Max: "1700000000"}).SetErr(errors.New("err"))
+					return client
+				}(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RedisCache{
+				Client: tt.fields.redisClient,
+			}
+			gotRes, gotErr := r.PopDueQuotaResets(context.Background(), now)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("PopDueQuotaResets() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantRes, gotRes)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"time"
 
 	"github.com/egnptr/dating-app/model"
 )
@@ -11,4 +12,49 @@ type Repo interface {
 	GetRelatedUserCache(ctx context.Context, userID int64) (userRelationMap map[int64]int, err error)
 	SetRelatedUserCache(ctx context.Context, userID int64, data model.UserRelation) (err error)
 	GetRelatedUserCacheLen(ctx context.Context, userID int64) (len int64, err error)
+	GetLastRelatedUserCache(ctx context.Context, userID int64) (data model.UserRelation, err error)
+	RemoveRelatedUserCache(ctx context.Context, userID int64, data model.UserRelation) (err error)
+	RemoveRelatedUserLike(ctx context.Context, userID, likedUserID int64) (err error)
+	GetSuperLikeCount(ctx context.Context, userID int64) (count int64, err error)
+	IncrSuperLikeCount(ctx context.Context, userID int64) (count int64, err error)
+	DecrSuperLikeCount(ctx context.Context, userID int64) (count int64, err error)
+	GetRewindCount(ctx context.Context, userID int64) (count int64, err error)
+	IncrRewindCount(ctx context.Context, userID int64) (count int64, err error)
+
+	SetSession(ctx context.Context, token string, userID int64) (err error)
+	GetSession(ctx context.Context, token string) (userID int64, err error)
+	DeleteSessions(ctx context.Context, userID int64) (err error)
+
+	SetLoginChallenge(ctx context.Context, token string, userID int64) (err error)
+	GetLoginChallenge(ctx context.Context, token string) (userID int64, err error)
+	DeleteLoginChallenge(ctx context.Context, token string) (err error)
+
+	GetLoginLock(ctx context.Context, key string) (retryAfter time.Duration, err error)
+	SetLoginLock(ctx context.Context, key string, duration time.Duration) (err error)
+	IncrLoginFailure(ctx context.Context, key string) (count int64, err error)
+	ResetLoginFailure(ctx context.Context, key string) (err error)
+
+	SetOAuthState(ctx context.Context, state string, data model.OAuthState) (err error)
+	PopOAuthState(ctx context.Context, state string) (data model.OAuthState, err error)
+
+	SetEmailVerification(ctx context.Context, token string, data model.EmailVerification) (err error)
+	GetEmailVerification(ctx context.Context, token string) (data model.EmailVerification, err error)
+	DeleteEmailVerification(ctx context.Context, token string) (err error)
+
+	PublishEvent(ctx context.Context, event model.Event) (err error)
+	GetEventsSince(ctx context.Context, userID, afterSeq int64) (events []model.Event, err error)
+	SubscribeEvents(ctx context.Context) (events <-chan model.Event)
+
+	MarkProfileViewed(ctx context.Context, view model.ProfileView) (first bool, err error)
+	PushProfileViews(ctx context.Context, views []model.ProfileView) (err error)
+	PopProfileViews(ctx context.Context, count int) (views []model.ProfileView, err error)
+
+	AddBoostedUser(ctx context.Context, boost model.Boost) (err error)
+	GetBoostedUsers(ctx context.Context, at time.Time) (boosted map[int64]bool, err error)
+
+	ScheduleQuotaReset(ctx context.Context, userID int64, at time.Time) (err error)
+	PopDueQuotaResets(ctx context.Context, now time.Time) (userIDs []int64, err error)
+
+	AcquireLock(ctx context.Context, name, owner string, ttl time.Duration) (acquired bool, err error)
+	ReleaseLock(ctx context.Context, name, owner string) (err error)
 }
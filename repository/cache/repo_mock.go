@@ -7,6 +7,7 @@ import (
 	"context"
 	"github.com/egnptr/dating-app/model"
 	"sync"
+	"time"
 )
 
 // Ensure, that RepoMock does implement Repo.
@@ -19,131 +20,1875 @@ var _ Repo = &RepoMock{}
 //
 //		// make and configure a mocked Repo
 //		mockedRepo := &RepoMock{
+//			AcquireLockFunc: func(ctx context.Context, name string, owner string, ttl time.Duration) (bool, error) {
+//				panic("mock out the AcquireLock method")
+//			},
+//			AddBoostedUserFunc: func(ctx context.Context, boost model.Boost) error {
+//				panic("mock out the AddBoostedUser method")
+//			},
+//			DecrSuperLikeCountFunc: func(ctx context.Context, userID int64) (int64, error) {
+//				panic("mock out the DecrSuperLikeCount method")
+//			},
+//			DeleteEmailVerificationFunc: func(ctx context.Context, token string) error {
+//				panic("mock out the DeleteEmailVerification method")
+//			},
+//			DeleteLoginChallengeFunc: func(ctx context.Context, token string) error {
+//				panic("mock out the DeleteLoginChallenge method")
+//			},
+//			DeleteSessionsFunc: func(ctx context.Context, userID int64) error {
+//				panic("mock out the DeleteSessions method")
+//			},
+//			GetBoostedUsersFunc: func(ctx context.Context, at time.Time) (map[int64]bool, error) {
+//				panic("mock out the GetBoostedUsers method")
+//			},
+//			GetEmailVerificationFunc: func(ctx context.Context, token string) (model.EmailVerification, error) {
+//				panic("mock out the GetEmailVerification method")
+//			},
+//			GetEventsSinceFunc: func(ctx context.Context, userID int64, afterSeq int64) ([]model.Event, error) {
+//				panic("mock out the GetEventsSince method")
+//			},
+//			GetLastRelatedUserCacheFunc: func(ctx context.Context, userID int64) (model.UserRelation, error) {
+//				panic("mock out the GetLastRelatedUserCache method")
+//			},
+//			GetLoginChallengeFunc: func(ctx context.Context, token string) (int64, error) {
+//				panic("mock out the GetLoginChallenge method")
+//			},
+//			GetLoginLockFunc: func(ctx context.Context, key string) (time.Duration, error) {
+//				panic("mock out the GetLoginLock method")
+//			},
 //			GetRelatedUserCacheFunc: func(ctx context.Context, userID int64) (map[int64]int, error) {
 //				panic("mock out the GetRelatedUserCache method")
 //			},
 //			GetRelatedUserCacheLenFunc: func(ctx context.Context, userID int64) (int64, error) {
 //				panic("mock out the GetRelatedUserCacheLen method")
 //			},
+//			GetRewindCountFunc: func(ctx context.Context, userID int64) (int64, error) {
+//				panic("mock out the GetRewindCount method")
+//			},
+//			GetSessionFunc: func(ctx context.Context, token string) (int64, error) {
+//				panic("mock out the GetSession method")
+//			},
+//			GetSuperLikeCountFunc: func(ctx context.Context, userID int64) (int64, error) {
+//				panic("mock out the GetSuperLikeCount method")
+//			},
+//			IncrLoginFailureFunc: func(ctx context.Context, key string) (int64, error) {
+//				panic("mock out the IncrLoginFailure method")
+//			},
+//			IncrRewindCountFunc: func(ctx context.Context, userID int64) (int64, error) {
+//				panic("mock out the IncrRewindCount method")
+//			},
+//			IncrSuperLikeCountFunc: func(ctx context.Context, userID int64) (int64, error) {
+//				panic("mock out the IncrSuperLikeCount method")
+//			},
+//			MarkProfileViewedFunc: func(ctx context.Context, view model.ProfileView) (bool, error) {
+//				panic("mock out the MarkProfileViewed method")
+//			},
+//			PopDueQuotaResetsFunc: func(ctx context.Context, now time.Time) ([]int64, error) {
+//				panic("mock out the PopDueQuotaResets method")
+//			},
+//			PopOAuthStateFunc: func(ctx context.Context, state string) (model.OAuthState, error) {
+//				panic("mock out the PopOAuthState method")
+//			},
+//			PopProfileViewsFunc: func(ctx context.Context, count int) ([]model.ProfileView, error) {
+//				panic("mock out the PopProfileViews method")
+//			},
+//			PublishEventFunc: func(ctx context.Context, event model.Event) error {
+//				panic("mock out the PublishEvent method")
+//			},
+//			PushProfileViewsFunc: func(ctx context.Context, views []model.ProfileView) error {
+//				panic("mock out the PushProfileViews method")
+//			},
+//			ReleaseLockFunc: func(ctx context.Context, name string, owner string) error {
+//				panic("mock out the ReleaseLock method")
+//			},
+//			RemoveRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
+//				panic("mock out the RemoveRelatedUserCache method")
+//			},
+//			RemoveRelatedUserLikeFunc: func(ctx context.Context, userID int64, likedUserID int64) error {
+//				panic("mock out the RemoveRelatedUserLike method")
+//			},
+//			ResetLoginFailureFunc: func(ctx context.Context, key string) error {
+//				panic("mock out the ResetLoginFailure method")
+//			},
+//			ScheduleQuotaResetFunc: func(ctx context.Context, userID int64, at time.Time) error {
+//				panic("mock out the ScheduleQuotaReset method")
+//			},
+//			SetEmailVerificationFunc: func(ctx context.Context, token string, data model.EmailVerification) error {
+//				panic("mock out the SetEmailVerification method")
+//			},
+//			SetLoginChallengeFunc: func(ctx context.Context, token string, userID int64) error {
+//				panic("mock out the SetLoginChallenge method")
+//			},
+//			SetLoginLockFunc: func(ctx context.Context, key string, duration time.Duration) error {
+//				panic("mock out the SetLoginLock method")
+//			},
+//			SetOAuthStateFunc: func(ctx context.Context, state string, data model.OAuthState) error {
+//				panic("mock out the SetOAuthState method")
+//			},
 //			SetRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
 //				panic("mock out the SetRelatedUserCache method")
 //			},
+//			SetSessionFunc: func(ctx context.Context, token string, userID int64) error {
+//				panic("mock out the SetSession method")
+//			},
+//			SubscribeEventsFunc: func(ctx context.Context) <-chan model.Event {
+//				panic("mock out the SubscribeEvents method")
+//			},
 //		}
 //
-//		// use mockedRepo in code that requires Repo
-//		// and then make assertions.
+//		// use mockedRepo in code that requires Repo
+//		// and then make assertions.
+//
+//	}
+type RepoMock struct {
+	// AcquireLockFunc mocks the AcquireLock method.
+	AcquireLockFunc func(ctx context.Context, name string, owner string, ttl time.Duration) (bool, error)
+
+	// AddBoostedUserFunc mocks the AddBoostedUser method.
+	AddBoostedUserFunc func(ctx context.Context, boost model.Boost) error
+
+	// DecrSuperLikeCountFunc mocks the DecrSuperLikeCount method.
+	DecrSuperLikeCountFunc func(ctx context.Context, userID int64) (int64, error)
+
+	// DeleteEmailVerificationFunc mocks the DeleteEmailVerification method.
+	DeleteEmailVerificationFunc func(ctx context.Context, token string) error
+
+	// DeleteLoginChallengeFunc mocks the DeleteLoginChallenge method.
+	DeleteLoginChallengeFunc func(ctx context.Context, token string) error
+
+	// DeleteSessionsFunc mocks the DeleteSessions method.
+	DeleteSessionsFunc func(ctx context.Context, userID int64) error
+
+	// GetBoostedUsersFunc mocks the GetBoostedUsers method.
+	GetBoostedUsersFunc func(ctx context.Context, at time.Time) (map[int64]bool, error)
+
+	// GetEmailVerificationFunc mocks the GetEmailVerification method.
+	GetEmailVerificationFunc func(ctx context.Context, token string) (model.EmailVerification, error)
+
+	// GetEventsSinceFunc mocks the GetEventsSince method.
+	GetEventsSinceFunc func(ctx context.Context, userID int64, afterSeq int64) ([]model.Event, error)
+
+	// GetLastRelatedUserCacheFunc mocks the GetLastRelatedUserCache method.
+	GetLastRelatedUserCacheFunc func(ctx context.Context, userID int64) (model.UserRelation, error)
+
+	// GetLoginChallengeFunc mocks the GetLoginChallenge method.
+	GetLoginChallengeFunc func(ctx context.Context, token string) (int64, error)
+
+	// GetLoginLockFunc mocks the GetLoginLock method.
+	GetLoginLockFunc func(ctx context.Context, key string) (time.Duration, error)
+
+	// GetRelatedUserCacheFunc mocks the GetRelatedUserCache method.
+	GetRelatedUserCacheFunc func(ctx context.Context, userID int64) (map[int64]int, error)
+
+	// GetRelatedUserCacheLenFunc mocks the GetRelatedUserCacheLen method.
+	GetRelatedUserCacheLenFunc func(ctx context.Context, userID int64) (int64, error)
+
+	// GetRewindCountFunc mocks the GetRewindCount method.
+	GetRewindCountFunc func(ctx context.Context, userID int64) (int64, error)
+
+	// GetSessionFunc mocks the GetSession method.
+	GetSessionFunc func(ctx context.Context, token string) (int64, error)
+
+	// GetSuperLikeCountFunc mocks the GetSuperLikeCount method.
+	GetSuperLikeCountFunc func(ctx context.Context, userID int64) (int64, error)
+
+	// IncrLoginFailureFunc mocks the IncrLoginFailure method.
+	IncrLoginFailureFunc func(ctx context.Context, key string) (int64, error)
+
+	// IncrRewindCountFunc mocks the IncrRewindCount method.
+	IncrRewindCountFunc func(ctx context.Context, userID int64) (int64, error)
+
+	// IncrSuperLikeCountFunc mocks the IncrSuperLikeCount method.
+	IncrSuperLikeCountFunc func(ctx context.Context, userID int64) (int64, error)
+
+	// MarkProfileViewedFunc mocks the MarkProfileViewed method.
+	MarkProfileViewedFunc func(ctx context.Context, view model.ProfileView) (bool, error)
+
+	// PopDueQuotaResetsFunc mocks the PopDueQuotaResets method.
+	PopDueQuotaResetsFunc func(ctx context.Context, now time.Time) ([]int64, error)
+
+	// PopOAuthStateFunc mocks the PopOAuthState method.
+	PopOAuthStateFunc func(ctx context.Context, state string) (model.OAuthState, error)
+
+	// PopProfileViewsFunc mocks the PopProfileViews method.
+	PopProfileViewsFunc func(ctx context.Context, count int) ([]model.ProfileView, error)
+
+	// PublishEventFunc mocks the PublishEvent method.
+	PublishEventFunc func(ctx context.Context, event model.Event) error
+
+	// PushProfileViewsFunc mocks the PushProfileViews method.
+	PushProfileViewsFunc func(ctx context.Context, views []model.ProfileView) error
+
+	// ReleaseLockFunc mocks the ReleaseLock method.
+	ReleaseLockFunc func(ctx context.Context, name string, owner string) error
+
+	// RemoveRelatedUserCacheFunc mocks the RemoveRelatedUserCache method.
+	RemoveRelatedUserCacheFunc func(ctx context.Context, userID int64, data model.UserRelation) error
+
+	// RemoveRelatedUserLikeFunc mocks the RemoveRelatedUserLike method.
+	RemoveRelatedUserLikeFunc func(ctx context.Context, userID int64, likedUserID int64) error
+
+	// ResetLoginFailureFunc mocks the ResetLoginFailure method.
+	ResetLoginFailureFunc func(ctx context.Context, key string) error
+
+	// ScheduleQuotaResetFunc mocks the ScheduleQuotaReset method.
+	ScheduleQuotaResetFunc func(ctx context.Context, userID int64, at time.Time) error
+
+	// SetEmailVerificationFunc mocks the SetEmailVerification method.
+	SetEmailVerificationFunc func(ctx context.Context, token string, data model.EmailVerification) error
+
+	// SetLoginChallengeFunc mocks the SetLoginChallenge method.
+	SetLoginChallengeFunc func(ctx context.Context, token string, userID int64) error
+
+	// SetLoginLockFunc mocks the SetLoginLock method.
+	SetLoginLockFunc func(ctx context.Context, key string, duration time.Duration) error
+
+	// SetOAuthStateFunc mocks the SetOAuthState method.
+	SetOAuthStateFunc func(ctx context.Context, state string, data model.OAuthState) error
+
+	// SetRelatedUserCacheFunc mocks the SetRelatedUserCache method.
+	SetRelatedUserCacheFunc func(ctx context.Context, userID int64, data model.UserRelation) error
+
+	// SetSessionFunc mocks the SetSession method.
+	SetSessionFunc func(ctx context.Context, token string, userID int64) error
+
+	// SubscribeEventsFunc mocks the SubscribeEvents method.
+	SubscribeEventsFunc func(ctx context.Context) <-chan model.Event
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// AcquireLock holds details about calls to the AcquireLock method.
+		AcquireLock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Owner is the owner argument value.
+			Owner string
+			// TTL is the ttl argument value.
+			TTL time.Duration
+		}
+		// AddBoostedUser holds details about calls to the AddBoostedUser method.
+		AddBoostedUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Boost is the boost argument value.
+			Boost model.Boost
+		}
+		// DecrSuperLikeCount holds details about calls to the DecrSuperLikeCount method.
+		DecrSuperLikeCount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// DeleteEmailVerification holds details about calls to the DeleteEmailVerification method.
+		DeleteEmailVerification []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+		}
+		// DeleteLoginChallenge holds details about calls to the DeleteLoginChallenge method.
+		DeleteLoginChallenge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+		}
+		// DeleteSessions holds details about calls to the DeleteSessions method.
+		DeleteSessions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetBoostedUsers holds details about calls to the GetBoostedUsers method.
+		GetBoostedUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// At is the at argument value.
+			At time.Time
+		}
+		// GetEmailVerification holds details about calls to the GetEmailVerification method.
+		GetEmailVerification []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+		}
+		// GetEventsSince holds details about calls to the GetEventsSince method.
+		GetEventsSince []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// AfterSeq is the afterSeq argument value.
+			AfterSeq int64
+		}
+		// GetLastRelatedUserCache holds details about calls to the GetLastRelatedUserCache method.
+		GetLastRelatedUserCache []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetLoginChallenge holds details about calls to the GetLoginChallenge method.
+		GetLoginChallenge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+		}
+		// GetLoginLock holds details about calls to the GetLoginLock method.
+		GetLoginLock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// GetRelatedUserCache holds details about calls to the GetRelatedUserCache method.
+		GetRelatedUserCache []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetRelatedUserCacheLen holds details about calls to the GetRelatedUserCacheLen method.
+		GetRelatedUserCacheLen []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetRewindCount holds details about calls to the GetRewindCount method.
+		GetRewindCount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetSession holds details about calls to the GetSession method.
+		GetSession []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+		}
+		// GetSuperLikeCount holds details about calls to the GetSuperLikeCount method.
+		GetSuperLikeCount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// IncrLoginFailure holds details about calls to the IncrLoginFailure method.
+		IncrLoginFailure []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// IncrRewindCount holds details about calls to the IncrRewindCount method.
+		IncrRewindCount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// IncrSuperLikeCount holds details about calls to the IncrSuperLikeCount method.
+		IncrSuperLikeCount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// MarkProfileViewed holds details about calls to the MarkProfileViewed method.
+		MarkProfileViewed []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// View is the view argument value.
+			View model.ProfileView
+		}
+		// PopDueQuotaResets holds details about calls to the PopDueQuotaResets method.
+		PopDueQuotaResets []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// PopOAuthState holds details about calls to the PopOAuthState method.
+		PopOAuthState []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// State is the state argument value.
+			State string
+		}
+		// PopProfileViews holds details about calls to the PopProfileViews method.
+		PopProfileViews []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Count is the count argument value.
+			Count int
+		}
+		// PublishEvent holds details about calls to the PublishEvent method.
+		PublishEvent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Event is the event argument value.
+			Event model.Event
+		}
+		// PushProfileViews holds details about calls to the PushProfileViews method.
+		PushProfileViews []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Views is the views argument value.
+			Views []model.ProfileView
+		}
+		// ReleaseLock holds details about calls to the ReleaseLock method.
+		ReleaseLock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Name is the name argument value.
+			Name string
+			// Owner is the owner argument value.
+			Owner string
+		}
+		// RemoveRelatedUserCache holds details about calls to the RemoveRelatedUserCache method.
+		RemoveRelatedUserCache []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Data is the data argument value.
+			Data model.UserRelation
+		}
+		// RemoveRelatedUserLike holds details about calls to the RemoveRelatedUserLike method.
+		RemoveRelatedUserLike []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// LikedUserID is the likedUserID argument value.
+			LikedUserID int64
+		}
+		// ResetLoginFailure holds details about calls to the ResetLoginFailure method.
+		ResetLoginFailure []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+		}
+		// ScheduleQuotaReset holds details about calls to the ScheduleQuotaReset method.
+		ScheduleQuotaReset []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// At is the at argument value.
+			At time.Time
+		}
+		// SetEmailVerification holds details about calls to the SetEmailVerification method.
+		SetEmailVerification []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+			// Data is the data argument value.
+			Data model.EmailVerification
+		}
+		// SetLoginChallenge holds details about calls to the SetLoginChallenge method.
+		SetLoginChallenge []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// SetLoginLock holds details about calls to the SetLoginLock method.
+		SetLoginLock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Key is the key argument value.
+			Key string
+			// Duration is the duration argument value.
+			Duration time.Duration
+		}
+		// SetOAuthState holds details about calls to the SetOAuthState method.
+		SetOAuthState []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// State is the state argument value.
+			State string
+			// Data is the data argument value.
+			Data model.OAuthState
+		}
+		// SetRelatedUserCache holds details about calls to the SetRelatedUserCache method.
+		SetRelatedUserCache []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Data is the data argument value.
+			Data model.UserRelation
+		}
+		// SetSession holds details about calls to the SetSession method.
+		SetSession []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// SubscribeEvents holds details about calls to the SubscribeEvents method.
+		SubscribeEvents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockAcquireLock             sync.RWMutex
+	lockAddBoostedUser          sync.RWMutex
+	lockDecrSuperLikeCount      sync.RWMutex
+	lockDeleteEmailVerification sync.RWMutex
+	lockDeleteLoginChallenge    sync.RWMutex
+	lockDeleteSessions          sync.RWMutex
+	lockGetBoostedUsers         sync.RWMutex
+	lockGetEmailVerification    sync.RWMutex
+	lockGetEventsSince          sync.RWMutex
+	lockGetLastRelatedUserCache sync.RWMutex
+	lockGetLoginChallenge       sync.RWMutex
+	lockGetLoginLock            sync.RWMutex
+	lockGetRelatedUserCache     sync.RWMutex
+	lockGetRelatedUserCacheLen  sync.RWMutex
+	lockGetRewindCount          sync.RWMutex
+	lockGetSession              sync.RWMutex
+	lockGetSuperLikeCount       sync.RWMutex
+	lockIncrLoginFailure        sync.RWMutex
+	lockIncrRewindCount         sync.RWMutex
+	lockIncrSuperLikeCount      sync.RWMutex
+	lockMarkProfileViewed       sync.RWMutex
+	lockPopDueQuotaResets       sync.RWMutex
+	lockPopOAuthState           sync.RWMutex
+	lockPopProfileViews         sync.RWMutex
+	lockPublishEvent            sync.RWMutex
+	lockPushProfileViews        sync.RWMutex
+	lockReleaseLock             sync.RWMutex
+	lockRemoveRelatedUserCache  sync.RWMutex
+	lockRemoveRelatedUserLike   sync.RWMutex
+	lockResetLoginFailure       sync.RWMutex
+	lockScheduleQuotaReset      sync.RWMutex
+	lockSetEmailVerification    sync.RWMutex
+	lockSetLoginChallenge       sync.RWMutex
+	lockSetLoginLock            sync.RWMutex
+	lockSetOAuthState           sync.RWMutex
+	lockSetRelatedUserCache     sync.RWMutex
+	lockSetSession              sync.RWMutex
+	lockSubscribeEvents         sync.RWMutex
+}
+
+// AcquireLock calls AcquireLockFunc.
+func (mock *RepoMock) AcquireLock(ctx context.Context, name string, owner string, ttl time.Duration) (bool, error) {
+	if mock.AcquireLockFunc == nil {
+		panic("RepoMock.AcquireLockFunc: method is nil but Repo.AcquireLock was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Name  string
+		Owner string
+		TTL   time.Duration
+	}{
+		Ctx:   ctx,
+		Name:  name,
+		Owner: owner,
+		TTL:   ttl,
+	}
+	mock.lockAcquireLock.Lock()
+	mock.calls.AcquireLock = append(mock.calls.AcquireLock, callInfo)
+	mock.lockAcquireLock.Unlock()
+	return mock.AcquireLockFunc(ctx, name, owner, ttl)
+}
+
+// AcquireLockCalls gets all the calls that were made to AcquireLock.
+// Check the length with:
+//
+//	len(mockedRepo.AcquireLockCalls())
+func (mock *RepoMock) AcquireLockCalls() []struct {
+	Ctx   context.Context
+	Name  string
+	Owner string
+	TTL   time.Duration
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Name  string
+		Owner string
+		TTL   time.Duration
+	}
+	mock.lockAcquireLock.RLock()
+	calls = mock.calls.AcquireLock
+	mock.lockAcquireLock.RUnlock()
+	return calls
+}
+
+// AddBoostedUser calls AddBoostedUserFunc.
+func (mock *RepoMock) AddBoostedUser(ctx context.Context, boost model.Boost) error {
+	if mock.AddBoostedUserFunc == nil {
+		panic("RepoMock.AddBoostedUserFunc: method is nil but Repo.AddBoostedUser was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Boost model.Boost
+	}{
+		Ctx:   ctx,
+		Boost: boost,
+	}
+	mock.lockAddBoostedUser.Lock()
+	mock.calls.AddBoostedUser = append(mock.calls.AddBoostedUser, callInfo)
+	mock.lockAddBoostedUser.Unlock()
+	return mock.AddBoostedUserFunc(ctx, boost)
+}
+
+// AddBoostedUserCalls gets all the calls that were made to AddBoostedUser.
+// Check the length with:
+//
+//	len(mockedRepo.AddBoostedUserCalls())
+func (mock *RepoMock) AddBoostedUserCalls() []struct {
+	Ctx   context.Context
+	Boost model.Boost
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Boost model.Boost
+	}
+	mock.lockAddBoostedUser.RLock()
+	calls = mock.calls.AddBoostedUser
+	mock.lockAddBoostedUser.RUnlock()
+	return calls
+}
+
+// DecrSuperLikeCount calls DecrSuperLikeCountFunc.
+func (mock *RepoMock) DecrSuperLikeCount(ctx context.Context, userID int64) (int64, error) {
+	if mock.DecrSuperLikeCountFunc == nil {
+		panic("RepoMock.DecrSuperLikeCountFunc: method is nil but Repo.DecrSuperLikeCount was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockDecrSuperLikeCount.Lock()
+	mock.calls.DecrSuperLikeCount = append(mock.calls.DecrSuperLikeCount, callInfo)
+	mock.lockDecrSuperLikeCount.Unlock()
+	return mock.DecrSuperLikeCountFunc(ctx, userID)
+}
+
+// DecrSuperLikeCountCalls gets all the calls that were made to DecrSuperLikeCount.
+// Check the length with:
+//
+//	len(mockedRepo.DecrSuperLikeCountCalls())
+func (mock *RepoMock) DecrSuperLikeCountCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockDecrSuperLikeCount.RLock()
+	calls = mock.calls.DecrSuperLikeCount
+	mock.lockDecrSuperLikeCount.RUnlock()
+	return calls
+}
+
+// DeleteEmailVerification calls DeleteEmailVerificationFunc.
+func (mock *RepoMock) DeleteEmailVerification(ctx context.Context, token string) error {
+	if mock.DeleteEmailVerificationFunc == nil {
+		panic("RepoMock.DeleteEmailVerificationFunc: method is nil but Repo.DeleteEmailVerification was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Token string
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockDeleteEmailVerification.Lock()
+	mock.calls.DeleteEmailVerification = append(mock.calls.DeleteEmailVerification, callInfo)
+	mock.lockDeleteEmailVerification.Unlock()
+	return mock.DeleteEmailVerificationFunc(ctx, token)
+}
+
+// DeleteEmailVerificationCalls gets all the calls that were made to DeleteEmailVerification.
+// Check the length with:
+//
+//	len(mockedRepo.DeleteEmailVerificationCalls())
+func (mock *RepoMock) DeleteEmailVerificationCalls() []struct {
+	Ctx   context.Context
+	Token string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token string
+	}
+	mock.lockDeleteEmailVerification.RLock()
+	calls = mock.calls.DeleteEmailVerification
+	mock.lockDeleteEmailVerification.RUnlock()
+	return calls
+}
+
+// DeleteLoginChallenge calls DeleteLoginChallengeFunc.
+func (mock *RepoMock) DeleteLoginChallenge(ctx context.Context, token string) error {
+	if mock.DeleteLoginChallengeFunc == nil {
+		panic("RepoMock.DeleteLoginChallengeFunc: method is nil but Repo.DeleteLoginChallenge was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Token string
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockDeleteLoginChallenge.Lock()
+	mock.calls.DeleteLoginChallenge = append(mock.calls.DeleteLoginChallenge, callInfo)
+	mock.lockDeleteLoginChallenge.Unlock()
+	return mock.DeleteLoginChallengeFunc(ctx, token)
+}
+
+// DeleteLoginChallengeCalls gets all the calls that were made to DeleteLoginChallenge.
+// Check the length with:
+//
+//	len(mockedRepo.DeleteLoginChallengeCalls())
+func (mock *RepoMock) DeleteLoginChallengeCalls() []struct {
+	Ctx   context.Context
+	Token string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token string
+	}
+	mock.lockDeleteLoginChallenge.RLock()
+	calls = mock.calls.DeleteLoginChallenge
+	mock.lockDeleteLoginChallenge.RUnlock()
+	return calls
+}
+
+// DeleteSessions calls DeleteSessionsFunc.
+func (mock *RepoMock) DeleteSessions(ctx context.Context, userID int64) error {
+	if mock.DeleteSessionsFunc == nil {
+		panic("RepoMock.DeleteSessionsFunc: method is nil but Repo.DeleteSessions was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockDeleteSessions.Lock()
+	mock.calls.DeleteSessions = append(mock.calls.DeleteSessions, callInfo)
+	mock.lockDeleteSessions.Unlock()
+	return mock.DeleteSessionsFunc(ctx, userID)
+}
+
+// DeleteSessionsCalls gets all the calls that were made to DeleteSessions.
+// Check the length with:
+//
+//	len(mockedRepo.DeleteSessionsCalls())
+func (mock *RepoMock) DeleteSessionsCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockDeleteSessions.RLock()
+	calls = mock.calls.DeleteSessions
+	mock.lockDeleteSessions.RUnlock()
+	return calls
+}
+
+// GetBoostedUsers calls GetBoostedUsersFunc.
+func (mock *RepoMock) GetBoostedUsers(ctx context.Context, at time.Time) (map[int64]bool, error) {
+	if mock.GetBoostedUsersFunc == nil {
+		panic("RepoMock.GetBoostedUsersFunc: method is nil but Repo.GetBoostedUsers was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		At  time.Time
+	}{
+		Ctx: ctx,
+		At:  at,
+	}
+	mock.lockGetBoostedUsers.Lock()
+	mock.calls.GetBoostedUsers = append(mock.calls.GetBoostedUsers, callInfo)
+	mock.lockGetBoostedUsers.Unlock()
+	return mock.GetBoostedUsersFunc(ctx, at)
+}
+
+// GetBoostedUsersCalls gets all the calls that were made to GetBoostedUsers.
+// Check the length with:
+//
+//	len(mockedRepo.GetBoostedUsersCalls())
+func (mock *RepoMock) GetBoostedUsersCalls() []struct {
+	Ctx context.Context
+	At  time.Time
+} {
+	var calls []struct {
+		Ctx context.Context
+		At  time.Time
+	}
+	mock.lockGetBoostedUsers.RLock()
+	calls = mock.calls.GetBoostedUsers
+	mock.lockGetBoostedUsers.RUnlock()
+	return calls
+}
+
+// GetEmailVerification calls GetEmailVerificationFunc.
+func (mock *RepoMock) GetEmailVerification(ctx context.Context, token string) (model.EmailVerification, error) {
+	if mock.GetEmailVerificationFunc == nil {
+		panic("RepoMock.GetEmailVerificationFunc: method is nil but Repo.GetEmailVerification was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Token string
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockGetEmailVerification.Lock()
+	mock.calls.GetEmailVerification = append(mock.calls.GetEmailVerification, callInfo)
+	mock.lockGetEmailVerification.Unlock()
+	return mock.GetEmailVerificationFunc(ctx, token)
+}
+
+// GetEmailVerificationCalls gets all the calls that were made to GetEmailVerification.
+// Check the length with:
+//
+//	len(mockedRepo.GetEmailVerificationCalls())
+func (mock *RepoMock) GetEmailVerificationCalls() []struct {
+	Ctx   context.Context
+	Token string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token string
+	}
+	mock.lockGetEmailVerification.RLock()
+	calls = mock.calls.GetEmailVerification
+	mock.lockGetEmailVerification.RUnlock()
+	return calls
+}
+
+// GetEventsSince calls GetEventsSinceFunc.
+func (mock *RepoMock) GetEventsSince(ctx context.Context, userID int64, afterSeq int64) ([]model.Event, error) {
+	if mock.GetEventsSinceFunc == nil {
+		panic("RepoMock.GetEventsSinceFunc: method is nil but Repo.GetEventsSince was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   int64
+		AfterSeq int64
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		AfterSeq: afterSeq,
+	}
+	mock.lockGetEventsSince.Lock()
+	mock.calls.GetEventsSince = append(mock.calls.GetEventsSince, callInfo)
+	mock.lockGetEventsSince.Unlock()
+	return mock.GetEventsSinceFunc(ctx, userID, afterSeq)
+}
+
+// GetEventsSinceCalls gets all the calls that were made to GetEventsSince.
+// Check the length with:
+//
+//	len(mockedRepo.GetEventsSinceCalls())
+func (mock *RepoMock) GetEventsSinceCalls() []struct {
+	Ctx      context.Context
+	UserID   int64
+	AfterSeq int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   int64
+		AfterSeq int64
+	}
+	mock.lockGetEventsSince.RLock()
+	calls = mock.calls.GetEventsSince
+	mock.lockGetEventsSince.RUnlock()
+	return calls
+}
+
+// GetLastRelatedUserCache calls GetLastRelatedUserCacheFunc.
+func (mock *RepoMock) GetLastRelatedUserCache(ctx context.Context, userID int64) (model.UserRelation, error) {
+	if mock.GetLastRelatedUserCacheFunc == nil {
+		panic("RepoMock.GetLastRelatedUserCacheFunc: method is nil but Repo.GetLastRelatedUserCache was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetLastRelatedUserCache.Lock()
+	mock.calls.GetLastRelatedUserCache = append(mock.calls.GetLastRelatedUserCache, callInfo)
+	mock.lockGetLastRelatedUserCache.Unlock()
+	return mock.GetLastRelatedUserCacheFunc(ctx, userID)
+}
+
+// GetLastRelatedUserCacheCalls gets all the calls that were made to GetLastRelatedUserCache.
+// Check the length with:
+//
+//	len(mockedRepo.GetLastRelatedUserCacheCalls())
+func (mock *RepoMock) GetLastRelatedUserCacheCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetLastRelatedUserCache.RLock()
+	calls = mock.calls.GetLastRelatedUserCache
+	mock.lockGetLastRelatedUserCache.RUnlock()
+	return calls
+}
+
+// GetLoginChallenge calls GetLoginChallengeFunc.
+func (mock *RepoMock) GetLoginChallenge(ctx context.Context, token string) (int64, error) {
+	if mock.GetLoginChallengeFunc == nil {
+		panic("RepoMock.GetLoginChallengeFunc: method is nil but Repo.GetLoginChallenge was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Token string
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockGetLoginChallenge.Lock()
+	mock.calls.GetLoginChallenge = append(mock.calls.GetLoginChallenge, callInfo)
+	mock.lockGetLoginChallenge.Unlock()
+	return mock.GetLoginChallengeFunc(ctx, token)
+}
+
+// GetLoginChallengeCalls gets all the calls that were made to GetLoginChallenge.
+// Check the length with:
+//
+//	len(mockedRepo.GetLoginChallengeCalls())
+func (mock *RepoMock) GetLoginChallengeCalls() []struct {
+	Ctx   context.Context
+	Token string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token string
+	}
+	mock.lockGetLoginChallenge.RLock()
+	calls = mock.calls.GetLoginChallenge
+	mock.lockGetLoginChallenge.RUnlock()
+	return calls
+}
+
+// GetLoginLock calls GetLoginLockFunc.
+func (mock *RepoMock) GetLoginLock(ctx context.Context, key string) (time.Duration, error) {
+	if mock.GetLoginLockFunc == nil {
+		panic("RepoMock.GetLoginLockFunc: method is nil but Repo.GetLoginLock was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockGetLoginLock.Lock()
+	mock.calls.GetLoginLock = append(mock.calls.GetLoginLock, callInfo)
+	mock.lockGetLoginLock.Unlock()
+	return mock.GetLoginLockFunc(ctx, key)
+}
+
+// GetLoginLockCalls gets all the calls that were made to GetLoginLock.
+// Check the length with:
+//
+//	len(mockedRepo.GetLoginLockCalls())
+func (mock *RepoMock) GetLoginLockCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockGetLoginLock.RLock()
+	calls = mock.calls.GetLoginLock
+	mock.lockGetLoginLock.RUnlock()
+	return calls
+}
+
+// GetRelatedUserCache calls GetRelatedUserCacheFunc.
+func (mock *RepoMock) GetRelatedUserCache(ctx context.Context, userID int64) (map[int64]int, error) {
+	if mock.GetRelatedUserCacheFunc == nil {
+		panic("RepoMock.GetRelatedUserCacheFunc: method is nil but Repo.GetRelatedUserCache was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetRelatedUserCache.Lock()
+	mock.calls.GetRelatedUserCache = append(mock.calls.GetRelatedUserCache, callInfo)
+	mock.lockGetRelatedUserCache.Unlock()
+	return mock.GetRelatedUserCacheFunc(ctx, userID)
+}
+
+// GetRelatedUserCacheCalls gets all the calls that were made to GetRelatedUserCache.
+// Check the length with:
+//
+//	len(mockedRepo.GetRelatedUserCacheCalls())
+func (mock *RepoMock) GetRelatedUserCacheCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetRelatedUserCache.RLock()
+	calls = mock.calls.GetRelatedUserCache
+	mock.lockGetRelatedUserCache.RUnlock()
+	return calls
+}
+
+// GetRelatedUserCacheLen calls GetRelatedUserCacheLenFunc.
+func (mock *RepoMock) GetRelatedUserCacheLen(ctx context.Context, userID int64) (int64, error) {
+	if mock.GetRelatedUserCacheLenFunc == nil {
+		panic("RepoMock.GetRelatedUserCacheLenFunc: method is nil but Repo.GetRelatedUserCacheLen was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetRelatedUserCacheLen.Lock()
+	mock.calls.GetRelatedUserCacheLen = append(mock.calls.GetRelatedUserCacheLen, callInfo)
+	mock.lockGetRelatedUserCacheLen.Unlock()
+	return mock.GetRelatedUserCacheLenFunc(ctx, userID)
+}
+
+// GetRelatedUserCacheLenCalls gets all the calls that were made to GetRelatedUserCacheLen.
+// Check the length with:
+//
+//	len(mockedRepo.GetRelatedUserCacheLenCalls())
+func (mock *RepoMock) GetRelatedUserCacheLenCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetRelatedUserCacheLen.RLock()
+	calls = mock.calls.GetRelatedUserCacheLen
+	mock.lockGetRelatedUserCacheLen.RUnlock()
+	return calls
+}
+
+// GetRewindCount calls GetRewindCountFunc.
+func (mock *RepoMock) GetRewindCount(ctx context.Context, userID int64) (int64, error) {
+	if mock.GetRewindCountFunc == nil {
+		panic("RepoMock.GetRewindCountFunc: method is nil but Repo.GetRewindCount was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetRewindCount.Lock()
+	mock.calls.GetRewindCount = append(mock.calls.GetRewindCount, callInfo)
+	mock.lockGetRewindCount.Unlock()
+	return mock.GetRewindCountFunc(ctx, userID)
+}
+
+// GetRewindCountCalls gets all the calls that were made to GetRewindCount.
+// Check the length with:
+//
+//	len(mockedRepo.GetRewindCountCalls())
+func (mock *RepoMock) GetRewindCountCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetRewindCount.RLock()
+	calls = mock.calls.GetRewindCount
+	mock.lockGetRewindCount.RUnlock()
+	return calls
+}
+
+// GetSession calls GetSessionFunc.
+func (mock *RepoMock) GetSession(ctx context.Context, token string) (int64, error) {
+	if mock.GetSessionFunc == nil {
+		panic("RepoMock.GetSessionFunc: method is nil but Repo.GetSession was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Token string
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockGetSession.Lock()
+	mock.calls.GetSession = append(mock.calls.GetSession, callInfo)
+	mock.lockGetSession.Unlock()
+	return mock.GetSessionFunc(ctx, token)
+}
+
+// GetSessionCalls gets all the calls that were made to GetSession.
+// Check the length with:
+//
+//	len(mockedRepo.GetSessionCalls())
+func (mock *RepoMock) GetSessionCalls() []struct {
+	Ctx   context.Context
+	Token string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token string
+	}
+	mock.lockGetSession.RLock()
+	calls = mock.calls.GetSession
+	mock.lockGetSession.RUnlock()
+	return calls
+}
+
+// GetSuperLikeCount calls GetSuperLikeCountFunc.
+func (mock *RepoMock) GetSuperLikeCount(ctx context.Context, userID int64) (int64, error) {
+	if mock.GetSuperLikeCountFunc == nil {
+		panic("RepoMock.GetSuperLikeCountFunc: method is nil but Repo.GetSuperLikeCount was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetSuperLikeCount.Lock()
+	mock.calls.GetSuperLikeCount = append(mock.calls.GetSuperLikeCount, callInfo)
+	mock.lockGetSuperLikeCount.Unlock()
+	return mock.GetSuperLikeCountFunc(ctx, userID)
+}
+
+// GetSuperLikeCountCalls gets all the calls that were made to GetSuperLikeCount.
+// Check the length with:
+//
+//	len(mockedRepo.GetSuperLikeCountCalls())
+func (mock *RepoMock) GetSuperLikeCountCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetSuperLikeCount.RLock()
+	calls = mock.calls.GetSuperLikeCount
+	mock.lockGetSuperLikeCount.RUnlock()
+	return calls
+}
+
+// IncrLoginFailure calls IncrLoginFailureFunc.
+func (mock *RepoMock) IncrLoginFailure(ctx context.Context, key string) (int64, error) {
+	if mock.IncrLoginFailureFunc == nil {
+		panic("RepoMock.IncrLoginFailureFunc: method is nil but Repo.IncrLoginFailure was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockIncrLoginFailure.Lock()
+	mock.calls.IncrLoginFailure = append(mock.calls.IncrLoginFailure, callInfo)
+	mock.lockIncrLoginFailure.Unlock()
+	return mock.IncrLoginFailureFunc(ctx, key)
+}
+
+// IncrLoginFailureCalls gets all the calls that were made to IncrLoginFailure.
+// Check the length with:
+//
+//	len(mockedRepo.IncrLoginFailureCalls())
+func (mock *RepoMock) IncrLoginFailureCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockIncrLoginFailure.RLock()
+	calls = mock.calls.IncrLoginFailure
+	mock.lockIncrLoginFailure.RUnlock()
+	return calls
+}
+
+// IncrRewindCount calls IncrRewindCountFunc.
+func (mock *RepoMock) IncrRewindCount(ctx context.Context, userID int64) (int64, error) {
+	if mock.IncrRewindCountFunc == nil {
+		panic("RepoMock.IncrRewindCountFunc: method is nil but Repo.IncrRewindCount was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockIncrRewindCount.Lock()
+	mock.calls.IncrRewindCount = append(mock.calls.IncrRewindCount, callInfo)
+	mock.lockIncrRewindCount.Unlock()
+	return mock.IncrRewindCountFunc(ctx, userID)
+}
+
+// IncrRewindCountCalls gets all the calls that were made to IncrRewindCount.
+// Check the length with:
+//
+//	len(mockedRepo.IncrRewindCountCalls())
+func (mock *RepoMock) IncrRewindCountCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockIncrRewindCount.RLock()
+	calls = mock.calls.IncrRewindCount
+	mock.lockIncrRewindCount.RUnlock()
+	return calls
+}
+
+// IncrSuperLikeCount calls IncrSuperLikeCountFunc.
+func (mock *RepoMock) IncrSuperLikeCount(ctx context.Context, userID int64) (int64, error) {
+	if mock.IncrSuperLikeCountFunc == nil {
+		panic("RepoMock.IncrSuperLikeCountFunc: method is nil but Repo.IncrSuperLikeCount was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockIncrSuperLikeCount.Lock()
+	mock.calls.IncrSuperLikeCount = append(mock.calls.IncrSuperLikeCount, callInfo)
+	mock.lockIncrSuperLikeCount.Unlock()
+	return mock.IncrSuperLikeCountFunc(ctx, userID)
+}
+
+// IncrSuperLikeCountCalls gets all the calls that were made to IncrSuperLikeCount.
+// Check the length with:
+//
+//	len(mockedRepo.IncrSuperLikeCountCalls())
+func (mock *RepoMock) IncrSuperLikeCountCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockIncrSuperLikeCount.RLock()
+	calls = mock.calls.IncrSuperLikeCount
+	mock.lockIncrSuperLikeCount.RUnlock()
+	return calls
+}
+
+// MarkProfileViewed calls MarkProfileViewedFunc.
+func (mock *RepoMock) MarkProfileViewed(ctx context.Context, view model.ProfileView) (bool, error) {
+	if mock.MarkProfileViewedFunc == nil {
+		panic("RepoMock.MarkProfileViewedFunc: method is nil but Repo.MarkProfileViewed was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		View model.ProfileView
+	}{
+		Ctx:  ctx,
+		View: view,
+	}
+	mock.lockMarkProfileViewed.Lock()
+	mock.calls.MarkProfileViewed = append(mock.calls.MarkProfileViewed, callInfo)
+	mock.lockMarkProfileViewed.Unlock()
+	return mock.MarkProfileViewedFunc(ctx, view)
+}
+
+// MarkProfileViewedCalls gets all the calls that were made to MarkProfileViewed.
+// Check the length with:
+//
+//	len(mockedRepo.MarkProfileViewedCalls())
+func (mock *RepoMock) MarkProfileViewedCalls() []struct {
+	Ctx  context.Context
+	View model.ProfileView
+} {
+	var calls []struct {
+		Ctx  context.Context
+		View model.ProfileView
+	}
+	mock.lockMarkProfileViewed.RLock()
+	calls = mock.calls.MarkProfileViewed
+	mock.lockMarkProfileViewed.RUnlock()
+	return calls
+}
+
+// PopDueQuotaResets calls PopDueQuotaResetsFunc.
+func (mock *RepoMock) PopDueQuotaResets(ctx context.Context, now time.Time) ([]int64, error) {
+	if mock.PopDueQuotaResetsFunc == nil {
+		panic("RepoMock.PopDueQuotaResetsFunc: method is nil but Repo.PopDueQuotaResets was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Now time.Time
+	}{
+		Ctx: ctx,
+		Now: now,
+	}
+	mock.lockPopDueQuotaResets.Lock()
+	mock.calls.PopDueQuotaResets = append(mock.calls.PopDueQuotaResets, callInfo)
+	mock.lockPopDueQuotaResets.Unlock()
+	return mock.PopDueQuotaResetsFunc(ctx, now)
+}
+
+// PopDueQuotaResetsCalls gets all the calls that were made to PopDueQuotaResets.
+// Check the length with:
+//
+//	len(mockedRepo.PopDueQuotaResetsCalls())
+func (mock *RepoMock) PopDueQuotaResetsCalls() []struct {
+	Ctx context.Context
+	Now time.Time
+} {
+	var calls []struct {
+		Ctx context.Context
+		Now time.Time
+	}
+	mock.lockPopDueQuotaResets.RLock()
+	calls = mock.calls.PopDueQuotaResets
+	mock.lockPopDueQuotaResets.RUnlock()
+	return calls
+}
+
+// PopOAuthState calls PopOAuthStateFunc.
+func (mock *RepoMock) PopOAuthState(ctx context.Context, state string) (model.OAuthState, error) {
+	if mock.PopOAuthStateFunc == nil {
+		panic("RepoMock.PopOAuthStateFunc: method is nil but Repo.PopOAuthState was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		State string
+	}{
+		Ctx:   ctx,
+		State: state,
+	}
+	mock.lockPopOAuthState.Lock()
+	mock.calls.PopOAuthState = append(mock.calls.PopOAuthState, callInfo)
+	mock.lockPopOAuthState.Unlock()
+	return mock.PopOAuthStateFunc(ctx, state)
+}
+
+// PopOAuthStateCalls gets all the calls that were made to PopOAuthState.
+// Check the length with:
+//
+//	len(mockedRepo.PopOAuthStateCalls())
+func (mock *RepoMock) PopOAuthStateCalls() []struct {
+	Ctx   context.Context
+	State string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		State string
+	}
+	mock.lockPopOAuthState.RLock()
+	calls = mock.calls.PopOAuthState
+	mock.lockPopOAuthState.RUnlock()
+	return calls
+}
+
+// PopProfileViews calls PopProfileViewsFunc.
+func (mock *RepoMock) PopProfileViews(ctx context.Context, count int) ([]model.ProfileView, error) {
+	if mock.PopProfileViewsFunc == nil {
+		panic("RepoMock.PopProfileViewsFunc: method is nil but Repo.PopProfileViews was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Count int
+	}{
+		Ctx:   ctx,
+		Count: count,
+	}
+	mock.lockPopProfileViews.Lock()
+	mock.calls.PopProfileViews = append(mock.calls.PopProfileViews, callInfo)
+	mock.lockPopProfileViews.Unlock()
+	return mock.PopProfileViewsFunc(ctx, count)
+}
+
+// PopProfileViewsCalls gets all the calls that were made to PopProfileViews.
+// Check the length with:
 //
-//	}
-type RepoMock struct {
-	// GetRelatedUserCacheFunc mocks the GetRelatedUserCache method.
-	GetRelatedUserCacheFunc func(ctx context.Context, userID int64) (map[int64]int, error)
+//	len(mockedRepo.PopProfileViewsCalls())
+func (mock *RepoMock) PopProfileViewsCalls() []struct {
+	Ctx   context.Context
+	Count int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Count int
+	}
+	mock.lockPopProfileViews.RLock()
+	calls = mock.calls.PopProfileViews
+	mock.lockPopProfileViews.RUnlock()
+	return calls
+}
 
-	// GetRelatedUserCacheLenFunc mocks the GetRelatedUserCacheLen method.
-	GetRelatedUserCacheLenFunc func(ctx context.Context, userID int64) (int64, error)
+// PublishEvent calls PublishEventFunc.
+func (mock *RepoMock) PublishEvent(ctx context.Context, event model.Event) error {
+	if mock.PublishEventFunc == nil {
+		panic("RepoMock.PublishEventFunc: method is nil but Repo.PublishEvent was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Event model.Event
+	}{
+		Ctx:   ctx,
+		Event: event,
+	}
+	mock.lockPublishEvent.Lock()
+	mock.calls.PublishEvent = append(mock.calls.PublishEvent, callInfo)
+	mock.lockPublishEvent.Unlock()
+	return mock.PublishEventFunc(ctx, event)
+}
 
-	// SetRelatedUserCacheFunc mocks the SetRelatedUserCache method.
-	SetRelatedUserCacheFunc func(ctx context.Context, userID int64, data model.UserRelation) error
+// PublishEventCalls gets all the calls that were made to PublishEvent.
+// Check the length with:
+//
+//	len(mockedRepo.PublishEventCalls())
+func (mock *RepoMock) PublishEventCalls() []struct {
+	Ctx   context.Context
+	Event model.Event
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Event model.Event
+	}
+	mock.lockPublishEvent.RLock()
+	calls = mock.calls.PublishEvent
+	mock.lockPublishEvent.RUnlock()
+	return calls
+}
 
-	// calls tracks calls to the methods.
-	calls struct {
-		// GetRelatedUserCache holds details about calls to the GetRelatedUserCache method.
-		GetRelatedUserCache []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// UserID is the userID argument value.
-			UserID int64
-		}
-		// GetRelatedUserCacheLen holds details about calls to the GetRelatedUserCacheLen method.
-		GetRelatedUserCacheLen []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// UserID is the userID argument value.
-			UserID int64
-		}
-		// SetRelatedUserCache holds details about calls to the SetRelatedUserCache method.
-		SetRelatedUserCache []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// UserID is the userID argument value.
-			UserID int64
-			// Data is the data argument value.
-			Data model.UserRelation
-		}
+// PushProfileViews calls PushProfileViewsFunc.
+func (mock *RepoMock) PushProfileViews(ctx context.Context, views []model.ProfileView) error {
+	if mock.PushProfileViewsFunc == nil {
+		panic("RepoMock.PushProfileViewsFunc: method is nil but Repo.PushProfileViews was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Views []model.ProfileView
+	}{
+		Ctx:   ctx,
+		Views: views,
 	}
-	lockGetRelatedUserCache    sync.RWMutex
-	lockGetRelatedUserCacheLen sync.RWMutex
-	lockSetRelatedUserCache    sync.RWMutex
+	mock.lockPushProfileViews.Lock()
+	mock.calls.PushProfileViews = append(mock.calls.PushProfileViews, callInfo)
+	mock.lockPushProfileViews.Unlock()
+	return mock.PushProfileViewsFunc(ctx, views)
 }
 
-// GetRelatedUserCache calls GetRelatedUserCacheFunc.
-func (mock *RepoMock) GetRelatedUserCache(ctx context.Context, userID int64) (map[int64]int, error) {
-	if mock.GetRelatedUserCacheFunc == nil {
-		panic("RepoMock.GetRelatedUserCacheFunc: method is nil but Repo.GetRelatedUserCache was just called")
+// PushProfileViewsCalls gets all the calls that were made to PushProfileViews.
+// Check the length with:
+//
+//	len(mockedRepo.PushProfileViewsCalls())
+func (mock *RepoMock) PushProfileViewsCalls() []struct {
+	Ctx   context.Context
+	Views []model.ProfileView
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Views []model.ProfileView
+	}
+	mock.lockPushProfileViews.RLock()
+	calls = mock.calls.PushProfileViews
+	mock.lockPushProfileViews.RUnlock()
+	return calls
+}
+
+// ReleaseLock calls ReleaseLockFunc.
+func (mock *RepoMock) ReleaseLock(ctx context.Context, name string, owner string) error {
+	if mock.ReleaseLockFunc == nil {
+		panic("RepoMock.ReleaseLockFunc: method is nil but Repo.ReleaseLock was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Name  string
+		Owner string
+	}{
+		Ctx:   ctx,
+		Name:  name,
+		Owner: owner,
+	}
+	mock.lockReleaseLock.Lock()
+	mock.calls.ReleaseLock = append(mock.calls.ReleaseLock, callInfo)
+	mock.lockReleaseLock.Unlock()
+	return mock.ReleaseLockFunc(ctx, name, owner)
+}
+
+// ReleaseLockCalls gets all the calls that were made to ReleaseLock.
+// Check the length with:
+//
+//	len(mockedRepo.ReleaseLockCalls())
+func (mock *RepoMock) ReleaseLockCalls() []struct {
+	Ctx   context.Context
+	Name  string
+	Owner string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Name  string
+		Owner string
+	}
+	mock.lockReleaseLock.RLock()
+	calls = mock.calls.ReleaseLock
+	mock.lockReleaseLock.RUnlock()
+	return calls
+}
+
+// RemoveRelatedUserCache calls RemoveRelatedUserCacheFunc.
+func (mock *RepoMock) RemoveRelatedUserCache(ctx context.Context, userID int64, data model.UserRelation) error {
+	if mock.RemoveRelatedUserCacheFunc == nil {
+		panic("RepoMock.RemoveRelatedUserCacheFunc: method is nil but Repo.RemoveRelatedUserCache was just called")
 	}
 	callInfo := struct {
 		Ctx    context.Context
 		UserID int64
+		Data   model.UserRelation
 	}{
 		Ctx:    ctx,
 		UserID: userID,
+		Data:   data,
 	}
-	mock.lockGetRelatedUserCache.Lock()
-	mock.calls.GetRelatedUserCache = append(mock.calls.GetRelatedUserCache, callInfo)
-	mock.lockGetRelatedUserCache.Unlock()
-	return mock.GetRelatedUserCacheFunc(ctx, userID)
+	mock.lockRemoveRelatedUserCache.Lock()
+	mock.calls.RemoveRelatedUserCache = append(mock.calls.RemoveRelatedUserCache, callInfo)
+	mock.lockRemoveRelatedUserCache.Unlock()
+	return mock.RemoveRelatedUserCacheFunc(ctx, userID, data)
 }
 
-// GetRelatedUserCacheCalls gets all the calls that were made to GetRelatedUserCache.
+// RemoveRelatedUserCacheCalls gets all the calls that were made to RemoveRelatedUserCache.
 // Check the length with:
 //
-//	len(mockedRepo.GetRelatedUserCacheCalls())
-func (mock *RepoMock) GetRelatedUserCacheCalls() []struct {
+//	len(mockedRepo.RemoveRelatedUserCacheCalls())
+func (mock *RepoMock) RemoveRelatedUserCacheCalls() []struct {
 	Ctx    context.Context
 	UserID int64
+	Data   model.UserRelation
 } {
 	var calls []struct {
 		Ctx    context.Context
 		UserID int64
+		Data   model.UserRelation
 	}
-	mock.lockGetRelatedUserCache.RLock()
-	calls = mock.calls.GetRelatedUserCache
-	mock.lockGetRelatedUserCache.RUnlock()
+	mock.lockRemoveRelatedUserCache.RLock()
+	calls = mock.calls.RemoveRelatedUserCache
+	mock.lockRemoveRelatedUserCache.RUnlock()
 	return calls
 }
 
-// GetRelatedUserCacheLen calls GetRelatedUserCacheLenFunc.
-func (mock *RepoMock) GetRelatedUserCacheLen(ctx context.Context, userID int64) (int64, error) {
-	if mock.GetRelatedUserCacheLenFunc == nil {
-		panic("RepoMock.GetRelatedUserCacheLenFunc: method is nil but Repo.GetRelatedUserCacheLen was just called")
+// RemoveRelatedUserLike calls RemoveRelatedUserLikeFunc.
+func (mock *RepoMock) RemoveRelatedUserLike(ctx context.Context, userID int64, likedUserID int64) error {
+	if mock.RemoveRelatedUserLikeFunc == nil {
+		panic("RepoMock.RemoveRelatedUserLikeFunc: method is nil but Repo.RemoveRelatedUserLike was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		UserID      int64
+		LikedUserID int64
+	}{
+		Ctx:         ctx,
+		UserID:      userID,
+		LikedUserID: likedUserID,
+	}
+	mock.lockRemoveRelatedUserLike.Lock()
+	mock.calls.RemoveRelatedUserLike = append(mock.calls.RemoveRelatedUserLike, callInfo)
+	mock.lockRemoveRelatedUserLike.Unlock()
+	return mock.RemoveRelatedUserLikeFunc(ctx, userID, likedUserID)
+}
+
+// RemoveRelatedUserLikeCalls gets all the calls that were made to RemoveRelatedUserLike.
+// Check the length with:
+//
+//	len(mockedRepo.RemoveRelatedUserLikeCalls())
+func (mock *RepoMock) RemoveRelatedUserLikeCalls() []struct {
+	Ctx         context.Context
+	UserID      int64
+	LikedUserID int64
+} {
+	var calls []struct {
+		Ctx         context.Context
+		UserID      int64
+		LikedUserID int64
+	}
+	mock.lockRemoveRelatedUserLike.RLock()
+	calls = mock.calls.RemoveRelatedUserLike
+	mock.lockRemoveRelatedUserLike.RUnlock()
+	return calls
+}
+
+// ResetLoginFailure calls ResetLoginFailureFunc.
+func (mock *RepoMock) ResetLoginFailure(ctx context.Context, key string) error {
+	if mock.ResetLoginFailureFunc == nil {
+		panic("RepoMock.ResetLoginFailureFunc: method is nil but Repo.ResetLoginFailure was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Key string
+	}{
+		Ctx: ctx,
+		Key: key,
+	}
+	mock.lockResetLoginFailure.Lock()
+	mock.calls.ResetLoginFailure = append(mock.calls.ResetLoginFailure, callInfo)
+	mock.lockResetLoginFailure.Unlock()
+	return mock.ResetLoginFailureFunc(ctx, key)
+}
+
+// ResetLoginFailureCalls gets all the calls that were made to ResetLoginFailure.
+// Check the length with:
+//
+//	len(mockedRepo.ResetLoginFailureCalls())
+func (mock *RepoMock) ResetLoginFailureCalls() []struct {
+	Ctx context.Context
+	Key string
+} {
+	var calls []struct {
+		Ctx context.Context
+		Key string
+	}
+	mock.lockResetLoginFailure.RLock()
+	calls = mock.calls.ResetLoginFailure
+	mock.lockResetLoginFailure.RUnlock()
+	return calls
+}
+
+// ScheduleQuotaReset calls ScheduleQuotaResetFunc.
+func (mock *RepoMock) ScheduleQuotaReset(ctx context.Context, userID int64, at time.Time) error {
+	if mock.ScheduleQuotaResetFunc == nil {
+		panic("RepoMock.ScheduleQuotaResetFunc: method is nil but Repo.ScheduleQuotaReset was just called")
 	}
 	callInfo := struct {
 		Ctx    context.Context
 		UserID int64
+		At     time.Time
 	}{
 		Ctx:    ctx,
 		UserID: userID,
+		At:     at,
 	}
-	mock.lockGetRelatedUserCacheLen.Lock()
-	mock.calls.GetRelatedUserCacheLen = append(mock.calls.GetRelatedUserCacheLen, callInfo)
-	mock.lockGetRelatedUserCacheLen.Unlock()
-	return mock.GetRelatedUserCacheLenFunc(ctx, userID)
+	mock.lockScheduleQuotaReset.Lock()
+	mock.calls.ScheduleQuotaReset = append(mock.calls.ScheduleQuotaReset, callInfo)
+	mock.lockScheduleQuotaReset.Unlock()
+	return mock.ScheduleQuotaResetFunc(ctx, userID, at)
 }
 
-// GetRelatedUserCacheLenCalls gets all the calls that were made to GetRelatedUserCacheLen.
+// ScheduleQuotaResetCalls gets all the calls that were made to ScheduleQuotaReset.
 // Check the length with:
 //
-//	len(mockedRepo.GetRelatedUserCacheLenCalls())
-func (mock *RepoMock) GetRelatedUserCacheLenCalls() []struct {
+//	len(mockedRepo.ScheduleQuotaResetCalls())
+func (mock *RepoMock) ScheduleQuotaResetCalls() []struct {
 	Ctx    context.Context
 	UserID int64
+	At     time.Time
 } {
 	var calls []struct {
 		Ctx    context.Context
 		UserID int64
+		At     time.Time
 	}
-	mock.lockGetRelatedUserCacheLen.RLock()
-	calls = mock.calls.GetRelatedUserCacheLen
-	mock.lockGetRelatedUserCacheLen.RUnlock()
+	mock.lockScheduleQuotaReset.RLock()
+	calls = mock.calls.ScheduleQuotaReset
+	mock.lockScheduleQuotaReset.RUnlock()
+	return calls
+}
+
+// SetEmailVerification calls SetEmailVerificationFunc.
+func (mock *RepoMock) SetEmailVerification(ctx context.Context, token string, data model.EmailVerification) error {
+	if mock.SetEmailVerificationFunc == nil {
+		panic("RepoMock.SetEmailVerificationFunc: method is nil but Repo.SetEmailVerification was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Token string
+		Data  model.EmailVerification
+	}{
+		Ctx:   ctx,
+		Token: token,
+		Data:  data,
+	}
+	mock.lockSetEmailVerification.Lock()
+	mock.calls.SetEmailVerification = append(mock.calls.SetEmailVerification, callInfo)
+	mock.lockSetEmailVerification.Unlock()
+	return mock.SetEmailVerificationFunc(ctx, token, data)
+}
+
+// SetEmailVerificationCalls gets all the calls that were made to SetEmailVerification.
+// Check the length with:
+//
+//	len(mockedRepo.SetEmailVerificationCalls())
+func (mock *RepoMock) SetEmailVerificationCalls() []struct {
+	Ctx   context.Context
+	Token string
+	Data  model.EmailVerification
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token string
+		Data  model.EmailVerification
+	}
+	mock.lockSetEmailVerification.RLock()
+	calls = mock.calls.SetEmailVerification
+	mock.lockSetEmailVerification.RUnlock()
+	return calls
+}
+
+// SetLoginChallenge calls SetLoginChallengeFunc.
+func (mock *RepoMock) SetLoginChallenge(ctx context.Context, token string, userID int64) error {
+	if mock.SetLoginChallengeFunc == nil {
+		panic("RepoMock.SetLoginChallengeFunc: method is nil but Repo.SetLoginChallenge was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Token  string
+		UserID int64
+	}{
+		Ctx:    ctx,
+		Token:  token,
+		UserID: userID,
+	}
+	mock.lockSetLoginChallenge.Lock()
+	mock.calls.SetLoginChallenge = append(mock.calls.SetLoginChallenge, callInfo)
+	mock.lockSetLoginChallenge.Unlock()
+	return mock.SetLoginChallengeFunc(ctx, token, userID)
+}
+
+// SetLoginChallengeCalls gets all the calls that were made to SetLoginChallenge.
+// Check the length with:
+//
+//	len(mockedRepo.SetLoginChallengeCalls())
+func (mock *RepoMock) SetLoginChallengeCalls() []struct {
+	Ctx    context.Context
+	Token  string
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Token  string
+		UserID int64
+	}
+	mock.lockSetLoginChallenge.RLock()
+	calls = mock.calls.SetLoginChallenge
+	mock.lockSetLoginChallenge.RUnlock()
+	return calls
+}
+
+// SetLoginLock calls SetLoginLockFunc.
+func (mock *RepoMock) SetLoginLock(ctx context.Context, key string, duration time.Duration) error {
+	if mock.SetLoginLockFunc == nil {
+		panic("RepoMock.SetLoginLockFunc: method is nil but Repo.SetLoginLock was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Key      string
+		Duration time.Duration
+	}{
+		Ctx:      ctx,
+		Key:      key,
+		Duration: duration,
+	}
+	mock.lockSetLoginLock.Lock()
+	mock.calls.SetLoginLock = append(mock.calls.SetLoginLock, callInfo)
+	mock.lockSetLoginLock.Unlock()
+	return mock.SetLoginLockFunc(ctx, key, duration)
+}
+
+// SetLoginLockCalls gets all the calls that were made to SetLoginLock.
+// Check the length with:
+//
+//	len(mockedRepo.SetLoginLockCalls())
+func (mock *RepoMock) SetLoginLockCalls() []struct {
+	Ctx      context.Context
+	Key      string
+	Duration time.Duration
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Key      string
+		Duration time.Duration
+	}
+	mock.lockSetLoginLock.RLock()
+	calls = mock.calls.SetLoginLock
+	mock.lockSetLoginLock.RUnlock()
+	return calls
+}
+
+// SetOAuthState calls SetOAuthStateFunc.
+func (mock *RepoMock) SetOAuthState(ctx context.Context, state string, data model.OAuthState) error {
+	if mock.SetOAuthStateFunc == nil {
+		panic("RepoMock.SetOAuthStateFunc: method is nil but Repo.SetOAuthState was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		State string
+		Data  model.OAuthState
+	}{
+		Ctx:   ctx,
+		State: state,
+		Data:  data,
+	}
+	mock.lockSetOAuthState.Lock()
+	mock.calls.SetOAuthState = append(mock.calls.SetOAuthState, callInfo)
+	mock.lockSetOAuthState.Unlock()
+	return mock.SetOAuthStateFunc(ctx, state, data)
+}
+
+// SetOAuthStateCalls gets all the calls that were made to SetOAuthState.
+// Check the length with:
+//
+//	len(mockedRepo.SetOAuthStateCalls())
+func (mock *RepoMock) SetOAuthStateCalls() []struct {
+	Ctx   context.Context
+	State string
+	Data  model.OAuthState
+} {
+	var calls []struct {
+		Ctx   context.Context
+		State string
+		Data  model.OAuthState
+	}
+	mock.lockSetOAuthState.RLock()
+	calls = mock.calls.SetOAuthState
+	mock.lockSetOAuthState.RUnlock()
 	return calls
 }
 
@@ -186,3 +1931,75 @@ func (mock *RepoMock) SetRelatedUserCacheCalls() []struct {
 	mock.lockSetRelatedUserCache.RUnlock()
 	return calls
 }
+
+// SetSession calls SetSessionFunc.
+func (mock *RepoMock) SetSession(ctx context.Context, token string, userID int64) error {
+	if mock.SetSessionFunc == nil {
+		panic("RepoMock.SetSessionFunc: method is nil but Repo.SetSession was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Token  string
+		UserID int64
+	}{
+		Ctx:    ctx,
+		Token:  token,
+		UserID: userID,
+	}
+	mock.lockSetSession.Lock()
+	mock.calls.SetSession = append(mock.calls.SetSession, callInfo)
+	mock.lockSetSession.Unlock()
+	return mock.SetSessionFunc(ctx, token, userID)
+}
+
+// SetSessionCalls gets all the calls that were made to SetSession.
+// Check the length with:
+//
+//	len(mockedRepo.SetSessionCalls())
+func (mock *RepoMock) SetSessionCalls() []struct {
+	Ctx    context.Context
+	Token  string
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Token  string
+		UserID int64
+	}
+	mock.lockSetSession.RLock()
+	calls = mock.calls.SetSession
+	mock.lockSetSession.RUnlock()
+	return calls
+}
+
+// SubscribeEvents calls SubscribeEventsFunc.
+func (mock *RepoMock) SubscribeEvents(ctx context.Context) <-chan model.Event {
+	if mock.SubscribeEventsFunc == nil {
+		panic("RepoMock.SubscribeEventsFunc: method is nil but Repo.SubscribeEvents was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockSubscribeEvents.Lock()
+	mock.calls.SubscribeEvents = append(mock.calls.SubscribeEvents, callInfo)
+	mock.lockSubscribeEvents.Unlock()
+	return mock.SubscribeEventsFunc(ctx)
+}
+
+// SubscribeEventsCalls gets all the calls that were made to SubscribeEvents.
+// Check the length with:
+//
+//	len(mockedRepo.SubscribeEventsCalls())
+func (mock *RepoMock) SubscribeEventsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockSubscribeEvents.RLock()
+	calls = mock.calls.SubscribeEvents
+	mock.lockSubscribeEvents.RUnlock()
+	return calls
+}
@@ -6,9 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/egnptr/dating-app/model"
+	"github.com/redis/go-redis/v9"
 )
 
 func (cache *RedisCache) GetRelatedUserCacheLen(ctx context.Context, userID int64) (len int64, err error) {
@@ -24,6 +26,8 @@ func (cache *RedisCache) GetRelatedUserCacheLen(ctx context.Context, userID int6
 	return
 }
 
+// GetRelatedUserCache returns the users swiped on by the user, each with the
+// most recent swipe on them
 func (cache *RedisCache) GetRelatedUserCache(ctx context.Context, userID int64) (userRelationMap map[int64]int, err error) {
 	userRelationMap = make(map[int64]int)
 	key := fmt.Sprintf("related_user:%d", userID)
@@ -43,7 +47,11 @@ func (cache *RedisCache) GetRelatedUserCache(ctx context.Context, userID int64)
 			return
 		}
 
-		userRelationMap[data.UserID] = data.SwipeStatus
+		// Swipes are pushed to the front of the list, so the first one is
+		// the most recent
+		if _, ok := userRelationMap[data.UserID]; !ok {
+			userRelationMap[data.UserID] = data.SwipeStatus
+		}
 	}
 
 	return
@@ -72,3 +80,590 @@ func (cache *RedisCache) SetRelatedUserCache(ctx context.Context, userID int64,
 
 	return
 }
+
+// GetLastRelatedUserCache returns the most recent swipe of the user
+func (cache *RedisCache) GetLastRelatedUserCache(ctx context.Context, userID int64) (data model.UserRelation, err error) {
+	key := fmt.Sprintf("related_user:%d", userID)
+
+	cacheData, err := cache.Client.LIndex(ctx, key, 0).Bytes()
+	if err == redis.Nil {
+		err = model.NotFoundErr
+		return
+	} else if err != nil {
+		err = errors.New("error fetching cached data")
+		log.Println(err.Error())
+		return
+	}
+
+	err = json.Unmarshal(cacheData, &data)
+	if err != nil {
+		log.Println("error unmarshal json")
+		return
+	}
+
+	return
+}
+
+// RemoveRelatedUserCache removes the most recent occurrence of the swipe
+func (cache *RedisCache) RemoveRelatedUserCache(ctx context.Context, userID int64, data model.UserRelation) (err error) {
+	key := fmt.Sprintf("related_user:%d", userID)
+
+	valueJson, err := json.Marshal(&data)
+	if err != nil {
+		log.Println("error marshal json")
+		return
+	}
+
+	err = cache.Client.LRem(ctx, key, 1, valueJson).Err()
+	if err != nil {
+		log.Println("error delete cache: ", key)
+		return
+	}
+
+	return
+}
+
+// RemoveRelatedUserLike removes both likes and super likes of the user
+func (cache *RedisCache) RemoveRelatedUserLike(ctx context.Context, userID, likedUserID int64) (err error) {
+	key := fmt.Sprintf("related_user:%d", userID)
+
+	for _, status := range []int{model.SwipeStatusLike, model.SwipeStatusSuperLike} {
+		valueJson, errMarshal := json.Marshal(&model.UserRelation{
+			UserID:      likedUserID,
+			SwipeStatus: status,
+		})
+		if errMarshal != nil {
+			err = errMarshal
+			log.Println("error marshal json")
+			return
+		}
+
+		err = cache.Client.LRem(ctx, key, 0, valueJson).Err()
+		if err != nil {
+			log.Println("error delete cache: ", key)
+			return
+		}
+	}
+
+	return
+}
+
+func (cache *RedisCache) GetSuperLikeCount(ctx context.Context, userID int64) (count int64, err error) {
+	key := fmt.Sprintf("super_likes:%d", userID)
+
+	count, err = cache.Client.Get(ctx, key).Int64()
+	if err == redis.Nil {
+		err = nil
+		return
+	} else if err != nil {
+		err = errors.New("error fetching cached data")
+		log.Println(err.Error())
+		return
+	}
+
+	return
+}
+
+// IncrSuperLikeCount counts a super like, the allowance is renewed a day
+// after the first super like of the window
+func (cache *RedisCache) IncrSuperLikeCount(ctx context.Context, userID int64) (count int64, err error) {
+	key := fmt.Sprintf("super_likes:%d", userID)
+
+	count, err = cache.Client.Incr(ctx, key).Result()
+	if err != nil {
+		log.Println("error set cache: ", key)
+		return
+	}
+
+	if count == 1 {
+		err = cache.Client.Expire(ctx, key, 24*time.Hour).Err()
+		if err != nil {
+			log.Println("error set cache expire: ", key)
+			return
+		}
+	}
+
+	return
+}
+
+// Decrements the count only while it is kept and above zero, so the window
+// of the allowance is neither restarted nor left without an expiry
+const decrCountScript = `
+if tonumber(redis.call("GET", KEYS[1]) or "0") > 0 then
+	return redis.call("DECR", KEYS[1])
+end
+return 0
+`
+
+// DecrSuperLikeCount gives back a super like that was taken back
+func (cache *RedisCache) DecrSuperLikeCount(ctx context.Context, userID int64) (count int64, err error) {
+	key := fmt.Sprintf("super_likes:%d", userID)
+
+	count, err = cache.Client.Eval(ctx, decrCountScript, []string{key}).Int64()
+	if err != nil {
+		log.Println("error set cache: ", key)
+		return
+	}
+
+	return
+}
+
+func (cache *RedisCache) GetRewindCount(ctx context.Context, userID int64) (count int64, err error) {
+	key := fmt.Sprintf("rewinds:%d", userID)
+
+	count, err = cache.Client.Get(ctx, key).Int64()
+	if err == redis.Nil {
+		err = nil
+		return
+	} else if err != nil {
+		err = errors.New("error fetching cached data")
+		log.Println(err.Error())
+		return
+	}
+
+	return
+}
+
+// IncrRewindCount counts an undone swipe, the allowance is renewed a day
+// after the first rewind of the window
+func (cache *RedisCache) IncrRewindCount(ctx context.Context, userID int64) (count int64, err error) {
+	key := fmt.Sprintf("rewinds:%d", userID)
+
+	count, err = cache.Client.Incr(ctx, key).Result()
+	if err != nil {
+		log.Println("error set cache: ", key)
+		return
+	}
+
+	if count == 1 {
+		err = cache.Client.Expire(ctx, key, 24*time.Hour).Err()
+		if err != nil {
+			log.Println("error set cache expire: ", key)
+			return
+		}
+	}
+
+	return
+}
+
+func (cache *RedisCache) SetSession(ctx context.Context, token string, userID int64) (err error) {
+	key := fmt.Sprintf("session:%s", token)
+
+	err = cache.Client.Set(ctx, key, userID, 24*time.Hour).Err()
+	if err != nil {
+		log.Println("error set cache: ", key)
+		return
+	}
+
+	// Sessions of a user are tracked so they can all be revoked at once, the
+	// set lives as long as the newest session in it
+	key = fmt.Sprintf("user_sessions:%d", userID)
+
+	err = cache.Client.SAdd(ctx, key, token).Err()
+	if err != nil {
+		log.Println("error set cache: ", key)
+		return
+	}
+
+	err = cache.Client.Expire(ctx, key, 24*time.Hour).Err()
+	if err != nil {
+		log.Println("error set cache: ", key)
+		return
+	}
+
+	return
+}
+
+// DeleteSessions logs the user out everywhere
+func (cache *RedisCache) DeleteSessions(ctx context.Context, userID int64) (err error) {
+	key := fmt.Sprintf("user_sessions:%d", userID)
+
+	tokens, err := cache.Client.SMembers(ctx, key).Result()
+	if err != nil {
+		err = errors.New("error fetching cached data")
+		log.Println(err.Error())
+		return
+	}
+
+	keys := []string{key}
+	for _, token := range tokens {
+		keys = append(keys, fmt.Sprintf("session:%s", token))
+	}
+
+	err = cache.Client.Del(ctx, keys...).Err()
+	if err != nil {
+		log.Println("error delete cache: ", key)
+		return
+	}
+
+	return
+}
+
+func (cache *RedisCache) GetSession(ctx context.Context, token string) (userID int64, err error) {
+	key := fmt.Sprintf("session:%s", token)
+
+	userID, err = cache.Client.Get(ctx, key).Int64()
+	if err == redis.Nil {
+		err = model.UnauthorizedErr
+		return
+	} else if err != nil {
+		err = errors.New("error fetching cached data")
+		log.Println(err.Error())
+		return
+	}
+
+	return
+}
+
+func (cache *RedisCache) SetLoginChallenge(ctx context.Context, token string, userID int64) (err error) {
+	key := fmt.Sprintf("login_challenge:%s", token)
+
+	err = cache.Client.Set(ctx, key, userID, 5*time.Minute).Err()
+	if err != nil {
+		log.Println("error set cache: ", key)
+		return
+	}
+
+	return
+}
+
+func (cache *RedisCache) GetLoginChallenge(ctx context.Context, token string) (userID int64, err error) {
+	key := fmt.Sprintf("login_challenge:%s", token)
+
+	userID, err = cache.Client.Get(ctx, key).Int64()
+	if err == redis.Nil {
+		err = model.InvalidTokenErr
+		return
+	} else if err != nil {
+		err = errors.New("error fetching cached data")
+		log.Println(err.Error())
+		return
+	}
+
+	return
+}
+
+func (cache *RedisCache) DeleteLoginChallenge(ctx context.Context, token string) (err error) {
+	key := fmt.Sprintf("login_challenge:%s", token)
+
+	err = cache.Client.Del(ctx, key).Err()
+	if err != nil {
+		log.Println("error delete cache: ", key)
+		return
+	}
+
+	return
+}
+
+func (cache *RedisCache) GetLoginLock(ctx context.Context, key string) (retryAfter time.Duration, err error) {
+	key = fmt.Sprintf("login_lock:%s", key)
+
+	retryAfter, err = cache.Client.TTL(ctx, key).Result()
+	if err != nil {
+		err = errors.New("error fetching cached data")
+		log.Println(err.Error())
+		return
+	}
+
+	// TTL is negative when the key does not exist or has no expiry
+	if retryAfter < 0 {
+		retryAfter = 0
+	}
+
+	return
+}
+
+func (cache *RedisCache) SetLoginLock(ctx context.Context, key string, duration time.Duration) (err error) {
+	key = fmt.Sprintf("login_lock:%s", key)
+
+	err = cache.Client.Set(ctx, key, 1, duration).Err()
+	if err != nil {
+		log.Println("error set cache: ", key)
+		return
+	}
+
+	return
+}
+
+func (cache *RedisCache) IncrLoginFailure(ctx context.Context, key string) (count int64, err error) {
+	key = fmt.Sprintf("login_failure:%s", key)
+
+	count, err = cache.Client.Incr(ctx, key).Result()
+	if err != nil {
+		log.Println("error set cache: ", key)
+		return
+	}
+
+	err = cache.Client.Expire(ctx, key, 24*time.Hour).Err()
+	if err != nil {
+		log.Println("error set cache expire: ", key)
+		return
+	}
+
+	return
+}
+
+func (cache *RedisCache) ResetLoginFailure(ctx context.Context, key string) (err error) {
+	err = cache.Client.Del(ctx, fmt.Sprintf("login_failure:%s", key), fmt.Sprintf("login_lock:%s", key)).Err()
+	if err != nil {
+		log.Println("error delete cache: ", key)
+		return
+	}
+
+	return
+}
+
+func (cache *RedisCache) SetOAuthState(ctx context.Context, state string, data model.OAuthState) (err error) {
+	key := fmt.Sprintf("oauth_state:%s", state)
+
+	valueJson, err := json.Marshal(&data)
+	if err != nil {
+		log.Println("error marshal json")
+		return
+	}
+
+	err = cache.Client.Set(ctx, key, valueJson, 10*time.Minute).Err()
+	if err != nil {
+		log.Println("error set cache: ", key)
+		return
+	}
+
+	return
+}
+
+// PopOAuthState fetches and deletes the state at once so every authorization
+// response can only be redeemed a single time
+func (cache *RedisCache) PopOAuthState(ctx context.Context, state string) (data model.OAuthState, err error) {
+	key := fmt.Sprintf("oauth_state:%s", state)
+
+	cacheData, err := cache.Client.GetDel(ctx, key).Bytes()
+	if err == redis.Nil {
+		err = model.InvalidTokenErr
+		return
+	} else if err != nil {
+		err = errors.New("error fetching cached data")
+		log.Println(err.Error())
+		return
+	}
+
+	err = json.Unmarshal(cacheData, &data)
+	if err != nil {
+		log.Println("error unmarshal json")
+		return
+	}
+
+	return
+}
+
+func (cache *RedisCache) SetEmailVerification(ctx context.Context, token string, data model.EmailVerification) (err error) {
+	key := fmt.Sprintf("email_verification:%s", token)
+
+	valueJson, err := json.Marshal(&data)
+	if err != nil {
+		log.Println("error marshal json")
+		return
+	}
+
+	err = cache.Client.Set(ctx, key, valueJson, 48*time.Hour).Err()
+	if err != nil {
+		log.Println("error set cache: ", key)
+		return
+	}
+
+	return
+}
+
+func (cache *RedisCache) GetEmailVerification(ctx context.Context, token string) (data model.EmailVerification, err error) {
+	key := fmt.Sprintf("email_verification:%s", token)
+
+	cacheData, err := cache.Client.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		err = model.InvalidTokenErr
+		return
+	} else if err != nil {
+		err = errors.New("error fetching cached data")
+		log.Println(err.Error())
+		return
+	}
+
+	err = json.Unmarshal(cacheData, &data)
+	if err != nil {
+		log.Println("error unmarshal json")
+		return
+	}
+
+	return
+}
+
+func (cache *RedisCache) DeleteEmailVerification(ctx context.Context, token string) (err error) {
+	key := fmt.Sprintf("email_verification:%s", token)
+
+	err = cache.Client.Del(ctx, key).Err()
+	if err != nil {
+		log.Println("error delete cache: ", key)
+		return
+	}
+
+	return
+}
+
+// MarkProfileViewed reports whether this is the first view of the profile by
+// the viewer on the day of the view
+func (cache *RedisCache) MarkProfileViewed(ctx context.Context, view model.ProfileView) (first bool, err error) {
+	key := fmt.Sprintf("profile_view:%d:%d:%s", view.ViewerID, view.ViewedID, view.ViewedAt.UTC().Format("2006-01-02"))
+
+	first, err = cache.Client.SetNX(ctx, key, 1, 48*time.Hour).Result()
+	if err != nil {
+		log.Println("error set cache: ", key)
+		return
+	}
+
+	return
+}
+
+// PushProfileViews buffers views until they are flushed to the db
+func (cache *RedisCache) PushProfileViews(ctx context.Context, views []model.ProfileView) (err error) {
+	key := "profile_views"
+
+	values := make([]interface{}, 0, len(views))
+	for _, view := range views {
+		valueJson, errMarshal := json.Marshal(&view)
+		if errMarshal != nil {
+			err = errMarshal
+			log.Println("error marshal json")
+			return
+		}
+		values = append(values, valueJson)
+	}
+
+	err = cache.Client.RPush(ctx, key, values...).Err()
+	if err != nil {
+		log.Println("error set cache: ", key)
+		return
+	}
+
+	return
+}
+
+// PopProfileViews takes up to count buffered views, oldest first
+func (cache *RedisCache) PopProfileViews(ctx context.Context, count int) (views []model.ProfileView, err error) {
+	key := "profile_views"
+
+	cacheData, err := cache.Client.LPopCount(ctx, key, count).Result()
+	if err == redis.Nil {
+		err = nil
+		return
+	} else if err != nil {
+		err = errors.New("error fetching cached data")
+		log.Println(err.Error())
+		return
+	}
+
+	for _, str := range cacheData {
+		// Skip a malformed view rather than losing the rest of the batch
+		var view model.ProfileView
+		if errUnmarshal := json.Unmarshal([]byte(str), &view); errUnmarshal != nil {
+			log.Println("error unmarshal json")
+			continue
+		}
+
+		views = append(views, view)
+	}
+
+	return
+}
+
+// AddBoostedUser puts the user of the boost in the boosted set until the
+// boost expires, dropping the boosts that expired before it started
+func (cache *RedisCache) AddBoostedUser(ctx context.Context, boost model.Boost) (err error) {
+	key := "boosted_users"
+
+	_, err = cache.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(boost.ActivatedAt.Unix(), 10))
+		pipe.ZAdd(ctx, key, redis.Z{
+			Score:  float64(boost.ExpiresAt.Unix()),
+			Member: boost.UserID,
+		})
+		return nil
+	})
+	if err != nil {
+		log.Println("error set cache: ", key)
+		return
+	}
+
+	return
+}
+
+// GetBoostedUsers returns the users whose boost is running at the time
+func (cache *RedisCache) GetBoostedUsers(ctx context.Context, at time.Time) (boosted map[int64]bool, err error) {
+	key := "boosted_users"
+
+	members, err := cache.Client.ZRangeByScore(ctx, key, &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(at.Unix(), 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		err = errors.New("error fetching cached data")
+		log.Println(err.Error())
+		return
+	}
+
+	boosted = make(map[int64]bool, len(members))
+	for _, member := range members {
+		userID, errParse := strconv.ParseInt(member, 10, 64)
+		if errParse != nil {
+			log.Println("error parse boosted user member: ", member)
+			continue
+		}
+		boosted[userID] = true
+	}
+
+	return
+}
+
+// Takes the lock for the owner, or extends it when the owner holds it already
+const acquireLockScript = `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return 1
+end
+return 0
+`
+
+// Deletes the lock only when the owner holds it, so a lock that expired and
+// was taken by someone else is left alone
+const releaseLockScript = `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`
+
+// AcquireLock takes the lock for the owner until the ttl passes, or extends it
+// when the owner holds it already. It reports whether the owner holds the lock
+func (cache *RedisCache) AcquireLock(ctx context.Context, name, owner string, ttl time.Duration) (acquired bool, err error) {
+	key := fmt.Sprintf("lock:%s", name)
+
+	res, err := cache.Client.Eval(ctx, acquireLockScript, []string{key}, owner, ttl.Milliseconds()).Int64()
+	if err != nil {
+		log.Println("error set cache: ", key)
+		return
+	}
+
+	return res == 1, nil
+}
+
+// ReleaseLock lets go of the lock when the owner holds it
+func (cache *RedisCache) ReleaseLock(ctx context.Context, name, owner string) (err error) {
+	key := fmt.Sprintf("lock:%s", name)
+
+	err = cache.Client.Eval(ctx, releaseLockScript, []string{key}, owner).Err()
+	if err != nil {
+		log.Println("error delete cache: ", key)
+		return
+	}
+
+	return
+}
@@ -109,6 +109,25 @@ func TestGetRelatedUserCache(t *testing.T) {
 				4: 1,
 			},
 		},
+		{
+			name: "case success swiped twice",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectLRange(key, 0, -1).SetVal([]string{
+						`{"id": 2, "swipe_status": -1}`,
+						`{"id": 2, "swipe_status": 1}`,
+					})
+					return client
+				}(),
+			},
+			args: args{
+				id: 1,
+			},
+			wantRes: map[int64]int{
+				2: -1,
+			},
+		},
 		{
 			name: "case error",
 			fields: fields{
@@ -220,3 +239,1123 @@ func TestSetRelatedUserCache(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLastRelatedUserCache(t *testing.T) {
+	valueJson, _ := json.Marshal(&model.UserRelation{
+		UserID:      2,
+		SwipeStatus: model.SwipeStatusPass,
+	})
+
+	tests := []struct {
+		name    string
+		mock    func(mock redismock.ClientMock)
+		wantRes model.UserRelation
+		wantErr error
+	}{
+		{
+			name: "case success",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectLIndex(key, 0).SetVal(string(valueJson))
+			},
+			wantRes: model.UserRelation{UserID: 2, SwipeStatus: model.SwipeStatusPass},
+		},
+		{
+			name: "case error no swipes",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectLIndex(key, 0).RedisNil()
+			},
+			wantErr: model.NotFoundErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := redismock.NewClientMock()
+			tt.mock(mock)
+			r := &RedisCache{
+				Client: client,
+			}
+			gotRes, gotErr := r.GetLastRelatedUserCache(context.Background(), 1)
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Equal(t, tt.wantRes, gotRes)
+		})
+	}
+}
+
+func TestRemoveRelatedUserCache(t *testing.T) {
+	valueJson, _ := json.Marshal(&model.UserRelation{
+		UserID:      2,
+		SwipeStatus: model.SwipeStatusPass,
+	})
+
+	client, mock := redismock.NewClientMock()
+	mock.ExpectLRem(key, 1, valueJson).SetVal(1)
+	r := &RedisCache{
+		Client: client,
+	}
+
+	err := r.RemoveRelatedUserCache(context.Background(), 1, model.UserRelation{UserID: 2, SwipeStatus: model.SwipeStatusPass})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRemoveRelatedUserLike(t *testing.T) {
+	valueJson, _ := json.Marshal(&model.UserRelation{
+		UserID:      2,
+		SwipeStatus: model.SwipeStatusLike,
+	})
+	superValueJson, _ := json.Marshal(&model.UserRelation{
+		UserID:      2,
+		SwipeStatus: model.SwipeStatusSuperLike,
+	})
+
+	type fields struct {
+		redisClient *redis.Client
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectLRem(key, 0, valueJson).SetVal(1)
+					mock.ExpectLRem(key, 0, superValueJson).SetVal(0)
+					return client
+				}(),
+			},
+		},
+		{
+			name: "case error LRem",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectLRem(key, 0, valueJson).SetErr(errors.New("err"))
+					return client
+				}(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RedisCache{
+				Client: tt.fields.redisClient,
+			}
+			gotErr := r.RemoveRelatedUserLike(context.Background(), 1, 2)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("RemoveRelatedUserLike() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestGetSuperLikeCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(mock redismock.ClientMock)
+		wantRes int64
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectGet("super_likes:1").SetVal("2")
+			},
+			wantRes: 2,
+		},
+		{
+			name: "case success none",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectGet("super_likes:1").RedisNil()
+			},
+		},
+		{
+			name: "case error",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectGet("super_likes:1").SetErr(errors.New("err"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := redismock.NewClientMock()
+			tt.mock(mock)
+			r := &RedisCache{
+				Client: client,
+			}
+			gotRes, gotErr := r.GetSuperLikeCount(context.Background(), 1)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("GetSuperLikeCount() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantRes, gotRes)
+		})
+	}
+}
+
+func TestIncrSuperLikeCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(mock redismock.ClientMock)
+		wantRes int64
+		wantErr bool
+	}{
+		{
+			name: "case success first",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectIncr("super_likes:1").SetVal(1)
+				mock.ExpectExpire("super_likes:1", 24*time.Hour).SetVal(true)
+			},
+			wantRes: 1,
+		},
+		{
+			name: "case success window kept",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectIncr("super_likes:1").SetVal(3)
+			},
+			wantRes: 3,
+		},
+		{
+			name: "case error",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectIncr("super_likes:1").SetErr(errors.New("err"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := redismock.NewClientMock()
+			tt.mock(mock)
+			r := &RedisCache{
+				Client: client,
+			}
+			gotRes, gotErr := r.IncrSuperLikeCount(context.Background(), 1)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("IncrSuperLikeCount() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantRes, gotRes)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDecrSuperLikeCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(mock redismock.ClientMock)
+		wantRes int64
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectEval(decrCountScript, []string{"super_likes:1"}).SetVal(int64(1))
+			},
+			wantRes: 1,
+		},
+		{
+			name: "case error",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectEval(decrCountScript, []string{"super_likes:1"}).SetErr(errors.New("err"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := redismock.NewClientMock()
+			tt.mock(mock)
+			r := &RedisCache{
+				Client: client,
+			}
+			gotRes, gotErr := r.DecrSuperLikeCount(context.Background(), 1)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("DecrSuperLikeCount() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantRes, gotRes)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetRewindCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(mock redismock.ClientMock)
+		wantRes int64
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectGet("rewinds:1").SetVal("2")
+			},
+			wantRes: 2,
+		},
+		{
+			name: "case success none",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectGet("rewinds:1").RedisNil()
+			},
+		},
+		{
+			name: "case error",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectGet("rewinds:1").SetErr(errors.New("err"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := redismock.NewClientMock()
+			tt.mock(mock)
+			r := &RedisCache{
+				Client: client,
+			}
+			gotRes, gotErr := r.GetRewindCount(context.Background(), 1)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("GetRewindCount() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantRes, gotRes)
+		})
+	}
+}
+
+func TestIncrRewindCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(mock redismock.ClientMock)
+		wantRes int64
+		wantErr bool
+	}{
+		{
+			name: "case success first",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectIncr("rewinds:1").SetVal(1)
+				mock.ExpectExpire("rewinds:1", 24*time.Hour).SetVal(true)
+			},
+			wantRes: 1,
+		},
+		{
+			name: "case success window kept",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectIncr("rewinds:1").SetVal(3)
+			},
+			wantRes: 3,
+		},
+		{
+			name: "case error",
+			mock: func(mock redismock.ClientMock) {
+				mock.ExpectIncr("rewinds:1").SetErr(errors.New("err"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, mock := redismock.NewClientMock()
+			tt.mock(mock)
+			r := &RedisCache{
+				Client: client,
+			}
+			gotRes, gotErr := r.IncrRewindCount(context.Background(), 1)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("IncrRewindCount() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantRes, gotRes)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSetSession(t *testing.T) {
+	type fields struct {
+		redisClient *redis.Client
+	}
+	type args struct {
+		token  string
+		userID int64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectSet("session:token", int64(1), 24*time.Hour).SetVal("OK")
+					mock.ExpectSAdd("user_sessions:1", "token").SetVal(1)
+					mock.ExpectExpire("user_sessions:1", 24*time.Hour).SetVal(true)
+					return client
+				}(),
+			},
+			args: args{
+				token:  "token",
+				userID: 1,
+			},
+		},
+		{
+			name: "case error SAdd",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectSet("session:token", int64(1), 24*time.Hour).SetVal("OK")
+					mock.ExpectSAdd("user_sessions:1", "token").SetErr(errors.New("err"))
+					return client
+				}(),
+			},
+			args: args{
+				token:  "token",
+				userID: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectSet("session:token", int64(1), 24*time.Hour).SetErr(errors.New("err"))
+					return client
+				}(),
+			},
+			args: args{
+				token:  "token",
+				userID: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RedisCache{
+				Client: tt.fields.redisClient,
+			}
+			gotErr := r.SetSession(context.Background(), tt.args.token, tt.args.userID)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("SetSession() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestDeleteSessions(t *testing.T) {
+	type fields struct {
+		redisClient *redis.Client
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectSMembers("user_sessions:1").SetVal([]string{"a", "b"})
+					mock.ExpectDel("user_sessions:1", "session:a", "session:b").SetVal(3)
+					return client
+				}(),
+			},
+		},
+		{
+			name: "case success no sessions",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectSMembers("user_sessions:1").SetVal([]string{})
+					mock.ExpectDel("user_sessions:1").SetVal(0)
+					return client
+				}(),
+			},
+		},
+		{
+			name: "case error SMembers",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectSMembers("user_sessions:1").SetErr(errors.New("err"))
+					return client
+				}(),
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error Del",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectSMembers("user_sessions:1").SetVal([]string{"a"})
+					mock.ExpectDel("user_sessions:1", "session:a").SetErr(errors.New("err"))
+					return client
+				}(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RedisCache{
+				Client: tt.fields.redisClient,
+			}
+			gotErr := r.DeleteSessions(context.Background(), 1)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("DeleteSessions() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestGetSession(t *testing.T) {
+	type fields struct {
+		redisClient *redis.Client
+	}
+	type args struct {
+		token string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantRes int64
+		wantErr error
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectGet("session:token").SetVal("1")
+					return client
+				}(),
+			},
+			args: args{
+				token: "token",
+			},
+			wantRes: 1,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectGet("session:token").RedisNil()
+					return client
+				}(),
+			},
+			args: args{
+				token: "token",
+			},
+			wantErr: model.UnauthorizedErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RedisCache{
+				Client: tt.fields.redisClient,
+			}
+			gotRes, gotErr := r.GetSession(context.Background(), tt.args.token)
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Equal(t, tt.wantRes, gotRes)
+		})
+	}
+}
+
+func TestGetLoginChallenge(t *testing.T) {
+	type fields struct {
+		redisClient *redis.Client
+	}
+	type args struct {
+		token string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantRes int64
+		wantErr error
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectGet("login_challenge:token").SetVal("1")
+					return client
+				}(),
+			},
+			args: args{
+				token: "token",
+			},
+			wantRes: 1,
+		},
+		{
+			name: "case error expired",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectGet("login_challenge:token").RedisNil()
+					return client
+				}(),
+			},
+			args: args{
+				token: "token",
+			},
+			wantErr: model.InvalidTokenErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RedisCache{
+				Client: tt.fields.redisClient,
+			}
+			gotRes, gotErr := r.GetLoginChallenge(context.Background(), tt.args.token)
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Equal(t, tt.wantRes, gotRes)
+		})
+	}
+}
+
+func TestGetLoginLock(t *testing.T) {
+	type fields struct {
+		redisClient *redis.Client
+	}
+	type args struct {
+		key string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantRes time.Duration
+		wantErr bool
+	}{
+		{
+			name: "case success locked",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectTTL("login_lock:user:test").SetVal(time.Minute)
+					return client
+				}(),
+			},
+			args: args{
+				key: "user:test",
+			},
+			wantRes: time.Minute,
+		},
+		{
+			name: "case success not locked",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectTTL("login_lock:user:test").SetVal(-2)
+					return client
+				}(),
+			},
+			args: args{
+				key: "user:test",
+			},
+		},
+		{
+			name: "case error",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectTTL("login_lock:user:test").SetErr(errors.New("err"))
+					return client
+				}(),
+			},
+			args: args{
+				key: "user:test",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RedisCache{
+				Client: tt.fields.redisClient,
+			}
+			gotRes, gotErr := r.GetLoginLock(context.Background(), tt.args.key)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("GetLoginLock() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantRes, gotRes)
+		})
+	}
+}
+
+func TestIncrLoginFailure(t *testing.T) {
+	type fields struct {
+		redisClient *redis.Client
+	}
+	type args struct {
+		key string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantRes int64
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectIncr("login_failure:user:test").SetVal(3)
+					mock.ExpectExpire("login_failure:user:test", 24*time.Hour).SetVal(true)
+					return client
+				}(),
+			},
+			args: args{
+				key: "user:test",
+			},
+			wantRes: 3,
+		},
+		{
+			name: "case error",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectIncr("login_failure:user:test").SetErr(errors.New("err"))
+					return client
+				}(),
+			},
+			args: args{
+				key: "user:test",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RedisCache{
+				Client: tt.fields.redisClient,
+			}
+			gotRes, gotErr := r.IncrLoginFailure(context.Background(), tt.args.key)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("IncrLoginFailure() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantRes, gotRes)
+		})
+	}
+}
+
+func TestGetEmailVerification(t *testing.T) {
+	type fields struct {
+		redisClient *redis.Client
+	}
+	type args struct {
+		token string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantRes model.EmailVerification
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectGet("email_verification:token").SetVal(`{"user_id": 1, "email": "test@mail.com"}`)
+					return client
+				}(),
+			},
+			args: args{
+				token: "token",
+			},
+			wantRes: model.EmailVerification{
+				UserID: 1,
+				Email:  "test@mail.com",
+			},
+		},
+		{
+			name: "case error",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectGet("email_verification:token").SetErr(errors.New("err"))
+					return client
+				}(),
+			},
+			args: args{
+				token: "token",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RedisCache{
+				Client: tt.fields.redisClient,
+			}
+			gotRes, gotErr := r.GetEmailVerification(context.Background(), tt.args.token)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("GetEmailVerification() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantRes, gotRes)
+		})
+	}
+}
+
+func TestMarkProfileViewed(t *testing.T) {
+	view := model.ProfileView{
+		ViewerID: 1,
+		ViewedID: 2,
+		ViewedAt: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	type fields struct {
+		redisClient *redis.Client
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantRes bool
+		wantErr bool
+	}{
+		{
+			name: "case success first view",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectSetNX("profile_view:1:2:2024-01-01", 1, 48*time.Hour).SetVal(true)
+					return client
+				}(),
+			},
+			wantRes: true,
+		},
+		{
+			name: "case success repeated view",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectSetNX("profile_view:1:2:2024-01-01", 1, 48*time.Hour).SetVal(false)
+					return client
+				}(),
+			},
+		},
+		{
+			name: "case error",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectSetNX("profile_view:1:2:2024-01-01", 1, 48*time.Hour).SetErr(errors.New("err"))
+					return client
+				}(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RedisCache{
+				Client: tt.fields.redisClient,
+			}
+			gotRes, gotErr := r.MarkProfileViewed(context.Background(), view)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("MarkProfileViewed() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantRes, gotRes)
+		})
+	}
+}
+
+func TestPopProfileViews(t *testing.T) {
+	view := model.ProfileView{
+		ViewerID: 1,
+		ViewedID: 2,
+		ViewedAt: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+	viewJson, _ := json.Marshal(&view)
+
+	type fields struct {
+		redisClient *redis.Client
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantRes []model.ProfileView
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectLPopCount("profile_views", 10).SetVal([]string{string(viewJson), "invalid"})
+					return client
+				}(),
+			},
+			wantRes: []model.ProfileView{view},
+		},
+		{
+			name: "case success empty",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectLPopCount("profile_views", 10).RedisNil()
+					return client
+				}(),
+			},
+		},
+		{
+			name: "case error",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectLPopCount("profile_views", 10).SetErr(errors.New("err"))
+					return client
+				}(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RedisCache{
+				Client: tt.fields.redisClient,
+			}
+			gotRes, gotErr := r.PopProfileViews(context.Background(), 10)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("PopProfileViews() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantRes, gotRes)
+		})
+	}
+}
+
+func TestAddBoostedUser(t *testing.T) {
+	boost := model.Boost{
+		UserID:      1,
+		ActivatedAt: time.Unix(1700000000, 0),
+		ExpiresAt:   time.Unix(1700001800, 0),
+	}
+
+	type fields struct {
+		redisClient *redis.Client
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectTxPipeline()
+					mock.ExpectZRemRangeByScore("boosted_users", "-inf", "1700000000").SetVal(0)
+					mock.ExpectZAdd("boosted_users", redis.Z{Score: 1700001800, Member: int64(1)}).SetVal(1)
+					mock.ExpectTxPipelineExec()
+					return client
+				}(),
+			},
+		},
+		{
+			name: "case error",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectTxPipeline()
+					mock.ExpectZRemRangeByScore("boosted_users", "-inf", "1700000000").SetErr(errors.New("err"))
+					return client
+				}(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RedisCache{
+				Client: tt.fields.redisClient,
+			}
+			gotErr := r.AddBoostedUser(context.Background(), boost)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("AddBoostedUser() error = %v, wantErr = %v", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetBoostedUsers(t *testing.T) {
+	at := time.Unix(1700000000, 0)
+
+	type fields struct {
+		redisClient *redis.Client
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantRes map[int64]bool
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectZRangeByScore("boosted_users", &redis.ZRangeBy{Min: "(1700000000", Max: "+inf"}).SetVal([]string{"1", "2", "invalid"})
+					return client
+				}(),
+			},
+			wantRes: map[int64]bool{1: true, 2: true},
+		},
+		{
+			name: "case error",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectZRangeByScore("boosted_users", &redis.ZRangeBy{Min: "(1700000000", Max: "+inf"}).SetErr(errors.New("err"))
+					return client
+				}(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RedisCache{
+				Client: tt.fields.redisClient,
+			}
+			gotRes, gotErr := r.GetBoostedUsers(context.Background(), at)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("GetBoostedUsers() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantRes, gotRes)
+		})
+	}
+}
+
+func TestAcquireLock(t *testing.T) {
+	type fields struct {
+		redisClient *redis.Client
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantRes bool
+		wantErr bool
+	}{
+		{
+			name: "case success acquired",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectEval(acquireLockScript, []string{"lock:scheduler_leader"}, "owner_1", int64(30000)).SetVal(int64(1))
+					return client
+				}(),
+			},
+			wantRes: true,
+		},
+		{
+			name: "case success held by another owner",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectEval(acquireLockScript, []string{"lock:scheduler_leader"}, "owner_1", int64(30000)).SetVal(int64(0))
+					return client
+				}(),
+			},
+		},
+		{
+			name: "case error",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectEval(acquireLockScript, []string{"lock:scheduler_leader"}, "owner_1", int64(30000)).SetErr(errors.New("err"))
+					return client
+				}(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RedisCache{
+				Client: tt.fields.redisClient,
+			}
+			gotRes, gotErr := r.AcquireLock(context.Background(), "scheduler_leader", "owner_1", 30*time.Second)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("AcquireLock() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantRes, gotRes)
+		})
+	}
+}
+
+func TestReleaseLock(t *testing.T) {
+	type fields struct {
+		redisClient *redis.Client
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectEval(releaseLockScript, []string{"lock:scheduler_leader"}, "owner_1").SetVal(int64(1))
+					return client
+				}(),
+			},
+		},
+		{
+			name: "case error",
+			fields: fields{
+				redisClient: func() *redis.Client {
+					client, mock := redismock.NewClientMock()
+					mock.ExpectEval(releaseLockScript, []string{"lock:scheduler_leader"}, "owner_1").SetErr(errors.New("err"))
+					return client
+				}(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &RedisCache{
+				Client: tt.fields.redisClient,
+			}
+			gotErr := r.ReleaseLock(context.Background(), "scheduler_leader", "owner_1")
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("ReleaseLock() error = %v, wantErr = %v", gotErr, tt.wantErr)
+			}
+		})
+	}
+}
@@ -3,7 +3,9 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"log"
+	"time"
 
 	"github.com/egnptr/dating-app/model"
 )
@@ -15,27 +17,53 @@ func (*sqliteRepo) GetUser(ctx context.Context, username string) (*model.User, e
 		return nil, err
 	}
 
+	var id int64
 	var hashedPassword string
 	var fullName string
 	var email string
 	var isPremium bool
+	var emailVerified bool
+	var role string
+	var accountStatus string
+	var statusReason string
+	var statusUntil sql.NullTime
+	var twoFactorEnabled bool
 
 	if err := db.QueryRow(getUser, username).Scan(
+		&id,
 		&hashedPassword,
 		&fullName,
 		&email,
 		&isPremium,
-	); err != nil {
+		&emailVerified,
+		&role,
+		&accountStatus,
+		&statusReason,
+		&statusUntil,
+		&twoFactorEnabled,
+	); err == sql.ErrNoRows {
+		return nil, model.NotFoundErr
+	} else if err != nil {
 		log.Println(err.Error())
 		return nil, err
 	}
 
 	user := model.User{
-		Username:  username,
-		Password:  hashedPassword,
-		FullName:  fullName,
-		Email:     email,
-		IsPremium: isPremium,
+		UserID:        id,
+		Username:      username,
+		Password:      hashedPassword,
+		FullName:      fullName,
+		Email:         email,
+		IsPremium:     isPremium,
+		EmailVerified: emailVerified,
+		Role:          role,
+		AccountStatus: accountStatus,
+		StatusReason:  statusReason,
+
+		TwoFactorEnabled: twoFactorEnabled,
+	}
+	if statusUntil.Valid {
+		user.StatusUntil = &statusUntil.Time
 	}
 
 	return &user, nil
@@ -49,25 +77,98 @@ func (*sqliteRepo) GetUserByID(ctx context.Context, userID int64) (*model.User,
 	}
 
 	var username string
+	var hashedPassword string
 	var fullName string
 	var email string
 	var isPremium bool
+	var emailVerified bool
+	var verifiedAt sql.NullTime
+	var isIncognito bool
+	var role string
+	var accountStatus string
+	var statusReason string
+	var statusUntil sql.NullTime
+	var twoFactorEnabled bool
+	var tier string
 
 	if err := db.QueryRow(getUserByID, userID).Scan(
 		&username,
+		&hashedPassword,
 		&fullName,
 		&email,
 		&isPremium,
-	); err != nil {
+		&tier,
+		&emailVerified,
+		&verifiedAt,
+		&isIncognito,
+		&role,
+		&accountStatus,
+		&statusReason,
+		&statusUntil,
+		&twoFactorEnabled,
+	); err == sql.ErrNoRows {
+		return nil, model.NotFoundErr
+	} else if err != nil {
 		log.Println(err.Error())
 		return nil, err
 	}
 
 	user := model.User{
-		Username:  username,
-		FullName:  fullName,
-		Email:     email,
-		IsPremium: isPremium,
+		UserID:        userID,
+		Username:      username,
+		Password:      hashedPassword,
+		FullName:      fullName,
+		Email:         email,
+		IsPremium:     isPremium,
+		Tier:          tier,
+		EmailVerified: emailVerified,
+		IsIncognito:   isIncognito,
+		Role:          role,
+		AccountStatus: accountStatus,
+		StatusReason:  statusReason,
+
+		TwoFactorEnabled: twoFactorEnabled,
+	}
+	if verifiedAt.Valid {
+		user.VerifiedAt = &verifiedAt.Time
+	}
+	if statusUntil.Valid {
+		user.StatusUntil = &statusUntil.Time
+	}
+
+	return &user, nil
+}
+
+func (*sqliteRepo) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	var id int64
+	var username string
+	var fullName string
+	var emailVerified bool
+
+	if err := db.QueryRow(getUserByEmail, email).Scan(
+		&id,
+		&username,
+		&fullName,
+		&emailVerified,
+	); err == sql.ErrNoRows {
+		return nil, model.NotFoundErr
+	} else if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	user := model.User{
+		UserID:        id,
+		Username:      username,
+		FullName:      fullName,
+		Email:         email,
+		EmailVerified: emailVerified,
 	}
 
 	return &user, nil
@@ -80,7 +181,7 @@ func (*sqliteRepo) GetRelatedUser(ctx context.Context, id int64) ([]model.User,
 		return nil, err
 	}
 
-	rows, err := db.Query(getRelatedUserBasedOnID, id)
+	rows, err := db.Query(getRelatedUserBasedOnID, id, model.SwipeStatusSuperLike, model.AccountStatusActive)
 	if err != nil {
 		log.Println(err.Error())
 		return nil, err
@@ -92,16 +193,18 @@ func (*sqliteRepo) GetRelatedUser(ctx context.Context, id int64) ([]model.User,
 		var fullName string
 		var email string
 		var isPremium bool
-		err = rows.Scan(&id, &fullName, &email, &isPremium)
+		var superLiked bool
+		err = rows.Scan(&id, &fullName, &email, &isPremium, &superLiked)
 		if err != nil {
 			log.Println(err.Error())
 			return nil, err
 		}
 		user := model.User{
-			UserID:    id,
-			FullName:  fullName,
-			Email:     email,
-			IsPremium: isPremium,
+			UserID:     id,
+			FullName:   fullName,
+			Email:      email,
+			IsPremium:  isPremium,
+			SuperLiked: superLiked,
 		}
 		users = append(users, user)
 	}
@@ -112,3 +215,1157 @@ func (*sqliteRepo) GetRelatedUser(ctx context.Context, id int64) ([]model.User,
 	}
 	return users, nil
 }
+
+// GetActiveSubscription returns the subscription granting the user premium
+// at the time
+func (*sqliteRepo) GetActiveSubscription(ctx context.Context, userID int64, at time.Time) (*model.Subscription, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return scanSubscription(db.QueryRow(getActiveSubscription, userID, model.SubscriptionStatusActive, at.UTC(), model.SubscriptionStatusPastDue))
+}
+
+// GetSubscriptionByProviderID returns the subscription known to the payment
+// provider by the ID, whatever its status
+func (*sqliteRepo) GetSubscriptionByProviderID(ctx context.Context, providerSubscriptionID string) (*model.Subscription, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return scanSubscription(db.QueryRow(getSubscriptionByProviderID, providerSubscriptionID))
+}
+
+// GetPremiumEnd returns when the last of the subscriptions granting the user
+// premium at the time ends
+func (*sqliteRepo) GetPremiumEnd(ctx context.Context, userID int64, at time.Time) (time.Time, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return time.Time{}, err
+	}
+
+	var end time.Time
+	if err := db.QueryRow(getPremiumEnd, userID, model.SubscriptionStatusActive, at.UTC()).Scan(&end); err == sql.ErrNoRows {
+		return time.Time{}, model.NotFoundErr
+	} else if err != nil {
+		log.Println(err.Error())
+		return time.Time{}, err
+	}
+
+	return end, nil
+}
+
+// HasPaidSubscription reports whether the user ever subscribed through the
+// payment provider, whatever came of it
+func (*sqliteRepo) HasPaidSubscription(ctx context.Context, userID int64) (bool, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	var paid bool
+	if err := db.QueryRow(hasPaidSubscription, userID).Scan(&paid); err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	return paid, nil
+}
+
+// GetLapsedSubscriptions returns active subscriptions whose period ended by
+// the time and that are not going to be renewed
+func (*sqliteRepo) GetLapsedSubscriptions(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error) {
+	return querySubscriptions(getLapsedSubscriptions, model.SubscriptionStatusActive, at.UTC(), limit)
+}
+
+// GetDueRenewals returns active subscriptions of the payment provider whose
+// period ended by the time and that are set to renew
+func (*sqliteRepo) GetDueRenewals(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error) {
+	return querySubscriptions(getDueRenewals, model.SubscriptionStatusActive, at.UTC(), limit)
+}
+
+// GetDunningSubscriptions returns past due subscriptions within their grace
+// period at the time whose user was last reminded before notifiedBefore
+func (*sqliteRepo) GetDunningSubscriptions(ctx context.Context, at, notifiedBefore time.Time, limit int) ([]model.Subscription, error) {
+	return querySubscriptions(getDunningSubscriptions, model.SubscriptionStatusPastDue, at.UTC(), notifiedBefore.UTC(), limit)
+}
+
+// GetGraceEndedSubscriptions returns past due subscriptions whose grace
+// period ended by the time
+func (*sqliteRepo) GetGraceEndedSubscriptions(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error) {
+	return querySubscriptions(getGraceEndedSubscriptions, model.SubscriptionStatusPastDue, at.UTC(), limit)
+}
+
+func querySubscriptions(query string, args ...interface{}) ([]model.Subscription, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []model.Subscription
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, *subscription)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+// scanSubscription reads a row of subscriptionColumns from a *sql.Row or
+// *sql.Rows
+func scanSubscription(row interface {
+	Scan(dest ...interface{}) error
+}) (*model.Subscription, error) {
+	var (
+		subscription model.Subscription
+		graceUntil   sql.NullTime
+		updatedAt    sql.NullTime
+	)
+
+	if err := row.Scan(
+		&subscription.ID,
+		&subscription.UserID,
+		&subscription.Tier,
+		&subscription.Plan,
+		&subscription.Status,
+		&subscription.CurrentPeriodStart,
+		&subscription.CurrentPeriodEnd,
+		&subscription.AutoRenew,
+		&subscription.CancelAtPeriodEnd,
+		&graceUntil,
+		&subscription.ProviderSubscriptionID,
+		&subscription.Store,
+		&subscription.CreatedAt,
+		&updatedAt,
+	); err == sql.ErrNoRows {
+		return nil, model.NotFoundErr
+	} else if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	if graceUntil.Valid {
+		subscription.GraceUntil = &graceUntil.Time
+	}
+	if updatedAt.Valid {
+		subscription.UpdatedAt = &updatedAt.Time
+	}
+
+	return &subscription, nil
+}
+
+func (*sqliteRepo) GetTOTP(ctx context.Context, userID int64) (*model.TOTP, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	var secret string
+	var enabled bool
+
+	if err := db.QueryRow(getTOTP, userID).Scan(
+		&secret,
+		&enabled,
+	); err == sql.ErrNoRows {
+		return nil, model.TwoFactorNotEnrolledErr
+	} else if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	totp := model.TOTP{
+		UserID:  userID,
+		Secret:  secret,
+		Enabled: enabled,
+	}
+
+	return &totp, nil
+}
+
+func (*sqliteRepo) GetIdentity(ctx context.Context, provider, subject string) (*model.Identity, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	var id int64
+	var userID int64
+	var email string
+	var createdAt time.Time
+
+	if err := db.QueryRow(getIdentity, provider, subject).Scan(
+		&id,
+		&userID,
+		&email,
+		&createdAt,
+	); err == sql.ErrNoRows {
+		return nil, model.NotFoundErr
+	} else if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	identity := model.Identity{
+		ID:        id,
+		UserID:    userID,
+		Provider:  provider,
+		Subject:   subject,
+		Email:     email,
+		CreatedAt: createdAt,
+	}
+
+	return &identity, nil
+}
+
+func (*sqliteRepo) IsMatch(ctx context.Context, userID, otherUserID int64) (bool, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	var likes int
+	if err := db.QueryRow(countMutualLikes, userID, otherUserID, model.SwipeStatusLike, model.SwipeStatusSuperLike, model.AccountStatusShadowbanned).Scan(&likes); err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	return likes == 2, nil
+}
+
+func (*sqliteRepo) GetSwipe(ctx context.Context, swiperID, swipedID int64) (*model.Swipe, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	swipe := model.Swipe{SwiperID: swiperID, SwipedID: swipedID}
+	var createdAt time.Time
+	var updatedAt sql.NullTime
+
+	if err := db.QueryRow(getSwipe, swiperID, swipedID).Scan(&swipe.SwipeStatus, &createdAt, &updatedAt); err == sql.ErrNoRows {
+		return nil, model.NotFoundErr
+	} else if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	// Swiping again on a profile overwrites the swipe
+	swipe.SwipedAt = createdAt
+	if updatedAt.Valid {
+		swipe.SwipedAt = updatedAt.Time
+	}
+
+	return &swipe, nil
+}
+
+// IsBlocked reports whether either user blocked the other
+func (*sqliteRepo) IsBlocked(ctx context.Context, userID, otherUserID int64) (bool, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	var blocks int
+	if err := db.QueryRow(countBlocksBetween, userID, otherUserID).Scan(&blocks); err != nil {
+		log.Println(err.Error())
+		return false, err
+	}
+
+	return blocks > 0, nil
+}
+
+func (*sqliteRepo) GetConversation(ctx context.Context, conversationID int64) (*model.Conversation, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	var userAID int64
+	var userBID int64
+	var createdAt time.Time
+
+	if err := db.QueryRow(getConversation, conversationID).Scan(
+		&userAID,
+		&userBID,
+		&createdAt,
+	); err == sql.ErrNoRows {
+		return nil, model.NotFoundErr
+	} else if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	conversation := model.Conversation{
+		ID:        conversationID,
+		UserAID:   userAID,
+		UserBID:   userBID,
+		CreatedAt: createdAt,
+	}
+
+	return &conversation, nil
+}
+
+func (*sqliteRepo) GetConversations(ctx context.Context, userID int64) ([]model.Conversation, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	rows, err := db.Query(getConversations, userID)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+	var conversations []model.Conversation
+	for rows.Next() {
+		var conversation model.Conversation
+		var lastMessageAt sql.NullTime
+		err = rows.Scan(
+			&conversation.ID,
+			&conversation.UserAID,
+			&conversation.UserBID,
+			&conversation.CreatedAt,
+			&conversation.PeerName,
+			&lastMessageAt,
+			&conversation.UnreadCount,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+		conversation.PeerID = conversation.Peer(userID)
+		if lastMessageAt.Valid {
+			conversation.LastMessageAt = &lastMessageAt.Time
+		}
+		conversations = append(conversations, conversation)
+	}
+	err = rows.Err()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	return conversations, nil
+}
+
+// GetMessages returns up to limit messages of the conversation older than
+// beforeID, newest first
+func (*sqliteRepo) GetMessages(ctx context.Context, conversationID, beforeID int64, limit int) ([]model.Message, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	rows, err := db.Query(getMessages, conversationID, beforeID, limit)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+	var messages []model.Message
+	for rows.Next() {
+		message := model.Message{ConversationID: conversationID}
+		var deletedAt sql.NullTime
+		err = rows.Scan(
+			&message.ID,
+			&message.SenderID,
+			&message.Body,
+			&message.CreatedAt,
+			&deletedAt,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+		if deletedAt.Valid {
+			message.DeletedAt = &deletedAt.Time
+		}
+		messages = append(messages, message)
+	}
+	err = rows.Err()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (*sqliteRepo) GetMessage(ctx context.Context, messageID int64) (*model.Message, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	message := model.Message{ID: messageID}
+	var deletedAt sql.NullTime
+
+	if err := db.QueryRow(getMessage, messageID).Scan(
+		&message.ConversationID,
+		&message.SenderID,
+		&message.Body,
+		&message.CreatedAt,
+		&deletedAt,
+	); err == sql.ErrNoRows {
+		return nil, model.NotFoundErr
+	} else if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	if deletedAt.Valid {
+		message.DeletedAt = &deletedAt.Time
+	}
+
+	return &message, nil
+}
+
+func (*sqliteRepo) CountProfileViewers(ctx context.Context, userID int64, since time.Time) (int64, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	var count int64
+	if err := db.QueryRow(countProfileViewers, userID, since.UTC(), model.AccountStatusActive).Scan(&count); err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (*sqliteRepo) GetProfileViewers(ctx context.Context, userID int64, since time.Time, limit int) ([]model.ProfileViewer, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	rows, err := db.Query(getProfileViewers, userID, since.UTC(), model.AccountStatusActive, limit)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+	var viewers []model.ProfileViewer
+	for rows.Next() {
+		var viewer model.ProfileViewer
+		err = rows.Scan(&viewer.UserID, &viewer.FullName, &viewer.ViewedAt)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+		viewers = append(viewers, viewer)
+	}
+	err = rows.Err()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	return viewers, nil
+}
+
+func (*sqliteRepo) CountLikers(ctx context.Context, userID int64) (int64, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	var count int64
+	if err := db.QueryRow(countLikers, userID, model.SwipeStatusLike, model.SwipeStatusSuperLike, model.AccountStatusActive).Scan(&count); err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (*sqliteRepo) GetLikers(ctx context.Context, userID int64, limit int) ([]model.Liker, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	rows, err := db.Query(getLikers, userID, model.SwipeStatusLike, model.SwipeStatusSuperLike, model.AccountStatusActive, limit)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+	var likers []model.Liker
+	for rows.Next() {
+		var liker model.Liker
+		var status int
+		var updatedAt sql.NullTime
+		err = rows.Scan(&liker.UserID, &liker.FullName, &status, &liker.LikedAt, &updatedAt)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+		liker.SuperLiked = status == model.SwipeStatusSuperLike
+		if updatedAt.Valid {
+			liker.LikedAt = updatedAt.Time
+		}
+		likers = append(likers, liker)
+	}
+	err = rows.Err()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	return likers, nil
+}
+
+func (*sqliteRepo) GetReport(ctx context.Context, reportID int64) (*model.Report, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	report := model.Report{ID: reportID}
+	var assigneeID sql.NullInt64
+	var updatedAt sql.NullTime
+
+	if err := db.QueryRow(getReport, reportID).Scan(
+		&report.ReporterID,
+		&report.ReportedID,
+		&report.Reason,
+		&report.Detail,
+		&report.Status,
+		&assigneeID,
+		&report.Resolution,
+		&report.CreatedAt,
+		&updatedAt,
+	); err == sql.ErrNoRows {
+		return nil, model.NotFoundErr
+	} else if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	report.AssigneeID = assigneeID.Int64
+	if updatedAt.Valid {
+		report.UpdatedAt = &updatedAt.Time
+	}
+
+	return &report, nil
+}
+
+func (*sqliteRepo) GetReports(ctx context.Context, status string, limit int) ([]model.Report, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	rows, err := db.Query(getReports, status, limit)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+	var reports []model.Report
+	for rows.Next() {
+		var report model.Report
+		var assigneeID sql.NullInt64
+		var updatedAt sql.NullTime
+		err = rows.Scan(
+			&report.ID,
+			&report.ReporterID,
+			&report.ReportedID,
+			&report.Reason,
+			&report.Detail,
+			&report.Status,
+			&assigneeID,
+			&report.Resolution,
+			&report.CreatedAt,
+			&updatedAt,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+		report.AssigneeID = assigneeID.Int64
+		if updatedAt.Valid {
+			report.UpdatedAt = &updatedAt.Time
+		}
+		reports = append(reports, report)
+	}
+	err = rows.Err()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	return reports, nil
+}
+
+// CountPendingReporters counts the users whose reports of the user are not
+// resolved yet
+func (*sqliteRepo) CountPendingReporters(ctx context.Context, userID int64) (int, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	var count int
+	if err := db.QueryRow(countPendingReporters, userID, model.ReportStatusResolved).Scan(&count); err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// SearchUsers finds users whose username, email or name contains the query
+func (*sqliteRepo) SearchUsers(ctx context.Context, query string, limit int) ([]model.User, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	rows, err := db.Query(searchUsers, "%"+query+"%", limit)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+	var users []model.User
+	for rows.Next() {
+		var user model.User
+		err = rows.Scan(
+			&user.UserID,
+			&user.Username,
+			&user.FullName,
+			&user.Email,
+			&user.IsPremium,
+			&user.Role,
+			&user.AccountStatus,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	err = rows.Err()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	return users, nil
+}
+
+func (*sqliteRepo) GetSwipeStats(ctx context.Context, userID int64) (*model.SwipeStats, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	var stats model.SwipeStats
+	if err := db.QueryRow(getSwipeStats, userID, model.SwipeStatusLike, model.SwipeStatusSuperLike, model.SwipeStatusPass).Scan(
+		&stats.LikesGiven,
+		&stats.PassesGiven,
+		&stats.LikesReceived,
+		&stats.Matches,
+	); err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// GetAccountStatusHistory returns the account status changes of the user,
+// newest first
+func (*sqliteRepo) GetAccountStatusHistory(ctx context.Context, userID int64) ([]model.AccountStatusChange, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	rows, err := db.Query(getAccountStatusHistory, userID)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+	var history []model.AccountStatusChange
+	for rows.Next() {
+		change := model.AccountStatusChange{UserID: userID}
+		var until sql.NullTime
+		err = rows.Scan(
+			&change.ID,
+			&change.Status,
+			&change.Reason,
+			&until,
+			&change.ActorID,
+			&change.CreatedAt,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+		if until.Valid {
+			change.Until = &until.Time
+		}
+		history = append(history, change)
+	}
+	err = rows.Err()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	return history, nil
+}
+
+func (*sqliteRepo) GetPromoCode(ctx context.Context, code string) (*model.PromoCode, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return scanPromoCode(db.QueryRow(getPromoCode, code))
+}
+
+// GetPendingDiscount returns the discount code the user redeemed last that
+// was not applied to a subscription yet
+func (*sqliteRepo) GetPendingDiscount(ctx context.Context, userID int64) (*model.PromoCode, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return scanPromoCode(db.QueryRow(getPendingDiscount, userID))
+}
+
+func scanPromoCode(row *sql.Row) (*model.PromoCode, error) {
+	var (
+		promo     model.PromoCode
+		expiresAt sql.NullTime
+	)
+
+	if err := row.Scan(
+		&promo.ID,
+		&promo.Code,
+		&promo.Kind,
+		&promo.Value,
+		&promo.Tier,
+		&promo.MaxUses,
+		&promo.Uses,
+		&expiresAt,
+		&promo.CreatedBy,
+		&promo.CreatedAt,
+	); err == sql.ErrNoRows {
+		return nil, model.NotFoundErr
+	} else if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	if expiresAt.Valid {
+		promo.ExpiresAt = &expiresAt.Time
+	}
+
+	return &promo, nil
+}
+
+func (*sqliteRepo) GetReferrerByCode(ctx context.Context, code string) (int64, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	var referrerID int64
+	if err := db.QueryRow(getReferrerByCode, code).Scan(&referrerID); err == sql.ErrNoRows {
+		return 0, model.NotFoundErr
+	} else if err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	return referrerID, nil
+}
+
+func (*sqliteRepo) GetReferral(ctx context.Context, refereeID int64) (*model.Referral, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	var (
+		referral   model.Referral
+		rewardedAt sql.NullTime
+	)
+	if err := db.QueryRow(getReferral, refereeID).Scan(
+		&referral.ReferrerID,
+		&referral.RefereeID,
+		&referral.CreatedAt,
+		&rewardedAt,
+	); err == sql.ErrNoRows {
+		return nil, model.NotFoundErr
+	} else if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	if rewardedAt.Valid {
+		referral.RewardedAt = &rewardedAt.Time
+	}
+
+	return &referral, nil
+}
+
+// CountReferrals returns how many users signed up through the link of the
+// user and how many of them got the referral rewarded
+func (*sqliteRepo) CountReferrals(ctx context.Context, referrerID int64) (referred, rewarded int64, err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	if err = db.QueryRow(countReferrals, referrerID).Scan(&referred, &rewarded); err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	return
+}
+
+// CountBoosts returns how many boosts the user activated from the source
+// since the time
+func (*sqliteRepo) CountBoosts(ctx context.Context, userID int64, source string, since time.Time) (int64, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	var count int64
+	if err := db.QueryRow(countBoosts, userID, source, since.UTC()).Scan(&count); err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetActiveBoost returns the boost of the user running at the time
+func (*sqliteRepo) GetActiveBoost(ctx context.Context, userID int64, at time.Time) (*model.Boost, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	var boost model.Boost
+	if err := db.QueryRow(getActiveBoost, userID, at.UTC()).Scan(
+		&boost.ID,
+		&boost.UserID,
+		&boost.Source,
+		&boost.ActivatedAt,
+		&boost.ExpiresAt,
+	); err == sql.ErrNoRows {
+		return nil, model.NotFoundErr
+	} else if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return &boost, nil
+}
+
+// GetBoostBalance returns how many bought boosts the user has left
+func (*sqliteRepo) GetBoostBalance(ctx context.Context, userID int64) (int64, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	var balance int64
+	if err := db.QueryRow(getBoostBalance, userID).Scan(&balance); err == sql.ErrNoRows {
+		return 0, nil
+	} else if err != nil {
+		log.Println(err.Error())
+		return 0, err
+	}
+
+	return balance, nil
+}
+
+func (*sqliteRepo) GetPhoto(ctx context.Context, photoID int64) (*model.Photo, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return scanPhoto(db.QueryRow(getPhoto, photoID))
+}
+
+// GetPhotos returns the photos of the user, the primary one first and the
+// rest in the order the user put them in
+func (*sqliteRepo) GetPhotos(ctx context.Context, userID int64) ([]model.Photo, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	rows, err := db.Query(getPhotos, userID)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var photos []model.Photo
+	for rows.Next() {
+		photo, err := scanPhoto(rows)
+		if err != nil {
+			return nil, err
+		}
+		photos = append(photos, *photo)
+	}
+	err = rows.Err()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	return photos, nil
+}
+
+// GetPhotosByUserIDs returns the photos of each of the users in the order of
+// GetPhotos, leaving out users without any
+func (*sqliteRepo) GetPhotosByUserIDs(ctx context.Context, userIDs []int64) (map[int64][]model.Photo, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	ids, err := json.Marshal(userIDs)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	rows, err := db.Query(getPhotosByUserIDs, string(ids))
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	photos := make(map[int64][]model.Photo)
+	for rows.Next() {
+		photo, err := scanPhoto(rows)
+		if err != nil {
+			return nil, err
+		}
+		photos[photo.UserID] = append(photos[photo.UserID], *photo)
+	}
+	err = rows.Err()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	return photos, nil
+}
+
+// scanPhoto reads a row of photoColumns from a *sql.Row or *sql.Rows
+func scanPhoto(row interface {
+	Scan(dest ...interface{}) error
+}) (*model.Photo, error) {
+	var photo model.Photo
+	if err := row.Scan(
+		&photo.ID,
+		&photo.UserID,
+		&photo.Key,
+		&photo.ThumbnailKey,
+		&photo.Width,
+		&photo.Height,
+		&photo.Position,
+		&photo.IsPrimary,
+		&photo.CreatedAt,
+	); err == sql.ErrNoRows {
+		return nil, model.NotFoundErr
+	} else if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return &photo, nil
+}
+
+// GetLedgerEntries returns the ledger entries of the user older than beforeID,
+// newest first
+func (*sqliteRepo) GetLedgerEntries(ctx context.Context, userID, beforeID int64, limit int) ([]model.LedgerEntry, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	rows, err := db.Query(getLedgerEntries, userID, beforeID, limit)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []model.LedgerEntry
+	for rows.Next() {
+		entry, err := scanLedgerEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+	err = rows.Err()
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetLastLedgerEntry returns the latest entry of the kind for the
+// subscription
+func (*sqliteRepo) GetLastLedgerEntry(ctx context.Context, subscriptionID int64, kind string) (*model.LedgerEntry, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	return scanLedgerEntry(db.QueryRow(getLastLedgerEntry, subscriptionID, kind))
+}
+
+func (*sqliteRepo) GetInvoice(ctx context.Context, invoiceID int64) (*model.Invoice, error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	var (
+		invoice     model.Invoice
+		periodStart sql.NullTime
+		periodEnd   sql.NullTime
+	)
+	err = db.QueryRow(getInvoice, invoiceID).Scan(
+		&invoice.ID,
+		&invoice.Number,
+		&invoice.UserID,
+		&invoice.LedgerEntryID,
+		&invoice.Kind,
+		&invoice.Description,
+		&periodStart,
+		&periodEnd,
+		&invoice.Subtotal,
+		&invoice.TaxName,
+		&invoice.TaxRate,
+		&invoice.Tax,
+		&invoice.Total,
+		&invoice.Currency,
+		&invoice.IssuedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, model.NotFoundErr
+	} else if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	if periodStart.Valid {
+		invoice.PeriodStart = &periodStart.Time
+	}
+	if periodEnd.Valid {
+		invoice.PeriodEnd = &periodEnd.Time
+	}
+
+	return &invoice, nil
+}
+
+// scanLedgerEntry reads a row of ledgerEntryColumns from a *sql.Row or
+// *sql.Rows
+func scanLedgerEntry(row interface {
+	Scan(dest ...interface{}) error
+}) (*model.LedgerEntry, error) {
+	var (
+		entry       model.LedgerEntry
+		periodStart sql.NullTime
+		periodEnd   sql.NullTime
+	)
+	err := row.Scan(
+		&entry.ID,
+		&entry.UserID,
+		&entry.SubscriptionID,
+		&entry.Kind,
+		&entry.Tier,
+		&entry.Plan,
+		&entry.Description,
+		&entry.Amount,
+		&entry.Currency,
+		&periodStart,
+		&periodEnd,
+		&entry.InvoiceID,
+		&entry.InvoiceNumber,
+		&entry.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, model.NotFoundErr
+	} else if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	if periodStart.Valid {
+		entry.PeriodStart = &periodStart.Time
+	}
+	if periodEnd.Valid {
+		entry.PeriodEnd = &periodEnd.Time
+	}
+
+	return &entry, nil
+}
@@ -1,5 +1,32 @@
 package db
 
+// Premium is not stored on the user but derived from a subscription covering
+// the current time, or past due within its grace period
+const premiumSubscription = `EXISTS (
+	SELECT 1 FROM subscriptions
+	WHERE user_id = users.id AND (
+		(status = 'active' AND current_period_end > datetime()) OR (status = 'past_due' AND grace_until > datetime())
+	)
+)`
+
+// The best tier of the subscriptions granting premium at the current time,
+// free without one
+const subscriptionTier = `COALESCE((
+	SELECT tier FROM subscriptions
+	WHERE user_id = users.id AND (
+		(status = 'active' AND current_period_end > datetime()) OR (status = 'past_due' AND grace_until > datetime())
+	)
+	ORDER BY tier = 'gold' DESC LIMIT 1
+), 'free')`
+
+const ledgerEntryColumns = `l.id, l.user_id, COALESCE(l.subscription_id, 0), l.kind, l.tier, l.plan, l.description, l.amount, l.currency,
+	l.period_start, l.period_end, COALESCE(i.id, 0), CASE WHEN i.id IS NULL THEN '' ELSE printf('INV-%06d', i.number) END, l.created_at`
+
+const photoColumns = `id, user_id, key, thumbnail_key, width, height, position, is_primary, created_at`
+
+const subscriptionColumns = `id, user_id, tier, plan, status, current_period_start, current_period_end, auto_renew, cancel_at_period_end,
+	grace_until, COALESCE(provider_subscription_id, ''), store, created_at, updated_at`
+
 const (
 	insertUserTable = `
 	CREATE TABLE "users" (
@@ -8,12 +35,307 @@ const (
 		"password" varchar NOT NULL,
 		"full_name" varchar NOT NULL,
 		"email" varchar UNIQUE NOT NULL,
-		"is_premium" bool NOT NULL DEFAULT (false),
+		"email_verified" bool NOT NULL DEFAULT (false),
+		"verified_at" timestamp,
+		"is_incognito" bool NOT NULL DEFAULT (false),
+		"is_hidden" bool NOT NULL DEFAULT (false),
+		"role" varchar NOT NULL DEFAULT ('user'),
+		"account_status" varchar NOT NULL DEFAULT ('active'),
+		"status_reason" varchar NOT NULL DEFAULT (''),
+		"status_until" timestamp,
 		"created_at" timestamptz NOT NULL DEFAULT (date()),
 		"updated_at" timestamptz
 	);
 	`
 
+	insertSubscriptionTable = `
+	CREATE TABLE "subscriptions" (
+		"id" integer PRIMARY KEY,
+		"user_id" integer NOT NULL,
+		"tier" varchar NOT NULL DEFAULT ('plus'),
+		"plan" varchar NOT NULL,
+		"status" varchar NOT NULL,
+		"current_period_start" timestamp NOT NULL,
+		"current_period_end" timestamp NOT NULL,
+		"auto_renew" bool NOT NULL DEFAULT (true),
+		"cancel_at_period_end" bool NOT NULL DEFAULT (false),
+		"provider_subscription_id" varchar UNIQUE,
+		"store" varchar NOT NULL DEFAULT (''),
+		"grace_until" timestamp,
+		"dunning_notified_at" timestamp,
+		"created_at" timestamp NOT NULL DEFAULT (datetime()),
+		"updated_at" timestamp
+	);
+	CREATE INDEX "subscriptions_user_id" ON "subscriptions" ("user_id", "status");
+	`
+
+	insertPaymentEventTable = `
+	CREATE TABLE "payment_events" (
+		"id" varchar PRIMARY KEY,
+		"type" varchar NOT NULL,
+		"subscription_id" varchar NOT NULL DEFAULT (''),
+		"created_at" timestamp NOT NULL DEFAULT (datetime())
+	);
+	`
+
+	insertPromoCodeTable = `
+	CREATE TABLE "promo_codes" (
+		"id" integer PRIMARY KEY,
+		"code" varchar NOT NULL UNIQUE,
+		"kind" varchar NOT NULL,
+		"value" integer NOT NULL,
+		"tier" varchar NOT NULL DEFAULT (''),
+		"max_uses" integer NOT NULL DEFAULT (0),
+		"uses" integer NOT NULL DEFAULT (0),
+		"expires_at" timestamp,
+		"created_by" integer NOT NULL,
+		"created_at" timestamp NOT NULL DEFAULT (datetime())
+	);
+	`
+
+	insertPromoRedemptionTable = `
+	CREATE TABLE "promo_redemptions" (
+		"promo_code_id" integer NOT NULL,
+		"user_id" integer NOT NULL,
+		"redeemed_at" timestamp NOT NULL DEFAULT (datetime()),
+		"applied_at" timestamp,
+		PRIMARY KEY ("promo_code_id", "user_id")
+	);
+	CREATE INDEX "promo_redemptions_user_id" ON "promo_redemptions" ("user_id");
+	`
+
+	insertReferralCodeTable = `
+	CREATE TABLE "referral_codes" (
+		"user_id" integer PRIMARY KEY,
+		"code" varchar NOT NULL UNIQUE,
+		"created_at" timestamp NOT NULL DEFAULT (datetime())
+	);
+	`
+
+	insertReferralTable = `
+	CREATE TABLE "referrals" (
+		"referee_id" integer PRIMARY KEY,
+		"referrer_id" integer NOT NULL,
+		"created_at" timestamp NOT NULL DEFAULT (datetime()),
+		"rewarded_at" timestamp
+	);
+	CREATE INDEX "referrals_referrer_id" ON "referrals" ("referrer_id");
+	`
+
+	insertBoostTable = `
+	CREATE TABLE "boosts" (
+		"id" integer PRIMARY KEY,
+		"user_id" integer NOT NULL,
+		"source" varchar NOT NULL,
+		"activated_at" timestamp NOT NULL,
+		"expires_at" timestamp NOT NULL
+	);
+	CREATE INDEX "boosts_user_id" ON "boosts" ("user_id", "activated_at");
+	`
+
+	insertBoostBalanceTable = `
+	CREATE TABLE "boost_balances" (
+		"user_id" integer PRIMARY KEY,
+		"balance" integer NOT NULL DEFAULT (0)
+	);
+	`
+
+	insertLedgerTable = `
+	CREATE TABLE "billing_ledger" (
+		"id" integer PRIMARY KEY,
+		"user_id" integer NOT NULL,
+		"subscription_id" integer,
+		"kind" varchar NOT NULL,
+		"tier" varchar NOT NULL DEFAULT (''),
+		"plan" varchar NOT NULL DEFAULT (''),
+		"description" varchar NOT NULL,
+		"amount" integer NOT NULL,
+		"currency" varchar NOT NULL,
+		"period_start" timestamp,
+		"period_end" timestamp,
+		"reference" varchar NOT NULL UNIQUE,
+		"created_at" timestamp NOT NULL DEFAULT (datetime())
+	);
+	CREATE INDEX "billing_ledger_user_id" ON "billing_ledger" ("user_id", "id");
+	`
+
+	insertInvoiceTable = `
+	CREATE TABLE "invoices" (
+		"id" integer PRIMARY KEY,
+		"number" integer NOT NULL UNIQUE,
+		"user_id" integer NOT NULL,
+		"ledger_entry_id" integer NOT NULL UNIQUE,
+		"subtotal" integer NOT NULL,
+		"tax_name" varchar NOT NULL,
+		"tax_rate" integer NOT NULL,
+		"tax" integer NOT NULL,
+		"total" integer NOT NULL,
+		"currency" varchar NOT NULL,
+		"issued_at" timestamp NOT NULL DEFAULT (datetime())
+	);
+	`
+
+	insertBoostPurchaseTable = `
+	CREATE TABLE "boost_purchases" (
+		"id" varchar PRIMARY KEY,
+		"user_id" integer NOT NULL,
+		"boosts" integer NOT NULL,
+		"created_at" timestamp NOT NULL DEFAULT (datetime())
+	);
+	`
+
+	insertPhotoTable = `
+	CREATE TABLE "photos" (
+		"id" integer PRIMARY KEY,
+		"user_id" integer NOT NULL,
+		"key" varchar NOT NULL,
+		"thumbnail_key" varchar NOT NULL,
+		"width" integer NOT NULL,
+		"height" integer NOT NULL,
+		"position" integer NOT NULL,
+		"is_primary" bool NOT NULL DEFAULT (false),
+		"created_at" timestamp NOT NULL DEFAULT (datetime())
+	);
+	CREATE INDEX "photos_user_id" ON "photos" ("user_id", "position");
+	`
+
+	insertAuditLogTable = `
+	CREATE TABLE "audit_logs" (
+		"id" integer PRIMARY KEY,
+		"user_id" integer NOT NULL,
+		"actor_id" integer NOT NULL,
+		"action" varchar NOT NULL,
+		"detail" varchar NOT NULL DEFAULT (''),
+		"created_at" timestamp NOT NULL DEFAULT (datetime())
+	);
+	`
+
+	insertUserTOTPTable = `
+	CREATE TABLE "user_totp" (
+		"user_id" integer PRIMARY KEY,
+		"secret" varchar NOT NULL,
+		"enabled" bool NOT NULL DEFAULT (false),
+		"last_step" integer NOT NULL DEFAULT (0),
+		"created_at" timestamp NOT NULL DEFAULT (datetime()),
+		"updated_at" timestamp
+	);
+	`
+
+	insertRecoveryCodeTable = `
+	CREATE TABLE "recovery_codes" (
+		"id" integer PRIMARY KEY,
+		"user_id" integer NOT NULL,
+		"code_hash" varchar NOT NULL,
+		"used_at" timestamp
+	);
+	`
+
+	insertUserIdentityTable = `
+	CREATE TABLE "user_identities" (
+		"id" integer PRIMARY KEY,
+		"user_id" integer NOT NULL,
+		"provider" varchar NOT NULL,
+		"subject" varchar NOT NULL,
+		"email" varchar NOT NULL DEFAULT (''),
+		"created_at" timestamp NOT NULL DEFAULT (datetime()),
+		UNIQUE ("provider", "subject")
+	);
+	`
+
+	insertSwipeTable = `
+	CREATE TABLE "swipes" (
+		"swiper_id" integer NOT NULL,
+		"swiped_id" integer NOT NULL,
+		"status" integer NOT NULL,
+		"created_at" timestamp NOT NULL DEFAULT (datetime()),
+		"updated_at" timestamp,
+		PRIMARY KEY ("swiper_id", "swiped_id")
+	);
+	CREATE INDEX "swipes_swiped_id" ON "swipes" ("swiped_id", "status");
+	`
+
+	insertConversationTable = `
+	CREATE TABLE "conversations" (
+		"id" integer PRIMARY KEY,
+		"user_a_id" integer NOT NULL,
+		"user_b_id" integer NOT NULL,
+		"created_at" timestamp NOT NULL DEFAULT (datetime()),
+		UNIQUE ("user_a_id", "user_b_id")
+	);
+	`
+
+	insertMessageTable = `
+	CREATE TABLE "messages" (
+		"id" integer PRIMARY KEY,
+		"conversation_id" integer NOT NULL,
+		"sender_id" integer NOT NULL,
+		"body" varchar NOT NULL,
+		"created_at" timestamp NOT NULL DEFAULT (datetime()),
+		"deleted_at" timestamp
+	);
+	CREATE INDEX "messages_conversation_id" ON "messages" ("conversation_id", "id");
+	`
+
+	insertConversationReadTable = `
+	CREATE TABLE "conversation_reads" (
+		"conversation_id" integer NOT NULL,
+		"user_id" integer NOT NULL,
+		"last_read_message_id" integer NOT NULL,
+		PRIMARY KEY ("conversation_id", "user_id")
+	);
+	`
+
+	insertProfileViewTable = `
+	CREATE TABLE "profile_views" (
+		"id" integer PRIMARY KEY,
+		"viewer_id" integer NOT NULL,
+		"viewed_id" integer NOT NULL,
+		"view_date" varchar NOT NULL,
+		"viewed_at" timestamp NOT NULL,
+		UNIQUE ("viewer_id", "viewed_id", "view_date")
+	);
+	CREATE INDEX "profile_views_viewed_id" ON "profile_views" ("viewed_id", "viewed_at");
+	`
+
+	insertBlockTable = `
+	CREATE TABLE "blocks" (
+		"blocker_id" integer NOT NULL,
+		"blocked_id" integer NOT NULL,
+		"created_at" timestamp NOT NULL DEFAULT (datetime()),
+		PRIMARY KEY ("blocker_id", "blocked_id")
+	);
+	`
+
+	insertReportTable = `
+	CREATE TABLE "reports" (
+		"id" integer PRIMARY KEY,
+		"reporter_id" integer NOT NULL,
+		"reported_id" integer NOT NULL,
+		"reason" varchar NOT NULL,
+		"detail" varchar NOT NULL DEFAULT (''),
+		"status" varchar NOT NULL DEFAULT ('open'),
+		"assignee_id" integer,
+		"resolution" varchar NOT NULL DEFAULT (''),
+		"created_at" timestamp NOT NULL DEFAULT (datetime()),
+		"updated_at" timestamp
+	);
+	CREATE INDEX "reports_status" ON "reports" ("status", "id");
+	CREATE INDEX "reports_reported_id" ON "reports" ("reported_id");
+	`
+
+	insertAccountStatusHistoryTable = `
+	CREATE TABLE "account_status_history" (
+		"id" integer PRIMARY KEY,
+		"user_id" integer NOT NULL,
+		"status" varchar NOT NULL,
+		"reason" varchar NOT NULL DEFAULT (''),
+		"until" timestamp,
+		"actor_id" integer NOT NULL DEFAULT (0),
+		"created_at" timestamp NOT NULL DEFAULT (datetime())
+	);
+	CREATE INDEX "account_status_history_user_id" ON "account_status_history" ("user_id");
+	`
+
 	createUser = `
 	INSERT INTO users (
 		username,
@@ -25,25 +347,810 @@ const (
 	)
 	`
 
-	updatePremiumStatus = `
-		UPDATE users SET 
-			is_premium = $1,
+	insertSubscription = `
+	INSERT INTO subscriptions (
+		user_id,
+		plan,
+		status,
+		current_period_start,
+		current_period_end,
+		auto_renew,
+		cancel_at_period_end,
+		provider_subscription_id,
+		tier,
+		store
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+	)
+	`
+
+	insertPaymentEvent = `
+	INSERT INTO payment_events (
+		id,
+		type,
+		subscription_id
+	) VALUES (
+		$1, $2, $3
+	) ON CONFLICT (id) DO NOTHING
+	`
+
+	deletePaymentEvent = `
+		DELETE FROM payment_events WHERE id = $1
+	`
+
+	insertPromoCode = `
+	INSERT INTO promo_codes (
+		code,
+		kind,
+		value,
+		tier,
+		max_uses,
+		expires_at,
+		created_by
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7
+	)
+	`
+
+	insertPromoRedemption = `
+	INSERT INTO promo_redemptions (
+		promo_code_id,
+		user_id,
+		applied_at
+	) VALUES (
+		$1, $2, $3
+	) ON CONFLICT (promo_code_id, user_id) DO NOTHING
+	`
+
+	usePromoCode = `
+		UPDATE promo_codes SET uses = uses + 1
+		WHERE id = $1 AND (max_uses = 0 OR uses < max_uses)
+	`
+
+	// Gives back a redemption whose premium days could not be granted
+	deletePromoRedemption = `
+		DELETE FROM promo_redemptions
+		WHERE promo_code_id = $1 AND user_id = $2
+	`
+
+	unusePromoCode = `
+		UPDATE promo_codes SET uses = uses - 1
+		WHERE id = $1 AND uses > 0
+	`
+
+	applyPendingDiscounts = `
+		UPDATE promo_redemptions SET applied_at = $1
+		WHERE user_id = $2 AND applied_at IS NULL
+	`
+
+	insertReferralCode = `
+	INSERT INTO referral_codes (
+		user_id,
+		code
+	) VALUES (
+		$1, $2
+	) ON CONFLICT (user_id) DO NOTHING
+	`
+
+	insertReferral = `
+	INSERT INTO referrals (
+		referee_id,
+		referrer_id
+	) VALUES (
+		$1, $2
+	) ON CONFLICT (referee_id) DO NOTHING
+	`
+
+	rewardReferral = `
+		UPDATE referrals SET rewarded_at = $1
+		WHERE referee_id = $2 AND rewarded_at IS NULL
+	`
+
+	// New photos go last, the first one becoming primary, as long as the
+	// user has fewer than $6
+	insertPhoto = `
+	INSERT INTO photos (
+		user_id,
+		key,
+		thumbnail_key,
+		width,
+		height,
+		position,
+		is_primary
+	) SELECT $1, $2, $3, $4, $5,
+		(SELECT COALESCE(MAX(position), -1) + 1 FROM photos WHERE user_id = $1),
+		NOT EXISTS (SELECT 1 FROM photos WHERE user_id = $1)
+	WHERE (SELECT COUNT(1) FROM photos WHERE user_id = $1) < $6
+	`
+
+	updatePhotoPosition = `
+		UPDATE photos SET position = $1 WHERE id = $2 AND user_id = $3
+	`
+
+	updatePrimaryPhoto = `
+		UPDATE photos SET is_primary = (id = $1)
+		WHERE user_id = $2 AND EXISTS (SELECT 1 FROM photos WHERE id = $1 AND user_id = $2)
+	`
+
+	deletePhoto = `
+		DELETE FROM photos WHERE id = $1 AND user_id = $2
+	`
+
+	// The first photo left takes over from a deleted primary one
+	promotePrimaryPhoto = `
+		UPDATE photos SET is_primary = true
+		WHERE id = (SELECT id FROM photos WHERE user_id = $1 ORDER BY position, id LIMIT 1)
+		AND NOT EXISTS (SELECT 1 FROM photos WHERE user_id = $1 AND is_primary)
+	`
+
+	// A user only has one boost running at a time
+	insertBoost = `
+	INSERT INTO boosts (
+		user_id,
+		source,
+		activated_at,
+		expires_at
+	) SELECT $1, $2, $3, $4
+	WHERE NOT EXISTS (SELECT 1 FROM boosts WHERE user_id = $1 AND expires_at > $3)
+	`
+
+	deleteBoost = `
+		DELETE FROM boosts WHERE id = $1
+	`
+
+	useBoostBalance = `
+		UPDATE boost_balances SET balance = balance - 1
+		WHERE user_id = $1 AND balance > 0
+	`
+
+	addBoostBalance = `
+	INSERT INTO boost_balances (
+		user_id,
+		balance
+	) VALUES (
+		$1, $2
+	) ON CONFLICT (user_id) DO UPDATE SET balance = balance + excluded.balance
+	`
+
+	insertLedgerEntry = `
+	INSERT INTO billing_ledger (
+		user_id,
+		subscription_id,
+		kind,
+		tier,
+		plan,
+		description,
+		amount,
+		currency,
+		period_start,
+		period_end,
+		reference
+	) VALUES (
+		$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+	) ON CONFLICT (reference) DO NOTHING
+	`
+
+	// Numbers are taken in the transaction adding the invoice so they have
+	// no gaps
+	insertInvoice = `
+	INSERT INTO invoices (
+		number,
+		user_id,
+		ledger_entry_id,
+		subtotal,
+		tax_name,
+		tax_rate,
+		tax,
+		total,
+		currency
+	) SELECT COALESCE(MAX(number), 0) + 1, $1, $2, $3, $4, $5, $6, $7, $8 FROM invoices
+	`
+
+	insertBoostPurchase = `
+	INSERT INTO boost_purchases (
+		id,
+		user_id,
+		boosts
+	) VALUES (
+		$1, $2, $3
+	) ON CONFLICT (id) DO NOTHING
+	`
+
+	updateSubscription = `
+		UPDATE subscriptions SET
+			tier = $1,
+			plan = $2,
+			status = $3,
+			current_period_start = $4,
+			current_period_end = $5,
+			auto_renew = $6,
+			cancel_at_period_end = $7,
+			grace_until = $8,
+			updated_at = datetime()
+		WHERE id = $9
+	`
+
+	updateDunningNotified = `
+		UPDATE subscriptions SET dunning_notified_at = $1 WHERE id = $2
+	`
+
+	updateIncognito = `
+		UPDATE users SET
+			is_incognito = $1,
 			updated_at = date()
 		WHERE id = $2
 	`
 
+	// Hidden profiles are left out of discovery until their reports are reviewed
+	updateHidden = `
+		UPDATE users SET
+			is_hidden = $1,
+			updated_at = date()
+		WHERE id = $2
+	`
+
+	updateRole = `
+		UPDATE users SET
+			role = $1,
+			updated_at = date()
+		WHERE id = $2
+	`
+
+	updateAccountStatus = `
+		UPDATE users SET
+			account_status = $1,
+			status_reason = $2,
+			status_until = $3,
+			updated_at = date()
+		WHERE id = $4
+	`
+
+	insertAccountStatusHistory = `
+	INSERT INTO account_status_history (
+		user_id,
+		status,
+		reason,
+		until,
+		actor_id
+	) VALUES (
+		$1, $2, $3, $4, $5
+	)
+	`
+
+	updatePassword = `
+		UPDATE users SET
+			password = $1,
+			updated_at = date()
+		WHERE id = $2
+	`
+
+	updateEmail = `
+		UPDATE users SET
+			email = $1,
+			email_verified = false,
+			updated_at = date()
+		WHERE id = $2
+	`
+
+	updateEmailVerified = `
+		UPDATE users SET
+			email_verified = true,
+			verified_at = COALESCE(verified_at, datetime()),
+			updated_at = date()
+		WHERE id = $1 AND email = $2
+	`
+
+	insertAuditLog = `
+	INSERT INTO audit_logs (
+		user_id,
+		actor_id,
+		action,
+		detail
+	) VALUES (
+		$1, $2, $3, $4
+	)
+	`
+
+	upsertTOTPSecret = `
+	INSERT INTO user_totp (
+		user_id,
+		secret
+	) VALUES (
+		$1, $2
+	) ON CONFLICT (user_id) DO UPDATE SET
+		secret = excluded.secret,
+		enabled = false,
+		last_step = 0,
+		updated_at = datetime()
+	`
+
+	enableTOTP = `
+		UPDATE user_totp SET
+			enabled = true,
+			updated_at = datetime()
+		WHERE user_id = $1
+	`
+
+	deleteRecoveryCodes = `
+		DELETE FROM recovery_codes WHERE user_id = $1
+	`
+
+	insertRecoveryCode = `
+	INSERT INTO recovery_codes (
+		user_id,
+		code_hash
+	) VALUES (
+		$1, $2
+	)
+	`
+
+	useTOTPStep = `
+		UPDATE user_totp SET
+			last_step = $1,
+			updated_at = datetime()
+		WHERE user_id = $2 AND last_step < $1
+	`
+
+	useRecoveryCode = `
+		UPDATE recovery_codes SET
+			used_at = datetime()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+	`
+
+	insertIdentity = `
+	INSERT INTO user_identities (
+		user_id,
+		provider,
+		subject,
+		email
+	) VALUES (
+		$1, $2, $3, $4
+	)
+	`
+
+	upsertSwipe = `
+	INSERT INTO swipes (
+		swiper_id,
+		swiped_id,
+		status
+	) VALUES (
+		$1, $2, $3
+	) ON CONFLICT (swiper_id, swiped_id) DO UPDATE SET
+		status = excluded.status,
+		updated_at = datetime()
+	`
+
+	getSwipe = `
+		SELECT status, created_at, updated_at FROM swipes
+		WHERE swiper_id = $1 AND swiped_id = $2
+	`
+
+	deleteSwipe = `
+		DELETE FROM swipes WHERE swiper_id = $1 AND swiped_id = $2
+	`
+
+	insertConversation = `
+	INSERT INTO conversations (
+		user_a_id,
+		user_b_id
+	) VALUES (
+		$1, $2
+	) ON CONFLICT (user_a_id, user_b_id) DO NOTHING
+	`
+
+	getConversationByUsers = `
+		SELECT id, created_at FROM conversations
+		WHERE user_a_id = $1 AND user_b_id = $2 LIMIT 1
+	`
+
+	insertMessage = `
+	INSERT INTO messages (
+		conversation_id,
+		sender_id,
+		body
+	) VALUES (
+		$1, $2, $3
+	)
+	`
+
+	deleteMessage = `
+		UPDATE messages SET
+			deleted_at = datetime()
+		WHERE id = $1 AND sender_id = $2 AND deleted_at IS NULL
+	`
+
+	upsertConversationRead = `
+	INSERT INTO conversation_reads (
+		conversation_id,
+		user_id,
+		last_read_message_id
+	) VALUES (
+		$1, $2, $3
+	) ON CONFLICT (conversation_id, user_id) DO UPDATE SET
+		last_read_message_id = MAX(last_read_message_id, excluded.last_read_message_id)
+	`
+
+	// A view is only kept once per viewer, profile and day
+	insertProfileView = `
+	INSERT INTO profile_views (
+		viewer_id,
+		viewed_id,
+		view_date,
+		viewed_at
+	) VALUES (
+		$1, $2, $3, $4
+	) ON CONFLICT (viewer_id, viewed_id, view_date) DO NOTHING
+	`
+
+	insertBlock = `
+	INSERT INTO blocks (
+		blocker_id,
+		blocked_id
+	) VALUES (
+		$1, $2
+	) ON CONFLICT (blocker_id, blocked_id) DO NOTHING
+	`
+
+	deleteBlock = `
+		DELETE FROM blocks WHERE blocker_id = $1 AND blocked_id = $2
+	`
+
+	// Blocking undoes the likes of both users for each other
+	deleteLikesBetween = `
+		DELETE FROM swipes
+		WHERE (
+			(swiper_id = $1 AND swiped_id = $2) OR
+			(swiper_id = $2 AND swiped_id = $1)
+		) AND status IN ($3, $4)
+	`
+
+	countBlocksBetween = `
+		SELECT COUNT(1) FROM blocks
+		WHERE (blocker_id = $1 AND blocked_id = $2) OR (blocker_id = $2 AND blocked_id = $1)
+	`
+
+	insertReport = `
+	INSERT INTO reports (
+		reporter_id,
+		reported_id,
+		reason,
+		detail
+	) VALUES (
+		$1, $2, $3, $4
+	)
+	`
+
+	// Only succeeds while the report is still in the status it was reviewed in,
+	// so two moderators cannot act on the same report at once
+	updateReportStatus = `
+		UPDATE reports SET
+			status = $1,
+			assignee_id = $2,
+			resolution = $3,
+			updated_at = datetime()
+		WHERE id = $4 AND status = $5
+	`
+
+	// Likes of shadowbanned users never make a match
+	countMutualLikes = `
+		SELECT COUNT(1) FROM swipes
+		WHERE (
+			(swiper_id = $1 AND swiped_id = $2) OR
+			(swiper_id = $2 AND swiped_id = $1)
+		) AND status IN ($3, $4)
+			AND NOT EXISTS (SELECT 1 FROM users WHERE id IN ($1, $2) AND account_status = $5)
+	`
+
+	getConversation = `
+		SELECT user_a_id, user_b_id, created_at FROM conversations
+		WHERE id = $1 LIMIT 1
+	`
+
+	getConversations = `
+		SELECT c.id, c.user_a_id, c.user_b_id, c.created_at, u.full_name, lm.created_at,
+			(SELECT COUNT(1) FROM messages m
+			WHERE m.conversation_id = c.id AND m.sender_id <> $1 AND m.deleted_at IS NULL
+				AND m.id > COALESCE((SELECT last_read_message_id FROM conversation_reads r
+					WHERE r.conversation_id = c.id AND r.user_id = $1), 0))
+		FROM conversations c
+		JOIN users u ON u.id = CASE WHEN c.user_a_id = $1 THEN c.user_b_id ELSE c.user_a_id END
+		LEFT JOIN messages lm ON lm.id = (SELECT MAX(id) FROM messages WHERE conversation_id = c.id)
+		WHERE c.user_a_id = $1 OR c.user_b_id = $1
+		ORDER BY COALESCE(lm.id, 0) DESC, c.id DESC
+	`
+
+	// Deleted messages keep their place in the history without their body
+	getMessages = `
+		SELECT id, sender_id, CASE WHEN deleted_at IS NULL THEN body ELSE '' END, created_at, deleted_at
+		FROM messages
+		WHERE conversation_id = $1 AND ($2 = 0 OR id < $2)
+		ORDER BY id DESC LIMIT $3
+	`
+
+	getMessage = `
+		SELECT conversation_id, sender_id, CASE WHEN deleted_at IS NULL THEN body ELSE '' END, created_at, deleted_at
+		FROM messages
+		WHERE id = $1 LIMIT 1
+	`
+
+	getIdentity = `
+		SELECT id, user_id, email, created_at FROM user_identities
+		WHERE provider = $1 AND subject = $2 LIMIT 1
+	`
+
+	getUserByEmail = `
+		SELECT id, username, full_name, email_verified FROM users
+		WHERE email = $1 LIMIT 1
+	`
+
+	// Newest subscription covering the time, or past due within its grace
+	// period
+	getActiveSubscription = `
+		SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE user_id = $1 AND ((status = $2 AND current_period_end > $3) OR (status = $4 AND grace_until > $3))
+		ORDER BY provider_subscription_id IS NULL, current_period_end DESC LIMIT 1
+	`
+
+	// Subscriptions whose period ended that are not renewed by the payment
+	// provider: granted ones and ones canceled at the period end. The stores
+	// tell when their subscriptions end
+	getLapsedSubscriptions = `
+		SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE status = $1 AND current_period_end <= $2 AND (
+			provider_subscription_id IS NULL OR (store = '' AND cancel_at_period_end)
+		)
+		ORDER BY current_period_end LIMIT $3
+	`
+
+	getDueRenewals = `
+		SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE status = $1 AND current_period_end <= $2
+			AND provider_subscription_id IS NOT NULL AND store = '' AND NOT cancel_at_period_end
+		ORDER BY current_period_end LIMIT $3
+	`
+
+	// Past due subscriptions within their grace period whose user was not
+	// reminded since the time
+	getDunningSubscriptions = `
+		SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE status = $1 AND store = '' AND grace_until > $2
+			AND (dunning_notified_at IS NULL OR dunning_notified_at <= $3)
+		ORDER BY grace_until LIMIT $4
+	`
+
+	getGraceEndedSubscriptions = `
+		SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE status = $1 AND grace_until <= $2
+		ORDER BY grace_until LIMIT $3
+	`
+
+	getPremiumEnd = `
+		SELECT current_period_end FROM subscriptions
+		WHERE user_id = $1 AND status = $2 AND current_period_end > $3
+		ORDER BY current_period_end DESC LIMIT 1
+	`
+
+	hasPaidSubscription = `
+		SELECT EXISTS (SELECT 1 FROM subscriptions WHERE user_id = $1 AND provider_subscription_id IS NOT NULL)
+	`
+
+	getPromoCode = `
+		SELECT id, code, kind, value, tier, max_uses, uses, expires_at, created_by, created_at
+		FROM promo_codes WHERE code = $1 LIMIT 1
+	`
+
+	getPendingDiscount = `
+		SELECT p.id, p.code, p.kind, p.value, p.tier, p.max_uses, p.uses, p.expires_at, p.created_by, p.created_at
+		FROM promo_redemptions r
+		JOIN promo_codes p ON p.id = r.promo_code_id
+		WHERE r.user_id = $1 AND r.applied_at IS NULL AND p.kind IN ('percent', 'fixed')
+		ORDER BY r.redeemed_at DESC, r.promo_code_id DESC LIMIT 1
+	`
+
+	getReferralCode = `
+		SELECT code FROM referral_codes WHERE user_id = $1
+	`
+
+	getReferrerByCode = `
+		SELECT user_id FROM referral_codes WHERE code = $1
+	`
+
+	getReferral = `
+		SELECT referrer_id, referee_id, created_at, rewarded_at FROM referrals WHERE referee_id = $1
+	`
+
+	countReferrals = `
+		SELECT COUNT(*), COUNT(rewarded_at) FROM referrals WHERE referrer_id = $1
+	`
+
+	countBoosts = `
+		SELECT COUNT(*) FROM boosts WHERE user_id = $1 AND source = $2 AND activated_at >= $3
+	`
+
+	getActiveBoost = `
+		SELECT id, user_id, source, activated_at, expires_at FROM boosts
+		WHERE user_id = $1 AND expires_at > $2
+		ORDER BY expires_at DESC LIMIT 1
+	`
+
+	getBoostBalance = `
+		SELECT balance FROM boost_balances WHERE user_id = $1
+	`
+
+	getPhoto = `
+		SELECT ` + photoColumns + ` FROM photos WHERE id = $1
+	`
+
+	getPhotos = `
+		SELECT ` + photoColumns + ` FROM photos WHERE user_id = $1
+		ORDER BY is_primary DESC, position, id
+	`
+
+	// The users are passed as a JSON array
+	getPhotosByUserIDs = `
+		SELECT ` + photoColumns + ` FROM photos WHERE user_id IN (SELECT value FROM json_each($1))
+		ORDER BY user_id, is_primary DESC, position, id
+	`
+
+	getLedgerEntries = `
+		SELECT ` + ledgerEntryColumns + `
+		FROM billing_ledger l
+		LEFT JOIN invoices i ON i.ledger_entry_id = l.id
+		WHERE l.user_id = $1 AND ($2 = 0 OR l.id < $2)
+		ORDER BY l.id DESC LIMIT $3
+	`
+
+	getLastLedgerEntry = `
+		SELECT ` + ledgerEntryColumns + `
+		FROM billing_ledger l
+		LEFT JOIN invoices i ON i.ledger_entry_id = l.id
+		WHERE l.subscription_id = $1 AND l.kind = $2
+		ORDER BY l.id DESC LIMIT 1
+	`
+
+	getInvoice = `
+		SELECT i.id, printf('INV-%06d', i.number), i.user_id, i.ledger_entry_id, l.kind, l.description, l.period_start, l.period_end,
+			i.subtotal, i.tax_name, i.tax_rate, i.tax, i.total, i.currency, i.issued_at
+		FROM invoices i
+		JOIN billing_ledger l ON l.id = i.ledger_entry_id
+		WHERE i.id = $1
+	`
+
+	getBoostPurchaseUser = `
+		SELECT user_id FROM boost_purchases WHERE id = $1
+	`
+
+	getSubscriptionByProviderID = `
+		SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE provider_subscription_id = $1 LIMIT 1
+	`
+
+	getTOTP = `
+		SELECT secret, enabled FROM user_totp
+		WHERE user_id = $1 LIMIT 1
+	`
+
 	getUser = `
-		SELECT password, full_name, email, is_premium FROM users
+		SELECT id, password, full_name, email, ` + premiumSubscription + `, email_verified, role, account_status, status_reason, status_until,
+			EXISTS (SELECT 1 FROM user_totp WHERE user_id = users.id AND enabled) FROM users
 		WHERE username = $1 LIMIT 1
 	`
 
 	getUserByID = `
-		SELECT password, full_name, email, is_premium FROM users
+		SELECT username, password, full_name, email, ` + premiumSubscription + `, ` + subscriptionTier + `, email_verified, verified_at, is_incognito, role, account_status, status_reason, status_until,
+			EXISTS (SELECT 1 FROM user_totp WHERE user_id = users.id AND enabled) FROM users
 		WHERE id = $1 LIMIT 1
 	`
 
+	countProfileViewers = `
+		SELECT COUNT(DISTINCT v.viewer_id) FROM profile_views v
+		JOIN users u ON u.id = v.viewer_id
+		WHERE v.viewed_id = $1 AND v.viewed_at >= $2 AND NOT u.is_incognito AND u.account_status = $3 AND NOT u.is_hidden
+			AND NOT EXISTS (
+				SELECT 1 FROM blocks
+				WHERE (blocker_id = $1 AND blocked_id = v.viewer_id) OR (blocker_id = v.viewer_id AND blocked_id = $1)
+			)
+	`
+
+	// Likes the user has not answered with a swipe yet
+	countLikers = `
+		SELECT COUNT(1) FROM swipes s
+		JOIN users u ON u.id = s.swiper_id
+		WHERE s.swiped_id = $1 AND s.status IN ($2, $3) AND u.account_status = $4 AND NOT u.is_hidden
+			AND NOT EXISTS (SELECT 1 FROM swipes WHERE swiper_id = $1 AND swiped_id = s.swiper_id)
+			AND NOT EXISTS (
+				SELECT 1 FROM blocks
+				WHERE (blocker_id = $1 AND blocked_id = s.swiper_id) OR (blocker_id = s.swiper_id AND blocked_id = $1)
+			)
+	`
+
+	// Super likes first, then the most recent likes
+	getLikers = `
+		SELECT s.swiper_id, u.full_name, s.status, s.created_at, s.updated_at FROM swipes s
+		JOIN users u ON u.id = s.swiper_id
+		WHERE s.swiped_id = $1 AND s.status IN ($2, $3) AND u.account_status = $4 AND NOT u.is_hidden
+			AND NOT EXISTS (SELECT 1 FROM swipes WHERE swiper_id = $1 AND swiped_id = s.swiper_id)
+			AND NOT EXISTS (
+				SELECT 1 FROM blocks
+				WHERE (blocker_id = $1 AND blocked_id = s.swiper_id) OR (blocker_id = s.swiper_id AND blocked_id = $1)
+			)
+		ORDER BY s.status DESC, COALESCE(s.updated_at, s.created_at) DESC LIMIT $5
+	`
+
+	// Latest view of every viewer, most recent first
+	getProfileViewers = `
+		SELECT v.viewer_id, u.full_name, v.viewed_at FROM profile_views v
+		JOIN users u ON u.id = v.viewer_id
+		WHERE v.viewed_id = $1 AND v.viewed_at >= $2 AND NOT u.is_incognito AND u.account_status = $3 AND NOT u.is_hidden
+			AND NOT EXISTS (
+				SELECT 1 FROM blocks
+				WHERE (blocker_id = $1 AND blocked_id = v.viewer_id) OR (blocker_id = v.viewer_id AND blocked_id = $1)
+			)
+			AND v.viewed_at = (
+				SELECT MAX(viewed_at) FROM profile_views
+				WHERE viewer_id = v.viewer_id AND viewed_id = v.viewed_id
+			)
+		ORDER BY v.viewed_at DESC LIMIT $4
+	`
+
+	getAccountStatusHistory = `
+		SELECT id, status, reason, until, actor_id, created_at FROM account_status_history
+		WHERE user_id = $1
+		ORDER BY id DESC
+	`
+
+	searchUsers = `
+		SELECT id, username, full_name, email, ` + premiumSubscription + `, role, account_status FROM users
+		WHERE username LIKE $1 OR email LIKE $1 OR full_name LIKE $1
+		ORDER BY id LIMIT $2
+	`
+
+	getSwipeStats = `
+		SELECT
+			(SELECT COUNT(1) FROM swipes WHERE swiper_id = $1 AND status IN ($2, $3)),
+			(SELECT COUNT(1) FROM swipes WHERE swiper_id = $1 AND status = $4),
+			(SELECT COUNT(1) FROM swipes WHERE swiped_id = $1 AND status IN ($2, $3)),
+			(SELECT COUNT(1) FROM swipes s
+				WHERE s.swiper_id = $1 AND s.status IN ($2, $3) AND EXISTS (
+					SELECT 1 FROM swipes WHERE swiper_id = s.swiped_id AND swiped_id = $1 AND status IN ($2, $3)
+				))
+	`
+
+	getReport = `
+		SELECT reporter_id, reported_id, reason, detail, status, assignee_id, resolution, created_at, updated_at
+		FROM reports WHERE id = $1
+	`
+
+	// Oldest reports first, so the queue is worked in order
+	getReports = `
+		SELECT id, reporter_id, reported_id, reason, detail, status, assignee_id, resolution, created_at, updated_at
+		FROM reports WHERE status = $1
+		ORDER BY id LIMIT $2
+	`
+
+	countPendingReporters = `
+		SELECT COUNT(DISTINCT reporter_id) FROM reports
+		WHERE reported_id = $1 AND status <> $2
+	`
+
+	// Users who super liked the viewer come first. Users swiped on already
+	// are left out here, the cache only saves a trip for the latest swipes
 	getRelatedUserBasedOnID = `
-		SELECT id, full_name, email, is_premium FROM users
-		WHERE id <> $1
+		SELECT id, full_name, email, ` + premiumSubscription + `, EXISTS (
+			SELECT 1 FROM swipes WHERE swiper_id = users.id AND swiped_id = $1 AND status = $2
+		) AS super_liked FROM users
+		WHERE id <> $1 AND NOT is_hidden AND account_status = $3 AND NOT EXISTS (
+			SELECT 1 FROM blocks
+			WHERE (blocker_id = $1 AND blocked_id = users.id) OR (blocker_id = users.id AND blocked_id = $1)
+		) AND NOT EXISTS (
+			SELECT 1 FROM swipes WHERE swiper_id = $1 AND swiped_id = users.id
+		)
+		ORDER BY super_liked DESC, id
 	`
 )
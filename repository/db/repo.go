@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/egnptr/dating-app/model"
 )
@@ -10,8 +11,93 @@ import (
 type Repo interface {
 	GetUser(ctx context.Context, username string) (*model.User, error)
 	GetUserByID(ctx context.Context, userID int64) (*model.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
 	GetRelatedUser(ctx context.Context, id int64) ([]model.User, error)
+	GetActiveSubscription(ctx context.Context, userID int64, at time.Time) (*model.Subscription, error)
+	GetSubscriptionByProviderID(ctx context.Context, providerSubscriptionID string) (*model.Subscription, error)
+	GetPremiumEnd(ctx context.Context, userID int64, at time.Time) (time.Time, error)
+	HasPaidSubscription(ctx context.Context, userID int64) (bool, error)
+	GetPromoCode(ctx context.Context, code string) (*model.PromoCode, error)
+	GetPendingDiscount(ctx context.Context, userID int64) (*model.PromoCode, error)
+	GetReferrerByCode(ctx context.Context, code string) (int64, error)
+	GetReferral(ctx context.Context, refereeID int64) (*model.Referral, error)
+	CountReferrals(ctx context.Context, referrerID int64) (referred, rewarded int64, err error)
+	GetTOTP(ctx context.Context, userID int64) (*model.TOTP, error)
+	GetIdentity(ctx context.Context, provider, subject string) (*model.Identity, error)
+	IsMatch(ctx context.Context, userID, otherUserID int64) (bool, error)
+	IsBlocked(ctx context.Context, userID, otherUserID int64) (bool, error)
+	GetSwipe(ctx context.Context, swiperID, swipedID int64) (*model.Swipe, error)
+	GetConversation(ctx context.Context, conversationID int64) (*model.Conversation, error)
+	GetConversations(ctx context.Context, userID int64) ([]model.Conversation, error)
+	GetMessages(ctx context.Context, conversationID, beforeID int64, limit int) ([]model.Message, error)
+	GetMessage(ctx context.Context, messageID int64) (*model.Message, error)
+	CountProfileViewers(ctx context.Context, userID int64, since time.Time) (int64, error)
+	GetProfileViewers(ctx context.Context, userID int64, since time.Time, limit int) ([]model.ProfileViewer, error)
+	CountLikers(ctx context.Context, userID int64) (int64, error)
+	GetLikers(ctx context.Context, userID int64, limit int) ([]model.Liker, error)
+	GetReport(ctx context.Context, reportID int64) (*model.Report, error)
+	GetReports(ctx context.Context, status string, limit int) ([]model.Report, error)
+	CountPendingReporters(ctx context.Context, userID int64) (int, error)
+	SearchUsers(ctx context.Context, query string, limit int) ([]model.User, error)
+	GetSwipeStats(ctx context.Context, userID int64) (*model.SwipeStats, error)
+	GetAccountStatusHistory(ctx context.Context, userID int64) ([]model.AccountStatusChange, error)
+	GetLapsedSubscriptions(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error)
+	GetDueRenewals(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error)
+	GetDunningSubscriptions(ctx context.Context, at, notifiedBefore time.Time, limit int) ([]model.Subscription, error)
+	GetGraceEndedSubscriptions(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error)
+	GetLedgerEntries(ctx context.Context, userID, beforeID int64, limit int) ([]model.LedgerEntry, error)
+	GetLastLedgerEntry(ctx context.Context, subscriptionID int64, kind string) (*model.LedgerEntry, error)
+	GetInvoice(ctx context.Context, invoiceID int64) (*model.Invoice, error)
+	CountBoosts(ctx context.Context, userID int64, source string, since time.Time) (int64, error)
+	GetActiveBoost(ctx context.Context, userID int64, at time.Time) (*model.Boost, error)
+	GetBoostBalance(ctx context.Context, userID int64) (int64, error)
+	GetPhoto(ctx context.Context, photoID int64) (*model.Photo, error)
+	GetPhotos(ctx context.Context, userID int64) ([]model.Photo, error)
+	GetPhotosByUserIDs(ctx context.Context, userIDs []int64) (map[int64][]model.Photo, error)
 
-	CreateUser(ctx context.Context, req model.User) (err error)
-	UpdatePremiumStatus(ctx context.Context, req model.SubscribeRequest) (err error)
+	CreateUser(ctx context.Context, req model.User) (userID int64, err error)
+	InsertSubscription(ctx context.Context, req model.Subscription) (subscriptionID int64, err error)
+	UpdateSubscription(ctx context.Context, req model.Subscription) (err error)
+	UpdateDunningNotified(ctx context.Context, subscriptionID int64, at time.Time) (err error)
+	InsertLedgerEntry(ctx context.Context, entry model.LedgerEntry, invoice *model.Invoice) (inserted bool, err error)
+	InsertPaymentEvent(ctx context.Context, req model.PaymentEvent) (inserted bool, err error)
+	DeletePaymentEvent(ctx context.Context, eventID string) (err error)
+	InsertPromoCode(ctx context.Context, req model.PromoCode) (promoCodeID int64, err error)
+	RedeemPromoCode(ctx context.Context, req model.PromoRedemption) (err error)
+	UnredeemPromoCode(ctx context.Context, req model.PromoRedemption) (err error)
+	ApplyPendingDiscounts(ctx context.Context, userID int64, at time.Time) (err error)
+	GetOrCreateReferralCode(ctx context.Context, userID int64, code string) (referralCode string, err error)
+	InsertReferral(ctx context.Context, req model.Referral) (inserted bool, err error)
+	RewardReferral(ctx context.Context, refereeID int64, at time.Time) (rewarded bool, err error)
+	InsertBoost(ctx context.Context, req model.Boost) (boostID int64, err error)
+	DeleteBoost(ctx context.Context, req model.Boost) (err error)
+	CreditBoostPurchase(ctx context.Context, req model.BoostPurchase) (userID int64, err error)
+	InsertPhoto(ctx context.Context, req model.Photo, limit int) (photoID int64, err error)
+	UpdatePhotoOrder(ctx context.Context, userID int64, photoIDs []int64) (err error)
+	UpdatePrimaryPhoto(ctx context.Context, userID, photoID int64) (err error)
+	DeletePhoto(ctx context.Context, userID, photoID int64) (err error)
+	UpdatePassword(ctx context.Context, userID int64, hashedPassword string) (err error)
+	UpdateEmail(ctx context.Context, userID int64, email string) (err error)
+	UpdateEmailVerified(ctx context.Context, userID int64, email string) (err error)
+	InsertAuditLog(ctx context.Context, req model.AuditLog) (err error)
+	UpsertTOTPSecret(ctx context.Context, userID int64, secret string) (err error)
+	EnableTOTP(ctx context.Context, userID int64, recoveryCodeHashes []string) (err error)
+	UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (err error)
+	UseTOTPStep(ctx context.Context, userID int64, step int64) (err error)
+	InsertIdentity(ctx context.Context, req model.Identity) (err error)
+	UpsertSwipe(ctx context.Context, req model.SwipeRequest) (err error)
+	DeleteSwipe(ctx context.Context, swiperID, swipedID int64) (err error)
+	GetOrCreateConversation(ctx context.Context, userID, otherUserID int64) (conversation *model.Conversation, err error)
+	InsertMessage(ctx context.Context, req model.Message) (messageID int64, err error)
+	DeleteMessage(ctx context.Context, messageID, senderID int64) (err error)
+	UpdateLastRead(ctx context.Context, conversationID, userID, messageID int64) (err error)
+	UpdateIncognito(ctx context.Context, userID int64, incognito bool) (err error)
+	InsertProfileViews(ctx context.Context, views []model.ProfileView) (err error)
+	InsertBlock(ctx context.Context, blockerID, blockedID int64) (err error)
+	DeleteBlock(ctx context.Context, blockerID, blockedID int64) (err error)
+	InsertReport(ctx context.Context, req model.Report) (reportID int64, err error)
+	UpdateReportStatus(ctx context.Context, req model.Report, fromStatus string) (err error)
+	UpdateHidden(ctx context.Context, userID int64, hidden bool) (err error)
+	UpdateRole(ctx context.Context, userID int64, role string) (err error)
+	UpdateAccountStatus(ctx context.Context, req model.AccountStatusChange) (err error)
 }
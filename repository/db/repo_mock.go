@@ -7,6 +7,7 @@ import (
 	"context"
 	"github.com/egnptr/dating-app/model"
 	"sync"
+	"time"
 )
 
 // Ensure, that RepoMock does implement Repo.
@@ -19,20 +20,275 @@ var _ Repo = &RepoMock{}
 //
 //		// make and configure a mocked Repo
 //		mockedRepo := &RepoMock{
-//			CreateUserFunc: func(ctx context.Context, req model.User) error {
+//			ApplyPendingDiscountsFunc: func(ctx context.Context, userID int64, at time.Time) error {
+//				panic("mock out the ApplyPendingDiscounts method")
+//			},
+//			CountBoostsFunc: func(ctx context.Context, userID int64, source string, since time.Time) (int64, error) {
+//				panic("mock out the CountBoosts method")
+//			},
+//			CountLikersFunc: func(ctx context.Context, userID int64) (int64, error) {
+//				panic("mock out the CountLikers method")
+//			},
+//			CountPendingReportersFunc: func(ctx context.Context, userID int64) (int, error) {
+//				panic("mock out the CountPendingReporters method")
+//			},
+//			CountProfileViewersFunc: func(ctx context.Context, userID int64, since time.Time) (int64, error) {
+//				panic("mock out the CountProfileViewers method")
+//			},
+//			CountReferralsFunc: func(ctx context.Context, referrerID int64) (int64, int64, error) {
+//				panic("mock out the CountReferrals method")
+//			},
+//			CreateUserFunc: func(ctx context.Context, req model.User) (int64, error) {
 //				panic("mock out the CreateUser method")
 //			},
+//			CreditBoostPurchaseFunc: func(ctx context.Context, req model.BoostPurchase) (int64, error) {
+//				panic("mock out the CreditBoostPurchase method")
+//			},
+//			DeleteBlockFunc: func(ctx context.Context, blockerID int64, blockedID int64) error {
+//				panic("mock out the DeleteBlock method")
+//			},
+//			DeleteBoostFunc: func(ctx context.Context, req model.Boost) error {
+//				panic("mock out the DeleteBoost method")
+//			},
+//			DeleteMessageFunc: func(ctx context.Context, messageID int64, senderID int64) error {
+//				panic("mock out the DeleteMessage method")
+//			},
+//			DeletePaymentEventFunc: func(ctx context.Context, eventID string) error {
+//				panic("mock out the DeletePaymentEvent method")
+//			},
+//			DeletePhotoFunc: func(ctx context.Context, userID int64, photoID int64) error {
+//				panic("mock out the DeletePhoto method")
+//			},
+//			DeleteSwipeFunc: func(ctx context.Context, swiperID int64, swipedID int64) error {
+//				panic("mock out the DeleteSwipe method")
+//			},
+//			EnableTOTPFunc: func(ctx context.Context, userID int64, recoveryCodeHashes []string) error {
+//				panic("mock out the EnableTOTP method")
+//			},
+//			GetAccountStatusHistoryFunc: func(ctx context.Context, userID int64) ([]model.AccountStatusChange, error) {
+//				panic("mock out the GetAccountStatusHistory method")
+//			},
+//			GetActiveBoostFunc: func(ctx context.Context, userID int64, at time.Time) (*model.Boost, error) {
+//				panic("mock out the GetActiveBoost method")
+//			},
+//			GetActiveSubscriptionFunc: func(ctx context.Context, userID int64, at time.Time) (*model.Subscription, error) {
+//				panic("mock out the GetActiveSubscription method")
+//			},
+//			GetBoostBalanceFunc: func(ctx context.Context, userID int64) (int64, error) {
+//				panic("mock out the GetBoostBalance method")
+//			},
+//			GetConversationFunc: func(ctx context.Context, conversationID int64) (*model.Conversation, error) {
+//				panic("mock out the GetConversation method")
+//			},
+//			GetConversationsFunc: func(ctx context.Context, userID int64) ([]model.Conversation, error) {
+//				panic("mock out the GetConversations method")
+//			},
+//			GetDueRenewalsFunc: func(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error) {
+//				panic("mock out the GetDueRenewals method")
+//			},
+//			GetDunningSubscriptionsFunc: func(ctx context.Context, at time.Time, notifiedBefore time.Time, limit int) ([]model.Subscription, error) {
+//				panic("mock out the GetDunningSubscriptions method")
+//			},
+//			GetGraceEndedSubscriptionsFunc: func(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error) {
+//				panic("mock out the GetGraceEndedSubscriptions method")
+//			},
+//			GetIdentityFunc: func(ctx context.Context, provider string, subject string) (*model.Identity, error) {
+//				panic("mock out the GetIdentity method")
+//			},
+//			GetInvoiceFunc: func(ctx context.Context, invoiceID int64) (*model.Invoice, error) {
+//				panic("mock out the GetInvoice method")
+//			},
+//			GetLapsedSubscriptionsFunc: func(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error) {
+//				panic("mock out the GetLapsedSubscriptions method")
+//			},
+//			GetLastLedgerEntryFunc: func(ctx context.Context, subscriptionID int64, kind string) (*model.LedgerEntry, error) {
+//				panic("mock out the GetLastLedgerEntry method")
+//			},
+//			GetLedgerEntriesFunc: func(ctx context.Context, userID int64, beforeID int64, limit int) ([]model.LedgerEntry, error) {
+//				panic("mock out the GetLedgerEntries method")
+//			},
+//			GetLikersFunc: func(ctx context.Context, userID int64, limit int) ([]model.Liker, error) {
+//				panic("mock out the GetLikers method")
+//			},
+//			GetMessageFunc: func(ctx context.Context, messageID int64) (*model.Message, error) {
+//				panic("mock out the GetMessage method")
+//			},
+//			GetMessagesFunc: func(ctx context.Context, conversationID int64, beforeID int64, limit int) ([]model.Message, error) {
+//				panic("mock out the GetMessages method")
+//			},
+//			GetOrCreateConversationFunc: func(ctx context.Context, userID int64, otherUserID int64) (*model.Conversation, error) {
+//				panic("mock out the GetOrCreateConversation method")
+//			},
+//			GetOrCreateReferralCodeFunc: func(ctx context.Context, userID int64, code string) (string, error) {
+//				panic("mock out the GetOrCreateReferralCode method")
+//			},
+//			GetPendingDiscountFunc: func(ctx context.Context, userID int64) (*model.PromoCode, error) {
+//				panic("mock out the GetPendingDiscount method")
+//			},
+//			GetPhotoFunc: func(ctx context.Context, photoID int64) (*model.Photo, error) {
+//				panic("mock out the GetPhoto method")
+//			},
+//			GetPhotosFunc: func(ctx context.Context, userID int64) ([]model.Photo, error) {
+//				panic("mock out the GetPhotos method")
+//			},
+//			GetPhotosByUserIDsFunc: func(ctx context.Context, userIDs []int64) (map[int64][]model.Photo, error) {
+//				panic("mock out the GetPhotosByUserIDs method")
+//			},
+//			GetPremiumEndFunc: func(ctx context.Context, userID int64, at time.Time) (time.Time, error) {
+//				panic("mock out the GetPremiumEnd method")
+//			},
+//			GetProfileViewersFunc: func(ctx context.Context, userID int64, since time.Time, limit int) ([]model.ProfileViewer, error) {
+//				panic("mock out the GetProfileViewers method")
+//			},
+//			GetPromoCodeFunc: func(ctx context.Context, code string) (*model.PromoCode, error) {
+//				panic("mock out the GetPromoCode method")
+//			},
+//			GetReferralFunc: func(ctx context.Context, refereeID int64) (*model.Referral, error) {
+//				panic("mock out the GetReferral method")
+//			},
+//			GetReferrerByCodeFunc: func(ctx context.Context, code string) (int64, error) {
+//				panic("mock out the GetReferrerByCode method")
+//			},
 //			GetRelatedUserFunc: func(ctx context.Context, id int64) ([]model.User, error) {
 //				panic("mock out the GetRelatedUser method")
 //			},
+//			GetReportFunc: func(ctx context.Context, reportID int64) (*model.Report, error) {
+//				panic("mock out the GetReport method")
+//			},
+//			GetReportsFunc: func(ctx context.Context, status string, limit int) ([]model.Report, error) {
+//				panic("mock out the GetReports method")
+//			},
+//			GetSubscriptionByProviderIDFunc: func(ctx context.Context, providerSubscriptionID string) (*model.Subscription, error) {
+//				panic("mock out the GetSubscriptionByProviderID method")
+//			},
+//			GetSwipeFunc: func(ctx context.Context, swiperID int64, swipedID int64) (*model.Swipe, error) {
+//				panic("mock out the GetSwipe method")
+//			},
+//			GetSwipeStatsFunc: func(ctx context.Context, userID int64) (*model.SwipeStats, error) {
+//				panic("mock out the GetSwipeStats method")
+//			},
+//			GetTOTPFunc: func(ctx context.Context, userID int64) (*model.TOTP, error) {
+//				panic("mock out the GetTOTP method")
+//			},
 //			GetUserFunc: func(ctx context.Context, username string) (*model.User, error) {
 //				panic("mock out the GetUser method")
 //			},
+//			GetUserByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+//				panic("mock out the GetUserByEmail method")
+//			},
 //			GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
 //				panic("mock out the GetUserByID method")
 //			},
-//			UpdatePremiumStatusFunc: func(ctx context.Context, req model.SubscribeRequest) error {
-//				panic("mock out the UpdatePremiumStatus method")
+//			HasPaidSubscriptionFunc: func(ctx context.Context, userID int64) (bool, error) {
+//				panic("mock out the HasPaidSubscription method")
+//			},
+//			InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+//				panic("mock out the InsertAuditLog method")
+//			},
+//			InsertBlockFunc: func(ctx context.Context, blockerID int64, blockedID int64) error {
+//				panic("mock out the InsertBlock method")
+//			},
+//			InsertBoostFunc: func(ctx context.Context, req model.Boost) (int64, error) {
+//				panic("mock out the InsertBoost method")
+//			},
+//			InsertIdentityFunc: func(ctx context.Context, req model.Identity) error {
+//				panic("mock out the InsertIdentity method")
+//			},
+//			InsertLedgerEntryFunc: func(ctx context.Context, entry model.LedgerEntry, invoice *model.Invoice) (bool, error) {
+//				panic("mock out the InsertLedgerEntry method")
+//			},
+//			InsertMessageFunc: func(ctx context.Context, req model.Message) (int64, error) {
+//				panic("mock out the InsertMessage method")
+//			},
+//			InsertPaymentEventFunc: func(ctx context.Context, req model.PaymentEvent) (bool, error) {
+//				panic("mock out the InsertPaymentEvent method")
+//			},
+//			InsertPhotoFunc: func(ctx context.Context, req model.Photo, limit int) (int64, error) {
+//				panic("mock out the InsertPhoto method")
+//			},
+//			InsertProfileViewsFunc: func(ctx context.Context, views []model.ProfileView) error {
+//				panic("mock out the InsertProfileViews method")
+//			},
+//			InsertPromoCodeFunc: func(ctx context.Context, req model.PromoCode) (int64, error) {
+//				panic("mock out the InsertPromoCode method")
+//			},
+//			InsertReferralFunc: func(ctx context.Context, req model.Referral) (bool, error) {
+//				panic("mock out the InsertReferral method")
+//			},
+//			InsertReportFunc: func(ctx context.Context, req model.Report) (int64, error) {
+//				panic("mock out the InsertReport method")
+//			},
+//			InsertSubscriptionFunc: func(ctx context.Context, req model.Subscription) (int64, error) {
+//				panic("mock out the InsertSubscription method")
+//			},
+//			IsBlockedFunc: func(ctx context.Context, userID int64, otherUserID int64) (bool, error) {
+//				panic("mock out the IsBlocked method")
+//			},
+//			IsMatchFunc: func(ctx context.Context, userID int64, otherUserID int64) (bool, error) {
+//				panic("mock out the IsMatch method")
+//			},
+//			RedeemPromoCodeFunc: func(ctx context.Context, req model.PromoRedemption) error {
+//				panic("mock out the RedeemPromoCode method")
+//			},
+//			RewardReferralFunc: func(ctx context.Context, refereeID int64, at time.Time) (bool, error) {
+//				panic("mock out the RewardReferral method")
+//			},
+//			SearchUsersFunc: func(ctx context.Context, query string, limit int) ([]model.User, error) {
+//				panic("mock out the SearchUsers method")
+//			},
+//			UnredeemPromoCodeFunc: func(ctx context.Context, req model.PromoRedemption) error {
+//				panic("mock out the UnredeemPromoCode method")
+//			},
+//			UpdateAccountStatusFunc: func(ctx context.Context, req model.AccountStatusChange) error {
+//				panic("mock out the UpdateAccountStatus method")
+//			},
+//			UpdateDunningNotifiedFunc: func(ctx context.Context, subscriptionID int64, at time.Time) error {
+//				panic("mock out the UpdateDunningNotified method")
+//			},
+//			UpdateEmailFunc: func(ctx context.Context, userID int64, email string) error {
+//				panic("mock out the UpdateEmail method")
+//			},
+//			UpdateEmailVerifiedFunc: func(ctx context.Context, userID int64, email string) error {
+//				panic("mock out the UpdateEmailVerified method")
+//			},
+//			UpdateHiddenFunc: func(ctx context.Context, userID int64, hidden bool) error {
+//				panic("mock out the UpdateHidden method")
+//			},
+//			UpdateIncognitoFunc: func(ctx context.Context, userID int64, incognito bool) error {
+//				panic("mock out the UpdateIncognito method")
+//			},
+//			UpdateLastReadFunc: func(ctx context.Context, conversationID int64, userID int64, messageID int64) error {
+//				panic("mock out the UpdateLastRead method")
+//			},
+//			UpdatePasswordFunc: func(ctx context.Context, userID int64, hashedPassword string) error {
+//				panic("mock out the UpdatePassword method")
+//			},
+//			UpdatePhotoOrderFunc: func(ctx context.Context, userID int64, photoIDs []int64) error {
+//				panic("mock out the UpdatePhotoOrder method")
+//			},
+//			UpdatePrimaryPhotoFunc: func(ctx context.Context, userID int64, photoID int64) error {
+//				panic("mock out the UpdatePrimaryPhoto method")
+//			},
+//			UpdateReportStatusFunc: func(ctx context.Context, req model.Report, fromStatus string) error {
+//				panic("mock out the UpdateReportStatus method")
+//			},
+//			UpdateRoleFunc: func(ctx context.Context, userID int64, role string) error {
+//				panic("mock out the UpdateRole method")
+//			},
+//			UpdateSubscriptionFunc: func(ctx context.Context, req model.Subscription) error {
+//				panic("mock out the UpdateSubscription method")
+//			},
+//			UpsertSwipeFunc: func(ctx context.Context, req model.SwipeRequest) error {
+//				panic("mock out the UpsertSwipe method")
+//			},
+//			UpsertTOTPSecretFunc: func(ctx context.Context, userID int64, secret string) error {
+//				panic("mock out the UpsertTOTPSecret method")
+//			},
+//			UseRecoveryCodeFunc: func(ctx context.Context, userID int64, codeHash string) error {
+//				panic("mock out the UseRecoveryCode method")
+//			},
+//			UseTOTPStepFunc: func(ctx context.Context, userID int64, step int64) error {
+//				panic("mock out the UseTOTPStep method")
 //			},
 //		}
 //
@@ -41,242 +297,4549 @@ var _ Repo = &RepoMock{}
 //
 //	}
 type RepoMock struct {
+	// ApplyPendingDiscountsFunc mocks the ApplyPendingDiscounts method.
+	ApplyPendingDiscountsFunc func(ctx context.Context, userID int64, at time.Time) error
+
+	// CountBoostsFunc mocks the CountBoosts method.
+	CountBoostsFunc func(ctx context.Context, userID int64, source string, since time.Time) (int64, error)
+
+	// CountLikersFunc mocks the CountLikers method.
+	CountLikersFunc func(ctx context.Context, userID int64) (int64, error)
+
+	// CountPendingReportersFunc mocks the CountPendingReporters method.
+	CountPendingReportersFunc func(ctx context.Context, userID int64) (int, error)
+
+	// CountProfileViewersFunc mocks the CountProfileViewers method.
+	CountProfileViewersFunc func(ctx context.Context, userID int64, since time.Time) (int64, error)
+
+	// CountReferralsFunc mocks the CountReferrals method.
+	CountReferralsFunc func(ctx context.Context, referrerID int64) (int64, int64, error)
+
 	// CreateUserFunc mocks the CreateUser method.
-	CreateUserFunc func(ctx context.Context, req model.User) error
+	CreateUserFunc func(ctx context.Context, req model.User) (int64, error)
+
+	// CreditBoostPurchaseFunc mocks the CreditBoostPurchase method.
+	CreditBoostPurchaseFunc func(ctx context.Context, req model.BoostPurchase) (int64, error)
+
+	// DeleteBlockFunc mocks the DeleteBlock method.
+	DeleteBlockFunc func(ctx context.Context, blockerID int64, blockedID int64) error
+
+	// DeleteBoostFunc mocks the DeleteBoost method.
+	DeleteBoostFunc func(ctx context.Context, req model.Boost) error
+
+	// DeleteMessageFunc mocks the DeleteMessage method.
+	DeleteMessageFunc func(ctx context.Context, messageID int64, senderID int64) error
+
+	// DeletePaymentEventFunc mocks the DeletePaymentEvent method.
+	DeletePaymentEventFunc func(ctx context.Context, eventID string) error
+
+	// DeletePhotoFunc mocks the DeletePhoto method.
+	DeletePhotoFunc func(ctx context.Context, userID int64, photoID int64) error
+
+	// DeleteSwipeFunc mocks the DeleteSwipe method.
+	DeleteSwipeFunc func(ctx context.Context, swiperID int64, swipedID int64) error
+
+	// EnableTOTPFunc mocks the EnableTOTP method.
+	EnableTOTPFunc func(ctx context.Context, userID int64, recoveryCodeHashes []string) error
+
+	// GetAccountStatusHistoryFunc mocks the GetAccountStatusHistory method.
+	GetAccountStatusHistoryFunc func(ctx context.Context, userID int64) ([]model.AccountStatusChange, error)
+
+	// GetActiveBoostFunc mocks the GetActiveBoost method.
+	GetActiveBoostFunc func(ctx context.Context, userID int64, at time.Time) (*model.Boost, error)
+
+	// GetActiveSubscriptionFunc mocks the GetActiveSubscription method.
+	GetActiveSubscriptionFunc func(ctx context.Context, userID int64, at time.Time) (*model.Subscription, error)
+
+	// GetBoostBalanceFunc mocks the GetBoostBalance method.
+	GetBoostBalanceFunc func(ctx context.Context, userID int64) (int64, error)
+
+	// GetConversationFunc mocks the GetConversation method.
+	GetConversationFunc func(ctx context.Context, conversationID int64) (*model.Conversation, error)
+
+	// GetConversationsFunc mocks the GetConversations method.
+	GetConversationsFunc func(ctx context.Context, userID int64) ([]model.Conversation, error)
+
+	// GetDueRenewalsFunc mocks the GetDueRenewals method.
+	GetDueRenewalsFunc func(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error)
+
+	// GetDunningSubscriptionsFunc mocks the GetDunningSubscriptions method.
+	GetDunningSubscriptionsFunc func(ctx context.Context, at time.Time, notifiedBefore time.Time, limit int) ([]model.Subscription, error)
+
+	// GetGraceEndedSubscriptionsFunc mocks the GetGraceEndedSubscriptions method.
+	GetGraceEndedSubscriptionsFunc func(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error)
+
+	// GetIdentityFunc mocks the GetIdentity method.
+	GetIdentityFunc func(ctx context.Context, provider string, subject string) (*model.Identity, error)
+
+	// GetInvoiceFunc mocks the GetInvoice method.
+	GetInvoiceFunc func(ctx context.Context, invoiceID int64) (*model.Invoice, error)
+
+	// GetLapsedSubscriptionsFunc mocks the GetLapsedSubscriptions method.
+	GetLapsedSubscriptionsFunc func(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error)
+
+	// GetLastLedgerEntryFunc mocks the GetLastLedgerEntry method.
+	GetLastLedgerEntryFunc func(ctx context.Context, subscriptionID int64, kind string) (*model.LedgerEntry, error)
+
+	// GetLedgerEntriesFunc mocks the GetLedgerEntries method.
+	GetLedgerEntriesFunc func(ctx context.Context, userID int64, beforeID int64, limit int) ([]model.LedgerEntry, error)
+
+	// GetLikersFunc mocks the GetLikers method.
+	GetLikersFunc func(ctx context.Context, userID int64, limit int) ([]model.Liker, error)
+
+	// GetMessageFunc mocks the GetMessage method.
+	GetMessageFunc func(ctx context.Context, messageID int64) (*model.Message, error)
+
+	// GetMessagesFunc mocks the GetMessages method.
+	GetMessagesFunc func(ctx context.Context, conversationID int64, beforeID int64, limit int) ([]model.Message, error)
+
+	// GetOrCreateConversationFunc mocks the GetOrCreateConversation method.
+	GetOrCreateConversationFunc func(ctx context.Context, userID int64, otherUserID int64) (*model.Conversation, error)
+
+	// GetOrCreateReferralCodeFunc mocks the GetOrCreateReferralCode method.
+	GetOrCreateReferralCodeFunc func(ctx context.Context, userID int64, code string) (string, error)
+
+	// GetPendingDiscountFunc mocks the GetPendingDiscount method.
+	GetPendingDiscountFunc func(ctx context.Context, userID int64) (*model.PromoCode, error)
+
+	// GetPhotoFunc mocks the GetPhoto method.
+	GetPhotoFunc func(ctx context.Context, photoID int64) (*model.Photo, error)
+
+	// GetPhotosFunc mocks the GetPhotos method.
+	GetPhotosFunc func(ctx context.Context, userID int64) ([]model.Photo, error)
+
+	// GetPhotosByUserIDsFunc mocks the GetPhotosByUserIDs method.
+	GetPhotosByUserIDsFunc func(ctx context.Context, userIDs []int64) (map[int64][]model.Photo, error)
+
+	// GetPremiumEndFunc mocks the GetPremiumEnd method.
+	GetPremiumEndFunc func(ctx context.Context, userID int64, at time.Time) (time.Time, error)
+
+	// GetProfileViewersFunc mocks the GetProfileViewers method.
+	GetProfileViewersFunc func(ctx context.Context, userID int64, since time.Time, limit int) ([]model.ProfileViewer, error)
+
+	// GetPromoCodeFunc mocks the GetPromoCode method.
+	GetPromoCodeFunc func(ctx context.Context, code string) (*model.PromoCode, error)
+
+	// GetReferralFunc mocks the GetReferral method.
+	GetReferralFunc func(ctx context.Context, refereeID int64) (*model.Referral, error)
+
+	// GetReferrerByCodeFunc mocks the GetReferrerByCode method.
+	GetReferrerByCodeFunc func(ctx context.Context, code string) (int64, error)
 
 	// GetRelatedUserFunc mocks the GetRelatedUser method.
 	GetRelatedUserFunc func(ctx context.Context, id int64) ([]model.User, error)
 
-	// GetUserFunc mocks the GetUser method.
-	GetUserFunc func(ctx context.Context, username string) (*model.User, error)
+	// GetReportFunc mocks the GetReport method.
+	GetReportFunc func(ctx context.Context, reportID int64) (*model.Report, error)
+
+	// GetReportsFunc mocks the GetReports method.
+	GetReportsFunc func(ctx context.Context, status string, limit int) ([]model.Report, error)
+
+	// GetSubscriptionByProviderIDFunc mocks the GetSubscriptionByProviderID method.
+	GetSubscriptionByProviderIDFunc func(ctx context.Context, providerSubscriptionID string) (*model.Subscription, error)
+
+	// GetSwipeFunc mocks the GetSwipe method.
+	GetSwipeFunc func(ctx context.Context, swiperID int64, swipedID int64) (*model.Swipe, error)
+
+	// GetSwipeStatsFunc mocks the GetSwipeStats method.
+	GetSwipeStatsFunc func(ctx context.Context, userID int64) (*model.SwipeStats, error)
+
+	// GetTOTPFunc mocks the GetTOTP method.
+	GetTOTPFunc func(ctx context.Context, userID int64) (*model.TOTP, error)
+
+	// GetUserFunc mocks the GetUser method.
+	GetUserFunc func(ctx context.Context, username string) (*model.User, error)
+
+	// GetUserByEmailFunc mocks the GetUserByEmail method.
+	GetUserByEmailFunc func(ctx context.Context, email string) (*model.User, error)
+
+	// GetUserByIDFunc mocks the GetUserByID method.
+	GetUserByIDFunc func(ctx context.Context, userID int64) (*model.User, error)
+
+	// HasPaidSubscriptionFunc mocks the HasPaidSubscription method.
+	HasPaidSubscriptionFunc func(ctx context.Context, userID int64) (bool, error)
+
+	// InsertAuditLogFunc mocks the InsertAuditLog method.
+	InsertAuditLogFunc func(ctx context.Context, req model.AuditLog) error
+
+	// InsertBlockFunc mocks the InsertBlock method.
+	InsertBlockFunc func(ctx context.Context, blockerID int64, blockedID int64) error
+
+	// InsertBoostFunc mocks the InsertBoost method.
+	InsertBoostFunc func(ctx context.Context, req model.Boost) (int64, error)
+
+	// InsertIdentityFunc mocks the InsertIdentity method.
+	InsertIdentityFunc func(ctx context.Context, req model.Identity) error
+
+	// InsertLedgerEntryFunc mocks the InsertLedgerEntry method.
+	InsertLedgerEntryFunc func(ctx context.Context, entry model.LedgerEntry, invoice *model.Invoice) (bool, error)
+
+	// InsertMessageFunc mocks the InsertMessage method.
+	InsertMessageFunc func(ctx context.Context, req model.Message) (int64, error)
+
+	// InsertPaymentEventFunc mocks the InsertPaymentEvent method.
+	InsertPaymentEventFunc func(ctx context.Context, req model.PaymentEvent) (bool, error)
+
+	// InsertPhotoFunc mocks the InsertPhoto method.
+	InsertPhotoFunc func(ctx context.Context, req model.Photo, limit int) (int64, error)
+
+	// InsertProfileViewsFunc mocks the InsertProfileViews method.
+	InsertProfileViewsFunc func(ctx context.Context, views []model.ProfileView) error
+
+	// InsertPromoCodeFunc mocks the InsertPromoCode method.
+	InsertPromoCodeFunc func(ctx context.Context, req model.PromoCode) (int64, error)
+
+	// InsertReferralFunc mocks the InsertReferral method.
+	InsertReferralFunc func(ctx context.Context, req model.Referral) (bool, error)
+
+	// InsertReportFunc mocks the InsertReport method.
+	InsertReportFunc func(ctx context.Context, req model.Report) (int64, error)
+
+	// InsertSubscriptionFunc mocks the InsertSubscription method.
+	InsertSubscriptionFunc func(ctx context.Context, req model.Subscription) (int64, error)
+
+	// IsBlockedFunc mocks the IsBlocked method.
+	IsBlockedFunc func(ctx context.Context, userID int64, otherUserID int64) (bool, error)
+
+	// IsMatchFunc mocks the IsMatch method.
+	IsMatchFunc func(ctx context.Context, userID int64, otherUserID int64) (bool, error)
+
+	// RedeemPromoCodeFunc mocks the RedeemPromoCode method.
+	RedeemPromoCodeFunc func(ctx context.Context, req model.PromoRedemption) error
+
+	// RewardReferralFunc mocks the RewardReferral method.
+	RewardReferralFunc func(ctx context.Context, refereeID int64, at time.Time) (bool, error)
+
+	// SearchUsersFunc mocks the SearchUsers method.
+	SearchUsersFunc func(ctx context.Context, query string, limit int) ([]model.User, error)
+
+	// UnredeemPromoCodeFunc mocks the UnredeemPromoCode method.
+	UnredeemPromoCodeFunc func(ctx context.Context, req model.PromoRedemption) error
+
+	// UpdateAccountStatusFunc mocks the UpdateAccountStatus method.
+	UpdateAccountStatusFunc func(ctx context.Context, req model.AccountStatusChange) error
+
+	// UpdateDunningNotifiedFunc mocks the UpdateDunningNotified method.
+	UpdateDunningNotifiedFunc func(ctx context.Context, subscriptionID int64, at time.Time) error
+
+	// UpdateEmailFunc mocks the UpdateEmail method.
+	UpdateEmailFunc func(ctx context.Context, userID int64, email string) error
+
+	// UpdateEmailVerifiedFunc mocks the UpdateEmailVerified method.
+	UpdateEmailVerifiedFunc func(ctx context.Context, userID int64, email string) error
+
+	// UpdateHiddenFunc mocks the UpdateHidden method.
+	UpdateHiddenFunc func(ctx context.Context, userID int64, hidden bool) error
+
+	// UpdateIncognitoFunc mocks the UpdateIncognito method.
+	UpdateIncognitoFunc func(ctx context.Context, userID int64, incognito bool) error
+
+	// UpdateLastReadFunc mocks the UpdateLastRead method.
+	UpdateLastReadFunc func(ctx context.Context, conversationID int64, userID int64, messageID int64) error
+
+	// UpdatePasswordFunc mocks the UpdatePassword method.
+	UpdatePasswordFunc func(ctx context.Context, userID int64, hashedPassword string) error
+
+	// UpdatePhotoOrderFunc mocks the UpdatePhotoOrder method.
+	UpdatePhotoOrderFunc func(ctx context.Context, userID int64, photoIDs []int64) error
+
+	// UpdatePrimaryPhotoFunc mocks the UpdatePrimaryPhoto method.
+	UpdatePrimaryPhotoFunc func(ctx context.Context, userID int64, photoID int64) error
+
+	// UpdateReportStatusFunc mocks the UpdateReportStatus method.
+	UpdateReportStatusFunc func(ctx context.Context, req model.Report, fromStatus string) error
+
+	// UpdateRoleFunc mocks the UpdateRole method.
+	UpdateRoleFunc func(ctx context.Context, userID int64, role string) error
+
+	// UpdateSubscriptionFunc mocks the UpdateSubscription method.
+	UpdateSubscriptionFunc func(ctx context.Context, req model.Subscription) error
+
+	// UpsertSwipeFunc mocks the UpsertSwipe method.
+	UpsertSwipeFunc func(ctx context.Context, req model.SwipeRequest) error
+
+	// UpsertTOTPSecretFunc mocks the UpsertTOTPSecret method.
+	UpsertTOTPSecretFunc func(ctx context.Context, userID int64, secret string) error
+
+	// UseRecoveryCodeFunc mocks the UseRecoveryCode method.
+	UseRecoveryCodeFunc func(ctx context.Context, userID int64, codeHash string) error
+
+	// UseTOTPStepFunc mocks the UseTOTPStep method.
+	UseTOTPStepFunc func(ctx context.Context, userID int64, step int64) error
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ApplyPendingDiscounts holds details about calls to the ApplyPendingDiscounts method.
+		ApplyPendingDiscounts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// At is the at argument value.
+			At time.Time
+		}
+		// CountBoosts holds details about calls to the CountBoosts method.
+		CountBoosts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Source is the source argument value.
+			Source string
+			// Since is the since argument value.
+			Since time.Time
+		}
+		// CountLikers holds details about calls to the CountLikers method.
+		CountLikers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// CountPendingReporters holds details about calls to the CountPendingReporters method.
+		CountPendingReporters []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// CountProfileViewers holds details about calls to the CountProfileViewers method.
+		CountProfileViewers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Since is the since argument value.
+			Since time.Time
+		}
+		// CountReferrals holds details about calls to the CountReferrals method.
+		CountReferrals []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ReferrerID is the referrerID argument value.
+			ReferrerID int64
+		}
+		// CreateUser holds details about calls to the CreateUser method.
+		CreateUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.User
+		}
+		// CreditBoostPurchase holds details about calls to the CreditBoostPurchase method.
+		CreditBoostPurchase []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.BoostPurchase
+		}
+		// DeleteBlock holds details about calls to the DeleteBlock method.
+		DeleteBlock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BlockerID is the blockerID argument value.
+			BlockerID int64
+			// BlockedID is the blockedID argument value.
+			BlockedID int64
+		}
+		// DeleteBoost holds details about calls to the DeleteBoost method.
+		DeleteBoost []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.Boost
+		}
+		// DeleteMessage holds details about calls to the DeleteMessage method.
+		DeleteMessage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// MessageID is the messageID argument value.
+			MessageID int64
+			// SenderID is the senderID argument value.
+			SenderID int64
+		}
+		// DeletePaymentEvent holds details about calls to the DeletePaymentEvent method.
+		DeletePaymentEvent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// EventID is the eventID argument value.
+			EventID string
+		}
+		// DeletePhoto holds details about calls to the DeletePhoto method.
+		DeletePhoto []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// PhotoID is the photoID argument value.
+			PhotoID int64
+		}
+		// DeleteSwipe holds details about calls to the DeleteSwipe method.
+		DeleteSwipe []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// SwiperID is the swiperID argument value.
+			SwiperID int64
+			// SwipedID is the swipedID argument value.
+			SwipedID int64
+		}
+		// EnableTOTP holds details about calls to the EnableTOTP method.
+		EnableTOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// RecoveryCodeHashes is the recoveryCodeHashes argument value.
+			RecoveryCodeHashes []string
+		}
+		// GetAccountStatusHistory holds details about calls to the GetAccountStatusHistory method.
+		GetAccountStatusHistory []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetActiveBoost holds details about calls to the GetActiveBoost method.
+		GetActiveBoost []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// At is the at argument value.
+			At time.Time
+		}
+		// GetActiveSubscription holds details about calls to the GetActiveSubscription method.
+		GetActiveSubscription []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// At is the at argument value.
+			At time.Time
+		}
+		// GetBoostBalance holds details about calls to the GetBoostBalance method.
+		GetBoostBalance []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetConversation holds details about calls to the GetConversation method.
+		GetConversation []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ConversationID is the conversationID argument value.
+			ConversationID int64
+		}
+		// GetConversations holds details about calls to the GetConversations method.
+		GetConversations []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetDueRenewals holds details about calls to the GetDueRenewals method.
+		GetDueRenewals []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// At is the at argument value.
+			At time.Time
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetDunningSubscriptions holds details about calls to the GetDunningSubscriptions method.
+		GetDunningSubscriptions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// At is the at argument value.
+			At time.Time
+			// NotifiedBefore is the notifiedBefore argument value.
+			NotifiedBefore time.Time
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetGraceEndedSubscriptions holds details about calls to the GetGraceEndedSubscriptions method.
+		GetGraceEndedSubscriptions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// At is the at argument value.
+			At time.Time
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetIdentity holds details about calls to the GetIdentity method.
+		GetIdentity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Provider is the provider argument value.
+			Provider string
+			// Subject is the subject argument value.
+			Subject string
+		}
+		// GetInvoice holds details about calls to the GetInvoice method.
+		GetInvoice []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// InvoiceID is the invoiceID argument value.
+			InvoiceID int64
+		}
+		// GetLapsedSubscriptions holds details about calls to the GetLapsedSubscriptions method.
+		GetLapsedSubscriptions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// At is the at argument value.
+			At time.Time
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetLastLedgerEntry holds details about calls to the GetLastLedgerEntry method.
+		GetLastLedgerEntry []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// SubscriptionID is the subscriptionID argument value.
+			SubscriptionID int64
+			// Kind is the kind argument value.
+			Kind string
+		}
+		// GetLedgerEntries holds details about calls to the GetLedgerEntries method.
+		GetLedgerEntries []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// BeforeID is the beforeID argument value.
+			BeforeID int64
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetLikers holds details about calls to the GetLikers method.
+		GetLikers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetMessage holds details about calls to the GetMessage method.
+		GetMessage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// MessageID is the messageID argument value.
+			MessageID int64
+		}
+		// GetMessages holds details about calls to the GetMessages method.
+		GetMessages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ConversationID is the conversationID argument value.
+			ConversationID int64
+			// BeforeID is the beforeID argument value.
+			BeforeID int64
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetOrCreateConversation holds details about calls to the GetOrCreateConversation method.
+		GetOrCreateConversation []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// OtherUserID is the otherUserID argument value.
+			OtherUserID int64
+		}
+		// GetOrCreateReferralCode holds details about calls to the GetOrCreateReferralCode method.
+		GetOrCreateReferralCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Code is the code argument value.
+			Code string
+		}
+		// GetPendingDiscount holds details about calls to the GetPendingDiscount method.
+		GetPendingDiscount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetPhoto holds details about calls to the GetPhoto method.
+		GetPhoto []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// PhotoID is the photoID argument value.
+			PhotoID int64
+		}
+		// GetPhotos holds details about calls to the GetPhotos method.
+		GetPhotos []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetPhotosByUserIDs holds details about calls to the GetPhotosByUserIDs method.
+		GetPhotosByUserIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserIDs is the userIDs argument value.
+			UserIDs []int64
+		}
+		// GetPremiumEnd holds details about calls to the GetPremiumEnd method.
+		GetPremiumEnd []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// At is the at argument value.
+			At time.Time
+		}
+		// GetProfileViewers holds details about calls to the GetProfileViewers method.
+		GetProfileViewers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Since is the since argument value.
+			Since time.Time
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetPromoCode holds details about calls to the GetPromoCode method.
+		GetPromoCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Code is the code argument value.
+			Code string
+		}
+		// GetReferral holds details about calls to the GetReferral method.
+		GetReferral []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RefereeID is the refereeID argument value.
+			RefereeID int64
+		}
+		// GetReferrerByCode holds details about calls to the GetReferrerByCode method.
+		GetReferrerByCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Code is the code argument value.
+			Code string
+		}
+		// GetRelatedUser holds details about calls to the GetRelatedUser method.
+		GetRelatedUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID int64
+		}
+		// GetReport holds details about calls to the GetReport method.
+		GetReport []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ReportID is the reportID argument value.
+			ReportID int64
+		}
+		// GetReports holds details about calls to the GetReports method.
+		GetReports []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Status is the status argument value.
+			Status string
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetSubscriptionByProviderID holds details about calls to the GetSubscriptionByProviderID method.
+		GetSubscriptionByProviderID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ProviderSubscriptionID is the providerSubscriptionID argument value.
+			ProviderSubscriptionID string
+		}
+		// GetSwipe holds details about calls to the GetSwipe method.
+		GetSwipe []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// SwiperID is the swiperID argument value.
+			SwiperID int64
+			// SwipedID is the swipedID argument value.
+			SwipedID int64
+		}
+		// GetSwipeStats holds details about calls to the GetSwipeStats method.
+		GetSwipeStats []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetTOTP holds details about calls to the GetTOTP method.
+		GetTOTP []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetUser holds details about calls to the GetUser method.
+		GetUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Username is the username argument value.
+			Username string
+		}
+		// GetUserByEmail holds details about calls to the GetUserByEmail method.
+		GetUserByEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Email is the email argument value.
+			Email string
+		}
+		// GetUserByID holds details about calls to the GetUserByID method.
+		GetUserByID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// HasPaidSubscription holds details about calls to the HasPaidSubscription method.
+		HasPaidSubscription []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// InsertAuditLog holds details about calls to the InsertAuditLog method.
+		InsertAuditLog []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.AuditLog
+		}
+		// InsertBlock holds details about calls to the InsertBlock method.
+		InsertBlock []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BlockerID is the blockerID argument value.
+			BlockerID int64
+			// BlockedID is the blockedID argument value.
+			BlockedID int64
+		}
+		// InsertBoost holds details about calls to the InsertBoost method.
+		InsertBoost []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.Boost
+		}
+		// InsertIdentity holds details about calls to the InsertIdentity method.
+		InsertIdentity []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.Identity
+		}
+		// InsertLedgerEntry holds details about calls to the InsertLedgerEntry method.
+		InsertLedgerEntry []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Entry is the entry argument value.
+			Entry model.LedgerEntry
+			// Invoice is the invoice argument value.
+			Invoice *model.Invoice
+		}
+		// InsertMessage holds details about calls to the InsertMessage method.
+		InsertMessage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.Message
+		}
+		// InsertPaymentEvent holds details about calls to the InsertPaymentEvent method.
+		InsertPaymentEvent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.PaymentEvent
+		}
+		// InsertPhoto holds details about calls to the InsertPhoto method.
+		InsertPhoto []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.Photo
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// InsertProfileViews holds details about calls to the InsertProfileViews method.
+		InsertProfileViews []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Views is the views argument value.
+			Views []model.ProfileView
+		}
+		// InsertPromoCode holds details about calls to the InsertPromoCode method.
+		InsertPromoCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.PromoCode
+		}
+		// InsertReferral holds details about calls to the InsertReferral method.
+		InsertReferral []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.Referral
+		}
+		// InsertReport holds details about calls to the InsertReport method.
+		InsertReport []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.Report
+		}
+		// InsertSubscription holds details about calls to the InsertSubscription method.
+		InsertSubscription []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.Subscription
+		}
+		// IsBlocked holds details about calls to the IsBlocked method.
+		IsBlocked []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// OtherUserID is the otherUserID argument value.
+			OtherUserID int64
+		}
+		// IsMatch holds details about calls to the IsMatch method.
+		IsMatch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// OtherUserID is the otherUserID argument value.
+			OtherUserID int64
+		}
+		// RedeemPromoCode holds details about calls to the RedeemPromoCode method.
+		RedeemPromoCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.PromoRedemption
+		}
+		// RewardReferral holds details about calls to the RewardReferral method.
+		RewardReferral []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// RefereeID is the refereeID argument value.
+			RefereeID int64
+			// At is the at argument value.
+			At time.Time
+		}
+		// SearchUsers holds details about calls to the SearchUsers method.
+		SearchUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Query is the query argument value.
+			Query string
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// UnredeemPromoCode holds details about calls to the UnredeemPromoCode method.
+		UnredeemPromoCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.PromoRedemption
+		}
+		// UpdateAccountStatus holds details about calls to the UpdateAccountStatus method.
+		UpdateAccountStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.AccountStatusChange
+		}
+		// UpdateDunningNotified holds details about calls to the UpdateDunningNotified method.
+		UpdateDunningNotified []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// SubscriptionID is the subscriptionID argument value.
+			SubscriptionID int64
+			// At is the at argument value.
+			At time.Time
+		}
+		// UpdateEmail holds details about calls to the UpdateEmail method.
+		UpdateEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Email is the email argument value.
+			Email string
+		}
+		// UpdateEmailVerified holds details about calls to the UpdateEmailVerified method.
+		UpdateEmailVerified []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Email is the email argument value.
+			Email string
+		}
+		// UpdateHidden holds details about calls to the UpdateHidden method.
+		UpdateHidden []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Hidden is the hidden argument value.
+			Hidden bool
+		}
+		// UpdateIncognito holds details about calls to the UpdateIncognito method.
+		UpdateIncognito []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Incognito is the incognito argument value.
+			Incognito bool
+		}
+		// UpdateLastRead holds details about calls to the UpdateLastRead method.
+		UpdateLastRead []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ConversationID is the conversationID argument value.
+			ConversationID int64
+			// UserID is the userID argument value.
+			UserID int64
+			// MessageID is the messageID argument value.
+			MessageID int64
+		}
+		// UpdatePassword holds details about calls to the UpdatePassword method.
+		UpdatePassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// HashedPassword is the hashedPassword argument value.
+			HashedPassword string
+		}
+		// UpdatePhotoOrder holds details about calls to the UpdatePhotoOrder method.
+		UpdatePhotoOrder []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// PhotoIDs is the photoIDs argument value.
+			PhotoIDs []int64
+		}
+		// UpdatePrimaryPhoto holds details about calls to the UpdatePrimaryPhoto method.
+		UpdatePrimaryPhoto []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// PhotoID is the photoID argument value.
+			PhotoID int64
+		}
+		// UpdateReportStatus holds details about calls to the UpdateReportStatus method.
+		UpdateReportStatus []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.Report
+			// FromStatus is the fromStatus argument value.
+			FromStatus string
+		}
+		// UpdateRole holds details about calls to the UpdateRole method.
+		UpdateRole []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Role is the role argument value.
+			Role string
+		}
+		// UpdateSubscription holds details about calls to the UpdateSubscription method.
+		UpdateSubscription []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.Subscription
+		}
+		// UpsertSwipe holds details about calls to the UpsertSwipe method.
+		UpsertSwipe []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.SwipeRequest
+		}
+		// UpsertTOTPSecret holds details about calls to the UpsertTOTPSecret method.
+		UpsertTOTPSecret []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Secret is the secret argument value.
+			Secret string
+		}
+		// UseRecoveryCode holds details about calls to the UseRecoveryCode method.
+		UseRecoveryCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// CodeHash is the codeHash argument value.
+			CodeHash string
+		}
+		// UseTOTPStep holds details about calls to the UseTOTPStep method.
+		UseTOTPStep []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Step is the step argument value.
+			Step int64
+		}
+	}
+	lockApplyPendingDiscounts       sync.RWMutex
+	lockCountBoosts                 sync.RWMutex
+	lockCountLikers                 sync.RWMutex
+	lockCountPendingReporters       sync.RWMutex
+	lockCountProfileViewers         sync.RWMutex
+	lockCountReferrals              sync.RWMutex
+	lockCreateUser                  sync.RWMutex
+	lockCreditBoostPurchase         sync.RWMutex
+	lockDeleteBlock                 sync.RWMutex
+	lockDeleteBoost                 sync.RWMutex
+	lockDeleteMessage               sync.RWMutex
+	lockDeletePaymentEvent          sync.RWMutex
+	lockDeletePhoto                 sync.RWMutex
+	lockDeleteSwipe                 sync.RWMutex
+	lockEnableTOTP                  sync.RWMutex
+	lockGetAccountStatusHistory     sync.RWMutex
+	lockGetActiveBoost              sync.RWMutex
+	lockGetActiveSubscription       sync.RWMutex
+	lockGetBoostBalance             sync.RWMutex
+	lockGetConversation             sync.RWMutex
+	lockGetConversations            sync.RWMutex
+	lockGetDueRenewals              sync.RWMutex
+	lockGetDunningSubscriptions     sync.RWMutex
+	lockGetGraceEndedSubscriptions  sync.RWMutex
+	lockGetIdentity                 sync.RWMutex
+	lockGetInvoice                  sync.RWMutex
+	lockGetLapsedSubscriptions      sync.RWMutex
+	lockGetLastLedgerEntry          sync.RWMutex
+	lockGetLedgerEntries            sync.RWMutex
+	lockGetLikers                   sync.RWMutex
+	lockGetMessage                  sync.RWMutex
+	lockGetMessages                 sync.RWMutex
+	lockGetOrCreateConversation     sync.RWMutex
+	lockGetOrCreateReferralCode     sync.RWMutex
+	lockGetPendingDiscount          sync.RWMutex
+	lockGetPhoto                    sync.RWMutex
+	lockGetPhotos                   sync.RWMutex
+	lockGetPhotosByUserIDs          sync.RWMutex
+	lockGetPremiumEnd               sync.RWMutex
+	lockGetProfileViewers           sync.RWMutex
+	lockGetPromoCode                sync.RWMutex
+	lockGetReferral                 sync.RWMutex
+	lockGetReferrerByCode           sync.RWMutex
+	lockGetRelatedUser              sync.RWMutex
+	lockGetReport                   sync.RWMutex
+	lockGetReports                  sync.RWMutex
+	lockGetSubscriptionByProviderID sync.RWMutex
+	lockGetSwipe                    sync.RWMutex
+	lockGetSwipeStats               sync.RWMutex
+	lockGetTOTP                     sync.RWMutex
+	lockGetUser                     sync.RWMutex
+	lockGetUserByEmail              sync.RWMutex
+	lockGetUserByID                 sync.RWMutex
+	lockHasPaidSubscription         sync.RWMutex
+	lockInsertAuditLog              sync.RWMutex
+	lockInsertBlock                 sync.RWMutex
+	lockInsertBoost                 sync.RWMutex
+	lockInsertIdentity              sync.RWMutex
+	lockInsertLedgerEntry           sync.RWMutex
+	lockInsertMessage               sync.RWMutex
+	lockInsertPaymentEvent          sync.RWMutex
+	lockInsertPhoto                 sync.RWMutex
+	lockInsertProfileViews          sync.RWMutex
+	lockInsertPromoCode             sync.RWMutex
+	lockInsertReferral              sync.RWMutex
+	lockInsertReport                sync.RWMutex
+	lockInsertSubscription          sync.RWMutex
+	lockIsBlocked                   sync.RWMutex
+	lockIsMatch                     sync.RWMutex
+	lockRedeemPromoCode             sync.RWMutex
+	lockRewardReferral              sync.RWMutex
+	lockSearchUsers                 sync.RWMutex
+	lockUnredeemPromoCode           sync.RWMutex
+	lockUpdateAccountStatus         sync.RWMutex
+	lockUpdateDunningNotified       sync.RWMutex
+	lockUpdateEmail                 sync.RWMutex
+	lockUpdateEmailVerified         sync.RWMutex
+	lockUpdateHidden                sync.RWMutex
+	lockUpdateIncognito             sync.RWMutex
+	lockUpdateLastRead              sync.RWMutex
+	lockUpdatePassword              sync.RWMutex
+	lockUpdatePhotoOrder            sync.RWMutex
+	lockUpdatePrimaryPhoto          sync.RWMutex
+	lockUpdateReportStatus          sync.RWMutex
+	lockUpdateRole                  sync.RWMutex
+	lockUpdateSubscription          sync.RWMutex
+	lockUpsertSwipe                 sync.RWMutex
+	lockUpsertTOTPSecret            sync.RWMutex
+	lockUseRecoveryCode             sync.RWMutex
+	lockUseTOTPStep                 sync.RWMutex
+}
+
+// ApplyPendingDiscounts calls ApplyPendingDiscountsFunc.
+func (mock *RepoMock) ApplyPendingDiscounts(ctx context.Context, userID int64, at time.Time) error {
+	if mock.ApplyPendingDiscountsFunc == nil {
+		panic("RepoMock.ApplyPendingDiscountsFunc: method is nil but Repo.ApplyPendingDiscounts was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+		At     time.Time
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		At:     at,
+	}
+	mock.lockApplyPendingDiscounts.Lock()
+	mock.calls.ApplyPendingDiscounts = append(mock.calls.ApplyPendingDiscounts, callInfo)
+	mock.lockApplyPendingDiscounts.Unlock()
+	return mock.ApplyPendingDiscountsFunc(ctx, userID, at)
+}
+
+// ApplyPendingDiscountsCalls gets all the calls that were made to ApplyPendingDiscounts.
+// Check the length with:
+//
+//	len(mockedRepo.ApplyPendingDiscountsCalls())
+func (mock *RepoMock) ApplyPendingDiscountsCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+	At     time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+		At     time.Time
+	}
+	mock.lockApplyPendingDiscounts.RLock()
+	calls = mock.calls.ApplyPendingDiscounts
+	mock.lockApplyPendingDiscounts.RUnlock()
+	return calls
+}
+
+// CountBoosts calls CountBoostsFunc.
+func (mock *RepoMock) CountBoosts(ctx context.Context, userID int64, source string, since time.Time) (int64, error) {
+	if mock.CountBoostsFunc == nil {
+		panic("RepoMock.CountBoostsFunc: method is nil but Repo.CountBoosts was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+		Source string
+		Since  time.Time
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Source: source,
+		Since:  since,
+	}
+	mock.lockCountBoosts.Lock()
+	mock.calls.CountBoosts = append(mock.calls.CountBoosts, callInfo)
+	mock.lockCountBoosts.Unlock()
+	return mock.CountBoostsFunc(ctx, userID, source, since)
+}
+
+// CountBoostsCalls gets all the calls that were made to CountBoosts.
+// Check the length with:
+//
+//	len(mockedRepo.CountBoostsCalls())
+func (mock *RepoMock) CountBoostsCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+	Source string
+	Since  time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+		Source string
+		Since  time.Time
+	}
+	mock.lockCountBoosts.RLock()
+	calls = mock.calls.CountBoosts
+	mock.lockCountBoosts.RUnlock()
+	return calls
+}
+
+// CountLikers calls CountLikersFunc.
+func (mock *RepoMock) CountLikers(ctx context.Context, userID int64) (int64, error) {
+	if mock.CountLikersFunc == nil {
+		panic("RepoMock.CountLikersFunc: method is nil but Repo.CountLikers was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockCountLikers.Lock()
+	mock.calls.CountLikers = append(mock.calls.CountLikers, callInfo)
+	mock.lockCountLikers.Unlock()
+	return mock.CountLikersFunc(ctx, userID)
+}
+
+// CountLikersCalls gets all the calls that were made to CountLikers.
+// Check the length with:
+//
+//	len(mockedRepo.CountLikersCalls())
+func (mock *RepoMock) CountLikersCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockCountLikers.RLock()
+	calls = mock.calls.CountLikers
+	mock.lockCountLikers.RUnlock()
+	return calls
+}
+
+// CountPendingReporters calls CountPendingReportersFunc.
+func (mock *RepoMock) CountPendingReporters(ctx context.Context, userID int64) (int, error) {
+	if mock.CountPendingReportersFunc == nil {
+		panic("RepoMock.CountPendingReportersFunc: method is nil but Repo.CountPendingReporters was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockCountPendingReporters.Lock()
+	mock.calls.CountPendingReporters = append(mock.calls.CountPendingReporters, callInfo)
+	mock.lockCountPendingReporters.Unlock()
+	return mock.CountPendingReportersFunc(ctx, userID)
+}
+
+// CountPendingReportersCalls gets all the calls that were made to CountPendingReporters.
+// Check the length with:
+//
+//	len(mockedRepo.CountPendingReportersCalls())
+func (mock *RepoMock) CountPendingReportersCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockCountPendingReporters.RLock()
+	calls = mock.calls.CountPendingReporters
+	mock.lockCountPendingReporters.RUnlock()
+	return calls
+}
+
+// CountProfileViewers calls CountProfileViewersFunc.
+func (mock *RepoMock) CountProfileViewers(ctx context.Context, userID int64, since time.Time) (int64, error) {
+	if mock.CountProfileViewersFunc == nil {
+		panic("RepoMock.CountProfileViewersFunc: method is nil but Repo.CountProfileViewers was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+		Since  time.Time
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Since:  since,
+	}
+	mock.lockCountProfileViewers.Lock()
+	mock.calls.CountProfileViewers = append(mock.calls.CountProfileViewers, callInfo)
+	mock.lockCountProfileViewers.Unlock()
+	return mock.CountProfileViewersFunc(ctx, userID, since)
+}
+
+// CountProfileViewersCalls gets all the calls that were made to CountProfileViewers.
+// Check the length with:
+//
+//	len(mockedRepo.CountProfileViewersCalls())
+func (mock *RepoMock) CountProfileViewersCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+	Since  time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+		Since  time.Time
+	}
+	mock.lockCountProfileViewers.RLock()
+	calls = mock.calls.CountProfileViewers
+	mock.lockCountProfileViewers.RUnlock()
+	return calls
+}
+
+// CountReferrals calls CountReferralsFunc.
+func (mock *RepoMock) CountReferrals(ctx context.Context, referrerID int64) (int64, int64, error) {
+	if mock.CountReferralsFunc == nil {
+		panic("RepoMock.CountReferralsFunc: method is nil but Repo.CountReferrals was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		ReferrerID int64
+	}{
+		Ctx:        ctx,
+		ReferrerID: referrerID,
+	}
+	mock.lockCountReferrals.Lock()
+	mock.calls.CountReferrals = append(mock.calls.CountReferrals, callInfo)
+	mock.lockCountReferrals.Unlock()
+	return mock.CountReferralsFunc(ctx, referrerID)
+}
+
+// CountReferralsCalls gets all the calls that were made to CountReferrals.
+// Check the length with:
+//
+//	len(mockedRepo.CountReferralsCalls())
+func (mock *RepoMock) CountReferralsCalls() []struct {
+	Ctx        context.Context
+	ReferrerID int64
+} {
+	var calls []struct {
+		Ctx        context.Context
+		ReferrerID int64
+	}
+	mock.lockCountReferrals.RLock()
+	calls = mock.calls.CountReferrals
+	mock.lockCountReferrals.RUnlock()
+	return calls
+}
+
+// CreateUser calls CreateUserFunc.
+func (mock *RepoMock) CreateUser(ctx context.Context, req model.User) (int64, error) {
+	if mock.CreateUserFunc == nil {
+		panic("RepoMock.CreateUserFunc: method is nil but Repo.CreateUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.User
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockCreateUser.Lock()
+	mock.calls.CreateUser = append(mock.calls.CreateUser, callInfo)
+	mock.lockCreateUser.Unlock()
+	return mock.CreateUserFunc(ctx, req)
+}
+
+// CreateUserCalls gets all the calls that were made to CreateUser.
+// Check the length with:
+//
+//	len(mockedRepo.CreateUserCalls())
+func (mock *RepoMock) CreateUserCalls() []struct {
+	Ctx context.Context
+	Req model.User
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.User
+	}
+	mock.lockCreateUser.RLock()
+	calls = mock.calls.CreateUser
+	mock.lockCreateUser.RUnlock()
+	return calls
+}
+
+// CreditBoostPurchase calls CreditBoostPurchaseFunc.
+func (mock *RepoMock) CreditBoostPurchase(ctx context.Context, req model.BoostPurchase) (int64, error) {
+	if mock.CreditBoostPurchaseFunc == nil {
+		panic("RepoMock.CreditBoostPurchaseFunc: method is nil but Repo.CreditBoostPurchase was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.BoostPurchase
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockCreditBoostPurchase.Lock()
+	mock.calls.CreditBoostPurchase = append(mock.calls.CreditBoostPurchase, callInfo)
+	mock.lockCreditBoostPurchase.Unlock()
+	return mock.CreditBoostPurchaseFunc(ctx, req)
+}
+
+// CreditBoostPurchaseCalls gets all the calls that were made to CreditBoostPurchase.
+// Check the length with:
+//
+//	len(mockedRepo.CreditBoostPurchaseCalls())
+func (mock *RepoMock) CreditBoostPurchaseCalls() []struct {
+	Ctx context.Context
+	Req model.BoostPurchase
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.BoostPurchase
+	}
+	mock.lockCreditBoostPurchase.RLock()
+	calls = mock.calls.CreditBoostPurchase
+	mock.lockCreditBoostPurchase.RUnlock()
+	return calls
+}
+
+// DeleteBlock calls DeleteBlockFunc.
+func (mock *RepoMock) DeleteBlock(ctx context.Context, blockerID int64, blockedID int64) error {
+	if mock.DeleteBlockFunc == nil {
+		panic("RepoMock.DeleteBlockFunc: method is nil but Repo.DeleteBlock was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		BlockerID int64
+		BlockedID int64
+	}{
+		Ctx:       ctx,
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+	}
+	mock.lockDeleteBlock.Lock()
+	mock.calls.DeleteBlock = append(mock.calls.DeleteBlock, callInfo)
+	mock.lockDeleteBlock.Unlock()
+	return mock.DeleteBlockFunc(ctx, blockerID, blockedID)
+}
+
+// DeleteBlockCalls gets all the calls that were made to DeleteBlock.
+// Check the length with:
+//
+//	len(mockedRepo.DeleteBlockCalls())
+func (mock *RepoMock) DeleteBlockCalls() []struct {
+	Ctx       context.Context
+	BlockerID int64
+	BlockedID int64
+} {
+	var calls []struct {
+		Ctx       context.Context
+		BlockerID int64
+		BlockedID int64
+	}
+	mock.lockDeleteBlock.RLock()
+	calls = mock.calls.DeleteBlock
+	mock.lockDeleteBlock.RUnlock()
+	return calls
+}
+
+// DeleteBoost calls DeleteBoostFunc.
+func (mock *RepoMock) DeleteBoost(ctx context.Context, req model.Boost) error {
+	if mock.DeleteBoostFunc == nil {
+		panic("RepoMock.DeleteBoostFunc: method is nil but Repo.DeleteBoost was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.Boost
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockDeleteBoost.Lock()
+	mock.calls.DeleteBoost = append(mock.calls.DeleteBoost, callInfo)
+	mock.lockDeleteBoost.Unlock()
+	return mock.DeleteBoostFunc(ctx, req)
+}
+
+// DeleteBoostCalls gets all the calls that were made to DeleteBoost.
+// Check the length with:
+//
+//	len(mockedRepo.DeleteBoostCalls())
+func (mock *RepoMock) DeleteBoostCalls() []struct {
+	Ctx context.Context
+	Req model.Boost
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.Boost
+	}
+	mock.lockDeleteBoost.RLock()
+	calls = mock.calls.DeleteBoost
+	mock.lockDeleteBoost.RUnlock()
+	return calls
+}
+
+// DeleteMessage calls DeleteMessageFunc.
+func (mock *RepoMock) DeleteMessage(ctx context.Context, messageID int64, senderID int64) error {
+	if mock.DeleteMessageFunc == nil {
+		panic("RepoMock.DeleteMessageFunc: method is nil but Repo.DeleteMessage was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		MessageID int64
+		SenderID  int64
+	}{
+		Ctx:       ctx,
+		MessageID: messageID,
+		SenderID:  senderID,
+	}
+	mock.lockDeleteMessage.Lock()
+	mock.calls.DeleteMessage = append(mock.calls.DeleteMessage, callInfo)
+	mock.lockDeleteMessage.Unlock()
+	return mock.DeleteMessageFunc(ctx, messageID, senderID)
+}
+
+// DeleteMessageCalls gets all the calls that were made to DeleteMessage.
+// Check the length with:
+//
+//	len(mockedRepo.DeleteMessageCalls())
+func (mock *RepoMock) DeleteMessageCalls() []struct {
+	Ctx       context.Context
+	MessageID int64
+	SenderID  int64
+} {
+	var calls []struct {
+		Ctx       context.Context
+		MessageID int64
+		SenderID  int64
+	}
+	mock.lockDeleteMessage.RLock()
+	calls = mock.calls.DeleteMessage
+	mock.lockDeleteMessage.RUnlock()
+	return calls
+}
+
+// DeletePaymentEvent calls DeletePaymentEventFunc.
+func (mock *RepoMock) DeletePaymentEvent(ctx context.Context, eventID string) error {
+	if mock.DeletePaymentEventFunc == nil {
+		panic("RepoMock.DeletePaymentEventFunc: method is nil but Repo.DeletePaymentEvent was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		EventID string
+	}{
+		Ctx:     ctx,
+		EventID: eventID,
+	}
+	mock.lockDeletePaymentEvent.Lock()
+	mock.calls.DeletePaymentEvent = append(mock.calls.DeletePaymentEvent, callInfo)
+	mock.lockDeletePaymentEvent.Unlock()
+	return mock.DeletePaymentEventFunc(ctx, eventID)
+}
+
+// DeletePaymentEventCalls gets all the calls that were made to DeletePaymentEvent.
+// Check the length with:
+//
+//	len(mockedRepo.DeletePaymentEventCalls())
+func (mock *RepoMock) DeletePaymentEventCalls() []struct {
+	Ctx     context.Context
+	EventID string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		EventID string
+	}
+	mock.lockDeletePaymentEvent.RLock()
+	calls = mock.calls.DeletePaymentEvent
+	mock.lockDeletePaymentEvent.RUnlock()
+	return calls
+}
+
+// DeletePhoto calls DeletePhotoFunc.
+func (mock *RepoMock) DeletePhoto(ctx context.Context, userID int64, photoID int64) error {
+	if mock.DeletePhotoFunc == nil {
+		panic("RepoMock.DeletePhotoFunc: method is nil but Repo.DeletePhoto was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		UserID  int64
+		PhotoID int64
+	}{
+		Ctx:     ctx,
+		UserID:  userID,
+		PhotoID: photoID,
+	}
+	mock.lockDeletePhoto.Lock()
+	mock.calls.DeletePhoto = append(mock.calls.DeletePhoto, callInfo)
+	mock.lockDeletePhoto.Unlock()
+	return mock.DeletePhotoFunc(ctx, userID, photoID)
+}
+
+// DeletePhotoCalls gets all the calls that were made to DeletePhoto.
+// Check the length with:
+//
+//	len(mockedRepo.DeletePhotoCalls())
+func (mock *RepoMock) DeletePhotoCalls() []struct {
+	Ctx     context.Context
+	UserID  int64
+	PhotoID int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		UserID  int64
+		PhotoID int64
+	}
+	mock.lockDeletePhoto.RLock()
+	calls = mock.calls.DeletePhoto
+	mock.lockDeletePhoto.RUnlock()
+	return calls
+}
+
+// DeleteSwipe calls DeleteSwipeFunc.
+func (mock *RepoMock) DeleteSwipe(ctx context.Context, swiperID int64, swipedID int64) error {
+	if mock.DeleteSwipeFunc == nil {
+		panic("RepoMock.DeleteSwipeFunc: method is nil but Repo.DeleteSwipe was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		SwiperID int64
+		SwipedID int64
+	}{
+		Ctx:      ctx,
+		SwiperID: swiperID,
+		SwipedID: swipedID,
+	}
+	mock.lockDeleteSwipe.Lock()
+	mock.calls.DeleteSwipe = append(mock.calls.DeleteSwipe, callInfo)
+	mock.lockDeleteSwipe.Unlock()
+	return mock.DeleteSwipeFunc(ctx, swiperID, swipedID)
+}
+
+// DeleteSwipeCalls gets all the calls that were made to DeleteSwipe.
+// Check the length with:
+//
+//	len(mockedRepo.DeleteSwipeCalls())
+func (mock *RepoMock) DeleteSwipeCalls() []struct {
+	Ctx      context.Context
+	SwiperID int64
+	SwipedID int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		SwiperID int64
+		SwipedID int64
+	}
+	mock.lockDeleteSwipe.RLock()
+	calls = mock.calls.DeleteSwipe
+	mock.lockDeleteSwipe.RUnlock()
+	return calls
+}
+
+// EnableTOTP calls EnableTOTPFunc.
+func (mock *RepoMock) EnableTOTP(ctx context.Context, userID int64, recoveryCodeHashes []string) error {
+	if mock.EnableTOTPFunc == nil {
+		panic("RepoMock.EnableTOTPFunc: method is nil but Repo.EnableTOTP was just called")
+	}
+	callInfo := struct {
+		Ctx                context.Context
+		UserID             int64
+		RecoveryCodeHashes []string
+	}{
+		Ctx:                ctx,
+		UserID:             userID,
+		RecoveryCodeHashes: recoveryCodeHashes,
+	}
+	mock.lockEnableTOTP.Lock()
+	mock.calls.EnableTOTP = append(mock.calls.EnableTOTP, callInfo)
+	mock.lockEnableTOTP.Unlock()
+	return mock.EnableTOTPFunc(ctx, userID, recoveryCodeHashes)
+}
+
+// EnableTOTPCalls gets all the calls that were made to EnableTOTP.
+// Check the length with:
+//
+//	len(mockedRepo.EnableTOTPCalls())
+func (mock *RepoMock) EnableTOTPCalls() []struct {
+	Ctx                context.Context
+	UserID             int64
+	RecoveryCodeHashes []string
+} {
+	var calls []struct {
+		Ctx                context.Context
+		UserID             int64
+		RecoveryCodeHashes []string
+	}
+	mock.lockEnableTOTP.RLock()
+	calls = mock.calls.EnableTOTP
+	mock.lockEnableTOTP.RUnlock()
+	return calls
+}
+
+// GetAccountStatusHistory calls GetAccountStatusHistoryFunc.
+func (mock *RepoMock) GetAccountStatusHistory(ctx context.Context, userID int64) ([]model.AccountStatusChange, error) {
+	if mock.GetAccountStatusHistoryFunc == nil {
+		panic("RepoMock.GetAccountStatusHistoryFunc: method is nil but Repo.GetAccountStatusHistory was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetAccountStatusHistory.Lock()
+	mock.calls.GetAccountStatusHistory = append(mock.calls.GetAccountStatusHistory, callInfo)
+	mock.lockGetAccountStatusHistory.Unlock()
+	return mock.GetAccountStatusHistoryFunc(ctx, userID)
+}
+
+// GetAccountStatusHistoryCalls gets all the calls that were made to GetAccountStatusHistory.
+// Check the length with:
+//
+//	len(mockedRepo.GetAccountStatusHistoryCalls())
+func (mock *RepoMock) GetAccountStatusHistoryCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetAccountStatusHistory.RLock()
+	calls = mock.calls.GetAccountStatusHistory
+	mock.lockGetAccountStatusHistory.RUnlock()
+	return calls
+}
+
+// GetActiveBoost calls GetActiveBoostFunc.
+func (mock *RepoMock) GetActiveBoost(ctx context.Context, userID int64, at time.Time) (*model.Boost, error) {
+	if mock.GetActiveBoostFunc == nil {
+		panic("RepoMock.GetActiveBoostFunc: method is nil but Repo.GetActiveBoost was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+		At     time.Time
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		At:     at,
+	}
+	mock.lockGetActiveBoost.Lock()
+	mock.calls.GetActiveBoost = append(mock.calls.GetActiveBoost, callInfo)
+	mock.lockGetActiveBoost.Unlock()
+	return mock.GetActiveBoostFunc(ctx, userID, at)
+}
+
+// GetActiveBoostCalls gets all the calls that were made to GetActiveBoost.
+// Check the length with:
+//
+//	len(mockedRepo.GetActiveBoostCalls())
+func (mock *RepoMock) GetActiveBoostCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+	At     time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+		At     time.Time
+	}
+	mock.lockGetActiveBoost.RLock()
+	calls = mock.calls.GetActiveBoost
+	mock.lockGetActiveBoost.RUnlock()
+	return calls
+}
+
+// GetActiveSubscription calls GetActiveSubscriptionFunc.
+func (mock *RepoMock) GetActiveSubscription(ctx context.Context, userID int64, at time.Time) (*model.Subscription, error) {
+	if mock.GetActiveSubscriptionFunc == nil {
+		panic("RepoMock.GetActiveSubscriptionFunc: method is nil but Repo.GetActiveSubscription was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+		At     time.Time
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		At:     at,
+	}
+	mock.lockGetActiveSubscription.Lock()
+	mock.calls.GetActiveSubscription = append(mock.calls.GetActiveSubscription, callInfo)
+	mock.lockGetActiveSubscription.Unlock()
+	return mock.GetActiveSubscriptionFunc(ctx, userID, at)
+}
+
+// GetActiveSubscriptionCalls gets all the calls that were made to GetActiveSubscription.
+// Check the length with:
+//
+//	len(mockedRepo.GetActiveSubscriptionCalls())
+func (mock *RepoMock) GetActiveSubscriptionCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+	At     time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+		At     time.Time
+	}
+	mock.lockGetActiveSubscription.RLock()
+	calls = mock.calls.GetActiveSubscription
+	mock.lockGetActiveSubscription.RUnlock()
+	return calls
+}
+
+// GetBoostBalance calls GetBoostBalanceFunc.
+func (mock *RepoMock) GetBoostBalance(ctx context.Context, userID int64) (int64, error) {
+	if mock.GetBoostBalanceFunc == nil {
+		panic("RepoMock.GetBoostBalanceFunc: method is nil but Repo.GetBoostBalance was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetBoostBalance.Lock()
+	mock.calls.GetBoostBalance = append(mock.calls.GetBoostBalance, callInfo)
+	mock.lockGetBoostBalance.Unlock()
+	return mock.GetBoostBalanceFunc(ctx, userID)
+}
+
+// GetBoostBalanceCalls gets all the calls that were made to GetBoostBalance.
+// Check the length with:
+//
+//	len(mockedRepo.GetBoostBalanceCalls())
+func (mock *RepoMock) GetBoostBalanceCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetBoostBalance.RLock()
+	calls = mock.calls.GetBoostBalance
+	mock.lockGetBoostBalance.RUnlock()
+	return calls
+}
+
+// GetConversation calls GetConversationFunc.
+func (mock *RepoMock) GetConversation(ctx context.Context, conversationID int64) (*model.Conversation, error) {
+	if mock.GetConversationFunc == nil {
+		panic("RepoMock.GetConversationFunc: method is nil but Repo.GetConversation was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		ConversationID int64
+	}{
+		Ctx:            ctx,
+		ConversationID: conversationID,
+	}
+	mock.lockGetConversation.Lock()
+	mock.calls.GetConversation = append(mock.calls.GetConversation, callInfo)
+	mock.lockGetConversation.Unlock()
+	return mock.GetConversationFunc(ctx, conversationID)
+}
+
+// GetConversationCalls gets all the calls that were made to GetConversation.
+// Check the length with:
+//
+//	len(mockedRepo.GetConversationCalls())
+func (mock *RepoMock) GetConversationCalls() []struct {
+	Ctx            context.Context
+	ConversationID int64
+} {
+	var calls []struct {
+		Ctx            context.Context
+		ConversationID int64
+	}
+	mock.lockGetConversation.RLock()
+	calls = mock.calls.GetConversation
+	mock.lockGetConversation.RUnlock()
+	return calls
+}
+
+// GetConversations calls GetConversationsFunc.
+func (mock *RepoMock) GetConversations(ctx context.Context, userID int64) ([]model.Conversation, error) {
+	if mock.GetConversationsFunc == nil {
+		panic("RepoMock.GetConversationsFunc: method is nil but Repo.GetConversations was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetConversations.Lock()
+	mock.calls.GetConversations = append(mock.calls.GetConversations, callInfo)
+	mock.lockGetConversations.Unlock()
+	return mock.GetConversationsFunc(ctx, userID)
+}
+
+// GetConversationsCalls gets all the calls that were made to GetConversations.
+// Check the length with:
+//
+//	len(mockedRepo.GetConversationsCalls())
+func (mock *RepoMock) GetConversationsCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetConversations.RLock()
+	calls = mock.calls.GetConversations
+	mock.lockGetConversations.RUnlock()
+	return calls
+}
+
+// GetDueRenewals calls GetDueRenewalsFunc.
+func (mock *RepoMock) GetDueRenewals(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error) {
+	if mock.GetDueRenewalsFunc == nil {
+		panic("RepoMock.GetDueRenewalsFunc: method is nil but Repo.GetDueRenewals was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		At    time.Time
+		Limit int
+	}{
+		Ctx:   ctx,
+		At:    at,
+		Limit: limit,
+	}
+	mock.lockGetDueRenewals.Lock()
+	mock.calls.GetDueRenewals = append(mock.calls.GetDueRenewals, callInfo)
+	mock.lockGetDueRenewals.Unlock()
+	return mock.GetDueRenewalsFunc(ctx, at, limit)
+}
+
+// GetDueRenewalsCalls gets all the calls that were made to GetDueRenewals.
+// Check the length with:
+//
+//	len(mockedRepo.GetDueRenewalsCalls())
+func (mock *RepoMock) GetDueRenewalsCalls() []struct {
+	Ctx   context.Context
+	At    time.Time
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		At    time.Time
+		Limit int
+	}
+	mock.lockGetDueRenewals.RLock()
+	calls = mock.calls.GetDueRenewals
+	mock.lockGetDueRenewals.RUnlock()
+	return calls
+}
+
+// GetDunningSubscriptions calls GetDunningSubscriptionsFunc.
+func (mock *RepoMock) GetDunningSubscriptions(ctx context.Context, at time.Time, notifiedBefore time.Time, limit int) ([]model.Subscription, error) {
+	if mock.GetDunningSubscriptionsFunc == nil {
+		panic("RepoMock.GetDunningSubscriptionsFunc: method is nil but Repo.GetDunningSubscriptions was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		At             time.Time
+		NotifiedBefore time.Time
+		Limit          int
+	}{
+		Ctx:            ctx,
+		At:             at,
+		NotifiedBefore: notifiedBefore,
+		Limit:          limit,
+	}
+	mock.lockGetDunningSubscriptions.Lock()
+	mock.calls.GetDunningSubscriptions = append(mock.calls.GetDunningSubscriptions, callInfo)
+	mock.lockGetDunningSubscriptions.Unlock()
+	return mock.GetDunningSubscriptionsFunc(ctx, at, notifiedBefore, limit)
+}
+
+// GetDunningSubscriptionsCalls gets all the calls that were made to GetDunningSubscriptions.
+// Check the length with:
+//
+//	len(mockedRepo.GetDunningSubscriptionsCalls())
+func (mock *RepoMock) GetDunningSubscriptionsCalls() []struct {
+	Ctx            context.Context
+	At             time.Time
+	NotifiedBefore time.Time
+	Limit          int
+} {
+	var calls []struct {
+		Ctx            context.Context
+		At             time.Time
+		NotifiedBefore time.Time
+		Limit          int
+	}
+	mock.lockGetDunningSubscriptions.RLock()
+	calls = mock.calls.GetDunningSubscriptions
+	mock.lockGetDunningSubscriptions.RUnlock()
+	return calls
+}
+
+// GetGraceEndedSubscriptions calls GetGraceEndedSubscriptionsFunc.
+func (mock *RepoMock) GetGraceEndedSubscriptions(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error) {
+	if mock.GetGraceEndedSubscriptionsFunc == nil {
+		panic("RepoMock.GetGraceEndedSubscriptionsFunc: method is nil but Repo.GetGraceEndedSubscriptions was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		At    time.Time
+		Limit int
+	}{
+		Ctx:   ctx,
+		At:    at,
+		Limit: limit,
+	}
+	mock.lockGetGraceEndedSubscriptions.Lock()
+	mock.calls.GetGraceEndedSubscriptions = append(mock.calls.GetGraceEndedSubscriptions, callInfo)
+	mock.lockGetGraceEndedSubscriptions.Unlock()
+	return mock.GetGraceEndedSubscriptionsFunc(ctx, at, limit)
+}
+
+// GetGraceEndedSubscriptionsCalls gets all the calls that were made to GetGraceEndedSubscriptions.
+// Check the length with:
+//
+//	len(mockedRepo.GetGraceEndedSubscriptionsCalls())
+func (mock *RepoMock) GetGraceEndedSubscriptionsCalls() []struct {
+	Ctx   context.Context
+	At    time.Time
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		At    time.Time
+		Limit int
+	}
+	mock.lockGetGraceEndedSubscriptions.RLock()
+	calls = mock.calls.GetGraceEndedSubscriptions
+	mock.lockGetGraceEndedSubscriptions.RUnlock()
+	return calls
+}
+
+// GetIdentity calls GetIdentityFunc.
+func (mock *RepoMock) GetIdentity(ctx context.Context, provider string, subject string) (*model.Identity, error) {
+	if mock.GetIdentityFunc == nil {
+		panic("RepoMock.GetIdentityFunc: method is nil but Repo.GetIdentity was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Provider string
+		Subject  string
+	}{
+		Ctx:      ctx,
+		Provider: provider,
+		Subject:  subject,
+	}
+	mock.lockGetIdentity.Lock()
+	mock.calls.GetIdentity = append(mock.calls.GetIdentity, callInfo)
+	mock.lockGetIdentity.Unlock()
+	return mock.GetIdentityFunc(ctx, provider, subject)
+}
+
+// GetIdentityCalls gets all the calls that were made to GetIdentity.
+// Check the length with:
+//
+//	len(mockedRepo.GetIdentityCalls())
+func (mock *RepoMock) GetIdentityCalls() []struct {
+	Ctx      context.Context
+	Provider string
+	Subject  string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Provider string
+		Subject  string
+	}
+	mock.lockGetIdentity.RLock()
+	calls = mock.calls.GetIdentity
+	mock.lockGetIdentity.RUnlock()
+	return calls
+}
+
+// GetInvoice calls GetInvoiceFunc.
+func (mock *RepoMock) GetInvoice(ctx context.Context, invoiceID int64) (*model.Invoice, error) {
+	if mock.GetInvoiceFunc == nil {
+		panic("RepoMock.GetInvoiceFunc: method is nil but Repo.GetInvoice was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		InvoiceID int64
+	}{
+		Ctx:       ctx,
+		InvoiceID: invoiceID,
+	}
+	mock.lockGetInvoice.Lock()
+	mock.calls.GetInvoice = append(mock.calls.GetInvoice, callInfo)
+	mock.lockGetInvoice.Unlock()
+	return mock.GetInvoiceFunc(ctx, invoiceID)
+}
+
+// GetInvoiceCalls gets all the calls that were made to GetInvoice.
+// Check the length with:
+//
+//	len(mockedRepo.GetInvoiceCalls())
+func (mock *RepoMock) GetInvoiceCalls() []struct {
+	Ctx       context.Context
+	InvoiceID int64
+} {
+	var calls []struct {
+		Ctx       context.Context
+		InvoiceID int64
+	}
+	mock.lockGetInvoice.RLock()
+	calls = mock.calls.GetInvoice
+	mock.lockGetInvoice.RUnlock()
+	return calls
+}
+
+// GetLapsedSubscriptions calls GetLapsedSubscriptionsFunc.
+func (mock *RepoMock) GetLapsedSubscriptions(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error) {
+	if mock.GetLapsedSubscriptionsFunc == nil {
+		panic("RepoMock.GetLapsedSubscriptionsFunc: method is nil but Repo.GetLapsedSubscriptions was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		At    time.Time
+		Limit int
+	}{
+		Ctx:   ctx,
+		At:    at,
+		Limit: limit,
+	}
+	mock.lockGetLapsedSubscriptions.Lock()
+	mock.calls.GetLapsedSubscriptions = append(mock.calls.GetLapsedSubscriptions, callInfo)
+	mock.lockGetLapsedSubscriptions.Unlock()
+	return mock.GetLapsedSubscriptionsFunc(ctx, at, limit)
+}
+
+// GetLapsedSubscriptionsCalls gets all the calls that were made to GetLapsedSubscriptions.
+// Check the length with:
+//
+//	len(mockedRepo.GetLapsedSubscriptionsCalls())
+func (mock *RepoMock) GetLapsedSubscriptionsCalls() []struct {
+	Ctx   context.Context
+	At    time.Time
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		At    time.Time
+		Limit int
+	}
+	mock.lockGetLapsedSubscriptions.RLock()
+	calls = mock.calls.GetLapsedSubscriptions
+	mock.lockGetLapsedSubscriptions.RUnlock()
+	return calls
+}
+
+// GetLastLedgerEntry calls GetLastLedgerEntryFunc.
+func (mock *RepoMock) GetLastLedgerEntry(ctx context.Context, subscriptionID int64, kind string) (*model.LedgerEntry, error) {
+	if mock.GetLastLedgerEntryFunc == nil {
+		panic("RepoMock.GetLastLedgerEntryFunc: method is nil but Repo.GetLastLedgerEntry was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		SubscriptionID int64
+		Kind           string
+	}{
+		Ctx:            ctx,
+		SubscriptionID: subscriptionID,
+		Kind:           kind,
+	}
+	mock.lockGetLastLedgerEntry.Lock()
+	mock.calls.GetLastLedgerEntry = append(mock.calls.GetLastLedgerEntry, callInfo)
+	mock.lockGetLastLedgerEntry.Unlock()
+	return mock.GetLastLedgerEntryFunc(ctx, subscriptionID, kind)
+}
+
+// GetLastLedgerEntryCalls gets all the calls that were made to GetLastLedgerEntry.
+// Check the length with:
+//
+//	len(mockedRepo.GetLastLedgerEntryCalls())
+func (mock *RepoMock) GetLastLedgerEntryCalls() []struct {
+	Ctx            context.Context
+	SubscriptionID int64
+	Kind           string
+} {
+	var calls []struct {
+		Ctx            context.Context
+		SubscriptionID int64
+		Kind           string
+	}
+	mock.lockGetLastLedgerEntry.RLock()
+	calls = mock.calls.GetLastLedgerEntry
+	mock.lockGetLastLedgerEntry.RUnlock()
+	return calls
+}
+
+// GetLedgerEntries calls GetLedgerEntriesFunc.
+func (mock *RepoMock) GetLedgerEntries(ctx context.Context, userID int64, beforeID int64, limit int) ([]model.LedgerEntry, error) {
+	if mock.GetLedgerEntriesFunc == nil {
+		panic("RepoMock.GetLedgerEntriesFunc: method is nil but Repo.GetLedgerEntries was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   int64
+		BeforeID int64
+		Limit    int
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		BeforeID: beforeID,
+		Limit:    limit,
+	}
+	mock.lockGetLedgerEntries.Lock()
+	mock.calls.GetLedgerEntries = append(mock.calls.GetLedgerEntries, callInfo)
+	mock.lockGetLedgerEntries.Unlock()
+	return mock.GetLedgerEntriesFunc(ctx, userID, beforeID, limit)
+}
+
+// GetLedgerEntriesCalls gets all the calls that were made to GetLedgerEntries.
+// Check the length with:
+//
+//	len(mockedRepo.GetLedgerEntriesCalls())
+func (mock *RepoMock) GetLedgerEntriesCalls() []struct {
+	Ctx      context.Context
+	UserID   int64
+	BeforeID int64
+	Limit    int
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   int64
+		BeforeID int64
+		Limit    int
+	}
+	mock.lockGetLedgerEntries.RLock()
+	calls = mock.calls.GetLedgerEntries
+	mock.lockGetLedgerEntries.RUnlock()
+	return calls
+}
+
+// GetLikers calls GetLikersFunc.
+func (mock *RepoMock) GetLikers(ctx context.Context, userID int64, limit int) ([]model.Liker, error) {
+	if mock.GetLikersFunc == nil {
+		panic("RepoMock.GetLikersFunc: method is nil but Repo.GetLikers was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+		Limit  int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Limit:  limit,
+	}
+	mock.lockGetLikers.Lock()
+	mock.calls.GetLikers = append(mock.calls.GetLikers, callInfo)
+	mock.lockGetLikers.Unlock()
+	return mock.GetLikersFunc(ctx, userID, limit)
+}
+
+// GetLikersCalls gets all the calls that were made to GetLikers.
+// Check the length with:
+//
+//	len(mockedRepo.GetLikersCalls())
+func (mock *RepoMock) GetLikersCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+		Limit  int
+	}
+	mock.lockGetLikers.RLock()
+	calls = mock.calls.GetLikers
+	mock.lockGetLikers.RUnlock()
+	return calls
+}
+
+// GetMessage calls GetMessageFunc.
+func (mock *RepoMock) GetMessage(ctx context.Context, messageID int64) (*model.Message, error) {
+	if mock.GetMessageFunc == nil {
+		panic("RepoMock.GetMessageFunc: method is nil but Repo.GetMessage was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		MessageID int64
+	}{
+		Ctx:       ctx,
+		MessageID: messageID,
+	}
+	mock.lockGetMessage.Lock()
+	mock.calls.GetMessage = append(mock.calls.GetMessage, callInfo)
+	mock.lockGetMessage.Unlock()
+	return mock.GetMessageFunc(ctx, messageID)
+}
+
+// GetMessageCalls gets all the calls that were made to GetMessage.
+// Check the length with:
+//
+//	len(mockedRepo.GetMessageCalls())
+func (mock *RepoMock) GetMessageCalls() []struct {
+	Ctx       context.Context
+	MessageID int64
+} {
+	var calls []struct {
+		Ctx       context.Context
+		MessageID int64
+	}
+	mock.lockGetMessage.RLock()
+	calls = mock.calls.GetMessage
+	mock.lockGetMessage.RUnlock()
+	return calls
+}
+
+// GetMessages calls GetMessagesFunc.
+func (mock *RepoMock) GetMessages(ctx context.Context, conversationID int64, beforeID int64, limit int) ([]model.Message, error) {
+	if mock.GetMessagesFunc == nil {
+		panic("RepoMock.GetMessagesFunc: method is nil but Repo.GetMessages was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		ConversationID int64
+		BeforeID       int64
+		Limit          int
+	}{
+		Ctx:            ctx,
+		ConversationID: conversationID,
+		BeforeID:       beforeID,
+		Limit:          limit,
+	}
+	mock.lockGetMessages.Lock()
+	mock.calls.GetMessages = append(mock.calls.GetMessages, callInfo)
+	mock.lockGetMessages.Unlock()
+	return mock.GetMessagesFunc(ctx, conversationID, beforeID, limit)
+}
+
+// GetMessagesCalls gets all the calls that were made to GetMessages.
+// Check the length with:
+//
+//	len(mockedRepo.GetMessagesCalls())
+func (mock *RepoMock) GetMessagesCalls() []struct {
+	Ctx            context.Context
+	ConversationID int64
+	BeforeID       int64
+	Limit          int
+} {
+	var calls []struct {
+		Ctx            context.Context
+		ConversationID int64
+		BeforeID       int64
+		Limit          int
+	}
+	mock.lockGetMessages.RLock()
+	calls = mock.calls.GetMessages
+	mock.lockGetMessages.RUnlock()
+	return calls
+}
+
+// GetOrCreateConversation calls GetOrCreateConversationFunc.
+func (mock *RepoMock) GetOrCreateConversation(ctx context.Context, userID int64, otherUserID int64) (*model.Conversation, error) {
+	if mock.GetOrCreateConversationFunc == nil {
+		panic("RepoMock.GetOrCreateConversationFunc: method is nil but Repo.GetOrCreateConversation was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		UserID      int64
+		OtherUserID int64
+	}{
+		Ctx:         ctx,
+		UserID:      userID,
+		OtherUserID: otherUserID,
+	}
+	mock.lockGetOrCreateConversation.Lock()
+	mock.calls.GetOrCreateConversation = append(mock.calls.GetOrCreateConversation, callInfo)
+	mock.lockGetOrCreateConversation.Unlock()
+	return mock.GetOrCreateConversationFunc(ctx, userID, otherUserID)
+}
+
+// GetOrCreateConversationCalls gets all the calls that were made to GetOrCreateConversation.
+// Check the length with:
+//
+//	len(mockedRepo.GetOrCreateConversationCalls())
+func (mock *RepoMock) GetOrCreateConversationCalls() []struct {
+	Ctx         context.Context
+	UserID      int64
+	OtherUserID int64
+} {
+	var calls []struct {
+		Ctx         context.Context
+		UserID      int64
+		OtherUserID int64
+	}
+	mock.lockGetOrCreateConversation.RLock()
+	calls = mock.calls.GetOrCreateConversation
+	mock.lockGetOrCreateConversation.RUnlock()
+	return calls
+}
+
+// GetOrCreateReferralCode calls GetOrCreateReferralCodeFunc.
+func (mock *RepoMock) GetOrCreateReferralCode(ctx context.Context, userID int64, code string) (string, error) {
+	if mock.GetOrCreateReferralCodeFunc == nil {
+		panic("RepoMock.GetOrCreateReferralCodeFunc: method is nil but Repo.GetOrCreateReferralCode was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+		Code   string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Code:   code,
+	}
+	mock.lockGetOrCreateReferralCode.Lock()
+	mock.calls.GetOrCreateReferralCode = append(mock.calls.GetOrCreateReferralCode, callInfo)
+	mock.lockGetOrCreateReferralCode.Unlock()
+	return mock.GetOrCreateReferralCodeFunc(ctx, userID, code)
+}
+
+// GetOrCreateReferralCodeCalls gets all the calls that were made to GetOrCreateReferralCode.
+// Check the length with:
+//
+//	len(mockedRepo.GetOrCreateReferralCodeCalls())
+func (mock *RepoMock) GetOrCreateReferralCodeCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+	Code   string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+		Code   string
+	}
+	mock.lockGetOrCreateReferralCode.RLock()
+	calls = mock.calls.GetOrCreateReferralCode
+	mock.lockGetOrCreateReferralCode.RUnlock()
+	return calls
+}
+
+// GetPendingDiscount calls GetPendingDiscountFunc.
+func (mock *RepoMock) GetPendingDiscount(ctx context.Context, userID int64) (*model.PromoCode, error) {
+	if mock.GetPendingDiscountFunc == nil {
+		panic("RepoMock.GetPendingDiscountFunc: method is nil but Repo.GetPendingDiscount was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetPendingDiscount.Lock()
+	mock.calls.GetPendingDiscount = append(mock.calls.GetPendingDiscount, callInfo)
+	mock.lockGetPendingDiscount.Unlock()
+	return mock.GetPendingDiscountFunc(ctx, userID)
+}
+
+// GetPendingDiscountCalls gets all the calls that were made to GetPendingDiscount.
+// Check the length with:
+//
+//	len(mockedRepo.GetPendingDiscountCalls())
+func (mock *RepoMock) GetPendingDiscountCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetPendingDiscount.RLock()
+	calls = mock.calls.GetPendingDiscount
+	mock.lockGetPendingDiscount.RUnlock()
+	return calls
+}
+
+// GetPhoto calls GetPhotoFunc.
+func (mock *RepoMock) GetPhoto(ctx context.Context, photoID int64) (*model.Photo, error) {
+	if mock.GetPhotoFunc == nil {
+		panic("RepoMock.GetPhotoFunc: method is nil but Repo.GetPhoto was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		PhotoID int64
+	}{
+		Ctx:     ctx,
+		PhotoID: photoID,
+	}
+	mock.lockGetPhoto.Lock()
+	mock.calls.GetPhoto = append(mock.calls.GetPhoto, callInfo)
+	mock.lockGetPhoto.Unlock()
+	return mock.GetPhotoFunc(ctx, photoID)
+}
+
+// GetPhotoCalls gets all the calls that were made to GetPhoto.
+// Check the length with:
+//
+//	len(mockedRepo.GetPhotoCalls())
+func (mock *RepoMock) GetPhotoCalls() []struct {
+	Ctx     context.Context
+	PhotoID int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		PhotoID int64
+	}
+	mock.lockGetPhoto.RLock()
+	calls = mock.calls.GetPhoto
+	mock.lockGetPhoto.RUnlock()
+	return calls
+}
+
+// GetPhotos calls GetPhotosFunc.
+func (mock *RepoMock) GetPhotos(ctx context.Context, userID int64) ([]model.Photo, error) {
+	if mock.GetPhotosFunc == nil {
+		panic("RepoMock.GetPhotosFunc: method is nil but Repo.GetPhotos was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetPhotos.Lock()
+	mock.calls.GetPhotos = append(mock.calls.GetPhotos, callInfo)
+	mock.lockGetPhotos.Unlock()
+	return mock.GetPhotosFunc(ctx, userID)
+}
+
+// GetPhotosCalls gets all the calls that were made to GetPhotos.
+// Check the length with:
+//
+//	len(mockedRepo.GetPhotosCalls())
+func (mock *RepoMock) GetPhotosCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetPhotos.RLock()
+	calls = mock.calls.GetPhotos
+	mock.lockGetPhotos.RUnlock()
+	return calls
+}
+
+// GetPhotosByUserIDs calls GetPhotosByUserIDsFunc.
+func (mock *RepoMock) GetPhotosByUserIDs(ctx context.Context, userIDs []int64) (map[int64][]model.Photo, error) {
+	if mock.GetPhotosByUserIDsFunc == nil {
+		panic("RepoMock.GetPhotosByUserIDsFunc: method is nil but Repo.GetPhotosByUserIDs was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		UserIDs []int64
+	}{
+		Ctx:     ctx,
+		UserIDs: userIDs,
+	}
+	mock.lockGetPhotosByUserIDs.Lock()
+	mock.calls.GetPhotosByUserIDs = append(mock.calls.GetPhotosByUserIDs, callInfo)
+	mock.lockGetPhotosByUserIDs.Unlock()
+	return mock.GetPhotosByUserIDsFunc(ctx, userIDs)
+}
+
+// GetPhotosByUserIDsCalls gets all the calls that were made to GetPhotosByUserIDs.
+// Check the length with:
+//
+//	len(mockedRepo.GetPhotosByUserIDsCalls())
+func (mock *RepoMock) GetPhotosByUserIDsCalls() []struct {
+	Ctx     context.Context
+	UserIDs []int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		UserIDs []int64
+	}
+	mock.lockGetPhotosByUserIDs.RLock()
+	calls = mock.calls.GetPhotosByUserIDs
+	mock.lockGetPhotosByUserIDs.RUnlock()
+	return calls
+}
+
+// GetPremiumEnd calls GetPremiumEndFunc.
+func (mock *RepoMock) GetPremiumEnd(ctx context.Context, userID int64, at time.Time) (time.Time, error) {
+	if mock.GetPremiumEndFunc == nil {
+		panic("RepoMock.GetPremiumEndFunc: method is nil but Repo.GetPremiumEnd was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+		At     time.Time
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		At:     at,
+	}
+	mock.lockGetPremiumEnd.Lock()
+	mock.calls.GetPremiumEnd = append(mock.calls.GetPremiumEnd, callInfo)
+	mock.lockGetPremiumEnd.Unlock()
+	return mock.GetPremiumEndFunc(ctx, userID, at)
+}
+
+// GetPremiumEndCalls gets all the calls that were made to GetPremiumEnd.
+// Check the length with:
+//
+//	len(mockedRepo.GetPremiumEndCalls())
+func (mock *RepoMock) GetPremiumEndCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+	At     time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+		At     time.Time
+	}
+	mock.lockGetPremiumEnd.RLock()
+	calls = mock.calls.GetPremiumEnd
+	mock.lockGetPremiumEnd.RUnlock()
+	return calls
+}
+
+// GetProfileViewers calls GetProfileViewersFunc.
+func (mock *RepoMock) GetProfileViewers(ctx context.Context, userID int64, since time.Time, limit int) ([]model.ProfileViewer, error) {
+	if mock.GetProfileViewersFunc == nil {
+		panic("RepoMock.GetProfileViewersFunc: method is nil but Repo.GetProfileViewers was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+		Since  time.Time
+		Limit  int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Since:  since,
+		Limit:  limit,
+	}
+	mock.lockGetProfileViewers.Lock()
+	mock.calls.GetProfileViewers = append(mock.calls.GetProfileViewers, callInfo)
+	mock.lockGetProfileViewers.Unlock()
+	return mock.GetProfileViewersFunc(ctx, userID, since, limit)
+}
+
+// GetProfileViewersCalls gets all the calls that were made to GetProfileViewers.
+// Check the length with:
+//
+//	len(mockedRepo.GetProfileViewersCalls())
+func (mock *RepoMock) GetProfileViewersCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+	Since  time.Time
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+		Since  time.Time
+		Limit  int
+	}
+	mock.lockGetProfileViewers.RLock()
+	calls = mock.calls.GetProfileViewers
+	mock.lockGetProfileViewers.RUnlock()
+	return calls
+}
+
+// GetPromoCode calls GetPromoCodeFunc.
+func (mock *RepoMock) GetPromoCode(ctx context.Context, code string) (*model.PromoCode, error) {
+	if mock.GetPromoCodeFunc == nil {
+		panic("RepoMock.GetPromoCodeFunc: method is nil but Repo.GetPromoCode was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Code string
+	}{
+		Ctx:  ctx,
+		Code: code,
+	}
+	mock.lockGetPromoCode.Lock()
+	mock.calls.GetPromoCode = append(mock.calls.GetPromoCode, callInfo)
+	mock.lockGetPromoCode.Unlock()
+	return mock.GetPromoCodeFunc(ctx, code)
+}
+
+// GetPromoCodeCalls gets all the calls that were made to GetPromoCode.
+// Check the length with:
+//
+//	len(mockedRepo.GetPromoCodeCalls())
+func (mock *RepoMock) GetPromoCodeCalls() []struct {
+	Ctx  context.Context
+	Code string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Code string
+	}
+	mock.lockGetPromoCode.RLock()
+	calls = mock.calls.GetPromoCode
+	mock.lockGetPromoCode.RUnlock()
+	return calls
+}
+
+// GetReferral calls GetReferralFunc.
+func (mock *RepoMock) GetReferral(ctx context.Context, refereeID int64) (*model.Referral, error) {
+	if mock.GetReferralFunc == nil {
+		panic("RepoMock.GetReferralFunc: method is nil but Repo.GetReferral was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		RefereeID int64
+	}{
+		Ctx:       ctx,
+		RefereeID: refereeID,
+	}
+	mock.lockGetReferral.Lock()
+	mock.calls.GetReferral = append(mock.calls.GetReferral, callInfo)
+	mock.lockGetReferral.Unlock()
+	return mock.GetReferralFunc(ctx, refereeID)
+}
+
+// GetReferralCalls gets all the calls that were made to GetReferral.
+// Check the length with:
+//
+//	len(mockedRepo.GetReferralCalls())
+func (mock *RepoMock) GetReferralCalls() []struct {
+	Ctx       context.Context
+	RefereeID int64
+} {
+	var calls []struct {
+		Ctx       context.Context
+		RefereeID int64
+	}
+	mock.lockGetReferral.RLock()
+	calls = mock.calls.GetReferral
+	mock.lockGetReferral.RUnlock()
+	return calls
+}
+
+// GetReferrerByCode calls GetReferrerByCodeFunc.
+func (mock *RepoMock) GetReferrerByCode(ctx context.Context, code string) (int64, error) {
+	if mock.GetReferrerByCodeFunc == nil {
+		panic("RepoMock.GetReferrerByCodeFunc: method is nil but Repo.GetReferrerByCode was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Code string
+	}{
+		Ctx:  ctx,
+		Code: code,
+	}
+	mock.lockGetReferrerByCode.Lock()
+	mock.calls.GetReferrerByCode = append(mock.calls.GetReferrerByCode, callInfo)
+	mock.lockGetReferrerByCode.Unlock()
+	return mock.GetReferrerByCodeFunc(ctx, code)
+}
+
+// GetReferrerByCodeCalls gets all the calls that were made to GetReferrerByCode.
+// Check the length with:
+//
+//	len(mockedRepo.GetReferrerByCodeCalls())
+func (mock *RepoMock) GetReferrerByCodeCalls() []struct {
+	Ctx  context.Context
+	Code string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Code string
+	}
+	mock.lockGetReferrerByCode.RLock()
+	calls = mock.calls.GetReferrerByCode
+	mock.lockGetReferrerByCode.RUnlock()
+	return calls
+}
+
+// GetRelatedUser calls GetRelatedUserFunc.
+func (mock *RepoMock) GetRelatedUser(ctx context.Context, id int64) ([]model.User, error) {
+	if mock.GetRelatedUserFunc == nil {
+		panic("RepoMock.GetRelatedUserFunc: method is nil but Repo.GetRelatedUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  int64
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockGetRelatedUser.Lock()
+	mock.calls.GetRelatedUser = append(mock.calls.GetRelatedUser, callInfo)
+	mock.lockGetRelatedUser.Unlock()
+	return mock.GetRelatedUserFunc(ctx, id)
+}
+
+// GetRelatedUserCalls gets all the calls that were made to GetRelatedUser.
+// Check the length with:
+//
+//	len(mockedRepo.GetRelatedUserCalls())
+func (mock *RepoMock) GetRelatedUserCalls() []struct {
+	Ctx context.Context
+	ID  int64
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  int64
+	}
+	mock.lockGetRelatedUser.RLock()
+	calls = mock.calls.GetRelatedUser
+	mock.lockGetRelatedUser.RUnlock()
+	return calls
+}
+
+// GetReport calls GetReportFunc.
+func (mock *RepoMock) GetReport(ctx context.Context, reportID int64) (*model.Report, error) {
+	if mock.GetReportFunc == nil {
+		panic("RepoMock.GetReportFunc: method is nil but Repo.GetReport was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ReportID int64
+	}{
+		Ctx:      ctx,
+		ReportID: reportID,
+	}
+	mock.lockGetReport.Lock()
+	mock.calls.GetReport = append(mock.calls.GetReport, callInfo)
+	mock.lockGetReport.Unlock()
+	return mock.GetReportFunc(ctx, reportID)
+}
+
+// GetReportCalls gets all the calls that were made to GetReport.
+// Check the length with:
+//
+//	len(mockedRepo.GetReportCalls())
+func (mock *RepoMock) GetReportCalls() []struct {
+	Ctx      context.Context
+	ReportID int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ReportID int64
+	}
+	mock.lockGetReport.RLock()
+	calls = mock.calls.GetReport
+	mock.lockGetReport.RUnlock()
+	return calls
+}
+
+// GetReports calls GetReportsFunc.
+func (mock *RepoMock) GetReports(ctx context.Context, status string, limit int) ([]model.Report, error) {
+	if mock.GetReportsFunc == nil {
+		panic("RepoMock.GetReportsFunc: method is nil but Repo.GetReports was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Status string
+		Limit  int
+	}{
+		Ctx:    ctx,
+		Status: status,
+		Limit:  limit,
+	}
+	mock.lockGetReports.Lock()
+	mock.calls.GetReports = append(mock.calls.GetReports, callInfo)
+	mock.lockGetReports.Unlock()
+	return mock.GetReportsFunc(ctx, status, limit)
+}
+
+// GetReportsCalls gets all the calls that were made to GetReports.
+// Check the length with:
+//
+//	len(mockedRepo.GetReportsCalls())
+func (mock *RepoMock) GetReportsCalls() []struct {
+	Ctx    context.Context
+	Status string
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Status string
+		Limit  int
+	}
+	mock.lockGetReports.RLock()
+	calls = mock.calls.GetReports
+	mock.lockGetReports.RUnlock()
+	return calls
+}
+
+// GetSubscriptionByProviderID calls GetSubscriptionByProviderIDFunc.
+func (mock *RepoMock) GetSubscriptionByProviderID(ctx context.Context, providerSubscriptionID string) (*model.Subscription, error) {
+	if mock.GetSubscriptionByProviderIDFunc == nil {
+		panic("RepoMock.GetSubscriptionByProviderIDFunc: method is nil but Repo.GetSubscriptionByProviderID was just called")
+	}
+	callInfo := struct {
+		Ctx                    context.Context
+		ProviderSubscriptionID string
+	}{
+		Ctx:                    ctx,
+		ProviderSubscriptionID: providerSubscriptionID,
+	}
+	mock.lockGetSubscriptionByProviderID.Lock()
+	mock.calls.GetSubscriptionByProviderID = append(mock.calls.GetSubscriptionByProviderID, callInfo)
+	mock.lockGetSubscriptionByProviderID.Unlock()
+	return mock.GetSubscriptionByProviderIDFunc(ctx, providerSubscriptionID)
+}
+
+// GetSubscriptionByProviderIDCalls gets all the calls that were made to GetSubscriptionByProviderID.
+// Check the length with:
+//
+//	len(mockedRepo.GetSubscriptionByProviderIDCalls())
+func (mock *RepoMock) GetSubscriptionByProviderIDCalls() []struct {
+	Ctx                    context.Context
+	ProviderSubscriptionID string
+} {
+	var calls []struct {
+		Ctx                    context.Context
+		ProviderSubscriptionID string
+	}
+	mock.lockGetSubscriptionByProviderID.RLock()
+	calls = mock.calls.GetSubscriptionByProviderID
+	mock.lockGetSubscriptionByProviderID.RUnlock()
+	return calls
+}
+
+// GetSwipe calls GetSwipeFunc.
+func (mock *RepoMock) GetSwipe(ctx context.Context, swiperID int64, swipedID int64) (*model.Swipe, error) {
+	if mock.GetSwipeFunc == nil {
+		panic("RepoMock.GetSwipeFunc: method is nil but Repo.GetSwipe was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		SwiperID int64
+		SwipedID int64
+	}{
+		Ctx:      ctx,
+		SwiperID: swiperID,
+		SwipedID: swipedID,
+	}
+	mock.lockGetSwipe.Lock()
+	mock.calls.GetSwipe = append(mock.calls.GetSwipe, callInfo)
+	mock.lockGetSwipe.Unlock()
+	return mock.GetSwipeFunc(ctx, swiperID, swipedID)
+}
+
+// GetSwipeCalls gets all the calls that were made to GetSwipe.
+// Check the length with:
+//
+//	len(mockedRepo.GetSwipeCalls())
+func (mock *RepoMock) GetSwipeCalls() []struct {
+	Ctx      context.Context
+	SwiperID int64
+	SwipedID int64
+} {
+	var calls []struct {
+		Ctx      context.Context
+		SwiperID int64
+		SwipedID int64
+	}
+	mock.lockGetSwipe.RLock()
+	calls = mock.calls.GetSwipe
+	mock.lockGetSwipe.RUnlock()
+	return calls
+}
+
+// GetSwipeStats calls GetSwipeStatsFunc.
+func (mock *RepoMock) GetSwipeStats(ctx context.Context, userID int64) (*model.SwipeStats, error) {
+	if mock.GetSwipeStatsFunc == nil {
+		panic("RepoMock.GetSwipeStatsFunc: method is nil but Repo.GetSwipeStats was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetSwipeStats.Lock()
+	mock.calls.GetSwipeStats = append(mock.calls.GetSwipeStats, callInfo)
+	mock.lockGetSwipeStats.Unlock()
+	return mock.GetSwipeStatsFunc(ctx, userID)
+}
+
+// GetSwipeStatsCalls gets all the calls that were made to GetSwipeStats.
+// Check the length with:
+//
+//	len(mockedRepo.GetSwipeStatsCalls())
+func (mock *RepoMock) GetSwipeStatsCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetSwipeStats.RLock()
+	calls = mock.calls.GetSwipeStats
+	mock.lockGetSwipeStats.RUnlock()
+	return calls
+}
+
+// GetTOTP calls GetTOTPFunc.
+func (mock *RepoMock) GetTOTP(ctx context.Context, userID int64) (*model.TOTP, error) {
+	if mock.GetTOTPFunc == nil {
+		panic("RepoMock.GetTOTPFunc: method is nil but Repo.GetTOTP was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetTOTP.Lock()
+	mock.calls.GetTOTP = append(mock.calls.GetTOTP, callInfo)
+	mock.lockGetTOTP.Unlock()
+	return mock.GetTOTPFunc(ctx, userID)
+}
+
+// GetTOTPCalls gets all the calls that were made to GetTOTP.
+// Check the length with:
+//
+//	len(mockedRepo.GetTOTPCalls())
+func (mock *RepoMock) GetTOTPCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetTOTP.RLock()
+	calls = mock.calls.GetTOTP
+	mock.lockGetTOTP.RUnlock()
+	return calls
+}
+
+// GetUser calls GetUserFunc.
+func (mock *RepoMock) GetUser(ctx context.Context, username string) (*model.User, error) {
+	if mock.GetUserFunc == nil {
+		panic("RepoMock.GetUserFunc: method is nil but Repo.GetUser was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Username string
+	}{
+		Ctx:      ctx,
+		Username: username,
+	}
+	mock.lockGetUser.Lock()
+	mock.calls.GetUser = append(mock.calls.GetUser, callInfo)
+	mock.lockGetUser.Unlock()
+	return mock.GetUserFunc(ctx, username)
+}
+
+// GetUserCalls gets all the calls that were made to GetUser.
+// Check the length with:
+//
+//	len(mockedRepo.GetUserCalls())
+func (mock *RepoMock) GetUserCalls() []struct {
+	Ctx      context.Context
+	Username string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Username string
+	}
+	mock.lockGetUser.RLock()
+	calls = mock.calls.GetUser
+	mock.lockGetUser.RUnlock()
+	return calls
+}
+
+// GetUserByEmail calls GetUserByEmailFunc.
+func (mock *RepoMock) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
+	if mock.GetUserByEmailFunc == nil {
+		panic("RepoMock.GetUserByEmailFunc: method is nil but Repo.GetUserByEmail was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Email string
+	}{
+		Ctx:   ctx,
+		Email: email,
+	}
+	mock.lockGetUserByEmail.Lock()
+	mock.calls.GetUserByEmail = append(mock.calls.GetUserByEmail, callInfo)
+	mock.lockGetUserByEmail.Unlock()
+	return mock.GetUserByEmailFunc(ctx, email)
+}
+
+// GetUserByEmailCalls gets all the calls that were made to GetUserByEmail.
+// Check the length with:
+//
+//	len(mockedRepo.GetUserByEmailCalls())
+func (mock *RepoMock) GetUserByEmailCalls() []struct {
+	Ctx   context.Context
+	Email string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Email string
+	}
+	mock.lockGetUserByEmail.RLock()
+	calls = mock.calls.GetUserByEmail
+	mock.lockGetUserByEmail.RUnlock()
+	return calls
+}
+
+// GetUserByID calls GetUserByIDFunc.
+func (mock *RepoMock) GetUserByID(ctx context.Context, userID int64) (*model.User, error) {
+	if mock.GetUserByIDFunc == nil {
+		panic("RepoMock.GetUserByIDFunc: method is nil but Repo.GetUserByID was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetUserByID.Lock()
+	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
+	mock.lockGetUserByID.Unlock()
+	return mock.GetUserByIDFunc(ctx, userID)
+}
+
+// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// Check the length with:
+//
+//	len(mockedRepo.GetUserByIDCalls())
+func (mock *RepoMock) GetUserByIDCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetUserByID.RLock()
+	calls = mock.calls.GetUserByID
+	mock.lockGetUserByID.RUnlock()
+	return calls
+}
+
+// HasPaidSubscription calls HasPaidSubscriptionFunc.
+func (mock *RepoMock) HasPaidSubscription(ctx context.Context, userID int64) (bool, error) {
+	if mock.HasPaidSubscriptionFunc == nil {
+		panic("RepoMock.HasPaidSubscriptionFunc: method is nil but Repo.HasPaidSubscription was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockHasPaidSubscription.Lock()
+	mock.calls.HasPaidSubscription = append(mock.calls.HasPaidSubscription, callInfo)
+	mock.lockHasPaidSubscription.Unlock()
+	return mock.HasPaidSubscriptionFunc(ctx, userID)
+}
+
+// HasPaidSubscriptionCalls gets all the calls that were made to HasPaidSubscription.
+// Check the length with:
+//
+//	len(mockedRepo.HasPaidSubscriptionCalls())
+func (mock *RepoMock) HasPaidSubscriptionCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockHasPaidSubscription.RLock()
+	calls = mock.calls.HasPaidSubscription
+	mock.lockHasPaidSubscription.RUnlock()
+	return calls
+}
+
+// InsertAuditLog calls InsertAuditLogFunc.
+func (mock *RepoMock) InsertAuditLog(ctx context.Context, req model.AuditLog) error {
+	if mock.InsertAuditLogFunc == nil {
+		panic("RepoMock.InsertAuditLogFunc: method is nil but Repo.InsertAuditLog was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.AuditLog
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockInsertAuditLog.Lock()
+	mock.calls.InsertAuditLog = append(mock.calls.InsertAuditLog, callInfo)
+	mock.lockInsertAuditLog.Unlock()
+	return mock.InsertAuditLogFunc(ctx, req)
+}
+
+// InsertAuditLogCalls gets all the calls that were made to InsertAuditLog.
+// Check the length with:
+//
+//	len(mockedRepo.InsertAuditLogCalls())
+func (mock *RepoMock) InsertAuditLogCalls() []struct {
+	Ctx context.Context
+	Req model.AuditLog
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.AuditLog
+	}
+	mock.lockInsertAuditLog.RLock()
+	calls = mock.calls.InsertAuditLog
+	mock.lockInsertAuditLog.RUnlock()
+	return calls
+}
+
+// InsertBlock calls InsertBlockFunc.
+func (mock *RepoMock) InsertBlock(ctx context.Context, blockerID int64, blockedID int64) error {
+	if mock.InsertBlockFunc == nil {
+		panic("RepoMock.InsertBlockFunc: method is nil but Repo.InsertBlock was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		BlockerID int64
+		BlockedID int64
+	}{
+		Ctx:       ctx,
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+	}
+	mock.lockInsertBlock.Lock()
+	mock.calls.InsertBlock = append(mock.calls.InsertBlock, callInfo)
+	mock.lockInsertBlock.Unlock()
+	return mock.InsertBlockFunc(ctx, blockerID, blockedID)
+}
+
+// InsertBlockCalls gets all the calls that were made to InsertBlock.
+// Check the length with:
+//
+//	len(mockedRepo.InsertBlockCalls())
+func (mock *RepoMock) InsertBlockCalls() []struct {
+	Ctx       context.Context
+	BlockerID int64
+	BlockedID int64
+} {
+	var calls []struct {
+		Ctx       context.Context
+		BlockerID int64
+		BlockedID int64
+	}
+	mock.lockInsertBlock.RLock()
+	calls = mock.calls.InsertBlock
+	mock.lockInsertBlock.RUnlock()
+	return calls
+}
+
+// InsertBoost calls InsertBoostFunc.
+func (mock *RepoMock) InsertBoost(ctx context.Context, req model.Boost) (int64, error) {
+	if mock.InsertBoostFunc == nil {
+		panic("RepoMock.InsertBoostFunc: method is nil but Repo.InsertBoost was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.Boost
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockInsertBoost.Lock()
+	mock.calls.InsertBoost = append(mock.calls.InsertBoost, callInfo)
+	mock.lockInsertBoost.Unlock()
+	return mock.InsertBoostFunc(ctx, req)
+}
+
+// InsertBoostCalls gets all the calls that were made to InsertBoost.
+// Check the length with:
+//
+//	len(mockedRepo.InsertBoostCalls())
+func (mock *RepoMock) InsertBoostCalls() []struct {
+	Ctx context.Context
+	Req model.Boost
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.Boost
+	}
+	mock.lockInsertBoost.RLock()
+	calls = mock.calls.InsertBoost
+	mock.lockInsertBoost.RUnlock()
+	return calls
+}
+
+// InsertIdentity calls InsertIdentityFunc.
+func (mock *RepoMock) InsertIdentity(ctx context.Context, req model.Identity) error {
+	if mock.InsertIdentityFunc == nil {
+		panic("RepoMock.InsertIdentityFunc: method is nil but Repo.InsertIdentity was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.Identity
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockInsertIdentity.Lock()
+	mock.calls.InsertIdentity = append(mock.calls.InsertIdentity, callInfo)
+	mock.lockInsertIdentity.Unlock()
+	return mock.InsertIdentityFunc(ctx, req)
+}
+
+// InsertIdentityCalls gets all the calls that were made to InsertIdentity.
+// Check the length with:
+//
+//	len(mockedRepo.InsertIdentityCalls())
+func (mock *RepoMock) InsertIdentityCalls() []struct {
+	Ctx context.Context
+	Req model.Identity
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.Identity
+	}
+	mock.lockInsertIdentity.RLock()
+	calls = mock.calls.InsertIdentity
+	mock.lockInsertIdentity.RUnlock()
+	return calls
+}
+
+// InsertLedgerEntry calls InsertLedgerEntryFunc.
+func (mock *RepoMock) InsertLedgerEntry(ctx context.Context, entry model.LedgerEntry, invoice *model.Invoice) (bool, error) {
+	if mock.InsertLedgerEntryFunc == nil {
+		panic("RepoMock.InsertLedgerEntryFunc: method is nil but Repo.InsertLedgerEntry was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		Entry   model.LedgerEntry
+		Invoice *model.Invoice
+	}{
+		Ctx:     ctx,
+		Entry:   entry,
+		Invoice: invoice,
+	}
+	mock.lockInsertLedgerEntry.Lock()
+	mock.calls.InsertLedgerEntry = append(mock.calls.InsertLedgerEntry, callInfo)
+	mock.lockInsertLedgerEntry.Unlock()
+	return mock.InsertLedgerEntryFunc(ctx, entry, invoice)
+}
+
+// InsertLedgerEntryCalls gets all the calls that were made to InsertLedgerEntry.
+// Check the length with:
+//
+//	len(mockedRepo.InsertLedgerEntryCalls())
+func (mock *RepoMock) InsertLedgerEntryCalls() []struct {
+	Ctx     context.Context
+	Entry   model.LedgerEntry
+	Invoice *model.Invoice
+} {
+	var calls []struct {
+		Ctx     context.Context
+		Entry   model.LedgerEntry
+		Invoice *model.Invoice
+	}
+	mock.lockInsertLedgerEntry.RLock()
+	calls = mock.calls.InsertLedgerEntry
+	mock.lockInsertLedgerEntry.RUnlock()
+	return calls
+}
+
+// InsertMessage calls InsertMessageFunc.
+func (mock *RepoMock) InsertMessage(ctx context.Context, req model.Message) (int64, error) {
+	if mock.InsertMessageFunc == nil {
+		panic("RepoMock.InsertMessageFunc: method is nil but Repo.InsertMessage was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.Message
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockInsertMessage.Lock()
+	mock.calls.InsertMessage = append(mock.calls.InsertMessage, callInfo)
+	mock.lockInsertMessage.Unlock()
+	return mock.InsertMessageFunc(ctx, req)
+}
+
+// InsertMessageCalls gets all the calls that were made to InsertMessage.
+// Check the length with:
+//
+//	len(mockedRepo.InsertMessageCalls())
+func (mock *RepoMock) InsertMessageCalls() []struct {
+	Ctx context.Context
+	Req model.Message
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.Message
+	}
+	mock.lockInsertMessage.RLock()
+	calls = mock.calls.InsertMessage
+	mock.lockInsertMessage.RUnlock()
+	return calls
+}
+
+// InsertPaymentEvent calls InsertPaymentEventFunc.
+func (mock *RepoMock) InsertPaymentEvent(ctx context.Context, req model.PaymentEvent) (bool, error) {
+	if mock.InsertPaymentEventFunc == nil {
+		panic("RepoMock.InsertPaymentEventFunc: method is nil but Repo.InsertPaymentEvent was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.PaymentEvent
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockInsertPaymentEvent.Lock()
+	mock.calls.InsertPaymentEvent = append(mock.calls.InsertPaymentEvent, callInfo)
+	mock.lockInsertPaymentEvent.Unlock()
+	return mock.InsertPaymentEventFunc(ctx, req)
+}
+
+// InsertPaymentEventCalls gets all the calls that were made to InsertPaymentEvent.
+// Check the length with:
+//
+//	len(mockedRepo.InsertPaymentEventCalls())
+func (mock *RepoMock) InsertPaymentEventCalls() []struct {
+	Ctx context.Context
+	Req model.PaymentEvent
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.PaymentEvent
+	}
+	mock.lockInsertPaymentEvent.RLock()
+	calls = mock.calls.InsertPaymentEvent
+	mock.lockInsertPaymentEvent.RUnlock()
+	return calls
+}
+
+// InsertPhoto calls InsertPhotoFunc.
+func (mock *RepoMock) InsertPhoto(ctx context.Context, req model.Photo, limit int) (int64, error) {
+	if mock.InsertPhotoFunc == nil {
+		panic("RepoMock.InsertPhotoFunc: method is nil but Repo.InsertPhoto was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Req   model.Photo
+		Limit int
+	}{
+		Ctx:   ctx,
+		Req:   req,
+		Limit: limit,
+	}
+	mock.lockInsertPhoto.Lock()
+	mock.calls.InsertPhoto = append(mock.calls.InsertPhoto, callInfo)
+	mock.lockInsertPhoto.Unlock()
+	return mock.InsertPhotoFunc(ctx, req, limit)
+}
+
+// InsertPhotoCalls gets all the calls that were made to InsertPhoto.
+// Check the length with:
+//
+//	len(mockedRepo.InsertPhotoCalls())
+func (mock *RepoMock) InsertPhotoCalls() []struct {
+	Ctx   context.Context
+	Req   model.Photo
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Req   model.Photo
+		Limit int
+	}
+	mock.lockInsertPhoto.RLock()
+	calls = mock.calls.InsertPhoto
+	mock.lockInsertPhoto.RUnlock()
+	return calls
+}
+
+// InsertProfileViews calls InsertProfileViewsFunc.
+func (mock *RepoMock) InsertProfileViews(ctx context.Context, views []model.ProfileView) error {
+	if mock.InsertProfileViewsFunc == nil {
+		panic("RepoMock.InsertProfileViewsFunc: method is nil but Repo.InsertProfileViews was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Views []model.ProfileView
+	}{
+		Ctx:   ctx,
+		Views: views,
+	}
+	mock.lockInsertProfileViews.Lock()
+	mock.calls.InsertProfileViews = append(mock.calls.InsertProfileViews, callInfo)
+	mock.lockInsertProfileViews.Unlock()
+	return mock.InsertProfileViewsFunc(ctx, views)
+}
+
+// InsertProfileViewsCalls gets all the calls that were made to InsertProfileViews.
+// Check the length with:
+//
+//	len(mockedRepo.InsertProfileViewsCalls())
+func (mock *RepoMock) InsertProfileViewsCalls() []struct {
+	Ctx   context.Context
+	Views []model.ProfileView
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Views []model.ProfileView
+	}
+	mock.lockInsertProfileViews.RLock()
+	calls = mock.calls.InsertProfileViews
+	mock.lockInsertProfileViews.RUnlock()
+	return calls
+}
+
+// InsertPromoCode calls InsertPromoCodeFunc.
+func (mock *RepoMock) InsertPromoCode(ctx context.Context, req model.PromoCode) (int64, error) {
+	if mock.InsertPromoCodeFunc == nil {
+		panic("RepoMock.InsertPromoCodeFunc: method is nil but Repo.InsertPromoCode was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.PromoCode
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockInsertPromoCode.Lock()
+	mock.calls.InsertPromoCode = append(mock.calls.InsertPromoCode, callInfo)
+	mock.lockInsertPromoCode.Unlock()
+	return mock.InsertPromoCodeFunc(ctx, req)
+}
+
+// InsertPromoCodeCalls gets all the calls that were made to InsertPromoCode.
+// Check the length with:
+//
+//	len(mockedRepo.InsertPromoCodeCalls())
+func (mock *RepoMock) InsertPromoCodeCalls() []struct {
+	Ctx context.Context
+	Req model.PromoCode
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.PromoCode
+	}
+	mock.lockInsertPromoCode.RLock()
+	calls = mock.calls.InsertPromoCode
+	mock.lockInsertPromoCode.RUnlock()
+	return calls
+}
+
+// InsertReferral calls InsertReferralFunc.
+func (mock *RepoMock) InsertReferral(ctx context.Context, req model.Referral) (bool, error) {
+	if mock.InsertReferralFunc == nil {
+		panic("RepoMock.InsertReferralFunc: method is nil but Repo.InsertReferral was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.Referral
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockInsertReferral.Lock()
+	mock.calls.InsertReferral = append(mock.calls.InsertReferral, callInfo)
+	mock.lockInsertReferral.Unlock()
+	return mock.InsertReferralFunc(ctx, req)
+}
+
+// InsertReferralCalls gets all the calls that were made to InsertReferral.
+// Check the length with:
+//
+//	len(mockedRepo.InsertReferralCalls())
+func (mock *RepoMock) InsertReferralCalls() []struct {
+	Ctx context.Context
+	Req model.Referral
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.Referral
+	}
+	mock.lockInsertReferral.RLock()
+	calls = mock.calls.InsertReferral
+	mock.lockInsertReferral.RUnlock()
+	return calls
+}
+
+// InsertReport calls InsertReportFunc.
+func (mock *RepoMock) InsertReport(ctx context.Context, req model.Report) (int64, error) {
+	if mock.InsertReportFunc == nil {
+		panic("RepoMock.InsertReportFunc: method is nil but Repo.InsertReport was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.Report
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockInsertReport.Lock()
+	mock.calls.InsertReport = append(mock.calls.InsertReport, callInfo)
+	mock.lockInsertReport.Unlock()
+	return mock.InsertReportFunc(ctx, req)
+}
+
+// InsertReportCalls gets all the calls that were made to InsertReport.
+// Check the length with:
+//
+//	len(mockedRepo.InsertReportCalls())
+func (mock *RepoMock) InsertReportCalls() []struct {
+	Ctx context.Context
+	Req model.Report
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.Report
+	}
+	mock.lockInsertReport.RLock()
+	calls = mock.calls.InsertReport
+	mock.lockInsertReport.RUnlock()
+	return calls
+}
+
+// InsertSubscription calls InsertSubscriptionFunc.
+func (mock *RepoMock) InsertSubscription(ctx context.Context, req model.Subscription) (int64, error) {
+	if mock.InsertSubscriptionFunc == nil {
+		panic("RepoMock.InsertSubscriptionFunc: method is nil but Repo.InsertSubscription was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.Subscription
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockInsertSubscription.Lock()
+	mock.calls.InsertSubscription = append(mock.calls.InsertSubscription, callInfo)
+	mock.lockInsertSubscription.Unlock()
+	return mock.InsertSubscriptionFunc(ctx, req)
+}
+
+// InsertSubscriptionCalls gets all the calls that were made to InsertSubscription.
+// Check the length with:
+//
+//	len(mockedRepo.InsertSubscriptionCalls())
+func (mock *RepoMock) InsertSubscriptionCalls() []struct {
+	Ctx context.Context
+	Req model.Subscription
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.Subscription
+	}
+	mock.lockInsertSubscription.RLock()
+	calls = mock.calls.InsertSubscription
+	mock.lockInsertSubscription.RUnlock()
+	return calls
+}
+
+// IsBlocked calls IsBlockedFunc.
+func (mock *RepoMock) IsBlocked(ctx context.Context, userID int64, otherUserID int64) (bool, error) {
+	if mock.IsBlockedFunc == nil {
+		panic("RepoMock.IsBlockedFunc: method is nil but Repo.IsBlocked was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		UserID      int64
+		OtherUserID int64
+	}{
+		Ctx:         ctx,
+		UserID:      userID,
+		OtherUserID: otherUserID,
+	}
+	mock.lockIsBlocked.Lock()
+	mock.calls.IsBlocked = append(mock.calls.IsBlocked, callInfo)
+	mock.lockIsBlocked.Unlock()
+	return mock.IsBlockedFunc(ctx, userID, otherUserID)
+}
+
+// IsBlockedCalls gets all the calls that were made to IsBlocked.
+// Check the length with:
+//
+//	len(mockedRepo.IsBlockedCalls())
+func (mock *RepoMock) IsBlockedCalls() []struct {
+	Ctx         context.Context
+	UserID      int64
+	OtherUserID int64
+} {
+	var calls []struct {
+		Ctx         context.Context
+		UserID      int64
+		OtherUserID int64
+	}
+	mock.lockIsBlocked.RLock()
+	calls = mock.calls.IsBlocked
+	mock.lockIsBlocked.RUnlock()
+	return calls
+}
+
+// IsMatch calls IsMatchFunc.
+func (mock *RepoMock) IsMatch(ctx context.Context, userID int64, otherUserID int64) (bool, error) {
+	if mock.IsMatchFunc == nil {
+		panic("RepoMock.IsMatchFunc: method is nil but Repo.IsMatch was just called")
+	}
+	callInfo := struct {
+		Ctx         context.Context
+		UserID      int64
+		OtherUserID int64
+	}{
+		Ctx:         ctx,
+		UserID:      userID,
+		OtherUserID: otherUserID,
+	}
+	mock.lockIsMatch.Lock()
+	mock.calls.IsMatch = append(mock.calls.IsMatch, callInfo)
+	mock.lockIsMatch.Unlock()
+	return mock.IsMatchFunc(ctx, userID, otherUserID)
+}
+
+// IsMatchCalls gets all the calls that were made to IsMatch.
+// Check the length with:
+//
+//	len(mockedRepo.IsMatchCalls())
+func (mock *RepoMock) IsMatchCalls() []struct {
+	Ctx         context.Context
+	UserID      int64
+	OtherUserID int64
+} {
+	var calls []struct {
+		Ctx         context.Context
+		UserID      int64
+		OtherUserID int64
+	}
+	mock.lockIsMatch.RLock()
+	calls = mock.calls.IsMatch
+	mock.lockIsMatch.RUnlock()
+	return calls
+}
+
+// RedeemPromoCode calls RedeemPromoCodeFunc.
+func (mock *RepoMock) RedeemPromoCode(ctx context.Context, req model.PromoRedemption) error {
+	if mock.RedeemPromoCodeFunc == nil {
+		panic("RepoMock.RedeemPromoCodeFunc: method is nil but Repo.RedeemPromoCode was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.PromoRedemption
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockRedeemPromoCode.Lock()
+	mock.calls.RedeemPromoCode = append(mock.calls.RedeemPromoCode, callInfo)
+	mock.lockRedeemPromoCode.Unlock()
+	return mock.RedeemPromoCodeFunc(ctx, req)
+}
+
+// RedeemPromoCodeCalls gets all the calls that were made to RedeemPromoCode.
+// Check the length with:
+//
+//	len(mockedRepo.RedeemPromoCodeCalls())
+func (mock *RepoMock) RedeemPromoCodeCalls() []struct {
+	Ctx context.Context
+	Req model.PromoRedemption
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.PromoRedemption
+	}
+	mock.lockRedeemPromoCode.RLock()
+	calls = mock.calls.RedeemPromoCode
+	mock.lockRedeemPromoCode.RUnlock()
+	return calls
+}
+
+// RewardReferral calls RewardReferralFunc.
+func (mock *RepoMock) RewardReferral(ctx context.Context, refereeID int64, at time.Time) (bool, error) {
+	if mock.RewardReferralFunc == nil {
+		panic("RepoMock.RewardReferralFunc: method is nil but Repo.RewardReferral was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		RefereeID int64
+		At        time.Time
+	}{
+		Ctx:       ctx,
+		RefereeID: refereeID,
+		At:        at,
+	}
+	mock.lockRewardReferral.Lock()
+	mock.calls.RewardReferral = append(mock.calls.RewardReferral, callInfo)
+	mock.lockRewardReferral.Unlock()
+	return mock.RewardReferralFunc(ctx, refereeID, at)
+}
+
+// RewardReferralCalls gets all the calls that were made to RewardReferral.
+// Check the length with:
+//
+//	len(mockedRepo.RewardReferralCalls())
+func (mock *RepoMock) RewardReferralCalls() []struct {
+	Ctx       context.Context
+	RefereeID int64
+	At        time.Time
+} {
+	var calls []struct {
+		Ctx       context.Context
+		RefereeID int64
+		At        time.Time
+	}
+	mock.lockRewardReferral.RLock()
+	calls = mock.calls.RewardReferral
+	mock.lockRewardReferral.RUnlock()
+	return calls
+}
+
+// SearchUsers calls SearchUsersFunc.
+func (mock *RepoMock) SearchUsers(ctx context.Context, query string, limit int) ([]model.User, error) {
+	if mock.SearchUsersFunc == nil {
+		panic("RepoMock.SearchUsersFunc: method is nil but Repo.SearchUsers was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Query string
+		Limit int
+	}{
+		Ctx:   ctx,
+		Query: query,
+		Limit: limit,
+	}
+	mock.lockSearchUsers.Lock()
+	mock.calls.SearchUsers = append(mock.calls.SearchUsers, callInfo)
+	mock.lockSearchUsers.Unlock()
+	return mock.SearchUsersFunc(ctx, query, limit)
+}
+
+// SearchUsersCalls gets all the calls that were made to SearchUsers.
+// Check the length with:
+//
+//	len(mockedRepo.SearchUsersCalls())
+func (mock *RepoMock) SearchUsersCalls() []struct {
+	Ctx   context.Context
+	Query string
+	Limit int
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Query string
+		Limit int
+	}
+	mock.lockSearchUsers.RLock()
+	calls = mock.calls.SearchUsers
+	mock.lockSearchUsers.RUnlock()
+	return calls
+}
+
+// UnredeemPromoCode calls UnredeemPromoCodeFunc.
+func (mock *RepoMock) UnredeemPromoCode(ctx context.Context, req model.PromoRedemption) error {
+	if mock.UnredeemPromoCodeFunc == nil {
+		panic("RepoMock.UnredeemPromoCodeFunc: method is nil but Repo.UnredeemPromoCode was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.PromoRedemption
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockUnredeemPromoCode.Lock()
+	mock.calls.UnredeemPromoCode = append(mock.calls.UnredeemPromoCode, callInfo)
+	mock.lockUnredeemPromoCode.Unlock()
+	return mock.UnredeemPromoCodeFunc(ctx, req)
+}
+
+// UnredeemPromoCodeCalls gets all the calls that were made to UnredeemPromoCode.
+// Check the length with:
+//
+//	len(mockedRepo.UnredeemPromoCodeCalls())
+func (mock *RepoMock) UnredeemPromoCodeCalls() []struct {
+	Ctx context.Context
+	Req model.PromoRedemption
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.PromoRedemption
+	}
+	mock.lockUnredeemPromoCode.RLock()
+	calls = mock.calls.UnredeemPromoCode
+	mock.lockUnredeemPromoCode.RUnlock()
+	return calls
+}
+
+// UpdateAccountStatus calls UpdateAccountStatusFunc.
+func (mock *RepoMock) UpdateAccountStatus(ctx context.Context, req model.AccountStatusChange) error {
+	if mock.UpdateAccountStatusFunc == nil {
+		panic("RepoMock.UpdateAccountStatusFunc: method is nil but Repo.UpdateAccountStatus was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.AccountStatusChange
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockUpdateAccountStatus.Lock()
+	mock.calls.UpdateAccountStatus = append(mock.calls.UpdateAccountStatus, callInfo)
+	mock.lockUpdateAccountStatus.Unlock()
+	return mock.UpdateAccountStatusFunc(ctx, req)
+}
+
+// UpdateAccountStatusCalls gets all the calls that were made to UpdateAccountStatus.
+// Check the length with:
+//
+//	len(mockedRepo.UpdateAccountStatusCalls())
+func (mock *RepoMock) UpdateAccountStatusCalls() []struct {
+	Ctx context.Context
+	Req model.AccountStatusChange
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.AccountStatusChange
+	}
+	mock.lockUpdateAccountStatus.RLock()
+	calls = mock.calls.UpdateAccountStatus
+	mock.lockUpdateAccountStatus.RUnlock()
+	return calls
+}
+
+// UpdateDunningNotified calls UpdateDunningNotifiedFunc.
+func (mock *RepoMock) UpdateDunningNotified(ctx context.Context, subscriptionID int64, at time.Time) error {
+	if mock.UpdateDunningNotifiedFunc == nil {
+		panic("RepoMock.UpdateDunningNotifiedFunc: method is nil but Repo.UpdateDunningNotified was just called")
+	}
+	callInfo := struct {
+		Ctx            context.Context
+		SubscriptionID int64
+		At             time.Time
+	}{
+		Ctx:            ctx,
+		SubscriptionID: subscriptionID,
+		At:             at,
+	}
+	mock.lockUpdateDunningNotified.Lock()
+	mock.calls.UpdateDunningNotified = append(mock.calls.UpdateDunningNotified, callInfo)
+	mock.lockUpdateDunningNotified.Unlock()
+	return mock.UpdateDunningNotifiedFunc(ctx, subscriptionID, at)
+}
+
+// UpdateDunningNotifiedCalls gets all the calls that were made to UpdateDunningNotified.
+// Check the length with:
+//
+//	len(mockedRepo.UpdateDunningNotifiedCalls())
+func (mock *RepoMock) UpdateDunningNotifiedCalls() []struct {
+	Ctx            context.Context
+	SubscriptionID int64
+	At             time.Time
+} {
+	var calls []struct {
+		Ctx            context.Context
+		SubscriptionID int64
+		At             time.Time
+	}
+	mock.lockUpdateDunningNotified.RLock()
+	calls = mock.calls.UpdateDunningNotified
+	mock.lockUpdateDunningNotified.RUnlock()
+	return calls
+}
+
+// UpdateEmail calls UpdateEmailFunc.
+func (mock *RepoMock) UpdateEmail(ctx context.Context, userID int64, email string) error {
+	if mock.UpdateEmailFunc == nil {
+		panic("RepoMock.UpdateEmailFunc: method is nil but Repo.UpdateEmail was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+		Email  string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Email:  email,
+	}
+	mock.lockUpdateEmail.Lock()
+	mock.calls.UpdateEmail = append(mock.calls.UpdateEmail, callInfo)
+	mock.lockUpdateEmail.Unlock()
+	return mock.UpdateEmailFunc(ctx, userID, email)
+}
+
+// UpdateEmailCalls gets all the calls that were made to UpdateEmail.
+// Check the length with:
+//
+//	len(mockedRepo.UpdateEmailCalls())
+func (mock *RepoMock) UpdateEmailCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+	Email  string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+		Email  string
+	}
+	mock.lockUpdateEmail.RLock()
+	calls = mock.calls.UpdateEmail
+	mock.lockUpdateEmail.RUnlock()
+	return calls
+}
+
+// UpdateEmailVerified calls UpdateEmailVerifiedFunc.
+func (mock *RepoMock) UpdateEmailVerified(ctx context.Context, userID int64, email string) error {
+	if mock.UpdateEmailVerifiedFunc == nil {
+		panic("RepoMock.UpdateEmailVerifiedFunc: method is nil but Repo.UpdateEmailVerified was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+		Email  string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Email:  email,
+	}
+	mock.lockUpdateEmailVerified.Lock()
+	mock.calls.UpdateEmailVerified = append(mock.calls.UpdateEmailVerified, callInfo)
+	mock.lockUpdateEmailVerified.Unlock()
+	return mock.UpdateEmailVerifiedFunc(ctx, userID, email)
+}
+
+// UpdateEmailVerifiedCalls gets all the calls that were made to UpdateEmailVerified.
+// Check the length with:
+//
+//	len(mockedRepo.UpdateEmailVerifiedCalls())
+func (mock *RepoMock) UpdateEmailVerifiedCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+	Email  string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+		Email  string
+	}
+	mock.lockUpdateEmailVerified.RLock()
+	calls = mock.calls.UpdateEmailVerified
+	mock.lockUpdateEmailVerified.RUnlock()
+	return calls
+}
+
+// UpdateHidden calls UpdateHiddenFunc.
+func (mock *RepoMock) UpdateHidden(ctx context.Context, userID int64, hidden bool) error {
+	if mock.UpdateHiddenFunc == nil {
+		panic("RepoMock.UpdateHiddenFunc: method is nil but Repo.UpdateHidden was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+		Hidden bool
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Hidden: hidden,
+	}
+	mock.lockUpdateHidden.Lock()
+	mock.calls.UpdateHidden = append(mock.calls.UpdateHidden, callInfo)
+	mock.lockUpdateHidden.Unlock()
+	return mock.UpdateHiddenFunc(ctx, userID, hidden)
+}
 
-	// GetUserByIDFunc mocks the GetUserByID method.
-	GetUserByIDFunc func(ctx context.Context, userID int64) (*model.User, error)
+// UpdateHiddenCalls gets all the calls that were made to UpdateHidden.
+// Check the length with:
+//
+//	len(mockedRepo.UpdateHiddenCalls())
+func (mock *RepoMock) UpdateHiddenCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+	Hidden bool
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+		Hidden bool
+	}
+	mock.lockUpdateHidden.RLock()
+	calls = mock.calls.UpdateHidden
+	mock.lockUpdateHidden.RUnlock()
+	return calls
+}
 
-	// UpdatePremiumStatusFunc mocks the UpdatePremiumStatus method.
-	UpdatePremiumStatusFunc func(ctx context.Context, req model.SubscribeRequest) error
+// UpdateIncognito calls UpdateIncognitoFunc.
+func (mock *RepoMock) UpdateIncognito(ctx context.Context, userID int64, incognito bool) error {
+	if mock.UpdateIncognitoFunc == nil {
+		panic("RepoMock.UpdateIncognitoFunc: method is nil but Repo.UpdateIncognito was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		UserID    int64
+		Incognito bool
+	}{
+		Ctx:       ctx,
+		UserID:    userID,
+		Incognito: incognito,
+	}
+	mock.lockUpdateIncognito.Lock()
+	mock.calls.UpdateIncognito = append(mock.calls.UpdateIncognito, callInfo)
+	mock.lockUpdateIncognito.Unlock()
+	return mock.UpdateIncognitoFunc(ctx, userID, incognito)
+}
 
-	// calls tracks calls to the methods.
-	calls struct {
-		// CreateUser holds details about calls to the CreateUser method.
-		CreateUser []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// Req is the req argument value.
-			Req model.User
-		}
-		// GetRelatedUser holds details about calls to the GetRelatedUser method.
-		GetRelatedUser []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// ID is the id argument value.
-			ID int64
-		}
-		// GetUser holds details about calls to the GetUser method.
-		GetUser []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// Username is the username argument value.
-			Username string
-		}
-		// GetUserByID holds details about calls to the GetUserByID method.
-		GetUserByID []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// UserID is the userID argument value.
-			UserID int64
-		}
-		// UpdatePremiumStatus holds details about calls to the UpdatePremiumStatus method.
-		UpdatePremiumStatus []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// Req is the req argument value.
-			Req model.SubscribeRequest
-		}
+// UpdateIncognitoCalls gets all the calls that were made to UpdateIncognito.
+// Check the length with:
+//
+//	len(mockedRepo.UpdateIncognitoCalls())
+func (mock *RepoMock) UpdateIncognitoCalls() []struct {
+	Ctx       context.Context
+	UserID    int64
+	Incognito bool
+} {
+	var calls []struct {
+		Ctx       context.Context
+		UserID    int64
+		Incognito bool
 	}
-	lockCreateUser          sync.RWMutex
-	lockGetRelatedUser      sync.RWMutex
-	lockGetUser             sync.RWMutex
-	lockGetUserByID         sync.RWMutex
-	lockUpdatePremiumStatus sync.RWMutex
+	mock.lockUpdateIncognito.RLock()
+	calls = mock.calls.UpdateIncognito
+	mock.lockUpdateIncognito.RUnlock()
+	return calls
 }
 
-// CreateUser calls CreateUserFunc.
-func (mock *RepoMock) CreateUser(ctx context.Context, req model.User) error {
-	if mock.CreateUserFunc == nil {
-		panic("RepoMock.CreateUserFunc: method is nil but Repo.CreateUser was just called")
+// UpdateLastRead calls UpdateLastReadFunc.
+func (mock *RepoMock) UpdateLastRead(ctx context.Context, conversationID int64, userID int64, messageID int64) error {
+	if mock.UpdateLastReadFunc == nil {
+		panic("RepoMock.UpdateLastReadFunc: method is nil but Repo.UpdateLastRead was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
-		Req model.User
+		Ctx            context.Context
+		ConversationID int64
+		UserID         int64
+		MessageID      int64
 	}{
-		Ctx: ctx,
-		Req: req,
+		Ctx:            ctx,
+		ConversationID: conversationID,
+		UserID:         userID,
+		MessageID:      messageID,
 	}
-	mock.lockCreateUser.Lock()
-	mock.calls.CreateUser = append(mock.calls.CreateUser, callInfo)
-	mock.lockCreateUser.Unlock()
-	return mock.CreateUserFunc(ctx, req)
+	mock.lockUpdateLastRead.Lock()
+	mock.calls.UpdateLastRead = append(mock.calls.UpdateLastRead, callInfo)
+	mock.lockUpdateLastRead.Unlock()
+	return mock.UpdateLastReadFunc(ctx, conversationID, userID, messageID)
 }
 
-// CreateUserCalls gets all the calls that were made to CreateUser.
+// UpdateLastReadCalls gets all the calls that were made to UpdateLastRead.
 // Check the length with:
 //
-//	len(mockedRepo.CreateUserCalls())
-func (mock *RepoMock) CreateUserCalls() []struct {
-	Ctx context.Context
-	Req model.User
+//	len(mockedRepo.UpdateLastReadCalls())
+func (mock *RepoMock) UpdateLastReadCalls() []struct {
+	Ctx            context.Context
+	ConversationID int64
+	UserID         int64
+	MessageID      int64
 } {
 	var calls []struct {
-		Ctx context.Context
-		Req model.User
+		Ctx            context.Context
+		ConversationID int64
+		UserID         int64
+		MessageID      int64
 	}
-	mock.lockCreateUser.RLock()
-	calls = mock.calls.CreateUser
-	mock.lockCreateUser.RUnlock()
+	mock.lockUpdateLastRead.RLock()
+	calls = mock.calls.UpdateLastRead
+	mock.lockUpdateLastRead.RUnlock()
 	return calls
 }
 
-// GetRelatedUser calls GetRelatedUserFunc.
-func (mock *RepoMock) GetRelatedUser(ctx context.Context, id int64) ([]model.User, error) {
-	if mock.GetRelatedUserFunc == nil {
-		panic("RepoMock.GetRelatedUserFunc: method is nil but Repo.GetRelatedUser was just called")
+// UpdatePassword calls UpdatePasswordFunc.
+func (mock *RepoMock) UpdatePassword(ctx context.Context, userID int64, hashedPassword string) error {
+	if mock.UpdatePasswordFunc == nil {
+		panic("RepoMock.UpdatePasswordFunc: method is nil but Repo.UpdatePassword was just called")
 	}
 	callInfo := struct {
-		Ctx context.Context
-		ID  int64
+		Ctx            context.Context
+		UserID         int64
+		HashedPassword string
 	}{
-		Ctx: ctx,
-		ID:  id,
+		Ctx:            ctx,
+		UserID:         userID,
+		HashedPassword: hashedPassword,
 	}
-	mock.lockGetRelatedUser.Lock()
-	mock.calls.GetRelatedUser = append(mock.calls.GetRelatedUser, callInfo)
-	mock.lockGetRelatedUser.Unlock()
-	return mock.GetRelatedUserFunc(ctx, id)
+	mock.lockUpdatePassword.Lock()
+	mock.calls.UpdatePassword = append(mock.calls.UpdatePassword, callInfo)
+	mock.lockUpdatePassword.Unlock()
+	return mock.UpdatePasswordFunc(ctx, userID, hashedPassword)
 }
 
-// GetRelatedUserCalls gets all the calls that were made to GetRelatedUser.
+// UpdatePasswordCalls gets all the calls that were made to UpdatePassword.
 // Check the length with:
 //
-//	len(mockedRepo.GetRelatedUserCalls())
-func (mock *RepoMock) GetRelatedUserCalls() []struct {
-	Ctx context.Context
-	ID  int64
+//	len(mockedRepo.UpdatePasswordCalls())
+func (mock *RepoMock) UpdatePasswordCalls() []struct {
+	Ctx            context.Context
+	UserID         int64
+	HashedPassword string
 } {
 	var calls []struct {
-		Ctx context.Context
-		ID  int64
+		Ctx            context.Context
+		UserID         int64
+		HashedPassword string
 	}
-	mock.lockGetRelatedUser.RLock()
-	calls = mock.calls.GetRelatedUser
-	mock.lockGetRelatedUser.RUnlock()
+	mock.lockUpdatePassword.RLock()
+	calls = mock.calls.UpdatePassword
+	mock.lockUpdatePassword.RUnlock()
 	return calls
 }
 
-// GetUser calls GetUserFunc.
-func (mock *RepoMock) GetUser(ctx context.Context, username string) (*model.User, error) {
-	if mock.GetUserFunc == nil {
-		panic("RepoMock.GetUserFunc: method is nil but Repo.GetUser was just called")
+// UpdatePhotoOrder calls UpdatePhotoOrderFunc.
+func (mock *RepoMock) UpdatePhotoOrder(ctx context.Context, userID int64, photoIDs []int64) error {
+	if mock.UpdatePhotoOrderFunc == nil {
+		panic("RepoMock.UpdatePhotoOrderFunc: method is nil but Repo.UpdatePhotoOrder was just called")
 	}
 	callInfo := struct {
 		Ctx      context.Context
-		Username string
+		UserID   int64
+		PhotoIDs []int64
 	}{
 		Ctx:      ctx,
-		Username: username,
+		UserID:   userID,
+		PhotoIDs: photoIDs,
 	}
-	mock.lockGetUser.Lock()
-	mock.calls.GetUser = append(mock.calls.GetUser, callInfo)
-	mock.lockGetUser.Unlock()
-	return mock.GetUserFunc(ctx, username)
+	mock.lockUpdatePhotoOrder.Lock()
+	mock.calls.UpdatePhotoOrder = append(mock.calls.UpdatePhotoOrder, callInfo)
+	mock.lockUpdatePhotoOrder.Unlock()
+	return mock.UpdatePhotoOrderFunc(ctx, userID, photoIDs)
 }
 
-// GetUserCalls gets all the calls that were made to GetUser.
+// UpdatePhotoOrderCalls gets all the calls that were made to UpdatePhotoOrder.
 // Check the length with:
 //
-//	len(mockedRepo.GetUserCalls())
-func (mock *RepoMock) GetUserCalls() []struct {
+//	len(mockedRepo.UpdatePhotoOrderCalls())
+func (mock *RepoMock) UpdatePhotoOrderCalls() []struct {
 	Ctx      context.Context
-	Username string
+	UserID   int64
+	PhotoIDs []int64
 } {
 	var calls []struct {
 		Ctx      context.Context
-		Username string
+		UserID   int64
+		PhotoIDs []int64
 	}
-	mock.lockGetUser.RLock()
-	calls = mock.calls.GetUser
-	mock.lockGetUser.RUnlock()
+	mock.lockUpdatePhotoOrder.RLock()
+	calls = mock.calls.UpdatePhotoOrder
+	mock.lockUpdatePhotoOrder.RUnlock()
 	return calls
 }
 
-// GetUserByID calls GetUserByIDFunc.
-func (mock *RepoMock) GetUserByID(ctx context.Context, userID int64) (*model.User, error) {
-	if mock.GetUserByIDFunc == nil {
-		panic("RepoMock.GetUserByIDFunc: method is nil but Repo.GetUserByID was just called")
+// UpdatePrimaryPhoto calls UpdatePrimaryPhotoFunc.
+func (mock *RepoMock) UpdatePrimaryPhoto(ctx context.Context, userID int64, photoID int64) error {
+	if mock.UpdatePrimaryPhotoFunc == nil {
+		panic("RepoMock.UpdatePrimaryPhotoFunc: method is nil but Repo.UpdatePrimaryPhoto was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		UserID  int64
+		PhotoID int64
+	}{
+		Ctx:     ctx,
+		UserID:  userID,
+		PhotoID: photoID,
+	}
+	mock.lockUpdatePrimaryPhoto.Lock()
+	mock.calls.UpdatePrimaryPhoto = append(mock.calls.UpdatePrimaryPhoto, callInfo)
+	mock.lockUpdatePrimaryPhoto.Unlock()
+	return mock.UpdatePrimaryPhotoFunc(ctx, userID, photoID)
+}
+
+// UpdatePrimaryPhotoCalls gets all the calls that were made to UpdatePrimaryPhoto.
+// Check the length with:
+//
+//	len(mockedRepo.UpdatePrimaryPhotoCalls())
+func (mock *RepoMock) UpdatePrimaryPhotoCalls() []struct {
+	Ctx     context.Context
+	UserID  int64
+	PhotoID int64
+} {
+	var calls []struct {
+		Ctx     context.Context
+		UserID  int64
+		PhotoID int64
+	}
+	mock.lockUpdatePrimaryPhoto.RLock()
+	calls = mock.calls.UpdatePrimaryPhoto
+	mock.lockUpdatePrimaryPhoto.RUnlock()
+	return calls
+}
+
+// UpdateReportStatus calls UpdateReportStatusFunc.
+func (mock *RepoMock) UpdateReportStatus(ctx context.Context, req model.Report, fromStatus string) error {
+	if mock.UpdateReportStatusFunc == nil {
+		panic("RepoMock.UpdateReportStatusFunc: method is nil but Repo.UpdateReportStatus was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		Req        model.Report
+		FromStatus string
+	}{
+		Ctx:        ctx,
+		Req:        req,
+		FromStatus: fromStatus,
+	}
+	mock.lockUpdateReportStatus.Lock()
+	mock.calls.UpdateReportStatus = append(mock.calls.UpdateReportStatus, callInfo)
+	mock.lockUpdateReportStatus.Unlock()
+	return mock.UpdateReportStatusFunc(ctx, req, fromStatus)
+}
+
+// UpdateReportStatusCalls gets all the calls that were made to UpdateReportStatus.
+// Check the length with:
+//
+//	len(mockedRepo.UpdateReportStatusCalls())
+func (mock *RepoMock) UpdateReportStatusCalls() []struct {
+	Ctx        context.Context
+	Req        model.Report
+	FromStatus string
+} {
+	var calls []struct {
+		Ctx        context.Context
+		Req        model.Report
+		FromStatus string
+	}
+	mock.lockUpdateReportStatus.RLock()
+	calls = mock.calls.UpdateReportStatus
+	mock.lockUpdateReportStatus.RUnlock()
+	return calls
+}
+
+// UpdateRole calls UpdateRoleFunc.
+func (mock *RepoMock) UpdateRole(ctx context.Context, userID int64, role string) error {
+	if mock.UpdateRoleFunc == nil {
+		panic("RepoMock.UpdateRoleFunc: method is nil but Repo.UpdateRole was just called")
 	}
 	callInfo := struct {
 		Ctx    context.Context
 		UserID int64
+		Role   string
 	}{
 		Ctx:    ctx,
 		UserID: userID,
+		Role:   role,
 	}
-	mock.lockGetUserByID.Lock()
-	mock.calls.GetUserByID = append(mock.calls.GetUserByID, callInfo)
-	mock.lockGetUserByID.Unlock()
-	return mock.GetUserByIDFunc(ctx, userID)
+	mock.lockUpdateRole.Lock()
+	mock.calls.UpdateRole = append(mock.calls.UpdateRole, callInfo)
+	mock.lockUpdateRole.Unlock()
+	return mock.UpdateRoleFunc(ctx, userID, role)
 }
 
-// GetUserByIDCalls gets all the calls that were made to GetUserByID.
+// UpdateRoleCalls gets all the calls that were made to UpdateRole.
 // Check the length with:
 //
-//	len(mockedRepo.GetUserByIDCalls())
-func (mock *RepoMock) GetUserByIDCalls() []struct {
+//	len(mockedRepo.UpdateRoleCalls())
+func (mock *RepoMock) UpdateRoleCalls() []struct {
 	Ctx    context.Context
 	UserID int64
+	Role   string
 } {
 	var calls []struct {
 		Ctx    context.Context
 		UserID int64
+		Role   string
 	}
-	mock.lockGetUserByID.RLock()
-	calls = mock.calls.GetUserByID
-	mock.lockGetUserByID.RUnlock()
+	mock.lockUpdateRole.RLock()
+	calls = mock.calls.UpdateRole
+	mock.lockUpdateRole.RUnlock()
+	return calls
+}
+
+// UpdateSubscription calls UpdateSubscriptionFunc.
+func (mock *RepoMock) UpdateSubscription(ctx context.Context, req model.Subscription) error {
+	if mock.UpdateSubscriptionFunc == nil {
+		panic("RepoMock.UpdateSubscriptionFunc: method is nil but Repo.UpdateSubscription was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.Subscription
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockUpdateSubscription.Lock()
+	mock.calls.UpdateSubscription = append(mock.calls.UpdateSubscription, callInfo)
+	mock.lockUpdateSubscription.Unlock()
+	return mock.UpdateSubscriptionFunc(ctx, req)
+}
+
+// UpdateSubscriptionCalls gets all the calls that were made to UpdateSubscription.
+// Check the length with:
+//
+//	len(mockedRepo.UpdateSubscriptionCalls())
+func (mock *RepoMock) UpdateSubscriptionCalls() []struct {
+	Ctx context.Context
+	Req model.Subscription
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.Subscription
+	}
+	mock.lockUpdateSubscription.RLock()
+	calls = mock.calls.UpdateSubscription
+	mock.lockUpdateSubscription.RUnlock()
 	return calls
 }
 
-// UpdatePremiumStatus calls UpdatePremiumStatusFunc.
-func (mock *RepoMock) UpdatePremiumStatus(ctx context.Context, req model.SubscribeRequest) error {
-	if mock.UpdatePremiumStatusFunc == nil {
-		panic("RepoMock.UpdatePremiumStatusFunc: method is nil but Repo.UpdatePremiumStatus was just called")
+// UpsertSwipe calls UpsertSwipeFunc.
+func (mock *RepoMock) UpsertSwipe(ctx context.Context, req model.SwipeRequest) error {
+	if mock.UpsertSwipeFunc == nil {
+		panic("RepoMock.UpsertSwipeFunc: method is nil but Repo.UpsertSwipe was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Req model.SubscribeRequest
+		Req model.SwipeRequest
 	}{
 		Ctx: ctx,
 		Req: req,
 	}
-	mock.lockUpdatePremiumStatus.Lock()
-	mock.calls.UpdatePremiumStatus = append(mock.calls.UpdatePremiumStatus, callInfo)
-	mock.lockUpdatePremiumStatus.Unlock()
-	return mock.UpdatePremiumStatusFunc(ctx, req)
+	mock.lockUpsertSwipe.Lock()
+	mock.calls.UpsertSwipe = append(mock.calls.UpsertSwipe, callInfo)
+	mock.lockUpsertSwipe.Unlock()
+	return mock.UpsertSwipeFunc(ctx, req)
 }
 
-// UpdatePremiumStatusCalls gets all the calls that were made to UpdatePremiumStatus.
+// UpsertSwipeCalls gets all the calls that were made to UpsertSwipe.
 // Check the length with:
 //
-//	len(mockedRepo.UpdatePremiumStatusCalls())
-func (mock *RepoMock) UpdatePremiumStatusCalls() []struct {
+//	len(mockedRepo.UpsertSwipeCalls())
+func (mock *RepoMock) UpsertSwipeCalls() []struct {
 	Ctx context.Context
-	Req model.SubscribeRequest
+	Req model.SwipeRequest
 } {
 	var calls []struct {
 		Ctx context.Context
-		Req model.SubscribeRequest
+		Req model.SwipeRequest
+	}
+	mock.lockUpsertSwipe.RLock()
+	calls = mock.calls.UpsertSwipe
+	mock.lockUpsertSwipe.RUnlock()
+	return calls
+}
+
+// UpsertTOTPSecret calls UpsertTOTPSecretFunc.
+func (mock *RepoMock) UpsertTOTPSecret(ctx context.Context, userID int64, secret string) error {
+	if mock.UpsertTOTPSecretFunc == nil {
+		panic("RepoMock.UpsertTOTPSecretFunc: method is nil but Repo.UpsertTOTPSecret was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+		Secret string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Secret: secret,
+	}
+	mock.lockUpsertTOTPSecret.Lock()
+	mock.calls.UpsertTOTPSecret = append(mock.calls.UpsertTOTPSecret, callInfo)
+	mock.lockUpsertTOTPSecret.Unlock()
+	return mock.UpsertTOTPSecretFunc(ctx, userID, secret)
+}
+
+// UpsertTOTPSecretCalls gets all the calls that were made to UpsertTOTPSecret.
+// Check the length with:
+//
+//	len(mockedRepo.UpsertTOTPSecretCalls())
+func (mock *RepoMock) UpsertTOTPSecretCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+	Secret string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+		Secret string
+	}
+	mock.lockUpsertTOTPSecret.RLock()
+	calls = mock.calls.UpsertTOTPSecret
+	mock.lockUpsertTOTPSecret.RUnlock()
+	return calls
+}
+
+// UseRecoveryCode calls UseRecoveryCodeFunc.
+func (mock *RepoMock) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) error {
+	if mock.UseRecoveryCodeFunc == nil {
+		panic("RepoMock.UseRecoveryCodeFunc: method is nil but Repo.UseRecoveryCode was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   int64
+		CodeHash string
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		CodeHash: codeHash,
+	}
+	mock.lockUseRecoveryCode.Lock()
+	mock.calls.UseRecoveryCode = append(mock.calls.UseRecoveryCode, callInfo)
+	mock.lockUseRecoveryCode.Unlock()
+	return mock.UseRecoveryCodeFunc(ctx, userID, codeHash)
+}
+
+// UseRecoveryCodeCalls gets all the calls that were made to UseRecoveryCode.
+// Check the length with:
+//
+//	len(mockedRepo.UseRecoveryCodeCalls())
+func (mock *RepoMock) UseRecoveryCodeCalls() []struct {
+	Ctx      context.Context
+	UserID   int64
+	CodeHash string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   int64
+		CodeHash string
+	}
+	mock.lockUseRecoveryCode.RLock()
+	calls = mock.calls.UseRecoveryCode
+	mock.lockUseRecoveryCode.RUnlock()
+	return calls
+}
+
+// UseTOTPStep calls UseTOTPStepFunc.
+func (mock *RepoMock) UseTOTPStep(ctx context.Context, userID int64, step int64) error {
+	if mock.UseTOTPStepFunc == nil {
+		panic("RepoMock.UseTOTPStepFunc: method is nil but Repo.UseTOTPStep was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+		Step   int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Step:   step,
+	}
+	mock.lockUseTOTPStep.Lock()
+	mock.calls.UseTOTPStep = append(mock.calls.UseTOTPStep, callInfo)
+	mock.lockUseTOTPStep.Unlock()
+	return mock.UseTOTPStepFunc(ctx, userID, step)
+}
+
+// UseTOTPStepCalls gets all the calls that were made to UseTOTPStep.
+// Check the length with:
+//
+//	len(mockedRepo.UseTOTPStepCalls())
+func (mock *RepoMock) UseTOTPStepCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+	Step   int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+		Step   int64
 	}
-	mock.lockUpdatePremiumStatus.RLock()
-	calls = mock.calls.UpdatePremiumStatus
-	mock.lockUpdatePremiumStatus.RUnlock()
+	mock.lockUseTOTPStep.RLock()
+	calls = mock.calls.UseTOTPStep
+	mock.lockUseTOTPStep.RUnlock()
 	return calls
 }
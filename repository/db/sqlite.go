@@ -19,11 +19,37 @@ func NewSQLiteRepository() Repo {
 	}
 	defer db.Close()
 
-	sqlStmt := insertUserTable
-
-	_, err = db.Exec(sqlStmt)
-	if err != nil {
-		log.Fatalf("%q: %s\n", err, sqlStmt)
+	for _, sqlStmt := range []string{
+		insertUserTable,
+		insertSubscriptionTable,
+		insertPaymentEventTable,
+		insertPromoCodeTable,
+		insertPromoRedemptionTable,
+		insertReferralCodeTable,
+		insertReferralTable,
+		insertBoostTable,
+		insertBoostBalanceTable,
+		insertBoostPurchaseTable,
+		insertLedgerTable,
+		insertInvoiceTable,
+		insertPhotoTable,
+		insertAccountStatusHistoryTable,
+		insertAuditLogTable,
+		insertUserTOTPTable,
+		insertRecoveryCodeTable,
+		insertUserIdentityTable,
+		insertSwipeTable,
+		insertConversationTable,
+		insertMessageTable,
+		insertConversationReadTable,
+		insertProfileViewTable,
+		insertBlockTable,
+		insertReportTable,
+	} {
+		_, err = db.Exec(sqlStmt)
+		if err != nil {
+			log.Fatalf("%q: %s\n", err, sqlStmt)
+		}
 	}
 	return &sqliteRepo{}
 }
@@ -5,11 +5,13 @@ import (
 	"database/sql"
 	"errors"
 	"log"
+	"time"
 
 	"github.com/egnptr/dating-app/model"
+	"github.com/mattn/go-sqlite3"
 )
 
-func (*sqliteRepo) CreateUser(ctx context.Context, req model.User) (err error) {
+func (*sqliteRepo) CreateUser(ctx context.Context, req model.User) (userID int64, err error) {
 	db, err := sql.Open("sqlite3", "./testing.db")
 	if err != nil {
 		log.Println(err.Error())
@@ -21,13 +23,66 @@ func (*sqliteRepo) CreateUser(ctx context.Context, req model.User) (err error) {
 		log.Println(err.Error())
 		return
 	}
+	defer tx.Rollback()
 	stmt, err := tx.Prepare(createUser)
 	if err != nil {
 		log.Println(err.Error())
 		return
 	}
 	defer stmt.Close()
-	_, err = stmt.Exec(req.Username, req.Password, req.FullName, req.Email)
+	res, err := stmt.Exec(req.Username, req.Password, req.FullName, req.Email)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	userID, err = res.LastInsertId()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+func (*sqliteRepo) InsertSubscription(ctx context.Context, req model.Subscription) (subscriptionID int64, err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(insertSubscription)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	res, err := stmt.Exec(
+		req.UserID,
+		req.Plan,
+		req.Status,
+		req.CurrentPeriodStart.UTC(),
+		req.CurrentPeriodEnd.UTC(),
+		req.AutoRenew,
+		req.CancelAtPeriodEnd,
+		sql.NullString{String: req.ProviderSubscriptionID, Valid: req.ProviderSubscriptionID != ""},
+		req.Tier,
+		req.Store,
+	)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	subscriptionID, err = res.LastInsertId()
 	if err != nil {
 		log.Println(err.Error())
 		return
@@ -37,7 +92,7 @@ func (*sqliteRepo) CreateUser(ctx context.Context, req model.User) (err error) {
 	return
 }
 
-func (*sqliteRepo) UpdatePremiumStatus(ctx context.Context, req model.SubscribeRequest) (err error) {
+func (*sqliteRepo) UpdateSubscription(ctx context.Context, req model.Subscription) (err error) {
 	db, err := sql.Open("sqlite3", "./testing.db")
 	if err != nil {
 		log.Println(err.Error())
@@ -49,13 +104,29 @@ func (*sqliteRepo) UpdatePremiumStatus(ctx context.Context, req model.SubscribeR
 		log.Println(err.Error())
 		return
 	}
-	stmt, err := tx.Prepare(updatePremiumStatus)
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(updateSubscription)
 	if err != nil {
 		log.Println(err.Error())
 		return
 	}
 	defer stmt.Close()
-	res, err := stmt.Exec(req.Subscribe, req.UserID)
+	var graceUntil sql.NullTime
+	if req.GraceUntil != nil {
+		graceUntil = sql.NullTime{Time: req.GraceUntil.UTC(), Valid: true}
+	}
+
+	res, err := stmt.Exec(
+		req.Tier,
+		req.Plan,
+		req.Status,
+		req.CurrentPeriodStart.UTC(),
+		req.CurrentPeriodEnd.UTC(),
+		req.AutoRenew,
+		req.CancelAtPeriodEnd,
+		graceUntil,
+		req.ID,
+	)
 	if err != nil {
 		log.Println(err.Error())
 		return
@@ -63,10 +134,1569 @@ func (*sqliteRepo) UpdatePremiumStatus(ctx context.Context, req model.SubscribeR
 
 	rowsAffected, err := res.RowsAffected()
 	if rowsAffected == 0 {
-		err = errors.New("error failed to update subscription status")
+		err = model.NotFoundErr
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+// InsertPaymentEvent records the webhook event as handled. It reports false
+// when the event was recorded before
+func (*sqliteRepo) InsertPaymentEvent(ctx context.Context, req model.PaymentEvent) (inserted bool, err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(insertPaymentEvent)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	res, err := stmt.Exec(req.ID, req.Type, req.SubscriptionID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return rowsAffected > 0, nil
+}
+
+func (*sqliteRepo) DeletePaymentEvent(ctx context.Context, eventID string) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec(deletePaymentEvent, eventID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+func (*sqliteRepo) InsertPromoCode(ctx context.Context, req model.PromoCode) (promoCodeID int64, err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	var expiresAt sql.NullTime
+	if req.ExpiresAt != nil {
+		expiresAt = sql.NullTime{Time: req.ExpiresAt.UTC(), Valid: true}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(insertPromoCode)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	res, err := stmt.Exec(
+		req.Code,
+		req.Kind,
+		req.Value,
+		req.Tier,
+		req.MaxUses,
+		expiresAt,
+		req.CreatedBy,
+	)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	promoCodeID, err = res.LastInsertId()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+// RedeemPromoCode records the user redeeming the code and counts the use.
+// Each user redeems a code once and never past its cap
+func (*sqliteRepo) RedeemPromoCode(ctx context.Context, req model.PromoRedemption) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	var appliedAt sql.NullTime
+	if req.AppliedAt != nil {
+		appliedAt = sql.NullTime{Time: req.AppliedAt.UTC(), Valid: true}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	res, err := tx.Exec(insertPromoRedemption, req.PromoCodeID, req.UserID, appliedAt)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	} else if rowsAffected == 0 {
+		err = model.AlreadyRedeemedErr
+		return
+	}
+
+	res, err = tx.Exec(usePromoCode, req.PromoCodeID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err = res.RowsAffected()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	} else if rowsAffected == 0 {
+		err = model.PromoCodeUsedUpErr
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+// UnredeemPromoCode takes back a redemption along with the use of the code it
+// took up
+func (*sqliteRepo) UnredeemPromoCode(ctx context.Context, req model.PromoRedemption) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	res, err := tx.Exec(deletePromoRedemption, req.PromoCodeID, req.UserID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	} else if rowsAffected == 0 {
+		err = model.NotFoundErr
+		return
+	}
+
+	_, err = tx.Exec(unusePromoCode, req.PromoCodeID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+// ApplyPendingDiscounts marks the discounts the user redeemed as used up by
+// the subscription that started
+func (*sqliteRepo) ApplyPendingDiscounts(ctx context.Context, userID int64, at time.Time) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec(applyPendingDiscounts, at.UTC(), userID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+// GetOrCreateReferralCode returns the referral code of the user, storing the
+// given one on first use
+func (*sqliteRepo) GetOrCreateReferralCode(ctx context.Context, userID int64, code string) (referralCode string, err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec(insertReferralCode, userID, code)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	err = tx.QueryRow(getReferralCode, userID).Scan(&referralCode)
+	if err != nil {
+		log.Println(err.Error())
 		return
 	}
 
 	tx.Commit()
 	return
 }
+
+// InsertReferral records who referred the user. It reports false when the
+// user was referred before
+func (*sqliteRepo) InsertReferral(ctx context.Context, req model.Referral) (inserted bool, err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	res, err := tx.Exec(insertReferral, req.RefereeID, req.ReferrerID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return rowsAffected > 0, nil
+}
+
+// RewardReferral marks the referral of the user as rewarded. It reports
+// false when it was rewarded before, so the reward is only granted once
+func (*sqliteRepo) RewardReferral(ctx context.Context, refereeID int64, at time.Time) (rewarded bool, err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	res, err := tx.Exec(rewardReferral, at.UTC(), refereeID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return rowsAffected > 0, nil
+}
+
+func (*sqliteRepo) UpdatePassword(ctx context.Context, userID int64, hashedPassword string) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(updatePassword)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	res, err := stmt.Exec(hashedPassword, userID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if rowsAffected == 0 {
+		err = errors.New("error failed to update password")
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+func (*sqliteRepo) UpdateEmail(ctx context.Context, userID int64, email string) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(updateEmail)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	res, err := stmt.Exec(email, userID)
+	if isUniqueViolation(err) {
+		return model.EmailTakenErr
+	} else if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if rowsAffected == 0 {
+		err = errors.New("error failed to update email")
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+func (*sqliteRepo) UpdateEmailVerified(ctx context.Context, userID int64, email string) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(updateEmailVerified)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	res, err := stmt.Exec(userID, email)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	// The email may have changed again since the verification was sent
+	rowsAffected, err := res.RowsAffected()
+	if rowsAffected == 0 {
+		err = errors.New("error failed to verify email")
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+func (*sqliteRepo) InsertAuditLog(ctx context.Context, req model.AuditLog) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(insertAuditLog)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(req.UserID, req.ActorID, req.Action, req.Detail)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+func (*sqliteRepo) UpsertTOTPSecret(ctx context.Context, userID int64, secret string) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(upsertTOTPSecret)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(userID, secret)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+func (*sqliteRepo) EnableTOTP(ctx context.Context, userID int64, recoveryCodeHashes []string) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	res, err := tx.Exec(enableTOTP, userID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if rowsAffected == 0 {
+		err = model.TwoFactorNotEnrolledErr
+		return
+	}
+
+	// Enabling again replaces every recovery code handed out before
+	_, err = tx.Exec(deleteRecoveryCodes, userID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	stmt, err := tx.Prepare(insertRecoveryCode)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	for _, codeHash := range recoveryCodeHashes {
+		_, err = stmt.Exec(userID, codeHash)
+		if err != nil {
+			log.Println(err.Error())
+			return
+		}
+	}
+
+	tx.Commit()
+	return
+}
+
+func (*sqliteRepo) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(useRecoveryCode)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	res, err := stmt.Exec(userID, codeHash)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if rowsAffected == 0 {
+		err = model.UnauthorizedErr
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+// UseTOTPStep records the time step of an accepted code, failing when it is
+// not later than the last one so no code is accepted twice
+func (*sqliteRepo) UseTOTPStep(ctx context.Context, userID int64, step int64) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(useTOTPStep)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	res, err := stmt.Exec(step, userID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if rowsAffected == 0 {
+		err = model.UnauthorizedErr
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+func (*sqliteRepo) InsertIdentity(ctx context.Context, req model.Identity) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(insertIdentity)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(req.UserID, req.Provider, req.Subject, req.Email)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+func (*sqliteRepo) UpsertSwipe(ctx context.Context, req model.SwipeRequest) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(upsertSwipe)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(req.UserID, req.SwipedUserID, req.SwipeStatus)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+func (*sqliteRepo) DeleteSwipe(ctx context.Context, swiperID, swipedID int64) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(deleteSwipe)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	res, err := stmt.Exec(swiperID, swipedID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if rowsAffected == 0 {
+		err = model.NotFoundErr
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+// GetOrCreateConversation returns the conversation between both users,
+// creating it on first use. Each pair of users has a single conversation
+func (*sqliteRepo) GetOrCreateConversation(ctx context.Context, userID, otherUserID int64) (conversation *model.Conversation, err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	userAID, userBID := userID, otherUserID
+	if userAID > userBID {
+		userAID, userBID = userBID, userAID
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec(insertConversation, userAID, userBID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	conversation = &model.Conversation{
+		UserAID: userAID,
+		UserBID: userBID,
+		PeerID:  otherUserID,
+	}
+	err = tx.QueryRow(getConversationByUsers, userAID, userBID).Scan(
+		&conversation.ID,
+		&conversation.CreatedAt,
+	)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	tx.Commit()
+	return
+}
+
+func (*sqliteRepo) InsertMessage(ctx context.Context, req model.Message) (messageID int64, err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(insertMessage)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	res, err := stmt.Exec(req.ConversationID, req.SenderID, req.Body)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	messageID, err = res.LastInsertId()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+func (*sqliteRepo) DeleteMessage(ctx context.Context, messageID, senderID int64) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(deleteMessage)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	res, err := stmt.Exec(messageID, senderID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if rowsAffected == 0 {
+		err = model.NotFoundErr
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+func (*sqliteRepo) UpdateLastRead(ctx context.Context, conversationID, userID, messageID int64) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(upsertConversationRead)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(conversationID, userID, messageID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+func (*sqliteRepo) UpdateIncognito(ctx context.Context, userID int64, incognito bool) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(updateIncognito)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	res, err := stmt.Exec(incognito, userID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if rowsAffected == 0 {
+		err = errors.New("error failed to update incognito mode")
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+// InsertProfileViews stores a batch of buffered views, views already kept
+// for the same day are skipped
+func (*sqliteRepo) InsertProfileViews(ctx context.Context, views []model.ProfileView) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(insertProfileView)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	for _, view := range views {
+		viewedAt := view.ViewedAt.UTC()
+		_, err = stmt.Exec(view.ViewerID, view.ViewedID, viewedAt.Format("2006-01-02"), viewedAt)
+		if err != nil {
+			log.Println(err.Error())
+			return
+		}
+	}
+
+	tx.Commit()
+	return
+}
+
+// InsertBlock blocks the user and removes the likes between both users in
+// the same transaction
+func (*sqliteRepo) InsertBlock(ctx context.Context, blockerID, blockedID int64) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec(insertBlock, blockerID, blockedID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	_, err = tx.Exec(deleteLikesBetween, blockerID, blockedID, model.SwipeStatusLike, model.SwipeStatusSuperLike)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+func (*sqliteRepo) DeleteBlock(ctx context.Context, blockerID, blockedID int64) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(deleteBlock)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	res, err := stmt.Exec(blockerID, blockedID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if rowsAffected == 0 {
+		err = model.NotFoundErr
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+func (*sqliteRepo) InsertReport(ctx context.Context, req model.Report) (reportID int64, err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(insertReport)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	res, err := stmt.Exec(req.ReporterID, req.ReportedID, req.Reason, req.Detail)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	reportID, err = res.LastInsertId()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+// UpdateReportStatus moves the report out of fromStatus, failing with
+// model.ReportConflictErr when someone else moved it first
+func (*sqliteRepo) UpdateReportStatus(ctx context.Context, req model.Report, fromStatus string) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(updateReportStatus)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	assigneeID := sql.NullInt64{Int64: req.AssigneeID, Valid: req.AssigneeID != 0}
+	res, err := stmt.Exec(req.Status, assigneeID, req.Resolution, req.ID, fromStatus)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if rowsAffected == 0 {
+		err = model.ReportConflictErr
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+func (*sqliteRepo) UpdateHidden(ctx context.Context, userID int64, hidden bool) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(updateHidden)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	_, err = stmt.Exec(hidden, userID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+func (*sqliteRepo) UpdateRole(ctx context.Context, userID int64, role string) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(updateRole)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	res, err := stmt.Exec(role, userID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if rowsAffected == 0 {
+		err = model.NotFoundErr
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+// UpdateAccountStatus changes the account status of the user and keeps the
+// change in the history in the same transaction
+func (*sqliteRepo) UpdateAccountStatus(ctx context.Context, req model.AccountStatusChange) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	var until sql.NullTime
+	if req.Until != nil {
+		until = sql.NullTime{Time: req.Until.UTC(), Valid: true}
+	}
+	res, err := tx.Exec(updateAccountStatus, req.Status, req.Reason, until, req.UserID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if rowsAffected == 0 {
+		err = model.NotFoundErr
+		return
+	}
+
+	_, err = tx.Exec(insertAccountStatusHistory, req.UserID, req.Status, req.Reason, until, req.ActorID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+// InsertBoost activates a boost for the user, taking a bought one from the
+// balance when that is the source. It fails with model.BoostActiveErr while
+// another boost of the user is running
+func (*sqliteRepo) InsertBoost(ctx context.Context, req model.Boost) (boostID int64, err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+
+	if req.Source == model.BoostSourcePurchased {
+		res, errExec := tx.Exec(useBoostBalance, req.UserID)
+		if errExec != nil {
+			err = errExec
+			log.Println(err.Error())
+			return
+		}
+
+		rowsAffected, errRows := res.RowsAffected()
+		if errRows != nil {
+			err = errRows
+			log.Println(err.Error())
+			return
+		} else if rowsAffected == 0 {
+			err = model.BoostLimitErr
+			return
+		}
+	}
+
+	res, err := tx.Exec(insertBoost, req.UserID, req.Source, req.ActivatedAt.UTC(), req.ExpiresAt.UTC())
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	} else if rowsAffected == 0 {
+		err = model.BoostActiveErr
+		return
+	}
+
+	boostID, err = res.LastInsertId()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+// DeleteBoost takes back a boost that could not be started, giving a bought
+// one back to the balance
+func (*sqliteRepo) DeleteBoost(ctx context.Context, req model.Boost) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec(deleteBoost, req.ID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	if req.Source == model.BoostSourcePurchased {
+		_, err = tx.Exec(addBoostBalance, req.UserID, 1)
+		if err != nil {
+			log.Println(err.Error())
+			return
+		}
+	}
+
+	tx.Commit()
+	return
+}
+
+// CreditBoostPurchase adds the boosts of a purchase to the balance of the
+// user, once per purchase. It returns the user the purchase was credited to,
+// which is someone else when another user sent the receipt first
+func (*sqliteRepo) CreditBoostPurchase(ctx context.Context, req model.BoostPurchase) (userID int64, err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	res, err := tx.Exec(insertBoostPurchase, req.ID, req.UserID, req.Boosts)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	} else if rowsAffected == 0 {
+		if err = tx.QueryRow(getBoostPurchaseUser, req.ID).Scan(&userID); err != nil {
+			log.Println(err.Error())
+		}
+		return
+	}
+
+	_, err = tx.Exec(addBoostBalance, req.UserID, req.Boosts)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return req.UserID, nil
+}
+
+// InsertPhoto adds the photo after the others of the user, unless the user
+// has limit photos already
+func (*sqliteRepo) InsertPhoto(ctx context.Context, req model.Photo, limit int) (photoID int64, err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	res, err := tx.Exec(insertPhoto, req.UserID, req.Key, req.ThumbnailKey, req.Width, req.Height, limit)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	} else if rowsAffected == 0 {
+		err = model.PhotoLimitErr
+		return
+	}
+
+	photoID, err = res.LastInsertId()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+// UpdatePhotoOrder puts the photos of the user in the order of photoIDs
+func (*sqliteRepo) UpdatePhotoOrder(ctx context.Context, userID int64, photoIDs []int64) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	stmt, err := tx.Prepare(updatePhotoPosition)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer stmt.Close()
+	for position, photoID := range photoIDs {
+		res, errExec := stmt.Exec(position, photoID, userID)
+		if errExec != nil {
+			err = errExec
+			log.Println(err.Error())
+			return
+		}
+
+		rowsAffected, errRows := res.RowsAffected()
+		if errRows != nil {
+			err = errRows
+			log.Println(err.Error())
+			return
+		} else if rowsAffected == 0 {
+			err = model.NotFoundErr
+			return
+		}
+	}
+
+	tx.Commit()
+	return
+}
+
+// UpdatePrimaryPhoto makes the photo the primary one of the user
+func (*sqliteRepo) UpdatePrimaryPhoto(ctx context.Context, userID, photoID int64) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	res, err := tx.Exec(updatePrimaryPhoto, photoID, userID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	} else if rowsAffected == 0 {
+		err = model.NotFoundErr
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+// DeletePhoto removes the photo of the user, the first photo left becoming
+// primary in its place when it was
+func (*sqliteRepo) DeletePhoto(ctx context.Context, userID, photoID int64) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	res, err := tx.Exec(deletePhoto, photoID, userID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	} else if rowsAffected == 0 {
+		err = model.NotFoundErr
+		return
+	}
+
+	_, err = tx.Exec(promotePrimaryPhoto, userID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+// UpdateDunningNotified records when the user of the past due subscription
+// was last reminded to pay
+func (*sqliteRepo) UpdateDunningNotified(ctx context.Context, subscriptionID int64, at time.Time) (err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec(updateDunningNotified, at.UTC(), subscriptionID)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	tx.Commit()
+	return
+}
+
+// InsertLedgerEntry adds the entry to the ledger along with its invoice, when
+// it has one. An entry whose reference is in the ledger already is not added
+// again
+func (*sqliteRepo) InsertLedgerEntry(ctx context.Context, entry model.LedgerEntry, invoice *model.Invoice) (inserted bool, err error) {
+	db, err := sql.Open("sqlite3", "./testing.db")
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	nullTime := func(t *time.Time) sql.NullTime {
+		if t == nil {
+			return sql.NullTime{}
+		}
+		return sql.NullTime{Time: t.UTC(), Valid: true}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	defer tx.Rollback()
+	res, err := tx.Exec(insertLedgerEntry,
+		entry.UserID,
+		sql.NullInt64{Int64: entry.SubscriptionID, Valid: entry.SubscriptionID != 0},
+		entry.Kind,
+		entry.Tier,
+		entry.Plan,
+		entry.Description,
+		entry.Amount,
+		entry.Currency,
+		nullTime(entry.PeriodStart),
+		nullTime(entry.PeriodEnd),
+		entry.Reference,
+	)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		log.Println(err.Error())
+		return
+	} else if rowsAffected == 0 {
+		return
+	}
+
+	if invoice != nil {
+		entryID, errID := res.LastInsertId()
+		if errID != nil {
+			err = errID
+			log.Println(err.Error())
+			return
+		}
+
+		_, err = tx.Exec(insertInvoice,
+			entry.UserID,
+			entryID,
+			invoice.Subtotal,
+			invoice.TaxName,
+			invoice.TaxRate,
+			invoice.Tax,
+			invoice.Total,
+			invoice.Currency,
+		)
+		if err != nil {
+			log.Println(err.Error())
+			return
+		}
+	}
+
+	tx.Commit()
+	return true, nil
+}
+
+// isUniqueViolation reports whether the statement broke a UNIQUE constraint,
+// e.g. when another request took the same value first
+func isUniqueViolation(err error) bool {
+	var sqliteErr sqlite3.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+}
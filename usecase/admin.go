@@ -0,0 +1,307 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+)
+
+// HasRole reports whether the user is granted the role. Users configured as
+// admins are granted every role, so there is always someone to hand out roles.
+func (s *usecase) HasRole(ctx context.Context, userID int64, role string) (ok bool, err error) {
+	if s.Config.AdminUserIDs[userID] {
+		return true, nil
+	}
+
+	user, err := s.RepoDB.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	return user.HasRole(role), nil
+}
+
+func (s *usecase) SearchUsers(ctx context.Context, req model.SearchUsersRequest) (res []model.User, err error) {
+	if req.Limit <= 0 || req.Limit > usersSearchMaxLimit {
+		req.Limit = usersSearchDefaultLimit
+	}
+
+	users, err := s.RepoDB.SearchUsers(ctx, req.Query, req.Limit)
+	if err != nil {
+		log.Println("error when searching users from db")
+		return
+	}
+
+	// Searching reads the accounts of everyone matched, so it is recorded
+	// without a user of its own
+	err = s.audit(ctx, req.ActorID, 0, model.AuditActionUsersSearched, fmt.Sprintf("query=%q", req.Query))
+	if err != nil {
+		return
+	}
+
+	res = users
+	return
+}
+
+func (s *usecase) GetUserProfile(ctx context.Context, req model.AdminUserRequest) (res model.AdminUserProfile, err error) {
+	user, err := s.RepoDB.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	stats, err := s.RepoDB.GetSwipeStats(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when fetching swipe stats from db")
+		return
+	}
+
+	err = s.audit(ctx, req.ActorID, req.UserID, model.AuditActionProfileViewed, "")
+	if err != nil {
+		return
+	}
+
+	user.Password = ""
+	res.User = *user
+	res.SwipeStats = *stats
+	return
+}
+
+func (s *usecase) SetPremium(ctx context.Context, req model.AdminPremiumRequest) (err error) {
+	if req.Tier == "" {
+		req.Tier = model.TierPlus
+	}
+	if req.IsPremium && !model.PaidTiers[req.Tier] {
+		err = model.InvalidPlanErr
+		return
+	}
+
+	_, err = s.RepoDB.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	// Granting premium starts a subscription to the tier for a month, unless
+	// the user already has one
+	if req.IsPremium {
+		err = s.grantSubscription(ctx, req.UserID, req.Tier, model.PlanMonthly)
+		if err == model.AlreadySubscribedErr {
+			err = nil
+		}
+	} else {
+		err = s.endSubscription(ctx, req.UserID)
+	}
+	if err != nil {
+		return
+	}
+
+	return s.audit(ctx, req.ActorID, req.UserID, model.AuditActionPremiumChanged, fmt.Sprintf("is_premium=%t tier=%s", req.IsPremium, req.Tier))
+}
+
+// BanUser bans the user for good and logs them out everywhere
+func (s *usecase) BanUser(ctx context.Context, req model.AdminUserRequest) (err error) {
+	req.Until = nil
+	return s.changeAccountStatus(ctx, req, model.AccountStatusBanned, model.AuditActionBanned)
+}
+
+// SuspendUser keeps the user from logging in until the suspension ends and
+// logs them out everywhere
+func (s *usecase) SuspendUser(ctx context.Context, req model.AdminUserRequest) (err error) {
+	if req.Until == nil || !req.Until.After(time.Now()) {
+		err = model.InvalidUntilErr
+		return
+	}
+
+	return s.changeAccountStatus(ctx, req, model.AccountStatusSuspended, model.AuditActionSuspended)
+}
+
+// ShadowbanUser hides the user from everyone without them noticing, so their
+// sessions are kept
+func (s *usecase) ShadowbanUser(ctx context.Context, req model.AdminUserRequest) (err error) {
+	req.Until = nil
+	return s.changeAccountStatus(ctx, req, model.AccountStatusShadowbanned, model.AuditActionShadowbanned)
+}
+
+// UnbanUser lifts a ban, suspension or shadowban
+func (s *usecase) UnbanUser(ctx context.Context, req model.AdminUserRequest) (err error) {
+	user, err := s.RepoDB.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	if s.isStaff(user) {
+		err = model.ForbiddenErr
+		return
+	}
+
+	err = s.RepoDB.UpdateAccountStatus(ctx, model.AccountStatusChange{
+		UserID:  req.UserID,
+		Status:  model.AccountStatusActive,
+		Reason:  req.Reason,
+		ActorID: req.ActorID,
+	})
+	if err != nil {
+		log.Println("error when updating account status to db")
+		return
+	}
+
+	return s.audit(ctx, req.ActorID, req.UserID, model.AuditActionUnbanned, req.Reason)
+}
+
+func (s *usecase) GetAccountStatusHistory(ctx context.Context, req model.AdminUserRequest) (res []model.AccountStatusChange, err error) {
+	history, err := s.RepoDB.GetAccountStatusHistory(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when fetching account status history from db")
+		return
+	}
+
+	err = s.audit(ctx, req.ActorID, req.UserID, model.AuditActionHistoryViewed, "")
+	if err != nil {
+		return
+	}
+
+	res = history
+	return
+}
+
+// changeAccountStatus takes action against a user. Staff have to be demoted
+// before action can be taken against them.
+func (s *usecase) changeAccountStatus(ctx context.Context, req model.AdminUserRequest, status, action string) (err error) {
+	user, err := s.RepoDB.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	if s.isStaff(user) {
+		err = model.ForbiddenErr
+		return
+	}
+
+	err = s.RepoDB.UpdateAccountStatus(ctx, model.AccountStatusChange{
+		UserID:  req.UserID,
+		Status:  status,
+		Reason:  req.Reason,
+		Until:   req.Until,
+		ActorID: req.ActorID,
+	})
+	if err != nil {
+		log.Println("error when updating account status to db")
+		return
+	}
+
+	if status != model.AccountStatusShadowbanned {
+		err = s.RepoCache.DeleteSessions(ctx, req.UserID)
+		if err != nil {
+			log.Println("error when deleting sessions from cache")
+			return
+		}
+	}
+
+	detail := req.Reason
+	if req.Until != nil {
+		detail = fmt.Sprintf("until %s: %s", req.Until.Format(time.RFC3339), req.Reason)
+	}
+	return s.audit(ctx, req.ActorID, req.UserID, action, detail)
+}
+
+// checkAccountStatus rejects users who are not allowed to use the app. A
+// suspension that is over is lifted on the way.
+func (s *usecase) checkAccountStatus(ctx context.Context, user *model.User) (err error) {
+	switch user.AccountStatus {
+	case model.AccountStatusBanned:
+		err = model.BannedErr
+		return
+	case model.AccountStatusSuspended:
+		if user.StatusUntil != nil && user.StatusUntil.After(time.Now()) {
+			err = &model.SuspendedError{
+				Reason: user.StatusReason,
+				Until:  *user.StatusUntil,
+			}
+			return
+		}
+
+		err = s.RepoDB.UpdateAccountStatus(ctx, model.AccountStatusChange{
+			UserID: user.UserID,
+			Status: model.AccountStatusActive,
+			Reason: "suspension ended",
+		})
+		if err != nil {
+			log.Println("error when updating account status to db")
+			return
+		}
+		user.AccountStatus = model.AccountStatusActive
+	}
+
+	return
+}
+
+func (s *usecase) ForceLogout(ctx context.Context, req model.AdminUserRequest) (err error) {
+	user, err := s.RepoDB.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	if s.isStaff(user) {
+		err = model.ForbiddenErr
+		return
+	}
+
+	err = s.RepoCache.DeleteSessions(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when deleting sessions from cache")
+		return
+	}
+
+	return s.audit(ctx, req.ActorID, req.UserID, model.AuditActionLoggedOut, req.Reason)
+}
+
+func (s *usecase) SetRole(ctx context.Context, req model.AdminRoleRequest) (err error) {
+	if !model.IsRole(req.Role) {
+		err = model.InvalidRoleErr
+		return
+	}
+
+	user, err := s.RepoDB.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	err = s.RepoDB.UpdateRole(ctx, req.UserID, req.Role)
+	if err != nil {
+		log.Println("error when updating role to db")
+		return
+	}
+
+	return s.audit(ctx, req.ActorID, req.UserID, model.AuditActionRoleChanged, fmt.Sprintf("%s -> %s", user.Role, req.Role))
+}
+
+// isStaff reports whether the user is staff, whom actions can only be taken
+// against once demoted
+func (s *usecase) isStaff(user *model.User) bool {
+	return user.HasRole(model.RoleModerator) || s.Config.AdminUserIDs[user.UserID]
+}
+
+// audit records an action taken by staff. Staff actions must not go
+// unrecorded, so unlike the audit of users' own changes a failure is returned.
+func (s *usecase) audit(ctx context.Context, actorID, userID int64, action, detail string) (err error) {
+	err = s.RepoDB.InsertAuditLog(ctx, model.AuditLog{
+		UserID:  userID,
+		ActorID: actorID,
+		Action:  action,
+		Detail:  detail,
+	})
+	if err != nil {
+		log.Println("error when inserting audit log to db")
+	}
+
+	return
+}
@@ -0,0 +1,702 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/event"
+	"github.com/egnptr/dating-app/repository/cache"
+	"github.com/egnptr/dating-app/repository/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasRole(t *testing.T) {
+	users := func(role string) func(ctx context.Context, userID int64) (*model.User, error) {
+		return func(ctx context.Context, userID int64) (*model.User, error) {
+			return &model.User{UserID: userID, Role: role}, nil
+		}
+	}
+
+	tests := []struct {
+		name    string
+		repoDB  db.Repo
+		userID  int64
+		role    string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:   "case success same role",
+			repoDB: &db.RepoMock{GetUserByIDFunc: users(model.RoleModerator)},
+			userID: 1,
+			role:   model.RoleModerator,
+			want:   true,
+		},
+		{
+			name:   "case success higher role",
+			repoDB: &db.RepoMock{GetUserByIDFunc: users(model.RoleAdmin)},
+			userID: 1,
+			role:   model.RoleModerator,
+			want:   true,
+		},
+		{
+			name:   "case success lower role",
+			repoDB: &db.RepoMock{GetUserByIDFunc: users(model.RoleModerator)},
+			userID: 1,
+			role:   model.RoleAdmin,
+			want:   false,
+		},
+		{
+			name:   "case success configured admin",
+			repoDB: &db.RepoMock{},
+			userID: 7,
+			role:   model.RoleAdmin,
+			want:   true,
+		},
+		{
+			name: "case error db",
+			repoDB: &db.RepoMock{
+				GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+					return nil, errors.New("err")
+				},
+			},
+			userID:  1,
+			role:    model.RoleModerator,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.repoDB,
+				Config: Config{AdminUserIDs: map[int64]bool{7: true}},
+			}
+			got, gotErr := u.HasRole(context.Background(), tt.userID, tt.role)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("HasRole() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSearchUsers(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoDB  *db.RepoMock
+		want    []model.User
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			repoDB: &db.RepoMock{
+				SearchUsersFunc: func(ctx context.Context, query string, limit int) ([]model.User, error) {
+					return []model.User{{UserID: 2, Username: "jdoe"}}, nil
+				},
+				InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+					assert.Equal(t, model.AuditLog{ActorID: 1, Action: model.AuditActionUsersSearched, Detail: `query="jdoe"`}, req)
+					return nil
+				},
+			},
+			want: []model.User{{UserID: 2, Username: "jdoe"}},
+		},
+		{
+			name: "case error audit",
+			repoDB: &db.RepoMock{
+				SearchUsersFunc: func(ctx context.Context, query string, limit int) ([]model.User, error) {
+					return []model.User{{UserID: 2, Username: "jdoe"}}, nil
+				},
+				InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+					return errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error search",
+			repoDB: &db.RepoMock{
+				SearchUsersFunc: func(ctx context.Context, query string, limit int) ([]model.User, error) {
+					return nil, errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.repoDB,
+			}
+			got, gotErr := u.SearchUsers(context.Background(), model.SearchUsersRequest{ActorID: 1, Query: "jdoe"})
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("SearchUsers() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetUserProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoDB  *db.RepoMock
+		want    model.AdminUserProfile
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			repoDB: &db.RepoMock{
+				GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+					return &model.User{UserID: userID, Username: "jdoe", Password: "hash"}, nil
+				},
+				GetSwipeStatsFunc: func(ctx context.Context, userID int64) (*model.SwipeStats, error) {
+					return &model.SwipeStats{LikesGiven: 3, Matches: 1}, nil
+				},
+				InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+					assert.Equal(t, model.AuditLog{UserID: 2, ActorID: 1, Action: model.AuditActionProfileViewed}, req)
+					return nil
+				},
+			},
+			want: model.AdminUserProfile{
+				User:       model.User{UserID: 2, Username: "jdoe"},
+				SwipeStats: model.SwipeStats{LikesGiven: 3, Matches: 1},
+			},
+		},
+		{
+			name: "case error audit",
+			repoDB: &db.RepoMock{
+				GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+					return &model.User{UserID: userID}, nil
+				},
+				GetSwipeStatsFunc: func(ctx context.Context, userID int64) (*model.SwipeStats, error) {
+					return &model.SwipeStats{}, nil
+				},
+				InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+					return errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error not found",
+			repoDB: &db.RepoMock{
+				GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+					return nil, model.NotFoundErr
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.repoDB,
+			}
+			got, gotErr := u.GetUserProfile(context.Background(), model.AdminUserRequest{ActorID: 1, UserID: 2})
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("GetUserProfile() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetPremium(t *testing.T) {
+	tests := []struct {
+		name      string
+		isPremium bool
+		repoDB    *db.RepoMock
+	}{
+		{
+			name:      "case success grant",
+			isPremium: true,
+			repoDB: &db.RepoMock{
+				GetActiveSubscriptionFunc: func(ctx context.Context, userID int64, at time.Time) (*model.Subscription, error) {
+					return nil, model.NotFoundErr
+				},
+				InsertSubscriptionFunc: func(ctx context.Context, req model.Subscription) (int64, error) {
+					assert.Equal(t, int64(2), req.UserID)
+					assert.Equal(t, model.PlanMonthly, req.Plan)
+					return 1, nil
+				},
+			},
+		},
+		{
+			name:      "case success revoke",
+			isPremium: false,
+			repoDB: &db.RepoMock{
+				GetActiveSubscriptionFunc: func(ctx context.Context, userID int64, at time.Time) (*model.Subscription, error) {
+					return &model.Subscription{ID: 1, UserID: userID, Status: model.SubscriptionStatusActive, CurrentPeriodEnd: at.Add(time.Hour)}, nil
+				},
+				UpdateSubscriptionFunc: func(ctx context.Context, req model.Subscription) error {
+					assert.Equal(t, model.SubscriptionStatusCanceled, req.Status)
+					assert.False(t, req.CurrentPeriodEnd.After(time.Now()))
+					return nil
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.repoDB.GetUserByIDFunc = func(ctx context.Context, userID int64) (*model.User, error) {
+				return &model.User{UserID: userID}, nil
+			}
+			tt.repoDB.InsertAuditLogFunc = func(ctx context.Context, req model.AuditLog) error {
+				return nil
+			}
+			repoCache := &cache.RepoMock{
+				PublishEventFunc: func(ctx context.Context, event model.Event) error {
+					return nil
+				},
+			}
+			u := &usecase{
+				RepoDB:    tt.repoDB,
+				RepoCache: repoCache,
+				Events:    event.NewHub(),
+			}
+
+			err := u.SetPremium(context.Background(), model.AdminPremiumRequest{ActorID: 1, UserID: 2, IsPremium: tt.isPremium})
+			assert.NoError(t, err)
+			assert.Len(t, repoCache.PublishEventCalls(), 1)
+			if assert.Len(t, tt.repoDB.InsertAuditLogCalls(), 1) {
+				assert.Equal(t, model.AuditActionPremiumChanged, tt.repoDB.InsertAuditLogCalls()[0].Req.Action)
+			}
+		})
+	}
+}
+
+func TestBanUser(t *testing.T) {
+	users := func(role string) func(ctx context.Context, userID int64) (*model.User, error) {
+		return func(ctx context.Context, userID int64) (*model.User, error) {
+			return &model.User{UserID: userID, Role: role}, nil
+		}
+	}
+
+	type fields struct {
+		repoDB    *db.RepoMock
+		repoCache *cache.RepoMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		wantLogout int
+		wantErr    error
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: users(model.RoleUser),
+					UpdateAccountStatusFunc: func(ctx context.Context, req model.AccountStatusChange) error {
+						assert.Equal(t, model.AccountStatusChange{UserID: 2, Status: model.AccountStatusBanned, Reason: "spam", ActorID: 1}, req)
+						return nil
+					},
+					InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+						assert.Equal(t, model.AuditLog{UserID: 2, ActorID: 1, Action: model.AuditActionBanned, Detail: "spam"}, req)
+						return nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					DeleteSessionsFunc: func(ctx context.Context, userID int64) error {
+						return nil
+					},
+				},
+			},
+			wantLogout: 1,
+		},
+		{
+			name: "case error staff",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: users(model.RoleModerator),
+				},
+				repoCache: &cache.RepoMock{},
+			},
+			wantErr: model.ForbiddenErr,
+		},
+		{
+			name: "case error delete sessions",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: users(model.RoleUser),
+					UpdateAccountStatusFunc: func(ctx context.Context, req model.AccountStatusChange) error {
+						return nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					DeleteSessionsFunc: func(ctx context.Context, userID int64) error {
+						return errors.New("err")
+					},
+				},
+			},
+			wantLogout: 1,
+			wantErr:    errors.New("err"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB:    tt.fields.repoDB,
+				RepoCache: tt.fields.repoCache,
+			}
+			gotErr := u.BanUser(context.Background(), model.AdminUserRequest{ActorID: 1, UserID: 2, Reason: "spam"})
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Len(t, tt.fields.repoCache.DeleteSessionsCalls(), tt.wantLogout)
+		})
+	}
+}
+
+func TestSuspendUser(t *testing.T) {
+	until := time.Now().Add(24 * time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		until      *time.Time
+		wantLogout int
+		wantErr    error
+	}{
+		{
+			name:       "case success",
+			until:      &until,
+			wantLogout: 1,
+		},
+		{
+			name:    "case error no end",
+			wantErr: model.InvalidUntilErr,
+		},
+		{
+			name:    "case error end in the past",
+			until:   &past,
+			wantErr: model.InvalidUntilErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoCache := &cache.RepoMock{
+				DeleteSessionsFunc: func(ctx context.Context, userID int64) error {
+					return nil
+				},
+			}
+			u := &usecase{
+				RepoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{UserID: userID, Role: model.RoleUser}, nil
+					},
+					UpdateAccountStatusFunc: func(ctx context.Context, req model.AccountStatusChange) error {
+						assert.Equal(t, model.AccountStatusSuspended, req.Status)
+						assert.Equal(t, tt.until, req.Until)
+						return nil
+					},
+					InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+						assert.Equal(t, model.AuditActionSuspended, req.Action)
+						return nil
+					},
+				},
+				RepoCache: repoCache,
+			}
+			gotErr := u.SuspendUser(context.Background(), model.AdminUserRequest{ActorID: 1, UserID: 2, Reason: "rude", Until: tt.until})
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Len(t, repoCache.DeleteSessionsCalls(), tt.wantLogout)
+		})
+	}
+}
+
+func TestShadowbanUser(t *testing.T) {
+	repoDB := &db.RepoMock{
+		GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+			return &model.User{UserID: userID, Role: model.RoleUser}, nil
+		},
+		UpdateAccountStatusFunc: func(ctx context.Context, req model.AccountStatusChange) error {
+			assert.Equal(t, model.AccountStatusShadowbanned, req.Status)
+			return nil
+		},
+		InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+			return nil
+		},
+	}
+	repoCache := &cache.RepoMock{}
+	u := &usecase{
+		RepoDB:    repoDB,
+		RepoCache: repoCache,
+	}
+
+	// The sessions are kept so the user does not notice
+	err := u.ShadowbanUser(context.Background(), model.AdminUserRequest{ActorID: 1, UserID: 2, Reason: "spam"})
+	assert.NoError(t, err)
+	assert.Len(t, repoCache.DeleteSessionsCalls(), 0)
+	assert.Len(t, repoDB.UpdateAccountStatusCalls(), 1)
+}
+
+func TestCheckAccountStatus(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		user       model.User
+		wantLifted int
+		wantErr    error
+	}{
+		{
+			name: "case success active",
+			user: model.User{AccountStatus: model.AccountStatusActive},
+		},
+		{
+			name: "case success shadowbanned",
+			user: model.User{AccountStatus: model.AccountStatusShadowbanned},
+		},
+		{
+			name:       "case success suspension ended",
+			user:       model.User{AccountStatus: model.AccountStatusSuspended, StatusUntil: &past},
+			wantLifted: 1,
+		},
+		{
+			name:    "case error banned",
+			user:    model.User{AccountStatus: model.AccountStatusBanned},
+			wantErr: model.BannedErr,
+		},
+		{
+			name:    "case error suspended",
+			user:    model.User{AccountStatus: model.AccountStatusSuspended, StatusReason: "rude", StatusUntil: &future},
+			wantErr: &model.SuspendedError{Reason: "rude", Until: future},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoDB := &db.RepoMock{
+				UpdateAccountStatusFunc: func(ctx context.Context, req model.AccountStatusChange) error {
+					assert.Equal(t, model.AccountStatusActive, req.Status)
+					return nil
+				},
+			}
+			u := &usecase{
+				RepoDB: repoDB,
+			}
+			gotErr := u.checkAccountStatus(context.Background(), &tt.user)
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Len(t, repoDB.UpdateAccountStatusCalls(), tt.wantLifted)
+		})
+	}
+}
+
+func TestUnbanUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoDB  db.Repo
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			repoDB: &db.RepoMock{
+				GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+					return &model.User{UserID: userID, AccountStatus: model.AccountStatusBanned}, nil
+				},
+				UpdateAccountStatusFunc: func(ctx context.Context, req model.AccountStatusChange) error {
+					assert.Equal(t, model.AccountStatusActive, req.Status)
+					return nil
+				},
+				InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+					return nil
+				},
+			},
+		},
+		{
+			name: "case error staff",
+			repoDB: &db.RepoMock{
+				GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+					return &model.User{UserID: userID, Role: model.RoleModerator}, nil
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error not found",
+			repoDB: &db.RepoMock{
+				GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+					return nil, model.NotFoundErr
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.repoDB,
+			}
+			gotErr := u.UnbanUser(context.Background(), model.AdminUserRequest{ActorID: 1, UserID: 2})
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("UnbanUser() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestGetAccountStatusHistory(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoDB  *db.RepoMock
+		want    []model.AccountStatusChange
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			repoDB: &db.RepoMock{
+				GetAccountStatusHistoryFunc: func(ctx context.Context, userID int64) ([]model.AccountStatusChange, error) {
+					return []model.AccountStatusChange{{UserID: userID, Status: model.AccountStatusBanned}}, nil
+				},
+				InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+					assert.Equal(t, model.AuditLog{UserID: 2, ActorID: 1, Action: model.AuditActionHistoryViewed}, req)
+					return nil
+				},
+			},
+			want: []model.AccountStatusChange{{UserID: 2, Status: model.AccountStatusBanned}},
+		},
+		{
+			name: "case error audit",
+			repoDB: &db.RepoMock{
+				GetAccountStatusHistoryFunc: func(ctx context.Context, userID int64) ([]model.AccountStatusChange, error) {
+					return []model.AccountStatusChange{{UserID: userID, Status: model.AccountStatusBanned}}, nil
+				},
+				InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+					return errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.repoDB,
+			}
+			got, gotErr := u.GetAccountStatusHistory(context.Background(), model.AdminUserRequest{ActorID: 1, UserID: 2})
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("GetAccountStatusHistory() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestForceLogout(t *testing.T) {
+	repoDB := &db.RepoMock{
+		GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+			return &model.User{UserID: userID}, nil
+		},
+		InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+			return nil
+		},
+	}
+	repoCache := &cache.RepoMock{
+		DeleteSessionsFunc: func(ctx context.Context, userID int64) error {
+			return nil
+		},
+	}
+	u := &usecase{
+		RepoDB:    repoDB,
+		RepoCache: repoCache,
+	}
+
+	err := u.ForceLogout(context.Background(), model.AdminUserRequest{ActorID: 1, UserID: 2})
+	assert.NoError(t, err)
+	if assert.Len(t, repoCache.DeleteSessionsCalls(), 1) {
+		assert.Equal(t, int64(2), repoCache.DeleteSessionsCalls()[0].UserID)
+	}
+	if assert.Len(t, repoDB.InsertAuditLogCalls(), 1) {
+		assert.Equal(t, model.AuditActionLoggedOut, repoDB.InsertAuditLogCalls()[0].Req.Action)
+	}
+}
+
+func TestForceLogoutStaff(t *testing.T) {
+	tests := []struct {
+		name string
+		user model.User
+	}{
+		{
+			name: "case error moderator",
+			user: model.User{UserID: 2, Role: model.RoleModerator},
+		},
+		{
+			name: "case error configured admin",
+			user: model.User{UserID: 7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						user := tt.user
+						return &user, nil
+					},
+				},
+				RepoCache: &cache.RepoMock{},
+				Config:    Config{AdminUserIDs: map[int64]bool{7: true}},
+			}
+
+			err := u.ForceLogout(context.Background(), model.AdminUserRequest{ActorID: 1, UserID: tt.user.UserID})
+			assert.Equal(t, model.ForbiddenErr, err)
+		})
+	}
+}
+
+func TestSetRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoDB  *db.RepoMock
+		role    string
+		wantErr error
+	}{
+		{
+			name: "case success",
+			repoDB: &db.RepoMock{
+				GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+					return &model.User{UserID: userID, Role: model.RoleUser}, nil
+				},
+				UpdateRoleFunc: func(ctx context.Context, userID int64, role string) error {
+					return nil
+				},
+				InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+					assert.Equal(t, "user -> moderator", req.Detail)
+					return nil
+				},
+			},
+			role: model.RoleModerator,
+		},
+		{
+			name:    "case error unknown role",
+			repoDB:  &db.RepoMock{},
+			role:    "owner",
+			wantErr: model.InvalidRoleErr,
+		},
+		{
+			name: "case error not found",
+			repoDB: &db.RepoMock{
+				GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+					return nil, model.NotFoundErr
+				},
+			},
+			role:    model.RoleAdmin,
+			wantErr: model.NotFoundErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.repoDB,
+			}
+			gotErr := u.SetRole(context.Background(), model.AdminRoleRequest{ActorID: 1, UserID: 2, Role: tt.role})
+			assert.Equal(t, tt.wantErr, gotErr)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/billing"
+)
+
+// GetBillingHistory lists what the user was charged and refunded and how
+// their plan changed, newest first
+func (s *usecase) GetBillingHistory(ctx context.Context, req model.BillingHistoryRequest) (res model.BillingHistoryResponse, err error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = billingHistoryDefaultLimit
+	} else if limit > billingHistoryMaxLimit {
+		limit = billingHistoryMaxLimit
+	}
+
+	// One extra entry tells whether there is an older page
+	entries, err := s.RepoDB.GetLedgerEntries(ctx, req.UserID, req.BeforeID, limit+1)
+	if err != nil {
+		log.Println("error when fetching ledger entries from db")
+		return
+	}
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+		res.NextBeforeID = entries[limit-1].ID
+	}
+
+	res.Entries = entries
+	if res.Entries == nil {
+		res.Entries = []model.LedgerEntry{}
+	}
+	return
+}
+
+// GetInvoice renders an invoice of the user for downloading. Invoices of other
+// users are not found
+func (s *usecase) GetInvoice(ctx context.Context, req model.InvoiceRequest) (res model.InvoiceDocument, err error) {
+	invoice, err := s.RepoDB.GetInvoice(ctx, req.InvoiceID)
+	if err == nil && invoice.UserID != req.UserID {
+		err = model.NotFoundErr
+		return
+	} else if err != nil {
+		if err != model.NotFoundErr {
+			log.Println("error when fetching invoice from db")
+		}
+		return
+	}
+
+	user, err := s.RepoDB.GetUserByID(ctx, invoice.UserID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	var buf bytes.Buffer
+	err = billing.RenderInvoice(&buf, billing.Document{
+		Seller:   s.Config.Seller,
+		Invoice:  *invoice,
+		Username: user.Username,
+		Email:    user.Email,
+	})
+	if err != nil {
+		log.Println("error when rendering invoice")
+		return
+	}
+
+	res = model.InvoiceDocument{
+		Filename:    invoice.Number + ".html",
+		ContentType: "text/html; charset=utf-8",
+		Body:        buf.Bytes(),
+	}
+	return
+}
+
+// recordSubscriptionCharge enters the payment for the current period of the
+// subscription in the ledger. Nothing is charged for a trial, which is entered
+// without an invoice
+func (s *usecase) recordSubscriptionCharge(ctx context.Context, subscription model.Subscription, amount int64, currency string) {
+	periodStart, periodEnd := subscription.CurrentPeriodStart, subscription.CurrentPeriodEnd
+	s.recordLedgerEntry(ctx, model.LedgerEntry{
+		UserID:         subscription.UserID,
+		SubscriptionID: subscription.ID,
+		Kind:           model.LedgerKindCharge,
+		Tier:           subscription.Tier,
+		Plan:           subscription.Plan,
+		Description:    fmt.Sprintf("%s %s subscription", subscription.Tier, subscription.Plan),
+		Amount:         amount,
+		Currency:       currency,
+		PeriodStart:    &periodStart,
+		PeriodEnd:      &periodEnd,
+		Reference:      fmt.Sprintf("subscription:%d:charge:%d", subscription.ID, periodStart.Unix()),
+	})
+}
+
+// recordSubscriptionRefund enters the refund of the last payment for the
+// subscription in the ledger
+func (s *usecase) recordSubscriptionRefund(ctx context.Context, subscription model.Subscription) {
+	charge, err := s.RepoDB.GetLastLedgerEntry(ctx, subscription.ID, model.LedgerKindCharge)
+	if err == model.NotFoundErr {
+		return
+	} else if err != nil {
+		log.Println("error when fetching ledger entry from db")
+		return
+	}
+	if charge.Amount == 0 {
+		return
+	}
+
+	description := fmt.Sprintf("Refund of %s %s subscription", charge.Tier, charge.Plan)
+	if charge.InvoiceNumber != "" {
+		description += ", invoice " + charge.InvoiceNumber
+	}
+	s.recordLedgerEntry(ctx, model.LedgerEntry{
+		UserID:         subscription.UserID,
+		SubscriptionID: subscription.ID,
+		Kind:           model.LedgerKindRefund,
+		Tier:           charge.Tier,
+		Plan:           charge.Plan,
+		Description:    description,
+		Amount:         -charge.Amount,
+		Currency:       charge.Currency,
+		PeriodStart:    charge.PeriodStart,
+		PeriodEnd:      charge.PeriodEnd,
+		Reference:      fmt.Sprintf("subscription:%d:refund:%d", subscription.ID, charge.ID),
+	})
+}
+
+// recordPlanChange enters a change of the tier of the subscription in the
+// ledger, the provider charging for it on its own
+func (s *usecase) recordPlanChange(ctx context.Context, subscription model.Subscription, fromTier string, now time.Time) {
+	s.recordLedgerEntry(ctx, model.LedgerEntry{
+		UserID:         subscription.UserID,
+		SubscriptionID: subscription.ID,
+		Kind:           model.LedgerKindPlanChange,
+		Tier:           subscription.Tier,
+		Plan:           subscription.Plan,
+		Description:    fmt.Sprintf("Changed from %s to %s %s", fromTier, subscription.Tier, subscription.Plan),
+		Reference:      fmt.Sprintf("subscription:%d:plan_change:%d", subscription.ID, now.UnixNano()),
+	})
+}
+
+// recordLedgerEntry adds the entry to the ledger, issuing an invoice for it
+// when it moves money. The ledger follows what happened at the payment
+// provider and the stores, so failing to record is logged rather than failing
+// what is recorded
+func (s *usecase) recordLedgerEntry(ctx context.Context, entry model.LedgerEntry) {
+	var invoice *model.Invoice
+	if entry.Amount != 0 {
+		tax := billing.TaxIncluded(entry.Amount, s.Config.TaxRate)
+		invoice = &model.Invoice{
+			Subtotal: entry.Amount - tax,
+			TaxName:  s.Config.TaxName,
+			TaxRate:  s.Config.TaxRate,
+			Tax:      tax,
+			Total:    entry.Amount,
+			Currency: entry.Currency,
+		}
+	}
+
+	if _, err := s.RepoDB.InsertLedgerEntry(ctx, entry, invoice); err != nil {
+		log.Println("error when inserting ledger entry to db")
+	}
+}
@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/billing"
+	"github.com/egnptr/dating-app/repository/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBillingHistory(t *testing.T) {
+	entries := func(ids ...int64) []model.LedgerEntry {
+		res := make([]model.LedgerEntry, 0, len(ids))
+		for _, id := range ids {
+			res = append(res, model.LedgerEntry{ID: id, UserID: 1, Kind: model.LedgerKindCharge, Amount: 999, Currency: "usd"})
+		}
+		return res
+	}
+
+	tests := []struct {
+		name      string
+		req       model.BillingHistoryRequest
+		repoDB    *db.RepoMock
+		wantLimit int
+		want      model.BillingHistoryResponse
+		wantErr   bool
+	}{
+		{
+			name: "case success with older page",
+			req:  model.BillingHistoryRequest{UserID: 1, Limit: 2},
+			repoDB: &db.RepoMock{
+				GetLedgerEntriesFunc: func(ctx context.Context, userID, beforeID int64, limit int) ([]model.LedgerEntry, error) {
+					return entries(5, 4, 3), nil
+				},
+			},
+			wantLimit: 3,
+			want:      model.BillingHistoryResponse{Entries: entries(5, 4), NextBeforeID: 4},
+		},
+		{
+			name: "case success last page",
+			req:  model.BillingHistoryRequest{UserID: 1, BeforeID: 4},
+			repoDB: &db.RepoMock{
+				GetLedgerEntriesFunc: func(ctx context.Context, userID, beforeID int64, limit int) ([]model.LedgerEntry, error) {
+					return entries(3), nil
+				},
+			},
+			wantLimit: billingHistoryDefaultLimit + 1,
+			want:      model.BillingHistoryResponse{Entries: entries(3)},
+		},
+		{
+			name: "case success empty",
+			req:  model.BillingHistoryRequest{UserID: 1, Limit: 1000},
+			repoDB: &db.RepoMock{
+				GetLedgerEntriesFunc: func(ctx context.Context, userID, beforeID int64, limit int) ([]model.LedgerEntry, error) {
+					return nil, nil
+				},
+			},
+			wantLimit: billingHistoryMaxLimit + 1,
+			want:      model.BillingHistoryResponse{Entries: []model.LedgerEntry{}},
+		},
+		{
+			name: "case error db",
+			req:  model.BillingHistoryRequest{UserID: 1},
+			repoDB: &db.RepoMock{
+				GetLedgerEntriesFunc: func(ctx context.Context, userID, beforeID int64, limit int) ([]model.LedgerEntry, error) {
+					return nil, errors.New("err")
+				},
+			},
+			wantLimit: billingHistoryDefaultLimit + 1,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.repoDB,
+			}
+			got, gotErr := u.GetBillingHistory(context.Background(), tt.req)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("GetBillingHistory() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+
+			calls := tt.repoDB.GetLedgerEntriesCalls()
+			if assert.Len(t, calls, 1) {
+				assert.Equal(t, tt.req.UserID, calls[0].UserID)
+				assert.Equal(t, tt.req.BeforeID, calls[0].BeforeID)
+				assert.Equal(t, tt.wantLimit, calls[0].Limit)
+			}
+		})
+	}
+}
+
+func TestGetInvoice(t *testing.T) {
+	invoice := &model.Invoice{
+		ID:          1,
+		Number:      "INV-000001",
+		UserID:      1,
+		Kind:        model.LedgerKindCharge,
+		Description: "gold monthly subscription",
+		Subtotal:    1666,
+		TaxName:     "VAT",
+		TaxRate:     2000,
+		Tax:         333,
+		Total:       1999,
+		Currency:    "usd",
+		IssuedAt:    time.Now(),
+	}
+	getInvoice := func(ctx context.Context, id int64) (*model.Invoice, error) {
+		return invoice, nil
+	}
+	getUser := func(ctx context.Context, userID int64) (*model.User, error) {
+		return &model.User{UserID: userID, Username: "john", Email: "john@example.com"}, nil
+	}
+
+	tests := []struct {
+		name    string
+		userID  int64
+		repoDB  *db.RepoMock
+		wantErr error
+	}{
+		{
+			name:   "case success",
+			userID: 1,
+			repoDB: &db.RepoMock{
+				GetInvoiceFunc:  getInvoice,
+				GetUserByIDFunc: getUser,
+			},
+		},
+		{
+			name:    "case error invoice of another user",
+			userID:  2,
+			repoDB:  &db.RepoMock{GetInvoiceFunc: getInvoice},
+			wantErr: model.NotFoundErr,
+		},
+		{
+			name:   "case error not found",
+			userID: 1,
+			repoDB: &db.RepoMock{
+				GetInvoiceFunc: func(ctx context.Context, id int64) (*model.Invoice, error) {
+					return nil, model.NotFoundErr
+				},
+			},
+			wantErr: model.NotFoundErr,
+		},
+		{
+			name:   "case error db",
+			userID: 1,
+			repoDB: &db.RepoMock{
+				GetInvoiceFunc: func(ctx context.Context, id int64) (*model.Invoice, error) {
+					return nil, errors.New("err")
+				},
+			},
+			wantErr: errors.New("err"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.repoDB,
+				Config: Config{Seller: billing.Seller{Name: "Dating App"}},
+			}
+			got, gotErr := u.GetInvoice(context.Background(), model.InvoiceRequest{UserID: tt.userID, InvoiceID: 1})
+			assert.Equal(t, tt.wantErr, gotErr)
+			if tt.wantErr != nil {
+				return
+			}
+
+			assert.Equal(t, "INV-000001.html", got.Filename)
+			assert.Equal(t, "text/html; charset=utf-8", got.ContentType)
+			assert.True(t, strings.Contains(string(got.Body), "Invoice INV-000001"))
+			assert.True(t, strings.Contains(string(got.Body), "john@example.com"))
+		})
+	}
+}
+
+func TestRecordLedgerEntry(t *testing.T) {
+	tests := []struct {
+		name        string
+		entry       model.LedgerEntry
+		wantInvoice *model.Invoice
+	}{
+		{
+			name:  "case charge",
+			entry: model.LedgerEntry{Kind: model.LedgerKindCharge, Amount: 1999, Currency: "usd"},
+			wantInvoice: &model.Invoice{
+				Subtotal: 1666,
+				TaxName:  "VAT",
+				TaxRate:  2000,
+				Tax:      333,
+				Total:    1999,
+				Currency: "usd",
+			},
+		},
+		{
+			name:  "case refund",
+			entry: model.LedgerEntry{Kind: model.LedgerKindRefund, Amount: -1999, Currency: "usd"},
+			wantInvoice: &model.Invoice{
+				Subtotal: -1666,
+				TaxName:  "VAT",
+				TaxRate:  2000,
+				Tax:      -333,
+				Total:    -1999,
+				Currency: "usd",
+			},
+		},
+		{
+			name:  "case plan change",
+			entry: model.LedgerEntry{Kind: model.LedgerKindPlanChange},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoDB := &db.RepoMock{
+				InsertLedgerEntryFunc: func(ctx context.Context, entry model.LedgerEntry, invoice *model.Invoice) (bool, error) {
+					return true, nil
+				},
+			}
+			u := &usecase{
+				RepoDB: repoDB,
+				Config: Config{TaxName: "VAT", TaxRate: 2000},
+			}
+			u.recordLedgerEntry(context.Background(), tt.entry)
+
+			calls := repoDB.InsertLedgerEntryCalls()
+			if assert.Len(t, calls, 1) {
+				assert.Equal(t, tt.entry, calls[0].Entry)
+				assert.Equal(t, tt.wantInvoice, calls[0].Invoice)
+			}
+		})
+	}
+}
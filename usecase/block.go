@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"github.com/egnptr/dating-app/model"
+)
+
+// BlockUser hides both users from each other and undoes the likes between
+// them, which also ends their match
+func (s *usecase) BlockUser(ctx context.Context, req model.BlockRequest) (err error) {
+	if req.UserID == req.BlockedUserID {
+		err = model.SelfBlockErr
+		return
+	}
+
+	_, err = s.RepoDB.GetUserByID(ctx, req.BlockedUserID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	err = s.RepoDB.InsertBlock(ctx, req.UserID, req.BlockedUserID)
+	if err != nil {
+		log.Println("error when inserting block to db")
+		return
+	}
+
+	err = s.RepoCache.RemoveRelatedUserLike(ctx, req.UserID, req.BlockedUserID)
+	if err != nil {
+		log.Println("error when removing like from cache")
+		return
+	}
+
+	err = s.RepoCache.RemoveRelatedUserLike(ctx, req.BlockedUserID, req.UserID)
+	if err != nil {
+		log.Println("error when removing like from cache")
+		return
+	}
+
+	return
+}
+
+func (s *usecase) UnblockUser(ctx context.Context, req model.BlockRequest) (err error) {
+	err = s.RepoDB.DeleteBlock(ctx, req.UserID, req.BlockedUserID)
+	if err != nil {
+		log.Println("error when deleting block from db")
+		return
+	}
+
+	return
+}
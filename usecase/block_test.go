@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/repository/cache"
+	"github.com/egnptr/dating-app/repository/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockUser(t *testing.T) {
+	users := func(ctx context.Context, userID int64) (*model.User, error) {
+		return &model.User{UserID: userID}, nil
+	}
+
+	type fields struct {
+		repoDB    *db.RepoMock
+		repoCache *cache.RepoMock
+	}
+	type args struct {
+		req model.BlockRequest
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		args        args
+		wantRemoved int
+		wantErr     error
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: users,
+					InsertBlockFunc: func(ctx context.Context, blockerID, blockedID int64) error {
+						return nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					RemoveRelatedUserLikeFunc: func(ctx context.Context, userID, likedUserID int64) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.BlockRequest{
+					UserID:        1,
+					BlockedUserID: 2,
+				},
+			},
+			wantRemoved: 2,
+		},
+		{
+			name: "case error self",
+			fields: fields{
+				repoDB:    &db.RepoMock{},
+				repoCache: &cache.RepoMock{},
+			},
+			args: args{
+				req: model.BlockRequest{
+					UserID:        1,
+					BlockedUserID: 1,
+				},
+			},
+			wantErr: model.SelfBlockErr,
+		},
+		{
+			name: "case error user not found",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return nil, model.NotFoundErr
+					},
+				},
+				repoCache: &cache.RepoMock{},
+			},
+			args: args{
+				req: model.BlockRequest{
+					UserID:        1,
+					BlockedUserID: 2,
+				},
+			},
+			wantErr: model.NotFoundErr,
+		},
+		{
+			name: "case error insert block",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: users,
+					InsertBlockFunc: func(ctx context.Context, blockerID, blockedID int64) error {
+						return errors.New("err")
+					},
+				},
+				repoCache: &cache.RepoMock{},
+			},
+			args: args{
+				req: model.BlockRequest{
+					UserID:        1,
+					BlockedUserID: 2,
+				},
+			},
+			wantErr: errors.New("err"),
+		},
+		{
+			name: "case error remove like",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: users,
+					InsertBlockFunc: func(ctx context.Context, blockerID, blockedID int64) error {
+						return nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					RemoveRelatedUserLikeFunc: func(ctx context.Context, userID, likedUserID int64) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				req: model.BlockRequest{
+					UserID:        1,
+					BlockedUserID: 2,
+				},
+			},
+			wantRemoved: 1,
+			wantErr:     errors.New("err"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB:    tt.fields.repoDB,
+				RepoCache: tt.fields.repoCache,
+			}
+			gotErr := u.BlockUser(context.Background(), tt.args.req)
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Len(t, tt.fields.repoCache.RemoveRelatedUserLikeCalls(), tt.wantRemoved)
+		})
+	}
+}
+
+func TestUnblockUser(t *testing.T) {
+	type fields struct {
+		repoDB db.Repo
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					DeleteBlockFunc: func(ctx context.Context, blockerID, blockedID int64) error {
+						return nil
+					},
+				},
+			},
+		},
+		{
+			name: "case error not blocked",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					DeleteBlockFunc: func(ctx context.Context, blockerID, blockedID int64) error {
+						return model.NotFoundErr
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.fields.repoDB,
+			}
+			gotErr := u.UnblockUser(context.Background(), model.BlockRequest{UserID: 1, BlockedUserID: 2})
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("UnblockUser() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/entitlement"
+	"github.com/egnptr/dating-app/pkg/receipt"
+)
+
+func (s *usecase) GetBoosts(ctx context.Context, userID int64) (res model.BoostInventory, err error) {
+	user, err := s.RepoDB.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	return s.boostInventory(ctx, user, time.Now())
+}
+
+// ActivateBoost starts a boost, from the monthly allowance of the tier while
+// it lasts and from the bought boosts after
+func (s *usecase) ActivateBoost(ctx context.Context, userID int64) (res model.Boost, err error) {
+	user, err := s.RepoDB.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	now := time.Now()
+	inventory, err := s.boostInventory(ctx, user, now)
+	if err != nil {
+		return
+	}
+
+	boost := model.Boost{
+		UserID:      userID,
+		ActivatedAt: now,
+		ExpiresAt:   now.Add(boostDuration),
+	}
+	if inventory.Active != nil {
+		err = model.BoostActiveErr
+		return
+	} else if inventory.Monthly != 0 {
+		boost.Source = model.BoostSourceMonthly
+	} else if inventory.Purchased > 0 {
+		boost.Source = model.BoostSourcePurchased
+	} else {
+		err = model.BoostLimitErr
+		return
+	}
+
+	// The db makes sure boosts do not overlap and bought ones are not spent
+	// twice, the cache is only written once the boost is taken
+	boost.ID, err = s.RepoDB.InsertBoost(ctx, boost)
+	if err == model.BoostActiveErr || err == model.BoostLimitErr {
+		return
+	} else if err != nil {
+		log.Println("error when inserting boost to db")
+		return
+	}
+
+	err = s.RepoCache.AddBoostedUser(ctx, boost)
+	if err != nil {
+		log.Println("error when adding boosted user to cache")
+		if errDelete := s.RepoDB.DeleteBoost(ctx, boost); errDelete != nil {
+			log.Println("error when deleting boost from db")
+		}
+		return
+	}
+
+	return boost, nil
+}
+
+// PurchaseBoosts adds the boosts of a boost pack bought in a store, from the
+// receipt the app got from the store. A receipt sent again adds nothing
+func (s *usecase) PurchaseBoosts(ctx context.Context, req model.ReceiptRequest) (res model.BoostInventory, err error) {
+	purchase, err := s.Receipts.VerifyReceipt(ctx, req.Store, req.Receipt)
+	if errors.Is(err, receipt.ErrInvalidReceipt) || errors.Is(err, receipt.ErrUnknownStore) {
+		err = model.InvalidReceiptErr
+		return
+	} else if err != nil {
+		log.Println("error when verifying receipt at store")
+		return
+	}
+
+	boosts, ok := s.Config.StoreBoostPacks[purchase.ProductID]
+	if !ok {
+		err = model.InvalidPlanErr
+		return
+	} else if purchase.Status == receipt.StatusRefunded {
+		err = model.InvalidReceiptErr
+		return
+	}
+
+	userID, err := s.RepoDB.CreditBoostPurchase(ctx, model.BoostPurchase{
+		ID:     storePurchaseID(purchase.Store, purchase.ID),
+		UserID: req.UserID,
+		Boosts: boosts,
+	})
+	if err != nil {
+		log.Println("error when crediting boost purchase in db")
+		return
+	} else if userID != req.UserID {
+		err = model.ReceiptInUseErr
+		return
+	}
+
+	s.recordLedgerEntry(ctx, model.LedgerEntry{
+		UserID:      req.UserID,
+		Kind:        model.LedgerKindCharge,
+		Description: fmt.Sprintf("%d boosts", boosts),
+		Amount:      purchase.Price,
+		Currency:    purchase.Currency,
+		Reference:   "boost_purchase:" + storePurchaseID(purchase.Store, purchase.ID),
+	})
+
+	return s.GetBoosts(ctx, req.UserID)
+}
+
+// boostInventory returns what the user has left to boost with at the time.
+// The monthly allowance starts over on the first of every month
+func (s *usecase) boostInventory(ctx context.Context, user *model.User, now time.Time) (res model.BoostInventory, err error) {
+	res.Monthly = s.entitlements(user).Limit(entitlement.LimitMonthlyBoosts)
+	if res.Monthly != entitlement.Unlimited {
+		utc := now.UTC()
+		monthStart := time.Date(utc.Year(), utc.Month(), 1, 0, 0, 0, 0, time.UTC)
+		used, errCount := s.RepoDB.CountBoosts(ctx, user.UserID, model.BoostSourceMonthly, monthStart)
+		if errCount != nil {
+			err = errCount
+			log.Println("error when counting boosts from db")
+			return
+		}
+
+		res.Monthly -= int(used)
+		if res.Monthly < 0 {
+			res.Monthly = 0
+		}
+	}
+
+	res.Purchased, err = s.RepoDB.GetBoostBalance(ctx, user.UserID)
+	if err != nil {
+		log.Println("error when fetching boost balance from db")
+		return
+	}
+
+	active, err := s.RepoDB.GetActiveBoost(ctx, user.UserID, now)
+	if err == nil {
+		res.Active = active
+	} else if err == model.NotFoundErr {
+		err = nil
+	} else {
+		log.Println("error when fetching active boost from db")
+		return
+	}
+
+	return
+}
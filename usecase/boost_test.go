@@ -0,0 +1,258 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/receipt"
+	"github.com/egnptr/dating-app/repository/cache"
+	"github.com/egnptr/dating-app/repository/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBoosts(t *testing.T) {
+	expiresAt := time.Now().Add(10 * time.Minute)
+	active := &model.Boost{ID: 1, UserID: 1, Source: model.BoostSourceMonthly, ExpiresAt: expiresAt}
+
+	tests := []struct {
+		name    string
+		tier    string
+		used    int64
+		active  *model.Boost
+		want    model.BoostInventory
+		wantErr error
+	}{
+		{
+			name: "case success",
+			tier: model.TierGold,
+			used: 2,
+			want: model.BoostInventory{Monthly: 3, Purchased: 4},
+		},
+		{
+			name:   "case success boost running",
+			tier:   model.TierPlus,
+			used:   1,
+			active: active,
+			want:   model.BoostInventory{Monthly: 0, Purchased: 4, Active: active},
+		},
+		{
+			name: "case success free tier",
+			tier: model.TierFree,
+			want: model.BoostInventory{Monthly: 0, Purchased: 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{UserID: userID, Tier: tt.tier}, nil
+					},
+					CountBoostsFunc: func(ctx context.Context, userID int64, source string, since time.Time) (int64, error) {
+						assert.Equal(t, model.BoostSourceMonthly, source)
+						assert.Equal(t, 1, since.Day())
+						return tt.used, nil
+					},
+					GetBoostBalanceFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 4, nil
+					},
+					GetActiveBoostFunc: func(ctx context.Context, userID int64, at time.Time) (*model.Boost, error) {
+						if tt.active == nil {
+							return nil, model.NotFoundErr
+						}
+						return tt.active, nil
+					},
+				},
+			}
+			got, gotErr := u.GetBoosts(context.Background(), 1)
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestActivateBoost(t *testing.T) {
+	repoDB := func(used, balance int64, active bool) *db.RepoMock {
+		return &db.RepoMock{
+			GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+				return &model.User{UserID: userID, Tier: model.TierPlus}, nil
+			},
+			CountBoostsFunc: func(ctx context.Context, userID int64, source string, since time.Time) (int64, error) {
+				return used, nil
+			},
+			GetBoostBalanceFunc: func(ctx context.Context, userID int64) (int64, error) {
+				return balance, nil
+			},
+			GetActiveBoostFunc: func(ctx context.Context, userID int64, at time.Time) (*model.Boost, error) {
+				if !active {
+					return nil, model.NotFoundErr
+				}
+				return &model.Boost{ID: 1, UserID: userID, ExpiresAt: at.Add(time.Minute)}, nil
+			},
+			InsertBoostFunc: func(ctx context.Context, req model.Boost) (int64, error) {
+				assert.Equal(t, boostDuration, req.ExpiresAt.Sub(req.ActivatedAt))
+				return 2, nil
+			},
+			DeleteBoostFunc: func(ctx context.Context, req model.Boost) error {
+				return nil
+			},
+		}
+	}
+	added := func(ctx context.Context, boost model.Boost) error {
+		return nil
+	}
+
+	tests := []struct {
+		name         string
+		repoDB       *db.RepoMock
+		repoCache    *cache.RepoMock
+		wantSource   string
+		wantErr      error
+		wantDeletion bool
+	}{
+		{
+			name:       "case success monthly",
+			repoDB:     repoDB(0, 3, false),
+			repoCache:  &cache.RepoMock{AddBoostedUserFunc: added},
+			wantSource: model.BoostSourceMonthly,
+		},
+		{
+			name:       "case success purchased",
+			repoDB:     repoDB(1, 3, false),
+			repoCache:  &cache.RepoMock{AddBoostedUserFunc: added},
+			wantSource: model.BoostSourcePurchased,
+		},
+		{
+			name:      "case error boost running",
+			repoDB:    repoDB(0, 3, true),
+			repoCache: &cache.RepoMock{},
+			wantErr:   model.BoostActiveErr,
+		},
+		{
+			name:      "case error no boosts left",
+			repoDB:    repoDB(1, 0, false),
+			repoCache: &cache.RepoMock{},
+			wantErr:   model.BoostLimitErr,
+		},
+		{
+			name:   "case error cache",
+			repoDB: repoDB(0, 0, false),
+			repoCache: &cache.RepoMock{
+				AddBoostedUserFunc: func(ctx context.Context, boost model.Boost) error {
+					return errors.New("err")
+				},
+			},
+			wantErr:      errors.New("err"),
+			wantDeletion: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB:    tt.repoDB,
+				RepoCache: tt.repoCache,
+			}
+			got, gotErr := u.ActivateBoost(context.Background(), 1)
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Equal(t, tt.wantSource, got.Source)
+			assert.Equal(t, tt.wantDeletion, len(tt.repoDB.DeleteBoostCalls()) == 1)
+			if tt.wantErr == nil {
+				assert.Equal(t, int64(2), got.ID)
+				assert.Len(t, tt.repoCache.AddBoostedUserCalls(), 1)
+			}
+		})
+	}
+}
+
+func TestPurchaseBoosts(t *testing.T) {
+	verify := func(productID, status string) func(ctx context.Context, store, r string) (receipt.Purchase, error) {
+		return func(ctx context.Context, store, r string) (receipt.Purchase, error) {
+			return receipt.Purchase{ID: "purchase_1", Store: store, ProductID: productID, Status: status, Price: 499, Currency: "usd"}, nil
+		}
+	}
+	repoDB := func(owner int64) *db.RepoMock {
+		return &db.RepoMock{
+			CreditBoostPurchaseFunc: func(ctx context.Context, req model.BoostPurchase) (int64, error) {
+				assert.Equal(t, model.BoostPurchase{ID: "play:purchase_1", UserID: 1, Boosts: 5}, req)
+				return owner, nil
+			},
+			GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+				return &model.User{UserID: userID, Tier: model.TierFree}, nil
+			},
+			CountBoostsFunc: func(ctx context.Context, userID int64, source string, since time.Time) (int64, error) {
+				return 0, nil
+			},
+			GetBoostBalanceFunc: func(ctx context.Context, userID int64) (int64, error) {
+				return 5, nil
+			},
+			GetActiveBoostFunc: func(ctx context.Context, userID int64, at time.Time) (*model.Boost, error) {
+				return nil, model.NotFoundErr
+			},
+			InsertLedgerEntryFunc: func(ctx context.Context, entry model.LedgerEntry, invoice *model.Invoice) (bool, error) {
+				assert.Equal(t, model.LedgerKindCharge, entry.Kind)
+				assert.Equal(t, "boost_purchase:play:purchase_1", entry.Reference)
+				assert.Equal(t, int64(499), invoice.Total)
+				return true, nil
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		receipts *receipt.VerifierMock
+		repoDB   *db.RepoMock
+		want     model.BoostInventory
+		wantErr  error
+	}{
+		{
+			name:     "case success",
+			receipts: &receipt.VerifierMock{VerifyReceiptFunc: verify("boosts_5", receipt.StatusActive)},
+			repoDB:   repoDB(1),
+			want:     model.BoostInventory{Purchased: 5},
+		},
+		{
+			name:     "case error receipt of another user",
+			receipts: &receipt.VerifierMock{VerifyReceiptFunc: verify("boosts_5", receipt.StatusActive)},
+			repoDB:   repoDB(2),
+			wantErr:  model.ReceiptInUseErr,
+		},
+		{
+			name:     "case error refunded",
+			receipts: &receipt.VerifierMock{VerifyReceiptFunc: verify("boosts_5", receipt.StatusRefunded)},
+			repoDB:   &db.RepoMock{},
+			wantErr:  model.InvalidReceiptErr,
+		},
+		{
+			name:     "case error not a boost pack",
+			receipts: &receipt.VerifierMock{VerifyReceiptFunc: verify("gold_monthly", receipt.StatusActive)},
+			repoDB:   &db.RepoMock{},
+			wantErr:  model.InvalidPlanErr,
+		},
+		{
+			name: "case error invalid receipt",
+			receipts: &receipt.VerifierMock{
+				VerifyReceiptFunc: func(ctx context.Context, store, r string) (receipt.Purchase, error) {
+					return receipt.Purchase{}, receipt.ErrInvalidReceipt
+				},
+			},
+			repoDB:  &db.RepoMock{},
+			wantErr: model.InvalidReceiptErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB:   tt.repoDB,
+				Receipts: tt.receipts,
+				Config:   Config{StoreBoostPacks: map[string]int{"boosts_5": 5}},
+			}
+			got, gotErr := u.PurchaseBoosts(context.Background(), model.ReceiptRequest{UserID: 1, Store: receipt.StorePlay, Receipt: "receipt_1"})
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr == nil, len(tt.repoDB.InsertLedgerEntryCalls()) == 1)
+		})
+	}
+}
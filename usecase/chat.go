@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/entitlement"
+)
+
+func (s *usecase) StartConversation(ctx context.Context, req model.StartConversationRequest) (res model.Conversation, err error) {
+	if req.UserID == req.RecipientID {
+		err = model.NotMatchedErr
+		return
+	}
+
+	matched, err := s.RepoDB.IsMatch(ctx, req.UserID, req.RecipientID)
+	if err != nil {
+		log.Println("error when checking match from db")
+		return
+	}
+
+	if !matched {
+		err = model.NotMatchedErr
+		return
+	}
+
+	conversation, err := s.RepoDB.GetOrCreateConversation(ctx, req.UserID, req.RecipientID)
+	if err != nil {
+		log.Println("error when creating conversation in db")
+		return
+	}
+
+	res = *conversation
+	return
+}
+
+func (s *usecase) GetConversations(ctx context.Context, userID int64) (conversations []model.Conversation, err error) {
+	conversations, err = s.RepoDB.GetConversations(ctx, userID)
+	if err != nil {
+		log.Println("error when fetching conversations from db")
+		return
+	}
+
+	return
+}
+
+func (s *usecase) SendMessage(ctx context.Context, req model.SendMessageRequest) (res model.Message, err error) {
+	if strings.TrimSpace(req.Body) == "" || utf8.RuneCountInString(req.Body) > messageMaxLength {
+		err = model.InvalidMessageErr
+		return
+	}
+
+	conversation, err := s.getConversation(ctx, req.UserID, req.ConversationID)
+	if err != nil {
+		return
+	}
+
+	// Unmatching after the conversation started ends it as well
+	matched, err := s.RepoDB.IsMatch(ctx, req.UserID, conversation.Peer(req.UserID))
+	if err != nil {
+		log.Println("error when checking match from db")
+		return
+	}
+
+	if !matched {
+		err = model.NotMatchedErr
+		return
+	}
+
+	res = model.Message{
+		ConversationID: req.ConversationID,
+		SenderID:       req.UserID,
+		Body:           req.Body,
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	res.ID, err = s.RepoDB.InsertMessage(ctx, res)
+	if err != nil {
+		log.Println("error when inserting message to db")
+		return
+	}
+
+	return
+}
+
+func (s *usecase) GetMessages(ctx context.Context, req model.GetMessagesRequest) (res model.GetMessagesResponse, err error) {
+	_, err = s.getConversation(ctx, req.UserID, req.ConversationID)
+	if err != nil {
+		return
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = messagesDefaultLimit
+	} else if limit > messagesMaxLimit {
+		limit = messagesMaxLimit
+	}
+
+	// One extra message tells whether there is an older page
+	messages, err := s.RepoDB.GetMessages(ctx, req.ConversationID, req.BeforeID, limit+1)
+	if err != nil {
+		log.Println("error when fetching messages from db")
+		return
+	}
+
+	if len(messages) > limit {
+		messages = messages[:limit]
+		res.NextBeforeID = messages[limit-1].ID
+	}
+
+	res.Messages = messages
+	if res.Messages == nil {
+		res.Messages = []model.Message{}
+	}
+	return
+}
+
+func (s *usecase) DeleteMessage(ctx context.Context, req model.DeleteMessageRequest) (err error) {
+	err = s.RepoDB.DeleteMessage(ctx, req.MessageID, req.UserID)
+	if err != nil {
+		log.Println("error when deleting message in db")
+		return
+	}
+
+	return
+}
+
+// MarkConversationRead moves the read marker of the user. The peer gets a
+// read receipt when their tier includes them
+func (s *usecase) MarkConversationRead(ctx context.Context, req model.MarkReadRequest) (err error) {
+	conversation, err := s.getConversation(ctx, req.UserID, req.ConversationID)
+	if err != nil {
+		return
+	}
+
+	// A message of another conversation would move the marker past messages
+	// this one has not had yet
+	message, err := s.RepoDB.GetMessage(ctx, req.MessageID)
+	if err != nil {
+		log.Println("error when fetching message from db")
+		return
+	}
+
+	if message.ConversationID != req.ConversationID {
+		err = model.NotFoundErr
+		return
+	}
+
+	err = s.RepoDB.UpdateLastRead(ctx, req.ConversationID, req.UserID, req.MessageID)
+	if err != nil {
+		log.Println("error when updating last read message in db")
+		return
+	}
+
+	peerID := conversation.Peer(req.UserID)
+	receipts, errCan := s.Can(ctx, peerID, entitlement.FeatureReadReceipts)
+	if errCan != nil {
+		log.Println("error when checking read receipts of peer")
+		return
+	}
+
+	if receipts {
+		s.publishEvent(ctx, peerID, model.EventTypeMessagesRead, model.MessagesReadEvent{
+			ConversationID: req.ConversationID,
+			MessageID:      req.MessageID,
+		})
+	}
+
+	return
+}
+
+// getConversation fetches a conversation the user takes part in. Other
+// conversations are reported as not found so their existence is not leaked
+func (s *usecase) getConversation(ctx context.Context, userID, conversationID int64) (conversation *model.Conversation, err error) {
+	conversation, err = s.RepoDB.GetConversation(ctx, conversationID)
+	if err != nil {
+		log.Println("error when fetching conversation from db")
+		return
+	}
+
+	if !conversation.HasParticipant(userID) {
+		err = model.NotFoundErr
+		return
+	}
+
+	return
+}
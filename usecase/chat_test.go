@@ -0,0 +1,553 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/repository/cache"
+	"github.com/egnptr/dating-app/repository/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartConversation(t *testing.T) {
+	type fields struct {
+		repoDB db.Repo
+	}
+	type args struct {
+		req model.StartConversationRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    model.Conversation
+		wantErr error
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					IsMatchFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return true, nil
+					},
+					GetOrCreateConversationFunc: func(ctx context.Context, userID, otherUserID int64) (*model.Conversation, error) {
+						return &model.Conversation{ID: 1, UserAID: 1, UserBID: 2, PeerID: 2}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.StartConversationRequest{
+					UserID:      1,
+					RecipientID: 2,
+				},
+			},
+			want: model.Conversation{ID: 1, UserAID: 1, UserBID: 2, PeerID: 2},
+		},
+		{
+			name: "case error not matched",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					IsMatchFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return false, nil
+					},
+				},
+			},
+			args: args{
+				req: model.StartConversationRequest{
+					UserID:      1,
+					RecipientID: 2,
+				},
+			},
+			wantErr: model.NotMatchedErr,
+		},
+		{
+			name: "case error self",
+			args: args{
+				req: model.StartConversationRequest{
+					UserID:      1,
+					RecipientID: 1,
+				},
+			},
+			wantErr: model.NotMatchedErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.fields.repoDB,
+			}
+			got, gotErr := u.StartConversation(context.Background(), tt.args.req)
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetConversations(t *testing.T) {
+	type fields struct {
+		repoDB db.Repo
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetConversationsFunc: func(ctx context.Context, userID int64) ([]model.Conversation, error) {
+						return []model.Conversation{{ID: 1, PeerID: 2, UnreadCount: 3}}, nil
+					},
+				},
+			},
+			wantLen: 1,
+		},
+		{
+			name: "case error db",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetConversationsFunc: func(ctx context.Context, userID int64) ([]model.Conversation, error) {
+						return nil, errors.New("err")
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.fields.repoDB,
+			}
+			got, gotErr := u.GetConversations(context.Background(), 1)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("GetConversations() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Len(t, got, tt.wantLen)
+		})
+	}
+}
+
+func TestSendMessage(t *testing.T) {
+	conversation := func(ctx context.Context, conversationID int64) (*model.Conversation, error) {
+		return &model.Conversation{ID: conversationID, UserAID: 1, UserBID: 2}, nil
+	}
+
+	type fields struct {
+		repoDB db.Repo
+	}
+	type args struct {
+		req model.SendMessageRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantID  int64
+		wantErr error
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetConversationFunc: conversation,
+					IsMatchFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return userID == 1 && otherUserID == 2, nil
+					},
+					InsertMessageFunc: func(ctx context.Context, req model.Message) (int64, error) {
+						return 10, nil
+					},
+				},
+			},
+			args: args{
+				req: model.SendMessageRequest{
+					UserID:         1,
+					ConversationID: 1,
+					Body:           "hello",
+				},
+			},
+			wantID: 10,
+		},
+		{
+			name: "case error empty body",
+			args: args{
+				req: model.SendMessageRequest{
+					UserID:         1,
+					ConversationID: 1,
+					Body:           "  ",
+				},
+			},
+			wantErr: model.InvalidMessageErr,
+		},
+		{
+			name: "case error body too long",
+			args: args{
+				req: model.SendMessageRequest{
+					UserID:         1,
+					ConversationID: 1,
+					Body:           strings.Repeat("a", messageMaxLength+1),
+				},
+			},
+			wantErr: model.InvalidMessageErr,
+		},
+		{
+			name: "case error not participant",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetConversationFunc: conversation,
+				},
+			},
+			args: args{
+				req: model.SendMessageRequest{
+					UserID:         3,
+					ConversationID: 1,
+					Body:           "hello",
+				},
+			},
+			wantErr: model.NotFoundErr,
+		},
+		{
+			name: "case error unmatched",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetConversationFunc: conversation,
+					IsMatchFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return false, nil
+					},
+				},
+			},
+			args: args{
+				req: model.SendMessageRequest{
+					UserID:         1,
+					ConversationID: 1,
+					Body:           "hello",
+				},
+			},
+			wantErr: model.NotMatchedErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.fields.repoDB,
+			}
+			got, gotErr := u.SendMessage(context.Background(), tt.args.req)
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Equal(t, tt.wantID, got.ID)
+		})
+	}
+}
+
+func TestGetMessages(t *testing.T) {
+	conversation := func(ctx context.Context, conversationID int64) (*model.Conversation, error) {
+		return &model.Conversation{ID: conversationID, UserAID: 1, UserBID: 2}, nil
+	}
+	messages := func(ctx context.Context, conversationID, beforeID int64, limit int) ([]model.Message, error) {
+		var messages []model.Message
+		for id := beforeID - 1; id > 0 && len(messages) < limit; id-- {
+			messages = append(messages, model.Message{ID: id, ConversationID: conversationID})
+		}
+		return messages, nil
+	}
+
+	type fields struct {
+		repoDB db.Repo
+	}
+	type args struct {
+		req model.GetMessagesRequest
+	}
+	tests := []struct {
+		name             string
+		fields           fields
+		args             args
+		wantLen          int
+		wantNextBeforeID int64
+		wantErr          bool
+	}{
+		{
+			name: "case success has older page",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetConversationFunc: conversation,
+					GetMessagesFunc:     messages,
+				},
+			},
+			args: args{
+				req: model.GetMessagesRequest{
+					UserID:         1,
+					ConversationID: 1,
+					BeforeID:       11,
+					Limit:          4,
+				},
+			},
+			wantLen:          4,
+			wantNextBeforeID: 7,
+		},
+		{
+			name: "case success last page",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetConversationFunc: conversation,
+					GetMessagesFunc:     messages,
+				},
+			},
+			args: args{
+				req: model.GetMessagesRequest{
+					UserID:         2,
+					ConversationID: 1,
+					BeforeID:       4,
+				},
+			},
+			wantLen: 3,
+		},
+		{
+			name: "case error not participant",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetConversationFunc: conversation,
+				},
+			},
+			args: args{
+				req: model.GetMessagesRequest{
+					UserID:         3,
+					ConversationID: 1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error db",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetConversationFunc: conversation,
+					GetMessagesFunc: func(ctx context.Context, conversationID, beforeID int64, limit int) ([]model.Message, error) {
+						return nil, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				req: model.GetMessagesRequest{
+					UserID:         1,
+					ConversationID: 1,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.fields.repoDB,
+			}
+			got, gotErr := u.GetMessages(context.Background(), tt.args.req)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("GetMessages() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Len(t, got.Messages, tt.wantLen)
+			assert.Equal(t, tt.wantNextBeforeID, got.NextBeforeID)
+		})
+	}
+}
+
+func TestDeleteMessage(t *testing.T) {
+	type fields struct {
+		repoDB db.Repo
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					DeleteMessageFunc: func(ctx context.Context, messageID, senderID int64) error {
+						return nil
+					},
+				},
+			},
+		},
+		{
+			name: "case error not sender",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					DeleteMessageFunc: func(ctx context.Context, messageID, senderID int64) error {
+						return model.NotFoundErr
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.fields.repoDB,
+			}
+			gotErr := u.DeleteMessage(context.Background(), model.DeleteMessageRequest{UserID: 1, MessageID: 1})
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("DeleteMessage() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestMarkConversationRead(t *testing.T) {
+	conversation := func(ctx context.Context, conversationID int64) (*model.Conversation, error) {
+		return &model.Conversation{ID: conversationID, UserAID: 1, UserBID: 2}, nil
+	}
+
+	message := func(ctx context.Context, messageID int64) (*model.Message, error) {
+		return &model.Message{ID: messageID, ConversationID: 1, SenderID: 1}, nil
+	}
+
+	peer := func(tier string) func(ctx context.Context, userID int64) (*model.User, error) {
+		return func(ctx context.Context, userID int64) (*model.User, error) {
+			assert.Equal(t, int64(1), userID)
+			return &model.User{UserID: userID, Tier: tier}, nil
+		}
+	}
+
+	type fields struct {
+		repoDB db.Repo
+	}
+	type args struct {
+		req model.MarkReadRequest
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantEvents []model.Event
+		wantErr    bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetConversationFunc: conversation,
+					GetMessageFunc:      message,
+					UpdateLastReadFunc: func(ctx context.Context, conversationID, userID, messageID int64) error {
+						return nil
+					},
+					GetUserByIDFunc: peer(model.TierFree),
+				},
+			},
+			args: args{
+				req: model.MarkReadRequest{
+					UserID:         2,
+					ConversationID: 1,
+					MessageID:      5,
+				},
+			},
+		},
+		{
+			name: "case success read receipt",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetConversationFunc: conversation,
+					GetMessageFunc:      message,
+					UpdateLastReadFunc: func(ctx context.Context, conversationID, userID, messageID int64) error {
+						return nil
+					},
+					GetUserByIDFunc: peer(model.TierGold),
+				},
+			},
+			args: args{
+				req: model.MarkReadRequest{
+					UserID:         2,
+					ConversationID: 1,
+					MessageID:      5,
+				},
+			},
+			wantEvents: []model.Event{
+				{UserID: 1, Type: model.EventTypeMessagesRead},
+			},
+		},
+		{
+			name: "case error message of another conversation",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetConversationFunc: conversation,
+					GetMessageFunc: func(ctx context.Context, messageID int64) (*model.Message, error) {
+						return &model.Message{ID: messageID, ConversationID: 7, SenderID: 3}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.MarkReadRequest{
+					UserID:         2,
+					ConversationID: 1,
+					MessageID:      5,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error message not found",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetConversationFunc: conversation,
+					GetMessageFunc: func(ctx context.Context, messageID int64) (*model.Message, error) {
+						return nil, model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				req: model.MarkReadRequest{
+					UserID:         2,
+					ConversationID: 1,
+					MessageID:      5,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetConversationFunc: func(ctx context.Context, conversationID int64) (*model.Conversation, error) {
+						return nil, model.NotFoundErr
+					},
+				},
+			},
+			args: args{
+				req: model.MarkReadRequest{
+					UserID:         2,
+					ConversationID: 1,
+					MessageID:      5,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotEvents []model.Event
+			u := &usecase{
+				RepoDB: tt.fields.repoDB,
+				RepoCache: &cache.RepoMock{
+					PublishEventFunc: func(ctx context.Context, event model.Event) error {
+						gotEvents = append(gotEvents, model.Event{UserID: event.UserID, Type: event.Type})
+						return nil
+					},
+				},
+			}
+			gotErr := u.MarkConversationRead(context.Background(), tt.args.req)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("MarkConversationRead() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
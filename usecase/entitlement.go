@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/entitlement"
+)
+
+// Can reports whether the tier of the user includes the feature
+func (s *usecase) Can(ctx context.Context, userID int64, feature string) (ok bool, err error) {
+	user, err := s.RepoDB.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	return s.entitlements(user).Can(feature), nil
+}
+
+// Limit returns how often the tier of the user allows something in its
+// period, entitlement.Unlimited when there is no cap
+func (s *usecase) Limit(ctx context.Context, userID int64, limit string) (n int, err error) {
+	user, err := s.RepoDB.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	return s.entitlements(user).Limit(limit), nil
+}
+
+func (s *usecase) GetEntitlements(ctx context.Context, userID int64) (res model.Entitlements, err error) {
+	user, err := s.RepoDB.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	return s.Config.Entitlements.Entitlements(user.Tier), nil
+}
+
+// entitlements returns the plan of the tier the user is on, for usecases
+// that fetched the user already
+func (s *usecase) entitlements(user *model.User) entitlement.Plan {
+	return s.Config.Entitlements.Plan(user.Tier)
+}
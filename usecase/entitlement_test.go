@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/entitlement"
+	"github.com/egnptr/dating-app/repository/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEntitlements(t *testing.T) {
+	user := func(tier string) func(ctx context.Context, userID int64) (*model.User, error) {
+		return func(ctx context.Context, userID int64) (*model.User, error) {
+			return &model.User{UserID: userID, Tier: tier}, nil
+		}
+	}
+
+	tests := []struct {
+		name    string
+		repoDB  db.Repo
+		want    model.Entitlements
+		wantErr error
+	}{
+		{
+			name:   "case success free",
+			repoDB: &db.RepoMock{GetUserByIDFunc: user(model.TierFree)},
+			want: model.Entitlements{
+				Tier:     model.TierFree,
+				Features: []string{},
+				Limits: map[string]int{
+					entitlement.LimitDailySwipes:     10,
+					entitlement.LimitDailySuperLikes: 1,
+					entitlement.LimitDailyRewinds:    0,
+					entitlement.LimitMonthlyBoosts:   0,
+				},
+			},
+		},
+		{
+			name:   "case success gold",
+			repoDB: &db.RepoMock{GetUserByIDFunc: user(model.TierGold)},
+			want: model.Entitlements{
+				Tier: model.TierGold,
+				Features: []string{
+					entitlement.FeatureReadReceipts,
+					entitlement.FeatureSeeProfileViewers,
+					entitlement.FeatureSeeWhoLiked,
+				},
+				Limits: map[string]int{
+					entitlement.LimitDailySwipes:     entitlement.Unlimited,
+					entitlement.LimitDailySuperLikes: 10,
+					entitlement.LimitDailyRewinds:    entitlement.Unlimited,
+					entitlement.LimitMonthlyBoosts:   5,
+				},
+			},
+		},
+		{
+			name: "case error get user",
+			repoDB: &db.RepoMock{
+				GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+					return nil, errors.New("err")
+				},
+			},
+			wantErr: errors.New("err"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.repoDB,
+			}
+			got, gotErr := u.GetEntitlements(context.Background(), 1)
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
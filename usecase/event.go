@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+)
+
+// SubscribeEvents starts listening for live events of the user and returns
+// the kept events after req.LastSeq to send first. Live events may repeat
+// the tail of the replay, clients should skip sequence numbers already seen
+func (s *usecase) SubscribeEvents(ctx context.Context, req model.SubscribeEventsRequest) (replay []model.Event, events <-chan model.Event, unsubscribe func(), err error) {
+	// Subscribe before fetching the replay so nothing published in between
+	// is lost
+	events, unsubscribe = s.Events.Subscribe(req.UserID)
+
+	if req.LastSeq > 0 {
+		replay, err = s.RepoCache.GetEventsSince(ctx, req.UserID, req.LastSeq)
+		if err != nil {
+			log.Println("error when fetching events from cache")
+			unsubscribe()
+			return nil, nil, nil, err
+		}
+	}
+
+	return
+}
+
+// PublishQuotaResets notifies the free users whose swipe quota reset
+func (s *usecase) PublishQuotaResets(ctx context.Context, now time.Time) (err error) {
+	userIDs, err := s.RepoCache.PopDueQuotaResets(ctx, now)
+	if err != nil {
+		log.Println("error when fetching quota resets from cache")
+		return
+	}
+
+	for _, userID := range userIDs {
+		s.publishEvent(ctx, userID, model.EventTypeQuotaReset, nil)
+	}
+
+	return
+}
+
+// publishEvent sends an event to the user. Events are best effort, failing
+// to publish one never fails the action that caused it
+func (s *usecase) publishEvent(ctx context.Context, userID int64, eventType string, data interface{}) {
+	event := model.Event{
+		UserID:    userID,
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if data != nil {
+		dataJson, err := json.Marshal(data)
+		if err != nil {
+			log.Println("error marshal json")
+			return
+		}
+		event.Data = dataJson
+	}
+
+	err := s.RepoCache.PublishEvent(ctx, event)
+	if err != nil {
+		log.Println("error when publishing event to cache")
+	}
+}
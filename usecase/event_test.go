@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/event"
+	"github.com/egnptr/dating-app/repository/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeEvents(t *testing.T) {
+	type fields struct {
+		repoCache cache.Repo
+	}
+	type args struct {
+		req model.SubscribeEventsRequest
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantReplay []model.Event
+		wantErr    bool
+	}{
+		{
+			name: "case success replay",
+			fields: fields{
+				repoCache: &cache.RepoMock{
+					GetEventsSinceFunc: func(ctx context.Context, userID, afterSeq int64) ([]model.Event, error) {
+						return []model.Event{{Seq: afterSeq + 1, UserID: userID}}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.SubscribeEventsRequest{
+					UserID:  1,
+					LastSeq: 4,
+				},
+			},
+			wantReplay: []model.Event{{Seq: 5, UserID: 1}},
+		},
+		{
+			name: "case success first connect",
+			args: args{
+				req: model.SubscribeEventsRequest{
+					UserID: 1,
+				},
+			},
+		},
+		{
+			name: "case error cache",
+			fields: fields{
+				repoCache: &cache.RepoMock{
+					GetEventsSinceFunc: func(ctx context.Context, userID, afterSeq int64) ([]model.Event, error) {
+						return nil, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				req: model.SubscribeEventsRequest{
+					UserID:  1,
+					LastSeq: 4,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := event.NewHub()
+			u := &usecase{
+				RepoCache: tt.fields.repoCache,
+				Events:    hub,
+			}
+			gotReplay, gotEvents, unsubscribe, gotErr := u.SubscribeEvents(context.Background(), tt.args.req)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("SubscribeEvents() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			defer unsubscribe()
+			assert.Equal(t, tt.wantReplay, gotReplay)
+
+			hub.Dispatch(model.Event{Seq: 6, UserID: tt.args.req.UserID})
+			assert.Equal(t, int64(6), (<-gotEvents).Seq)
+		})
+	}
+}
+
+func TestPublishQuotaResets(t *testing.T) {
+	type fields struct {
+		repoCache *cache.RepoMock
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		wantEvents int
+		wantErr    bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				repoCache: &cache.RepoMock{
+					PopDueQuotaResetsFunc: func(ctx context.Context, now time.Time) ([]int64, error) {
+						return []int64{1, 2}, nil
+					},
+					PublishEventFunc: func(ctx context.Context, event model.Event) error {
+						return nil
+					},
+				},
+			},
+			wantEvents: 2,
+		},
+		{
+			name: "case error cache",
+			fields: fields{
+				repoCache: &cache.RepoMock{
+					PopDueQuotaResetsFunc: func(ctx context.Context, now time.Time) ([]int64, error) {
+						return nil, errors.New("err")
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoCache: tt.fields.repoCache,
+			}
+			gotErr := u.PublishQuotaResets(context.Background(), time.Now())
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("PublishQuotaResets() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Len(t, tt.fields.repoCache.PublishEventCalls(), tt.wantEvents)
+			for _, call := range tt.fields.repoCache.PublishEventCalls() {
+				assert.Equal(t, model.EventTypeQuotaReset, call.Event.Type)
+			}
+		})
+	}
+}
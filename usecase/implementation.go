@@ -3,15 +3,39 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/entitlement"
+	"github.com/egnptr/dating-app/pkg/oidc"
+	"github.com/egnptr/dating-app/pkg/totp"
 	"github.com/egnptr/dating-app/pkg/util"
 )
 
 func (s *usecase) CreateUser(ctx context.Context, req model.User) (err error) {
 	var hashedPassword string
 
+	errStrength := util.ValidatePassword(req.Password, req.Username, req.Email)
+	if errStrength != nil {
+		err = fmt.Errorf("%w: %v", model.WeakPasswordErr, errStrength)
+		log.Println(err.Error())
+		return
+	}
+
+	// An unknown referral code is turned down before the account exists
+	var referrerID int64
+	if req.ReferralCode != "" {
+		referrerID, err = s.RepoDB.GetReferrerByCode(ctx, normalizeCode(req.ReferralCode))
+		if err != nil {
+			log.Println("error when fetching referral code from db")
+			return
+		}
+	}
+
 	hashedPassword, err = util.HashPassword(req.Password)
 	if err != nil {
 		log.Println("error hashing password")
@@ -19,40 +43,787 @@ func (s *usecase) CreateUser(ctx context.Context, req model.User) (err error) {
 	}
 	req.Password = hashedPassword
 
-	err = s.RepoDB.CreateUser(ctx, req)
+	userID, err := s.RepoDB.CreateUser(ctx, req)
 	if err != nil {
 		log.Println("error when creating new user in db")
+		return
+	}
+
+	if referrerID != 0 {
+		if errRefer := s.refer(ctx, referrerID, userID); errRefer != nil {
+			log.Println("error when recording referral")
+		}
+	}
+
+	// The account is usable before verification, so a failed email is not fatal
+	errVerify := s.sendEmailVerification(ctx, userID, req.Email)
+	if errVerify != nil {
+		log.Println("error when sending email verification")
 	}
 
 	return
 }
 
-func (s *usecase) Login(ctx context.Context, req model.LoginRequest) (err error) {
+func (s *usecase) Login(ctx context.Context, req model.LoginRequest) (res model.LoginResponse, err error) {
+	lockKeys := loginLockKeys(req)
+
+	// Reject locked attempts before spending any time on bcrypt
+	for key := range lockKeys {
+		retryAfter, errCache := s.RepoCache.GetLoginLock(ctx, key)
+		if errCache != nil {
+			err = errCache
+			log.Println("error when fetching login lock from cache")
+			return
+		}
+
+		if retryAfter > 0 {
+			err = &model.LockedError{RetryAfter: retryAfter}
+			log.Println(err.Error())
+			return
+		}
+	}
+
 	user, err := s.RepoDB.GetUser(ctx, req.Username)
+	if err != nil && err != model.NotFoundErr {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	// Unknown usernames fail as wrong passwords do, and take as long, so
+	// neither tells which usernames exist
+	hashedPassword := unknownUserHash()
+	if user != nil {
+		hashedPassword = user.Password
+	}
+
+	err = util.CheckPassword(req.Password, hashedPassword)
+	if err != nil || user == nil {
+		err = model.UnauthorizedErr
+		log.Println("error unauthorized login")
+
+		for key, maxAttempts := range lockKeys {
+			errLock := s.registerLoginFailure(ctx, key, maxAttempts)
+			if errLock != nil {
+				log.Println("error when registering failed login")
+			}
+		}
+		return
+	}
+
+	// The address is left to run out on its own, or logging into an account
+	// of one's own would clear the failures on everyone else's. With a second
+	// factor the failures are only cleared once it is verified too
+	if !user.TwoFactorEnabled {
+		err = s.RepoCache.ResetLoginFailure(ctx, loginUserLockKey(req.Username))
+		if err != nil {
+			log.Println("error when resetting failed login in cache")
+			return
+		}
+	}
+
+	// The plain password is only available here, so hashes created under an
+	// older policy are upgraded as their owners log in
+	if util.NeedsRehash(user.Password) {
+		errRehash := s.rehashPassword(ctx, user.UserID, req.Password)
+		if errRehash != nil {
+			log.Println("error when rehashing password")
+		}
+	}
+
+	return s.completeLogin(ctx, user)
+}
+
+// completeLogin hands out a session for a user whose first factor has been
+// verified, or a challenge when a second factor is still required
+func (s *usecase) completeLogin(ctx context.Context, user *model.User) (res model.LoginResponse, err error) {
+	err = s.checkAccountStatus(ctx, user)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	if user.TwoFactorEnabled {
+		res.TwoFactorRequired = true
+		res.ChallengeToken, err = util.GenerateToken(32)
+		if err != nil {
+			log.Println("error generating login challenge token")
+			return
+		}
+
+		err = s.RepoCache.SetLoginChallenge(ctx, res.ChallengeToken, user.UserID)
+		if err != nil {
+			log.Println("error when setting login challenge to cache")
+		}
+		return
+	}
+
+	res.Token, err = s.createSession(ctx, user.UserID)
+	return
+}
+
+func (s *usecase) OAuthLogin(ctx context.Context, req model.OAuthLoginRequest) (res model.OAuthLoginResponse, err error) {
+	provider, ok := s.Providers[req.Provider]
+	if !ok {
+		err = model.NotFoundErr
+		log.Println("error unknown oauth provider")
+		return
+	}
+
+	state, err := util.GenerateToken(16)
+	if err != nil {
+		log.Println("error generating oauth state")
+		return
+	}
+
+	nonce, err := util.GenerateToken(16)
+	if err != nil {
+		log.Println("error generating oauth nonce")
+		return
+	}
+
+	codeVerifier, err := oidc.GenerateCodeVerifier()
+	if err != nil {
+		log.Println("error generating pkce code verifier")
+		return
+	}
+
+	err = s.RepoCache.SetOAuthState(ctx, state, model.OAuthState{
+		Provider:     req.Provider,
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+	})
+	if err != nil {
+		log.Println("error when setting oauth state to cache")
+		return
+	}
+
+	res.AuthorizationURL = provider.AuthCodeURL(state, nonce, codeVerifier)
+	return
+}
+
+func (s *usecase) OAuthCallback(ctx context.Context, req model.OAuthCallbackRequest) (res model.LoginResponse, err error) {
+	state, err := s.RepoCache.PopOAuthState(ctx, req.State)
+	if err != nil {
+		log.Println("error when fetching oauth state from cache")
+		return
+	}
+
+	provider, ok := s.Providers[state.Provider]
+	if !ok {
+		err = model.NotFoundErr
+		log.Println("error unknown oauth provider")
+		return
+	}
+
+	claims, err := provider.Exchange(ctx, req.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		log.Println("error exchanging oauth code: ", err.Error())
+		err = model.UnauthorizedErr
+		return
+	}
+
+	var userID int64
+	identity, err := s.RepoDB.GetIdentity(ctx, state.Provider, claims.Subject)
+	if err == model.NotFoundErr {
+		userID, err = s.linkIdentity(ctx, state.Provider, claims)
+		if err != nil {
+			log.Println("error when linking oauth identity")
+			return
+		}
+	} else if err != nil {
+		log.Println("error when fetching identity from db")
+		return
+	} else {
+		userID = identity.UserID
+	}
+
+	user, err := s.RepoDB.GetUserByID(ctx, userID)
 	if err != nil {
 		log.Println("error when fetching user from db")
 		return
 	}
 
-	err = util.CheckPassword(req.Password, user.Password)
+	return s.completeLogin(ctx, user)
+}
+
+// linkIdentity attaches a first time external identity to the account with
+// the same email, or creates a new account for it. Accounts are only linked
+// when both sides verified the email, otherwise anyone registering an
+// address at a provider could take over the local account
+func (s *usecase) linkIdentity(ctx context.Context, provider string, claims oidc.Claims) (userID int64, err error) {
+	email := claims.Email
+	if email == "" {
+		email = fmt.Sprintf("%s@%s.invalid", claims.Subject, provider)
+	}
+
+	existing, err := s.RepoDB.GetUserByEmail(ctx, email)
+	if err == nil {
+		if !claims.EmailVerified || !existing.EmailVerified {
+			err = model.EmailTakenErr
+			return
+		}
+		userID = existing.UserID
+	} else if err == model.NotFoundErr {
+		userID, err = s.createOAuthUser(ctx, provider, email, claims)
+		if err != nil {
+			return
+		}
+	} else {
+		log.Println("error when fetching user by email from db")
+		return
+	}
+
+	err = s.RepoDB.InsertIdentity(ctx, model.Identity{
+		UserID:   userID,
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	})
 	if err != nil {
+		log.Println("error when inserting identity to db")
+		return
+	}
+
+	err = s.RepoDB.InsertAuditLog(ctx, model.AuditLog{
+		UserID:  userID,
+		ActorID: userID,
+		Action:  model.AuditActionIdentityLinked,
+		Detail:  provider,
+	})
+	if err != nil {
+		log.Println("error when inserting audit log to db")
+	}
+
+	return
+}
+
+func (s *usecase) createOAuthUser(ctx context.Context, provider, email string, claims oidc.Claims) (userID int64, err error) {
+	// Accounts created through a provider have no usable password until
+	// their owner sets one
+	randomPassword, err := util.GenerateToken(32)
+	if err != nil {
+		log.Println("error generating password")
+		return
+	}
+
+	hashedPassword, err := util.HashPassword(randomPassword)
+	if err != nil {
+		log.Println("error hashing password")
+		return
+	}
+
+	user := model.User{
+		Username: fmt.Sprintf("%s_%s", provider, claims.Subject),
+		Password: hashedPassword,
+		FullName: claims.Name,
+		Email:    email,
+	}
+	if user.FullName == "" {
+		user.FullName = user.Username
+	}
+
+	userID, err = s.RepoDB.CreateUser(ctx, user)
+	if err != nil {
+		log.Println("error when creating new user in db")
+		return
+	}
+
+	if claims.EmailVerified {
+		err = s.RepoDB.UpdateEmailVerified(ctx, userID, email)
+		if err != nil {
+			log.Println("error when updating email verification in db")
+		}
+		return
+	}
+
+	if claims.Email != "" {
+		errVerify := s.sendEmailVerification(ctx, userID, email)
+		if errVerify != nil {
+			log.Println("error when sending email verification")
+		}
+	}
+
+	return
+}
+
+func (s *usecase) rehashPassword(ctx context.Context, userID int64, password string) (err error) {
+	hashedPassword, err := util.HashPassword(password)
+	if err != nil {
+		log.Println("error hashing password")
+		return
+	}
+
+	err = s.RepoDB.UpdatePassword(ctx, userID, hashedPassword)
+	if err != nil {
+		log.Println("error when updating password in db")
+	}
+
+	return
+}
+
+func (s *usecase) LoginTwoFactor(ctx context.Context, req model.LoginTwoFactorRequest) (res model.LoginResponse, err error) {
+	userID, err := s.RepoCache.GetLoginChallenge(ctx, req.ChallengeToken)
+	if err != nil {
+		log.Println("error when fetching login challenge from cache")
+		return
+	}
+
+	user, err := s.RepoDB.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	// Failed codes count against the username like failed passwords do, so
+	// asking for a fresh challenge does not give more guesses
+	lockKey := loginUserLockKey(user.Username)
+	retryAfter, err := s.RepoCache.GetLoginLock(ctx, lockKey)
+	if err != nil {
+		log.Println("error when fetching login lock from cache")
+		return
+	}
+
+	if retryAfter > 0 {
+		err = &model.LockedError{RetryAfter: retryAfter}
+		log.Println(err.Error())
+		return
+	}
+
+	attemptKey := fmt.Sprintf("challenge:%s", req.ChallengeToken)
+	attempts, err := s.RepoCache.IncrLoginFailure(ctx, attemptKey)
+	if err != nil {
+		log.Println("error when incrementing failed login in cache")
+		return
+	}
+
+	if attempts > loginChallengeMaxAttempts {
+		err = s.RepoCache.DeleteLoginChallenge(ctx, req.ChallengeToken)
+		if err != nil {
+			log.Println("error when deleting login challenge from cache")
+			return
+		}
+
+		err = model.InvalidTokenErr
+		return
+	}
+
+	err = s.verifySecondFactor(ctx, userID, req)
+	if err == model.UnauthorizedErr {
+		errLock := s.registerLoginFailure(ctx, lockKey, loginMaxAttemptsUser)
+		if errLock != nil {
+			log.Println("error when registering failed login")
+		}
+		return
+	} else if err != nil {
+		return
+	}
+
+	err = s.RepoCache.ResetLoginFailure(ctx, lockKey)
+	if err != nil {
+		log.Println("error when resetting failed login in cache")
+		return
+	}
+
+	err = s.RepoCache.DeleteLoginChallenge(ctx, req.ChallengeToken)
+	if err != nil {
+		log.Println("error when deleting login challenge from cache")
+		return
+	}
+
+	res.Token, err = s.createSession(ctx, userID)
+	return
+}
+
+// verifySecondFactor checks the TOTP code or recovery code of a login, a
+// wrong or reused one fails with UnauthorizedErr
+func (s *usecase) verifySecondFactor(ctx context.Context, userID int64, req model.LoginTwoFactorRequest) (err error) {
+	if req.RecoveryCode != "" {
+		err = s.RepoDB.UseRecoveryCode(ctx, userID, util.HashToken(req.RecoveryCode))
+		if err != nil {
+			log.Println("error unauthorized recovery code")
+		}
+		return
+	}
+
+	secret, err := s.RepoDB.GetTOTP(ctx, userID)
+	if err != nil {
+		log.Println("error when fetching totp from db")
+		return
+	}
+
+	step, ok := totp.Match(secret.Secret, req.Code, time.Now())
+	if !ok {
 		err = model.UnauthorizedErr
-		log.Println("error unauthorized login")
+		log.Println("error unauthorized totp code")
+		return
 	}
 
+	err = s.RepoDB.UseTOTPStep(ctx, userID, step)
+	if err == model.UnauthorizedErr {
+		log.Println("error unauthorized totp code reuse")
+	} else if err != nil {
+		log.Println("error when recording totp step in db")
+	}
 	return
 }
 
-func (s *usecase) UpdateSubscription(ctx context.Context, req model.SubscribeRequest) (err error) {
-	err = s.RepoDB.UpdatePremiumStatus(ctx, req)
+func (s *usecase) createSession(ctx context.Context, userID int64) (token string, err error) {
+	token, err = util.GenerateToken(32)
 	if err != nil {
-		log.Println("error when updating subscription status from db")
+		log.Println("error generating session token")
+		return
+	}
+
+	err = s.RepoCache.SetSession(ctx, token, userID)
+	if err != nil {
+		log.Println("error when setting session to cache")
 	}
 
 	return
 }
 
-func (s *usecase) GetProfiles(ctx context.Context, req model.GetRelatedUserRequest) (filteredUser []model.User, err error) {
+var (
+	unknownUserHashOnce sync.Once
+	unknownUserHashed   string
+)
+
+// unknownUserHash returns a hash of a random password, which logins for
+// unknown usernames are checked against
+func unknownUserHash() string {
+	unknownUserHashOnce.Do(func() {
+		password, err := util.GenerateToken(16)
+		if err == nil {
+			unknownUserHashed, err = util.HashPassword(password)
+		}
+		if err != nil {
+			log.Println("error when hashing password for unknown users")
+		}
+	})
+	return unknownUserHashed
+}
+
+// loginLockKeys returns the keys failed logins are counted against, along with
+// the number of attempts allowed for each of them
+func loginLockKeys(req model.LoginRequest) map[string]int64 {
+	keys := map[string]int64{
+		loginUserLockKey(req.Username): loginMaxAttemptsUser,
+	}
+	if req.IPAddress != "" {
+		keys[fmt.Sprintf("ip:%s", req.IPAddress)] = loginMaxAttemptsIP
+	}
+	return keys
+}
+
+func loginUserLockKey(username string) string {
+	return fmt.Sprintf("user:%s", username)
+}
+
+func (s *usecase) registerLoginFailure(ctx context.Context, key string, maxAttempts int64) (err error) {
+	count, err := s.RepoCache.IncrLoginFailure(ctx, key)
+	if err != nil {
+		log.Println("error when incrementing failed login in cache")
+		return
+	}
+
+	if count < maxAttempts {
+		return
+	}
+
+	lockDuration := loginLockMax
+	if exceeded := count - maxAttempts; exceeded < 6 {
+		lockDuration = loginLockBase << exceeded
+		if lockDuration > loginLockMax {
+			lockDuration = loginLockMax
+		}
+	}
+
+	err = s.RepoCache.SetLoginLock(ctx, key, lockDuration)
+	if err != nil {
+		log.Println("error when setting login lock to cache")
+	}
+
+	return
+}
+
+func (s *usecase) Authenticate(ctx context.Context, token string) (userID int64, err error) {
+	if token == "" {
+		err = model.UnauthorizedErr
+		return
+	}
+
+	userID, err = s.RepoCache.GetSession(ctx, token)
+	if err != nil {
+		log.Println("error when fetching session from cache")
+	}
+
+	return
+}
+
+func (s *usecase) EnrollTwoFactor(ctx context.Context, userID int64) (res model.TwoFactorEnrollResponse, err error) {
+	user, err := s.RepoDB.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	if user.TwoFactorEnabled {
+		err = model.TwoFactorEnabledErr
+		return
+	}
+
+	res.Secret, err = totp.GenerateSecret()
+	if err != nil {
+		log.Println("error generating totp secret")
+		return
+	}
+
+	err = s.RepoDB.UpsertTOTPSecret(ctx, userID, res.Secret)
+	if err != nil {
+		log.Println("error when upserting totp secret in db")
+		return
+	}
+
+	res.URI = totp.URI(totpIssuer, user.Username, res.Secret)
+	return
+}
+
+func (s *usecase) VerifyTwoFactor(ctx context.Context, req model.TwoFactorVerifyRequest) (res model.TwoFactorVerifyResponse, err error) {
+	secret, err := s.RepoDB.GetTOTP(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when fetching totp from db")
+		return
+	}
+
+	if secret.Enabled {
+		err = model.TwoFactorEnabledErr
+		return
+	}
+
+	step, ok := totp.Match(secret.Secret, req.Code, time.Now())
+	if !ok {
+		err = model.UnauthorizedErr
+		log.Println("error unauthorized totp code")
+		return
+	}
+
+	err = s.RepoDB.UseTOTPStep(ctx, req.UserID, step)
+	if err != nil {
+		if err == model.UnauthorizedErr {
+			log.Println("error unauthorized totp code reuse")
+		} else {
+			log.Println("error when recording totp step in db")
+		}
+		return
+	}
+
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, errToken := util.GenerateToken(5)
+		if errToken != nil {
+			err = errToken
+			log.Println("error generating recovery code")
+			return
+		}
+
+		res.RecoveryCodes = append(res.RecoveryCodes, code)
+		hashes = append(hashes, util.HashToken(code))
+	}
+
+	err = s.RepoDB.EnableTOTP(ctx, req.UserID, hashes)
+	if err != nil {
+		log.Println("error when enabling totp in db")
+		return
+	}
+
+	err = s.RepoDB.InsertAuditLog(ctx, model.AuditLog{
+		UserID:  req.UserID,
+		ActorID: req.UserID,
+		Action:  model.AuditActionTwoFactorOn,
+	})
+	if err != nil {
+		log.Println("error when inserting audit log to db")
+	}
+
+	return
+}
+
+func (s *usecase) ChangePassword(ctx context.Context, req model.ChangePasswordRequest) (err error) {
+	user, err := s.RepoDB.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	err = util.CheckPassword(req.CurrentPassword, user.Password)
+	if err != nil {
+		err = model.UnauthorizedErr
+		log.Println("error unauthorized password change")
+		return
+	}
+
+	errStrength := util.ValidatePassword(req.NewPassword, user.Username, user.Email)
+	if errStrength != nil {
+		err = fmt.Errorf("%w: %v", model.WeakPasswordErr, errStrength)
+		log.Println(err.Error())
+		return
+	}
+
+	hashedPassword, err := util.HashPassword(req.NewPassword)
+	if err != nil {
+		log.Println("error hashing password")
+		return
+	}
+
+	err = s.RepoDB.UpdatePassword(ctx, req.UserID, hashedPassword)
+	if err != nil {
+		log.Println("error when updating password in db")
+		return
+	}
+
+	err = s.RepoDB.InsertAuditLog(ctx, model.AuditLog{
+		UserID:  req.UserID,
+		ActorID: req.UserID,
+		Action:  model.AuditActionPasswordChanged,
+	})
+	if err != nil {
+		log.Println("error when inserting audit log to db")
+	}
+
+	return
+}
+
+func (s *usecase) ChangeEmail(ctx context.Context, req model.ChangeEmailRequest) (err error) {
+	user, err := s.RepoDB.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	err = util.CheckPassword(req.CurrentPassword, user.Password)
+	if err != nil {
+		err = model.UnauthorizedErr
+		log.Println("error unauthorized email change")
+		return
+	}
+
+	if !util.ValidEmail(req.NewEmail) {
+		err = model.InvalidEmailErr
+		return
+	}
+
+	_, err = s.RepoDB.GetUserByEmail(ctx, req.NewEmail)
+	if err == nil {
+		err = model.EmailTakenErr
+		return
+	} else if err != model.NotFoundErr {
+		log.Println("error when fetching user by email from db")
+		return
+	}
+
+	err = s.RepoDB.UpdateEmail(ctx, req.UserID, req.NewEmail)
+	if err != nil {
+		if err != model.EmailTakenErr {
+			log.Println("error when updating email in db")
+		}
+		return
+	}
+
+	err = s.RepoDB.InsertAuditLog(ctx, model.AuditLog{
+		UserID:  req.UserID,
+		ActorID: req.UserID,
+		Action:  model.AuditActionEmailChanged,
+		Detail:  fmt.Sprintf("%s -> %s", user.Email, req.NewEmail),
+	})
+	if err != nil {
+		log.Println("error when inserting audit log to db")
+		return
+	}
+
+	err = s.sendEmailVerification(ctx, req.UserID, req.NewEmail)
+	if err != nil {
+		log.Println("error when sending email verification")
+	}
+
+	return
+}
+
+func (s *usecase) VerifyEmail(ctx context.Context, req model.VerifyEmailRequest) (err error) {
+	data, err := s.RepoCache.GetEmailVerification(ctx, req.Token)
+	if err != nil {
+		log.Println("error when fetching email verification from cache")
+		return
+	}
+
+	err = s.RepoDB.UpdateEmailVerified(ctx, data.UserID, data.Email)
+	if err != nil {
+		log.Println("error when updating email verification in db")
+		return
+	}
+
+	// The email is verified either way, the reward can be looked into later
+	if errReward := s.rewardReferral(ctx, data.UserID); errReward != nil {
+		log.Println("error when rewarding referral")
+	}
+
+	err = s.RepoCache.DeleteEmailVerification(ctx, req.Token)
+	if err != nil {
+		log.Println("error when deleting email verification from cache")
+	}
+
+	return
+}
+
+func (s *usecase) sendEmailVerification(ctx context.Context, userID int64, email string) (err error) {
+	token, err := util.GenerateToken(32)
+	if err != nil {
+		log.Println("error generating verification token")
+		return
+	}
+
+	err = s.RepoCache.SetEmailVerification(ctx, token, model.EmailVerification{
+		UserID: userID,
+		Email:  email,
+	})
+	if err != nil {
+		log.Println("error when setting email verification to cache")
+		return
+	}
+
+	err = s.Mailer.Send(ctx, email, "Verify your email",
+		fmt.Sprintf("Open /user/verify-email?token=%s to verify your email address.", token))
+	if err != nil {
+		log.Println("error when sending email")
+	}
+
+	return
+}
+
+// UpdateSubscription starts the checkout of a subscription, or cancels the
+// active one at the end of the period it was paid for
+func (s *usecase) UpdateSubscription(ctx context.Context, req model.SubscribeRequest) (res model.SubscribeResponse, err error) {
+	if !req.Subscribe {
+		err = s.cancelSubscription(ctx, req.UserID)
+		return
+	}
+
+	return s.subscribe(ctx, req)
+}
+
+// GetProfiles lists the users the user has not swiped on yet, shown as to a
+// stranger
+func (s *usecase) GetProfiles(ctx context.Context, req model.GetRelatedUserRequest) (res []model.Profile, err error) {
+	viewer, err := s.RepoDB.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	err = s.checkAccountStatus(ctx, viewer)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
 	users, err := s.RepoDB.GetRelatedUser(ctx, req.UserID)
 	if err != nil {
 		log.Println("error when fetching related users from db")
@@ -71,6 +842,7 @@ func (s *usecase) GetProfiles(ctx context.Context, req model.GetRelatedUserReque
 		return
 	}
 
+	var filteredUser []model.User
 	for _, user := range users {
 		_, exist := userRelationMap[user.UserID]
 		if exist {
@@ -80,6 +852,26 @@ func (s *usecase) GetProfiles(ctx context.Context, req model.GetRelatedUserReque
 		filteredUser = append(filteredUser, user)
 	}
 
+	err = s.attachPhotos(ctx, filteredUser)
+	if err != nil {
+		return
+	}
+
+	// Boosted users come first, keeping the order otherwise. Discovery still
+	// works without boosts when the cache fails
+	boosted, errCache := s.RepoCache.GetBoostedUsers(ctx, time.Now())
+	if errCache != nil {
+		log.Println("error when fetching boosted users from cache")
+	}
+	sort.SliceStable(filteredUser, func(i, j int) bool {
+		return boosted[filteredUser[i].UserID] && !boosted[filteredUser[j].UserID]
+	})
+
+	// Discovery leaves out the user and everyone they swiped on, so the rest
+	// are strangers, to admins as well
+	for _, user := range filteredUser {
+		res = append(res, user.Profile(model.RelationStranger))
+	}
 	return
 }
 
@@ -90,8 +882,41 @@ func (s *usecase) Swipe(ctx context.Context, req model.SwipeRequest) (err error)
 		return
 	}
 
-	// Limit number of swipes based on subscription status
-	if !user.IsPremium {
+	blocked, err := s.RepoDB.IsBlocked(ctx, req.UserID, req.SwipedUserID)
+	if err != nil {
+		log.Println("error when checking block from db")
+		return
+	}
+
+	if blocked {
+		err = model.BlockedErr
+		return
+	}
+
+	err = s.checkAccountStatus(ctx, user)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+
+	superLike := req.SwipeStatus == model.SwipeStatusSuperLike
+	plan := s.entitlements(user)
+	dailySwipes := plan.Limit(entitlement.LimitDailySwipes)
+
+	// Super likes have their own allowance apart from the regular swipes
+	if superLike {
+		used, errCache := s.RepoCache.GetSuperLikeCount(ctx, req.UserID)
+		if errCache != nil {
+			err = errCache
+			log.Println("error when fetching super like count from cache")
+			return
+		}
+
+		if !plan.Allows(entitlement.LimitDailySuperLikes, used) {
+			err = model.SuperLikeLimitErr
+			return
+		}
+	} else if dailySwipes != entitlement.Unlimited {
 		limit, errCache := s.RepoCache.GetRelatedUserCacheLen(ctx, req.UserID)
 		if errCache != nil {
 			err = errCache
@@ -99,7 +924,15 @@ func (s *usecase) Swipe(ctx context.Context, req model.SwipeRequest) (err error)
 			return
 		}
 
-		if limit > 10 {
+		// Super likes are kept in the same list but do not count
+		superLikes, errCache := s.RepoCache.GetSuperLikeCount(ctx, req.UserID)
+		if errCache != nil {
+			err = errCache
+			log.Println("error when fetching super like count from cache")
+			return
+		}
+
+		if limit-superLikes > int64(dailySwipes) {
 			err = errors.New("error reach limit of swipe")
 			return
 		}
@@ -114,5 +947,57 @@ func (s *usecase) Swipe(ctx context.Context, req model.SwipeRequest) (err error)
 		return
 	}
 
+	// The cache only covers the last day, matches are decided on the swipes
+	// kept in the db
+	err = s.RepoDB.UpsertSwipe(ctx, req)
+	if err != nil {
+		log.Println("error when upserting swipe to db")
+		return
+	}
+
+	if superLike {
+		_, errCache := s.RepoCache.IncrSuperLikeCount(ctx, req.UserID)
+		if errCache != nil {
+			log.Println("error when counting super like to cache")
+		}
+	}
+
+	if dailySwipes != entitlement.Unlimited {
+		errSchedule := s.RepoCache.ScheduleQuotaReset(ctx, req.UserID, time.Now().Add(swipeQuotaWindow))
+		if errSchedule != nil {
+			log.Println("error when scheduling quota reset to cache")
+		}
+	}
+
+	// Shadowbanned users are not told apart, their likes are kept but nobody
+	// hears of them
+	liked := req.SwipeStatus == model.SwipeStatusLike || superLike
+	if liked && user.AccountStatus != model.AccountStatusShadowbanned {
+		s.publishLike(ctx, req.UserID, req.SwipedUserID, superLike)
+	}
+
 	return
 }
+
+// publishLike tells the liked user about the like, or both users about the
+// match when the like was mutual
+func (s *usecase) publishLike(ctx context.Context, userID, likedUserID int64, superLike bool) {
+	matched, err := s.RepoDB.IsMatch(ctx, userID, likedUserID)
+	if err != nil {
+		log.Println("error when checking match from db")
+		return
+	}
+
+	if !matched {
+		eventType := model.EventTypeLiked
+		if superLike {
+			eventType = model.EventTypeSuperLiked
+		}
+
+		s.publishEvent(ctx, likedUserID, eventType, model.LikedEvent{UserID: userID})
+		return
+	}
+
+	s.publishEvent(ctx, userID, model.EventTypeMatched, model.MatchedEvent{UserID: likedUserID})
+	s.publishEvent(ctx, likedUserID, model.EventTypeMatched, model.MatchedEvent{UserID: userID})
+}
@@ -4,18 +4,26 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/mail"
+	"github.com/egnptr/dating-app/pkg/oidc"
+	"github.com/egnptr/dating-app/pkg/payment"
+	"github.com/egnptr/dating-app/pkg/storage"
+	"github.com/egnptr/dating-app/pkg/totp"
 	"github.com/egnptr/dating-app/pkg/util"
 	"github.com/egnptr/dating-app/repository/cache"
 	"github.com/egnptr/dating-app/repository/db"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestCreateUser(t *testing.T) {
 	type fields struct {
 		repoDB    db.Repo
 		repoCache cache.Repo
+		mailer    mail.Mailer
 	}
 	type args struct {
 		req model.User
@@ -30,7 +38,17 @@ func TestCreateUser(t *testing.T) {
 			name: "case success",
 			fields: fields{
 				repoDB: &db.RepoMock{
-					CreateUserFunc: func(ctx context.Context, req model.User) error {
+					CreateUserFunc: func(ctx context.Context, req model.User) (int64, error) {
+						return 1, nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					SetEmailVerificationFunc: func(ctx context.Context, token string, data model.EmailVerification) error {
+						return nil
+					},
+				},
+				mailer: &mail.MailerMock{
+					SendFunc: func(ctx context.Context, to, subject, body string) error {
 						return nil
 					},
 				},
@@ -38,21 +56,37 @@ func TestCreateUser(t *testing.T) {
 			args: args{
 				req: model.User{
 					Username: "test",
-					Password: "password",
+					Password: "correct-horse-42",
 					FullName: "full name",
 					Email:    "test@mail.com",
 				},
 			},
 		},
 		{
-			name: "case error db",
+			name: "case success error email verification",
 			fields: fields{
 				repoDB: &db.RepoMock{
-					CreateUserFunc: func(ctx context.Context, req model.User) error {
+					CreateUserFunc: func(ctx context.Context, req model.User) (int64, error) {
+						return 1, nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					SetEmailVerificationFunc: func(ctx context.Context, token string, data model.EmailVerification) error {
 						return errors.New("err")
 					},
 				},
 			},
+			args: args{
+				req: model.User{
+					Username: "test",
+					Password: "correct-horse-42",
+					FullName: "full name",
+					Email:    "test@mail.com",
+				},
+			},
+		},
+		{
+			name: "case error weak password",
 			args: args{
 				req: model.User{
 					Username: "test",
@@ -63,12 +97,32 @@ func TestCreateUser(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "case error db",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					CreateUserFunc: func(ctx context.Context, req model.User) (int64, error) {
+						return 0, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				req: model.User{
+					Username: "test",
+					Password: "correct-horse-42",
+					FullName: "full name",
+					Email:    "test@mail.com",
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			u := &usecase{
 				RepoDB:    tt.fields.repoDB,
 				RepoCache: tt.fields.repoCache,
+				Mailer:    tt.fields.mailer,
 			}
 			gotErr := u.CreateUser(context.Background(), tt.args.req)
 			if (gotErr != nil) != tt.wantErr {
@@ -82,6 +136,7 @@ func TestCreateUser(t *testing.T) {
 func TestLogin(t *testing.T) {
 	password := "testpass"
 	hashedPassword, _ := util.HashPassword(password)
+	outdatedHashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
 
 	type fields struct {
 		repoDB    db.Repo
@@ -107,6 +162,189 @@ func TestLogin(t *testing.T) {
 						}, nil
 					},
 				},
+				repoCache: &cache.RepoMock{
+					GetLoginLockFunc: func(ctx context.Context, key string) (time.Duration, error) {
+						return 0, nil
+					},
+					ResetLoginFailureFunc: func(ctx context.Context, key string) error {
+						assert.Equal(t, "user:test", key)
+						return nil
+					},
+					SetSessionFunc: func(ctx context.Context, token string, userID int64) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.LoginRequest{
+					Username:  "test",
+					Password:  password,
+					IPAddress: "127.0.0.1",
+				},
+			},
+		},
+		{
+			name: "case error banned",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserFunc: func(ctx context.Context, username string) (*model.User, error) {
+						return &model.User{
+							Username:      "test",
+							Password:      hashedPassword,
+							AccountStatus: model.AccountStatusBanned,
+						}, nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetLoginLockFunc: func(ctx context.Context, key string) (time.Duration, error) {
+						return 0, nil
+					},
+					ResetLoginFailureFunc: func(ctx context.Context, key string) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.LoginRequest{
+					Username: "test",
+					Password: password,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case success rehash outdated password",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserFunc: func(ctx context.Context, username string) (*model.User, error) {
+						return &model.User{
+							UserID:   1,
+							Username: "test",
+							Password: string(outdatedHashedPassword),
+						}, nil
+					},
+					UpdatePasswordFunc: func(ctx context.Context, userID int64, hashedPassword string) error {
+						assert.False(t, util.NeedsRehash(hashedPassword))
+						return nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetLoginLockFunc: func(ctx context.Context, key string) (time.Duration, error) {
+						return 0, nil
+					},
+					ResetLoginFailureFunc: func(ctx context.Context, key string) error {
+						return nil
+					},
+					SetSessionFunc: func(ctx context.Context, token string, userID int64) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.LoginRequest{
+					Username: "test",
+					Password: password,
+				},
+			},
+		},
+		{
+			name: "case success two factor challenge",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserFunc: func(ctx context.Context, username string) (*model.User, error) {
+						return &model.User{
+							Username:         "test",
+							Password:         hashedPassword,
+							TwoFactorEnabled: true,
+						}, nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetLoginLockFunc: func(ctx context.Context, key string) (time.Duration, error) {
+						return 0, nil
+					},
+					SetLoginChallengeFunc: func(ctx context.Context, token string, userID int64) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.LoginRequest{
+					Username: "test",
+					Password: password,
+				},
+			},
+		},
+		{
+			name: "case error locked",
+			fields: fields{
+				repoCache: &cache.RepoMock{
+					GetLoginLockFunc: func(ctx context.Context, key string) (time.Duration, error) {
+						return time.Minute, nil
+					},
+				},
+			},
+			args: args{
+				req: model.LoginRequest{
+					Username: "test",
+					Password: password,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error wrong password",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserFunc: func(ctx context.Context, username string) (*model.User, error) {
+						return &model.User{
+							Username: "test",
+							Password: hashedPassword,
+						}, nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetLoginLockFunc: func(ctx context.Context, key string) (time.Duration, error) {
+						return 0, nil
+					},
+					IncrLoginFailureFunc: func(ctx context.Context, key string) (int64, error) {
+						return 5, nil
+					},
+					SetLoginLockFunc: func(ctx context.Context, key string, duration time.Duration) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.LoginRequest{
+					Username: "test",
+					Password: "wrongpass",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error cache",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserFunc: func(ctx context.Context, username string) (*model.User, error) {
+						return &model.User{
+							Username: "test",
+							Password: hashedPassword,
+						}, nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetLoginLockFunc: func(ctx context.Context, key string) (time.Duration, error) {
+						return 0, nil
+					},
+					ResetLoginFailureFunc: func(ctx context.Context, key string) error {
+						return nil
+					},
+					SetSessionFunc: func(ctx context.Context, token string, userID int64) error {
+						return errors.New("err")
+					},
+				},
 			},
 			args: args{
 				req: model.LoginRequest{
@@ -114,6 +352,7 @@ func TestLogin(t *testing.T) {
 					Password: password,
 				},
 			},
+			wantErr: true,
 		},
 		{
 			name: "case error db",
@@ -123,6 +362,11 @@ func TestLogin(t *testing.T) {
 						return &model.User{}, errors.New("err")
 					},
 				},
+				repoCache: &cache.RepoMock{
+					GetLoginLockFunc: func(ctx context.Context, key string) (time.Duration, error) {
+						return 0, nil
+					},
+				},
 			},
 			args: args{
 				req: model.LoginRequest{
@@ -139,7 +383,7 @@ func TestLogin(t *testing.T) {
 				RepoDB:    tt.fields.repoDB,
 				RepoCache: tt.fields.repoCache,
 			}
-			gotErr := u.Login(context.Background(), tt.args.req)
+			_, gotErr := u.Login(context.Background(), tt.args.req)
 			if (gotErr != nil) != tt.wantErr {
 				t.Errorf("Login() error = %v, wantErr = %v", gotErr, tt.wantErr)
 				return
@@ -148,50 +392,2244 @@ func TestLogin(t *testing.T) {
 	}
 }
 
-func TestUpdateSubscription(t *testing.T) {
+func TestLoginUnknownUsername(t *testing.T) {
+	repoCache := &cache.RepoMock{
+		GetLoginLockFunc: func(ctx context.Context, key string) (time.Duration, error) {
+			return 0, nil
+		},
+		IncrLoginFailureFunc: func(ctx context.Context, key string) (int64, error) {
+			return 1, nil
+		},
+	}
+	u := &usecase{
+		RepoDB: &db.RepoMock{
+			GetUserFunc: func(ctx context.Context, username string) (*model.User, error) {
+				return nil, model.NotFoundErr
+			},
+		},
+		RepoCache: repoCache,
+	}
+
+	_, gotErr := u.Login(context.Background(), model.LoginRequest{
+		Username:  "nobody",
+		Password:  "testpass",
+		IPAddress: "203.0.113.7",
+	})
+	assert.Equal(t, model.UnauthorizedErr, gotErr)
+
+	var keys []string
+	for _, call := range repoCache.IncrLoginFailureCalls() {
+		keys = append(keys, call.Key)
+	}
+	assert.ElementsMatch(t, []string{"user:nobody", "ip:203.0.113.7"}, keys)
+}
+
+func TestLoginTwoFactor(t *testing.T) {
+	now := time.Now()
+	secret, _ := totp.GenerateSecret()
+	code, _ := totp.GenerateCode(secret, now)
+
+	user := func(ctx context.Context, userID int64) (*model.User, error) {
+		return &model.User{UserID: userID, Username: "test", TwoFactorEnabled: true}, nil
+	}
+	enrolled := func(ctx context.Context, userID int64) (*model.TOTP, error) {
+		return &model.TOTP{
+			UserID:  1,
+			Secret:  secret,
+			Enabled: true,
+		}, nil
+	}
+	challenge := func(ctx context.Context, token string) (int64, error) {
+		return 1, nil
+	}
+	unlocked := func(ctx context.Context, key string) (time.Duration, error) {
+		assert.Equal(t, "user:test", key)
+		return 0, nil
+	}
+	failures := func(ctx context.Context, key string) (int64, error) {
+		return 1, nil
+	}
+
 	type fields struct {
 		repoDB    db.Repo
-		repoCache cache.Repo
+		repoCache *cache.RepoMock
 	}
 	type args struct {
-		req model.SubscribeRequest
+		req model.LoginTwoFactorRequest
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
+		name      string
+		fields    fields
+		args      args
+		wantErr   error
+		wantIncr  []string
+		wantReset []string
 	}{
 		{
-			name: "case success",
+			name: "case success totp",
 			fields: fields{
 				repoDB: &db.RepoMock{
-					UpdatePremiumStatusFunc: func(ctx context.Context, req model.SubscribeRequest) error {
+					GetUserByIDFunc: user,
+					GetTOTPFunc:     enrolled,
+					UseTOTPStepFunc: func(ctx context.Context, userID int64, step int64) error {
+						assert.Equal(t, now.Unix()/totp.Period, step)
+						return nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetLoginChallengeFunc: challenge,
+					GetLoginLockFunc:      unlocked,
+					IncrLoginFailureFunc:  failures,
+					ResetLoginFailureFunc: func(ctx context.Context, key string) error {
+						return nil
+					},
+					DeleteLoginChallengeFunc: func(ctx context.Context, token string) error {
+						return nil
+					},
+					SetSessionFunc: func(ctx context.Context, token string, userID int64) error {
 						return nil
 					},
 				},
 			},
 			args: args{
-				req: model.SubscribeRequest{
-					UserID:    1,
-					Subscribe: true,
+				req: model.LoginTwoFactorRequest{
+					ChallengeToken: "challenge",
+					Code:           code,
+				},
+			},
+			wantIncr:  []string{"challenge:challenge"},
+			wantReset: []string{"user:test"},
+		},
+		{
+			name: "case success recovery code",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: user,
+					UseRecoveryCodeFunc: func(ctx context.Context, userID int64, codeHash string) error {
+						return nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetLoginChallengeFunc: challenge,
+					GetLoginLockFunc:      unlocked,
+					IncrLoginFailureFunc:  failures,
+					ResetLoginFailureFunc: func(ctx context.Context, key string) error {
+						return nil
+					},
+					DeleteLoginChallengeFunc: func(ctx context.Context, token string) error {
+						return nil
+					},
+					SetSessionFunc: func(ctx context.Context, token string, userID int64) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.LoginTwoFactorRequest{
+					ChallengeToken: "challenge",
+					RecoveryCode:   "recovery",
+				},
+			},
+			wantIncr:  []string{"challenge:challenge"},
+			wantReset: []string{"user:test"},
+		},
+		{
+			name: "case error wrong code",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: user,
+					GetTOTPFunc:     enrolled,
+				},
+				repoCache: &cache.RepoMock{
+					GetLoginChallengeFunc: challenge,
+					GetLoginLockFunc:      unlocked,
+					IncrLoginFailureFunc:  failures,
+				},
+			},
+			args: args{
+				req: model.LoginTwoFactorRequest{
+					ChallengeToken: "challenge",
+					Code:           "abcdef",
 				},
 			},
+			wantErr:  model.UnauthorizedErr,
+			wantIncr: []string{"challenge:challenge", "user:test"},
 		},
 		{
-			name: "case erorr db",
+			name: "case error wrong recovery code",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: user,
+					UseRecoveryCodeFunc: func(ctx context.Context, userID int64, codeHash string) error {
+						return model.UnauthorizedErr
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetLoginChallengeFunc: challenge,
+					GetLoginLockFunc:      unlocked,
+					IncrLoginFailureFunc:  failures,
+				},
+			},
+			args: args{
+				req: model.LoginTwoFactorRequest{
+					ChallengeToken: "challenge",
+					RecoveryCode:   "recovery",
+				},
+			},
+			wantErr:  model.UnauthorizedErr,
+			wantIncr: []string{"challenge:challenge", "user:test"},
+		},
+		{
+			name: "case error code used before",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: user,
+					GetTOTPFunc:     enrolled,
+					UseTOTPStepFunc: func(ctx context.Context, userID int64, step int64) error {
+						return model.UnauthorizedErr
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetLoginChallengeFunc: challenge,
+					GetLoginLockFunc:      unlocked,
+					IncrLoginFailureFunc:  failures,
+				},
+			},
+			args: args{
+				req: model.LoginTwoFactorRequest{
+					ChallengeToken: "challenge",
+					Code:           code,
+				},
+			},
+			wantErr:  model.UnauthorizedErr,
+			wantIncr: []string{"challenge:challenge", "user:test"},
+		},
+		{
+			name: "case error wrong code locks the user",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: user,
+					GetTOTPFunc:     enrolled,
+				},
+				repoCache: &cache.RepoMock{
+					GetLoginChallengeFunc: challenge,
+					GetLoginLockFunc:      unlocked,
+					IncrLoginFailureFunc: func(ctx context.Context, key string) (int64, error) {
+						if key == "user:test" {
+							return loginMaxAttemptsUser, nil
+						}
+						return 1, nil
+					},
+					SetLoginLockFunc: func(ctx context.Context, key string, duration time.Duration) error {
+						assert.Equal(t, "user:test", key)
+						assert.Equal(t, loginLockBase, duration)
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.LoginTwoFactorRequest{
+					ChallengeToken: "challenge",
+					Code:           "abcdef",
+				},
+			},
+			wantErr:  model.UnauthorizedErr,
+			wantIncr: []string{"challenge:challenge", "user:test"},
+		},
+		{
+			name: "case error locked",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: user,
+				},
+				repoCache: &cache.RepoMock{
+					GetLoginChallengeFunc: challenge,
+					GetLoginLockFunc: func(ctx context.Context, key string) (time.Duration, error) {
+						return time.Minute, nil
+					},
+				},
+			},
+			args: args{
+				req: model.LoginTwoFactorRequest{
+					ChallengeToken: "challenge",
+					Code:           code,
+				},
+			},
+			wantErr: &model.LockedError{RetryAfter: time.Minute},
+		},
+		{
+			name: "case error too many attempts",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: user,
+				},
+				repoCache: &cache.RepoMock{
+					GetLoginChallengeFunc: challenge,
+					GetLoginLockFunc:      unlocked,
+					IncrLoginFailureFunc: func(ctx context.Context, key string) (int64, error) {
+						return 6, nil
+					},
+					DeleteLoginChallengeFunc: func(ctx context.Context, token string) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.LoginTwoFactorRequest{
+					ChallengeToken: "challenge",
+					Code:           code,
+				},
+			},
+			wantErr:  model.InvalidTokenErr,
+			wantIncr: []string{"challenge:challenge"},
+		},
+		{
+			name: "case error expired challenge",
+			fields: fields{
+				repoCache: &cache.RepoMock{
+					GetLoginChallengeFunc: func(ctx context.Context, token string) (int64, error) {
+						return 0, model.InvalidTokenErr
+					},
+				},
+			},
+			args: args{
+				req: model.LoginTwoFactorRequest{
+					ChallengeToken: "challenge",
+					Code:           code,
+				},
+			},
+			wantErr: model.InvalidTokenErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB:    tt.fields.repoDB,
+				RepoCache: tt.fields.repoCache,
+			}
+			gotRes, gotErr := u.LoginTwoFactor(context.Background(), tt.args.req)
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Equal(t, tt.wantErr == nil, gotRes.Token != "")
+
+			var gotIncr, gotReset []string
+			for _, call := range tt.fields.repoCache.IncrLoginFailureCalls() {
+				gotIncr = append(gotIncr, call.Key)
+			}
+			for _, call := range tt.fields.repoCache.ResetLoginFailureCalls() {
+				gotReset = append(gotReset, call.Key)
+			}
+			assert.Equal(t, tt.wantIncr, gotIncr)
+			assert.Equal(t, tt.wantReset, gotReset)
+		})
+	}
+}
+
+func TestRegisterLoginFailure(t *testing.T) {
+	tests := []struct {
+		name         string
+		count        int64
+		wantDuration time.Duration
+	}{
+		{
+			name:  "case below limit",
+			count: 4,
+		},
+		{
+			name:         "case reach limit",
+			count:        5,
+			wantDuration: time.Minute,
+		},
+		{
+			name:         "case exponential backoff",
+			count:        8,
+			wantDuration: 8 * time.Minute,
+		},
+		{
+			name:         "case capped backoff",
+			count:        100,
+			wantDuration: time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotDuration time.Duration
+			u := &usecase{
+				RepoCache: &cache.RepoMock{
+					IncrLoginFailureFunc: func(ctx context.Context, key string) (int64, error) {
+						return tt.count, nil
+					},
+					SetLoginLockFunc: func(ctx context.Context, key string, duration time.Duration) error {
+						gotDuration = duration
+						return nil
+					},
+				},
+			}
+			gotErr := u.registerLoginFailure(context.Background(), "user:test", loginMaxAttemptsUser)
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tt.wantDuration, gotDuration)
+		})
+	}
+}
+
+func TestUpdateSubscription(t *testing.T) {
+	periodEnd := time.Now().Add(24 * time.Hour)
+	active := func(cancelAtPeriodEnd bool, providerID string) func(ctx context.Context, userID int64, at time.Time) (*model.Subscription, error) {
+		return func(ctx context.Context, userID int64, at time.Time) (*model.Subscription, error) {
+			return &model.Subscription{
+				ID:                     1,
+				UserID:                 userID,
+				Plan:                   model.PlanMonthly,
+				Status:                 model.SubscriptionStatusActive,
+				CurrentPeriodEnd:       periodEnd,
+				AutoRenew:              !cancelAtPeriodEnd,
+				CancelAtPeriodEnd:      cancelAtPeriodEnd,
+				ProviderSubscriptionID: providerID,
+			}, nil
+		}
+	}
+	none := func(ctx context.Context, userID int64, at time.Time) (*model.Subscription, error) {
+		return nil, model.NotFoundErr
+	}
+	noDiscount := func(ctx context.Context, userID int64) (*model.PromoCode, error) {
+		return nil, model.NotFoundErr
+	}
+
+	type fields struct {
+		repoDB   *db.RepoMock
+		payments *payment.ProviderMock
+	}
+	type args struct {
+		req model.SubscribeRequest
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		trialDays int
+		wantRes   model.SubscribeResponse
+		wantErr   error
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetActiveSubscriptionFunc: none,
+					GetPendingDiscountFunc:    noDiscount,
+				},
+				payments: &payment.ProviderMock{
+					CreateCheckoutFunc: func(ctx context.Context, req payment.CheckoutRequest) (payment.Checkout, error) {
+						assert.Equal(t, int64(1), req.UserID)
+						assert.Equal(t, model.PlanYearly, req.Plan)
+						return payment.Checkout{ID: "cs_1", URL: "https://pay.example.com/cs_1"}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.SubscribeRequest{
+					UserID:    1,
+					Plan:      model.PlanYearly,
+					Subscribe: true,
+				},
+			},
+			wantRes: model.SubscribeResponse{
+				CheckoutID:  "cs_1",
+				CheckoutURL: "https://pay.example.com/cs_1",
+			},
+		},
+		{
+			name: "case success default plan",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetActiveSubscriptionFunc: none,
+					GetPendingDiscountFunc:    noDiscount,
+				},
+				payments: &payment.ProviderMock{
+					CreateCheckoutFunc: func(ctx context.Context, req payment.CheckoutRequest) (payment.Checkout, error) {
+						assert.Equal(t, model.PlanMonthly, req.Plan)
+						return payment.Checkout{ID: "cs_1", URL: "https://pay.example.com/cs_1"}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.SubscribeRequest{
+					UserID:    1,
+					Subscribe: true,
+				},
+			},
+			wantRes: model.SubscribeResponse{
+				CheckoutID:  "cs_1",
+				CheckoutURL: "https://pay.example.com/cs_1",
+			},
+		},
+		{
+			name: "case success trial and discount",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetActiveSubscriptionFunc: none,
+					HasPaidSubscriptionFunc: func(ctx context.Context, userID int64) (bool, error) {
+						return false, nil
+					},
+					GetPendingDiscountFunc: func(ctx context.Context, userID int64) (*model.PromoCode, error) {
+						return &model.PromoCode{ID: 1, Kind: model.PromoKindPercent, Value: 20}, nil
+					},
+				},
+				payments: &payment.ProviderMock{
+					CreateCheckoutFunc: func(ctx context.Context, req payment.CheckoutRequest) (payment.Checkout, error) {
+						assert.Equal(t, 7, req.TrialDays)
+						assert.Equal(t, int64(20), req.PercentOff)
+						assert.Zero(t, req.AmountOff)
+						return payment.Checkout{ID: "cs_1", URL: "https://pay.example.com/cs_1"}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.SubscribeRequest{
+					UserID:    1,
+					Subscribe: true,
+				},
+			},
+			trialDays: 7,
+			wantRes: model.SubscribeResponse{
+				CheckoutID:  "cs_1",
+				CheckoutURL: "https://pay.example.com/cs_1",
+			},
+		},
+		{
+			name: "case success no trial after paying before",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetActiveSubscriptionFunc: func(ctx context.Context, userID int64, at time.Time) (*model.Subscription, error) {
+						return &model.Subscription{ID: 2, UserID: userID, Plan: model.PlanPromo, Status: model.SubscriptionStatusActive}, nil
+					},
+					HasPaidSubscriptionFunc: func(ctx context.Context, userID int64) (bool, error) {
+						return true, nil
+					},
+					GetPendingDiscountFunc: noDiscount,
+				},
+				payments: &payment.ProviderMock{
+					CreateCheckoutFunc: func(ctx context.Context, req payment.CheckoutRequest) (payment.Checkout, error) {
+						assert.Zero(t, req.TrialDays)
+						return payment.Checkout{ID: "cs_1", URL: "https://pay.example.com/cs_1"}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.SubscribeRequest{
+					UserID:    1,
+					Subscribe: true,
+				},
+			},
+			trialDays: 7,
+			wantRes: model.SubscribeResponse{
+				CheckoutID:  "cs_1",
+				CheckoutURL: "https://pay.example.com/cs_1",
+			},
+		},
+		{
+			name: "case success resume canceled",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetActiveSubscriptionFunc: active(true, "sub_1"),
+					UpdateSubscriptionFunc: func(ctx context.Context, req model.Subscription) error {
+						assert.True(t, req.AutoRenew)
+						assert.False(t, req.CancelAtPeriodEnd)
+						return nil
+					},
+				},
+				payments: &payment.ProviderMock{
+					SetCancelAtPeriodEndFunc: func(ctx context.Context, subscriptionID string, cancel bool) error {
+						assert.Equal(t, "sub_1", subscriptionID)
+						assert.False(t, cancel)
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.SubscribeRequest{
+					UserID:    1,
+					Subscribe: true,
+				},
+			},
+		},
+		{
+			name: "case success cancel at period end",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetActiveSubscriptionFunc: active(false, "sub_1"),
+					UpdateSubscriptionFunc: func(ctx context.Context, req model.Subscription) error {
+						assert.Equal(t, model.SubscriptionStatusActive, req.Status)
+						assert.Equal(t, periodEnd, req.CurrentPeriodEnd)
+						assert.False(t, req.AutoRenew)
+						assert.True(t, req.CancelAtPeriodEnd)
+						return nil
+					},
+				},
+				payments: &payment.ProviderMock{
+					SetCancelAtPeriodEndFunc: func(ctx context.Context, subscriptionID string, cancel bool) error {
+						assert.True(t, cancel)
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.SubscribeRequest{
+					UserID: 1,
+				},
+			},
+		},
+		{
+			name: "case success cancel granted subscription",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetActiveSubscriptionFunc: active(false, ""),
+					UpdateSubscriptionFunc: func(ctx context.Context, req model.Subscription) error {
+						assert.True(t, req.CancelAtPeriodEnd)
+						return nil
+					},
+				},
+				payments: &payment.ProviderMock{},
+			},
+			args: args{
+				req: model.SubscribeRequest{
+					UserID: 1,
+				},
+			},
+		},
+		{
+			name: "case error cancel at payment provider",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetActiveSubscriptionFunc: active(false, "sub_1"),
+				},
+				payments: &payment.ProviderMock{
+					SetCancelAtPeriodEndFunc: func(ctx context.Context, subscriptionID string, cancel bool) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				req: model.SubscribeRequest{
+					UserID: 1,
+				},
+			},
+			wantErr: errors.New("err"),
+		},
+		{
+			name: "case error cancel store subscription",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetActiveSubscriptionFunc: func(ctx context.Context, userID int64, at time.Time) (*model.Subscription, error) {
+						subscription, _ := active(false, "app_store:purchase_1")(ctx, userID, at)
+						subscription.Store = "app_store"
+						return subscription, nil
+					},
+				},
+				payments: &payment.ProviderMock{},
+			},
+			args: args{
+				req: model.SubscribeRequest{
+					UserID: 1,
+				},
+			},
+			wantErr: model.StoreManagedErr,
+		},
+		{
+			name: "case error invalid plan",
+			fields: fields{
+				repoDB:   &db.RepoMock{},
+				payments: &payment.ProviderMock{},
+			},
+			args: args{
+				req: model.SubscribeRequest{
+					UserID:    1,
+					Plan:      "weekly",
+					Subscribe: true,
+				},
+			},
+			wantErr: model.InvalidPlanErr,
+		},
+		{
+			name: "case error already subscribed",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetActiveSubscriptionFunc: active(false, ""),
+				},
+				payments: &payment.ProviderMock{},
+			},
+			args: args{
+				req: model.SubscribeRequest{
+					UserID:    1,
+					Subscribe: true,
+				},
+			},
+			wantErr: model.AlreadySubscribedErr,
+		},
+		{
+			name: "case error cancel without subscription",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetActiveSubscriptionFunc: none,
+				},
+				payments: &payment.ProviderMock{},
+			},
+			args: args{
+				req: model.SubscribeRequest{
+					UserID: 1,
+				},
+			},
+			wantErr: model.NotFoundErr,
+		},
+		{
+			name: "case erorr payment provider",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetActiveSubscriptionFunc: none,
+					GetPendingDiscountFunc:    noDiscount,
+				},
+				payments: &payment.ProviderMock{
+					CreateCheckoutFunc: func(ctx context.Context, req payment.CheckoutRequest) (payment.Checkout, error) {
+						return payment.Checkout{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				req: model.SubscribeRequest{
+					UserID:    1,
+					Subscribe: true,
+				},
+			},
+			wantErr: errors.New("err"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB:   tt.fields.repoDB,
+				Payments: tt.fields.payments,
+				Config:   Config{TrialDays: tt.trialDays},
+			}
+			gotRes, gotErr := u.UpdateSubscription(context.Background(), tt.args.req)
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Equal(t, tt.wantRes, gotRes)
+		})
+	}
+}
+
+func TestGetProfiles(t *testing.T) {
+	viewer := func(ctx context.Context, userID int64) (*model.User, error) {
+		return &model.User{UserID: userID, AccountStatus: model.AccountStatusActive}, nil
+	}
+
+	type fields struct {
+		repoDB    db.Repo
+		repoCache cache.Repo
+	}
+	type args struct {
+		req model.GetRelatedUserRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantRes []model.Profile
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: viewer,
+					GetRelatedUserFunc: func(ctx context.Context, id int64) ([]model.User, error) {
+						return []model.User{
+							{
+								UserID:     2,
+								FullName:   "Jane Doe",
+								Email:      "jane@doe.com",
+								SuperLiked: true,
+							},
+							{
+								UserID: 3,
+							},
+							{
+								UserID: 4,
+							},
+							{
+								UserID: 5,
+							},
+							{
+								UserID: 6,
+							},
+							{
+								UserID: 7,
+							},
+						}, nil
+					},
+					GetPhotosByUserIDsFunc: func(ctx context.Context, userIDs []int64) (map[int64][]model.Photo, error) {
+						assert.Equal(t, []int64{2, 3, 5, 6}, userIDs)
+						return map[int64][]model.Photo{
+							2: {{ID: 1, UserID: 2, Key: "photos/2/a.jpg", ThumbnailKey: "photos/2/a_thumb.jpg", IsPrimary: true}},
+						}, nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetRelatedUserCacheFunc: func(ctx context.Context, userID int64) (map[int64]int, error) {
+						return map[int64]int{
+							4: 0,
+							7: 1,
+						}, nil
+					},
+					GetBoostedUsersFunc: func(ctx context.Context, at time.Time) (map[int64]bool, error) {
+						return map[int64]bool{}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.GetRelatedUserRequest{
+					UserID: 1,
+				},
+			},
+			wantRes: []model.Profile{
+				{
+					UserID:     2,
+					Relation:   model.RelationStranger,
+					FullName:   "Jane Doe",
+					SuperLiked: true,
+					Photos: []model.Photo{{
+						ID:           1,
+						UserID:       2,
+						Key:          "photos/2/a.jpg",
+						ThumbnailKey: "photos/2/a_thumb.jpg",
+						URL:          "/media/photos/2/a.jpg",
+						ThumbnailURL: "/media/photos/2/a_thumb.jpg",
+						IsPrimary:    true,
+					}},
+				},
+				{
+					UserID:   3,
+					Relation: model.RelationStranger,
+				},
+				{
+					UserID:   5,
+					Relation: model.RelationStranger,
+				},
+				{
+					UserID:   6,
+					Relation: model.RelationStranger,
+				},
+			},
+		},
+		{
+			name: "case success boosted first",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: viewer,
+					GetRelatedUserFunc: func(ctx context.Context, id int64) ([]model.User, error) {
+						return []model.User{
+							{
+								UserID: 2,
+							},
+							{
+								UserID: 3,
+							},
+							{
+								UserID: 4,
+							},
+							{
+								UserID: 5,
+							},
+						}, nil
+					},
+					GetPhotosByUserIDsFunc: func(ctx context.Context, userIDs []int64) (map[int64][]model.Photo, error) {
+						return map[int64][]model.Photo{}, nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetRelatedUserCacheFunc: func(ctx context.Context, userID int64) (map[int64]int, error) {
+						return map[int64]int{
+							4: 0,
+						}, nil
+					},
+					GetBoostedUsersFunc: func(ctx context.Context, at time.Time) (map[int64]bool, error) {
+						return map[int64]bool{
+							4: true,
+							5: true,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.GetRelatedUserRequest{
+					UserID: 1,
+				},
+			},
+			wantRes: []model.Profile{
+				{
+					UserID:   5,
+					Relation: model.RelationStranger,
+				},
+				{
+					UserID:   2,
+					Relation: model.RelationStranger,
+				},
+				{
+					UserID:   3,
+					Relation: model.RelationStranger,
+				},
+			},
+		},
+		{
+			name: "case success boosts unavailable",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: viewer,
+					GetRelatedUserFunc: func(ctx context.Context, id int64) ([]model.User, error) {
+						return []model.User{
+							{
+								UserID: 2,
+							},
+							{
+								UserID: 3,
+							},
+						}, nil
+					},
+					GetPhotosByUserIDsFunc: func(ctx context.Context, userIDs []int64) (map[int64][]model.Photo, error) {
+						return map[int64][]model.Photo{}, nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetRelatedUserCacheFunc: func(ctx context.Context, userID int64) (map[int64]int, error) {
+						return map[int64]int{}, nil
+					},
+					GetBoostedUsersFunc: func(ctx context.Context, at time.Time) (map[int64]bool, error) {
+						return nil, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				req: model.GetRelatedUserRequest{
+					UserID: 1,
+				},
+			},
+			wantRes: []model.Profile{
+				{
+					UserID:   2,
+					Relation: model.RelationStranger,
+				},
+				{
+					UserID:   3,
+					Relation: model.RelationStranger,
+				},
+			},
+		},
+		{
+			name: "case success admin sees strangers",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{UserID: userID, Role: model.RoleAdmin, AccountStatus: model.AccountStatusActive}, nil
+					},
+					GetRelatedUserFunc: func(ctx context.Context, id int64) ([]model.User, error) {
+						return []model.User{
+							{
+								UserID:   2,
+								Username: "jdoe",
+								Email:    "jane@doe.com",
+							},
+						}, nil
+					},
+					GetPhotosByUserIDsFunc: func(ctx context.Context, userIDs []int64) (map[int64][]model.Photo, error) {
+						return map[int64][]model.Photo{}, nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetRelatedUserCacheFunc: func(ctx context.Context, userID int64) (map[int64]int, error) {
+						return map[int64]int{}, nil
+					},
+					GetBoostedUsersFunc: func(ctx context.Context, at time.Time) (map[int64]bool, error) {
+						return map[int64]bool{}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.GetRelatedUserRequest{
+					UserID: 1,
+				},
+			},
+			wantRes: []model.Profile{
+				{
+					UserID:   2,
+					Relation: model.RelationStranger,
+				},
+			},
+		},
+		{
+			name: "case error photos",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: viewer,
+					GetRelatedUserFunc: func(ctx context.Context, id int64) ([]model.User, error) {
+						return []model.User{
+							{
+								UserID: 2,
+							},
+						}, nil
+					},
+					GetPhotosByUserIDsFunc: func(ctx context.Context, userIDs []int64) (map[int64][]model.Photo, error) {
+						return nil, errors.New("err")
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetRelatedUserCacheFunc: func(ctx context.Context, userID int64) (map[int64]int, error) {
+						return map[int64]int{}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.GetRelatedUserRequest{
+					UserID: 1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error banned",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{UserID: userID, AccountStatus: model.AccountStatusBanned}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.GetRelatedUserRequest{
+					UserID: 1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error suspended",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						until := time.Now().Add(time.Hour)
+						return &model.User{UserID: userID, AccountStatus: model.AccountStatusSuspended, StatusUntil: &until}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.GetRelatedUserRequest{
+					UserID: 1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error db",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: viewer,
+					GetRelatedUserFunc: func(ctx context.Context, id int64) ([]model.User, error) {
+						return []model.User{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				req: model.GetRelatedUserRequest{
+					UserID: 1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error empty db",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: viewer,
+					GetRelatedUserFunc: func(ctx context.Context, id int64) ([]model.User, error) {
+						return []model.User{}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.GetRelatedUserRequest{
+					UserID: 1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error cache",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: viewer,
+					GetRelatedUserFunc: func(ctx context.Context, id int64) ([]model.User, error) {
+						return []model.User{
+							{
+								UserID: 2,
+							},
+							{
+								UserID: 3,
+							},
+							{
+								UserID: 4,
+							},
+							{
+								UserID: 5,
+							},
+							{
+								UserID: 6,
+							},
+							{
+								UserID: 7,
+							},
+						}, nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetRelatedUserCacheFunc: func(ctx context.Context, userID int64) (map[int64]int, error) {
+						return map[int64]int{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				req: model.GetRelatedUserRequest{
+					UserID: 1,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB:    tt.fields.repoDB,
+				RepoCache: tt.fields.repoCache,
+				Blobs: &storage.BlobStoreMock{
+					URLFunc: func(key string) string {
+						return "/media/" + key
+					},
+				},
+			}
+			gotRes, gotErr := u.GetProfiles(context.Background(), tt.args.req)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("GetProfiles() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantRes, gotRes)
+		})
+	}
+}
+
+func TestSwipe(t *testing.T) {
+	type fields struct {
+		repoDB    db.Repo
+		repoCache cache.Repo
+	}
+	type args struct {
+		req model.SwipeRequest
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantEvents []model.Event
+		wantErr    bool
+	}{
+		{
+			name: "case success not premium",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:    1,
+							IsPremium: false,
+						}, nil
+					},
+					IsBlockedFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return false, nil
+					},
+					UpsertSwipeFunc: func(ctx context.Context, req model.SwipeRequest) error {
+						return nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetRelatedUserCacheLenFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 6, nil
+					},
+					GetSuperLikeCountFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 0, nil
+					},
+					SetRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
+						return nil
+					},
+					ScheduleQuotaResetFunc: func(ctx context.Context, userID int64, at time.Time) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.SwipeRequest{
+					UserID:       1,
+					SwipedUserID: 2,
+					SwipeStatus:  -1,
+				},
+			},
+		},
+		{
+			name: "case success premium",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:    1,
+							IsPremium: true,
+							Tier:      model.TierPlus,
+						}, nil
+					},
+					IsBlockedFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return false, nil
+					},
+					UpsertSwipeFunc: func(ctx context.Context, req model.SwipeRequest) error {
+						return nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					SetRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.SwipeRequest{
+					UserID:       1,
+					SwipedUserID: 2,
+					SwipeStatus:  -1,
+				},
+			},
+		},
+		{
+			name: "case success like",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:    1,
+							IsPremium: true,
+							Tier:      model.TierPlus,
+						}, nil
+					},
+					IsBlockedFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return false, nil
+					},
+					UpsertSwipeFunc: func(ctx context.Context, req model.SwipeRequest) error {
+						return nil
+					},
+					IsMatchFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return false, nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					SetRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
+						return nil
+					},
+					PublishEventFunc: func(ctx context.Context, event model.Event) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.SwipeRequest{
+					UserID:       1,
+					SwipedUserID: 2,
+					SwipeStatus:  1,
+				},
+			},
+			wantEvents: []model.Event{
+				{UserID: 2, Type: model.EventTypeLiked},
+			},
+		},
+		{
+			name: "case success mutual like",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:    1,
+							IsPremium: true,
+							Tier:      model.TierPlus,
+						}, nil
+					},
+					IsBlockedFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return false, nil
+					},
+					UpsertSwipeFunc: func(ctx context.Context, req model.SwipeRequest) error {
+						return nil
+					},
+					IsMatchFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return true, nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					SetRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
+						return nil
+					},
+					PublishEventFunc: func(ctx context.Context, event model.Event) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.SwipeRequest{
+					UserID:       1,
+					SwipedUserID: 2,
+					SwipeStatus:  1,
+				},
+			},
+			wantEvents: []model.Event{
+				{UserID: 1, Type: model.EventTypeMatched},
+				{UserID: 2, Type: model.EventTypeMatched},
+			},
+		},
+		{
+			name: "case success super like",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:    1,
+							IsPremium: true,
+							Tier:      model.TierPlus,
+						}, nil
+					},
+					IsBlockedFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return false, nil
+					},
+					UpsertSwipeFunc: func(ctx context.Context, req model.SwipeRequest) error {
+						return nil
+					},
+					IsMatchFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return false, nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetSuperLikeCountFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 4, nil
+					},
+					IncrSuperLikeCountFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 5, nil
+					},
+					SetRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
+						return nil
+					},
+					PublishEventFunc: func(ctx context.Context, event model.Event) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.SwipeRequest{
+					UserID:       1,
+					SwipedUserID: 2,
+					SwipeStatus:  model.SwipeStatusSuperLike,
+				},
+			},
+			wantEvents: []model.Event{
+				{UserID: 2, Type: model.EventTypeSuperLiked},
+			},
+		},
+		{
+			name: "case success super like with regular quota used",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:    1,
+							IsPremium: false,
+						}, nil
+					},
+					IsBlockedFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return false, nil
+					},
+					UpsertSwipeFunc: func(ctx context.Context, req model.SwipeRequest) error {
+						return nil
+					},
+					IsMatchFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return true, nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetRelatedUserCacheLenFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 11, nil
+					},
+					GetSuperLikeCountFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 0, nil
+					},
+					IncrSuperLikeCountFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 1, nil
+					},
+					SetRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
+						return nil
+					},
+					ScheduleQuotaResetFunc: func(ctx context.Context, userID int64, at time.Time) error {
+						return nil
+					},
+					PublishEventFunc: func(ctx context.Context, event model.Event) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.SwipeRequest{
+					UserID:       1,
+					SwipedUserID: 2,
+					SwipeStatus:  model.SwipeStatusSuperLike,
+				},
+			},
+			wantEvents: []model.Event{
+				{UserID: 1, Type: model.EventTypeMatched},
+				{UserID: 2, Type: model.EventTypeMatched},
+			},
+		},
+		{
+			name: "case success super likes do not use regular quota",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:    1,
+							IsPremium: false,
+						}, nil
+					},
+					IsBlockedFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return false, nil
+					},
+					UpsertSwipeFunc: func(ctx context.Context, req model.SwipeRequest) error {
+						return nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetRelatedUserCacheLenFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 11, nil
+					},
+					GetSuperLikeCountFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 1, nil
+					},
+					SetRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
+						return nil
+					},
+					ScheduleQuotaResetFunc: func(ctx context.Context, userID int64, at time.Time) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.SwipeRequest{
+					UserID:       1,
+					SwipedUserID: 2,
+					SwipeStatus:  -1,
+				},
+			},
+		},
+		{
+			name: "case error super like limit",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:    1,
+							IsPremium: false,
+						}, nil
+					},
+					IsBlockedFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return false, nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetSuperLikeCountFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 1, nil
+					},
+				},
+			},
+			args: args{
+				req: model.SwipeRequest{
+					UserID:       1,
+					SwipedUserID: 2,
+					SwipeStatus:  model.SwipeStatusSuperLike,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case success like shadowbanned",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:        1,
+							IsPremium:     true,
+							Tier:          model.TierPlus,
+							AccountStatus: model.AccountStatusShadowbanned,
+						}, nil
+					},
+					IsBlockedFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return false, nil
+					},
+					UpsertSwipeFunc: func(ctx context.Context, req model.SwipeRequest) error {
+						return nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					SetRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.SwipeRequest{
+					UserID:       1,
+					SwipedUserID: 2,
+					SwipeStatus:  1,
+				},
+			},
+		},
+		{
+			name: "case error db",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{}, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				req: model.SwipeRequest{
+					UserID:       1,
+					SwipedUserID: 2,
+					SwipeStatus:  -1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error banned",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:        1,
+							AccountStatus: model.AccountStatusBanned,
+						}, nil
+					},
+					IsBlockedFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return false, nil
+					},
+				},
+				repoCache: &cache.RepoMock{},
+			},
+			args: args{
+				req: model.SwipeRequest{
+					UserID:       1,
+					SwipedUserID: 2,
+					SwipeStatus:  1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error blocked",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:    1,
+							IsPremium: true,
+							Tier:      model.TierPlus,
+						}, nil
+					},
+					IsBlockedFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return true, nil
+					},
+				},
+			},
+			args: args{
+				req: model.SwipeRequest{
+					UserID:       1,
+					SwipedUserID: 2,
+					SwipeStatus:  1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error cache len",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:    1,
+							IsPremium: false,
+						}, nil
+					},
+					IsBlockedFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return false, nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetRelatedUserCacheLenFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 6, errors.New("err")
+					},
+				},
+			},
+			args: args{
+				req: model.SwipeRequest{
+					UserID:       1,
+					SwipedUserID: 2,
+					SwipeStatus:  -1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error db upsert swipe",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:    1,
+							IsPremium: true,
+							Tier:      model.TierPlus,
+						}, nil
+					},
+					IsBlockedFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return false, nil
+					},
+					UpsertSwipeFunc: func(ctx context.Context, req model.SwipeRequest) error {
+						return errors.New("err")
+					},
+				},
+				repoCache: &cache.RepoMock{
+					SetRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.SwipeRequest{
+					UserID:       1,
+					SwipedUserID: 2,
+					SwipeStatus:  1,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error cache set",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:    1,
+							IsPremium: false,
+						}, nil
+					},
+					IsBlockedFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return false, nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetRelatedUserCacheLenFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 6, nil
+					},
+					GetSuperLikeCountFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 0, nil
+					},
+					SetRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				req: model.SwipeRequest{
+					UserID:       1,
+					SwipedUserID: 2,
+					SwipeStatus:  -1,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB:    tt.fields.repoDB,
+				RepoCache: tt.fields.repoCache,
+			}
+			gotErr := u.Swipe(context.Background(), tt.args.req)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("Swipe() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+
+			var gotEvents []model.Event
+			if repoCache, ok := tt.fields.repoCache.(*cache.RepoMock); ok {
+				for _, call := range repoCache.PublishEventCalls() {
+					gotEvents = append(gotEvents, model.Event{UserID: call.Event.UserID, Type: call.Event.Type})
+				}
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	type fields struct {
+		repoDB    db.Repo
+		repoCache cache.Repo
+	}
+	type args struct {
+		token string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantRes int64
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				repoCache: &cache.RepoMock{
+					GetSessionFunc: func(ctx context.Context, token string) (int64, error) {
+						return 1, nil
+					},
+				},
+			},
+			args: args{
+				token: "token",
+			},
+			wantRes: 1,
+		},
+		{
+			name: "case error empty token",
+			args: args{
+				token: "",
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error cache",
+			fields: fields{
+				repoCache: &cache.RepoMock{
+					GetSessionFunc: func(ctx context.Context, token string) (int64, error) {
+						return 0, model.UnauthorizedErr
+					},
+				},
+			},
+			args: args{
+				token: "token",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB:    tt.fields.repoDB,
+				RepoCache: tt.fields.repoCache,
+			}
+			gotRes, gotErr := u.Authenticate(context.Background(), tt.args.token)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("Authenticate() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantRes, gotRes)
+		})
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	password := "testpass"
+	hashedPassword, _ := util.HashPassword(password)
+
+	type fields struct {
+		repoDB    db.Repo
+		repoCache cache.Repo
+	}
+	type args struct {
+		req model.ChangePasswordRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:   1,
+							Password: hashedPassword,
+						}, nil
+					},
+					UpdatePasswordFunc: func(ctx context.Context, userID int64, hashedPassword string) error {
+						return nil
+					},
+					InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.ChangePasswordRequest{
+					UserID:          1,
+					CurrentPassword: password,
+					NewPassword:     "n3w-passphrase",
+				},
+			},
+		},
+		{
+			name: "case error wrong password",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:   1,
+							Password: hashedPassword,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.ChangePasswordRequest{
+					UserID:          1,
+					CurrentPassword: "wrongpass",
+					NewPassword:     "n3w-passphrase",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error weak password",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:   1,
+							Password: hashedPassword,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.ChangePasswordRequest{
+					UserID:          1,
+					CurrentPassword: password,
+					NewPassword:     "qwerty123",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error db",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:   1,
+							Password: hashedPassword,
+						}, nil
+					},
+					UpdatePasswordFunc: func(ctx context.Context, userID int64, hashedPassword string) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				req: model.ChangePasswordRequest{
+					UserID:          1,
+					CurrentPassword: password,
+					NewPassword:     "n3w-passphrase",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB:    tt.fields.repoDB,
+				RepoCache: tt.fields.repoCache,
+			}
+			gotErr := u.ChangePassword(context.Background(), tt.args.req)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("ChangePassword() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestChangeEmail(t *testing.T) {
+	password := "testpass"
+	hashedPassword, _ := util.HashPassword(password)
+
+	type fields struct {
+		repoDB    db.Repo
+		repoCache cache.Repo
+		mailer    mail.Mailer
+	}
+	type args struct {
+		req model.ChangeEmailRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:   1,
+							Password: hashedPassword,
+							Email:    "old@mail.com",
+						}, nil
+					},
+					GetUserByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+						return nil, model.NotFoundErr
+					},
+					UpdateEmailFunc: func(ctx context.Context, userID int64, email string) error {
+						return nil
+					},
+					InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+						return nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					SetEmailVerificationFunc: func(ctx context.Context, token string, data model.EmailVerification) error {
+						return nil
+					},
+				},
+				mailer: &mail.MailerMock{
+					SendFunc: func(ctx context.Context, to, subject, body string) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.ChangeEmailRequest{
+					UserID:          1,
+					CurrentPassword: password,
+					NewEmail:        "new@mail.com",
+				},
+			},
+		},
+		{
+			name: "case error wrong password",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:   1,
+							Password: hashedPassword,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.ChangeEmailRequest{
+					UserID:          1,
+					CurrentPassword: "wrongpass",
+					NewEmail:        "new@mail.com",
+				},
+			},
+			wantErr: model.UnauthorizedErr,
+		},
+		{
+			name: "case error invalid email",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:   1,
+							Password: hashedPassword,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.ChangeEmailRequest{
+					UserID:          1,
+					CurrentPassword: password,
+					NewEmail:        "Jane <new@mail.com>",
+				},
+			},
+			wantErr: model.InvalidEmailErr,
+		},
+		{
+			name: "case error email taken",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:   1,
+							Password: hashedPassword,
+						}, nil
+					},
+					GetUserByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+						return &model.User{UserID: 2, Email: email}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.ChangeEmailRequest{
+					UserID:          1,
+					CurrentPassword: password,
+					NewEmail:        "new@mail.com",
+				},
+			},
+			wantErr: model.EmailTakenErr,
+		},
+		{
+			name: "case error email taken meanwhile",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:   1,
+							Password: hashedPassword,
+						}, nil
+					},
+					GetUserByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+						return nil, model.NotFoundErr
+					},
+					UpdateEmailFunc: func(ctx context.Context, userID int64, email string) error {
+						return model.EmailTakenErr
+					},
+				},
+			},
+			args: args{
+				req: model.ChangeEmailRequest{
+					UserID:          1,
+					CurrentPassword: password,
+					NewEmail:        "new@mail.com",
+				},
+			},
+			wantErr: model.EmailTakenErr,
+		},
+		{
+			name: "case error db",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:   1,
+							Password: hashedPassword,
+						}, nil
+					},
+					GetUserByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+						return nil, model.NotFoundErr
+					},
+					UpdateEmailFunc: func(ctx context.Context, userID int64, email string) error {
+						return errors.New("err")
+					},
+				},
+			},
+			args: args{
+				req: model.ChangeEmailRequest{
+					UserID:          1,
+					CurrentPassword: password,
+					NewEmail:        "new@mail.com",
+				},
+			},
+			wantErr: errors.New("err"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB:    tt.fields.repoDB,
+				RepoCache: tt.fields.repoCache,
+				Mailer:    tt.fields.mailer,
+			}
+			gotErr := u.ChangeEmail(context.Background(), tt.args.req)
+			assert.Equal(t, tt.wantErr, gotErr)
+		})
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	type fields struct {
+		repoDB    db.Repo
+		repoCache cache.Repo
+	}
+	type args struct {
+		req model.VerifyEmailRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					UpdateEmailVerifiedFunc: func(ctx context.Context, userID int64, email string) error {
+						return nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetEmailVerificationFunc: func(ctx context.Context, token string) (model.EmailVerification, error) {
+						return model.EmailVerification{
+							UserID: 1,
+							Email:  "test@mail.com",
+						}, nil
+					},
+					DeleteEmailVerificationFunc: func(ctx context.Context, token string) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				req: model.VerifyEmailRequest{
+					Token: "token",
+				},
+			},
+		},
+		{
+			name: "case error invalid token",
+			fields: fields{
+				repoCache: &cache.RepoMock{
+					GetEmailVerificationFunc: func(ctx context.Context, token string) (model.EmailVerification, error) {
+						return model.EmailVerification{}, model.InvalidTokenErr
+					},
+				},
+			},
+			args: args{
+				req: model.VerifyEmailRequest{
+					Token: "token",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error db",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					UpdateEmailVerifiedFunc: func(ctx context.Context, userID int64, email string) error {
+						return errors.New("err")
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetEmailVerificationFunc: func(ctx context.Context, token string) (model.EmailVerification, error) {
+						return model.EmailVerification{
+							UserID: 1,
+							Email:  "test@mail.com",
+						}, nil
+					},
+				},
+			},
+			args: args{
+				req: model.VerifyEmailRequest{
+					Token: "token",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB:    tt.fields.repoDB,
+				RepoCache: tt.fields.repoCache,
+			}
+			gotErr := u.VerifyEmail(context.Background(), tt.args.req)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("VerifyEmail() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestEnrollTwoFactor(t *testing.T) {
+	type fields struct {
+		repoDB    db.Repo
+		repoCache cache.Repo
+	}
+	type args struct {
+		userID int64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:   1,
+							Username: "test",
+						}, nil
+					},
+					UpsertTOTPSecretFunc: func(ctx context.Context, userID int64, secret string) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				userID: 1,
+			},
+		},
+		{
+			name: "case error already enabled",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:           1,
+							Username:         "test",
+							TwoFactorEnabled: true,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				userID: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error db",
 			fields: fields{
 				repoDB: &db.RepoMock{
-					UpdatePremiumStatusFunc: func(ctx context.Context, req model.SubscribeRequest) error {
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{
+							UserID:   1,
+							Username: "test",
+						}, nil
+					},
+					UpsertTOTPSecretFunc: func(ctx context.Context, userID int64, secret string) error {
 						return errors.New("err")
 					},
 				},
 			},
 			args: args{
-				req: model.SubscribeRequest{
-					UserID:    1,
-					Subscribe: true,
-				},
+				userID: 1,
 			},
 			wantErr: true,
 		},
@@ -202,154 +2640,121 @@ func TestUpdateSubscription(t *testing.T) {
 				RepoDB:    tt.fields.repoDB,
 				RepoCache: tt.fields.repoCache,
 			}
-			gotErr := u.UpdateSubscription(context.Background(), tt.args.req)
+			gotRes, gotErr := u.EnrollTwoFactor(context.Background(), tt.args.userID)
 			if (gotErr != nil) != tt.wantErr {
-				t.Errorf("UpdateSubscription() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				t.Errorf("EnrollTwoFactor() error = %v, wantErr = %v", gotErr, tt.wantErr)
 				return
 			}
+			if !tt.wantErr {
+				assert.Contains(t, gotRes.URI, gotRes.Secret)
+			}
 		})
 	}
 }
 
-func TestGetProfiles(t *testing.T) {
+func TestVerifyTwoFactor(t *testing.T) {
+	secret, _ := totp.GenerateSecret()
+	code, _ := totp.GenerateCode(secret, time.Now())
+
 	type fields struct {
 		repoDB    db.Repo
 		repoCache cache.Repo
 	}
 	type args struct {
-		req model.GetRelatedUserRequest
+		req model.TwoFactorVerifyRequest
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantRes []model.User
-		wantErr bool
+		name      string
+		fields    fields
+		args      args
+		wantCodes int
+		wantErr   bool
 	}{
 		{
 			name: "case success",
 			fields: fields{
 				repoDB: &db.RepoMock{
-					GetRelatedUserFunc: func(ctx context.Context, id int64) ([]model.User, error) {
-						return []model.User{
-							{
-								UserID: 2,
-							},
-							{
-								UserID: 3,
-							},
-							{
-								UserID: 4,
-							},
-							{
-								UserID: 5,
-							},
-							{
-								UserID: 6,
-							},
-							{
-								UserID: 7,
-							},
+					GetTOTPFunc: func(ctx context.Context, userID int64) (*model.TOTP, error) {
+						return &model.TOTP{
+							UserID: 1,
+							Secret: secret,
 						}, nil
 					},
-				},
-				repoCache: &cache.RepoMock{
-					GetRelatedUserCacheFunc: func(ctx context.Context, userID int64) (map[int64]int, error) {
-						return map[int64]int{
-							4: 0,
-							7: 1,
-						}, nil
+					UseTOTPStepFunc: func(ctx context.Context, userID int64, step int64) error {
+						return nil
+					},
+					EnableTOTPFunc: func(ctx context.Context, userID int64, recoveryCodeHashes []string) error {
+						return nil
+					},
+					InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+						return nil
 					},
 				},
 			},
 			args: args{
-				req: model.GetRelatedUserRequest{
+				req: model.TwoFactorVerifyRequest{
 					UserID: 1,
+					Code:   code,
 				},
 			},
-			wantRes: []model.User{
-				{
-					UserID: 2,
-				},
-				{
-					UserID: 3,
-				},
-				{
-					UserID: 5,
-				},
-				{
-					UserID: 6,
-				},
-			},
+			wantCodes: recoveryCodeCount,
 		},
 		{
-			name: "case error db",
+			name: "case error wrong code",
 			fields: fields{
 				repoDB: &db.RepoMock{
-					GetRelatedUserFunc: func(ctx context.Context, id int64) ([]model.User, error) {
-						return []model.User{}, errors.New("err")
+					GetTOTPFunc: func(ctx context.Context, userID int64) (*model.TOTP, error) {
+						return &model.TOTP{
+							UserID: 1,
+							Secret: secret,
+						}, nil
 					},
 				},
 			},
 			args: args{
-				req: model.GetRelatedUserRequest{
+				req: model.TwoFactorVerifyRequest{
 					UserID: 1,
+					Code:   "abcdef",
 				},
 			},
 			wantErr: true,
 		},
 		{
-			name: "case error empty db",
+			name: "case error code used before",
 			fields: fields{
 				repoDB: &db.RepoMock{
-					GetRelatedUserFunc: func(ctx context.Context, id int64) ([]model.User, error) {
-						return []model.User{}, nil
+					GetTOTPFunc: func(ctx context.Context, userID int64) (*model.TOTP, error) {
+						return &model.TOTP{
+							UserID: 1,
+							Secret: secret,
+						}, nil
+					},
+					UseTOTPStepFunc: func(ctx context.Context, userID int64, step int64) error {
+						return model.UnauthorizedErr
 					},
 				},
 			},
 			args: args{
-				req: model.GetRelatedUserRequest{
+				req: model.TwoFactorVerifyRequest{
 					UserID: 1,
+					Code:   code,
 				},
 			},
 			wantErr: true,
 		},
 		{
-			name: "case error cache",
+			name: "case error not enrolled",
 			fields: fields{
 				repoDB: &db.RepoMock{
-					GetRelatedUserFunc: func(ctx context.Context, id int64) ([]model.User, error) {
-						return []model.User{
-							{
-								UserID: 2,
-							},
-							{
-								UserID: 3,
-							},
-							{
-								UserID: 4,
-							},
-							{
-								UserID: 5,
-							},
-							{
-								UserID: 6,
-							},
-							{
-								UserID: 7,
-							},
-						}, nil
-					},
-				},
-				repoCache: &cache.RepoMock{
-					GetRelatedUserCacheFunc: func(ctx context.Context, userID int64) (map[int64]int, error) {
-						return map[int64]int{}, errors.New("err")
+					GetTOTPFunc: func(ctx context.Context, userID int64) (*model.TOTP, error) {
+						return nil, model.TwoFactorNotEnrolledErr
 					},
 				},
 			},
 			args: args{
-				req: model.GetRelatedUserRequest{
+				req: model.TwoFactorVerifyRequest{
 					UserID: 1,
+					Code:   code,
 				},
 			},
 			wantErr: true,
@@ -361,155 +2766,248 @@ func TestGetProfiles(t *testing.T) {
 				RepoDB:    tt.fields.repoDB,
 				RepoCache: tt.fields.repoCache,
 			}
-			gotRes, gotErr := u.GetProfiles(context.Background(), tt.args.req)
+			gotRes, gotErr := u.VerifyTwoFactor(context.Background(), tt.args.req)
 			if (gotErr != nil) != tt.wantErr {
-				t.Errorf("GetProfiles() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				t.Errorf("VerifyTwoFactor() error = %v, wantErr = %v", gotErr, tt.wantErr)
 				return
 			}
-			assert.Equal(t, tt.wantRes, gotRes)
+			assert.Len(t, gotRes.RecoveryCodes, tt.wantCodes)
 		})
 	}
 }
 
-func TestSwipe(t *testing.T) {
+func TestOAuthLogin(t *testing.T) {
 	type fields struct {
-		repoDB    db.Repo
 		repoCache cache.Repo
+		providers map[string]oidc.Provider
 	}
 	type args struct {
-		req model.SwipeRequest
+		req model.OAuthLoginRequest
 	}
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
+		wantURL string
 		wantErr bool
 	}{
 		{
-			name: "case success not premium",
+			name: "case success",
 			fields: fields{
-				repoDB: &db.RepoMock{
-					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
-						return &model.User{
-							UserID:    1,
-							IsPremium: false,
-						}, nil
-					},
-				},
 				repoCache: &cache.RepoMock{
-					GetRelatedUserCacheLenFunc: func(ctx context.Context, userID int64) (int64, error) {
-						return 6, nil
-					},
-					SetRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
+					SetOAuthStateFunc: func(ctx context.Context, state string, data model.OAuthState) error {
 						return nil
 					},
 				},
+				providers: map[string]oidc.Provider{
+					"google": &oidc.ProviderMock{
+						AuthCodeURLFunc: func(state, nonce, codeVerifier string) string {
+							return "https://accounts.example.com/authorize"
+						},
+					},
+				},
 			},
 			args: args{
-				req: model.SwipeRequest{
-					UserID:       1,
-					SwipedUserID: 2,
-					SwipeStatus:  -1,
+				req: model.OAuthLoginRequest{
+					Provider: "google",
 				},
 			},
+			wantURL: "https://accounts.example.com/authorize",
 		},
 		{
-			name: "case success premium",
+			name: "case error unknown provider",
 			fields: fields{
-				repoDB: &db.RepoMock{
-					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
-						return &model.User{
-							UserID:    1,
-							IsPremium: true,
-						}, nil
-					},
-				},
-				repoCache: &cache.RepoMock{
-					SetRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
-						return nil
-					},
-				},
+				providers: map[string]oidc.Provider{},
 			},
 			args: args{
-				req: model.SwipeRequest{
-					UserID:       1,
-					SwipedUserID: 2,
-					SwipeStatus:  -1,
+				req: model.OAuthLoginRequest{
+					Provider: "google",
 				},
 			},
+			wantErr: true,
 		},
 		{
-			name: "case error db",
+			name: "case error set state",
 			fields: fields{
-				repoDB: &db.RepoMock{
-					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
-						return &model.User{}, errors.New("err")
+				repoCache: &cache.RepoMock{
+					SetOAuthStateFunc: func(ctx context.Context, state string, data model.OAuthState) error {
+						return errors.New("err")
 					},
 				},
+				providers: map[string]oidc.Provider{
+					"google": &oidc.ProviderMock{},
+				},
 			},
 			args: args{
-				req: model.SwipeRequest{
-					UserID:       1,
-					SwipedUserID: 2,
-					SwipeStatus:  -1,
+				req: model.OAuthLoginRequest{
+					Provider: "google",
 				},
 			},
 			wantErr: true,
 		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoCache: tt.fields.repoCache,
+				Providers: tt.fields.providers,
+			}
+			gotRes, gotErr := u.OAuthLogin(context.Background(), tt.args.req)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("OAuthLogin() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantURL, gotRes.AuthorizationURL)
+		})
+	}
+}
+
+func TestOAuthCallback(t *testing.T) {
+	provider := func(claims oidc.Claims, err error) map[string]oidc.Provider {
+		return map[string]oidc.Provider{
+			"google": &oidc.ProviderMock{
+				ExchangeFunc: func(ctx context.Context, code, codeVerifier, nonce string) (oidc.Claims, error) {
+					return claims, err
+				},
+			},
+		}
+	}
+	validState := &cache.RepoMock{
+		PopOAuthStateFunc: func(ctx context.Context, state string) (model.OAuthState, error) {
+			return model.OAuthState{Provider: "google"}, nil
+		},
+		SetSessionFunc: func(ctx context.Context, token string, userID int64) error {
+			return nil
+		},
+	}
+	verifiedClaims := oidc.Claims{
+		Subject:       "sub-1",
+		Email:         "test@mail.com",
+		EmailVerified: true,
+		Name:          "Test User",
+	}
+
+	type fields struct {
+		repoDB    db.Repo
+		repoCache cache.Repo
+		providers map[string]oidc.Provider
+	}
+	type args struct {
+		req model.OAuthCallbackRequest
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		args      args
+		wantToken bool
+		wantErr   error
+	}{
 		{
-			name: "case error cache len",
+			name: "case success existing identity",
 			fields: fields{
 				repoDB: &db.RepoMock{
-					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
-						return &model.User{
-							UserID:    1,
-							IsPremium: false,
-						}, nil
+					GetIdentityFunc: func(ctx context.Context, provider, subject string) (*model.Identity, error) {
+						return &model.Identity{UserID: 1}, nil
 					},
-				},
-				repoCache: &cache.RepoMock{
-					GetRelatedUserCacheLenFunc: func(ctx context.Context, userID int64) (int64, error) {
-						return 6, errors.New("err")
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{UserID: userID}, nil
 					},
 				},
+				repoCache: validState,
+				providers: provider(verifiedClaims, nil),
 			},
-			args: args{
-				req: model.SwipeRequest{
-					UserID:       1,
-					SwipedUserID: 2,
-					SwipeStatus:  -1,
+			wantToken: true,
+		},
+		{
+			name: "case success link verified email",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetIdentityFunc: func(ctx context.Context, provider, subject string) (*model.Identity, error) {
+						return nil, model.NotFoundErr
+					},
+					GetUserByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+						return &model.User{UserID: 1, EmailVerified: true}, nil
+					},
+					InsertIdentityFunc: func(ctx context.Context, req model.Identity) error {
+						return nil
+					},
+					InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+						return nil
+					},
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{UserID: userID}, nil
+					},
 				},
+				repoCache: validState,
+				providers: provider(verifiedClaims, nil),
 			},
-			wantErr: true,
+			wantToken: true,
 		},
 		{
-			name: "case error cache set",
+			name: "case success create user",
 			fields: fields{
 				repoDB: &db.RepoMock{
+					GetIdentityFunc: func(ctx context.Context, provider, subject string) (*model.Identity, error) {
+						return nil, model.NotFoundErr
+					},
+					GetUserByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+						return nil, model.NotFoundErr
+					},
+					CreateUserFunc: func(ctx context.Context, req model.User) (int64, error) {
+						return 2, nil
+					},
+					UpdateEmailVerifiedFunc: func(ctx context.Context, userID int64, email string) error {
+						return nil
+					},
+					InsertIdentityFunc: func(ctx context.Context, req model.Identity) error {
+						return nil
+					},
+					InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+						return nil
+					},
 					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
-						return &model.User{
-							UserID:    1,
-							IsPremium: false,
-						}, nil
+						return &model.User{UserID: userID}, nil
 					},
 				},
-				repoCache: &cache.RepoMock{
-					GetRelatedUserCacheLenFunc: func(ctx context.Context, userID int64) (int64, error) {
-						return 6, nil
+				repoCache: validState,
+				providers: provider(verifiedClaims, nil),
+			},
+			wantToken: true,
+		},
+		{
+			name: "case error unverified email already used",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetIdentityFunc: func(ctx context.Context, provider, subject string) (*model.Identity, error) {
+						return nil, model.NotFoundErr
 					},
-					SetRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
-						return errors.New("err")
+					GetUserByEmailFunc: func(ctx context.Context, email string) (*model.User, error) {
+						return &model.User{UserID: 1, EmailVerified: true}, nil
 					},
 				},
+				repoCache: validState,
+				providers: provider(oidc.Claims{Subject: "sub-1", Email: "test@mail.com"}, nil),
 			},
-			args: args{
-				req: model.SwipeRequest{
-					UserID:       1,
-					SwipedUserID: 2,
-					SwipeStatus:  -1,
+			wantErr: model.EmailTakenErr,
+		},
+		{
+			name: "case error invalid state",
+			fields: fields{
+				repoCache: &cache.RepoMock{
+					PopOAuthStateFunc: func(ctx context.Context, state string) (model.OAuthState, error) {
+						return model.OAuthState{}, model.InvalidTokenErr
+					},
 				},
 			},
-			wantErr: true,
+			wantErr: model.InvalidTokenErr,
+		},
+		{
+			name: "case error exchange",
+			fields: fields{
+				repoCache: validState,
+				providers: provider(oidc.Claims{}, errors.New("err")),
+			},
+			wantErr: model.UnauthorizedErr,
 		},
 	}
 	for _, tt := range tests {
@@ -517,12 +3015,11 @@ func TestSwipe(t *testing.T) {
 			u := &usecase{
 				RepoDB:    tt.fields.repoDB,
 				RepoCache: tt.fields.repoCache,
+				Providers: tt.fields.providers,
 			}
-			gotErr := u.Swipe(context.Background(), tt.args.req)
-			if (gotErr != nil) != tt.wantErr {
-				t.Errorf("Swipe() error = %v, wantErr = %v", gotErr, tt.wantErr)
-				return
-			}
+			gotRes, gotErr := u.OAuthCallback(context.Background(), tt.args.req)
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Equal(t, tt.wantToken, gotRes.Token != "")
 		})
 	}
 }
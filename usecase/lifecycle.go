@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+)
+
+// The jobs below run periodically on a single instance. Each run handles at
+// most a batch of subscriptions, the rest are left to the next run
+
+// ExpireSubscriptions ends the subscriptions whose period ran out without
+// being renewed: ones granted by staff or as a reward, and ones canceled at
+// the end of the period
+func (s *usecase) ExpireSubscriptions(ctx context.Context, now time.Time) (err error) {
+	subscriptions, err := s.RepoDB.GetLapsedSubscriptions(ctx, now, subscriptionJobBatch)
+	if err != nil {
+		log.Println("error when fetching lapsed subscriptions from db")
+		return
+	}
+
+	for i := range subscriptions {
+		subscription := &subscriptions[i]
+		subscription.Status = model.SubscriptionStatusCanceled
+		subscription.AutoRenew = false
+		if errUpdate := s.RepoDB.UpdateSubscription(ctx, *subscription); errUpdate != nil {
+			log.Println("error when updating subscription in db")
+			err = errUpdate
+			continue
+		}
+
+		s.publishPremiumLost(ctx, subscription.UserID, now)
+	}
+
+	return
+}
+
+// RenewSubscriptions charges the subscriptions of the payment provider whose
+// period ended for the next one. A failed charge makes the subscription past
+// due, starting its grace period
+func (s *usecase) RenewSubscriptions(ctx context.Context, now time.Time) (err error) {
+	subscriptions, err := s.RepoDB.GetDueRenewals(ctx, now, subscriptionJobBatch)
+	if err != nil {
+		log.Println("error when fetching due renewals from db")
+		return
+	}
+
+	for i := range subscriptions {
+		subscription := &subscriptions[i]
+		remote, errRenew := s.Payments.RenewSubscription(ctx, subscription.ProviderSubscriptionID)
+		if errRenew != nil {
+			log.Println("error when renewing subscription at payment provider")
+			err = errRenew
+			continue
+		}
+
+		if errApply := s.applySubscription(ctx, subscription, remote); errApply != nil {
+			err = errApply
+		}
+	}
+
+	return
+}
+
+// SendDunningNotices reminds the users of past due subscriptions to update
+// their payment details before the grace period ends, once per
+// dunningInterval
+func (s *usecase) SendDunningNotices(ctx context.Context, now time.Time) (err error) {
+	subscriptions, err := s.RepoDB.GetDunningSubscriptions(ctx, now, now.Add(-dunningInterval), subscriptionJobBatch)
+	if err != nil {
+		log.Println("error when fetching dunning subscriptions from db")
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		user, errUser := s.RepoDB.GetUserByID(ctx, subscription.UserID)
+		if errUser != nil {
+			log.Println("error when fetching user from db")
+			err = errUser
+			continue
+		}
+
+		if user.Email != "" {
+			errSend := s.Mailer.Send(ctx, user.Email, "Your payment failed",
+				fmt.Sprintf("We could not charge you for your %s subscription. Update your payment details before %s to keep premium.",
+					subscription.Tier, subscription.GraceUntil.UTC().Format("January 2, 2006 15:04 MST")))
+			if errSend != nil {
+				log.Println("error when sending email")
+				err = errSend
+				continue
+			}
+		}
+
+		s.publishEvent(ctx, subscription.UserID, model.EventTypePaymentFailed, model.PaymentFailedEvent{
+			GraceUntil: *subscription.GraceUntil,
+		})
+
+		if errUpdate := s.RepoDB.UpdateDunningNotified(ctx, subscription.ID, now); errUpdate != nil {
+			log.Println("error when updating dunning notified in db")
+			err = errUpdate
+		}
+	}
+
+	return
+}
+
+// EndGracePeriods cancels the past due subscriptions that were not paid for
+// by the end of their grace period, which takes premium away
+func (s *usecase) EndGracePeriods(ctx context.Context, now time.Time) (err error) {
+	subscriptions, err := s.RepoDB.GetGraceEndedSubscriptions(ctx, now, subscriptionJobBatch)
+	if err != nil {
+		log.Println("error when fetching grace ended subscriptions from db")
+		return
+	}
+
+	for i := range subscriptions {
+		subscription := &subscriptions[i]
+
+		// Stop the provider from retrying the payment
+		if subscription.ProviderSubscriptionID != "" && subscription.Store == "" {
+			errCancel := s.Payments.SetCancelAtPeriodEnd(ctx, subscription.ProviderSubscriptionID, true)
+			if errCancel != nil {
+				log.Println("error when canceling subscription at payment provider")
+				err = errCancel
+				continue
+			}
+		}
+
+		subscription.Status = model.SubscriptionStatusCanceled
+		subscription.AutoRenew = false
+		subscription.CancelAtPeriodEnd = true
+		subscription.GraceUntil = nil
+		if errUpdate := s.RepoDB.UpdateSubscription(ctx, *subscription); errUpdate != nil {
+			log.Println("error when updating subscription in db")
+			err = errUpdate
+			continue
+		}
+
+		s.publishPremiumLost(ctx, subscription.UserID, now)
+	}
+
+	return
+}
+
+// publishPremiumLost tells the user premium ended, unless another
+// subscription keeps it
+func (s *usecase) publishPremiumLost(ctx context.Context, userID int64, now time.Time) {
+	_, err := s.RepoDB.GetActiveSubscription(ctx, userID, now)
+	if err == nil {
+		return
+	} else if err != model.NotFoundErr {
+		log.Println("error when fetching active subscription from db")
+		return
+	}
+
+	s.publishEvent(ctx, userID, model.EventTypePremiumChanged, model.PremiumChangedEvent{
+		IsPremium: false,
+	})
+}
@@ -0,0 +1,359 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/mail"
+	"github.com/egnptr/dating-app/pkg/payment"
+	"github.com/egnptr/dating-app/repository/cache"
+	"github.com/egnptr/dating-app/repository/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpireSubscriptions(t *testing.T) {
+	now := time.Now()
+	lapsed := func(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error) {
+		return []model.Subscription{
+			{ID: 1, UserID: 1, Status: model.SubscriptionStatusActive, CurrentPeriodEnd: now.Add(-time.Minute)},
+		}, nil
+	}
+
+	tests := []struct {
+		name       string
+		repoDB     *db.RepoMock
+		wantEvents int
+		wantErr    bool
+	}{
+		{
+			name: "case success",
+			repoDB: &db.RepoMock{
+				GetLapsedSubscriptionsFunc: lapsed,
+				UpdateSubscriptionFunc: func(ctx context.Context, req model.Subscription) error {
+					assert.Equal(t, model.SubscriptionStatusCanceled, req.Status)
+					assert.False(t, req.AutoRenew)
+					return nil
+				},
+				GetActiveSubscriptionFunc: func(ctx context.Context, userID int64, at time.Time) (*model.Subscription, error) {
+					return nil, model.NotFoundErr
+				},
+			},
+			wantEvents: 1,
+		},
+		{
+			name: "case success another subscription keeps premium",
+			repoDB: &db.RepoMock{
+				GetLapsedSubscriptionsFunc: lapsed,
+				UpdateSubscriptionFunc: func(ctx context.Context, req model.Subscription) error {
+					return nil
+				},
+				GetActiveSubscriptionFunc: func(ctx context.Context, userID int64, at time.Time) (*model.Subscription, error) {
+					return &model.Subscription{ID: 2, UserID: userID}, nil
+				},
+			},
+		},
+		{
+			name: "case error update",
+			repoDB: &db.RepoMock{
+				GetLapsedSubscriptionsFunc: lapsed,
+				UpdateSubscriptionFunc: func(ctx context.Context, req model.Subscription) error {
+					return errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "case error db",
+			repoDB: &db.RepoMock{
+				GetLapsedSubscriptionsFunc: func(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error) {
+					return nil, errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoCache := &cache.RepoMock{
+				PublishEventFunc: func(ctx context.Context, event model.Event) error {
+					return nil
+				},
+			}
+			u := &usecase{
+				RepoDB:    tt.repoDB,
+				RepoCache: repoCache,
+			}
+			gotErr := u.ExpireSubscriptions(context.Background(), now)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("ExpireSubscriptions() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Len(t, repoCache.PublishEventCalls(), tt.wantEvents)
+		})
+	}
+}
+
+func TestRenewSubscriptions(t *testing.T) {
+	var (
+		now       = time.Now()
+		periodEnd = now.Add(-time.Minute)
+	)
+	due := func(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error) {
+		return []model.Subscription{{
+			ID:                     1,
+			UserID:                 1,
+			Tier:                   model.TierPlus,
+			Plan:                   model.PlanMonthly,
+			Status:                 model.SubscriptionStatusActive,
+			CurrentPeriodEnd:       periodEnd,
+			ProviderSubscriptionID: "sub_1",
+		}}, nil
+	}
+	renew := func(status string, end time.Time) func(ctx context.Context, subscriptionID string) (payment.Subscription, error) {
+		return func(ctx context.Context, subscriptionID string) (payment.Subscription, error) {
+			assert.Equal(t, "sub_1", subscriptionID)
+			return payment.Subscription{
+				ID:               subscriptionID,
+				Tier:             model.TierPlus,
+				Plan:             model.PlanMonthly,
+				Status:           status,
+				CurrentPeriodEnd: end,
+			}, nil
+		}
+	}
+
+	tests := []struct {
+		name     string
+		payments *payment.ProviderMock
+		check    func(t *testing.T, req model.Subscription)
+		wantErr  bool
+	}{
+		{
+			name:     "case success renewed",
+			payments: &payment.ProviderMock{RenewSubscriptionFunc: renew(payment.StatusActive, periodEnd.AddDate(0, 1, 0))},
+			check: func(t *testing.T, req model.Subscription) {
+				assert.Equal(t, model.SubscriptionStatusActive, req.Status)
+				assert.Equal(t, periodEnd.AddDate(0, 1, 0), req.CurrentPeriodEnd)
+				assert.Nil(t, req.GraceUntil)
+			},
+		},
+		{
+			name:     "case success payment failed",
+			payments: &payment.ProviderMock{RenewSubscriptionFunc: renew(payment.StatusPastDue, periodEnd)},
+			check: func(t *testing.T, req model.Subscription) {
+				assert.Equal(t, model.SubscriptionStatusPastDue, req.Status)
+				assert.Equal(t, periodEnd.Add(72*time.Hour), *req.GraceUntil)
+			},
+		},
+		{
+			name: "case error payment provider",
+			payments: &payment.ProviderMock{
+				RenewSubscriptionFunc: func(ctx context.Context, subscriptionID string) (payment.Subscription, error) {
+					return payment.Subscription{}, errors.New("err")
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoDB := &db.RepoMock{
+				GetDueRenewalsFunc: due,
+				UpdateSubscriptionFunc: func(ctx context.Context, req model.Subscription) error {
+					tt.check(t, req)
+					return nil
+				},
+			}
+			u := &usecase{
+				RepoDB: repoDB,
+				RepoCache: &cache.RepoMock{
+					PublishEventFunc: func(ctx context.Context, event model.Event) error {
+						return nil
+					},
+				},
+				Payments: tt.payments,
+				Config:   Config{GracePeriod: 72 * time.Hour},
+			}
+			gotErr := u.RenewSubscriptions(context.Background(), now)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("RenewSubscriptions() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, !tt.wantErr, len(repoDB.UpdateSubscriptionCalls()) == 1)
+		})
+	}
+}
+
+func TestSendDunningNotices(t *testing.T) {
+	var (
+		now        = time.Now()
+		graceUntil = now.Add(48 * time.Hour)
+	)
+
+	tests := []struct {
+		name         string
+		email        string
+		mailer       *mail.MailerMock
+		wantMails    int
+		wantNotified bool
+		wantErr      bool
+	}{
+		{
+			name:  "case success",
+			email: "user@example.com",
+			mailer: &mail.MailerMock{
+				SendFunc: func(ctx context.Context, to, subject, body string) error {
+					assert.Equal(t, "user@example.com", to)
+					return nil
+				},
+			},
+			wantMails:    1,
+			wantNotified: true,
+		},
+		{
+			name:         "case success no email",
+			mailer:       &mail.MailerMock{},
+			wantNotified: true,
+		},
+		{
+			name:  "case error mail",
+			email: "user@example.com",
+			mailer: &mail.MailerMock{
+				SendFunc: func(ctx context.Context, to, subject, body string) error {
+					return errors.New("err")
+				},
+			},
+			wantMails: 1,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoDB := &db.RepoMock{
+				GetDunningSubscriptionsFunc: func(ctx context.Context, at, notifiedBefore time.Time, limit int) ([]model.Subscription, error) {
+					assert.Equal(t, now.Add(-dunningInterval), notifiedBefore)
+					return []model.Subscription{
+						{ID: 1, UserID: 1, Tier: model.TierGold, Status: model.SubscriptionStatusPastDue, GraceUntil: &graceUntil},
+					}, nil
+				},
+				GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+					return &model.User{UserID: userID, Email: tt.email}, nil
+				},
+				UpdateDunningNotifiedFunc: func(ctx context.Context, subscriptionID int64, at time.Time) error {
+					assert.Equal(t, int64(1), subscriptionID)
+					return nil
+				},
+			}
+			repoCache := &cache.RepoMock{
+				PublishEventFunc: func(ctx context.Context, event model.Event) error {
+					assert.Equal(t, model.EventTypePaymentFailed, event.Type)
+					return nil
+				},
+			}
+			u := &usecase{
+				RepoDB:    repoDB,
+				RepoCache: repoCache,
+				Mailer:    tt.mailer,
+			}
+			gotErr := u.SendDunningNotices(context.Background(), now)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("SendDunningNotices() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Len(t, tt.mailer.SendCalls(), tt.wantMails)
+			assert.Equal(t, tt.wantNotified, len(repoDB.UpdateDunningNotifiedCalls()) == 1)
+			assert.Equal(t, tt.wantNotified, len(repoCache.PublishEventCalls()) == 1)
+		})
+	}
+}
+
+func TestEndGracePeriods(t *testing.T) {
+	var (
+		now        = time.Now()
+		graceUntil = now.Add(-time.Minute)
+	)
+	ended := func(providerSubscriptionID, store string) func(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error) {
+		return func(ctx context.Context, at time.Time, limit int) ([]model.Subscription, error) {
+			return []model.Subscription{{
+				ID:                     1,
+				UserID:                 1,
+				Status:                 model.SubscriptionStatusPastDue,
+				GraceUntil:             &graceUntil,
+				ProviderSubscriptionID: providerSubscriptionID,
+				Store:                  store,
+			}}, nil
+		}
+	}
+	canceled := func(ctx context.Context, subscriptionID string, cancel bool) error {
+		assert.Equal(t, "sub_1", subscriptionID)
+		assert.True(t, cancel)
+		return nil
+	}
+
+	tests := []struct {
+		name        string
+		repoDB      *db.RepoMock
+		payments    *payment.ProviderMock
+		wantCancels int
+		wantEvents  int
+		wantErr     bool
+	}{
+		{
+			name:        "case success",
+			repoDB:      &db.RepoMock{GetGraceEndedSubscriptionsFunc: ended("sub_1", "")},
+			payments:    &payment.ProviderMock{SetCancelAtPeriodEndFunc: canceled},
+			wantCancels: 1,
+			wantEvents:  1,
+		},
+		{
+			name:       "case success store purchase",
+			repoDB:     &db.RepoMock{GetGraceEndedSubscriptionsFunc: ended("play:purchase_1", "play")},
+			payments:   &payment.ProviderMock{},
+			wantEvents: 1,
+		},
+		{
+			name:   "case error payment provider",
+			repoDB: &db.RepoMock{GetGraceEndedSubscriptionsFunc: ended("sub_1", "")},
+			payments: &payment.ProviderMock{
+				SetCancelAtPeriodEndFunc: func(ctx context.Context, subscriptionID string, cancel bool) error {
+					return errors.New("err")
+				},
+			},
+			wantCancels: 1,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.repoDB.UpdateSubscriptionFunc = func(ctx context.Context, req model.Subscription) error {
+				assert.Equal(t, model.SubscriptionStatusCanceled, req.Status)
+				assert.Nil(t, req.GraceUntil)
+				return nil
+			}
+			tt.repoDB.GetActiveSubscriptionFunc = func(ctx context.Context, userID int64, at time.Time) (*model.Subscription, error) {
+				return nil, model.NotFoundErr
+			}
+			repoCache := &cache.RepoMock{
+				PublishEventFunc: func(ctx context.Context, event model.Event) error {
+					assert.Equal(t, model.EventTypePremiumChanged, event.Type)
+					return nil
+				},
+			}
+			u := &usecase{
+				RepoDB:    tt.repoDB,
+				RepoCache: repoCache,
+				Payments:  tt.payments,
+			}
+			gotErr := u.EndGracePeriods(context.Background(), now)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("EndGracePeriods() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Len(t, tt.payments.SetCancelAtPeriodEndCalls(), tt.wantCancels)
+			assert.Len(t, repoCache.PublishEventCalls(), tt.wantEvents)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/entitlement"
+)
+
+// GetLikers lists the users who liked the user and are still waiting for a
+// swipe back
+func (s *usecase) GetLikers(ctx context.Context, userID int64) (res model.LikersResponse, err error) {
+	user, err := s.RepoDB.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	res.Count, err = s.RepoDB.CountLikers(ctx, userID)
+	if err != nil {
+		log.Println("error when counting likers from db")
+		return
+	}
+
+	// Users without the feature only get a teaser of how many people liked
+	// them
+	if !s.entitlements(user).Can(entitlement.FeatureSeeWhoLiked) {
+		return
+	}
+
+	res.Likers, err = s.RepoDB.GetLikers(ctx, userID, likersLimit)
+	if err != nil {
+		log.Println("error when fetching likers from db")
+		return
+	}
+
+	return
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/repository/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLikers(t *testing.T) {
+	likers := []model.Liker{{UserID: 2, FullName: "Jane Doe", SuperLiked: true}}
+
+	type fields struct {
+		repoDB db.Repo
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    model.LikersResponse
+		wantErr bool
+	}{
+		{
+			name: "case success premium",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{UserID: userID, IsPremium: true, Tier: model.TierPlus}, nil
+					},
+					CountLikersFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 1, nil
+					},
+					GetLikersFunc: func(ctx context.Context, userID int64, limit int) ([]model.Liker, error) {
+						return likers, nil
+					},
+				},
+			},
+			want: model.LikersResponse{Count: 1, Likers: likers},
+		},
+		{
+			name: "case success free",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{UserID: userID}, nil
+					},
+					CountLikersFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 1, nil
+					},
+				},
+			},
+			want: model.LikersResponse{Count: 1},
+		},
+		{
+			name: "case error db",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{UserID: userID, IsPremium: true, Tier: model.TierPlus}, nil
+					},
+					CountLikersFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 0, errors.New("err")
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.fields.repoDB,
+			}
+			got, gotErr := u.GetLikers(context.Background(), 1)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("GetLikers() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
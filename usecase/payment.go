@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/payment"
+)
+
+// subscriptionStatuses maps the statuses of the payment provider to ours
+var subscriptionStatuses = map[string]string{
+	payment.StatusActive:   model.SubscriptionStatusActive,
+	payment.StatusPastDue:  model.SubscriptionStatusPastDue,
+	payment.StatusCanceled: model.SubscriptionStatusCanceled,
+}
+
+// HandlePaymentWebhook brings the subscription an event is about up to date.
+// Providers deliver events at least once and in no particular order, so each
+// event is handled once and the state is taken from the provider rather than
+// from the event
+func (s *usecase) HandlePaymentWebhook(ctx context.Context, req model.PaymentWebhookRequest) (err error) {
+	event, err := s.Payments.VerifyWebhook(ctx, req.Payload, req.Header)
+	if errors.Is(err, payment.ErrInvalidSignature) {
+		err = model.InvalidSignatureErr
+		return
+	} else if err != nil {
+		log.Println("error when verifying payment webhook")
+		return
+	}
+
+	switch event.Type {
+	case payment.EventPaymentSucceeded, payment.EventPaymentFailed, payment.EventPaymentRefunded, payment.EventSubscriptionCanceled:
+	default:
+		return
+	}
+	if event.SubscriptionID == "" {
+		return
+	}
+
+	inserted, err := s.RepoDB.InsertPaymentEvent(ctx, model.PaymentEvent{
+		ID:             event.ID,
+		Type:           event.Type,
+		SubscriptionID: event.SubscriptionID,
+	})
+	if err != nil {
+		log.Println("error when inserting payment event to db")
+		return
+	} else if !inserted {
+		return
+	}
+
+	// Forget the event when handling it failed so the redelivery is handled
+	defer func() {
+		if err == nil {
+			return
+		}
+		if deleteErr := s.RepoDB.DeletePaymentEvent(ctx, event.ID); deleteErr != nil {
+			log.Println("error when deleting payment event from db")
+		}
+	}()
+
+	if event.Type == payment.EventPaymentRefunded {
+		return s.refundSubscription(ctx, event.SubscriptionID)
+	}
+	return s.syncSubscription(ctx, event.SubscriptionID)
+}
+
+// syncSubscription copies the state of the subscription at the payment
+// provider, starting it on its first payment
+func (s *usecase) syncSubscription(ctx context.Context, providerSubscriptionID string) (err error) {
+	remote, err := s.Payments.FetchSubscription(ctx, providerSubscriptionID)
+	if err != nil {
+		log.Println("error when fetching subscription from payment provider")
+		return
+	}
+
+	subscription, err := s.RepoDB.GetSubscriptionByProviderID(ctx, providerSubscriptionID)
+	if err == model.NotFoundErr {
+		// Nothing to start before the first payment went through
+		if remote.Status != payment.StatusActive {
+			err = nil
+			return
+		}
+		if _, ok := model.PlanPeriods[remote.Plan]; !ok || !model.PaidTiers[remote.Tier] || remote.UserID == 0 {
+			log.Println("error subscription at payment provider has no user, tier or plan")
+			err = nil
+			return
+		}
+
+		subscription = &model.Subscription{
+			UserID:                 remote.UserID,
+			Plan:                   remote.Plan,
+			ProviderSubscriptionID: remote.ID,
+		}
+	} else if err != nil {
+		log.Println("error when fetching subscription from db")
+		return
+	}
+
+	return s.applySubscription(ctx, subscription, remote)
+}
+
+// applySubscription copies the state of the subscription at the payment
+// provider to ours, inserting it when it is new. A subscription turning past
+// due keeps premium for the grace period from the end of its period
+func (s *usecase) applySubscription(ctx context.Context, subscription *model.Subscription, remote payment.Subscription) (err error) {
+	now := time.Now()
+	wasActive := subscription.IsActive(now)
+	previous := *subscription
+
+	// The tier can be changed at the provider
+	if model.PaidTiers[remote.Tier] {
+		subscription.Tier = remote.Tier
+	}
+	subscription.Status = subscriptionStatuses[remote.Status]
+	subscription.CurrentPeriodStart = remote.CurrentPeriodStart
+	subscription.CurrentPeriodEnd = remote.CurrentPeriodEnd
+	subscription.AutoRenew = !remote.CancelAtPeriodEnd
+	subscription.CancelAtPeriodEnd = remote.CancelAtPeriodEnd
+
+	if subscription.Status != model.SubscriptionStatusPastDue {
+		subscription.GraceUntil = nil
+	} else if subscription.GraceUntil == nil {
+		graceUntil := subscription.CurrentPeriodEnd.Add(s.Config.GracePeriod)
+		subscription.GraceUntil = &graceUntil
+	}
+
+	if subscription.ID == 0 {
+		subscription.ID, err = s.RepoDB.InsertSubscription(ctx, *subscription)
+		if err != nil {
+			log.Println("error when inserting subscription to db")
+			return
+		}
+
+		// Discounts redeemed before went to this subscription's checkout
+		if errApply := s.RepoDB.ApplyPendingDiscounts(ctx, subscription.UserID, now); errApply != nil {
+			log.Println("error when applying pending discounts in db")
+		}
+	} else {
+		err = s.RepoDB.UpdateSubscription(ctx, *subscription)
+		if err != nil {
+			log.Println("error when updating subscription in db")
+			return
+		}
+	}
+
+	// A period was paid for when it starts active, or when paying for it
+	// went through after failing
+	if subscription.Status == model.SubscriptionStatusActive && (previous.ID == 0 ||
+		!subscription.CurrentPeriodStart.Equal(previous.CurrentPeriodStart) || previous.Status == model.SubscriptionStatusPastDue) {
+		s.recordSubscriptionCharge(ctx, *subscription, remote.AmountPaid, remote.Currency)
+	}
+	if previous.ID != 0 && subscription.Tier != previous.Tier {
+		s.recordPlanChange(ctx, *subscription, previous.Tier, now)
+	}
+
+	if isActive := subscription.IsActive(now); isActive != wasActive {
+		s.publishEvent(ctx, subscription.UserID, model.EventTypePremiumChanged, model.PremiumChangedEvent{
+			IsPremium: isActive,
+		})
+	}
+
+	return
+}
+
+// refundSubscription takes premium away right away, the money for the period
+// was given back
+func (s *usecase) refundSubscription(ctx context.Context, providerSubscriptionID string) (err error) {
+	subscription, err := s.RepoDB.GetSubscriptionByProviderID(ctx, providerSubscriptionID)
+	if err == model.NotFoundErr {
+		err = nil
+		return
+	} else if err != nil {
+		log.Println("error when fetching subscription from db")
+		return
+	}
+
+	err = s.stopSubscription(ctx, subscription, time.Now())
+	if err != nil {
+		return
+	}
+
+	s.recordSubscriptionRefund(ctx, *subscription)
+	return
+}
@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/payment"
+	"github.com/egnptr/dating-app/repository/cache"
+	"github.com/egnptr/dating-app/repository/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlePaymentWebhook(t *testing.T) {
+	var (
+		now       = time.Now()
+		periodEnd = now.AddDate(0, 1, 0)
+	)
+
+	verify := func(eventType string) func(ctx context.Context, payload []byte, header http.Header) (payment.Event, error) {
+		return func(ctx context.Context, payload []byte, header http.Header) (payment.Event, error) {
+			return payment.Event{ID: "evt_1", Type: eventType, SubscriptionID: "sub_1"}, nil
+		}
+	}
+	remote := func(status string) func(ctx context.Context, subscriptionID string) (payment.Subscription, error) {
+		return func(ctx context.Context, subscriptionID string) (payment.Subscription, error) {
+			return payment.Subscription{
+				ID:                 subscriptionID,
+				UserID:             1,
+				Tier:               model.TierGold,
+				Plan:               model.PlanMonthly,
+				Status:             status,
+				CurrentPeriodStart: now,
+				CurrentPeriodEnd:   periodEnd,
+				AmountPaid:         1999,
+				Currency:           "usd",
+			}, nil
+		}
+	}
+	local := func(status string) func(ctx context.Context, providerSubscriptionID string) (*model.Subscription, error) {
+		return func(ctx context.Context, providerSubscriptionID string) (*model.Subscription, error) {
+			return &model.Subscription{
+				ID:                     1,
+				UserID:                 1,
+				Tier:                   model.TierGold,
+				Plan:                   model.PlanMonthly,
+				Status:                 status,
+				CurrentPeriodStart:     now.AddDate(0, -1, 0),
+				CurrentPeriodEnd:       now.Add(time.Hour),
+				ProviderSubscriptionID: providerSubscriptionID,
+			}, nil
+		}
+	}
+	notFound := func(ctx context.Context, providerSubscriptionID string) (*model.Subscription, error) {
+		return nil, model.NotFoundErr
+	}
+	inserted := func(ctx context.Context, req model.PaymentEvent) (bool, error) {
+		assert.Equal(t, "evt_1", req.ID)
+		return true, nil
+	}
+
+	type fields struct {
+		repoDB   *db.RepoMock
+		payments *payment.ProviderMock
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		wantEvents    int
+		wantLedger    []string
+		wantForgotten bool
+		wantErr       error
+	}{
+		{
+			name: "case success first payment",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					InsertPaymentEventFunc:          inserted,
+					GetSubscriptionByProviderIDFunc: notFound,
+					InsertSubscriptionFunc: func(ctx context.Context, req model.Subscription) (int64, error) {
+						assert.Equal(t, int64(1), req.UserID)
+						assert.Equal(t, model.SubscriptionStatusActive, req.Status)
+						assert.Equal(t, model.TierGold, req.Tier)
+						assert.Equal(t, periodEnd, req.CurrentPeriodEnd)
+						assert.Equal(t, "sub_1", req.ProviderSubscriptionID)
+						assert.True(t, req.AutoRenew)
+						return 1, nil
+					},
+					ApplyPendingDiscountsFunc: func(ctx context.Context, userID int64, at time.Time) error {
+						assert.Equal(t, int64(1), userID)
+						return nil
+					},
+				},
+				payments: &payment.ProviderMock{
+					VerifyWebhookFunc:     verify(payment.EventPaymentSucceeded),
+					FetchSubscriptionFunc: remote(payment.StatusActive),
+				},
+			},
+			wantEvents: 1,
+			wantLedger: []string{model.LedgerKindCharge},
+		},
+		{
+			name: "case success renewal",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					InsertPaymentEventFunc:          inserted,
+					GetSubscriptionByProviderIDFunc: local(model.SubscriptionStatusActive),
+					UpdateSubscriptionFunc: func(ctx context.Context, req model.Subscription) error {
+						assert.Equal(t, int64(1), req.ID)
+						assert.Equal(t, now, req.CurrentPeriodStart)
+						assert.Equal(t, periodEnd, req.CurrentPeriodEnd)
+						return nil
+					},
+				},
+				payments: &payment.ProviderMock{
+					VerifyWebhookFunc:     verify(payment.EventPaymentSucceeded),
+					FetchSubscriptionFunc: remote(payment.StatusActive),
+				},
+			},
+			wantLedger: []string{model.LedgerKindCharge},
+		},
+		{
+			name: "case success payment failed keeps premium for the grace period",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					InsertPaymentEventFunc:          inserted,
+					GetSubscriptionByProviderIDFunc: local(model.SubscriptionStatusActive),
+					UpdateSubscriptionFunc: func(ctx context.Context, req model.Subscription) error {
+						assert.Equal(t, model.SubscriptionStatusPastDue, req.Status)
+						assert.Equal(t, periodEnd.Add(72*time.Hour), *req.GraceUntil)
+						return nil
+					},
+				},
+				payments: &payment.ProviderMock{
+					VerifyWebhookFunc:     verify(payment.EventPaymentFailed),
+					FetchSubscriptionFunc: remote(payment.StatusPastDue),
+				},
+			},
+		},
+		{
+			name: "case success payment failed before first payment",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					InsertPaymentEventFunc:          inserted,
+					GetSubscriptionByProviderIDFunc: notFound,
+				},
+				payments: &payment.ProviderMock{
+					VerifyWebhookFunc:     verify(payment.EventPaymentFailed),
+					FetchSubscriptionFunc: remote(payment.StatusPastDue),
+				},
+			},
+		},
+		{
+			name: "case success refunded",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					InsertPaymentEventFunc:          inserted,
+					GetSubscriptionByProviderIDFunc: local(model.SubscriptionStatusActive),
+					UpdateSubscriptionFunc: func(ctx context.Context, req model.Subscription) error {
+						assert.Equal(t, model.SubscriptionStatusCanceled, req.Status)
+						assert.False(t, req.CurrentPeriodEnd.After(time.Now()))
+						return nil
+					},
+				},
+				payments: &payment.ProviderMock{
+					VerifyWebhookFunc: verify(payment.EventPaymentRefunded),
+				},
+			},
+			wantEvents: 1,
+			wantLedger: []string{model.LedgerKindRefund},
+		},
+		{
+			name: "case success canceled",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					InsertPaymentEventFunc:          inserted,
+					GetSubscriptionByProviderIDFunc: local(model.SubscriptionStatusActive),
+					UpdateSubscriptionFunc: func(ctx context.Context, req model.Subscription) error {
+						assert.Equal(t, model.SubscriptionStatusCanceled, req.Status)
+						return nil
+					},
+				},
+				payments: &payment.ProviderMock{
+					VerifyWebhookFunc:     verify(payment.EventSubscriptionCanceled),
+					FetchSubscriptionFunc: remote(payment.StatusCanceled),
+				},
+			},
+			wantEvents: 1,
+		},
+		{
+			name: "case success redelivered event",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					InsertPaymentEventFunc: func(ctx context.Context, req model.PaymentEvent) (bool, error) {
+						return false, nil
+					},
+				},
+				payments: &payment.ProviderMock{
+					VerifyWebhookFunc: verify(payment.EventPaymentSucceeded),
+				},
+			},
+		},
+		{
+			name: "case success ignored event",
+			fields: fields{
+				repoDB: &db.RepoMock{},
+				payments: &payment.ProviderMock{
+					VerifyWebhookFunc: verify("customer.created"),
+				},
+			},
+		},
+		{
+			name: "case error invalid signature",
+			fields: fields{
+				repoDB: &db.RepoMock{},
+				payments: &payment.ProviderMock{
+					VerifyWebhookFunc: func(ctx context.Context, payload []byte, header http.Header) (payment.Event, error) {
+						return payment.Event{}, payment.ErrInvalidSignature
+					},
+				},
+			},
+			wantErr: model.InvalidSignatureErr,
+		},
+		{
+			name: "case error fetching subscription forgets event",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					InsertPaymentEventFunc: inserted,
+					DeletePaymentEventFunc: func(ctx context.Context, eventID string) error {
+						assert.Equal(t, "evt_1", eventID)
+						return nil
+					},
+				},
+				payments: &payment.ProviderMock{
+					VerifyWebhookFunc: verify(payment.EventPaymentSucceeded),
+					FetchSubscriptionFunc: func(ctx context.Context, subscriptionID string) (payment.Subscription, error) {
+						return payment.Subscription{}, errors.New("err")
+					},
+				},
+			},
+			wantForgotten: true,
+			wantErr:       errors.New("err"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ledger []string
+			tt.fields.repoDB.InsertLedgerEntryFunc = func(ctx context.Context, entry model.LedgerEntry, invoice *model.Invoice) (bool, error) {
+				ledger = append(ledger, entry.Kind)
+				return true, nil
+			}
+			tt.fields.repoDB.GetLastLedgerEntryFunc = func(ctx context.Context, subscriptionID int64, kind string) (*model.LedgerEntry, error) {
+				return &model.LedgerEntry{ID: 1, SubscriptionID: subscriptionID, Kind: kind, Amount: 1999, Currency: "usd"}, nil
+			}
+			repoCache := &cache.RepoMock{
+				PublishEventFunc: func(ctx context.Context, event model.Event) error {
+					return nil
+				},
+			}
+			u := &usecase{
+				RepoDB:    tt.fields.repoDB,
+				RepoCache: repoCache,
+				Payments:  tt.fields.payments,
+				Config:    Config{GracePeriod: 72 * time.Hour},
+			}
+			gotErr := u.HandlePaymentWebhook(context.Background(), model.PaymentWebhookRequest{})
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Len(t, repoCache.PublishEventCalls(), tt.wantEvents)
+			assert.Equal(t, tt.wantLedger, ledger)
+			assert.Equal(t, tt.wantForgotten, len(tt.fields.repoDB.DeletePaymentEventCalls()) > 0)
+		})
+	}
+}
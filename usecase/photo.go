@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/photo"
+	"github.com/egnptr/dating-app/pkg/util"
+)
+
+// UploadPhoto adds a photo to the profile of the user. The upload is decoded
+// and stored again as a photo and a thumbnail, keeping nothing else of the
+// file the user sent
+func (s *usecase) UploadPhoto(ctx context.Context, req model.UploadPhotoRequest) (res model.Photo, err error) {
+	if len(req.Body) > model.PhotoMaxBytes {
+		err = model.PhotoTooLargeErr
+		return
+	}
+
+	// Checked again when the photo is added, this spares processing it
+	photos, err := s.RepoDB.GetPhotos(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when fetching photos from db")
+		return
+	}
+	if len(photos) >= photoMaxCount {
+		err = model.PhotoLimitErr
+		return
+	}
+
+	processed, err := photo.Process(req.Body)
+	if err == photo.ErrTooLarge {
+		err = model.PhotoTooLargeErr
+		return
+	} else if err == photo.ErrUnsupportedFormat || err == photo.ErrInvalid {
+		err = model.InvalidPhotoErr
+		return
+	} else if err != nil {
+		log.Println("error when processing photo")
+		return
+	}
+
+	name, err := util.GenerateToken(photoKeyBytes)
+	if err != nil {
+		log.Println("error when generating photo key")
+		return
+	}
+	res = model.Photo{
+		UserID:       req.UserID,
+		Key:          fmt.Sprintf("photos/%d/%s.jpg", req.UserID, name),
+		ThumbnailKey: fmt.Sprintf("photos/%d/%s_thumb.jpg", req.UserID, name),
+		Width:        processed.Width,
+		Height:       processed.Height,
+	}
+
+	if err = s.Blobs.Put(ctx, res.Key, "image/jpeg", processed.Full); err != nil {
+		log.Println("error when storing photo")
+		return
+	}
+	if err = s.Blobs.Put(ctx, res.ThumbnailKey, "image/jpeg", processed.Thumbnail); err != nil {
+		log.Println("error when storing photo thumbnail")
+		s.deletePhotoBlobs(ctx, res)
+		return
+	}
+
+	photoID, err := s.RepoDB.InsertPhoto(ctx, res, photoMaxCount)
+	if err != nil {
+		if err != model.PhotoLimitErr {
+			log.Println("error when inserting photo to db")
+		}
+		s.deletePhotoBlobs(ctx, res)
+		return
+	}
+
+	inserted, err := s.RepoDB.GetPhoto(ctx, photoID)
+	if err != nil {
+		log.Println("error when fetching photo from db")
+		return
+	}
+	return s.photoURLs(*inserted), nil
+}
+
+// GetPhotos lists the photos of the user, the primary one first
+func (s *usecase) GetPhotos(ctx context.Context, userID int64) (res []model.Photo, err error) {
+	photos, err := s.RepoDB.GetPhotos(ctx, userID)
+	if err != nil {
+		log.Println("error when fetching photos from db")
+		return
+	}
+
+	res = make([]model.Photo, 0, len(photos))
+	for _, photo := range photos {
+		res = append(res, s.photoURLs(photo))
+	}
+	return
+}
+
+// ReorderPhotos puts the photos of the user in the given order, which has to
+// list each of them once
+func (s *usecase) ReorderPhotos(ctx context.Context, req model.ReorderPhotosRequest) (res []model.Photo, err error) {
+	photos, err := s.RepoDB.GetPhotos(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when fetching photos from db")
+		return
+	}
+
+	listed := make(map[int64]bool)
+	for _, photo := range photos {
+		listed[photo.ID] = false
+	}
+	for _, photoID := range req.PhotoIDs {
+		if seen, ok := listed[photoID]; !ok || seen {
+			err = model.InvalidPhotoOrderErr
+			return
+		}
+		listed[photoID] = true
+	}
+	if len(req.PhotoIDs) != len(photos) {
+		err = model.InvalidPhotoOrderErr
+		return
+	}
+
+	err = s.RepoDB.UpdatePhotoOrder(ctx, req.UserID, req.PhotoIDs)
+	if err != nil {
+		log.Println("error when updating photo order in db")
+		return
+	}
+
+	return s.GetPhotos(ctx, req.UserID)
+}
+
+// SetPrimaryPhoto makes the photo the one standing for the user in lists
+func (s *usecase) SetPrimaryPhoto(ctx context.Context, req model.PhotoRequest) (err error) {
+	err = s.RepoDB.UpdatePrimaryPhoto(ctx, req.UserID, req.PhotoID)
+	if err != nil && err != model.NotFoundErr {
+		log.Println("error when updating primary photo in db")
+	}
+	return
+}
+
+// DeletePhoto removes a photo of the user along with its files. Photos of
+// other users are not found
+func (s *usecase) DeletePhoto(ctx context.Context, req model.PhotoRequest) (err error) {
+	photo, err := s.RepoDB.GetPhoto(ctx, req.PhotoID)
+	if err == nil && photo.UserID != req.UserID {
+		err = model.NotFoundErr
+		return
+	} else if err != nil {
+		if err != model.NotFoundErr {
+			log.Println("error when fetching photo from db")
+		}
+		return
+	}
+
+	err = s.RepoDB.DeletePhoto(ctx, req.UserID, req.PhotoID)
+	if err != nil {
+		if err != model.NotFoundErr {
+			log.Println("error when deleting photo from db")
+		}
+		return
+	}
+
+	// The photo is gone from the profile either way, files left behind are
+	// only never shown
+	s.deletePhotoBlobs(ctx, *photo)
+	return
+}
+
+// attachPhotos sets the photos of each of the users
+func (s *usecase) attachPhotos(ctx context.Context, users []model.User) (err error) {
+	if len(users) == 0 {
+		return
+	}
+
+	userIDs := make([]int64, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.UserID)
+	}
+
+	photos, err := s.RepoDB.GetPhotosByUserIDs(ctx, userIDs)
+	if err != nil {
+		log.Println("error when fetching photos from db")
+		return
+	}
+
+	for i := range users {
+		for _, photo := range photos[users[i].UserID] {
+			users[i].Photos = append(users[i].Photos, s.photoURLs(photo))
+		}
+	}
+	return
+}
+
+// photoURLs sets where clients download the photo and its thumbnail from
+func (s *usecase) photoURLs(photo model.Photo) model.Photo {
+	photo.URL = s.Blobs.URL(photo.Key)
+	photo.ThumbnailURL = s.Blobs.URL(photo.ThumbnailKey)
+	return photo
+}
+
+func (s *usecase) deletePhotoBlobs(ctx context.Context, photo model.Photo) {
+	for _, key := range []string{photo.Key, photo.ThumbnailKey} {
+		if err := s.Blobs.Delete(ctx, key); err != nil {
+			log.Println("error when deleting photo file")
+		}
+	}
+}
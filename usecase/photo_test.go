@@ -0,0 +1,293 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/storage"
+	"github.com/egnptr/dating-app/repository/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func testBlobs() *storage.BlobStoreMock {
+	return &storage.BlobStoreMock{
+		PutFunc: func(ctx context.Context, key, contentType string, body []byte) error {
+			return nil
+		},
+		DeleteFunc: func(ctx context.Context, key string) error {
+			return nil
+		},
+		URLFunc: func(key string) string {
+			return "/media/" + key
+		},
+	}
+}
+
+func TestUploadPhoto(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 40, 20))); err != nil {
+		t.Fatal(err)
+	}
+	upload := buf.Bytes()
+
+	noPhotos := func(ctx context.Context, userID int64) ([]model.Photo, error) {
+		return nil, nil
+	}
+	getPhoto := func(ctx context.Context, photoID int64) (*model.Photo, error) {
+		return &model.Photo{ID: photoID, UserID: 1, Key: "photos/1/a.jpg", ThumbnailKey: "photos/1/a_thumb.jpg", Width: 40, Height: 20, IsPrimary: true}, nil
+	}
+
+	tests := []struct {
+		name        string
+		body        []byte
+		repoDB      *db.RepoMock
+		blobs       *storage.BlobStoreMock
+		want        model.Photo
+		wantErr     error
+		wantPuts    int
+		wantDeletes int
+	}{
+		{
+			name: "case success",
+			body: upload,
+			repoDB: &db.RepoMock{
+				GetPhotosFunc: noPhotos,
+				InsertPhotoFunc: func(ctx context.Context, req model.Photo, limit int) (int64, error) {
+					assert.Equal(t, int64(1), req.UserID)
+					assert.Regexp(t, `^photos/1/[0-9a-f]+\.jpg$`, req.Key)
+					assert.Regexp(t, `^photos/1/[0-9a-f]+_thumb\.jpg$`, req.ThumbnailKey)
+					assert.Equal(t, 40, req.Width)
+					assert.Equal(t, 20, req.Height)
+					assert.Equal(t, photoMaxCount, limit)
+					return 7, nil
+				},
+				GetPhotoFunc: getPhoto,
+			},
+			blobs: testBlobs(),
+			want: model.Photo{
+				ID:           7,
+				UserID:       1,
+				Key:          "photos/1/a.jpg",
+				ThumbnailKey: "photos/1/a_thumb.jpg",
+				URL:          "/media/photos/1/a.jpg",
+				ThumbnailURL: "/media/photos/1/a_thumb.jpg",
+				Width:        40,
+				Height:       20,
+				IsPrimary:    true,
+			},
+			wantPuts: 2,
+		},
+		{
+			name:    "case error unsupported format",
+			body:    []byte("<svg></svg>"),
+			repoDB:  &db.RepoMock{GetPhotosFunc: noPhotos},
+			blobs:   testBlobs(),
+			wantErr: model.InvalidPhotoErr,
+		},
+		{
+			name:    "case error too large",
+			body:    make([]byte, model.PhotoMaxBytes+1),
+			repoDB:  &db.RepoMock{},
+			blobs:   testBlobs(),
+			wantErr: model.PhotoTooLargeErr,
+		},
+		{
+			name: "case error limit reached",
+			body: upload,
+			repoDB: &db.RepoMock{
+				GetPhotosFunc: func(ctx context.Context, userID int64) ([]model.Photo, error) {
+					return make([]model.Photo, photoMaxCount), nil
+				},
+			},
+			blobs:   testBlobs(),
+			wantErr: model.PhotoLimitErr,
+		},
+		{
+			name: "case error limit reached meanwhile",
+			body: upload,
+			repoDB: &db.RepoMock{
+				GetPhotosFunc: noPhotos,
+				InsertPhotoFunc: func(ctx context.Context, req model.Photo, limit int) (int64, error) {
+					return 0, model.PhotoLimitErr
+				},
+			},
+			blobs:       testBlobs(),
+			wantErr:     model.PhotoLimitErr,
+			wantPuts:    2,
+			wantDeletes: 2,
+		},
+		{
+			name:   "case error storing",
+			body:   upload,
+			repoDB: &db.RepoMock{GetPhotosFunc: noPhotos},
+			blobs: &storage.BlobStoreMock{
+				PutFunc: func(ctx context.Context, key, contentType string, body []byte) error {
+					return errors.New("err")
+				},
+			},
+			wantErr:  errors.New("err"),
+			wantPuts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.repoDB,
+				Blobs:  tt.blobs,
+			}
+			got, gotErr := u.UploadPhoto(context.Background(), model.UploadPhotoRequest{UserID: 1, Body: tt.body})
+			assert.Equal(t, tt.wantErr, gotErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.want, got)
+			}
+			assert.Len(t, tt.blobs.PutCalls(), tt.wantPuts)
+			assert.Len(t, tt.blobs.DeleteCalls(), tt.wantDeletes)
+			for _, call := range tt.blobs.PutCalls() {
+				assert.Equal(t, "image/jpeg", call.ContentType)
+			}
+		})
+	}
+}
+
+func TestReorderPhotos(t *testing.T) {
+	photos := func(ctx context.Context, userID int64) ([]model.Photo, error) {
+		return []model.Photo{{ID: 1, UserID: userID}, {ID: 2, UserID: userID}, {ID: 3, UserID: userID}}, nil
+	}
+
+	tests := []struct {
+		name     string
+		photoIDs []int64
+		repoDB   *db.RepoMock
+		wantErr  error
+	}{
+		{
+			name:     "case success",
+			photoIDs: []int64{3, 1, 2},
+			repoDB: &db.RepoMock{
+				GetPhotosFunc: photos,
+				UpdatePhotoOrderFunc: func(ctx context.Context, userID int64, photoIDs []int64) error {
+					assert.Equal(t, []int64{3, 1, 2}, photoIDs)
+					return nil
+				},
+			},
+		},
+		{
+			name:     "case error missing photo",
+			photoIDs: []int64{3, 1},
+			repoDB:   &db.RepoMock{GetPhotosFunc: photos},
+			wantErr:  model.InvalidPhotoOrderErr,
+		},
+		{
+			name:     "case error repeated photo",
+			photoIDs: []int64{3, 1, 1},
+			repoDB:   &db.RepoMock{GetPhotosFunc: photos},
+			wantErr:  model.InvalidPhotoOrderErr,
+		},
+		{
+			name:     "case error photo of another user",
+			photoIDs: []int64{3, 1, 2, 4},
+			repoDB:   &db.RepoMock{GetPhotosFunc: photos},
+			wantErr:  model.InvalidPhotoOrderErr,
+		},
+		{
+			name:     "case error db",
+			photoIDs: []int64{3, 1, 2},
+			repoDB: &db.RepoMock{
+				GetPhotosFunc: photos,
+				UpdatePhotoOrderFunc: func(ctx context.Context, userID int64, photoIDs []int64) error {
+					return errors.New("err")
+				},
+			},
+			wantErr: errors.New("err"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.repoDB,
+				Blobs:  testBlobs(),
+			}
+			got, gotErr := u.ReorderPhotos(context.Background(), model.ReorderPhotosRequest{UserID: 1, PhotoIDs: tt.photoIDs})
+			assert.Equal(t, tt.wantErr, gotErr)
+			if tt.wantErr == nil {
+				assert.Len(t, got, 3)
+				assert.Equal(t, "/media/", got[0].URL)
+			}
+		})
+	}
+}
+
+func TestDeletePhoto(t *testing.T) {
+	photo := func(ctx context.Context, photoID int64) (*model.Photo, error) {
+		return &model.Photo{ID: photoID, UserID: 1, Key: "photos/1/a.jpg", ThumbnailKey: "photos/1/a_thumb.jpg"}, nil
+	}
+
+	tests := []struct {
+		name        string
+		userID      int64
+		repoDB      *db.RepoMock
+		wantErr     error
+		wantDeletes []string
+	}{
+		{
+			name:   "case success",
+			userID: 1,
+			repoDB: &db.RepoMock{
+				GetPhotoFunc: photo,
+				DeletePhotoFunc: func(ctx context.Context, userID, photoID int64) error {
+					return nil
+				},
+			},
+			wantDeletes: []string{"photos/1/a.jpg", "photos/1/a_thumb.jpg"},
+		},
+		{
+			name:    "case error photo of another user",
+			userID:  2,
+			repoDB:  &db.RepoMock{GetPhotoFunc: photo},
+			wantErr: model.NotFoundErr,
+		},
+		{
+			name:   "case error not found",
+			userID: 1,
+			repoDB: &db.RepoMock{
+				GetPhotoFunc: func(ctx context.Context, photoID int64) (*model.Photo, error) {
+					return nil, model.NotFoundErr
+				},
+			},
+			wantErr: model.NotFoundErr,
+		},
+		{
+			name:   "case error db",
+			userID: 1,
+			repoDB: &db.RepoMock{
+				GetPhotoFunc: photo,
+				DeletePhotoFunc: func(ctx context.Context, userID, photoID int64) error {
+					return errors.New("err")
+				},
+			},
+			wantErr: errors.New("err"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blobs := testBlobs()
+			u := &usecase{
+				RepoDB: tt.repoDB,
+				Blobs:  blobs,
+			}
+			gotErr := u.DeletePhoto(context.Background(), model.PhotoRequest{UserID: tt.userID, PhotoID: 1})
+			assert.Equal(t, tt.wantErr, gotErr)
+
+			var deleted []string
+			for _, call := range blobs.DeleteCalls() {
+				deleted = append(deleted, call.Key)
+			}
+			assert.Equal(t, tt.wantDeletes, deleted)
+		})
+	}
+}
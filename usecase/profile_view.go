@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/entitlement"
+)
+
+// ViewProfile shows the profile to the user, as much of it as their relation
+// to it allows. Blocked profiles and accounts that are not active are not
+// found, other than by the user themselves and admins
+func (s *usecase) ViewProfile(ctx context.Context, req model.ViewProfileRequest) (res model.Profile, err error) {
+	viewer, err := s.RepoDB.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	profile, err := s.RepoDB.GetUserByID(ctx, req.ProfileID)
+	if err != nil {
+		if err != model.NotFoundErr {
+			log.Println("error when fetching user from db")
+		}
+		return
+	}
+
+	relation := model.RelationSelf
+	var likedAt *time.Time
+	switch {
+	case req.UserID == req.ProfileID:
+	case viewer.HasRole(model.RoleAdmin):
+		relation = model.RelationAdmin
+	default:
+		// Blocked profiles are hidden both ways as if they did not exist
+		blocked, errBlock := s.RepoDB.IsBlocked(ctx, req.UserID, req.ProfileID)
+		if errBlock != nil {
+			err = errBlock
+			log.Println("error when checking block from db")
+			return
+		}
+
+		if blocked || profile.AccountStatus != model.AccountStatusActive {
+			err = model.NotFoundErr
+			return
+		}
+
+		relation, likedAt, err = s.profileRelation(ctx, req.UserID, req.ProfileID)
+		if err != nil {
+			return
+		}
+	}
+
+	profile.Photos, err = s.GetPhotos(ctx, req.ProfileID)
+	if err != nil {
+		return
+	}
+
+	res = profile.Profile(relation)
+	res.LikedAt = likedAt
+
+	// Admins looking into an account are not courting it
+	if relation != model.RelationSelf && relation != model.RelationAdmin {
+		s.recordProfileView(ctx, viewer, req.ProfileID)
+	}
+
+	return
+}
+
+// profileRelation tells whether the viewer liked the profile, and when, and
+// whether it liked them back
+func (s *usecase) profileRelation(ctx context.Context, viewerID, profileID int64) (relation string, likedAt *time.Time, err error) {
+	relation = model.RelationStranger
+
+	swipe, err := s.RepoDB.GetSwipe(ctx, viewerID, profileID)
+	if err == model.NotFoundErr {
+		err = nil
+		return
+	} else if err != nil {
+		log.Println("error when fetching swipe from db")
+		return
+	}
+
+	if swipe.SwipeStatus != model.SwipeStatusLike && swipe.SwipeStatus != model.SwipeStatusSuperLike {
+		return
+	}
+
+	matched, err := s.RepoDB.IsMatch(ctx, viewerID, profileID)
+	if err != nil {
+		log.Println("error when checking match from db")
+		return
+	}
+
+	relation = model.RelationLiked
+	if matched {
+		relation = model.RelationMatch
+	}
+	likedAt = &swipe.SwipedAt
+	return
+}
+
+// recordProfileView buffers the first view of the day in the cache, failing
+// to record a view never fails showing the profile
+func (s *usecase) recordProfileView(ctx context.Context, viewer *model.User, viewedID int64) {
+	if viewer.IsIncognito {
+		return
+	}
+
+	view := model.ProfileView{
+		ViewerID: viewer.UserID,
+		ViewedID: viewedID,
+		ViewedAt: time.Now().UTC(),
+	}
+
+	first, err := s.RepoCache.MarkProfileViewed(ctx, view)
+	if err != nil {
+		log.Println("error when marking profile view in cache")
+		return
+	}
+
+	if !first {
+		return
+	}
+
+	err = s.RepoCache.PushProfileViews(ctx, []model.ProfileView{view})
+	if err != nil {
+		log.Println("error when buffering profile view in cache")
+	}
+}
+
+func (s *usecase) GetProfileViewers(ctx context.Context, userID int64) (res model.ProfileViewersResponse, err error) {
+	user, err := s.RepoDB.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	since := time.Now().Add(-profileViewersWindow)
+	res.Count, err = s.RepoDB.CountProfileViewers(ctx, userID, since)
+	if err != nil {
+		log.Println("error when counting profile viewers from db")
+		return
+	}
+
+	// Users without the feature only get to know how many people viewed them
+	if !s.entitlements(user).Can(entitlement.FeatureSeeProfileViewers) {
+		return
+	}
+
+	res.Viewers, err = s.RepoDB.GetProfileViewers(ctx, userID, since, profileViewersLimit)
+	if err != nil {
+		log.Println("error when fetching profile viewers from db")
+		return
+	}
+
+	return
+}
+
+func (s *usecase) SetIncognito(ctx context.Context, req model.IncognitoRequest) (err error) {
+	err = s.RepoDB.UpdateIncognito(ctx, req.UserID, req.Incognito)
+	if err != nil {
+		log.Println("error when updating incognito mode in db")
+		return
+	}
+
+	return
+}
+
+// FlushProfileViews writes the buffered profile views to the db. A batch
+// that fails to be written goes back to the buffer for the next flush
+func (s *usecase) FlushProfileViews(ctx context.Context) (err error) {
+	for {
+		views, errPop := s.RepoCache.PopProfileViews(ctx, profileViewFlushBatch)
+		if errPop != nil {
+			err = errPop
+			log.Println("error when fetching profile views from cache")
+			return
+		}
+
+		if len(views) == 0 {
+			return
+		}
+
+		err = s.RepoDB.InsertProfileViews(ctx, views)
+		if err != nil {
+			log.Println("error when inserting profile views to db")
+			errPush := s.RepoCache.PushProfileViews(ctx, views)
+			if errPush != nil {
+				log.Println("error when buffering profile views in cache")
+			}
+			return
+		}
+
+		if len(views) < profileViewFlushBatch {
+			return
+		}
+	}
+}
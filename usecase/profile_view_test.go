@@ -0,0 +1,549 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/repository/cache"
+	"github.com/egnptr/dating-app/repository/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViewProfile(t *testing.T) {
+	likedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	users := func(ctx context.Context, userID int64) (*model.User, error) {
+		user := &model.User{
+			UserID:        userID,
+			Username:      "jane",
+			FullName:      "Jane Doe",
+			Email:         "jane@doe.com",
+			EmailVerified: true,
+			Role:          model.RoleUser,
+			AccountStatus: model.AccountStatusActive,
+			IsIncognito:   userID == 3,
+		}
+		switch userID {
+		case 4:
+			user.AccountStatus = model.AccountStatusBanned
+		case 9:
+			user.Role = model.RoleAdmin
+		}
+		return user, nil
+	}
+	photos := func(ctx context.Context, userID int64) ([]model.Photo, error) {
+		return []model.Photo{{ID: 1, UserID: userID, Key: "photos/2/a.jpg", ThumbnailKey: "photos/2/a_thumb.jpg", IsPrimary: true}}, nil
+	}
+	wantPhotos := []model.Photo{{
+		ID:           1,
+		UserID:       2,
+		Key:          "photos/2/a.jpg",
+		ThumbnailKey: "photos/2/a_thumb.jpg",
+		URL:          "/media/photos/2/a.jpg",
+		ThumbnailURL: "/media/photos/2/a_thumb.jpg",
+		IsPrimary:    true,
+	}}
+
+	notBlocked := func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+		return false, nil
+	}
+	notSwiped := func(ctx context.Context, swiperID, swipedID int64) (*model.Swipe, error) {
+		return nil, model.NotFoundErr
+	}
+	liked := func(ctx context.Context, swiperID, swipedID int64) (*model.Swipe, error) {
+		return &model.Swipe{SwiperID: swiperID, SwipedID: swipedID, SwipeStatus: model.SwipeStatusLike, SwipedAt: likedAt}, nil
+	}
+	firstView := func() *cache.RepoMock {
+		return &cache.RepoMock{
+			MarkProfileViewedFunc: func(ctx context.Context, view model.ProfileView) (bool, error) {
+				return true, nil
+			},
+			PushProfileViewsFunc: func(ctx context.Context, views []model.ProfileView) error {
+				return nil
+			},
+		}
+	}
+
+	type fields struct {
+		repoDB    db.Repo
+		repoCache *cache.RepoMock
+	}
+	type args struct {
+		req model.ViewProfileRequest
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		want         model.Profile
+		wantBuffered int
+		wantErr      error
+	}{
+		{
+			name: "case success stranger first view of the day",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: users,
+					IsBlockedFunc:   notBlocked,
+					GetSwipeFunc:    notSwiped,
+					GetPhotosFunc:   photos,
+				},
+				repoCache: firstView(),
+			},
+			args: args{
+				req: model.ViewProfileRequest{
+					UserID:    1,
+					ProfileID: 2,
+				},
+			},
+			want:         model.Profile{UserID: 2, Relation: model.RelationStranger, FullName: "Jane Doe", Photos: wantPhotos},
+			wantBuffered: 1,
+		},
+		{
+			name: "case success passed viewed earlier today",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: users,
+					IsBlockedFunc:   notBlocked,
+					GetSwipeFunc: func(ctx context.Context, swiperID, swipedID int64) (*model.Swipe, error) {
+						return &model.Swipe{SwiperID: swiperID, SwipedID: swipedID, SwipeStatus: model.SwipeStatusPass}, nil
+					},
+					GetPhotosFunc: photos,
+				},
+				repoCache: &cache.RepoMock{
+					MarkProfileViewedFunc: func(ctx context.Context, view model.ProfileView) (bool, error) {
+						return false, nil
+					},
+				},
+			},
+			args: args{
+				req: model.ViewProfileRequest{
+					UserID:    1,
+					ProfileID: 2,
+				},
+			},
+			want: model.Profile{UserID: 2, Relation: model.RelationStranger, FullName: "Jane Doe", Photos: wantPhotos},
+		},
+		{
+			name: "case success liked",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: users,
+					IsBlockedFunc:   notBlocked,
+					GetSwipeFunc:    liked,
+					IsMatchFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return false, nil
+					},
+					GetPhotosFunc: photos,
+				},
+				repoCache: firstView(),
+			},
+			args: args{
+				req: model.ViewProfileRequest{
+					UserID:    1,
+					ProfileID: 2,
+				},
+			},
+			want:         model.Profile{UserID: 2, Relation: model.RelationLiked, FullName: "Jane Doe", Photos: wantPhotos, LikedAt: &likedAt},
+			wantBuffered: 1,
+		},
+		{
+			name: "case success match",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: users,
+					IsBlockedFunc:   notBlocked,
+					GetSwipeFunc:    liked,
+					IsMatchFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return true, nil
+					},
+					GetPhotosFunc: photos,
+				},
+				repoCache: firstView(),
+			},
+			args: args{
+				req: model.ViewProfileRequest{
+					UserID:    1,
+					ProfileID: 2,
+				},
+			},
+			want:         model.Profile{UserID: 2, Relation: model.RelationMatch, FullName: "Jane Doe", Photos: wantPhotos, LikedAt: &likedAt, Username: "jane"},
+			wantBuffered: 1,
+		},
+		{
+			name: "case success incognito viewer",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: users,
+					IsBlockedFunc:   notBlocked,
+					GetSwipeFunc:    notSwiped,
+					GetPhotosFunc:   photos,
+				},
+				repoCache: &cache.RepoMock{},
+			},
+			args: args{
+				req: model.ViewProfileRequest{
+					UserID:    3,
+					ProfileID: 2,
+				},
+			},
+			want: model.Profile{UserID: 2, Relation: model.RelationStranger, FullName: "Jane Doe", Photos: wantPhotos},
+		},
+		{
+			name: "case success own profile",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: users,
+					GetPhotosFunc:   photos,
+				},
+				repoCache: &cache.RepoMock{},
+			},
+			args: args{
+				req: model.ViewProfileRequest{
+					UserID:    2,
+					ProfileID: 2,
+				},
+			},
+			want: model.Profile{
+				UserID:        2,
+				Relation:      model.RelationSelf,
+				FullName:      "Jane Doe",
+				Photos:        wantPhotos,
+				Username:      "jane",
+				Email:         "jane@doe.com",
+				EmailVerified: true,
+				Role:          model.RoleUser,
+				AccountStatus: model.AccountStatusActive,
+			},
+		},
+		{
+			name: "case success admin sees banned account",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: users,
+					GetPhotosFunc: func(ctx context.Context, userID int64) ([]model.Photo, error) {
+						return nil, nil
+					},
+				},
+				repoCache: &cache.RepoMock{},
+			},
+			args: args{
+				req: model.ViewProfileRequest{
+					UserID:    9,
+					ProfileID: 4,
+				},
+			},
+			want: model.Profile{
+				UserID:        4,
+				Relation:      model.RelationAdmin,
+				FullName:      "Jane Doe",
+				Photos:        []model.Photo{},
+				Username:      "jane",
+				Email:         "jane@doe.com",
+				EmailVerified: true,
+				Role:          model.RoleUser,
+				AccountStatus: model.AccountStatusBanned,
+			},
+		},
+		{
+			name: "case error banned account",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: users,
+					IsBlockedFunc:   notBlocked,
+				},
+				repoCache: &cache.RepoMock{},
+			},
+			args: args{
+				req: model.ViewProfileRequest{
+					UserID:    1,
+					ProfileID: 4,
+				},
+			},
+			wantErr: model.NotFoundErr,
+		},
+		{
+			name: "case error blocked",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: users,
+					IsBlockedFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return true, nil
+					},
+				},
+				repoCache: &cache.RepoMock{},
+			},
+			args: args{
+				req: model.ViewProfileRequest{
+					UserID:    1,
+					ProfileID: 2,
+				},
+			},
+			wantErr: model.NotFoundErr,
+		},
+		{
+			name: "case error not found",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						if userID == 1 {
+							return users(ctx, userID)
+						}
+						return nil, model.NotFoundErr
+					},
+				},
+				repoCache: &cache.RepoMock{},
+			},
+			args: args{
+				req: model.ViewProfileRequest{
+					UserID:    1,
+					ProfileID: 2,
+				},
+			},
+			wantErr: model.NotFoundErr,
+		},
+		{
+			name: "case error swipe",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: users,
+					IsBlockedFunc:   notBlocked,
+					GetSwipeFunc: func(ctx context.Context, swiperID, swipedID int64) (*model.Swipe, error) {
+						return nil, errors.New("err")
+					},
+				},
+				repoCache: &cache.RepoMock{},
+			},
+			args: args{
+				req: model.ViewProfileRequest{
+					UserID:    1,
+					ProfileID: 2,
+				},
+			},
+			wantErr: errors.New("err"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB:    tt.fields.repoDB,
+				RepoCache: tt.fields.repoCache,
+				Blobs:     testBlobs(),
+			}
+			got, gotErr := u.ViewProfile(context.Background(), tt.args.req)
+			assert.Equal(t, tt.wantErr, gotErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.want, got)
+			}
+			assert.Len(t, tt.fields.repoCache.PushProfileViewsCalls(), tt.wantBuffered)
+		})
+	}
+}
+
+func TestGetProfileViewers(t *testing.T) {
+	viewers := []model.ProfileViewer{{UserID: 2, FullName: "Jane Doe"}}
+
+	type fields struct {
+		repoDB db.Repo
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    model.ProfileViewersResponse
+		wantErr bool
+	}{
+		{
+			name: "case success premium",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{UserID: userID, IsPremium: true, Tier: model.TierPlus}, nil
+					},
+					CountProfileViewersFunc: func(ctx context.Context, userID int64, since time.Time) (int64, error) {
+						return 1, nil
+					},
+					GetProfileViewersFunc: func(ctx context.Context, userID int64, since time.Time, limit int) ([]model.ProfileViewer, error) {
+						return viewers, nil
+					},
+				},
+			},
+			want: model.ProfileViewersResponse{Count: 1, Viewers: viewers},
+		},
+		{
+			name: "case success free",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{UserID: userID}, nil
+					},
+					CountProfileViewersFunc: func(ctx context.Context, userID int64, since time.Time) (int64, error) {
+						return 1, nil
+					},
+				},
+			},
+			want: model.ProfileViewersResponse{Count: 1},
+		},
+		{
+			name: "case error db",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{UserID: userID}, nil
+					},
+					CountProfileViewersFunc: func(ctx context.Context, userID int64, since time.Time) (int64, error) {
+						return 0, errors.New("err")
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.fields.repoDB,
+			}
+			got, gotErr := u.GetProfileViewers(context.Background(), 1)
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("GetProfileViewers() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetIncognito(t *testing.T) {
+	type fields struct {
+		repoDB db.Repo
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "case success",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					UpdateIncognitoFunc: func(ctx context.Context, userID int64, incognito bool) error {
+						return nil
+					},
+				},
+			},
+		},
+		{
+			name: "case error db",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					UpdateIncognitoFunc: func(ctx context.Context, userID int64, incognito bool) error {
+						return errors.New("err")
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.fields.repoDB,
+			}
+			gotErr := u.SetIncognito(context.Background(), model.IncognitoRequest{UserID: 1, Incognito: true})
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("SetIncognito() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestFlushProfileViews(t *testing.T) {
+	batch := func(n int) []model.ProfileView {
+		return make([]model.ProfileView, n)
+	}
+
+	type fields struct {
+		repoDB    *db.RepoMock
+		repoCache *cache.RepoMock
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		wantInserted int
+		wantPushed   int
+		wantErr      bool
+	}{
+		{
+			name: "case success several batches",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					InsertProfileViewsFunc: func(ctx context.Context, views []model.ProfileView) error {
+						return nil
+					},
+				},
+				repoCache: func() *cache.RepoMock {
+					batches := [][]model.ProfileView{batch(profileViewFlushBatch), batch(3)}
+					return &cache.RepoMock{
+						PopProfileViewsFunc: func(ctx context.Context, count int) ([]model.ProfileView, error) {
+							views := batches[0]
+							batches = batches[1:]
+							return views, nil
+						},
+					}
+				}(),
+			},
+			wantInserted: 2,
+		},
+		{
+			name: "case success empty buffer",
+			fields: fields{
+				repoDB: &db.RepoMock{},
+				repoCache: &cache.RepoMock{
+					PopProfileViewsFunc: func(ctx context.Context, count int) ([]model.ProfileView, error) {
+						return nil, nil
+					},
+				},
+			},
+		},
+		{
+			name: "case error db requeues batch",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					InsertProfileViewsFunc: func(ctx context.Context, views []model.ProfileView) error {
+						return errors.New("err")
+					},
+				},
+				repoCache: &cache.RepoMock{
+					PopProfileViewsFunc: func(ctx context.Context, count int) ([]model.ProfileView, error) {
+						return batch(3), nil
+					},
+					PushProfileViewsFunc: func(ctx context.Context, views []model.ProfileView) error {
+						return nil
+					},
+				},
+			},
+			wantInserted: 1,
+			wantPushed:   1,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB:    tt.fields.repoDB,
+				RepoCache: tt.fields.repoCache,
+			}
+			gotErr := u.FlushProfileViews(context.Background())
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("FlushProfileViews() error = %v, wantErr = %v", gotErr, tt.wantErr)
+				return
+			}
+			assert.Len(t, tt.fields.repoDB.InsertProfileViewsCalls(), tt.wantInserted)
+			assert.Len(t, tt.fields.repoCache.PushProfileViewsCalls(), tt.wantPushed)
+		})
+	}
+}
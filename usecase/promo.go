@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/util"
+)
+
+// CreatePromoCode adds a code for users to redeem, generating one when none
+// is given. Codes are matched without regard to case
+func (s *usecase) CreatePromoCode(ctx context.Context, req model.CreatePromoCodeRequest) (res model.PromoCode, err error) {
+	req.Code = normalizeCode(req.Code)
+	if req.Code == "" {
+		token, errToken := util.GenerateToken(promoCodeBytes)
+		if errToken != nil {
+			err = errToken
+			log.Println("error generating promo code")
+			return
+		}
+		req.Code = normalizeCode(token)
+	}
+
+	// Discounts go to whatever tier is paid for next
+	if req.Kind == model.PromoKindDays {
+		if req.Tier == "" {
+			req.Tier = model.TierPlus
+		}
+	} else {
+		req.Tier = ""
+	}
+
+	now := time.Now()
+	if !validPromoCode(req, now) {
+		err = model.InvalidPromoCodeErr
+		return
+	}
+
+	_, err = s.RepoDB.GetPromoCode(ctx, req.Code)
+	if err == nil {
+		err = model.PromoCodeTakenErr
+		return
+	} else if err != model.NotFoundErr {
+		log.Println("error when fetching promo code from db")
+		return
+	}
+
+	res = model.PromoCode{
+		Code:      req.Code,
+		Kind:      req.Kind,
+		Value:     req.Value,
+		Tier:      req.Tier,
+		MaxUses:   req.MaxUses,
+		ExpiresAt: req.ExpiresAt,
+		CreatedBy: req.ActorID,
+		CreatedAt: now,
+	}
+	res.ID, err = s.RepoDB.InsertPromoCode(ctx, res)
+	if err != nil {
+		log.Println("error when inserting promo code to db")
+		return
+	}
+
+	return
+}
+
+// RedeemPromoCode grants the premium days of a days code right away. A
+// discount is kept for the next checkout of the user
+func (s *usecase) RedeemPromoCode(ctx context.Context, req model.RedeemRequest) (res model.RedeemPromoCodeResponse, err error) {
+	promo, err := s.RepoDB.GetPromoCode(ctx, normalizeCode(req.Code))
+	if err == model.NotFoundErr {
+		err = model.UnknownPromoCodeErr
+		return
+	} else if err != nil {
+		log.Println("error when fetching promo code from db")
+		return
+	}
+
+	now := time.Now()
+	if promo.ExpiresAt != nil && !now.Before(*promo.ExpiresAt) {
+		err = model.PromoCodeExpiredErr
+		return
+	}
+
+	redemption := model.PromoRedemption{
+		PromoCodeID: promo.ID,
+		UserID:      req.UserID,
+		RedeemedAt:  now,
+	}
+	if !promo.IsDiscount() {
+		redemption.AppliedAt = &now
+	}
+	err = s.RepoDB.RedeemPromoCode(ctx, redemption)
+	if err != nil {
+		log.Println("error when redeeming promo code in db")
+		return
+	}
+
+	if !promo.IsDiscount() {
+		until, errGrant := s.grantPremiumDays(ctx, req.UserID, promo.Tier, int(promo.Value))
+		if errGrant != nil {
+			err = errGrant
+
+			// The user keeps the code to try again rather than losing it
+			// without the days
+			if errUnredeem := s.RepoDB.UnredeemPromoCode(ctx, redemption); errUnredeem != nil {
+				log.Println("error when unredeeming promo code in db")
+			}
+			return
+		}
+		res.PremiumUntil = &until
+	}
+
+	res.Kind = promo.Kind
+	res.Value = promo.Value
+	return
+}
+
+func validPromoCode(req model.CreatePromoCodeRequest, now time.Time) bool {
+	if req.MaxUses < 0 || (req.ExpiresAt != nil && !req.ExpiresAt.After(now)) {
+		return false
+	}
+
+	switch req.Kind {
+	case model.PromoKindPercent:
+		return req.Value > 0 && req.Value <= 100
+	case model.PromoKindFixed:
+		return req.Value > 0
+	case model.PromoKindDays:
+		return req.Value > 0 && model.PaidTiers[req.Tier]
+	}
+	return false
+}
+
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/repository/cache"
+	"github.com/egnptr/dating-app/repository/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePromoCode(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	unused := func(ctx context.Context, code string) (*model.PromoCode, error) {
+		return nil, model.NotFoundErr
+	}
+
+	tests := []struct {
+		name    string
+		repoDB  *db.RepoMock
+		req     model.CreatePromoCodeRequest
+		want    model.PromoCode
+		wantErr error
+	}{
+		{
+			name: "case success days",
+			repoDB: &db.RepoMock{
+				GetPromoCodeFunc: unused,
+				InsertPromoCodeFunc: func(ctx context.Context, req model.PromoCode) (int64, error) {
+					return 1, nil
+				},
+			},
+			req:  model.CreatePromoCodeRequest{ActorID: 9, Code: " summer ", Kind: model.PromoKindDays, Value: 14, MaxUses: 100},
+			want: model.PromoCode{ID: 1, Code: "SUMMER", Kind: model.PromoKindDays, Value: 14, Tier: model.TierPlus, MaxUses: 100, CreatedBy: 9},
+		},
+		{
+			name: "case success discount ignores tier",
+			repoDB: &db.RepoMock{
+				GetPromoCodeFunc: unused,
+				InsertPromoCodeFunc: func(ctx context.Context, req model.PromoCode) (int64, error) {
+					return 2, nil
+				},
+			},
+			req:  model.CreatePromoCodeRequest{ActorID: 9, Code: "HALF", Kind: model.PromoKindPercent, Value: 50, Tier: model.TierGold},
+			want: model.PromoCode{ID: 2, Code: "HALF", Kind: model.PromoKindPercent, Value: 50, CreatedBy: 9},
+		},
+		{
+			name:    "case error percent over 100",
+			repoDB:  &db.RepoMock{},
+			req:     model.CreatePromoCodeRequest{Code: "FREE", Kind: model.PromoKindPercent, Value: 150},
+			wantErr: model.InvalidPromoCodeErr,
+		},
+		{
+			name:    "case error unknown tier",
+			repoDB:  &db.RepoMock{},
+			req:     model.CreatePromoCodeRequest{Code: "PLAT", Kind: model.PromoKindDays, Value: 7, Tier: "platinum"},
+			wantErr: model.InvalidPromoCodeErr,
+		},
+		{
+			name:    "case error expired",
+			repoDB:  &db.RepoMock{},
+			req:     model.CreatePromoCodeRequest{Code: "OLD", Kind: model.PromoKindFixed, Value: 500, ExpiresAt: &past},
+			wantErr: model.InvalidPromoCodeErr,
+		},
+		{
+			name: "case error taken",
+			repoDB: &db.RepoMock{
+				GetPromoCodeFunc: func(ctx context.Context, code string) (*model.PromoCode, error) {
+					return &model.PromoCode{ID: 1, Code: code}, nil
+				},
+			},
+			req:     model.CreatePromoCodeRequest{Code: "SUMMER", Kind: model.PromoKindDays, Value: 14},
+			wantErr: model.PromoCodeTakenErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.repoDB,
+			}
+			got, gotErr := u.CreatePromoCode(context.Background(), tt.req)
+			assert.Equal(t, tt.wantErr, gotErr)
+
+			got.CreatedAt = time.Time{}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRedeemPromoCode(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	premiumEnd := now.Add(48 * time.Hour)
+	errGrant := errors.New("err")
+	promo := func(kind string, value int64, expiresAt *time.Time) func(ctx context.Context, code string) (*model.PromoCode, error) {
+		return func(ctx context.Context, code string) (*model.PromoCode, error) {
+			assert.Equal(t, "SUMMER", code)
+			return &model.PromoCode{ID: 1, Code: code, Kind: kind, Value: value, Tier: model.TierPlus, ExpiresAt: expiresAt}, nil
+		}
+	}
+
+	tests := []struct {
+		name           string
+		repoDB         *db.RepoMock
+		wantRes        model.RedeemPromoCodeResponse
+		wantEvents     int
+		wantUnredeemed int
+		wantErr        error
+	}{
+		{
+			name: "case success days",
+			repoDB: &db.RepoMock{
+				GetPromoCodeFunc: promo(model.PromoKindDays, 7, nil),
+				RedeemPromoCodeFunc: func(ctx context.Context, req model.PromoRedemption) error {
+					assert.NotNil(t, req.AppliedAt)
+					return nil
+				},
+				GetPremiumEndFunc: func(ctx context.Context, userID int64, at time.Time) (time.Time, error) {
+					return time.Time{}, model.NotFoundErr
+				},
+				InsertSubscriptionFunc: func(ctx context.Context, req model.Subscription) (int64, error) {
+					assert.Equal(t, model.PlanPromo, req.Plan)
+					assert.Equal(t, model.TierPlus, req.Tier)
+					assert.Equal(t, req.CurrentPeriodStart.AddDate(0, 0, 7), req.CurrentPeriodEnd)
+					return 1, nil
+				},
+			},
+			wantRes:    model.RedeemPromoCodeResponse{Kind: model.PromoKindDays, Value: 7},
+			wantEvents: 1,
+		},
+		{
+			name: "case success days after premium",
+			repoDB: &db.RepoMock{
+				GetPromoCodeFunc: promo(model.PromoKindDays, 7, nil),
+				RedeemPromoCodeFunc: func(ctx context.Context, req model.PromoRedemption) error {
+					return nil
+				},
+				GetPremiumEndFunc: func(ctx context.Context, userID int64, at time.Time) (time.Time, error) {
+					return premiumEnd, nil
+				},
+				InsertSubscriptionFunc: func(ctx context.Context, req model.Subscription) (int64, error) {
+					assert.Equal(t, premiumEnd, req.CurrentPeriodStart)
+					return 1, nil
+				},
+			},
+			wantRes: model.RedeemPromoCodeResponse{Kind: model.PromoKindDays, Value: 7},
+		},
+		{
+			name: "case success discount",
+			repoDB: &db.RepoMock{
+				GetPromoCodeFunc: promo(model.PromoKindPercent, 20, nil),
+				RedeemPromoCodeFunc: func(ctx context.Context, req model.PromoRedemption) error {
+					assert.Nil(t, req.AppliedAt)
+					return nil
+				},
+			},
+			wantRes: model.RedeemPromoCodeResponse{Kind: model.PromoKindPercent, Value: 20},
+		},
+		{
+			name: "case error expired",
+			repoDB: &db.RepoMock{
+				GetPromoCodeFunc: promo(model.PromoKindDays, 7, &past),
+			},
+			wantErr: model.PromoCodeExpiredErr,
+		},
+		{
+			name: "case error redeemed before",
+			repoDB: &db.RepoMock{
+				GetPromoCodeFunc: promo(model.PromoKindDays, 7, nil),
+				RedeemPromoCodeFunc: func(ctx context.Context, req model.PromoRedemption) error {
+					return model.AlreadyRedeemedErr
+				},
+			},
+			wantErr: model.AlreadyRedeemedErr,
+		},
+		{
+			name: "case error unknown code",
+			repoDB: &db.RepoMock{
+				GetPromoCodeFunc: func(ctx context.Context, code string) (*model.PromoCode, error) {
+					return nil, model.NotFoundErr
+				},
+			},
+			wantErr: model.UnknownPromoCodeErr,
+		},
+		{
+			name: "case error grant gives the code back",
+			repoDB: &db.RepoMock{
+				GetPromoCodeFunc: promo(model.PromoKindDays, 7, nil),
+				RedeemPromoCodeFunc: func(ctx context.Context, req model.PromoRedemption) error {
+					return nil
+				},
+				GetPremiumEndFunc: func(ctx context.Context, userID int64, at time.Time) (time.Time, error) {
+					return time.Time{}, model.NotFoundErr
+				},
+				InsertSubscriptionFunc: func(ctx context.Context, req model.Subscription) (int64, error) {
+					return 0, errGrant
+				},
+				UnredeemPromoCodeFunc: func(ctx context.Context, req model.PromoRedemption) error {
+					assert.Equal(t, int64(1), req.PromoCodeID)
+					assert.Equal(t, int64(1), req.UserID)
+					return nil
+				},
+			},
+			wantUnredeemed: 1,
+			wantErr:        errGrant,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoCache := &cache.RepoMock{
+				PublishEventFunc: func(ctx context.Context, event model.Event) error {
+					return nil
+				},
+			}
+			u := &usecase{
+				RepoDB:    tt.repoDB,
+				RepoCache: repoCache,
+			}
+			gotRes, gotErr := u.RedeemPromoCode(context.Background(), model.RedeemRequest{UserID: 1, Code: "summer"})
+			assert.Equal(t, tt.wantErr, gotErr)
+
+			if tt.wantRes.Kind == model.PromoKindDays {
+				assert.NotNil(t, gotRes.PremiumUntil)
+				gotRes.PremiumUntil = nil
+			}
+			assert.Equal(t, tt.wantRes, gotRes)
+			assert.Len(t, repoCache.PublishEventCalls(), tt.wantEvents)
+			assert.Len(t, tt.repoDB.UnredeemPromoCodeCalls(), tt.wantUnredeemed)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/receipt"
+)
+
+// storeStatuses maps the purchase statuses of the stores to ours. The stores
+// keep the user entitled during a grace period, until the purchase expires
+var storeStatuses = map[string]string{
+	receipt.StatusActive:       model.SubscriptionStatusActive,
+	receipt.StatusGracePeriod:  model.SubscriptionStatusActive,
+	receipt.StatusBillingRetry: model.SubscriptionStatusPastDue,
+	receipt.StatusExpired:      model.SubscriptionStatusCanceled,
+	receipt.StatusRefunded:     model.SubscriptionStatusCanceled,
+}
+
+// storePurchaseID is how a purchase in a store is known to the app, prefixed
+// with the store so it never clashes with the payment provider or the other
+// store
+func storePurchaseID(store, purchaseID string) string {
+	return store + ":" + purchaseID
+}
+
+// VerifyReceipt starts or brings up to date the subscription the user bought
+// in a store. Apps send the receipt again on restoring purchases, so a receipt
+// already known for the user is not an error
+func (s *usecase) VerifyReceipt(ctx context.Context, req model.ReceiptRequest) (res model.Subscription, err error) {
+	purchase, err := s.Receipts.VerifyReceipt(ctx, req.Store, req.Receipt)
+	if errors.Is(err, receipt.ErrInvalidReceipt) || errors.Is(err, receipt.ErrUnknownStore) {
+		err = model.InvalidReceiptErr
+		return
+	} else if err != nil {
+		log.Println("error when verifying receipt at store")
+		return
+	}
+
+	// A receipt cannot make two accounts premium
+	subscription, err := s.RepoDB.GetSubscriptionByProviderID(ctx, storePurchaseID(purchase.Store, purchase.ID))
+	if err == nil && subscription.UserID != req.UserID {
+		err = model.ReceiptInUseErr
+		return
+	} else if err == model.NotFoundErr {
+		subscription = &model.Subscription{
+			UserID:                 req.UserID,
+			Store:                  purchase.Store,
+			ProviderSubscriptionID: storePurchaseID(purchase.Store, purchase.ID),
+			CurrentPeriodStart:     purchase.PurchasedAt,
+		}
+	} else if err != nil {
+		log.Println("error when fetching subscription from db")
+		return
+	}
+
+	err = s.syncStorePurchase(ctx, subscription, purchase)
+	if err != nil {
+		return
+	}
+
+	res = *subscription
+	return
+}
+
+// HandleStoreNotification brings the subscription a server notification is
+// about up to date. Like the payment webhooks, notifications are handled once
+// and the state is taken from the store rather than from the notification
+func (s *usecase) HandleStoreNotification(ctx context.Context, req model.StoreNotificationRequest) (err error) {
+	notification, err := s.Receipts.ParseNotification(ctx, req.Store, req.Payload, req.Header)
+	if errors.Is(err, receipt.ErrInvalidSignature) {
+		err = model.InvalidSignatureErr
+		return
+	} else if errors.Is(err, receipt.ErrUnknownStore) {
+		err = model.InvalidReceiptErr
+		return
+	} else if err != nil {
+		log.Println("error when verifying store notification")
+		return
+	}
+
+	switch notification.Type {
+	case receipt.NotificationRenewed, receipt.NotificationGracePeriod, receipt.NotificationBillingRetry,
+		receipt.NotificationRenewalChanged, receipt.NotificationExpired, receipt.NotificationRefunded:
+	default:
+		return
+	}
+	if notification.PurchaseID == "" {
+		return
+	}
+
+	providerSubscriptionID := storePurchaseID(req.Store, notification.PurchaseID)
+	eventID := storePurchaseID(req.Store, notification.ID)
+	inserted, err := s.RepoDB.InsertPaymentEvent(ctx, model.PaymentEvent{
+		ID:             eventID,
+		Type:           notification.Type,
+		SubscriptionID: providerSubscriptionID,
+	})
+	if err != nil {
+		log.Println("error when inserting payment event to db")
+		return
+	} else if !inserted {
+		return
+	}
+
+	// Forget the notification when handling it failed so the redelivery is
+	// handled
+	defer func() {
+		if err == nil {
+			return
+		}
+		if deleteErr := s.RepoDB.DeletePaymentEvent(ctx, eventID); deleteErr != nil {
+			log.Println("error when deleting payment event from db")
+		}
+	}()
+
+	// Nothing to update before the app sent the receipt, which tells whose
+	// purchase it is
+	subscription, err := s.RepoDB.GetSubscriptionByProviderID(ctx, providerSubscriptionID)
+	if err == model.NotFoundErr {
+		err = nil
+		return
+	} else if err != nil {
+		log.Println("error when fetching subscription from db")
+		return
+	}
+
+	purchase, err := s.Receipts.FetchPurchase(ctx, req.Store, notification.PurchaseID)
+	if err != nil {
+		log.Println("error when fetching purchase from store")
+		return
+	}
+
+	err = s.syncStorePurchase(ctx, subscription, purchase)
+	if err == model.InvalidPlanErr {
+		log.Println("error purchase at store is for an unknown product")
+		err = nil
+	}
+	return
+}
+
+// syncStorePurchase copies the state of the purchase at the store to the
+// subscription, inserting it when it is new
+func (s *usecase) syncStorePurchase(ctx context.Context, subscription *model.Subscription, purchase receipt.Purchase) (err error) {
+	product, ok := s.Config.StoreProducts[purchase.ProductID]
+	if !ok {
+		err = model.InvalidPlanErr
+		return
+	}
+
+	now := time.Now()
+	wasActive := subscription.IsActive(now)
+	previous := *subscription
+
+	// A renewal starts the period where the last one ended
+	if subscription.ID != 0 && purchase.Status == receipt.StatusActive && purchase.ExpiresAt.After(subscription.CurrentPeriodEnd) {
+		subscription.CurrentPeriodStart = subscription.CurrentPeriodEnd
+	}
+
+	subscription.Tier = product.Tier
+	subscription.Plan = product.Plan
+	subscription.Status = storeStatuses[purchase.Status]
+	subscription.CurrentPeriodEnd = purchase.ExpiresAt
+	subscription.AutoRenew = purchase.AutoRenew
+	subscription.CancelAtPeriodEnd = !purchase.AutoRenew
+
+	// The money for the period was given back
+	if purchase.Status == receipt.StatusRefunded && subscription.CurrentPeriodEnd.After(now) {
+		subscription.CurrentPeriodEnd = now
+	}
+
+	if subscription.ID == 0 {
+		subscription.ID, err = s.RepoDB.InsertSubscription(ctx, *subscription)
+		if err != nil {
+			log.Println("error when inserting subscription to db")
+			return
+		}
+	} else {
+		err = s.RepoDB.UpdateSubscription(ctx, *subscription)
+		if err != nil {
+			log.Println("error when updating subscription in db")
+			return
+		}
+	}
+
+	switch {
+	case purchase.Status == receipt.StatusRefunded:
+		s.recordSubscriptionRefund(ctx, *subscription)
+	case purchase.Status == receipt.StatusActive && (previous.ID == 0 ||
+		!subscription.CurrentPeriodStart.Equal(previous.CurrentPeriodStart) || previous.Status == model.SubscriptionStatusPastDue):
+		s.recordSubscriptionCharge(ctx, *subscription, purchase.Price, purchase.Currency)
+	}
+	if previous.ID != 0 && subscription.Tier != previous.Tier {
+		s.recordPlanChange(ctx, *subscription, previous.Tier, now)
+	}
+
+	if isActive := subscription.IsActive(now); isActive != wasActive {
+		s.publishEvent(ctx, subscription.UserID, model.EventTypePremiumChanged, model.PremiumChangedEvent{
+			IsPremium: isActive,
+		})
+	}
+
+	return
+}
@@ -0,0 +1,406 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/receipt"
+	"github.com/egnptr/dating-app/repository/cache"
+	"github.com/egnptr/dating-app/repository/db"
+	"github.com/stretchr/testify/assert"
+)
+
+var testStoreProducts = map[string]model.StoreProduct{
+	"gold_monthly": {Tier: model.TierGold, Plan: model.PlanMonthly},
+}
+
+func TestVerifyReceipt(t *testing.T) {
+	var (
+		now       = time.Now()
+		periodEnd = now.AddDate(0, 1, 0)
+	)
+
+	verify := func(productID string) func(ctx context.Context, store, receipt string) (receipt.Purchase, error) {
+		return func(ctx context.Context, store, r string) (receipt.Purchase, error) {
+			return receipt.Purchase{
+				ID:          "purchase_1",
+				Store:       store,
+				ProductID:   productID,
+				Status:      receipt.StatusActive,
+				PurchasedAt: now,
+				ExpiresAt:   periodEnd,
+				AutoRenew:   true,
+			}, nil
+		}
+	}
+	owner := func(userID int64) func(ctx context.Context, providerSubscriptionID string) (*model.Subscription, error) {
+		return func(ctx context.Context, providerSubscriptionID string) (*model.Subscription, error) {
+			return &model.Subscription{
+				ID:                     1,
+				UserID:                 userID,
+				Tier:                   model.TierGold,
+				Plan:                   model.PlanMonthly,
+				Status:                 model.SubscriptionStatusActive,
+				CurrentPeriodStart:     now,
+				CurrentPeriodEnd:       periodEnd,
+				ProviderSubscriptionID: providerSubscriptionID,
+				Store:                  receipt.StoreAppStore,
+			}, nil
+		}
+	}
+
+	tests := []struct {
+		name       string
+		receipts   *receipt.VerifierMock
+		repoDB     *db.RepoMock
+		want       model.Subscription
+		wantErr    error
+		wantEvents int
+		wantLedger []string
+	}{
+		{
+			name:     "case success",
+			receipts: &receipt.VerifierMock{VerifyReceiptFunc: verify("gold_monthly")},
+			repoDB: &db.RepoMock{
+				GetSubscriptionByProviderIDFunc: func(ctx context.Context, providerSubscriptionID string) (*model.Subscription, error) {
+					assert.Equal(t, "app_store:purchase_1", providerSubscriptionID)
+					return nil, model.NotFoundErr
+				},
+				InsertSubscriptionFunc: func(ctx context.Context, req model.Subscription) (int64, error) {
+					return 1, nil
+				},
+			},
+			want: model.Subscription{
+				ID:                     1,
+				UserID:                 1,
+				Tier:                   model.TierGold,
+				Plan:                   model.PlanMonthly,
+				Status:                 model.SubscriptionStatusActive,
+				CurrentPeriodStart:     now,
+				CurrentPeriodEnd:       periodEnd,
+				AutoRenew:              true,
+				ProviderSubscriptionID: "app_store:purchase_1",
+				Store:                  receipt.StoreAppStore,
+			},
+			wantEvents: 1,
+			wantLedger: []string{model.LedgerKindCharge},
+		},
+		{
+			name:     "case success restored",
+			receipts: &receipt.VerifierMock{VerifyReceiptFunc: verify("gold_monthly")},
+			repoDB: &db.RepoMock{
+				GetSubscriptionByProviderIDFunc: owner(1),
+				UpdateSubscriptionFunc: func(ctx context.Context, req model.Subscription) error {
+					return nil
+				},
+			},
+			want: model.Subscription{
+				ID:                     1,
+				UserID:                 1,
+				Tier:                   model.TierGold,
+				Plan:                   model.PlanMonthly,
+				Status:                 model.SubscriptionStatusActive,
+				CurrentPeriodStart:     now,
+				CurrentPeriodEnd:       periodEnd,
+				AutoRenew:              true,
+				ProviderSubscriptionID: "app_store:purchase_1",
+				Store:                  receipt.StoreAppStore,
+			},
+		},
+		{
+			name:     "case error receipt of another user",
+			receipts: &receipt.VerifierMock{VerifyReceiptFunc: verify("gold_monthly")},
+			repoDB: &db.RepoMock{
+				GetSubscriptionByProviderIDFunc: owner(2),
+			},
+			wantErr: model.ReceiptInUseErr,
+		},
+		{
+			name: "case error invalid receipt",
+			receipts: &receipt.VerifierMock{
+				VerifyReceiptFunc: func(ctx context.Context, store, r string) (receipt.Purchase, error) {
+					return receipt.Purchase{}, receipt.ErrInvalidReceipt
+				},
+			},
+			repoDB:  &db.RepoMock{},
+			wantErr: model.InvalidReceiptErr,
+		},
+		{
+			name:     "case error unknown product",
+			receipts: &receipt.VerifierMock{VerifyReceiptFunc: verify("platinum_monthly")},
+			repoDB: &db.RepoMock{
+				GetSubscriptionByProviderIDFunc: func(ctx context.Context, providerSubscriptionID string) (*model.Subscription, error) {
+					return nil, model.NotFoundErr
+				},
+			},
+			wantErr: model.InvalidPlanErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoCache := &cache.RepoMock{
+				PublishEventFunc: func(ctx context.Context, event model.Event) error {
+					return nil
+				},
+			}
+			var ledger []string
+			tt.repoDB.InsertLedgerEntryFunc = func(ctx context.Context, entry model.LedgerEntry, invoice *model.Invoice) (bool, error) {
+				ledger = append(ledger, entry.Kind)
+				return true, nil
+			}
+			tt.repoDB.GetLastLedgerEntryFunc = func(ctx context.Context, subscriptionID int64, kind string) (*model.LedgerEntry, error) {
+				return &model.LedgerEntry{ID: 1, SubscriptionID: subscriptionID, Kind: kind, Amount: 999, Currency: "usd"}, nil
+			}
+			u := &usecase{
+				RepoDB:    tt.repoDB,
+				RepoCache: repoCache,
+				Receipts:  tt.receipts,
+				Config:    Config{StoreProducts: testStoreProducts},
+			}
+			got, gotErr := u.VerifyReceipt(context.Background(), model.ReceiptRequest{UserID: 1, Store: receipt.StoreAppStore, Receipt: "receipt_1"})
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Equal(t, tt.want, got)
+			assert.Len(t, repoCache.PublishEventCalls(), tt.wantEvents)
+			assert.Equal(t, tt.wantLedger, ledger)
+		})
+	}
+}
+
+func TestHandleStoreNotification(t *testing.T) {
+	var (
+		now       = time.Now()
+		periodEnd = now.Add(time.Hour)
+	)
+
+	parse := func(notificationType string) func(ctx context.Context, store string, payload []byte, header http.Header) (receipt.Notification, error) {
+		return func(ctx context.Context, store string, payload []byte, header http.Header) (receipt.Notification, error) {
+			return receipt.Notification{ID: "notification_1", Type: notificationType, PurchaseID: "purchase_1"}, nil
+		}
+	}
+	fetch := func(status string, expiresAt time.Time) func(ctx context.Context, store, purchaseID string) (receipt.Purchase, error) {
+		return func(ctx context.Context, store, purchaseID string) (receipt.Purchase, error) {
+			return receipt.Purchase{
+				ID:          purchaseID,
+				Store:       store,
+				ProductID:   "gold_monthly",
+				Status:      status,
+				PurchasedAt: now.AddDate(0, -1, 0),
+				ExpiresAt:   expiresAt,
+				AutoRenew:   status == receipt.StatusActive || status == receipt.StatusGracePeriod,
+			}, nil
+		}
+	}
+	local := func(ctx context.Context, providerSubscriptionID string) (*model.Subscription, error) {
+		return &model.Subscription{
+			ID:                     1,
+			UserID:                 1,
+			Tier:                   model.TierGold,
+			Plan:                   model.PlanMonthly,
+			Status:                 model.SubscriptionStatusActive,
+			CurrentPeriodStart:     now.AddDate(0, -1, 0),
+			CurrentPeriodEnd:       periodEnd,
+			AutoRenew:              true,
+			ProviderSubscriptionID: providerSubscriptionID,
+			Store:                  receipt.StoreAppStore,
+		}, nil
+	}
+	inserted := func(ctx context.Context, req model.PaymentEvent) (bool, error) {
+		assert.Equal(t, "app_store:notification_1", req.ID)
+		assert.Equal(t, "app_store:purchase_1", req.SubscriptionID)
+		return true, nil
+	}
+
+	tests := []struct {
+		name         string
+		receipts     *receipt.VerifierMock
+		repoDB       *db.RepoMock
+		wantErr      error
+		wantUpdate   *model.Subscription
+		wantEvents   int
+		wantLedger   []string
+		wantDeletion bool
+	}{
+		{
+			name: "case success renewed",
+			receipts: &receipt.VerifierMock{
+				ParseNotificationFunc: parse(receipt.NotificationRenewed),
+				FetchPurchaseFunc:     fetch(receipt.StatusActive, periodEnd.AddDate(0, 1, 0)),
+			},
+			repoDB: &db.RepoMock{
+				InsertPaymentEventFunc:          inserted,
+				GetSubscriptionByProviderIDFunc: local,
+				UpdateSubscriptionFunc: func(ctx context.Context, req model.Subscription) error {
+					return nil
+				},
+			},
+			wantUpdate: &model.Subscription{
+				Status:             model.SubscriptionStatusActive,
+				CurrentPeriodStart: periodEnd,
+				CurrentPeriodEnd:   periodEnd.AddDate(0, 1, 0),
+				AutoRenew:          true,
+			},
+			wantLedger: []string{model.LedgerKindCharge},
+		},
+		{
+			name: "case success grace period",
+			receipts: &receipt.VerifierMock{
+				ParseNotificationFunc: parse(receipt.NotificationGracePeriod),
+				FetchPurchaseFunc:     fetch(receipt.StatusGracePeriod, periodEnd.AddDate(0, 0, 3)),
+			},
+			repoDB: &db.RepoMock{
+				InsertPaymentEventFunc:          inserted,
+				GetSubscriptionByProviderIDFunc: local,
+				UpdateSubscriptionFunc: func(ctx context.Context, req model.Subscription) error {
+					return nil
+				},
+			},
+			wantUpdate: &model.Subscription{
+				Status:             model.SubscriptionStatusActive,
+				CurrentPeriodStart: now.AddDate(0, -1, 0),
+				CurrentPeriodEnd:   periodEnd.AddDate(0, 0, 3),
+				AutoRenew:          true,
+			},
+		},
+		{
+			name: "case success billing retry",
+			receipts: &receipt.VerifierMock{
+				ParseNotificationFunc: parse(receipt.NotificationBillingRetry),
+				FetchPurchaseFunc:     fetch(receipt.StatusBillingRetry, periodEnd),
+			},
+			repoDB: &db.RepoMock{
+				InsertPaymentEventFunc:          inserted,
+				GetSubscriptionByProviderIDFunc: local,
+				UpdateSubscriptionFunc: func(ctx context.Context, req model.Subscription) error {
+					return nil
+				},
+			},
+			wantUpdate: &model.Subscription{
+				Status:             model.SubscriptionStatusPastDue,
+				CurrentPeriodStart: now.AddDate(0, -1, 0),
+				CurrentPeriodEnd:   periodEnd,
+				CancelAtPeriodEnd:  true,
+			},
+			wantEvents: 1,
+		},
+		{
+			name: "case success refunded",
+			receipts: &receipt.VerifierMock{
+				ParseNotificationFunc: parse(receipt.NotificationRefunded),
+				FetchPurchaseFunc:     fetch(receipt.StatusRefunded, periodEnd),
+			},
+			repoDB: &db.RepoMock{
+				InsertPaymentEventFunc:          inserted,
+				GetSubscriptionByProviderIDFunc: local,
+				UpdateSubscriptionFunc: func(ctx context.Context, req model.Subscription) error {
+					assert.WithinDuration(t, time.Now(), req.CurrentPeriodEnd, time.Minute)
+					return nil
+				},
+			},
+			wantEvents: 1,
+			wantLedger: []string{model.LedgerKindRefund},
+		},
+		{
+			name: "case success handled before",
+			receipts: &receipt.VerifierMock{
+				ParseNotificationFunc: parse(receipt.NotificationRenewed),
+			},
+			repoDB: &db.RepoMock{
+				InsertPaymentEventFunc: func(ctx context.Context, req model.PaymentEvent) (bool, error) {
+					return false, nil
+				},
+			},
+		},
+		{
+			name: "case success receipt not sent yet",
+			receipts: &receipt.VerifierMock{
+				ParseNotificationFunc: parse(receipt.NotificationRenewed),
+			},
+			repoDB: &db.RepoMock{
+				InsertPaymentEventFunc: inserted,
+				GetSubscriptionByProviderIDFunc: func(ctx context.Context, providerSubscriptionID string) (*model.Subscription, error) {
+					return nil, model.NotFoundErr
+				},
+			},
+		},
+		{
+			name: "case success ignored type",
+			receipts: &receipt.VerifierMock{
+				ParseNotificationFunc: parse("price_increase"),
+			},
+			repoDB: &db.RepoMock{},
+		},
+		{
+			name: "case error invalid signature",
+			receipts: &receipt.VerifierMock{
+				ParseNotificationFunc: func(ctx context.Context, store string, payload []byte, header http.Header) (receipt.Notification, error) {
+					return receipt.Notification{}, receipt.ErrInvalidSignature
+				},
+			},
+			repoDB:  &db.RepoMock{},
+			wantErr: model.InvalidSignatureErr,
+		},
+		{
+			name: "case error fetching purchase",
+			receipts: &receipt.VerifierMock{
+				ParseNotificationFunc: parse(receipt.NotificationRenewed),
+				FetchPurchaseFunc: func(ctx context.Context, store, purchaseID string) (receipt.Purchase, error) {
+					return receipt.Purchase{}, errors.New("err")
+				},
+			},
+			repoDB: &db.RepoMock{
+				InsertPaymentEventFunc:          inserted,
+				GetSubscriptionByProviderIDFunc: local,
+				DeletePaymentEventFunc: func(ctx context.Context, eventID string) error {
+					assert.Equal(t, "app_store:notification_1", eventID)
+					return nil
+				},
+			},
+			wantErr:      errors.New("err"),
+			wantDeletion: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoCache := &cache.RepoMock{
+				PublishEventFunc: func(ctx context.Context, event model.Event) error {
+					return nil
+				},
+			}
+			var ledger []string
+			tt.repoDB.InsertLedgerEntryFunc = func(ctx context.Context, entry model.LedgerEntry, invoice *model.Invoice) (bool, error) {
+				ledger = append(ledger, entry.Kind)
+				return true, nil
+			}
+			tt.repoDB.GetLastLedgerEntryFunc = func(ctx context.Context, subscriptionID int64, kind string) (*model.LedgerEntry, error) {
+				return &model.LedgerEntry{ID: 1, SubscriptionID: subscriptionID, Kind: kind, Amount: 999, Currency: "usd"}, nil
+			}
+			u := &usecase{
+				RepoDB:    tt.repoDB,
+				RepoCache: repoCache,
+				Receipts:  tt.receipts,
+				Config:    Config{StoreProducts: testStoreProducts},
+			}
+			gotErr := u.HandleStoreNotification(context.Background(), model.StoreNotificationRequest{Store: receipt.StoreAppStore})
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Len(t, repoCache.PublishEventCalls(), tt.wantEvents)
+			assert.Equal(t, tt.wantDeletion, len(tt.repoDB.DeletePaymentEventCalls()) == 1)
+			assert.Equal(t, tt.wantLedger, ledger)
+
+			if tt.wantUpdate != nil {
+				calls := tt.repoDB.UpdateSubscriptionCalls()
+				if assert.Len(t, calls, 1) {
+					got := calls[0].Req
+					assert.Equal(t, tt.wantUpdate.Status, got.Status)
+					assert.Equal(t, tt.wantUpdate.CurrentPeriodStart, got.CurrentPeriodStart)
+					assert.Equal(t, tt.wantUpdate.CurrentPeriodEnd, got.CurrentPeriodEnd)
+					assert.Equal(t, tt.wantUpdate.AutoRenew, got.AutoRenew)
+					assert.Equal(t, tt.wantUpdate.CancelAtPeriodEnd, got.CancelAtPeriodEnd)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/util"
+)
+
+// GetReferral returns the referral link of the user, handing out a code on
+// first use, and how many users signed up through it
+func (s *usecase) GetReferral(ctx context.Context, userID int64) (res model.ReferralResponse, err error) {
+	token, err := util.GenerateToken(referralCodeBytes)
+	if err != nil {
+		log.Println("error generating referral code")
+		return
+	}
+
+	res.Code, err = s.RepoDB.GetOrCreateReferralCode(ctx, userID, normalizeCode(token))
+	if err != nil {
+		log.Println("error when fetching referral code from db")
+		return
+	}
+	res.Link = "/user/sign-up?referral_code=" + res.Code
+
+	res.Referred, res.Rewarded, err = s.RepoDB.CountReferrals(ctx, userID)
+	if err != nil {
+		log.Println("error when counting referrals from db")
+		return
+	}
+
+	return
+}
+
+// RedeemReferral records who referred the user. Only users who have never
+// verified an email can be referred, so existing users cannot claim the
+// reward, not even by changing their email
+func (s *usecase) RedeemReferral(ctx context.Context, req model.RedeemRequest) (err error) {
+	user, err := s.RepoDB.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	if user.VerifiedAt != nil {
+		err = model.ReferralClosedErr
+		return
+	}
+
+	referrerID, err := s.RepoDB.GetReferrerByCode(ctx, normalizeCode(req.Code))
+	if err != nil {
+		log.Println("error when fetching referral code from db")
+		return
+	}
+
+	return s.refer(ctx, referrerID, req.UserID)
+}
+
+func (s *usecase) refer(ctx context.Context, referrerID, refereeID int64) (err error) {
+	if referrerID == refereeID {
+		err = model.SelfReferralErr
+		return
+	}
+
+	inserted, err := s.RepoDB.InsertReferral(ctx, model.Referral{
+		ReferrerID: referrerID,
+		RefereeID:  refereeID,
+	})
+	if err != nil {
+		log.Println("error when inserting referral to db")
+		return
+	} else if !inserted {
+		err = model.AlreadyRedeemedErr
+		return
+	}
+
+	return
+}
+
+// rewardReferral grants the premium days to both users once the referred
+// user verified their email
+func (s *usecase) rewardReferral(ctx context.Context, refereeID int64) (err error) {
+	if s.Config.ReferralPremiumDays <= 0 {
+		return
+	}
+
+	referral, err := s.RepoDB.GetReferral(ctx, refereeID)
+	if err == model.NotFoundErr {
+		err = nil
+		return
+	} else if err != nil {
+		log.Println("error when fetching referral from db")
+		return
+	} else if referral.RewardedAt != nil {
+		return
+	}
+
+	// Verifying again after changing the email must not reward twice
+	rewarded, err := s.RepoDB.RewardReferral(ctx, refereeID, time.Now())
+	if err != nil {
+		log.Println("error when updating referral in db")
+		return
+	} else if !rewarded {
+		return
+	}
+
+	for _, userID := range []int64{referral.ReferrerID, referral.RefereeID} {
+		_, err = s.grantPremiumDays(ctx, userID, model.TierPlus, s.Config.ReferralPremiumDays)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/repository/cache"
+	"github.com/egnptr/dating-app/repository/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedeemReferral(t *testing.T) {
+	verifiedAt := time.Now().Add(-time.Hour)
+	user := func(verifiedAt *time.Time) func(ctx context.Context, userID int64) (*model.User, error) {
+		return func(ctx context.Context, userID int64) (*model.User, error) {
+			return &model.User{UserID: userID, EmailVerified: verifiedAt != nil, VerifiedAt: verifiedAt}, nil
+		}
+	}
+	referrer := func(referrerID int64) func(ctx context.Context, code string) (int64, error) {
+		return func(ctx context.Context, code string) (int64, error) {
+			assert.Equal(t, "ABC123", code)
+			return referrerID, nil
+		}
+	}
+
+	tests := []struct {
+		name    string
+		repoDB  *db.RepoMock
+		wantErr error
+	}{
+		{
+			name: "case success",
+			repoDB: &db.RepoMock{
+				GetUserByIDFunc:       user(nil),
+				GetReferrerByCodeFunc: referrer(2),
+				InsertReferralFunc: func(ctx context.Context, req model.Referral) (bool, error) {
+					assert.Equal(t, model.Referral{ReferrerID: 2, RefereeID: 1}, req)
+					return true, nil
+				},
+			},
+		},
+		{
+			name: "case error verified already",
+			repoDB: &db.RepoMock{
+				GetUserByIDFunc: user(&verifiedAt),
+			},
+			wantErr: model.ReferralClosedErr,
+		},
+		{
+			name: "case error verified before changing email",
+			repoDB: &db.RepoMock{
+				GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+					return &model.User{UserID: userID, VerifiedAt: &verifiedAt}, nil
+				},
+			},
+			wantErr: model.ReferralClosedErr,
+		},
+		{
+			name: "case error own code",
+			repoDB: &db.RepoMock{
+				GetUserByIDFunc:       user(nil),
+				GetReferrerByCodeFunc: referrer(1),
+			},
+			wantErr: model.SelfReferralErr,
+		},
+		{
+			name: "case error referred before",
+			repoDB: &db.RepoMock{
+				GetUserByIDFunc:       user(nil),
+				GetReferrerByCodeFunc: referrer(2),
+				InsertReferralFunc: func(ctx context.Context, req model.Referral) (bool, error) {
+					return false, nil
+				},
+			},
+			wantErr: model.AlreadyRedeemedErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.repoDB,
+			}
+			gotErr := u.RedeemReferral(context.Background(), model.RedeemRequest{UserID: 1, Code: "abc123"})
+			assert.Equal(t, tt.wantErr, gotErr)
+		})
+	}
+}
+
+func TestGetReferral(t *testing.T) {
+	u := &usecase{
+		RepoDB: &db.RepoMock{
+			GetOrCreateReferralCodeFunc: func(ctx context.Context, userID int64, code string) (string, error) {
+				assert.NotEmpty(t, code)
+				return "ABC123", nil
+			},
+			CountReferralsFunc: func(ctx context.Context, referrerID int64) (int64, int64, error) {
+				return 3, 1, nil
+			},
+		},
+	}
+
+	got, err := u.GetReferral(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, model.ReferralResponse{
+		Code:     "ABC123",
+		Link:     "/user/sign-up?referral_code=ABC123",
+		Referred: 3,
+		Rewarded: 1,
+	}, got)
+}
+
+func TestRewardReferral(t *testing.T) {
+	rewardedAt := time.Now()
+	referral := func(rewardedAt *time.Time) func(ctx context.Context, refereeID int64) (*model.Referral, error) {
+		return func(ctx context.Context, refereeID int64) (*model.Referral, error) {
+			return &model.Referral{ReferrerID: 2, RefereeID: refereeID, RewardedAt: rewardedAt}, nil
+		}
+	}
+
+	tests := []struct {
+		name        string
+		repoDB      *db.RepoMock
+		days        int
+		wantGranted []int64
+	}{
+		{
+			name: "case success",
+			repoDB: &db.RepoMock{
+				GetReferralFunc: referral(nil),
+				RewardReferralFunc: func(ctx context.Context, refereeID int64, at time.Time) (bool, error) {
+					return true, nil
+				},
+				GetPremiumEndFunc: func(ctx context.Context, userID int64, at time.Time) (time.Time, error) {
+					return time.Time{}, model.NotFoundErr
+				},
+				InsertSubscriptionFunc: func(ctx context.Context, req model.Subscription) (int64, error) {
+					assert.Equal(t, model.PlanPromo, req.Plan)
+					assert.Equal(t, req.CurrentPeriodStart.AddDate(0, 0, 7), req.CurrentPeriodEnd)
+					return 1, nil
+				},
+			},
+			days:        7,
+			wantGranted: []int64{2, 1},
+		},
+		{
+			name: "case success rewarded before",
+			repoDB: &db.RepoMock{
+				GetReferralFunc: referral(&rewardedAt),
+			},
+			days: 7,
+		},
+		{
+			name: "case success not referred",
+			repoDB: &db.RepoMock{
+				GetReferralFunc: func(ctx context.Context, refereeID int64) (*model.Referral, error) {
+					return nil, model.NotFoundErr
+				},
+			},
+			days: 7,
+		},
+		{
+			name:   "case success rewards off",
+			repoDB: &db.RepoMock{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.repoDB,
+				RepoCache: &cache.RepoMock{
+					PublishEventFunc: func(ctx context.Context, event model.Event) error {
+						return nil
+					},
+				},
+				Config: Config{ReferralPremiumDays: tt.days},
+			}
+			assert.NoError(t, u.rewardReferral(context.Background(), 1))
+
+			var gotGranted []int64
+			for _, call := range tt.repoDB.InsertSubscriptionCalls() {
+				gotGranted = append(gotGranted, call.Req.UserID)
+			}
+			assert.Equal(t, tt.wantGranted, gotGranted)
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/egnptr/dating-app/model"
+)
+
+// ReportUser files a report and hides the reported profile from discovery
+// once enough users reported it, until moderators review the reports
+func (s *usecase) ReportUser(ctx context.Context, req model.ReportRequest) (res model.Report, err error) {
+	if req.UserID == req.ReportedUserID {
+		err = model.SelfReportErr
+		return
+	}
+
+	if !isReportReason(req.Reason) || len([]rune(req.Detail)) > reportDetailMaxLength {
+		err = model.InvalidReportErr
+		return
+	}
+
+	_, err = s.RepoDB.GetUserByID(ctx, req.ReportedUserID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	res = model.Report{
+		ReporterID: req.UserID,
+		ReportedID: req.ReportedUserID,
+		Reason:     req.Reason,
+		Detail:     req.Detail,
+		Status:     model.ReportStatusOpen,
+	}
+	res.ID, err = s.RepoDB.InsertReport(ctx, res)
+	if err != nil {
+		log.Println("error when inserting report to db")
+		return
+	}
+
+	err = s.updateHidden(ctx, req.ReportedUserID)
+	return
+}
+
+func (s *usecase) GetReports(ctx context.Context, req model.GetReportsRequest) (res []model.Report, err error) {
+	if req.Status == "" {
+		req.Status = model.ReportStatusOpen
+	}
+	if req.Limit <= 0 || req.Limit > reportsMaxLimit {
+		req.Limit = reportsDefaultLimit
+	}
+
+	res, err = s.RepoDB.GetReports(ctx, req.Status, req.Limit)
+	if err != nil {
+		log.Println("error when fetching reports from db")
+		return
+	}
+
+	return
+}
+
+// ClaimReport assigns an open report to the moderator, escalated reports can
+// only be claimed by admins
+func (s *usecase) ClaimReport(ctx context.Context, req model.ReviewReportRequest) (err error) {
+	report, err := s.RepoDB.GetReport(ctx, req.ReportID)
+	if err != nil {
+		log.Println("error when fetching report from db")
+		return
+	}
+
+	if report.Status != model.ReportStatusOpen && report.Status != model.ReportStatusEscalated {
+		err = model.ReportConflictErr
+		return
+	}
+
+	if report.Status == model.ReportStatusEscalated {
+		var isAdmin bool
+		isAdmin, err = s.HasRole(ctx, req.ModeratorID, model.RoleAdmin)
+		if err != nil {
+			return
+		}
+
+		if !isAdmin {
+			err = model.ForbiddenErr
+			return
+		}
+	}
+
+	fromStatus := report.Status
+	report.Status = model.ReportStatusClaimed
+	report.AssigneeID = req.ModeratorID
+	err = s.RepoDB.UpdateReportStatus(ctx, *report, fromStatus)
+	if err != nil {
+		log.Println("error when updating report to db")
+		return
+	}
+
+	return s.audit(ctx, req.ModeratorID, report.ReportedID, model.AuditActionReportClaimed, fmt.Sprintf("report %d", report.ID))
+}
+
+// ResolveReport closes a report claimed by the moderator. The profile is
+// shown in discovery again once it no longer has enough pending reports.
+func (s *usecase) ResolveReport(ctx context.Context, req model.ReviewReportRequest) (err error) {
+	report, err := s.claimedReport(ctx, req)
+	if err != nil {
+		return
+	}
+
+	report.Status = model.ReportStatusResolved
+	report.Resolution = req.Note
+	err = s.RepoDB.UpdateReportStatus(ctx, *report, model.ReportStatusClaimed)
+	if err != nil {
+		log.Println("error when updating report to db")
+		return
+	}
+
+	err = s.updateHidden(ctx, report.ReportedID)
+	if err != nil {
+		return
+	}
+
+	return s.audit(ctx, req.ModeratorID, report.ReportedID, model.AuditActionReportResolved, fmt.Sprintf("report %d: %s", report.ID, req.Note))
+}
+
+// EscalateReport hands a report claimed by the moderator back to the queue
+// as escalated, for someone more senior to claim
+func (s *usecase) EscalateReport(ctx context.Context, req model.ReviewReportRequest) (err error) {
+	report, err := s.claimedReport(ctx, req)
+	if err != nil {
+		return
+	}
+
+	report.Status = model.ReportStatusEscalated
+	report.AssigneeID = 0
+	report.Resolution = req.Note
+	err = s.RepoDB.UpdateReportStatus(ctx, *report, model.ReportStatusClaimed)
+	if err != nil {
+		log.Println("error when updating report to db")
+		return
+	}
+
+	return s.audit(ctx, req.ModeratorID, report.ReportedID, model.AuditActionReportEscalated, fmt.Sprintf("report %d: %s", report.ID, req.Note))
+}
+
+func (s *usecase) claimedReport(ctx context.Context, req model.ReviewReportRequest) (report *model.Report, err error) {
+	report, err = s.RepoDB.GetReport(ctx, req.ReportID)
+	if err != nil {
+		log.Println("error when fetching report from db")
+		return
+	}
+
+	if report.Status != model.ReportStatusClaimed || report.AssigneeID != req.ModeratorID {
+		err = model.ReportConflictErr
+		return
+	}
+
+	return
+}
+
+// updateHidden hides the user from discovery while the user has been
+// reported by at least the configured number of users
+func (s *usecase) updateHidden(ctx context.Context, userID int64) (err error) {
+	if s.Config.ReportHideThreshold <= 0 {
+		return
+	}
+
+	reporters, err := s.RepoDB.CountPendingReporters(ctx, userID)
+	if err != nil {
+		log.Println("error when counting reports from db")
+		return
+	}
+
+	err = s.RepoDB.UpdateHidden(ctx, userID, reporters >= s.Config.ReportHideThreshold)
+	if err != nil {
+		log.Println("error when updating hidden status to db")
+		return
+	}
+
+	return
+}
+
+func isReportReason(reason string) bool {
+	for _, r := range model.ReportReasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
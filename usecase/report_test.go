@@ -0,0 +1,419 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/repository/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportUser(t *testing.T) {
+	users := func(ctx context.Context, userID int64) (*model.User, error) {
+		return &model.User{UserID: userID}, nil
+	}
+	insertReport := func(ctx context.Context, req model.Report) (int64, error) {
+		return 10, nil
+	}
+	updateHidden := func(ctx context.Context, userID int64, hidden bool) error {
+		return nil
+	}
+
+	type args struct {
+		req model.ReportRequest
+	}
+	tests := []struct {
+		name       string
+		repoDB     *db.RepoMock
+		args       args
+		want       model.Report
+		wantHidden []bool
+		wantErr    error
+	}{
+		{
+			name: "case success below threshold",
+			repoDB: &db.RepoMock{
+				GetUserByIDFunc:  users,
+				InsertReportFunc: insertReport,
+				CountPendingReportersFunc: func(ctx context.Context, userID int64) (int, error) {
+					return 2, nil
+				},
+				UpdateHiddenFunc: updateHidden,
+			},
+			args: args{
+				req: model.ReportRequest{
+					UserID:         1,
+					ReportedUserID: 2,
+					Reason:         model.ReportReasonSpam,
+				},
+			},
+			want: model.Report{
+				ID:         10,
+				ReporterID: 1,
+				ReportedID: 2,
+				Reason:     model.ReportReasonSpam,
+				Status:     model.ReportStatusOpen,
+			},
+			wantHidden: []bool{false},
+		},
+		{
+			name: "case success hides profile at threshold",
+			repoDB: &db.RepoMock{
+				GetUserByIDFunc:  users,
+				InsertReportFunc: insertReport,
+				CountPendingReportersFunc: func(ctx context.Context, userID int64) (int, error) {
+					return 3, nil
+				},
+				UpdateHiddenFunc: updateHidden,
+			},
+			args: args{
+				req: model.ReportRequest{
+					UserID:         1,
+					ReportedUserID: 2,
+					Reason:         model.ReportReasonHarassment,
+					Detail:         "rude messages",
+				},
+			},
+			want: model.Report{
+				ID:         10,
+				ReporterID: 1,
+				ReportedID: 2,
+				Reason:     model.ReportReasonHarassment,
+				Detail:     "rude messages",
+				Status:     model.ReportStatusOpen,
+			},
+			wantHidden: []bool{true},
+		},
+		{
+			name:   "case error self report",
+			repoDB: &db.RepoMock{},
+			args: args{
+				req: model.ReportRequest{
+					UserID:         1,
+					ReportedUserID: 1,
+					Reason:         model.ReportReasonSpam,
+				},
+			},
+			wantErr: model.SelfReportErr,
+		},
+		{
+			name:   "case error unknown reason",
+			repoDB: &db.RepoMock{},
+			args: args{
+				req: model.ReportRequest{
+					UserID:         1,
+					ReportedUserID: 2,
+					Reason:         "boring",
+				},
+			},
+			wantErr: model.InvalidReportErr,
+		},
+		{
+			name:   "case error detail too long",
+			repoDB: &db.RepoMock{},
+			args: args{
+				req: model.ReportRequest{
+					UserID:         1,
+					ReportedUserID: 2,
+					Reason:         model.ReportReasonOther,
+					Detail:         strings.Repeat("a", reportDetailMaxLength+1),
+				},
+			},
+			wantErr: model.InvalidReportErr,
+		},
+		{
+			name: "case error user not found",
+			repoDB: &db.RepoMock{
+				GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+					return nil, model.NotFoundErr
+				},
+			},
+			args: args{
+				req: model.ReportRequest{
+					UserID:         1,
+					ReportedUserID: 2,
+					Reason:         model.ReportReasonSpam,
+				},
+			},
+			wantErr: model.NotFoundErr,
+		},
+		{
+			name: "case error insert report",
+			repoDB: &db.RepoMock{
+				GetUserByIDFunc: users,
+				InsertReportFunc: func(ctx context.Context, req model.Report) (int64, error) {
+					return 0, errors.New("err")
+				},
+			},
+			args: args{
+				req: model.ReportRequest{
+					UserID:         1,
+					ReportedUserID: 2,
+					Reason:         model.ReportReasonSpam,
+				},
+			},
+			want: model.Report{
+				ReporterID: 1,
+				ReportedID: 2,
+				Reason:     model.ReportReasonSpam,
+				Status:     model.ReportStatusOpen,
+			},
+			wantErr: errors.New("err"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.repoDB,
+				Config: Config{ReportHideThreshold: 3},
+			}
+			got, gotErr := u.ReportUser(context.Background(), tt.args.req)
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Equal(t, tt.want, got)
+
+			var hidden []bool
+			for _, call := range tt.repoDB.UpdateHiddenCalls() {
+				hidden = append(hidden, call.Hidden)
+			}
+			assert.Equal(t, tt.wantHidden, hidden)
+		})
+	}
+}
+
+func TestGetReports(t *testing.T) {
+	var gotStatus string
+	var gotLimit int
+	u := &usecase{
+		RepoDB: &db.RepoMock{
+			GetReportsFunc: func(ctx context.Context, status string, limit int) ([]model.Report, error) {
+				gotStatus, gotLimit = status, limit
+				return []model.Report{{ID: 1}}, nil
+			},
+		},
+	}
+
+	got, err := u.GetReports(context.Background(), model.GetReportsRequest{Limit: 1000})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Report{{ID: 1}}, got)
+	assert.Equal(t, model.ReportStatusOpen, gotStatus)
+	assert.Equal(t, reportsDefaultLimit, gotLimit)
+}
+
+func TestClaimReport(t *testing.T) {
+	report := func(status string, assigneeID int64) func(ctx context.Context, reportID int64) (*model.Report, error) {
+		return func(ctx context.Context, reportID int64) (*model.Report, error) {
+			return &model.Report{ID: reportID, Status: status, AssigneeID: assigneeID}, nil
+		}
+	}
+
+	tests := []struct {
+		name    string
+		repoDB  *db.RepoMock
+		want    []model.Report
+		wantErr error
+	}{
+		{
+			name: "case success open",
+			repoDB: &db.RepoMock{
+				GetReportFunc: report(model.ReportStatusOpen, 0),
+				UpdateReportStatusFunc: func(ctx context.Context, req model.Report, fromStatus string) error {
+					assert.Equal(t, model.ReportStatusOpen, fromStatus)
+					return nil
+				},
+				InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+					return nil
+				},
+			},
+			want: []model.Report{{ID: 1, Status: model.ReportStatusClaimed, AssigneeID: 5}},
+		},
+		{
+			name: "case success escalated by admin",
+			repoDB: &db.RepoMock{
+				GetReportFunc: report(model.ReportStatusEscalated, 0),
+				GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+					return &model.User{UserID: userID, Role: model.RoleAdmin}, nil
+				},
+				UpdateReportStatusFunc: func(ctx context.Context, req model.Report, fromStatus string) error {
+					assert.Equal(t, model.ReportStatusEscalated, fromStatus)
+					return nil
+				},
+				InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+					return nil
+				},
+			},
+			want: []model.Report{{ID: 1, Status: model.ReportStatusClaimed, AssigneeID: 5}},
+		},
+		{
+			name: "case error escalated by moderator",
+			repoDB: &db.RepoMock{
+				GetReportFunc: report(model.ReportStatusEscalated, 0),
+				GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+					return &model.User{UserID: userID, Role: model.RoleModerator}, nil
+				},
+			},
+			wantErr: model.ForbiddenErr,
+		},
+		{
+			name: "case error already claimed",
+			repoDB: &db.RepoMock{
+				GetReportFunc: report(model.ReportStatusClaimed, 6),
+			},
+			wantErr: model.ReportConflictErr,
+		},
+		{
+			name: "case error claimed concurrently",
+			repoDB: &db.RepoMock{
+				GetReportFunc: report(model.ReportStatusOpen, 0),
+				UpdateReportStatusFunc: func(ctx context.Context, req model.Report, fromStatus string) error {
+					return model.ReportConflictErr
+				},
+			},
+			want:    []model.Report{{ID: 1, Status: model.ReportStatusClaimed, AssigneeID: 5}},
+			wantErr: model.ReportConflictErr,
+		},
+		{
+			name: "case error not found",
+			repoDB: &db.RepoMock{
+				GetReportFunc: func(ctx context.Context, reportID int64) (*model.Report, error) {
+					return nil, model.NotFoundErr
+				},
+			},
+			wantErr: model.NotFoundErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.repoDB,
+			}
+			gotErr := u.ClaimReport(context.Background(), model.ReviewReportRequest{ModeratorID: 5, ReportID: 1})
+			assert.Equal(t, tt.wantErr, gotErr)
+
+			var updated []model.Report
+			for _, call := range tt.repoDB.UpdateReportStatusCalls() {
+				updated = append(updated, call.Req)
+			}
+			assert.Equal(t, tt.want, updated)
+		})
+	}
+}
+
+func TestResolveReport(t *testing.T) {
+	report := func(status string, assigneeID int64) func(ctx context.Context, reportID int64) (*model.Report, error) {
+		return func(ctx context.Context, reportID int64) (*model.Report, error) {
+			return &model.Report{ID: reportID, ReportedID: 2, Status: status, AssigneeID: assigneeID}, nil
+		}
+	}
+
+	tests := []struct {
+		name       string
+		repoDB     *db.RepoMock
+		wantHidden []bool
+		wantErr    error
+	}{
+		{
+			name: "case success shows profile again",
+			repoDB: &db.RepoMock{
+				GetReportFunc: report(model.ReportStatusClaimed, 5),
+				UpdateReportStatusFunc: func(ctx context.Context, req model.Report, fromStatus string) error {
+					assert.Equal(t, model.ReportStatusResolved, req.Status)
+					assert.Equal(t, "warned", req.Resolution)
+					return nil
+				},
+				CountPendingReportersFunc: func(ctx context.Context, userID int64) (int, error) {
+					return 2, nil
+				},
+				UpdateHiddenFunc: func(ctx context.Context, userID int64, hidden bool) error {
+					return nil
+				},
+				InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+					assert.Equal(t, model.AuditActionReportResolved, req.Action)
+					return nil
+				},
+			},
+			wantHidden: []bool{false},
+		},
+		{
+			name: "case error claimed by someone else",
+			repoDB: &db.RepoMock{
+				GetReportFunc: report(model.ReportStatusClaimed, 6),
+			},
+			wantErr: model.ReportConflictErr,
+		},
+		{
+			name: "case error not claimed",
+			repoDB: &db.RepoMock{
+				GetReportFunc: report(model.ReportStatusOpen, 0),
+			},
+			wantErr: model.ReportConflictErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.repoDB,
+				Config: Config{ReportHideThreshold: 3},
+			}
+			gotErr := u.ResolveReport(context.Background(), model.ReviewReportRequest{ModeratorID: 5, ReportID: 1, Note: "warned"})
+			assert.Equal(t, tt.wantErr, gotErr)
+
+			var hidden []bool
+			for _, call := range tt.repoDB.UpdateHiddenCalls() {
+				hidden = append(hidden, call.Hidden)
+			}
+			assert.Equal(t, tt.wantHidden, hidden)
+		})
+	}
+}
+
+func TestEscalateReport(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoDB  *db.RepoMock
+		want    []model.Report
+		wantErr error
+	}{
+		{
+			name: "case success",
+			repoDB: &db.RepoMock{
+				GetReportFunc: func(ctx context.Context, reportID int64) (*model.Report, error) {
+					return &model.Report{ID: reportID, Status: model.ReportStatusClaimed, AssigneeID: 5}, nil
+				},
+				UpdateReportStatusFunc: func(ctx context.Context, req model.Report, fromStatus string) error {
+					return nil
+				},
+				InsertAuditLogFunc: func(ctx context.Context, req model.AuditLog) error {
+					return nil
+				},
+			},
+			want: []model.Report{{ID: 1, Status: model.ReportStatusEscalated, Resolution: "needs admin"}},
+		},
+		{
+			name: "case error resolved",
+			repoDB: &db.RepoMock{
+				GetReportFunc: func(ctx context.Context, reportID int64) (*model.Report, error) {
+					return &model.Report{ID: reportID, Status: model.ReportStatusResolved, AssigneeID: 5}, nil
+				},
+			},
+			wantErr: model.ReportConflictErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.repoDB,
+			}
+			gotErr := u.EscalateReport(context.Background(), model.ReviewReportRequest{ModeratorID: 5, ReportID: 1, Note: "needs admin"})
+			assert.Equal(t, tt.wantErr, gotErr)
+
+			var updated []model.Report
+			for _, call := range tt.repoDB.UpdateReportStatusCalls() {
+				updated = append(updated, call.Req)
+			}
+			assert.Equal(t, tt.want, updated)
+		})
+	}
+}
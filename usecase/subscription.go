@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/payment"
+)
+
+func (s *usecase) GetSubscription(ctx context.Context, userID int64) (res model.Subscription, err error) {
+	subscription, err := s.RepoDB.GetActiveSubscription(ctx, userID, time.Now())
+	if err != nil {
+		log.Println("error when fetching subscription from db")
+		return
+	}
+
+	res = *subscription
+	return
+}
+
+// subscribe sends the user to pay for the first period of the plan. The
+// subscription only starts once the payment provider says it was paid for
+func (s *usecase) subscribe(ctx context.Context, req model.SubscribeRequest) (res model.SubscribeResponse, err error) {
+	if req.Tier == "" {
+		req.Tier = model.TierPlus
+	}
+	if req.Plan == "" {
+		req.Plan = model.PlanMonthly
+	}
+
+	if _, ok := model.PlanPeriods[req.Plan]; !ok || !model.PaidTiers[req.Tier] {
+		err = model.InvalidPlanErr
+		return
+	}
+
+	// Promo days do not stop the user from paying for a subscription
+	subscription, err := s.RepoDB.GetActiveSubscription(ctx, req.UserID, time.Now())
+	if err == nil && subscription.Plan != model.PlanPromo {
+		// Subscribing again takes back a cancellation
+		if !subscription.CancelAtPeriodEnd {
+			err = model.AlreadySubscribedErr
+			return
+		}
+
+		err = s.setCancelAtPeriodEnd(ctx, subscription, false)
+		return
+	} else if err != nil && err != model.NotFoundErr {
+		log.Println("error when fetching subscription from db")
+		return
+	}
+
+	checkoutReq := payment.CheckoutRequest{
+		UserID: req.UserID,
+		Tier:   req.Tier,
+		Plan:   req.Plan,
+	}
+
+	// The first subscription paid for starts with a trial
+	if s.Config.TrialDays > 0 {
+		paid, errPaid := s.RepoDB.HasPaidSubscription(ctx, req.UserID)
+		if errPaid != nil {
+			err = errPaid
+			log.Println("error when fetching paid subscriptions from db")
+			return
+		}
+		if !paid {
+			checkoutReq.TrialDays = s.Config.TrialDays
+		}
+	}
+
+	discount, err := s.RepoDB.GetPendingDiscount(ctx, req.UserID)
+	if err == nil {
+		if discount.Kind == model.PromoKindPercent {
+			checkoutReq.PercentOff = discount.Value
+		} else {
+			checkoutReq.AmountOff = discount.Value
+		}
+	} else if err != model.NotFoundErr {
+		log.Println("error when fetching pending discount from db")
+		return
+	}
+
+	checkout, err := s.Payments.CreateCheckout(ctx, checkoutReq)
+	if err != nil {
+		log.Println("error when creating checkout at payment provider")
+		return
+	}
+
+	res.CheckoutID = checkout.ID
+	res.CheckoutURL = checkout.URL
+	return
+}
+
+// grantSubscription starts a subscription for the first period of the plan
+// without charging for it. It does not renew
+func (s *usecase) grantSubscription(ctx context.Context, userID int64, tier, plan string) (err error) {
+	now := time.Now()
+	_, err = s.RepoDB.GetActiveSubscription(ctx, userID, now)
+	if err == nil {
+		err = model.AlreadySubscribedErr
+		return
+	} else if err != model.NotFoundErr {
+		log.Println("error when fetching subscription from db")
+		return
+	}
+
+	_, err = s.RepoDB.InsertSubscription(ctx, model.Subscription{
+		UserID:             userID,
+		Tier:               tier,
+		Plan:               plan,
+		Status:             model.SubscriptionStatusActive,
+		CurrentPeriodStart: now,
+		CurrentPeriodEnd:   now.AddDate(0, model.PlanPeriods[plan], 0),
+	})
+	if err != nil {
+		log.Println("error when inserting subscription to db")
+		return
+	}
+
+	s.publishEvent(ctx, userID, model.EventTypePremiumChanged, model.PremiumChangedEvent{
+		IsPremium: true,
+	})
+
+	return
+}
+
+// grantPremiumDays makes the user premium on the tier for the days, on top of
+// the premium they have already. It returns when the days run out
+func (s *usecase) grantPremiumDays(ctx context.Context, userID int64, tier string, days int) (until time.Time, err error) {
+	now := time.Now()
+	start, err := s.RepoDB.GetPremiumEnd(ctx, userID, now)
+	wasPremium := err == nil
+	if err == model.NotFoundErr {
+		start = now
+	} else if err != nil {
+		log.Println("error when fetching premium end from db")
+		return
+	}
+
+	until = start.AddDate(0, 0, days)
+	_, err = s.RepoDB.InsertSubscription(ctx, model.Subscription{
+		UserID:             userID,
+		Tier:               tier,
+		Plan:               model.PlanPromo,
+		Status:             model.SubscriptionStatusActive,
+		CurrentPeriodStart: start,
+		CurrentPeriodEnd:   until,
+	})
+	if err != nil {
+		log.Println("error when inserting subscription to db")
+		return
+	}
+
+	if !wasPremium {
+		s.publishEvent(ctx, userID, model.EventTypePremiumChanged, model.PremiumChangedEvent{
+			IsPremium: true,
+		})
+	}
+
+	return
+}
+
+// cancelSubscription keeps premium until the end of the period and stops the
+// subscription from renewing
+func (s *usecase) cancelSubscription(ctx context.Context, userID int64) (err error) {
+	subscription, err := s.RepoDB.GetActiveSubscription(ctx, userID, time.Now())
+	if err != nil {
+		log.Println("error when fetching subscription from db")
+		return
+	}
+
+	return s.setCancelAtPeriodEnd(ctx, subscription, true)
+}
+
+// setCancelAtPeriodEnd tells the payment provider first so the user is never
+// charged for a subscription the app thinks is canceled. Subscriptions bought
+// in a store can only be canceled there
+func (s *usecase) setCancelAtPeriodEnd(ctx context.Context, subscription *model.Subscription, cancel bool) (err error) {
+	if subscription.Store != "" {
+		err = model.StoreManagedErr
+		return
+	}
+
+	if subscription.ProviderSubscriptionID != "" {
+		err = s.Payments.SetCancelAtPeriodEnd(ctx, subscription.ProviderSubscriptionID, cancel)
+		if err != nil {
+			log.Println("error when updating subscription at payment provider")
+			return
+		}
+	}
+
+	subscription.AutoRenew = !cancel
+	subscription.CancelAtPeriodEnd = cancel
+	err = s.RepoDB.UpdateSubscription(ctx, *subscription)
+	if err != nil {
+		log.Println("error when updating subscription in db")
+		return
+	}
+
+	return
+}
+
+// endSubscription takes premium away right away
+func (s *usecase) endSubscription(ctx context.Context, userID int64) (err error) {
+	now := time.Now()
+	subscription, err := s.RepoDB.GetActiveSubscription(ctx, userID, now)
+	if err == model.NotFoundErr {
+		err = nil
+		return
+	} else if err != nil {
+		log.Println("error when fetching subscription from db")
+		return
+	}
+
+	return s.stopSubscription(ctx, subscription, now)
+}
+
+// stopSubscription cancels the subscription as of now
+func (s *usecase) stopSubscription(ctx context.Context, subscription *model.Subscription, now time.Time) (err error) {
+	wasActive := subscription.IsActive(now)
+
+	subscription.Status = model.SubscriptionStatusCanceled
+	if subscription.CurrentPeriodEnd.After(now) {
+		subscription.CurrentPeriodEnd = now
+	}
+	subscription.AutoRenew = false
+	err = s.RepoDB.UpdateSubscription(ctx, *subscription)
+	if err != nil {
+		log.Println("error when updating subscription in db")
+		return
+	}
+
+	if wasActive {
+		s.publishEvent(ctx, subscription.UserID, model.EventTypePremiumChanged, model.PremiumChangedEvent{
+			IsPremium: false,
+		})
+	}
+
+	return
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/repository/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSubscription(t *testing.T) {
+	tests := []struct {
+		name    string
+		repoDB  db.Repo
+		want    model.Subscription
+		wantErr error
+	}{
+		{
+			name: "case success",
+			repoDB: &db.RepoMock{
+				GetActiveSubscriptionFunc: func(ctx context.Context, userID int64, at time.Time) (*model.Subscription, error) {
+					return &model.Subscription{ID: 1, UserID: userID, Plan: model.PlanMonthly}, nil
+				},
+			},
+			want: model.Subscription{ID: 1, UserID: 1, Plan: model.PlanMonthly},
+		},
+		{
+			name: "case error not subscribed",
+			repoDB: &db.RepoMock{
+				GetActiveSubscriptionFunc: func(ctx context.Context, userID int64, at time.Time) (*model.Subscription, error) {
+					return nil, model.NotFoundErr
+				},
+			},
+			wantErr: model.NotFoundErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB: tt.repoDB,
+			}
+			got, gotErr := u.GetSubscription(context.Background(), 1)
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
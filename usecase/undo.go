@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/entitlement"
+)
+
+// UndoSwipe takes back the most recent swipe while it is within the undo
+// window, as many times a day as the tier of the user allows. A match made by
+// the swipe is dissolved with it, and a super like is given back
+func (s *usecase) UndoSwipe(ctx context.Context, req model.UndoSwipeRequest) (res model.Swipe, err error) {
+	user, err := s.RepoDB.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when fetching user from db")
+		return
+	}
+
+	rewinds := s.entitlements(user).Limit(entitlement.LimitDailyRewinds)
+	if rewinds == 0 {
+		err = model.PremiumRequiredErr
+		return
+	}
+
+	if rewinds != entitlement.Unlimited {
+		used, errCache := s.RepoCache.GetRewindCount(ctx, req.UserID)
+		if errCache != nil {
+			err = errCache
+			log.Println("error when fetching rewind count from cache")
+			return
+		}
+
+		if used >= int64(rewinds) {
+			err = model.RewindLimitErr
+			return
+		}
+	}
+
+	// Swipes are pushed to the front of the list, so it tells which one is
+	// the most recent
+	last, err := s.RepoCache.GetLastRelatedUserCache(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when fetching last swipe from cache")
+		return
+	}
+
+	swipe, err := s.RepoDB.GetSwipe(ctx, req.UserID, last.UserID)
+	if err != nil {
+		log.Println("error when fetching swipe from db")
+		return
+	}
+
+	if time.Since(swipe.SwipedAt) > s.Config.UndoSwipeWindow {
+		err = model.UndoExpiredErr
+		return
+	}
+
+	var matched bool
+	if swipe.SwipeStatus == model.SwipeStatusLike || swipe.SwipeStatus == model.SwipeStatusSuperLike {
+		matched, err = s.RepoDB.IsMatch(ctx, req.UserID, swipe.SwipedID)
+		if err != nil {
+			log.Println("error when checking match from db")
+			return
+		}
+	}
+
+	err = s.RepoCache.RemoveRelatedUserCache(ctx, req.UserID, last)
+	if err != nil {
+		log.Println("error when removing swipe from cache")
+		return
+	}
+
+	// The db keeps one swipe per user, so a user swiped on again is put back
+	// to the swipe before rather than forgotten
+	related, err := s.RepoCache.GetRelatedUserCache(ctx, req.UserID)
+	if err != nil {
+		log.Println("error when fetching related users from cache")
+		return
+	}
+
+	if status, ok := related[swipe.SwipedID]; ok {
+		err = s.RepoDB.UpsertSwipe(ctx, model.SwipeRequest{
+			UserID:       req.UserID,
+			SwipedUserID: swipe.SwipedID,
+			SwipeStatus:  status,
+		})
+		if err != nil {
+			log.Println("error when upserting swipe to db")
+			return
+		}
+
+		// A like taken back to the like before keeps the match
+		if status == model.SwipeStatusLike || status == model.SwipeStatusSuperLike {
+			matched = false
+		}
+	} else {
+		err = s.RepoDB.DeleteSwipe(ctx, req.UserID, swipe.SwipedID)
+		if err != nil {
+			log.Println("error when deleting swipe from db")
+			return
+		}
+	}
+
+	if swipe.SwipeStatus == model.SwipeStatusSuperLike {
+		_, errCache := s.RepoCache.DecrSuperLikeCount(ctx, req.UserID)
+		if errCache != nil {
+			log.Println("error when giving super like back to cache")
+		}
+	}
+
+	if rewinds != entitlement.Unlimited {
+		_, errCache := s.RepoCache.IncrRewindCount(ctx, req.UserID)
+		if errCache != nil {
+			log.Println("error when counting rewind to cache")
+		}
+	}
+
+	if matched {
+		s.publishEvent(ctx, req.UserID, model.EventTypeUnmatched, model.MatchedEvent{UserID: swipe.SwipedID})
+		s.publishEvent(ctx, swipe.SwipedID, model.EventTypeUnmatched, model.MatchedEvent{UserID: req.UserID})
+	}
+
+	res = *swipe
+	return
+}
@@ -0,0 +1,333 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/entitlement"
+	"github.com/egnptr/dating-app/repository/cache"
+	"github.com/egnptr/dating-app/repository/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUndoSwipe(t *testing.T) {
+	premium := func(ctx context.Context, userID int64) (*model.User, error) {
+		return &model.User{UserID: userID, IsPremium: true, Tier: model.TierPlus}, nil
+	}
+	swipedAt := time.Now().Add(-time.Minute)
+	oneRewind := entitlement.Config{
+		model.TierPlus: {Limits: map[string]int{entitlement.LimitDailyRewinds: 1}},
+	}
+
+	type fields struct {
+		repoDB    *db.RepoMock
+		repoCache *cache.RepoMock
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		entitlements  entitlement.Config
+		want          model.Swipe
+		wantEvents    []model.Event
+		wantSuperLike bool
+		wantErr       error
+	}{
+		{
+			name: "case success pass",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: premium,
+					GetSwipeFunc: func(ctx context.Context, swiperID, swipedID int64) (*model.Swipe, error) {
+						return &model.Swipe{SwiperID: swiperID, SwipedID: swipedID, SwipeStatus: model.SwipeStatusPass, SwipedAt: swipedAt}, nil
+					},
+					DeleteSwipeFunc: func(ctx context.Context, swiperID, swipedID int64) error {
+						return nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetLastRelatedUserCacheFunc: func(ctx context.Context, userID int64) (model.UserRelation, error) {
+						return model.UserRelation{UserID: 2, SwipeStatus: model.SwipeStatusPass}, nil
+					},
+					RemoveRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
+						assert.Equal(t, model.UserRelation{UserID: 2, SwipeStatus: model.SwipeStatusPass}, data)
+						return nil
+					},
+					GetRelatedUserCacheFunc: func(ctx context.Context, userID int64) (map[int64]int, error) {
+						return map[int64]int{}, nil
+					},
+				},
+			},
+			want: model.Swipe{SwiperID: 1, SwipedID: 2, SwipeStatus: model.SwipeStatusPass, SwipedAt: swipedAt},
+		},
+		{
+			name: "case success match dissolved",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: premium,
+					GetSwipeFunc: func(ctx context.Context, swiperID, swipedID int64) (*model.Swipe, error) {
+						return &model.Swipe{SwiperID: swiperID, SwipedID: swipedID, SwipeStatus: model.SwipeStatusLike, SwipedAt: swipedAt}, nil
+					},
+					IsMatchFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return true, nil
+					},
+					DeleteSwipeFunc: func(ctx context.Context, swiperID, swipedID int64) error {
+						return nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetLastRelatedUserCacheFunc: func(ctx context.Context, userID int64) (model.UserRelation, error) {
+						return model.UserRelation{UserID: 2, SwipeStatus: model.SwipeStatusLike}, nil
+					},
+					RemoveRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
+						return nil
+					},
+					GetRelatedUserCacheFunc: func(ctx context.Context, userID int64) (map[int64]int, error) {
+						return map[int64]int{}, nil
+					},
+					PublishEventFunc: func(ctx context.Context, event model.Event) error {
+						return nil
+					},
+				},
+			},
+			want: model.Swipe{SwiperID: 1, SwipedID: 2, SwipeStatus: model.SwipeStatusLike, SwipedAt: swipedAt},
+			wantEvents: []model.Event{
+				{UserID: 1, Type: model.EventTypeUnmatched},
+				{UserID: 2, Type: model.EventTypeUnmatched},
+			},
+		},
+		{
+			name: "case success super like given back",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: premium,
+					GetSwipeFunc: func(ctx context.Context, swiperID, swipedID int64) (*model.Swipe, error) {
+						return &model.Swipe{SwiperID: swiperID, SwipedID: swipedID, SwipeStatus: model.SwipeStatusSuperLike, SwipedAt: swipedAt}, nil
+					},
+					IsMatchFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return false, nil
+					},
+					DeleteSwipeFunc: func(ctx context.Context, swiperID, swipedID int64) error {
+						return nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetLastRelatedUserCacheFunc: func(ctx context.Context, userID int64) (model.UserRelation, error) {
+						return model.UserRelation{UserID: 2, SwipeStatus: model.SwipeStatusSuperLike}, nil
+					},
+					RemoveRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
+						return nil
+					},
+					GetRelatedUserCacheFunc: func(ctx context.Context, userID int64) (map[int64]int, error) {
+						return map[int64]int{}, nil
+					},
+					DecrSuperLikeCountFunc: func(ctx context.Context, userID int64) (int64, error) {
+						assert.Equal(t, int64(1), userID)
+						return 0, nil
+					},
+				},
+			},
+			want:          model.Swipe{SwiperID: 1, SwipedID: 2, SwipeStatus: model.SwipeStatusSuperLike, SwipedAt: swipedAt},
+			wantSuperLike: true,
+		},
+		{
+			name: "case success swiped twice",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: premium,
+					GetSwipeFunc: func(ctx context.Context, swiperID, swipedID int64) (*model.Swipe, error) {
+						return &model.Swipe{SwiperID: swiperID, SwipedID: swipedID, SwipeStatus: model.SwipeStatusPass, SwipedAt: swipedAt}, nil
+					},
+					UpsertSwipeFunc: func(ctx context.Context, req model.SwipeRequest) error {
+						assert.Equal(t, model.SwipeRequest{UserID: 1, SwipedUserID: 2, SwipeStatus: model.SwipeStatusLike}, req)
+						return nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetLastRelatedUserCacheFunc: func(ctx context.Context, userID int64) (model.UserRelation, error) {
+						return model.UserRelation{UserID: 2, SwipeStatus: model.SwipeStatusPass}, nil
+					},
+					RemoveRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
+						return nil
+					},
+					GetRelatedUserCacheFunc: func(ctx context.Context, userID int64) (map[int64]int, error) {
+						return map[int64]int{2: model.SwipeStatusLike}, nil
+					},
+				},
+			},
+			want: model.Swipe{SwiperID: 1, SwipedID: 2, SwipeStatus: model.SwipeStatusPass, SwipedAt: swipedAt},
+		},
+		{
+			name: "case success super like swiped twice keeps match",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: premium,
+					GetSwipeFunc: func(ctx context.Context, swiperID, swipedID int64) (*model.Swipe, error) {
+						return &model.Swipe{SwiperID: swiperID, SwipedID: swipedID, SwipeStatus: model.SwipeStatusSuperLike, SwipedAt: swipedAt}, nil
+					},
+					IsMatchFunc: func(ctx context.Context, userID, otherUserID int64) (bool, error) {
+						return true, nil
+					},
+					UpsertSwipeFunc: func(ctx context.Context, req model.SwipeRequest) error {
+						assert.Equal(t, model.SwipeStatusLike, req.SwipeStatus)
+						return nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetLastRelatedUserCacheFunc: func(ctx context.Context, userID int64) (model.UserRelation, error) {
+						return model.UserRelation{UserID: 2, SwipeStatus: model.SwipeStatusSuperLike}, nil
+					},
+					RemoveRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
+						return nil
+					},
+					GetRelatedUserCacheFunc: func(ctx context.Context, userID int64) (map[int64]int, error) {
+						return map[int64]int{2: model.SwipeStatusLike}, nil
+					},
+					DecrSuperLikeCountFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 0, nil
+					},
+				},
+			},
+			want:          model.Swipe{SwiperID: 1, SwipedID: 2, SwipeStatus: model.SwipeStatusSuperLike, SwipedAt: swipedAt},
+			wantSuperLike: true,
+		},
+		{
+			name: "case success counted rewind",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: premium,
+					GetSwipeFunc: func(ctx context.Context, swiperID, swipedID int64) (*model.Swipe, error) {
+						return &model.Swipe{SwiperID: swiperID, SwipedID: swipedID, SwipeStatus: model.SwipeStatusPass, SwipedAt: swipedAt}, nil
+					},
+					DeleteSwipeFunc: func(ctx context.Context, swiperID, swipedID int64) error {
+						return nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetRewindCountFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 0, nil
+					},
+					IncrRewindCountFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 1, nil
+					},
+					GetLastRelatedUserCacheFunc: func(ctx context.Context, userID int64) (model.UserRelation, error) {
+						return model.UserRelation{UserID: 2, SwipeStatus: model.SwipeStatusPass}, nil
+					},
+					RemoveRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
+						return nil
+					},
+					GetRelatedUserCacheFunc: func(ctx context.Context, userID int64) (map[int64]int, error) {
+						return map[int64]int{}, nil
+					},
+				},
+			},
+			entitlements: oneRewind,
+			want:         model.Swipe{SwiperID: 1, SwipedID: 2, SwipeStatus: model.SwipeStatusPass, SwipedAt: swipedAt},
+		},
+		{
+			name: "case error rewind limit",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: premium,
+				},
+				repoCache: &cache.RepoMock{
+					GetRewindCountFunc: func(ctx context.Context, userID int64) (int64, error) {
+						return 1, nil
+					},
+				},
+			},
+			entitlements: oneRewind,
+			wantErr:      model.RewindLimitErr,
+		},
+		{
+			name: "case error not premium",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: func(ctx context.Context, userID int64) (*model.User, error) {
+						return &model.User{UserID: userID}, nil
+					},
+				},
+				repoCache: &cache.RepoMock{},
+			},
+			wantErr: model.PremiumRequiredErr,
+		},
+		{
+			name: "case error no swipes",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: premium,
+				},
+				repoCache: &cache.RepoMock{
+					GetLastRelatedUserCacheFunc: func(ctx context.Context, userID int64) (model.UserRelation, error) {
+						return model.UserRelation{}, model.NotFoundErr
+					},
+				},
+			},
+			wantErr: model.NotFoundErr,
+		},
+		{
+			name: "case error window passed",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: premium,
+					GetSwipeFunc: func(ctx context.Context, swiperID, swipedID int64) (*model.Swipe, error) {
+						return &model.Swipe{SwiperID: swiperID, SwipedID: swipedID, SwipeStatus: model.SwipeStatusPass, SwipedAt: time.Now().Add(-time.Hour)}, nil
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetLastRelatedUserCacheFunc: func(ctx context.Context, userID int64) (model.UserRelation, error) {
+						return model.UserRelation{UserID: 2, SwipeStatus: model.SwipeStatusPass}, nil
+					},
+				},
+			},
+			wantErr: model.UndoExpiredErr,
+		},
+		{
+			name: "case error delete swipe",
+			fields: fields{
+				repoDB: &db.RepoMock{
+					GetUserByIDFunc: premium,
+					GetSwipeFunc: func(ctx context.Context, swiperID, swipedID int64) (*model.Swipe, error) {
+						return &model.Swipe{SwiperID: swiperID, SwipedID: swipedID, SwipeStatus: model.SwipeStatusPass, SwipedAt: swipedAt}, nil
+					},
+					DeleteSwipeFunc: func(ctx context.Context, swiperID, swipedID int64) error {
+						return errors.New("err")
+					},
+				},
+				repoCache: &cache.RepoMock{
+					GetLastRelatedUserCacheFunc: func(ctx context.Context, userID int64) (model.UserRelation, error) {
+						return model.UserRelation{UserID: 2, SwipeStatus: model.SwipeStatusPass}, nil
+					},
+					RemoveRelatedUserCacheFunc: func(ctx context.Context, userID int64, data model.UserRelation) error {
+						return nil
+					},
+					GetRelatedUserCacheFunc: func(ctx context.Context, userID int64) (map[int64]int, error) {
+						return map[int64]int{}, nil
+					},
+				},
+			},
+			wantErr: errors.New("err"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &usecase{
+				RepoDB:    tt.fields.repoDB,
+				RepoCache: tt.fields.repoCache,
+				Config:    Config{UndoSwipeWindow: 5 * time.Minute, Entitlements: tt.entitlements},
+			}
+			got, gotErr := u.UndoSwipe(context.Background(), model.UndoSwipeRequest{UserID: 1})
+			assert.Equal(t, tt.wantErr, gotErr)
+			assert.Equal(t, tt.want, got)
+
+			var gotEvents []model.Event
+			for _, call := range tt.fields.repoCache.PublishEventCalls() {
+				gotEvents = append(gotEvents, model.Event{UserID: call.Event.UserID, Type: call.Event.Type})
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents)
+			assert.Equal(t, tt.wantSuperLike, len(tt.fields.repoCache.DecrSuperLikeCountCalls()) == 1)
+		})
+	}
+}
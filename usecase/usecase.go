@@ -2,29 +2,233 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/egnptr/dating-app/model"
+	"github.com/egnptr/dating-app/pkg/billing"
+	"github.com/egnptr/dating-app/pkg/entitlement"
+	"github.com/egnptr/dating-app/pkg/event"
+	"github.com/egnptr/dating-app/pkg/mail"
+	"github.com/egnptr/dating-app/pkg/oidc"
+	"github.com/egnptr/dating-app/pkg/payment"
+	"github.com/egnptr/dating-app/pkg/receipt"
+	"github.com/egnptr/dating-app/pkg/storage"
 	"github.com/egnptr/dating-app/repository/cache"
 	"github.com/egnptr/dating-app/repository/db"
 )
 
+const (
+	// Failed attempts allowed before logging in is locked, tracked per
+	// username and per IP address
+	loginMaxAttemptsUser = 5
+	loginMaxAttemptsIP   = 20
+
+	// Every failed attempt past the limit doubles the lockout duration
+	loginLockBase = time.Minute
+	loginLockMax  = time.Hour
+
+	// Codes accepted for a single two-factor login challenge before it is
+	// thrown away and the password has to be entered again
+	loginChallengeMaxAttempts = 5
+
+	totpIssuer        = "Dating App"
+	recoveryCodeCount = 10
+
+	// Free users get a fresh swipe quota once they stop swiping for a day
+	swipeQuotaWindow = 24 * time.Hour
+
+	// Likers listed to premium users at once
+	likersLimit = 100
+
+	// Views older than the window are not shown to the viewed user
+	profileViewersWindow = 30 * 24 * time.Hour
+	profileViewersLimit  = 100
+
+	// Buffered profile views written to the db at once
+	profileViewFlushBatch = 500
+
+	messageMaxLength     = 2000
+	messagesDefaultLimit = 20
+	messagesMaxLimit     = 100
+
+	reportDetailMaxLength = 1000
+	reportsDefaultLimit   = 50
+	reportsMaxLimit       = 200
+
+	billingHistoryDefaultLimit = 20
+	billingHistoryMaxLimit     = 100
+
+	usersSearchDefaultLimit = 20
+	usersSearchMaxLimit     = 100
+
+	// How long a boost keeps the user toward the top of discovery
+	boostDuration = 30 * time.Minute
+
+	// Subscriptions handled by a run of a lifecycle job
+	subscriptionJobBatch = 100
+
+	// Users of past due subscriptions are reminded to pay this often
+	dunningInterval = 24 * time.Hour
+
+	// Photos a user can have on their profile at once
+	photoMaxCount = 6
+
+	// Random bytes of the names photos are stored under
+	photoKeyBytes = 16
+
+	// Random bytes of generated promo and referral codes
+	promoCodeBytes    = 4
+	referralCodeBytes = 5
+)
+
 // go:generate moq -rm -out usecase_mock.go . Usecases
 type Usecases interface {
 	CreateUser(ctx context.Context, req model.User) error
-	Login(ctx context.Context, req model.LoginRequest) error
-	UpdateSubscription(ctx context.Context, req model.SubscribeRequest) (err error)
-	GetProfiles(ctx context.Context, req model.GetRelatedUserRequest) (filteredUser []model.User, err error)
+	Login(ctx context.Context, req model.LoginRequest) (res model.LoginResponse, err error)
+	LoginTwoFactor(ctx context.Context, req model.LoginTwoFactorRequest) (res model.LoginResponse, err error)
+	OAuthLogin(ctx context.Context, req model.OAuthLoginRequest) (res model.OAuthLoginResponse, err error)
+	OAuthCallback(ctx context.Context, req model.OAuthCallbackRequest) (res model.LoginResponse, err error)
+	Authenticate(ctx context.Context, token string) (userID int64, err error)
+	EnrollTwoFactor(ctx context.Context, userID int64) (res model.TwoFactorEnrollResponse, err error)
+	VerifyTwoFactor(ctx context.Context, req model.TwoFactorVerifyRequest) (res model.TwoFactorVerifyResponse, err error)
+	ChangePassword(ctx context.Context, req model.ChangePasswordRequest) (err error)
+	ChangeEmail(ctx context.Context, req model.ChangeEmailRequest) (err error)
+	VerifyEmail(ctx context.Context, req model.VerifyEmailRequest) (err error)
+	UpdateSubscription(ctx context.Context, req model.SubscribeRequest) (res model.SubscribeResponse, err error)
+	GetSubscription(ctx context.Context, userID int64) (res model.Subscription, err error)
+	HandlePaymentWebhook(ctx context.Context, req model.PaymentWebhookRequest) (err error)
+	GetBillingHistory(ctx context.Context, req model.BillingHistoryRequest) (res model.BillingHistoryResponse, err error)
+	GetInvoice(ctx context.Context, req model.InvoiceRequest) (res model.InvoiceDocument, err error)
+	VerifyReceipt(ctx context.Context, req model.ReceiptRequest) (res model.Subscription, err error)
+	HandleStoreNotification(ctx context.Context, req model.StoreNotificationRequest) (err error)
+	GetProfiles(ctx context.Context, req model.GetRelatedUserRequest) (res []model.Profile, err error)
+	UploadPhoto(ctx context.Context, req model.UploadPhotoRequest) (res model.Photo, err error)
+	GetPhotos(ctx context.Context, userID int64) (res []model.Photo, err error)
+	ReorderPhotos(ctx context.Context, req model.ReorderPhotosRequest) (res []model.Photo, err error)
+	SetPrimaryPhoto(ctx context.Context, req model.PhotoRequest) (err error)
+	DeletePhoto(ctx context.Context, req model.PhotoRequest) (err error)
 	Swipe(ctx context.Context, req model.SwipeRequest) (err error)
+	UndoSwipe(ctx context.Context, req model.UndoSwipeRequest) (res model.Swipe, err error)
+	StartConversation(ctx context.Context, req model.StartConversationRequest) (res model.Conversation, err error)
+	GetConversations(ctx context.Context, userID int64) (conversations []model.Conversation, err error)
+	SendMessage(ctx context.Context, req model.SendMessageRequest) (res model.Message, err error)
+	GetMessages(ctx context.Context, req model.GetMessagesRequest) (res model.GetMessagesResponse, err error)
+	DeleteMessage(ctx context.Context, req model.DeleteMessageRequest) (err error)
+	MarkConversationRead(ctx context.Context, req model.MarkReadRequest) (err error)
+	BlockUser(ctx context.Context, req model.BlockRequest) (err error)
+	UnblockUser(ctx context.Context, req model.BlockRequest) (err error)
+	ReportUser(ctx context.Context, req model.ReportRequest) (res model.Report, err error)
+	GetReports(ctx context.Context, req model.GetReportsRequest) (res []model.Report, err error)
+	ClaimReport(ctx context.Context, req model.ReviewReportRequest) (err error)
+	ResolveReport(ctx context.Context, req model.ReviewReportRequest) (err error)
+	EscalateReport(ctx context.Context, req model.ReviewReportRequest) (err error)
+	HasRole(ctx context.Context, userID int64, role string) (ok bool, err error)
+	Can(ctx context.Context, userID int64, feature string) (ok bool, err error)
+	Limit(ctx context.Context, userID int64, limit string) (n int, err error)
+	GetEntitlements(ctx context.Context, userID int64) (res model.Entitlements, err error)
+	GetBoosts(ctx context.Context, userID int64) (res model.BoostInventory, err error)
+	ActivateBoost(ctx context.Context, userID int64) (res model.Boost, err error)
+	PurchaseBoosts(ctx context.Context, req model.ReceiptRequest) (res model.BoostInventory, err error)
+	CreatePromoCode(ctx context.Context, req model.CreatePromoCodeRequest) (res model.PromoCode, err error)
+	RedeemPromoCode(ctx context.Context, req model.RedeemRequest) (res model.RedeemPromoCodeResponse, err error)
+	GetReferral(ctx context.Context, userID int64) (res model.ReferralResponse, err error)
+	RedeemReferral(ctx context.Context, req model.RedeemRequest) (err error)
+	SearchUsers(ctx context.Context, req model.SearchUsersRequest) (res []model.User, err error)
+	GetUserProfile(ctx context.Context, req model.AdminUserRequest) (res model.AdminUserProfile, err error)
+	SetPremium(ctx context.Context, req model.AdminPremiumRequest) (err error)
+	BanUser(ctx context.Context, req model.AdminUserRequest) (err error)
+	SuspendUser(ctx context.Context, req model.AdminUserRequest) (err error)
+	ShadowbanUser(ctx context.Context, req model.AdminUserRequest) (err error)
+	UnbanUser(ctx context.Context, req model.AdminUserRequest) (err error)
+	GetAccountStatusHistory(ctx context.Context, req model.AdminUserRequest) (res []model.AccountStatusChange, err error)
+	ForceLogout(ctx context.Context, req model.AdminUserRequest) (err error)
+	SetRole(ctx context.Context, req model.AdminRoleRequest) (err error)
+	ViewProfile(ctx context.Context, req model.ViewProfileRequest) (res model.Profile, err error)
+	GetProfileViewers(ctx context.Context, userID int64) (res model.ProfileViewersResponse, err error)
+	GetLikers(ctx context.Context, userID int64) (res model.LikersResponse, err error)
+	SetIncognito(ctx context.Context, req model.IncognitoRequest) (err error)
+	FlushProfileViews(ctx context.Context) (err error)
+	SubscribeEvents(ctx context.Context, req model.SubscribeEventsRequest) (replay []model.Event, events <-chan model.Event, unsubscribe func(), err error)
+	PublishQuotaResets(ctx context.Context, now time.Time) (err error)
+	ExpireSubscriptions(ctx context.Context, now time.Time) (err error)
+	RenewSubscriptions(ctx context.Context, now time.Time) (err error)
+	SendDunningNotices(ctx context.Context, now time.Time) (err error)
+	EndGracePeriods(ctx context.Context, now time.Time) (err error)
+}
+
+// Config holds the settings of the usecases read from the environment
+type Config struct {
+	// Users reporting a profile before it is hidden from discovery pending
+	// review, zero never hides profiles
+	ReportHideThreshold int
+
+	// Users granted the admin role whatever their role in the db is
+	AdminUserIDs map[int64]bool
+
+	// How long premium users have to undo a swipe, zero turns undoing off
+	UndoSwipeWindow time.Duration
+
+	// What each tier is entitled to, nil uses the defaults
+	Entitlements entitlement.Config
+
+	// Days the first subscription a user pays for is free, zero has no trial
+	TrialDays int
+
+	// Premium days granted to both users of a referral, zero turns the
+	// rewards off
+	ReferralPremiumDays int
+
+	// What each product sold in the stores subscribes to, keyed by product ID
+	StoreProducts map[string]model.StoreProduct
+
+	// Boosts in each boost pack sold in the stores, keyed by product ID
+	StoreBoostPacks map[string]int
+
+	// How long a past due subscription of the payment provider keeps premium
+	// after its period ends while the payment is retried
+	GracePeriod time.Duration
+
+	// Issuer of the invoices
+	Seller billing.Seller
+
+	// Tax included in what users pay, the rate in basis points
+	TaxName string
+	TaxRate int64
 }
 
 type usecase struct {
 	RepoDB    db.Repo
 	RepoCache cache.Repo
+	Mailer    mail.Mailer
+
+	// OpenID providers available for social login, keyed by name
+	Providers map[string]oidc.Provider
+
+	// Charges for subscriptions
+	Payments payment.Provider
+
+	// Checks subscriptions bought in the stores of the mobile apps
+	Receipts receipt.Verifier
+
+	// Keeps the photos users upload
+	Blobs storage.BlobStore
+
+	// Events of the users connected to this instance
+	Events *event.Hub
+
+	Config Config
 }
 
-func NewUsecase(db db.Repo, cache cache.Repo) Usecases {
+func NewUsecase(db db.Repo, cache cache.Repo, mailer mail.Mailer, providers map[string]oidc.Provider, payments payment.Provider, receipts receipt.Verifier, blobs storage.BlobStore, events *event.Hub, config Config) Usecases {
 	return &usecase{
 		RepoDB:    db,
 		RepoCache: cache,
+		Mailer:    mailer,
+		Providers: providers,
+		Payments:  payments,
+		Receipts:  receipts,
+		Blobs:     blobs,
+		Events:    events,
+		Config:    config,
 	}
 }
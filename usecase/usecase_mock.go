@@ -7,6 +7,7 @@ import (
 	"context"
 	"github.com/egnptr/dating-app/model"
 	"sync"
+	"time"
 )
 
 // Ensure, that UsecasesMock does implement Usecases.
@@ -19,21 +20,219 @@ var _ Usecases = &UsecasesMock{}
 //
 //		// make and configure a mocked Usecases
 //		mockedUsecases := &UsecasesMock{
+//			ActivateBoostFunc: func(ctx context.Context, userID int64) (model.Boost, error) {
+//				panic("mock out the ActivateBoost method")
+//			},
+//			AuthenticateFunc: func(ctx context.Context, token string) (int64, error) {
+//				panic("mock out the Authenticate method")
+//			},
+//			BanUserFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+//				panic("mock out the BanUser method")
+//			},
+//			BlockUserFunc: func(ctx context.Context, req model.BlockRequest) error {
+//				panic("mock out the BlockUser method")
+//			},
+//			CanFunc: func(ctx context.Context, userID int64, feature string) (bool, error) {
+//				panic("mock out the Can method")
+//			},
+//			ChangeEmailFunc: func(ctx context.Context, req model.ChangeEmailRequest) error {
+//				panic("mock out the ChangeEmail method")
+//			},
+//			ChangePasswordFunc: func(ctx context.Context, req model.ChangePasswordRequest) error {
+//				panic("mock out the ChangePassword method")
+//			},
+//			ClaimReportFunc: func(ctx context.Context, req model.ReviewReportRequest) error {
+//				panic("mock out the ClaimReport method")
+//			},
+//			CreatePromoCodeFunc: func(ctx context.Context, req model.CreatePromoCodeRequest) (model.PromoCode, error) {
+//				panic("mock out the CreatePromoCode method")
+//			},
 //			CreateUserFunc: func(ctx context.Context, req model.User) error {
 //				panic("mock out the CreateUser method")
 //			},
-//			GetProfilesFunc: func(ctx context.Context, req model.GetRelatedUserRequest) ([]model.User, error) {
+//			DeleteMessageFunc: func(ctx context.Context, req model.DeleteMessageRequest) error {
+//				panic("mock out the DeleteMessage method")
+//			},
+//			DeletePhotoFunc: func(ctx context.Context, req model.PhotoRequest) error {
+//				panic("mock out the DeletePhoto method")
+//			},
+//			EndGracePeriodsFunc: func(ctx context.Context, now time.Time) error {
+//				panic("mock out the EndGracePeriods method")
+//			},
+//			EnrollTwoFactorFunc: func(ctx context.Context, userID int64) (model.TwoFactorEnrollResponse, error) {
+//				panic("mock out the EnrollTwoFactor method")
+//			},
+//			EscalateReportFunc: func(ctx context.Context, req model.ReviewReportRequest) error {
+//				panic("mock out the EscalateReport method")
+//			},
+//			ExpireSubscriptionsFunc: func(ctx context.Context, now time.Time) error {
+//				panic("mock out the ExpireSubscriptions method")
+//			},
+//			FlushProfileViewsFunc: func(ctx context.Context) error {
+//				panic("mock out the FlushProfileViews method")
+//			},
+//			ForceLogoutFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+//				panic("mock out the ForceLogout method")
+//			},
+//			GetAccountStatusHistoryFunc: func(ctx context.Context, req model.AdminUserRequest) ([]model.AccountStatusChange, error) {
+//				panic("mock out the GetAccountStatusHistory method")
+//			},
+//			GetBillingHistoryFunc: func(ctx context.Context, req model.BillingHistoryRequest) (model.BillingHistoryResponse, error) {
+//				panic("mock out the GetBillingHistory method")
+//			},
+//			GetBoostsFunc: func(ctx context.Context, userID int64) (model.BoostInventory, error) {
+//				panic("mock out the GetBoosts method")
+//			},
+//			GetConversationsFunc: func(ctx context.Context, userID int64) ([]model.Conversation, error) {
+//				panic("mock out the GetConversations method")
+//			},
+//			GetEntitlementsFunc: func(ctx context.Context, userID int64) (model.Entitlements, error) {
+//				panic("mock out the GetEntitlements method")
+//			},
+//			GetInvoiceFunc: func(ctx context.Context, req model.InvoiceRequest) (model.InvoiceDocument, error) {
+//				panic("mock out the GetInvoice method")
+//			},
+//			GetLikersFunc: func(ctx context.Context, userID int64) (model.LikersResponse, error) {
+//				panic("mock out the GetLikers method")
+//			},
+//			GetMessagesFunc: func(ctx context.Context, req model.GetMessagesRequest) (model.GetMessagesResponse, error) {
+//				panic("mock out the GetMessages method")
+//			},
+//			GetPhotosFunc: func(ctx context.Context, userID int64) ([]model.Photo, error) {
+//				panic("mock out the GetPhotos method")
+//			},
+//			GetProfileViewersFunc: func(ctx context.Context, userID int64) (model.ProfileViewersResponse, error) {
+//				panic("mock out the GetProfileViewers method")
+//			},
+//			GetProfilesFunc: func(ctx context.Context, req model.GetRelatedUserRequest) ([]model.Profile, error) {
 //				panic("mock out the GetProfiles method")
 //			},
-//			LoginFunc: func(ctx context.Context, req model.LoginRequest) error {
+//			GetReferralFunc: func(ctx context.Context, userID int64) (model.ReferralResponse, error) {
+//				panic("mock out the GetReferral method")
+//			},
+//			GetReportsFunc: func(ctx context.Context, req model.GetReportsRequest) ([]model.Report, error) {
+//				panic("mock out the GetReports method")
+//			},
+//			GetSubscriptionFunc: func(ctx context.Context, userID int64) (model.Subscription, error) {
+//				panic("mock out the GetSubscription method")
+//			},
+//			GetUserProfileFunc: func(ctx context.Context, req model.AdminUserRequest) (model.AdminUserProfile, error) {
+//				panic("mock out the GetUserProfile method")
+//			},
+//			HandlePaymentWebhookFunc: func(ctx context.Context, req model.PaymentWebhookRequest) error {
+//				panic("mock out the HandlePaymentWebhook method")
+//			},
+//			HandleStoreNotificationFunc: func(ctx context.Context, req model.StoreNotificationRequest) error {
+//				panic("mock out the HandleStoreNotification method")
+//			},
+//			HasRoleFunc: func(ctx context.Context, userID int64, role string) (bool, error) {
+//				panic("mock out the HasRole method")
+//			},
+//			LimitFunc: func(ctx context.Context, userID int64, limit string) (int, error) {
+//				panic("mock out the Limit method")
+//			},
+//			LoginFunc: func(ctx context.Context, req model.LoginRequest) (model.LoginResponse, error) {
 //				panic("mock out the Login method")
 //			},
+//			LoginTwoFactorFunc: func(ctx context.Context, req model.LoginTwoFactorRequest) (model.LoginResponse, error) {
+//				panic("mock out the LoginTwoFactor method")
+//			},
+//			MarkConversationReadFunc: func(ctx context.Context, req model.MarkReadRequest) error {
+//				panic("mock out the MarkConversationRead method")
+//			},
+//			OAuthCallbackFunc: func(ctx context.Context, req model.OAuthCallbackRequest) (model.LoginResponse, error) {
+//				panic("mock out the OAuthCallback method")
+//			},
+//			OAuthLoginFunc: func(ctx context.Context, req model.OAuthLoginRequest) (model.OAuthLoginResponse, error) {
+//				panic("mock out the OAuthLogin method")
+//			},
+//			PublishQuotaResetsFunc: func(ctx context.Context, now time.Time) error {
+//				panic("mock out the PublishQuotaResets method")
+//			},
+//			PurchaseBoostsFunc: func(ctx context.Context, req model.ReceiptRequest) (model.BoostInventory, error) {
+//				panic("mock out the PurchaseBoosts method")
+//			},
+//			RedeemPromoCodeFunc: func(ctx context.Context, req model.RedeemRequest) (model.RedeemPromoCodeResponse, error) {
+//				panic("mock out the RedeemPromoCode method")
+//			},
+//			RedeemReferralFunc: func(ctx context.Context, req model.RedeemRequest) error {
+//				panic("mock out the RedeemReferral method")
+//			},
+//			RenewSubscriptionsFunc: func(ctx context.Context, now time.Time) error {
+//				panic("mock out the RenewSubscriptions method")
+//			},
+//			ReorderPhotosFunc: func(ctx context.Context, req model.ReorderPhotosRequest) ([]model.Photo, error) {
+//				panic("mock out the ReorderPhotos method")
+//			},
+//			ReportUserFunc: func(ctx context.Context, req model.ReportRequest) (model.Report, error) {
+//				panic("mock out the ReportUser method")
+//			},
+//			ResolveReportFunc: func(ctx context.Context, req model.ReviewReportRequest) error {
+//				panic("mock out the ResolveReport method")
+//			},
+//			SearchUsersFunc: func(ctx context.Context, req model.SearchUsersRequest) ([]model.User, error) {
+//				panic("mock out the SearchUsers method")
+//			},
+//			SendDunningNoticesFunc: func(ctx context.Context, now time.Time) error {
+//				panic("mock out the SendDunningNotices method")
+//			},
+//			SendMessageFunc: func(ctx context.Context, req model.SendMessageRequest) (model.Message, error) {
+//				panic("mock out the SendMessage method")
+//			},
+//			SetIncognitoFunc: func(ctx context.Context, req model.IncognitoRequest) error {
+//				panic("mock out the SetIncognito method")
+//			},
+//			SetPremiumFunc: func(ctx context.Context, req model.AdminPremiumRequest) error {
+//				panic("mock out the SetPremium method")
+//			},
+//			SetPrimaryPhotoFunc: func(ctx context.Context, req model.PhotoRequest) error {
+//				panic("mock out the SetPrimaryPhoto method")
+//			},
+//			SetRoleFunc: func(ctx context.Context, req model.AdminRoleRequest) error {
+//				panic("mock out the SetRole method")
+//			},
+//			ShadowbanUserFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+//				panic("mock out the ShadowbanUser method")
+//			},
+//			StartConversationFunc: func(ctx context.Context, req model.StartConversationRequest) (model.Conversation, error) {
+//				panic("mock out the StartConversation method")
+//			},
+//			SubscribeEventsFunc: func(ctx context.Context, req model.SubscribeEventsRequest) ([]model.Event, <-chan model.Event, func(), error) {
+//				panic("mock out the SubscribeEvents method")
+//			},
+//			SuspendUserFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+//				panic("mock out the SuspendUser method")
+//			},
 //			SwipeFunc: func(ctx context.Context, req model.SwipeRequest) error {
 //				panic("mock out the Swipe method")
 //			},
-//			UpdateSubscriptionFunc: func(ctx context.Context, req model.SubscribeRequest) error {
+//			UnbanUserFunc: func(ctx context.Context, req model.AdminUserRequest) error {
+//				panic("mock out the UnbanUser method")
+//			},
+//			UnblockUserFunc: func(ctx context.Context, req model.BlockRequest) error {
+//				panic("mock out the UnblockUser method")
+//			},
+//			UndoSwipeFunc: func(ctx context.Context, req model.UndoSwipeRequest) (model.Swipe, error) {
+//				panic("mock out the UndoSwipe method")
+//			},
+//			UpdateSubscriptionFunc: func(ctx context.Context, req model.SubscribeRequest) (model.SubscribeResponse, error) {
 //				panic("mock out the UpdateSubscription method")
 //			},
+//			UploadPhotoFunc: func(ctx context.Context, req model.UploadPhotoRequest) (model.Photo, error) {
+//				panic("mock out the UploadPhoto method")
+//			},
+//			VerifyEmailFunc: func(ctx context.Context, req model.VerifyEmailRequest) error {
+//				panic("mock out the VerifyEmail method")
+//			},
+//			VerifyReceiptFunc: func(ctx context.Context, req model.ReceiptRequest) (model.Subscription, error) {
+//				panic("mock out the VerifyReceipt method")
+//			},
+//			VerifyTwoFactorFunc: func(ctx context.Context, req model.TwoFactorVerifyRequest) (model.TwoFactorVerifyResponse, error) {
+//				panic("mock out the VerifyTwoFactor method")
+//			},
+//			ViewProfileFunc: func(ctx context.Context, req model.ViewProfileRequest) (model.Profile, error) {
+//				panic("mock out the ViewProfile method")
+//			},
 //		}
 //
 //		// use mockedUsecases in code that requires Usecases
@@ -41,171 +240,2997 @@ var _ Usecases = &UsecasesMock{}
 //
 //	}
 type UsecasesMock struct {
+	// ActivateBoostFunc mocks the ActivateBoost method.
+	ActivateBoostFunc func(ctx context.Context, userID int64) (model.Boost, error)
+
+	// AuthenticateFunc mocks the Authenticate method.
+	AuthenticateFunc func(ctx context.Context, token string) (int64, error)
+
+	// BanUserFunc mocks the BanUser method.
+	BanUserFunc func(ctx context.Context, req model.AdminUserRequest) error
+
+	// BlockUserFunc mocks the BlockUser method.
+	BlockUserFunc func(ctx context.Context, req model.BlockRequest) error
+
+	// CanFunc mocks the Can method.
+	CanFunc func(ctx context.Context, userID int64, feature string) (bool, error)
+
+	// ChangeEmailFunc mocks the ChangeEmail method.
+	ChangeEmailFunc func(ctx context.Context, req model.ChangeEmailRequest) error
+
+	// ChangePasswordFunc mocks the ChangePassword method.
+	ChangePasswordFunc func(ctx context.Context, req model.ChangePasswordRequest) error
+
+	// ClaimReportFunc mocks the ClaimReport method.
+	ClaimReportFunc func(ctx context.Context, req model.ReviewReportRequest) error
+
+	// CreatePromoCodeFunc mocks the CreatePromoCode method.
+	CreatePromoCodeFunc func(ctx context.Context, req model.CreatePromoCodeRequest) (model.PromoCode, error)
+
 	// CreateUserFunc mocks the CreateUser method.
 	CreateUserFunc func(ctx context.Context, req model.User) error
 
+	// DeleteMessageFunc mocks the DeleteMessage method.
+	DeleteMessageFunc func(ctx context.Context, req model.DeleteMessageRequest) error
+
+	// DeletePhotoFunc mocks the DeletePhoto method.
+	DeletePhotoFunc func(ctx context.Context, req model.PhotoRequest) error
+
+	// EndGracePeriodsFunc mocks the EndGracePeriods method.
+	EndGracePeriodsFunc func(ctx context.Context, now time.Time) error
+
+	// EnrollTwoFactorFunc mocks the EnrollTwoFactor method.
+	EnrollTwoFactorFunc func(ctx context.Context, userID int64) (model.TwoFactorEnrollResponse, error)
+
+	// EscalateReportFunc mocks the EscalateReport method.
+	EscalateReportFunc func(ctx context.Context, req model.ReviewReportRequest) error
+
+	// ExpireSubscriptionsFunc mocks the ExpireSubscriptions method.
+	ExpireSubscriptionsFunc func(ctx context.Context, now time.Time) error
+
+	// FlushProfileViewsFunc mocks the FlushProfileViews method.
+	FlushProfileViewsFunc func(ctx context.Context) error
+
+	// ForceLogoutFunc mocks the ForceLogout method.
+	ForceLogoutFunc func(ctx context.Context, req model.AdminUserRequest) error
+
+	// GetAccountStatusHistoryFunc mocks the GetAccountStatusHistory method.
+	GetAccountStatusHistoryFunc func(ctx context.Context, req model.AdminUserRequest) ([]model.AccountStatusChange, error)
+
+	// GetBillingHistoryFunc mocks the GetBillingHistory method.
+	GetBillingHistoryFunc func(ctx context.Context, req model.BillingHistoryRequest) (model.BillingHistoryResponse, error)
+
+	// GetBoostsFunc mocks the GetBoosts method.
+	GetBoostsFunc func(ctx context.Context, userID int64) (model.BoostInventory, error)
+
+	// GetConversationsFunc mocks the GetConversations method.
+	GetConversationsFunc func(ctx context.Context, userID int64) ([]model.Conversation, error)
+
+	// GetEntitlementsFunc mocks the GetEntitlements method.
+	GetEntitlementsFunc func(ctx context.Context, userID int64) (model.Entitlements, error)
+
+	// GetInvoiceFunc mocks the GetInvoice method.
+	GetInvoiceFunc func(ctx context.Context, req model.InvoiceRequest) (model.InvoiceDocument, error)
+
+	// GetLikersFunc mocks the GetLikers method.
+	GetLikersFunc func(ctx context.Context, userID int64) (model.LikersResponse, error)
+
+	// GetMessagesFunc mocks the GetMessages method.
+	GetMessagesFunc func(ctx context.Context, req model.GetMessagesRequest) (model.GetMessagesResponse, error)
+
+	// GetPhotosFunc mocks the GetPhotos method.
+	GetPhotosFunc func(ctx context.Context, userID int64) ([]model.Photo, error)
+
+	// GetProfileViewersFunc mocks the GetProfileViewers method.
+	GetProfileViewersFunc func(ctx context.Context, userID int64) (model.ProfileViewersResponse, error)
+
 	// GetProfilesFunc mocks the GetProfiles method.
-	GetProfilesFunc func(ctx context.Context, req model.GetRelatedUserRequest) ([]model.User, error)
+	GetProfilesFunc func(ctx context.Context, req model.GetRelatedUserRequest) ([]model.Profile, error)
+
+	// GetReferralFunc mocks the GetReferral method.
+	GetReferralFunc func(ctx context.Context, userID int64) (model.ReferralResponse, error)
+
+	// GetReportsFunc mocks the GetReports method.
+	GetReportsFunc func(ctx context.Context, req model.GetReportsRequest) ([]model.Report, error)
+
+	// GetSubscriptionFunc mocks the GetSubscription method.
+	GetSubscriptionFunc func(ctx context.Context, userID int64) (model.Subscription, error)
+
+	// GetUserProfileFunc mocks the GetUserProfile method.
+	GetUserProfileFunc func(ctx context.Context, req model.AdminUserRequest) (model.AdminUserProfile, error)
+
+	// HandlePaymentWebhookFunc mocks the HandlePaymentWebhook method.
+	HandlePaymentWebhookFunc func(ctx context.Context, req model.PaymentWebhookRequest) error
+
+	// HandleStoreNotificationFunc mocks the HandleStoreNotification method.
+	HandleStoreNotificationFunc func(ctx context.Context, req model.StoreNotificationRequest) error
+
+	// HasRoleFunc mocks the HasRole method.
+	HasRoleFunc func(ctx context.Context, userID int64, role string) (bool, error)
+
+	// LimitFunc mocks the Limit method.
+	LimitFunc func(ctx context.Context, userID int64, limit string) (int, error)
 
 	// LoginFunc mocks the Login method.
-	LoginFunc func(ctx context.Context, req model.LoginRequest) error
+	LoginFunc func(ctx context.Context, req model.LoginRequest) (model.LoginResponse, error)
+
+	// LoginTwoFactorFunc mocks the LoginTwoFactor method.
+	LoginTwoFactorFunc func(ctx context.Context, req model.LoginTwoFactorRequest) (model.LoginResponse, error)
+
+	// MarkConversationReadFunc mocks the MarkConversationRead method.
+	MarkConversationReadFunc func(ctx context.Context, req model.MarkReadRequest) error
+
+	// OAuthCallbackFunc mocks the OAuthCallback method.
+	OAuthCallbackFunc func(ctx context.Context, req model.OAuthCallbackRequest) (model.LoginResponse, error)
+
+	// OAuthLoginFunc mocks the OAuthLogin method.
+	OAuthLoginFunc func(ctx context.Context, req model.OAuthLoginRequest) (model.OAuthLoginResponse, error)
+
+	// PublishQuotaResetsFunc mocks the PublishQuotaResets method.
+	PublishQuotaResetsFunc func(ctx context.Context, now time.Time) error
+
+	// PurchaseBoostsFunc mocks the PurchaseBoosts method.
+	PurchaseBoostsFunc func(ctx context.Context, req model.ReceiptRequest) (model.BoostInventory, error)
+
+	// RedeemPromoCodeFunc mocks the RedeemPromoCode method.
+	RedeemPromoCodeFunc func(ctx context.Context, req model.RedeemRequest) (model.RedeemPromoCodeResponse, error)
+
+	// RedeemReferralFunc mocks the RedeemReferral method.
+	RedeemReferralFunc func(ctx context.Context, req model.RedeemRequest) error
+
+	// RenewSubscriptionsFunc mocks the RenewSubscriptions method.
+	RenewSubscriptionsFunc func(ctx context.Context, now time.Time) error
+
+	// ReorderPhotosFunc mocks the ReorderPhotos method.
+	ReorderPhotosFunc func(ctx context.Context, req model.ReorderPhotosRequest) ([]model.Photo, error)
+
+	// ReportUserFunc mocks the ReportUser method.
+	ReportUserFunc func(ctx context.Context, req model.ReportRequest) (model.Report, error)
+
+	// ResolveReportFunc mocks the ResolveReport method.
+	ResolveReportFunc func(ctx context.Context, req model.ReviewReportRequest) error
+
+	// SearchUsersFunc mocks the SearchUsers method.
+	SearchUsersFunc func(ctx context.Context, req model.SearchUsersRequest) ([]model.User, error)
+
+	// SendDunningNoticesFunc mocks the SendDunningNotices method.
+	SendDunningNoticesFunc func(ctx context.Context, now time.Time) error
+
+	// SendMessageFunc mocks the SendMessage method.
+	SendMessageFunc func(ctx context.Context, req model.SendMessageRequest) (model.Message, error)
+
+	// SetIncognitoFunc mocks the SetIncognito method.
+	SetIncognitoFunc func(ctx context.Context, req model.IncognitoRequest) error
+
+	// SetPremiumFunc mocks the SetPremium method.
+	SetPremiumFunc func(ctx context.Context, req model.AdminPremiumRequest) error
+
+	// SetPrimaryPhotoFunc mocks the SetPrimaryPhoto method.
+	SetPrimaryPhotoFunc func(ctx context.Context, req model.PhotoRequest) error
+
+	// SetRoleFunc mocks the SetRole method.
+	SetRoleFunc func(ctx context.Context, req model.AdminRoleRequest) error
+
+	// ShadowbanUserFunc mocks the ShadowbanUser method.
+	ShadowbanUserFunc func(ctx context.Context, req model.AdminUserRequest) error
+
+	// StartConversationFunc mocks the StartConversation method.
+	StartConversationFunc func(ctx context.Context, req model.StartConversationRequest) (model.Conversation, error)
+
+	// SubscribeEventsFunc mocks the SubscribeEvents method.
+	SubscribeEventsFunc func(ctx context.Context, req model.SubscribeEventsRequest) ([]model.Event, <-chan model.Event, func(), error)
+
+	// SuspendUserFunc mocks the SuspendUser method.
+	SuspendUserFunc func(ctx context.Context, req model.AdminUserRequest) error
 
 	// SwipeFunc mocks the Swipe method.
 	SwipeFunc func(ctx context.Context, req model.SwipeRequest) error
 
-	// UpdateSubscriptionFunc mocks the UpdateSubscription method.
-	UpdateSubscriptionFunc func(ctx context.Context, req model.SubscribeRequest) error
+	// UnbanUserFunc mocks the UnbanUser method.
+	UnbanUserFunc func(ctx context.Context, req model.AdminUserRequest) error
+
+	// UnblockUserFunc mocks the UnblockUser method.
+	UnblockUserFunc func(ctx context.Context, req model.BlockRequest) error
+
+	// UndoSwipeFunc mocks the UndoSwipe method.
+	UndoSwipeFunc func(ctx context.Context, req model.UndoSwipeRequest) (model.Swipe, error)
+
+	// UpdateSubscriptionFunc mocks the UpdateSubscription method.
+	UpdateSubscriptionFunc func(ctx context.Context, req model.SubscribeRequest) (model.SubscribeResponse, error)
+
+	// UploadPhotoFunc mocks the UploadPhoto method.
+	UploadPhotoFunc func(ctx context.Context, req model.UploadPhotoRequest) (model.Photo, error)
+
+	// VerifyEmailFunc mocks the VerifyEmail method.
+	VerifyEmailFunc func(ctx context.Context, req model.VerifyEmailRequest) error
+
+	// VerifyReceiptFunc mocks the VerifyReceipt method.
+	VerifyReceiptFunc func(ctx context.Context, req model.ReceiptRequest) (model.Subscription, error)
+
+	// VerifyTwoFactorFunc mocks the VerifyTwoFactor method.
+	VerifyTwoFactorFunc func(ctx context.Context, req model.TwoFactorVerifyRequest) (model.TwoFactorVerifyResponse, error)
+
+	// ViewProfileFunc mocks the ViewProfile method.
+	ViewProfileFunc func(ctx context.Context, req model.ViewProfileRequest) (model.Profile, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// ActivateBoost holds details about calls to the ActivateBoost method.
+		ActivateBoost []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// Authenticate holds details about calls to the Authenticate method.
+		Authenticate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Token is the token argument value.
+			Token string
+		}
+		// BanUser holds details about calls to the BanUser method.
+		BanUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.AdminUserRequest
+		}
+		// BlockUser holds details about calls to the BlockUser method.
+		BlockUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.BlockRequest
+		}
+		// Can holds details about calls to the Can method.
+		Can []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Feature is the feature argument value.
+			Feature string
+		}
+		// ChangeEmail holds details about calls to the ChangeEmail method.
+		ChangeEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.ChangeEmailRequest
+		}
+		// ChangePassword holds details about calls to the ChangePassword method.
+		ChangePassword []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.ChangePasswordRequest
+		}
+		// ClaimReport holds details about calls to the ClaimReport method.
+		ClaimReport []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.ReviewReportRequest
+		}
+		// CreatePromoCode holds details about calls to the CreatePromoCode method.
+		CreatePromoCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.CreatePromoCodeRequest
+		}
+		// CreateUser holds details about calls to the CreateUser method.
+		CreateUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.User
+		}
+		// DeleteMessage holds details about calls to the DeleteMessage method.
+		DeleteMessage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.DeleteMessageRequest
+		}
+		// DeletePhoto holds details about calls to the DeletePhoto method.
+		DeletePhoto []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.PhotoRequest
+		}
+		// EndGracePeriods holds details about calls to the EndGracePeriods method.
+		EndGracePeriods []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// EnrollTwoFactor holds details about calls to the EnrollTwoFactor method.
+		EnrollTwoFactor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// EscalateReport holds details about calls to the EscalateReport method.
+		EscalateReport []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.ReviewReportRequest
+		}
+		// ExpireSubscriptions holds details about calls to the ExpireSubscriptions method.
+		ExpireSubscriptions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// FlushProfileViews holds details about calls to the FlushProfileViews method.
+		FlushProfileViews []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ForceLogout holds details about calls to the ForceLogout method.
+		ForceLogout []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.AdminUserRequest
+		}
+		// GetAccountStatusHistory holds details about calls to the GetAccountStatusHistory method.
+		GetAccountStatusHistory []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.AdminUserRequest
+		}
+		// GetBillingHistory holds details about calls to the GetBillingHistory method.
+		GetBillingHistory []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.BillingHistoryRequest
+		}
+		// GetBoosts holds details about calls to the GetBoosts method.
+		GetBoosts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetConversations holds details about calls to the GetConversations method.
+		GetConversations []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetEntitlements holds details about calls to the GetEntitlements method.
+		GetEntitlements []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetInvoice holds details about calls to the GetInvoice method.
+		GetInvoice []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.InvoiceRequest
+		}
+		// GetLikers holds details about calls to the GetLikers method.
+		GetLikers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetMessages holds details about calls to the GetMessages method.
+		GetMessages []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.GetMessagesRequest
+		}
+		// GetPhotos holds details about calls to the GetPhotos method.
+		GetPhotos []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetProfileViewers holds details about calls to the GetProfileViewers method.
+		GetProfileViewers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetProfiles holds details about calls to the GetProfiles method.
+		GetProfiles []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.GetRelatedUserRequest
+		}
+		// GetReferral holds details about calls to the GetReferral method.
+		GetReferral []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetReports holds details about calls to the GetReports method.
+		GetReports []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.GetReportsRequest
+		}
+		// GetSubscription holds details about calls to the GetSubscription method.
+		GetSubscription []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+		}
+		// GetUserProfile holds details about calls to the GetUserProfile method.
+		GetUserProfile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.AdminUserRequest
+		}
+		// HandlePaymentWebhook holds details about calls to the HandlePaymentWebhook method.
+		HandlePaymentWebhook []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.PaymentWebhookRequest
+		}
+		// HandleStoreNotification holds details about calls to the HandleStoreNotification method.
+		HandleStoreNotification []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.StoreNotificationRequest
+		}
+		// HasRole holds details about calls to the HasRole method.
+		HasRole []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Role is the role argument value.
+			Role string
+		}
+		// Limit holds details about calls to the Limit method.
+		Limit []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID int64
+			// Limit is the limit argument value.
+			Limit string
+		}
+		// Login holds details about calls to the Login method.
+		Login []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.LoginRequest
+		}
+		// LoginTwoFactor holds details about calls to the LoginTwoFactor method.
+		LoginTwoFactor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.LoginTwoFactorRequest
+		}
+		// MarkConversationRead holds details about calls to the MarkConversationRead method.
+		MarkConversationRead []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.MarkReadRequest
+		}
+		// OAuthCallback holds details about calls to the OAuthCallback method.
+		OAuthCallback []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.OAuthCallbackRequest
+		}
+		// OAuthLogin holds details about calls to the OAuthLogin method.
+		OAuthLogin []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.OAuthLoginRequest
+		}
+		// PublishQuotaResets holds details about calls to the PublishQuotaResets method.
+		PublishQuotaResets []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// PurchaseBoosts holds details about calls to the PurchaseBoosts method.
+		PurchaseBoosts []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.ReceiptRequest
+		}
+		// RedeemPromoCode holds details about calls to the RedeemPromoCode method.
+		RedeemPromoCode []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.RedeemRequest
+		}
+		// RedeemReferral holds details about calls to the RedeemReferral method.
+		RedeemReferral []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.RedeemRequest
+		}
+		// RenewSubscriptions holds details about calls to the RenewSubscriptions method.
+		RenewSubscriptions []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// ReorderPhotos holds details about calls to the ReorderPhotos method.
+		ReorderPhotos []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.ReorderPhotosRequest
+		}
+		// ReportUser holds details about calls to the ReportUser method.
+		ReportUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.ReportRequest
+		}
+		// ResolveReport holds details about calls to the ResolveReport method.
+		ResolveReport []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.ReviewReportRequest
+		}
+		// SearchUsers holds details about calls to the SearchUsers method.
+		SearchUsers []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.SearchUsersRequest
+		}
+		// SendDunningNotices holds details about calls to the SendDunningNotices method.
+		SendDunningNotices []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Now is the now argument value.
+			Now time.Time
+		}
+		// SendMessage holds details about calls to the SendMessage method.
+		SendMessage []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.SendMessageRequest
+		}
+		// SetIncognito holds details about calls to the SetIncognito method.
+		SetIncognito []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.IncognitoRequest
+		}
+		// SetPremium holds details about calls to the SetPremium method.
+		SetPremium []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.AdminPremiumRequest
+		}
+		// SetPrimaryPhoto holds details about calls to the SetPrimaryPhoto method.
+		SetPrimaryPhoto []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.PhotoRequest
+		}
+		// SetRole holds details about calls to the SetRole method.
+		SetRole []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.AdminRoleRequest
+		}
+		// ShadowbanUser holds details about calls to the ShadowbanUser method.
+		ShadowbanUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.AdminUserRequest
+		}
+		// StartConversation holds details about calls to the StartConversation method.
+		StartConversation []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.StartConversationRequest
+		}
+		// SubscribeEvents holds details about calls to the SubscribeEvents method.
+		SubscribeEvents []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.SubscribeEventsRequest
+		}
+		// SuspendUser holds details about calls to the SuspendUser method.
+		SuspendUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.AdminUserRequest
+		}
+		// Swipe holds details about calls to the Swipe method.
+		Swipe []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.SwipeRequest
+		}
+		// UnbanUser holds details about calls to the UnbanUser method.
+		UnbanUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.AdminUserRequest
+		}
+		// UnblockUser holds details about calls to the UnblockUser method.
+		UnblockUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.BlockRequest
+		}
+		// UndoSwipe holds details about calls to the UndoSwipe method.
+		UndoSwipe []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.UndoSwipeRequest
+		}
+		// UpdateSubscription holds details about calls to the UpdateSubscription method.
+		UpdateSubscription []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.SubscribeRequest
+		}
+		// UploadPhoto holds details about calls to the UploadPhoto method.
+		UploadPhoto []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.UploadPhotoRequest
+		}
+		// VerifyEmail holds details about calls to the VerifyEmail method.
+		VerifyEmail []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.VerifyEmailRequest
+		}
+		// VerifyReceipt holds details about calls to the VerifyReceipt method.
+		VerifyReceipt []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.ReceiptRequest
+		}
+		// VerifyTwoFactor holds details about calls to the VerifyTwoFactor method.
+		VerifyTwoFactor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.TwoFactorVerifyRequest
+		}
+		// ViewProfile holds details about calls to the ViewProfile method.
+		ViewProfile []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Req is the req argument value.
+			Req model.ViewProfileRequest
+		}
+	}
+	lockActivateBoost           sync.RWMutex
+	lockAuthenticate            sync.RWMutex
+	lockBanUser                 sync.RWMutex
+	lockBlockUser               sync.RWMutex
+	lockCan                     sync.RWMutex
+	lockChangeEmail             sync.RWMutex
+	lockChangePassword          sync.RWMutex
+	lockClaimReport             sync.RWMutex
+	lockCreatePromoCode         sync.RWMutex
+	lockCreateUser              sync.RWMutex
+	lockDeleteMessage           sync.RWMutex
+	lockDeletePhoto             sync.RWMutex
+	lockEndGracePeriods         sync.RWMutex
+	lockEnrollTwoFactor         sync.RWMutex
+	lockEscalateReport          sync.RWMutex
+	lockExpireSubscriptions     sync.RWMutex
+	lockFlushProfileViews       sync.RWMutex
+	lockForceLogout             sync.RWMutex
+	lockGetAccountStatusHistory sync.RWMutex
+	lockGetBillingHistory       sync.RWMutex
+	lockGetBoosts               sync.RWMutex
+	lockGetConversations        sync.RWMutex
+	lockGetEntitlements         sync.RWMutex
+	lockGetInvoice              sync.RWMutex
+	lockGetLikers               sync.RWMutex
+	lockGetMessages             sync.RWMutex
+	lockGetPhotos               sync.RWMutex
+	lockGetProfileViewers       sync.RWMutex
+	lockGetProfiles             sync.RWMutex
+	lockGetReferral             sync.RWMutex
+	lockGetReports              sync.RWMutex
+	lockGetSubscription         sync.RWMutex
+	lockGetUserProfile          sync.RWMutex
+	lockHandlePaymentWebhook    sync.RWMutex
+	lockHandleStoreNotification sync.RWMutex
+	lockHasRole                 sync.RWMutex
+	lockLimit                   sync.RWMutex
+	lockLogin                   sync.RWMutex
+	lockLoginTwoFactor          sync.RWMutex
+	lockMarkConversationRead    sync.RWMutex
+	lockOAuthCallback           sync.RWMutex
+	lockOAuthLogin              sync.RWMutex
+	lockPublishQuotaResets      sync.RWMutex
+	lockPurchaseBoosts          sync.RWMutex
+	lockRedeemPromoCode         sync.RWMutex
+	lockRedeemReferral          sync.RWMutex
+	lockRenewSubscriptions      sync.RWMutex
+	lockReorderPhotos           sync.RWMutex
+	lockReportUser              sync.RWMutex
+	lockResolveReport           sync.RWMutex
+	lockSearchUsers             sync.RWMutex
+	lockSendDunningNotices      sync.RWMutex
+	lockSendMessage             sync.RWMutex
+	lockSetIncognito            sync.RWMutex
+	lockSetPremium              sync.RWMutex
+	lockSetPrimaryPhoto         sync.RWMutex
+	lockSetRole                 sync.RWMutex
+	lockShadowbanUser           sync.RWMutex
+	lockStartConversation       sync.RWMutex
+	lockSubscribeEvents         sync.RWMutex
+	lockSuspendUser             sync.RWMutex
+	lockSwipe                   sync.RWMutex
+	lockUnbanUser               sync.RWMutex
+	lockUnblockUser             sync.RWMutex
+	lockUndoSwipe               sync.RWMutex
+	lockUpdateSubscription      sync.RWMutex
+	lockUploadPhoto             sync.RWMutex
+	lockVerifyEmail             sync.RWMutex
+	lockVerifyReceipt           sync.RWMutex
+	lockVerifyTwoFactor         sync.RWMutex
+	lockViewProfile             sync.RWMutex
+}
+
+// ActivateBoost calls ActivateBoostFunc.
+func (mock *UsecasesMock) ActivateBoost(ctx context.Context, userID int64) (model.Boost, error) {
+	if mock.ActivateBoostFunc == nil {
+		panic("UsecasesMock.ActivateBoostFunc: method is nil but Usecases.ActivateBoost was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockActivateBoost.Lock()
+	mock.calls.ActivateBoost = append(mock.calls.ActivateBoost, callInfo)
+	mock.lockActivateBoost.Unlock()
+	return mock.ActivateBoostFunc(ctx, userID)
+}
+
+// ActivateBoostCalls gets all the calls that were made to ActivateBoost.
+// Check the length with:
+//
+//	len(mockedUsecases.ActivateBoostCalls())
+func (mock *UsecasesMock) ActivateBoostCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockActivateBoost.RLock()
+	calls = mock.calls.ActivateBoost
+	mock.lockActivateBoost.RUnlock()
+	return calls
+}
+
+// Authenticate calls AuthenticateFunc.
+func (mock *UsecasesMock) Authenticate(ctx context.Context, token string) (int64, error) {
+	if mock.AuthenticateFunc == nil {
+		panic("UsecasesMock.AuthenticateFunc: method is nil but Usecases.Authenticate was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Token string
+	}{
+		Ctx:   ctx,
+		Token: token,
+	}
+	mock.lockAuthenticate.Lock()
+	mock.calls.Authenticate = append(mock.calls.Authenticate, callInfo)
+	mock.lockAuthenticate.Unlock()
+	return mock.AuthenticateFunc(ctx, token)
+}
+
+// AuthenticateCalls gets all the calls that were made to Authenticate.
+// Check the length with:
+//
+//	len(mockedUsecases.AuthenticateCalls())
+func (mock *UsecasesMock) AuthenticateCalls() []struct {
+	Ctx   context.Context
+	Token string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Token string
+	}
+	mock.lockAuthenticate.RLock()
+	calls = mock.calls.Authenticate
+	mock.lockAuthenticate.RUnlock()
+	return calls
+}
+
+// BanUser calls BanUserFunc.
+func (mock *UsecasesMock) BanUser(ctx context.Context, req model.AdminUserRequest) error {
+	if mock.BanUserFunc == nil {
+		panic("UsecasesMock.BanUserFunc: method is nil but Usecases.BanUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.AdminUserRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockBanUser.Lock()
+	mock.calls.BanUser = append(mock.calls.BanUser, callInfo)
+	mock.lockBanUser.Unlock()
+	return mock.BanUserFunc(ctx, req)
+}
+
+// BanUserCalls gets all the calls that were made to BanUser.
+// Check the length with:
+//
+//	len(mockedUsecases.BanUserCalls())
+func (mock *UsecasesMock) BanUserCalls() []struct {
+	Ctx context.Context
+	Req model.AdminUserRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.AdminUserRequest
+	}
+	mock.lockBanUser.RLock()
+	calls = mock.calls.BanUser
+	mock.lockBanUser.RUnlock()
+	return calls
+}
+
+// BlockUser calls BlockUserFunc.
+func (mock *UsecasesMock) BlockUser(ctx context.Context, req model.BlockRequest) error {
+	if mock.BlockUserFunc == nil {
+		panic("UsecasesMock.BlockUserFunc: method is nil but Usecases.BlockUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.BlockRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockBlockUser.Lock()
+	mock.calls.BlockUser = append(mock.calls.BlockUser, callInfo)
+	mock.lockBlockUser.Unlock()
+	return mock.BlockUserFunc(ctx, req)
+}
+
+// BlockUserCalls gets all the calls that were made to BlockUser.
+// Check the length with:
+//
+//	len(mockedUsecases.BlockUserCalls())
+func (mock *UsecasesMock) BlockUserCalls() []struct {
+	Ctx context.Context
+	Req model.BlockRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.BlockRequest
+	}
+	mock.lockBlockUser.RLock()
+	calls = mock.calls.BlockUser
+	mock.lockBlockUser.RUnlock()
+	return calls
+}
+
+// Can calls CanFunc.
+func (mock *UsecasesMock) Can(ctx context.Context, userID int64, feature string) (bool, error) {
+	if mock.CanFunc == nil {
+		panic("UsecasesMock.CanFunc: method is nil but Usecases.Can was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		UserID  int64
+		Feature string
+	}{
+		Ctx:     ctx,
+		UserID:  userID,
+		Feature: feature,
+	}
+	mock.lockCan.Lock()
+	mock.calls.Can = append(mock.calls.Can, callInfo)
+	mock.lockCan.Unlock()
+	return mock.CanFunc(ctx, userID, feature)
+}
+
+// CanCalls gets all the calls that were made to Can.
+// Check the length with:
+//
+//	len(mockedUsecases.CanCalls())
+func (mock *UsecasesMock) CanCalls() []struct {
+	Ctx     context.Context
+	UserID  int64
+	Feature string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		UserID  int64
+		Feature string
+	}
+	mock.lockCan.RLock()
+	calls = mock.calls.Can
+	mock.lockCan.RUnlock()
+	return calls
+}
+
+// ChangeEmail calls ChangeEmailFunc.
+func (mock *UsecasesMock) ChangeEmail(ctx context.Context, req model.ChangeEmailRequest) error {
+	if mock.ChangeEmailFunc == nil {
+		panic("UsecasesMock.ChangeEmailFunc: method is nil but Usecases.ChangeEmail was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.ChangeEmailRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockChangeEmail.Lock()
+	mock.calls.ChangeEmail = append(mock.calls.ChangeEmail, callInfo)
+	mock.lockChangeEmail.Unlock()
+	return mock.ChangeEmailFunc(ctx, req)
+}
+
+// ChangeEmailCalls gets all the calls that were made to ChangeEmail.
+// Check the length with:
+//
+//	len(mockedUsecases.ChangeEmailCalls())
+func (mock *UsecasesMock) ChangeEmailCalls() []struct {
+	Ctx context.Context
+	Req model.ChangeEmailRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.ChangeEmailRequest
+	}
+	mock.lockChangeEmail.RLock()
+	calls = mock.calls.ChangeEmail
+	mock.lockChangeEmail.RUnlock()
+	return calls
+}
+
+// ChangePassword calls ChangePasswordFunc.
+func (mock *UsecasesMock) ChangePassword(ctx context.Context, req model.ChangePasswordRequest) error {
+	if mock.ChangePasswordFunc == nil {
+		panic("UsecasesMock.ChangePasswordFunc: method is nil but Usecases.ChangePassword was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.ChangePasswordRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockChangePassword.Lock()
+	mock.calls.ChangePassword = append(mock.calls.ChangePassword, callInfo)
+	mock.lockChangePassword.Unlock()
+	return mock.ChangePasswordFunc(ctx, req)
+}
+
+// ChangePasswordCalls gets all the calls that were made to ChangePassword.
+// Check the length with:
+//
+//	len(mockedUsecases.ChangePasswordCalls())
+func (mock *UsecasesMock) ChangePasswordCalls() []struct {
+	Ctx context.Context
+	Req model.ChangePasswordRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.ChangePasswordRequest
+	}
+	mock.lockChangePassword.RLock()
+	calls = mock.calls.ChangePassword
+	mock.lockChangePassword.RUnlock()
+	return calls
+}
+
+// ClaimReport calls ClaimReportFunc.
+func (mock *UsecasesMock) ClaimReport(ctx context.Context, req model.ReviewReportRequest) error {
+	if mock.ClaimReportFunc == nil {
+		panic("UsecasesMock.ClaimReportFunc: method is nil but Usecases.ClaimReport was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.ReviewReportRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockClaimReport.Lock()
+	mock.calls.ClaimReport = append(mock.calls.ClaimReport, callInfo)
+	mock.lockClaimReport.Unlock()
+	return mock.ClaimReportFunc(ctx, req)
+}
+
+// ClaimReportCalls gets all the calls that were made to ClaimReport.
+// Check the length with:
+//
+//	len(mockedUsecases.ClaimReportCalls())
+func (mock *UsecasesMock) ClaimReportCalls() []struct {
+	Ctx context.Context
+	Req model.ReviewReportRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.ReviewReportRequest
+	}
+	mock.lockClaimReport.RLock()
+	calls = mock.calls.ClaimReport
+	mock.lockClaimReport.RUnlock()
+	return calls
+}
+
+// CreatePromoCode calls CreatePromoCodeFunc.
+func (mock *UsecasesMock) CreatePromoCode(ctx context.Context, req model.CreatePromoCodeRequest) (model.PromoCode, error) {
+	if mock.CreatePromoCodeFunc == nil {
+		panic("UsecasesMock.CreatePromoCodeFunc: method is nil but Usecases.CreatePromoCode was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.CreatePromoCodeRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockCreatePromoCode.Lock()
+	mock.calls.CreatePromoCode = append(mock.calls.CreatePromoCode, callInfo)
+	mock.lockCreatePromoCode.Unlock()
+	return mock.CreatePromoCodeFunc(ctx, req)
+}
+
+// CreatePromoCodeCalls gets all the calls that were made to CreatePromoCode.
+// Check the length with:
+//
+//	len(mockedUsecases.CreatePromoCodeCalls())
+func (mock *UsecasesMock) CreatePromoCodeCalls() []struct {
+	Ctx context.Context
+	Req model.CreatePromoCodeRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.CreatePromoCodeRequest
+	}
+	mock.lockCreatePromoCode.RLock()
+	calls = mock.calls.CreatePromoCode
+	mock.lockCreatePromoCode.RUnlock()
+	return calls
+}
+
+// CreateUser calls CreateUserFunc.
+func (mock *UsecasesMock) CreateUser(ctx context.Context, req model.User) error {
+	if mock.CreateUserFunc == nil {
+		panic("UsecasesMock.CreateUserFunc: method is nil but Usecases.CreateUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.User
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockCreateUser.Lock()
+	mock.calls.CreateUser = append(mock.calls.CreateUser, callInfo)
+	mock.lockCreateUser.Unlock()
+	return mock.CreateUserFunc(ctx, req)
+}
+
+// CreateUserCalls gets all the calls that were made to CreateUser.
+// Check the length with:
+//
+//	len(mockedUsecases.CreateUserCalls())
+func (mock *UsecasesMock) CreateUserCalls() []struct {
+	Ctx context.Context
+	Req model.User
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.User
+	}
+	mock.lockCreateUser.RLock()
+	calls = mock.calls.CreateUser
+	mock.lockCreateUser.RUnlock()
+	return calls
+}
+
+// DeleteMessage calls DeleteMessageFunc.
+func (mock *UsecasesMock) DeleteMessage(ctx context.Context, req model.DeleteMessageRequest) error {
+	if mock.DeleteMessageFunc == nil {
+		panic("UsecasesMock.DeleteMessageFunc: method is nil but Usecases.DeleteMessage was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.DeleteMessageRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockDeleteMessage.Lock()
+	mock.calls.DeleteMessage = append(mock.calls.DeleteMessage, callInfo)
+	mock.lockDeleteMessage.Unlock()
+	return mock.DeleteMessageFunc(ctx, req)
+}
+
+// DeleteMessageCalls gets all the calls that were made to DeleteMessage.
+// Check the length with:
+//
+//	len(mockedUsecases.DeleteMessageCalls())
+func (mock *UsecasesMock) DeleteMessageCalls() []struct {
+	Ctx context.Context
+	Req model.DeleteMessageRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.DeleteMessageRequest
+	}
+	mock.lockDeleteMessage.RLock()
+	calls = mock.calls.DeleteMessage
+	mock.lockDeleteMessage.RUnlock()
+	return calls
+}
+
+// DeletePhoto calls DeletePhotoFunc.
+func (mock *UsecasesMock) DeletePhoto(ctx context.Context, req model.PhotoRequest) error {
+	if mock.DeletePhotoFunc == nil {
+		panic("UsecasesMock.DeletePhotoFunc: method is nil but Usecases.DeletePhoto was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.PhotoRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockDeletePhoto.Lock()
+	mock.calls.DeletePhoto = append(mock.calls.DeletePhoto, callInfo)
+	mock.lockDeletePhoto.Unlock()
+	return mock.DeletePhotoFunc(ctx, req)
+}
+
+// DeletePhotoCalls gets all the calls that were made to DeletePhoto.
+// Check the length with:
+//
+//	len(mockedUsecases.DeletePhotoCalls())
+func (mock *UsecasesMock) DeletePhotoCalls() []struct {
+	Ctx context.Context
+	Req model.PhotoRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.PhotoRequest
+	}
+	mock.lockDeletePhoto.RLock()
+	calls = mock.calls.DeletePhoto
+	mock.lockDeletePhoto.RUnlock()
+	return calls
+}
+
+// EndGracePeriods calls EndGracePeriodsFunc.
+func (mock *UsecasesMock) EndGracePeriods(ctx context.Context, now time.Time) error {
+	if mock.EndGracePeriodsFunc == nil {
+		panic("UsecasesMock.EndGracePeriodsFunc: method is nil but Usecases.EndGracePeriods was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Now time.Time
+	}{
+		Ctx: ctx,
+		Now: now,
+	}
+	mock.lockEndGracePeriods.Lock()
+	mock.calls.EndGracePeriods = append(mock.calls.EndGracePeriods, callInfo)
+	mock.lockEndGracePeriods.Unlock()
+	return mock.EndGracePeriodsFunc(ctx, now)
+}
+
+// EndGracePeriodsCalls gets all the calls that were made to EndGracePeriods.
+// Check the length with:
+//
+//	len(mockedUsecases.EndGracePeriodsCalls())
+func (mock *UsecasesMock) EndGracePeriodsCalls() []struct {
+	Ctx context.Context
+	Now time.Time
+} {
+	var calls []struct {
+		Ctx context.Context
+		Now time.Time
+	}
+	mock.lockEndGracePeriods.RLock()
+	calls = mock.calls.EndGracePeriods
+	mock.lockEndGracePeriods.RUnlock()
+	return calls
+}
+
+// EnrollTwoFactor calls EnrollTwoFactorFunc.
+func (mock *UsecasesMock) EnrollTwoFactor(ctx context.Context, userID int64) (model.TwoFactorEnrollResponse, error) {
+	if mock.EnrollTwoFactorFunc == nil {
+		panic("UsecasesMock.EnrollTwoFactorFunc: method is nil but Usecases.EnrollTwoFactor was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockEnrollTwoFactor.Lock()
+	mock.calls.EnrollTwoFactor = append(mock.calls.EnrollTwoFactor, callInfo)
+	mock.lockEnrollTwoFactor.Unlock()
+	return mock.EnrollTwoFactorFunc(ctx, userID)
+}
+
+// EnrollTwoFactorCalls gets all the calls that were made to EnrollTwoFactor.
+// Check the length with:
+//
+//	len(mockedUsecases.EnrollTwoFactorCalls())
+func (mock *UsecasesMock) EnrollTwoFactorCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockEnrollTwoFactor.RLock()
+	calls = mock.calls.EnrollTwoFactor
+	mock.lockEnrollTwoFactor.RUnlock()
+	return calls
+}
+
+// EscalateReport calls EscalateReportFunc.
+func (mock *UsecasesMock) EscalateReport(ctx context.Context, req model.ReviewReportRequest) error {
+	if mock.EscalateReportFunc == nil {
+		panic("UsecasesMock.EscalateReportFunc: method is nil but Usecases.EscalateReport was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.ReviewReportRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockEscalateReport.Lock()
+	mock.calls.EscalateReport = append(mock.calls.EscalateReport, callInfo)
+	mock.lockEscalateReport.Unlock()
+	return mock.EscalateReportFunc(ctx, req)
+}
+
+// EscalateReportCalls gets all the calls that were made to EscalateReport.
+// Check the length with:
+//
+//	len(mockedUsecases.EscalateReportCalls())
+func (mock *UsecasesMock) EscalateReportCalls() []struct {
+	Ctx context.Context
+	Req model.ReviewReportRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.ReviewReportRequest
+	}
+	mock.lockEscalateReport.RLock()
+	calls = mock.calls.EscalateReport
+	mock.lockEscalateReport.RUnlock()
+	return calls
+}
+
+// ExpireSubscriptions calls ExpireSubscriptionsFunc.
+func (mock *UsecasesMock) ExpireSubscriptions(ctx context.Context, now time.Time) error {
+	if mock.ExpireSubscriptionsFunc == nil {
+		panic("UsecasesMock.ExpireSubscriptionsFunc: method is nil but Usecases.ExpireSubscriptions was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Now time.Time
+	}{
+		Ctx: ctx,
+		Now: now,
+	}
+	mock.lockExpireSubscriptions.Lock()
+	mock.calls.ExpireSubscriptions = append(mock.calls.ExpireSubscriptions, callInfo)
+	mock.lockExpireSubscriptions.Unlock()
+	return mock.ExpireSubscriptionsFunc(ctx, now)
+}
+
+// ExpireSubscriptionsCalls gets all the calls that were made to ExpireSubscriptions.
+// Check the length with:
+//
+//	len(mockedUsecases.ExpireSubscriptionsCalls())
+func (mock *UsecasesMock) ExpireSubscriptionsCalls() []struct {
+	Ctx context.Context
+	Now time.Time
+} {
+	var calls []struct {
+		Ctx context.Context
+		Now time.Time
+	}
+	mock.lockExpireSubscriptions.RLock()
+	calls = mock.calls.ExpireSubscriptions
+	mock.lockExpireSubscriptions.RUnlock()
+	return calls
+}
+
+// FlushProfileViews calls FlushProfileViewsFunc.
+func (mock *UsecasesMock) FlushProfileViews(ctx context.Context) error {
+	if mock.FlushProfileViewsFunc == nil {
+		panic("UsecasesMock.FlushProfileViewsFunc: method is nil but Usecases.FlushProfileViews was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockFlushProfileViews.Lock()
+	mock.calls.FlushProfileViews = append(mock.calls.FlushProfileViews, callInfo)
+	mock.lockFlushProfileViews.Unlock()
+	return mock.FlushProfileViewsFunc(ctx)
+}
+
+// FlushProfileViewsCalls gets all the calls that were made to FlushProfileViews.
+// Check the length with:
+//
+//	len(mockedUsecases.FlushProfileViewsCalls())
+func (mock *UsecasesMock) FlushProfileViewsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockFlushProfileViews.RLock()
+	calls = mock.calls.FlushProfileViews
+	mock.lockFlushProfileViews.RUnlock()
+	return calls
+}
+
+// ForceLogout calls ForceLogoutFunc.
+func (mock *UsecasesMock) ForceLogout(ctx context.Context, req model.AdminUserRequest) error {
+	if mock.ForceLogoutFunc == nil {
+		panic("UsecasesMock.ForceLogoutFunc: method is nil but Usecases.ForceLogout was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.AdminUserRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockForceLogout.Lock()
+	mock.calls.ForceLogout = append(mock.calls.ForceLogout, callInfo)
+	mock.lockForceLogout.Unlock()
+	return mock.ForceLogoutFunc(ctx, req)
+}
+
+// ForceLogoutCalls gets all the calls that were made to ForceLogout.
+// Check the length with:
+//
+//	len(mockedUsecases.ForceLogoutCalls())
+func (mock *UsecasesMock) ForceLogoutCalls() []struct {
+	Ctx context.Context
+	Req model.AdminUserRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.AdminUserRequest
+	}
+	mock.lockForceLogout.RLock()
+	calls = mock.calls.ForceLogout
+	mock.lockForceLogout.RUnlock()
+	return calls
+}
+
+// GetAccountStatusHistory calls GetAccountStatusHistoryFunc.
+func (mock *UsecasesMock) GetAccountStatusHistory(ctx context.Context, req model.AdminUserRequest) ([]model.AccountStatusChange, error) {
+	if mock.GetAccountStatusHistoryFunc == nil {
+		panic("UsecasesMock.GetAccountStatusHistoryFunc: method is nil but Usecases.GetAccountStatusHistory was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.AdminUserRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockGetAccountStatusHistory.Lock()
+	mock.calls.GetAccountStatusHistory = append(mock.calls.GetAccountStatusHistory, callInfo)
+	mock.lockGetAccountStatusHistory.Unlock()
+	return mock.GetAccountStatusHistoryFunc(ctx, req)
+}
+
+// GetAccountStatusHistoryCalls gets all the calls that were made to GetAccountStatusHistory.
+// Check the length with:
+//
+//	len(mockedUsecases.GetAccountStatusHistoryCalls())
+func (mock *UsecasesMock) GetAccountStatusHistoryCalls() []struct {
+	Ctx context.Context
+	Req model.AdminUserRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.AdminUserRequest
+	}
+	mock.lockGetAccountStatusHistory.RLock()
+	calls = mock.calls.GetAccountStatusHistory
+	mock.lockGetAccountStatusHistory.RUnlock()
+	return calls
+}
+
+// GetBillingHistory calls GetBillingHistoryFunc.
+func (mock *UsecasesMock) GetBillingHistory(ctx context.Context, req model.BillingHistoryRequest) (model.BillingHistoryResponse, error) {
+	if mock.GetBillingHistoryFunc == nil {
+		panic("UsecasesMock.GetBillingHistoryFunc: method is nil but Usecases.GetBillingHistory was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.BillingHistoryRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockGetBillingHistory.Lock()
+	mock.calls.GetBillingHistory = append(mock.calls.GetBillingHistory, callInfo)
+	mock.lockGetBillingHistory.Unlock()
+	return mock.GetBillingHistoryFunc(ctx, req)
+}
+
+// GetBillingHistoryCalls gets all the calls that were made to GetBillingHistory.
+// Check the length with:
+//
+//	len(mockedUsecases.GetBillingHistoryCalls())
+func (mock *UsecasesMock) GetBillingHistoryCalls() []struct {
+	Ctx context.Context
+	Req model.BillingHistoryRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.BillingHistoryRequest
+	}
+	mock.lockGetBillingHistory.RLock()
+	calls = mock.calls.GetBillingHistory
+	mock.lockGetBillingHistory.RUnlock()
+	return calls
+}
+
+// GetBoosts calls GetBoostsFunc.
+func (mock *UsecasesMock) GetBoosts(ctx context.Context, userID int64) (model.BoostInventory, error) {
+	if mock.GetBoostsFunc == nil {
+		panic("UsecasesMock.GetBoostsFunc: method is nil but Usecases.GetBoosts was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetBoosts.Lock()
+	mock.calls.GetBoosts = append(mock.calls.GetBoosts, callInfo)
+	mock.lockGetBoosts.Unlock()
+	return mock.GetBoostsFunc(ctx, userID)
+}
+
+// GetBoostsCalls gets all the calls that were made to GetBoosts.
+// Check the length with:
+//
+//	len(mockedUsecases.GetBoostsCalls())
+func (mock *UsecasesMock) GetBoostsCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetBoosts.RLock()
+	calls = mock.calls.GetBoosts
+	mock.lockGetBoosts.RUnlock()
+	return calls
+}
+
+// GetConversations calls GetConversationsFunc.
+func (mock *UsecasesMock) GetConversations(ctx context.Context, userID int64) ([]model.Conversation, error) {
+	if mock.GetConversationsFunc == nil {
+		panic("UsecasesMock.GetConversationsFunc: method is nil but Usecases.GetConversations was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetConversations.Lock()
+	mock.calls.GetConversations = append(mock.calls.GetConversations, callInfo)
+	mock.lockGetConversations.Unlock()
+	return mock.GetConversationsFunc(ctx, userID)
+}
+
+// GetConversationsCalls gets all the calls that were made to GetConversations.
+// Check the length with:
+//
+//	len(mockedUsecases.GetConversationsCalls())
+func (mock *UsecasesMock) GetConversationsCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetConversations.RLock()
+	calls = mock.calls.GetConversations
+	mock.lockGetConversations.RUnlock()
+	return calls
+}
+
+// GetEntitlements calls GetEntitlementsFunc.
+func (mock *UsecasesMock) GetEntitlements(ctx context.Context, userID int64) (model.Entitlements, error) {
+	if mock.GetEntitlementsFunc == nil {
+		panic("UsecasesMock.GetEntitlementsFunc: method is nil but Usecases.GetEntitlements was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetEntitlements.Lock()
+	mock.calls.GetEntitlements = append(mock.calls.GetEntitlements, callInfo)
+	mock.lockGetEntitlements.Unlock()
+	return mock.GetEntitlementsFunc(ctx, userID)
+}
+
+// GetEntitlementsCalls gets all the calls that were made to GetEntitlements.
+// Check the length with:
+//
+//	len(mockedUsecases.GetEntitlementsCalls())
+func (mock *UsecasesMock) GetEntitlementsCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetEntitlements.RLock()
+	calls = mock.calls.GetEntitlements
+	mock.lockGetEntitlements.RUnlock()
+	return calls
+}
+
+// GetInvoice calls GetInvoiceFunc.
+func (mock *UsecasesMock) GetInvoice(ctx context.Context, req model.InvoiceRequest) (model.InvoiceDocument, error) {
+	if mock.GetInvoiceFunc == nil {
+		panic("UsecasesMock.GetInvoiceFunc: method is nil but Usecases.GetInvoice was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.InvoiceRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockGetInvoice.Lock()
+	mock.calls.GetInvoice = append(mock.calls.GetInvoice, callInfo)
+	mock.lockGetInvoice.Unlock()
+	return mock.GetInvoiceFunc(ctx, req)
+}
+
+// GetInvoiceCalls gets all the calls that were made to GetInvoice.
+// Check the length with:
+//
+//	len(mockedUsecases.GetInvoiceCalls())
+func (mock *UsecasesMock) GetInvoiceCalls() []struct {
+	Ctx context.Context
+	Req model.InvoiceRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.InvoiceRequest
+	}
+	mock.lockGetInvoice.RLock()
+	calls = mock.calls.GetInvoice
+	mock.lockGetInvoice.RUnlock()
+	return calls
+}
+
+// GetLikers calls GetLikersFunc.
+func (mock *UsecasesMock) GetLikers(ctx context.Context, userID int64) (model.LikersResponse, error) {
+	if mock.GetLikersFunc == nil {
+		panic("UsecasesMock.GetLikersFunc: method is nil but Usecases.GetLikers was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetLikers.Lock()
+	mock.calls.GetLikers = append(mock.calls.GetLikers, callInfo)
+	mock.lockGetLikers.Unlock()
+	return mock.GetLikersFunc(ctx, userID)
+}
+
+// GetLikersCalls gets all the calls that were made to GetLikers.
+// Check the length with:
+//
+//	len(mockedUsecases.GetLikersCalls())
+func (mock *UsecasesMock) GetLikersCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetLikers.RLock()
+	calls = mock.calls.GetLikers
+	mock.lockGetLikers.RUnlock()
+	return calls
+}
+
+// GetMessages calls GetMessagesFunc.
+func (mock *UsecasesMock) GetMessages(ctx context.Context, req model.GetMessagesRequest) (model.GetMessagesResponse, error) {
+	if mock.GetMessagesFunc == nil {
+		panic("UsecasesMock.GetMessagesFunc: method is nil but Usecases.GetMessages was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.GetMessagesRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockGetMessages.Lock()
+	mock.calls.GetMessages = append(mock.calls.GetMessages, callInfo)
+	mock.lockGetMessages.Unlock()
+	return mock.GetMessagesFunc(ctx, req)
+}
+
+// GetMessagesCalls gets all the calls that were made to GetMessages.
+// Check the length with:
+//
+//	len(mockedUsecases.GetMessagesCalls())
+func (mock *UsecasesMock) GetMessagesCalls() []struct {
+	Ctx context.Context
+	Req model.GetMessagesRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.GetMessagesRequest
+	}
+	mock.lockGetMessages.RLock()
+	calls = mock.calls.GetMessages
+	mock.lockGetMessages.RUnlock()
+	return calls
+}
+
+// GetPhotos calls GetPhotosFunc.
+func (mock *UsecasesMock) GetPhotos(ctx context.Context, userID int64) ([]model.Photo, error) {
+	if mock.GetPhotosFunc == nil {
+		panic("UsecasesMock.GetPhotosFunc: method is nil but Usecases.GetPhotos was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetPhotos.Lock()
+	mock.calls.GetPhotos = append(mock.calls.GetPhotos, callInfo)
+	mock.lockGetPhotos.Unlock()
+	return mock.GetPhotosFunc(ctx, userID)
+}
+
+// GetPhotosCalls gets all the calls that were made to GetPhotos.
+// Check the length with:
+//
+//	len(mockedUsecases.GetPhotosCalls())
+func (mock *UsecasesMock) GetPhotosCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetPhotos.RLock()
+	calls = mock.calls.GetPhotos
+	mock.lockGetPhotos.RUnlock()
+	return calls
+}
+
+// GetProfileViewers calls GetProfileViewersFunc.
+func (mock *UsecasesMock) GetProfileViewers(ctx context.Context, userID int64) (model.ProfileViewersResponse, error) {
+	if mock.GetProfileViewersFunc == nil {
+		panic("UsecasesMock.GetProfileViewersFunc: method is nil but Usecases.GetProfileViewers was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetProfileViewers.Lock()
+	mock.calls.GetProfileViewers = append(mock.calls.GetProfileViewers, callInfo)
+	mock.lockGetProfileViewers.Unlock()
+	return mock.GetProfileViewersFunc(ctx, userID)
+}
+
+// GetProfileViewersCalls gets all the calls that were made to GetProfileViewers.
+// Check the length with:
+//
+//	len(mockedUsecases.GetProfileViewersCalls())
+func (mock *UsecasesMock) GetProfileViewersCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetProfileViewers.RLock()
+	calls = mock.calls.GetProfileViewers
+	mock.lockGetProfileViewers.RUnlock()
+	return calls
+}
+
+// GetProfiles calls GetProfilesFunc.
+func (mock *UsecasesMock) GetProfiles(ctx context.Context, req model.GetRelatedUserRequest) ([]model.Profile, error) {
+	if mock.GetProfilesFunc == nil {
+		panic("UsecasesMock.GetProfilesFunc: method is nil but Usecases.GetProfiles was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.GetRelatedUserRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockGetProfiles.Lock()
+	mock.calls.GetProfiles = append(mock.calls.GetProfiles, callInfo)
+	mock.lockGetProfiles.Unlock()
+	return mock.GetProfilesFunc(ctx, req)
+}
+
+// GetProfilesCalls gets all the calls that were made to GetProfiles.
+// Check the length with:
+//
+//	len(mockedUsecases.GetProfilesCalls())
+func (mock *UsecasesMock) GetProfilesCalls() []struct {
+	Ctx context.Context
+	Req model.GetRelatedUserRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.GetRelatedUserRequest
+	}
+	mock.lockGetProfiles.RLock()
+	calls = mock.calls.GetProfiles
+	mock.lockGetProfiles.RUnlock()
+	return calls
+}
+
+// GetReferral calls GetReferralFunc.
+func (mock *UsecasesMock) GetReferral(ctx context.Context, userID int64) (model.ReferralResponse, error) {
+	if mock.GetReferralFunc == nil {
+		panic("UsecasesMock.GetReferralFunc: method is nil but Usecases.GetReferral was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetReferral.Lock()
+	mock.calls.GetReferral = append(mock.calls.GetReferral, callInfo)
+	mock.lockGetReferral.Unlock()
+	return mock.GetReferralFunc(ctx, userID)
+}
+
+// GetReferralCalls gets all the calls that were made to GetReferral.
+// Check the length with:
+//
+//	len(mockedUsecases.GetReferralCalls())
+func (mock *UsecasesMock) GetReferralCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetReferral.RLock()
+	calls = mock.calls.GetReferral
+	mock.lockGetReferral.RUnlock()
+	return calls
+}
+
+// GetReports calls GetReportsFunc.
+func (mock *UsecasesMock) GetReports(ctx context.Context, req model.GetReportsRequest) ([]model.Report, error) {
+	if mock.GetReportsFunc == nil {
+		panic("UsecasesMock.GetReportsFunc: method is nil but Usecases.GetReports was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.GetReportsRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockGetReports.Lock()
+	mock.calls.GetReports = append(mock.calls.GetReports, callInfo)
+	mock.lockGetReports.Unlock()
+	return mock.GetReportsFunc(ctx, req)
+}
+
+// GetReportsCalls gets all the calls that were made to GetReports.
+// Check the length with:
+//
+//	len(mockedUsecases.GetReportsCalls())
+func (mock *UsecasesMock) GetReportsCalls() []struct {
+	Ctx context.Context
+	Req model.GetReportsRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.GetReportsRequest
+	}
+	mock.lockGetReports.RLock()
+	calls = mock.calls.GetReports
+	mock.lockGetReports.RUnlock()
+	return calls
+}
+
+// GetSubscription calls GetSubscriptionFunc.
+func (mock *UsecasesMock) GetSubscription(ctx context.Context, userID int64) (model.Subscription, error) {
+	if mock.GetSubscriptionFunc == nil {
+		panic("UsecasesMock.GetSubscriptionFunc: method is nil but Usecases.GetSubscription was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockGetSubscription.Lock()
+	mock.calls.GetSubscription = append(mock.calls.GetSubscription, callInfo)
+	mock.lockGetSubscription.Unlock()
+	return mock.GetSubscriptionFunc(ctx, userID)
+}
+
+// GetSubscriptionCalls gets all the calls that were made to GetSubscription.
+// Check the length with:
+//
+//	len(mockedUsecases.GetSubscriptionCalls())
+func (mock *UsecasesMock) GetSubscriptionCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+	}
+	mock.lockGetSubscription.RLock()
+	calls = mock.calls.GetSubscription
+	mock.lockGetSubscription.RUnlock()
+	return calls
+}
+
+// GetUserProfile calls GetUserProfileFunc.
+func (mock *UsecasesMock) GetUserProfile(ctx context.Context, req model.AdminUserRequest) (model.AdminUserProfile, error) {
+	if mock.GetUserProfileFunc == nil {
+		panic("UsecasesMock.GetUserProfileFunc: method is nil but Usecases.GetUserProfile was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.AdminUserRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockGetUserProfile.Lock()
+	mock.calls.GetUserProfile = append(mock.calls.GetUserProfile, callInfo)
+	mock.lockGetUserProfile.Unlock()
+	return mock.GetUserProfileFunc(ctx, req)
+}
+
+// GetUserProfileCalls gets all the calls that were made to GetUserProfile.
+// Check the length with:
+//
+//	len(mockedUsecases.GetUserProfileCalls())
+func (mock *UsecasesMock) GetUserProfileCalls() []struct {
+	Ctx context.Context
+	Req model.AdminUserRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.AdminUserRequest
+	}
+	mock.lockGetUserProfile.RLock()
+	calls = mock.calls.GetUserProfile
+	mock.lockGetUserProfile.RUnlock()
+	return calls
+}
+
+// HandlePaymentWebhook calls HandlePaymentWebhookFunc.
+func (mock *UsecasesMock) HandlePaymentWebhook(ctx context.Context, req model.PaymentWebhookRequest) error {
+	if mock.HandlePaymentWebhookFunc == nil {
+		panic("UsecasesMock.HandlePaymentWebhookFunc: method is nil but Usecases.HandlePaymentWebhook was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.PaymentWebhookRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockHandlePaymentWebhook.Lock()
+	mock.calls.HandlePaymentWebhook = append(mock.calls.HandlePaymentWebhook, callInfo)
+	mock.lockHandlePaymentWebhook.Unlock()
+	return mock.HandlePaymentWebhookFunc(ctx, req)
+}
+
+// HandlePaymentWebhookCalls gets all the calls that were made to HandlePaymentWebhook.
+// Check the length with:
+//
+//	len(mockedUsecases.HandlePaymentWebhookCalls())
+func (mock *UsecasesMock) HandlePaymentWebhookCalls() []struct {
+	Ctx context.Context
+	Req model.PaymentWebhookRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.PaymentWebhookRequest
+	}
+	mock.lockHandlePaymentWebhook.RLock()
+	calls = mock.calls.HandlePaymentWebhook
+	mock.lockHandlePaymentWebhook.RUnlock()
+	return calls
+}
+
+// HandleStoreNotification calls HandleStoreNotificationFunc.
+func (mock *UsecasesMock) HandleStoreNotification(ctx context.Context, req model.StoreNotificationRequest) error {
+	if mock.HandleStoreNotificationFunc == nil {
+		panic("UsecasesMock.HandleStoreNotificationFunc: method is nil but Usecases.HandleStoreNotification was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.StoreNotificationRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockHandleStoreNotification.Lock()
+	mock.calls.HandleStoreNotification = append(mock.calls.HandleStoreNotification, callInfo)
+	mock.lockHandleStoreNotification.Unlock()
+	return mock.HandleStoreNotificationFunc(ctx, req)
+}
+
+// HandleStoreNotificationCalls gets all the calls that were made to HandleStoreNotification.
+// Check the length with:
+//
+//	len(mockedUsecases.HandleStoreNotificationCalls())
+func (mock *UsecasesMock) HandleStoreNotificationCalls() []struct {
+	Ctx context.Context
+	Req model.StoreNotificationRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.StoreNotificationRequest
+	}
+	mock.lockHandleStoreNotification.RLock()
+	calls = mock.calls.HandleStoreNotification
+	mock.lockHandleStoreNotification.RUnlock()
+	return calls
+}
+
+// HasRole calls HasRoleFunc.
+func (mock *UsecasesMock) HasRole(ctx context.Context, userID int64, role string) (bool, error) {
+	if mock.HasRoleFunc == nil {
+		panic("UsecasesMock.HasRoleFunc: method is nil but Usecases.HasRole was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+		Role   string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Role:   role,
+	}
+	mock.lockHasRole.Lock()
+	mock.calls.HasRole = append(mock.calls.HasRole, callInfo)
+	mock.lockHasRole.Unlock()
+	return mock.HasRoleFunc(ctx, userID, role)
+}
+
+// HasRoleCalls gets all the calls that were made to HasRole.
+// Check the length with:
+//
+//	len(mockedUsecases.HasRoleCalls())
+func (mock *UsecasesMock) HasRoleCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+	Role   string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+		Role   string
+	}
+	mock.lockHasRole.RLock()
+	calls = mock.calls.HasRole
+	mock.lockHasRole.RUnlock()
+	return calls
+}
+
+// Limit calls LimitFunc.
+func (mock *UsecasesMock) Limit(ctx context.Context, userID int64, limit string) (int, error) {
+	if mock.LimitFunc == nil {
+		panic("UsecasesMock.LimitFunc: method is nil but Usecases.Limit was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID int64
+		Limit  string
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Limit:  limit,
+	}
+	mock.lockLimit.Lock()
+	mock.calls.Limit = append(mock.calls.Limit, callInfo)
+	mock.lockLimit.Unlock()
+	return mock.LimitFunc(ctx, userID, limit)
+}
+
+// LimitCalls gets all the calls that were made to Limit.
+// Check the length with:
+//
+//	len(mockedUsecases.LimitCalls())
+func (mock *UsecasesMock) LimitCalls() []struct {
+	Ctx    context.Context
+	UserID int64
+	Limit  string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID int64
+		Limit  string
+	}
+	mock.lockLimit.RLock()
+	calls = mock.calls.Limit
+	mock.lockLimit.RUnlock()
+	return calls
+}
+
+// Login calls LoginFunc.
+func (mock *UsecasesMock) Login(ctx context.Context, req model.LoginRequest) (model.LoginResponse, error) {
+	if mock.LoginFunc == nil {
+		panic("UsecasesMock.LoginFunc: method is nil but Usecases.Login was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.LoginRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockLogin.Lock()
+	mock.calls.Login = append(mock.calls.Login, callInfo)
+	mock.lockLogin.Unlock()
+	return mock.LoginFunc(ctx, req)
+}
+
+// LoginCalls gets all the calls that were made to Login.
+// Check the length with:
+//
+//	len(mockedUsecases.LoginCalls())
+func (mock *UsecasesMock) LoginCalls() []struct {
+	Ctx context.Context
+	Req model.LoginRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.LoginRequest
+	}
+	mock.lockLogin.RLock()
+	calls = mock.calls.Login
+	mock.lockLogin.RUnlock()
+	return calls
+}
+
+// LoginTwoFactor calls LoginTwoFactorFunc.
+func (mock *UsecasesMock) LoginTwoFactor(ctx context.Context, req model.LoginTwoFactorRequest) (model.LoginResponse, error) {
+	if mock.LoginTwoFactorFunc == nil {
+		panic("UsecasesMock.LoginTwoFactorFunc: method is nil but Usecases.LoginTwoFactor was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.LoginTwoFactorRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockLoginTwoFactor.Lock()
+	mock.calls.LoginTwoFactor = append(mock.calls.LoginTwoFactor, callInfo)
+	mock.lockLoginTwoFactor.Unlock()
+	return mock.LoginTwoFactorFunc(ctx, req)
+}
+
+// LoginTwoFactorCalls gets all the calls that were made to LoginTwoFactor.
+// Check the length with:
+//
+//	len(mockedUsecases.LoginTwoFactorCalls())
+func (mock *UsecasesMock) LoginTwoFactorCalls() []struct {
+	Ctx context.Context
+	Req model.LoginTwoFactorRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.LoginTwoFactorRequest
+	}
+	mock.lockLoginTwoFactor.RLock()
+	calls = mock.calls.LoginTwoFactor
+	mock.lockLoginTwoFactor.RUnlock()
+	return calls
+}
+
+// MarkConversationRead calls MarkConversationReadFunc.
+func (mock *UsecasesMock) MarkConversationRead(ctx context.Context, req model.MarkReadRequest) error {
+	if mock.MarkConversationReadFunc == nil {
+		panic("UsecasesMock.MarkConversationReadFunc: method is nil but Usecases.MarkConversationRead was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.MarkReadRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockMarkConversationRead.Lock()
+	mock.calls.MarkConversationRead = append(mock.calls.MarkConversationRead, callInfo)
+	mock.lockMarkConversationRead.Unlock()
+	return mock.MarkConversationReadFunc(ctx, req)
+}
+
+// MarkConversationReadCalls gets all the calls that were made to MarkConversationRead.
+// Check the length with:
+//
+//	len(mockedUsecases.MarkConversationReadCalls())
+func (mock *UsecasesMock) MarkConversationReadCalls() []struct {
+	Ctx context.Context
+	Req model.MarkReadRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.MarkReadRequest
+	}
+	mock.lockMarkConversationRead.RLock()
+	calls = mock.calls.MarkConversationRead
+	mock.lockMarkConversationRead.RUnlock()
+	return calls
+}
+
+// OAuthCallback calls OAuthCallbackFunc.
+func (mock *UsecasesMock) OAuthCallback(ctx context.Context, req model.OAuthCallbackRequest) (model.LoginResponse, error) {
+	if mock.OAuthCallbackFunc == nil {
+		panic("UsecasesMock.OAuthCallbackFunc: method is nil but Usecases.OAuthCallback was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.OAuthCallbackRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockOAuthCallback.Lock()
+	mock.calls.OAuthCallback = append(mock.calls.OAuthCallback, callInfo)
+	mock.lockOAuthCallback.Unlock()
+	return mock.OAuthCallbackFunc(ctx, req)
+}
+
+// OAuthCallbackCalls gets all the calls that were made to OAuthCallback.
+// Check the length with:
+//
+//	len(mockedUsecases.OAuthCallbackCalls())
+func (mock *UsecasesMock) OAuthCallbackCalls() []struct {
+	Ctx context.Context
+	Req model.OAuthCallbackRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.OAuthCallbackRequest
+	}
+	mock.lockOAuthCallback.RLock()
+	calls = mock.calls.OAuthCallback
+	mock.lockOAuthCallback.RUnlock()
+	return calls
+}
+
+// OAuthLogin calls OAuthLoginFunc.
+func (mock *UsecasesMock) OAuthLogin(ctx context.Context, req model.OAuthLoginRequest) (model.OAuthLoginResponse, error) {
+	if mock.OAuthLoginFunc == nil {
+		panic("UsecasesMock.OAuthLoginFunc: method is nil but Usecases.OAuthLogin was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.OAuthLoginRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockOAuthLogin.Lock()
+	mock.calls.OAuthLogin = append(mock.calls.OAuthLogin, callInfo)
+	mock.lockOAuthLogin.Unlock()
+	return mock.OAuthLoginFunc(ctx, req)
+}
+
+// OAuthLoginCalls gets all the calls that were made to OAuthLogin.
+// Check the length with:
+//
+//	len(mockedUsecases.OAuthLoginCalls())
+func (mock *UsecasesMock) OAuthLoginCalls() []struct {
+	Ctx context.Context
+	Req model.OAuthLoginRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.OAuthLoginRequest
+	}
+	mock.lockOAuthLogin.RLock()
+	calls = mock.calls.OAuthLogin
+	mock.lockOAuthLogin.RUnlock()
+	return calls
+}
+
+// PublishQuotaResets calls PublishQuotaResetsFunc.
+func (mock *UsecasesMock) PublishQuotaResets(ctx context.Context, now time.Time) error {
+	if mock.PublishQuotaResetsFunc == nil {
+		panic("UsecasesMock.PublishQuotaResetsFunc: method is nil but Usecases.PublishQuotaResets was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Now time.Time
+	}{
+		Ctx: ctx,
+		Now: now,
+	}
+	mock.lockPublishQuotaResets.Lock()
+	mock.calls.PublishQuotaResets = append(mock.calls.PublishQuotaResets, callInfo)
+	mock.lockPublishQuotaResets.Unlock()
+	return mock.PublishQuotaResetsFunc(ctx, now)
+}
+
+// PublishQuotaResetsCalls gets all the calls that were made to PublishQuotaResets.
+// Check the length with:
+//
+//	len(mockedUsecases.PublishQuotaResetsCalls())
+func (mock *UsecasesMock) PublishQuotaResetsCalls() []struct {
+	Ctx context.Context
+	Now time.Time
+} {
+	var calls []struct {
+		Ctx context.Context
+		Now time.Time
+	}
+	mock.lockPublishQuotaResets.RLock()
+	calls = mock.calls.PublishQuotaResets
+	mock.lockPublishQuotaResets.RUnlock()
+	return calls
+}
+
+// PurchaseBoosts calls PurchaseBoostsFunc.
+func (mock *UsecasesMock) PurchaseBoosts(ctx context.Context, req model.ReceiptRequest) (model.BoostInventory, error) {
+	if mock.PurchaseBoostsFunc == nil {
+		panic("UsecasesMock.PurchaseBoostsFunc: method is nil but Usecases.PurchaseBoosts was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.ReceiptRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockPurchaseBoosts.Lock()
+	mock.calls.PurchaseBoosts = append(mock.calls.PurchaseBoosts, callInfo)
+	mock.lockPurchaseBoosts.Unlock()
+	return mock.PurchaseBoostsFunc(ctx, req)
+}
+
+// PurchaseBoostsCalls gets all the calls that were made to PurchaseBoosts.
+// Check the length with:
+//
+//	len(mockedUsecases.PurchaseBoostsCalls())
+func (mock *UsecasesMock) PurchaseBoostsCalls() []struct {
+	Ctx context.Context
+	Req model.ReceiptRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.ReceiptRequest
+	}
+	mock.lockPurchaseBoosts.RLock()
+	calls = mock.calls.PurchaseBoosts
+	mock.lockPurchaseBoosts.RUnlock()
+	return calls
+}
+
+// RedeemPromoCode calls RedeemPromoCodeFunc.
+func (mock *UsecasesMock) RedeemPromoCode(ctx context.Context, req model.RedeemRequest) (model.RedeemPromoCodeResponse, error) {
+	if mock.RedeemPromoCodeFunc == nil {
+		panic("UsecasesMock.RedeemPromoCodeFunc: method is nil but Usecases.RedeemPromoCode was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.RedeemRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockRedeemPromoCode.Lock()
+	mock.calls.RedeemPromoCode = append(mock.calls.RedeemPromoCode, callInfo)
+	mock.lockRedeemPromoCode.Unlock()
+	return mock.RedeemPromoCodeFunc(ctx, req)
+}
+
+// RedeemPromoCodeCalls gets all the calls that were made to RedeemPromoCode.
+// Check the length with:
+//
+//	len(mockedUsecases.RedeemPromoCodeCalls())
+func (mock *UsecasesMock) RedeemPromoCodeCalls() []struct {
+	Ctx context.Context
+	Req model.RedeemRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.RedeemRequest
+	}
+	mock.lockRedeemPromoCode.RLock()
+	calls = mock.calls.RedeemPromoCode
+	mock.lockRedeemPromoCode.RUnlock()
+	return calls
+}
+
+// RedeemReferral calls RedeemReferralFunc.
+func (mock *UsecasesMock) RedeemReferral(ctx context.Context, req model.RedeemRequest) error {
+	if mock.RedeemReferralFunc == nil {
+		panic("UsecasesMock.RedeemReferralFunc: method is nil but Usecases.RedeemReferral was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.RedeemRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockRedeemReferral.Lock()
+	mock.calls.RedeemReferral = append(mock.calls.RedeemReferral, callInfo)
+	mock.lockRedeemReferral.Unlock()
+	return mock.RedeemReferralFunc(ctx, req)
+}
+
+// RedeemReferralCalls gets all the calls that were made to RedeemReferral.
+// Check the length with:
+//
+//	len(mockedUsecases.RedeemReferralCalls())
+func (mock *UsecasesMock) RedeemReferralCalls() []struct {
+	Ctx context.Context
+	Req model.RedeemRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.RedeemRequest
+	}
+	mock.lockRedeemReferral.RLock()
+	calls = mock.calls.RedeemReferral
+	mock.lockRedeemReferral.RUnlock()
+	return calls
+}
+
+// RenewSubscriptions calls RenewSubscriptionsFunc.
+func (mock *UsecasesMock) RenewSubscriptions(ctx context.Context, now time.Time) error {
+	if mock.RenewSubscriptionsFunc == nil {
+		panic("UsecasesMock.RenewSubscriptionsFunc: method is nil but Usecases.RenewSubscriptions was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Now time.Time
+	}{
+		Ctx: ctx,
+		Now: now,
+	}
+	mock.lockRenewSubscriptions.Lock()
+	mock.calls.RenewSubscriptions = append(mock.calls.RenewSubscriptions, callInfo)
+	mock.lockRenewSubscriptions.Unlock()
+	return mock.RenewSubscriptionsFunc(ctx, now)
+}
+
+// RenewSubscriptionsCalls gets all the calls that were made to RenewSubscriptions.
+// Check the length with:
+//
+//	len(mockedUsecases.RenewSubscriptionsCalls())
+func (mock *UsecasesMock) RenewSubscriptionsCalls() []struct {
+	Ctx context.Context
+	Now time.Time
+} {
+	var calls []struct {
+		Ctx context.Context
+		Now time.Time
+	}
+	mock.lockRenewSubscriptions.RLock()
+	calls = mock.calls.RenewSubscriptions
+	mock.lockRenewSubscriptions.RUnlock()
+	return calls
+}
+
+// ReorderPhotos calls ReorderPhotosFunc.
+func (mock *UsecasesMock) ReorderPhotos(ctx context.Context, req model.ReorderPhotosRequest) ([]model.Photo, error) {
+	if mock.ReorderPhotosFunc == nil {
+		panic("UsecasesMock.ReorderPhotosFunc: method is nil but Usecases.ReorderPhotos was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.ReorderPhotosRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockReorderPhotos.Lock()
+	mock.calls.ReorderPhotos = append(mock.calls.ReorderPhotos, callInfo)
+	mock.lockReorderPhotos.Unlock()
+	return mock.ReorderPhotosFunc(ctx, req)
+}
+
+// ReorderPhotosCalls gets all the calls that were made to ReorderPhotos.
+// Check the length with:
+//
+//	len(mockedUsecases.ReorderPhotosCalls())
+func (mock *UsecasesMock) ReorderPhotosCalls() []struct {
+	Ctx context.Context
+	Req model.ReorderPhotosRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.ReorderPhotosRequest
+	}
+	mock.lockReorderPhotos.RLock()
+	calls = mock.calls.ReorderPhotos
+	mock.lockReorderPhotos.RUnlock()
+	return calls
+}
+
+// ReportUser calls ReportUserFunc.
+func (mock *UsecasesMock) ReportUser(ctx context.Context, req model.ReportRequest) (model.Report, error) {
+	if mock.ReportUserFunc == nil {
+		panic("UsecasesMock.ReportUserFunc: method is nil but Usecases.ReportUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.ReportRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockReportUser.Lock()
+	mock.calls.ReportUser = append(mock.calls.ReportUser, callInfo)
+	mock.lockReportUser.Unlock()
+	return mock.ReportUserFunc(ctx, req)
+}
+
+// ReportUserCalls gets all the calls that were made to ReportUser.
+// Check the length with:
+//
+//	len(mockedUsecases.ReportUserCalls())
+func (mock *UsecasesMock) ReportUserCalls() []struct {
+	Ctx context.Context
+	Req model.ReportRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.ReportRequest
+	}
+	mock.lockReportUser.RLock()
+	calls = mock.calls.ReportUser
+	mock.lockReportUser.RUnlock()
+	return calls
+}
+
+// ResolveReport calls ResolveReportFunc.
+func (mock *UsecasesMock) ResolveReport(ctx context.Context, req model.ReviewReportRequest) error {
+	if mock.ResolveReportFunc == nil {
+		panic("UsecasesMock.ResolveReportFunc: method is nil but Usecases.ResolveReport was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.ReviewReportRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockResolveReport.Lock()
+	mock.calls.ResolveReport = append(mock.calls.ResolveReport, callInfo)
+	mock.lockResolveReport.Unlock()
+	return mock.ResolveReportFunc(ctx, req)
+}
+
+// ResolveReportCalls gets all the calls that were made to ResolveReport.
+// Check the length with:
+//
+//	len(mockedUsecases.ResolveReportCalls())
+func (mock *UsecasesMock) ResolveReportCalls() []struct {
+	Ctx context.Context
+	Req model.ReviewReportRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.ReviewReportRequest
+	}
+	mock.lockResolveReport.RLock()
+	calls = mock.calls.ResolveReport
+	mock.lockResolveReport.RUnlock()
+	return calls
+}
+
+// SearchUsers calls SearchUsersFunc.
+func (mock *UsecasesMock) SearchUsers(ctx context.Context, req model.SearchUsersRequest) ([]model.User, error) {
+	if mock.SearchUsersFunc == nil {
+		panic("UsecasesMock.SearchUsersFunc: method is nil but Usecases.SearchUsers was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.SearchUsersRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockSearchUsers.Lock()
+	mock.calls.SearchUsers = append(mock.calls.SearchUsers, callInfo)
+	mock.lockSearchUsers.Unlock()
+	return mock.SearchUsersFunc(ctx, req)
+}
+
+// SearchUsersCalls gets all the calls that were made to SearchUsers.
+// Check the length with:
+//
+//	len(mockedUsecases.SearchUsersCalls())
+func (mock *UsecasesMock) SearchUsersCalls() []struct {
+	Ctx context.Context
+	Req model.SearchUsersRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.SearchUsersRequest
+	}
+	mock.lockSearchUsers.RLock()
+	calls = mock.calls.SearchUsers
+	mock.lockSearchUsers.RUnlock()
+	return calls
+}
+
+// SendDunningNotices calls SendDunningNoticesFunc.
+func (mock *UsecasesMock) SendDunningNotices(ctx context.Context, now time.Time) error {
+	if mock.SendDunningNoticesFunc == nil {
+		panic("UsecasesMock.SendDunningNoticesFunc: method is nil but Usecases.SendDunningNotices was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Now time.Time
+	}{
+		Ctx: ctx,
+		Now: now,
+	}
+	mock.lockSendDunningNotices.Lock()
+	mock.calls.SendDunningNotices = append(mock.calls.SendDunningNotices, callInfo)
+	mock.lockSendDunningNotices.Unlock()
+	return mock.SendDunningNoticesFunc(ctx, now)
+}
+
+// SendDunningNoticesCalls gets all the calls that were made to SendDunningNotices.
+// Check the length with:
+//
+//	len(mockedUsecases.SendDunningNoticesCalls())
+func (mock *UsecasesMock) SendDunningNoticesCalls() []struct {
+	Ctx context.Context
+	Now time.Time
+} {
+	var calls []struct {
+		Ctx context.Context
+		Now time.Time
+	}
+	mock.lockSendDunningNotices.RLock()
+	calls = mock.calls.SendDunningNotices
+	mock.lockSendDunningNotices.RUnlock()
+	return calls
+}
+
+// SendMessage calls SendMessageFunc.
+func (mock *UsecasesMock) SendMessage(ctx context.Context, req model.SendMessageRequest) (model.Message, error) {
+	if mock.SendMessageFunc == nil {
+		panic("UsecasesMock.SendMessageFunc: method is nil but Usecases.SendMessage was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.SendMessageRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockSendMessage.Lock()
+	mock.calls.SendMessage = append(mock.calls.SendMessage, callInfo)
+	mock.lockSendMessage.Unlock()
+	return mock.SendMessageFunc(ctx, req)
+}
+
+// SendMessageCalls gets all the calls that were made to SendMessage.
+// Check the length with:
+//
+//	len(mockedUsecases.SendMessageCalls())
+func (mock *UsecasesMock) SendMessageCalls() []struct {
+	Ctx context.Context
+	Req model.SendMessageRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.SendMessageRequest
+	}
+	mock.lockSendMessage.RLock()
+	calls = mock.calls.SendMessage
+	mock.lockSendMessage.RUnlock()
+	return calls
+}
+
+// SetIncognito calls SetIncognitoFunc.
+func (mock *UsecasesMock) SetIncognito(ctx context.Context, req model.IncognitoRequest) error {
+	if mock.SetIncognitoFunc == nil {
+		panic("UsecasesMock.SetIncognitoFunc: method is nil but Usecases.SetIncognito was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.IncognitoRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockSetIncognito.Lock()
+	mock.calls.SetIncognito = append(mock.calls.SetIncognito, callInfo)
+	mock.lockSetIncognito.Unlock()
+	return mock.SetIncognitoFunc(ctx, req)
+}
+
+// SetIncognitoCalls gets all the calls that were made to SetIncognito.
+// Check the length with:
+//
+//	len(mockedUsecases.SetIncognitoCalls())
+func (mock *UsecasesMock) SetIncognitoCalls() []struct {
+	Ctx context.Context
+	Req model.IncognitoRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.IncognitoRequest
+	}
+	mock.lockSetIncognito.RLock()
+	calls = mock.calls.SetIncognito
+	mock.lockSetIncognito.RUnlock()
+	return calls
+}
+
+// SetPremium calls SetPremiumFunc.
+func (mock *UsecasesMock) SetPremium(ctx context.Context, req model.AdminPremiumRequest) error {
+	if mock.SetPremiumFunc == nil {
+		panic("UsecasesMock.SetPremiumFunc: method is nil but Usecases.SetPremium was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.AdminPremiumRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockSetPremium.Lock()
+	mock.calls.SetPremium = append(mock.calls.SetPremium, callInfo)
+	mock.lockSetPremium.Unlock()
+	return mock.SetPremiumFunc(ctx, req)
+}
+
+// SetPremiumCalls gets all the calls that were made to SetPremium.
+// Check the length with:
+//
+//	len(mockedUsecases.SetPremiumCalls())
+func (mock *UsecasesMock) SetPremiumCalls() []struct {
+	Ctx context.Context
+	Req model.AdminPremiumRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.AdminPremiumRequest
+	}
+	mock.lockSetPremium.RLock()
+	calls = mock.calls.SetPremium
+	mock.lockSetPremium.RUnlock()
+	return calls
+}
+
+// SetPrimaryPhoto calls SetPrimaryPhotoFunc.
+func (mock *UsecasesMock) SetPrimaryPhoto(ctx context.Context, req model.PhotoRequest) error {
+	if mock.SetPrimaryPhotoFunc == nil {
+		panic("UsecasesMock.SetPrimaryPhotoFunc: method is nil but Usecases.SetPrimaryPhoto was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.PhotoRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockSetPrimaryPhoto.Lock()
+	mock.calls.SetPrimaryPhoto = append(mock.calls.SetPrimaryPhoto, callInfo)
+	mock.lockSetPrimaryPhoto.Unlock()
+	return mock.SetPrimaryPhotoFunc(ctx, req)
+}
+
+// SetPrimaryPhotoCalls gets all the calls that were made to SetPrimaryPhoto.
+// Check the length with:
+//
+//	len(mockedUsecases.SetPrimaryPhotoCalls())
+func (mock *UsecasesMock) SetPrimaryPhotoCalls() []struct {
+	Ctx context.Context
+	Req model.PhotoRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.PhotoRequest
+	}
+	mock.lockSetPrimaryPhoto.RLock()
+	calls = mock.calls.SetPrimaryPhoto
+	mock.lockSetPrimaryPhoto.RUnlock()
+	return calls
+}
+
+// SetRole calls SetRoleFunc.
+func (mock *UsecasesMock) SetRole(ctx context.Context, req model.AdminRoleRequest) error {
+	if mock.SetRoleFunc == nil {
+		panic("UsecasesMock.SetRoleFunc: method is nil but Usecases.SetRole was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.AdminRoleRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockSetRole.Lock()
+	mock.calls.SetRole = append(mock.calls.SetRole, callInfo)
+	mock.lockSetRole.Unlock()
+	return mock.SetRoleFunc(ctx, req)
+}
+
+// SetRoleCalls gets all the calls that were made to SetRole.
+// Check the length with:
+//
+//	len(mockedUsecases.SetRoleCalls())
+func (mock *UsecasesMock) SetRoleCalls() []struct {
+	Ctx context.Context
+	Req model.AdminRoleRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.AdminRoleRequest
+	}
+	mock.lockSetRole.RLock()
+	calls = mock.calls.SetRole
+	mock.lockSetRole.RUnlock()
+	return calls
+}
+
+// ShadowbanUser calls ShadowbanUserFunc.
+func (mock *UsecasesMock) ShadowbanUser(ctx context.Context, req model.AdminUserRequest) error {
+	if mock.ShadowbanUserFunc == nil {
+		panic("UsecasesMock.ShadowbanUserFunc: method is nil but Usecases.ShadowbanUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.AdminUserRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockShadowbanUser.Lock()
+	mock.calls.ShadowbanUser = append(mock.calls.ShadowbanUser, callInfo)
+	mock.lockShadowbanUser.Unlock()
+	return mock.ShadowbanUserFunc(ctx, req)
+}
 
-	// calls tracks calls to the methods.
-	calls struct {
-		// CreateUser holds details about calls to the CreateUser method.
-		CreateUser []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// Req is the req argument value.
-			Req model.User
-		}
-		// GetProfiles holds details about calls to the GetProfiles method.
-		GetProfiles []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// Req is the req argument value.
-			Req model.GetRelatedUserRequest
-		}
-		// Login holds details about calls to the Login method.
-		Login []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// Req is the req argument value.
-			Req model.LoginRequest
-		}
-		// Swipe holds details about calls to the Swipe method.
-		Swipe []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// Req is the req argument value.
-			Req model.SwipeRequest
-		}
-		// UpdateSubscription holds details about calls to the UpdateSubscription method.
-		UpdateSubscription []struct {
-			// Ctx is the ctx argument value.
-			Ctx context.Context
-			// Req is the req argument value.
-			Req model.SubscribeRequest
-		}
+// ShadowbanUserCalls gets all the calls that were made to ShadowbanUser.
+// Check the length with:
+//
+//	len(mockedUsecases.ShadowbanUserCalls())
+func (mock *UsecasesMock) ShadowbanUserCalls() []struct {
+	Ctx context.Context
+	Req model.AdminUserRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.AdminUserRequest
 	}
-	lockCreateUser         sync.RWMutex
-	lockGetProfiles        sync.RWMutex
-	lockLogin              sync.RWMutex
-	lockSwipe              sync.RWMutex
-	lockUpdateSubscription sync.RWMutex
+	mock.lockShadowbanUser.RLock()
+	calls = mock.calls.ShadowbanUser
+	mock.lockShadowbanUser.RUnlock()
+	return calls
 }
 
-// CreateUser calls CreateUserFunc.
-func (mock *UsecasesMock) CreateUser(ctx context.Context, req model.User) error {
-	if mock.CreateUserFunc == nil {
-		panic("UsecasesMock.CreateUserFunc: method is nil but Usecases.CreateUser was just called")
+// StartConversation calls StartConversationFunc.
+func (mock *UsecasesMock) StartConversation(ctx context.Context, req model.StartConversationRequest) (model.Conversation, error) {
+	if mock.StartConversationFunc == nil {
+		panic("UsecasesMock.StartConversationFunc: method is nil but Usecases.StartConversation was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Req model.User
+		Req model.StartConversationRequest
 	}{
 		Ctx: ctx,
 		Req: req,
 	}
-	mock.lockCreateUser.Lock()
-	mock.calls.CreateUser = append(mock.calls.CreateUser, callInfo)
-	mock.lockCreateUser.Unlock()
-	return mock.CreateUserFunc(ctx, req)
+	mock.lockStartConversation.Lock()
+	mock.calls.StartConversation = append(mock.calls.StartConversation, callInfo)
+	mock.lockStartConversation.Unlock()
+	return mock.StartConversationFunc(ctx, req)
 }
 
-// CreateUserCalls gets all the calls that were made to CreateUser.
+// StartConversationCalls gets all the calls that were made to StartConversation.
 // Check the length with:
 //
-//	len(mockedUsecases.CreateUserCalls())
-func (mock *UsecasesMock) CreateUserCalls() []struct {
+//	len(mockedUsecases.StartConversationCalls())
+func (mock *UsecasesMock) StartConversationCalls() []struct {
 	Ctx context.Context
-	Req model.User
+	Req model.StartConversationRequest
 } {
 	var calls []struct {
 		Ctx context.Context
-		Req model.User
+		Req model.StartConversationRequest
 	}
-	mock.lockCreateUser.RLock()
-	calls = mock.calls.CreateUser
-	mock.lockCreateUser.RUnlock()
+	mock.lockStartConversation.RLock()
+	calls = mock.calls.StartConversation
+	mock.lockStartConversation.RUnlock()
 	return calls
 }
 
-// GetProfiles calls GetProfilesFunc.
-func (mock *UsecasesMock) GetProfiles(ctx context.Context, req model.GetRelatedUserRequest) ([]model.User, error) {
-	if mock.GetProfilesFunc == nil {
-		panic("UsecasesMock.GetProfilesFunc: method is nil but Usecases.GetProfiles was just called")
+// SubscribeEvents calls SubscribeEventsFunc.
+func (mock *UsecasesMock) SubscribeEvents(ctx context.Context, req model.SubscribeEventsRequest) ([]model.Event, <-chan model.Event, func(), error) {
+	if mock.SubscribeEventsFunc == nil {
+		panic("UsecasesMock.SubscribeEventsFunc: method is nil but Usecases.SubscribeEvents was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Req model.GetRelatedUserRequest
+		Req model.SubscribeEventsRequest
 	}{
 		Ctx: ctx,
 		Req: req,
 	}
-	mock.lockGetProfiles.Lock()
-	mock.calls.GetProfiles = append(mock.calls.GetProfiles, callInfo)
-	mock.lockGetProfiles.Unlock()
-	return mock.GetProfilesFunc(ctx, req)
+	mock.lockSubscribeEvents.Lock()
+	mock.calls.SubscribeEvents = append(mock.calls.SubscribeEvents, callInfo)
+	mock.lockSubscribeEvents.Unlock()
+	return mock.SubscribeEventsFunc(ctx, req)
 }
 
-// GetProfilesCalls gets all the calls that were made to GetProfiles.
+// SubscribeEventsCalls gets all the calls that were made to SubscribeEvents.
 // Check the length with:
 //
-//	len(mockedUsecases.GetProfilesCalls())
-func (mock *UsecasesMock) GetProfilesCalls() []struct {
+//	len(mockedUsecases.SubscribeEventsCalls())
+func (mock *UsecasesMock) SubscribeEventsCalls() []struct {
 	Ctx context.Context
-	Req model.GetRelatedUserRequest
+	Req model.SubscribeEventsRequest
 } {
 	var calls []struct {
 		Ctx context.Context
-		Req model.GetRelatedUserRequest
+		Req model.SubscribeEventsRequest
 	}
-	mock.lockGetProfiles.RLock()
-	calls = mock.calls.GetProfiles
-	mock.lockGetProfiles.RUnlock()
+	mock.lockSubscribeEvents.RLock()
+	calls = mock.calls.SubscribeEvents
+	mock.lockSubscribeEvents.RUnlock()
 	return calls
 }
 
-// Login calls LoginFunc.
-func (mock *UsecasesMock) Login(ctx context.Context, req model.LoginRequest) error {
-	if mock.LoginFunc == nil {
-		panic("UsecasesMock.LoginFunc: method is nil but Usecases.Login was just called")
+// SuspendUser calls SuspendUserFunc.
+func (mock *UsecasesMock) SuspendUser(ctx context.Context, req model.AdminUserRequest) error {
+	if mock.SuspendUserFunc == nil {
+		panic("UsecasesMock.SuspendUserFunc: method is nil but Usecases.SuspendUser was just called")
 	}
 	callInfo := struct {
 		Ctx context.Context
-		Req model.LoginRequest
+		Req model.AdminUserRequest
 	}{
 		Ctx: ctx,
 		Req: req,
 	}
-	mock.lockLogin.Lock()
-	mock.calls.Login = append(mock.calls.Login, callInfo)
-	mock.lockLogin.Unlock()
-	return mock.LoginFunc(ctx, req)
+	mock.lockSuspendUser.Lock()
+	mock.calls.SuspendUser = append(mock.calls.SuspendUser, callInfo)
+	mock.lockSuspendUser.Unlock()
+	return mock.SuspendUserFunc(ctx, req)
 }
 
-// LoginCalls gets all the calls that were made to Login.
+// SuspendUserCalls gets all the calls that were made to SuspendUser.
 // Check the length with:
 //
-//	len(mockedUsecases.LoginCalls())
-func (mock *UsecasesMock) LoginCalls() []struct {
+//	len(mockedUsecases.SuspendUserCalls())
+func (mock *UsecasesMock) SuspendUserCalls() []struct {
 	Ctx context.Context
-	Req model.LoginRequest
+	Req model.AdminUserRequest
 } {
 	var calls []struct {
 		Ctx context.Context
-		Req model.LoginRequest
+		Req model.AdminUserRequest
 	}
-	mock.lockLogin.RLock()
-	calls = mock.calls.Login
-	mock.lockLogin.RUnlock()
+	mock.lockSuspendUser.RLock()
+	calls = mock.calls.SuspendUser
+	mock.lockSuspendUser.RUnlock()
 	return calls
 }
 
@@ -245,8 +3270,116 @@ func (mock *UsecasesMock) SwipeCalls() []struct {
 	return calls
 }
 
+// UnbanUser calls UnbanUserFunc.
+func (mock *UsecasesMock) UnbanUser(ctx context.Context, req model.AdminUserRequest) error {
+	if mock.UnbanUserFunc == nil {
+		panic("UsecasesMock.UnbanUserFunc: method is nil but Usecases.UnbanUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.AdminUserRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockUnbanUser.Lock()
+	mock.calls.UnbanUser = append(mock.calls.UnbanUser, callInfo)
+	mock.lockUnbanUser.Unlock()
+	return mock.UnbanUserFunc(ctx, req)
+}
+
+// UnbanUserCalls gets all the calls that were made to UnbanUser.
+// Check the length with:
+//
+//	len(mockedUsecases.UnbanUserCalls())
+func (mock *UsecasesMock) UnbanUserCalls() []struct {
+	Ctx context.Context
+	Req model.AdminUserRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.AdminUserRequest
+	}
+	mock.lockUnbanUser.RLock()
+	calls = mock.calls.UnbanUser
+	mock.lockUnbanUser.RUnlock()
+	return calls
+}
+
+// UnblockUser calls UnblockUserFunc.
+func (mock *UsecasesMock) UnblockUser(ctx context.Context, req model.BlockRequest) error {
+	if mock.UnblockUserFunc == nil {
+		panic("UsecasesMock.UnblockUserFunc: method is nil but Usecases.UnblockUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.BlockRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockUnblockUser.Lock()
+	mock.calls.UnblockUser = append(mock.calls.UnblockUser, callInfo)
+	mock.lockUnblockUser.Unlock()
+	return mock.UnblockUserFunc(ctx, req)
+}
+
+// UnblockUserCalls gets all the calls that were made to UnblockUser.
+// Check the length with:
+//
+//	len(mockedUsecases.UnblockUserCalls())
+func (mock *UsecasesMock) UnblockUserCalls() []struct {
+	Ctx context.Context
+	Req model.BlockRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.BlockRequest
+	}
+	mock.lockUnblockUser.RLock()
+	calls = mock.calls.UnblockUser
+	mock.lockUnblockUser.RUnlock()
+	return calls
+}
+
+// UndoSwipe calls UndoSwipeFunc.
+func (mock *UsecasesMock) UndoSwipe(ctx context.Context, req model.UndoSwipeRequest) (model.Swipe, error) {
+	if mock.UndoSwipeFunc == nil {
+		panic("UsecasesMock.UndoSwipeFunc: method is nil but Usecases.UndoSwipe was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.UndoSwipeRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockUndoSwipe.Lock()
+	mock.calls.UndoSwipe = append(mock.calls.UndoSwipe, callInfo)
+	mock.lockUndoSwipe.Unlock()
+	return mock.UndoSwipeFunc(ctx, req)
+}
+
+// UndoSwipeCalls gets all the calls that were made to UndoSwipe.
+// Check the length with:
+//
+//	len(mockedUsecases.UndoSwipeCalls())
+func (mock *UsecasesMock) UndoSwipeCalls() []struct {
+	Ctx context.Context
+	Req model.UndoSwipeRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.UndoSwipeRequest
+	}
+	mock.lockUndoSwipe.RLock()
+	calls = mock.calls.UndoSwipe
+	mock.lockUndoSwipe.RUnlock()
+	return calls
+}
+
 // UpdateSubscription calls UpdateSubscriptionFunc.
-func (mock *UsecasesMock) UpdateSubscription(ctx context.Context, req model.SubscribeRequest) error {
+func (mock *UsecasesMock) UpdateSubscription(ctx context.Context, req model.SubscribeRequest) (model.SubscribeResponse, error) {
 	if mock.UpdateSubscriptionFunc == nil {
 		panic("UsecasesMock.UpdateSubscriptionFunc: method is nil but Usecases.UpdateSubscription was just called")
 	}
@@ -280,3 +3413,183 @@ func (mock *UsecasesMock) UpdateSubscriptionCalls() []struct {
 	mock.lockUpdateSubscription.RUnlock()
 	return calls
 }
+
+// UploadPhoto calls UploadPhotoFunc.
+func (mock *UsecasesMock) UploadPhoto(ctx context.Context, req model.UploadPhotoRequest) (model.Photo, error) {
+	if mock.UploadPhotoFunc == nil {
+		panic("UsecasesMock.UploadPhotoFunc: method is nil but Usecases.UploadPhoto was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.UploadPhotoRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockUploadPhoto.Lock()
+	mock.calls.UploadPhoto = append(mock.calls.UploadPhoto, callInfo)
+	mock.lockUploadPhoto.Unlock()
+	return mock.UploadPhotoFunc(ctx, req)
+}
+
+// UploadPhotoCalls gets all the calls that were made to UploadPhoto.
+// Check the length with:
+//
+//	len(mockedUsecases.UploadPhotoCalls())
+func (mock *UsecasesMock) UploadPhotoCalls() []struct {
+	Ctx context.Context
+	Req model.UploadPhotoRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.UploadPhotoRequest
+	}
+	mock.lockUploadPhoto.RLock()
+	calls = mock.calls.UploadPhoto
+	mock.lockUploadPhoto.RUnlock()
+	return calls
+}
+
+// VerifyEmail calls VerifyEmailFunc.
+func (mock *UsecasesMock) VerifyEmail(ctx context.Context, req model.VerifyEmailRequest) error {
+	if mock.VerifyEmailFunc == nil {
+		panic("UsecasesMock.VerifyEmailFunc: method is nil but Usecases.VerifyEmail was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.VerifyEmailRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockVerifyEmail.Lock()
+	mock.calls.VerifyEmail = append(mock.calls.VerifyEmail, callInfo)
+	mock.lockVerifyEmail.Unlock()
+	return mock.VerifyEmailFunc(ctx, req)
+}
+
+// VerifyEmailCalls gets all the calls that were made to VerifyEmail.
+// Check the length with:
+//
+//	len(mockedUsecases.VerifyEmailCalls())
+func (mock *UsecasesMock) VerifyEmailCalls() []struct {
+	Ctx context.Context
+	Req model.VerifyEmailRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.VerifyEmailRequest
+	}
+	mock.lockVerifyEmail.RLock()
+	calls = mock.calls.VerifyEmail
+	mock.lockVerifyEmail.RUnlock()
+	return calls
+}
+
+// VerifyReceipt calls VerifyReceiptFunc.
+func (mock *UsecasesMock) VerifyReceipt(ctx context.Context, req model.ReceiptRequest) (model.Subscription, error) {
+	if mock.VerifyReceiptFunc == nil {
+		panic("UsecasesMock.VerifyReceiptFunc: method is nil but Usecases.VerifyReceipt was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.ReceiptRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockVerifyReceipt.Lock()
+	mock.calls.VerifyReceipt = append(mock.calls.VerifyReceipt, callInfo)
+	mock.lockVerifyReceipt.Unlock()
+	return mock.VerifyReceiptFunc(ctx, req)
+}
+
+// VerifyReceiptCalls gets all the calls that were made to VerifyReceipt.
+// Check the length with:
+//
+//	len(mockedUsecases.VerifyReceiptCalls())
+func (mock *UsecasesMock) VerifyReceiptCalls() []struct {
+	Ctx context.Context
+	Req model.ReceiptRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.ReceiptRequest
+	}
+	mock.lockVerifyReceipt.RLock()
+	calls = mock.calls.VerifyReceipt
+	mock.lockVerifyReceipt.RUnlock()
+	return calls
+}
+
+// VerifyTwoFactor calls VerifyTwoFactorFunc.
+func (mock *UsecasesMock) VerifyTwoFactor(ctx context.Context, req model.TwoFactorVerifyRequest) (model.TwoFactorVerifyResponse, error) {
+	if mock.VerifyTwoFactorFunc == nil {
+		panic("UsecasesMock.VerifyTwoFactorFunc: method is nil but Usecases.VerifyTwoFactor was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.TwoFactorVerifyRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockVerifyTwoFactor.Lock()
+	mock.calls.VerifyTwoFactor = append(mock.calls.VerifyTwoFactor, callInfo)
+	mock.lockVerifyTwoFactor.Unlock()
+	return mock.VerifyTwoFactorFunc(ctx, req)
+}
+
+// VerifyTwoFactorCalls gets all the calls that were made to VerifyTwoFactor.
+// Check the length with:
+//
+//	len(mockedUsecases.VerifyTwoFactorCalls())
+func (mock *UsecasesMock) VerifyTwoFactorCalls() []struct {
+	Ctx context.Context
+	Req model.TwoFactorVerifyRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.TwoFactorVerifyRequest
+	}
+	mock.lockVerifyTwoFactor.RLock()
+	calls = mock.calls.VerifyTwoFactor
+	mock.lockVerifyTwoFactor.RUnlock()
+	return calls
+}
+
+// ViewProfile calls ViewProfileFunc.
+func (mock *UsecasesMock) ViewProfile(ctx context.Context, req model.ViewProfileRequest) (model.Profile, error) {
+	if mock.ViewProfileFunc == nil {
+		panic("UsecasesMock.ViewProfileFunc: method is nil but Usecases.ViewProfile was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		Req model.ViewProfileRequest
+	}{
+		Ctx: ctx,
+		Req: req,
+	}
+	mock.lockViewProfile.Lock()
+	mock.calls.ViewProfile = append(mock.calls.ViewProfile, callInfo)
+	mock.lockViewProfile.Unlock()
+	return mock.ViewProfileFunc(ctx, req)
+}
+
+// ViewProfileCalls gets all the calls that were made to ViewProfile.
+// Check the length with:
+//
+//	len(mockedUsecases.ViewProfileCalls())
+func (mock *UsecasesMock) ViewProfileCalls() []struct {
+	Ctx context.Context
+	Req model.ViewProfileRequest
+} {
+	var calls []struct {
+		Ctx context.Context
+		Req model.ViewProfileRequest
+	}
+	mock.lockViewProfile.RLock()
+	calls = mock.calls.ViewProfile
+	mock.lockViewProfile.RUnlock()
+	return calls
+}